@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+// Imports and register the 'raft2' topo.Server. A vtctld can also be a
+// member of the raft group which serves it.
+
+import (
+	"flag"
+	"strings"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo/raft2topo"
+)
+
+var (
+	raft2MemberRaftAddr = flag.String("raft2topo_member_raft_addr", "", "If set, this vtctld is a member of the raft group of a raft2 topo, and this is the host:port of its raft transport.")
+	raft2MemberAPIAddr  = flag.String("raft2topo_member_api_addr", "", "The address the API of the raft2 topo member listens on. The server address of the raft2 topo is the comma-separated list of the API addresses of the members.")
+	raft2MemberPeers    = flag.String("raft2topo_member_peers", "", "Comma-separated list of the raft addresses of the members of the raft2 topo group. A member without other peers elects itself.")
+	raft2MemberDataDir  = flag.String("raft2topo_member_data_dir", "", "The directory of the raft log and snapshots of the raft2 topo member.")
+	raft2MemberCert     = flag.String("raft2topo_member_tls_cert", "", "path to the cert of the raft2 topo member, presented to the other members and to the clients")
+	raft2MemberKey      = flag.String("raft2topo_member_tls_key", "", "path to the key of the raft2 topo member")
+	raft2MemberCA       = flag.String("raft2topo_member_tls_ca", "", "path to the ca to use to validate the certs of the other members and of the clients of the raft2 topo member, which must all present one")
+)
+
+func init() {
+	// The member must serve before the topo is opened.
+	servenv.OnInit(func() {
+		if *raft2MemberRaftAddr == "" {
+			return
+		}
+		if *raft2MemberAPIAddr == "" || *raft2MemberDataDir == "" {
+			log.Exitf("raft2topo_member_api_addr and raft2topo_member_data_dir are required for a raft2 topo member")
+		}
+		if *raft2MemberCert == "" || *raft2MemberKey == "" || *raft2MemberCA == "" {
+			log.Exitf("raft2topo_member_tls_cert, raft2topo_member_tls_key and raft2topo_member_tls_ca are required for a raft2 topo member")
+		}
+		var peers []string
+		if *raft2MemberPeers != "" {
+			peers = strings.Split(*raft2MemberPeers, ",")
+		}
+		member, err := raft2topo.NewMember(&raft2topo.MemberConfig{
+			DataDir:  *raft2MemberDataDir,
+			RaftAddr: *raft2MemberRaftAddr,
+			Peers:    peers,
+			APIAddr:  *raft2MemberAPIAddr,
+			CertPath: *raft2MemberCert,
+			KeyPath:  *raft2MemberKey,
+			CAPath:   *raft2MemberCA,
+		})
+		if err != nil {
+			log.Exitf("cannot start the raft2 topo member: %v", err)
+		}
+		servenv.OnClose(member.Close)
+	})
+}
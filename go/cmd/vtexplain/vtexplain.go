@@ -20,6 +20,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"os"
 
 	"vitess.io/vitess/go/exit"
 	"vitess.io/vitess/go/vt/log"
@@ -43,6 +44,10 @@ var (
 	normalize          = flag.Bool("normalize", false, "Whether to enable vtgate normalization")
 	outputMode         = flag.String("output-mode", "text", "Output in human-friendly text or json")
 	dbName             = flag.String("dbname", "", "Optional database target to override normal routing")
+	queryLogFlag       = flag.String("query-log", "", "Identifies the query log whose workload to analyze instead of -sql, in the format of -query-log-format")
+	queryLogFormat     = flag.String("query-log-format", "text", "The format of -query-log -- must be set to text or json for a vtgate query log, or general or slow for a MySQL query log")
+	cmpVSchemaFlag     = flag.String("compare-vschema", "", "A VTGate routing schema to compare to -vschema for the workload of -query-log")
+	cmpVSchemaFileFlag = flag.String("compare-vschema-file", "", "Identifies the VTGate routing schema file to compare to -vschema for the workload of -query-log")
 
 	// vtexplainFlags lists all the flags that should show in usage
 	vtexplainFlags = []string{
@@ -59,6 +64,10 @@ var (
 		"ks-shard-map",
 		"ks-shard-map-file",
 		"dbname",
+		"query-log",
+		"query-log-format",
+		"compare-vschema",
+		"compare-vschema-file",
 		"queryserver-config-passthrough-dmls",
 	}
 )
@@ -145,7 +154,7 @@ func main() {
 }
 
 func parseAndRun() error {
-	sql, err := getFileParam(*sqlFlag, *sqlFileFlag, "sql", true)
+	sql, err := getFileParam(*sqlFlag, *sqlFileFlag, "sql", *queryLogFlag == "")
 	if err != nil {
 		return err
 	}
@@ -173,6 +182,10 @@ func parseAndRun() error {
 		Target:          *dbName,
 	}
 
+	if *queryLogFlag != "" {
+		return analyzeQueryLog(vschema, schema, ksShardMap, opts)
+	}
+
 	log.V(100).Infof("sql %s\n", sql)
 	log.V(100).Infof("schema %s\n", schema)
 	log.V(100).Infof("vschema %s\n", vschema)
@@ -195,3 +208,44 @@ func parseAndRun() error {
 
 	return nil
 }
+
+func analyzeQueryLog(vschema, schema, ksShardMap string, opts *vtexplain.Options) error {
+	compareVSchema, err := getFileParam(*cmpVSchemaFlag, *cmpVSchemaFileFlag, "compare-vschema", false)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(*queryLogFlag)
+	if err != nil {
+		return fmt.Errorf("cannot read query log %v: %v", *queryLogFlag, err)
+	}
+	defer f.Close()
+	queries, err := vtexplain.ParseQueryLog(f, *queryLogFormat)
+	if err != nil {
+		return fmt.Errorf("cannot parse query log %v: %v", *queryLogFlag, err)
+	}
+
+	if compareVSchema != "" {
+		comparisons, err := vtexplain.CompareVSchemas(queries, vschema, compareVSchema, schema, ksShardMap, opts)
+		if err != nil {
+			return err
+		}
+		if *outputMode == "text" {
+			fmt.Print(vtexplain.ComparisonAsText(comparisons))
+		} else {
+			fmt.Print(vtexplain.ComparisonAsJSON(comparisons))
+		}
+		return nil
+	}
+
+	if err := vtexplain.Init(vschema, schema, ksShardMap, opts); err != nil {
+		return err
+	}
+	report := vtexplain.AnalyzeWorkload(queries)
+	if *outputMode == "text" {
+		fmt.Print(vtexplain.WorkloadAsText(report))
+	} else {
+		fmt.Print(vtexplain.WorkloadAsJSON(report))
+	}
+	return nil
+}
@@ -29,6 +29,8 @@ import (
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/tableacl"
+	"vitess.io/vitess/go/vt/tableacl/simpleacl"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtgate"
@@ -39,6 +41,9 @@ import (
 var (
 	cell              = flag.String("cell", "test_nj", "cell to use")
 	tabletTypesToWait = flag.String("tablet_types_to_wait", "", "wait till connected for specified tablet types during Gateway initialization")
+
+	tableACLConfig               = flag.String("table-acl-config", "", "path to the table access checker config file whose column rules are enforced by vtgate; send SIGHUP to reload this file")
+	tableACLConfigReloadInterval = flag.Duration("table-acl-config-reload-interval", 0, "Ticker to reload ACLs. Duration flag, format e.g.: 30s. Default: do not reload")
 )
 
 var resilientServer *srvtopo.ResilientServer
@@ -84,6 +89,12 @@ func main() {
 		vtg = vtgate.Init(context.Background(), resilientServer, *cell, tabletTypes)
 	}
 
+	if *tableACLConfig != "" {
+		// To override default simpleacl, other ACL plugins must set themselves to be default ACL factory
+		tableacl.Register("simpleacl", &simpleacl.Factory{})
+		vtg.InitTableACL(*tableACLConfig, *tableACLConfigReloadInterval)
+	}
+
 	servenv.OnRun(func() {
 		// Flags are parsed now. Parse the template using the actual flag value and overwrite the current template.
 		discovery.ParseTabletURLTemplateFromFlag()
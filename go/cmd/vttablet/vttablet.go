@@ -47,7 +47,10 @@ var (
 	tableACLConfig               = flag.String("table-acl-config", "", "path to table access checker config file; send SIGHUP to reload this file")
 	tableACLConfigReloadInterval = flag.Duration("table-acl-config-reload-interval", 0, "Ticker to reload ACLs. Duration flag, format e.g.: 30s. Default: do not reload")
 	tabletPath                   = flag.String("tablet-path", "", "tablet alias")
-	tabletConfig                 = flag.String("tablet_config", "", "YAML file config for tablet")
+	tabletConfig                 = flag.String("tablet_config", "", "YAML file config for tablet; send SIGHUP to reload this file")
+
+	tabletConfigTopoPath       = flag.String("tablet_config_topo_path", "", "path of a YAML tablet config in the global topo, e.g. vttablet/config.yaml. It overrides the settings of tablet_config, and is watched for changes.")
+	tabletConfigReloadInterval = flag.Duration("tablet_config_reload_interval", 0, "Ticker to reload the tablet_config file. Duration flag, format e.g.: 30s. Default: only reload on SIGHUP")
 
 	tm *tabletmanager.TabletManager
 )
@@ -72,10 +75,11 @@ func main() {
 	}
 
 	// config and mycnf intializations are intertwined.
-	config, mycnf := initConfig(tabletAlias)
+	config, baseConfig, mycnf := initConfig(tabletAlias)
 
 	ts := topo.Open()
 	qsc := createTabletServer(config, ts, tabletAlias)
+	qsc.InitConfigReload(baseConfig, *tabletConfig, *tabletConfigTopoPath, *tabletConfigReloadInterval)
 
 	mysqld := mysqlctl.NewMysqld(config.DB)
 	servenv.OnClose(mysqld.Close)
@@ -101,7 +105,7 @@ func main() {
 		DBConfigs:           config.DB.Clone(),
 		QueryServiceControl: qsc,
 		UpdateStream:        binlog.NewUpdateStream(ts, tablet.Keyspace, tabletAlias.Cell, qsc.SchemaEngine()),
-		VREngine:            vreplication.NewEngine(config, ts, tabletAlias.Cell, mysqld),
+		VREngine:            vreplication.NewEngine(config, ts, tabletAlias.Cell, mysqld, qsc.LagThrottler()),
 	}
 	if err := tm.Start(tablet, config.Healthcheck.IntervalSeconds.Get()); err != nil {
 		log.Exitf("failed to parse -tablet-path: %v", err)
@@ -118,13 +122,16 @@ func main() {
 	servenv.RunDefault()
 }
 
-func initConfig(tabletAlias *topodatapb.TabletAlias) (*tabletenv.TabletConfig, *mysqlctl.Mycnf) {
+// initConfig returns the tablet config, the part of it which comes from the
+// flags, and the mycnf.
+func initConfig(tabletAlias *topodatapb.TabletAlias) (*tabletenv.TabletConfig, *tabletenv.TabletConfig, *mysqlctl.Mycnf) {
 	tabletenv.Init()
 	// Load current config after tabletenv.Init, because it changes it.
 	config := tabletenv.NewCurrentConfig()
 	if err := config.Verify(); err != nil {
 		log.Exitf("invalid config: %v", err)
 	}
+	baseConfig := config.Clone()
 
 	if *tabletConfig != "" {
 		bytes, err := ioutil.ReadFile(*tabletConfig)
@@ -161,7 +168,7 @@ func initConfig(tabletAlias *topodatapb.TabletAlias) (*tabletenv.TabletConfig, *
 	for _, cfg := range config.ExternalConnections {
 		cfg.InitWithSocket("")
 	}
-	return config, mycnf
+	return config, baseConfig, mycnf
 }
 
 // extractOnlineDDL extracts the gh-ost binary from this executable. gh-ost is appended
@@ -81,6 +81,9 @@ type AuthServerStaticEntry struct {
 	UserData            string
 	SourceHost          string
 	Groups              []string
+	// Attributes are passed to vtgate with the username and groups. The
+	// row policies of the VSchema can use them.
+	Attributes map[string]string
 }
 
 // InitAuthServerStatic Handles initializing the AuthServerStatic if necessary.
@@ -241,13 +244,13 @@ func (a *AuthServerStatic) ValidateHash(salt []byte, user string, authResponse [
 		if entry.MysqlNativePassword != "" {
 			isPass := isPassScrambleMysqlNativePassword(authResponse, salt, entry.MysqlNativePassword)
 			if matchSourceHost(remoteAddr, entry.SourceHost) && isPass {
-				return &StaticUserData{entry.UserData, entry.Groups}, nil
+				return &StaticUserData{entry.UserData, entry.Groups, entry.Attributes}, nil
 			}
 		} else {
 			computedAuthResponse := ScramblePassword(salt, []byte(entry.Password))
 			// Validate the password.
 			if matchSourceHost(remoteAddr, entry.SourceHost) && bytes.Equal(authResponse, computedAuthResponse) {
-				return &StaticUserData{entry.UserData, entry.Groups}, nil
+				return &StaticUserData{entry.UserData, entry.Groups, entry.Attributes}, nil
 			}
 		}
 	}
@@ -274,7 +277,7 @@ func (a *AuthServerStatic) Negotiate(c *Conn, user string, remoteAddr net.Addr)
 	for _, entry := range entries {
 		// Validate the password.
 		if matchSourceHost(remoteAddr, entry.SourceHost) && entry.Password == password {
-			return &StaticUserData{entry.UserData, entry.Groups}, nil
+			return &StaticUserData{entry.UserData, entry.Groups, entry.Attributes}, nil
 		}
 	}
 	return &StaticUserData{}, NewSQLError(ERAccessDeniedError, SSAccessDeniedError, "Access denied for user '%v'", user)
@@ -294,13 +297,14 @@ func matchSourceHost(remoteAddr net.Addr, targetSourceHost string) bool {
 	return false
 }
 
-// StaticUserData holds the username and groups
+// StaticUserData holds the username, groups and attributes
 type StaticUserData struct {
-	username string
-	groups   []string
+	username   string
+	groups     []string
+	attributes map[string]string
 }
 
-// Get returns the wrapped username and groups
+// Get returns the wrapped username, groups and attributes
 func (sud *StaticUserData) Get() *querypb.VTGateCallerID {
-	return &querypb.VTGateCallerID{Username: sud.username, Groups: sud.groups}
+	return &querypb.VTGateCallerID{Username: sud.username, Groups: sud.groups, Attributes: sud.attributes}
 }
@@ -80,7 +80,7 @@ func TestJsonConfigParser(t *testing.T) {
 }
 
 func TestValidateHashGetter(t *testing.T) {
-	jsonConfig := `{"mysql_user": [{"Password": "password", "UserData": "user.name", "Groups": ["user_group"]}]}`
+	jsonConfig := `{"mysql_user": [{"Password": "password", "UserData": "user.name", "Groups": ["user_group"], "Attributes": {"tenant": "1"}}]}`
 
 	auth := NewAuthServerStatic("", jsonConfig, 0)
 	defer auth.close()
@@ -105,6 +105,9 @@ func TestValidateHashGetter(t *testing.T) {
 	if len(callerID.Groups) != 1 || callerID.Groups[0] != "user_group" {
 		t.Fatalf("getter groups incorrect, expected [\"user_group\"], got %v", callerID.Groups)
 	}
+	if callerID.Attributes["tenant"] != "1" {
+		t.Fatalf("getter attributes incorrect, expected {\"tenant\": \"1\"}, got %v", callerID.Attributes)
+	}
 }
 
 func TestHostMatcher(t *testing.T) {
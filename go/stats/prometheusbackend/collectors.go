@@ -394,3 +394,77 @@ func (c *histogramCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- metric
 	}
 }
+
+// queryDigestsCollector collects stats.QueryDigests. Each digest is
+// exported with its digest and query labels, which are bounded by the
+// capacity of the QueryDigests. The executions are the ones recorded
+// since the digest was added, so that they are a counter: the count a
+// digest inherits when it replaces another one is not exported.
+type queryDigestsCollector struct {
+	qd           *stats.QueryDigests
+	latency      *prometheus.Desc
+	executions   *prometheus.Desc
+	errors       *prometheus.Desc
+	rowsAffected *prometheus.Desc
+	rowsReturned *prometheus.Desc
+	shardQueries *prometheus.Desc
+}
+
+func newQueryDigestsCollector(qd *stats.QueryDigests, name string) {
+	labels := []string{"digest", "query"}
+	collector := &queryDigestsCollector{
+		qd:           qd,
+		latency:      prometheus.NewDesc(name+"_latency_seconds", qd.Help()+" (latency)", labels, nil),
+		executions:   prometheus.NewDesc(name+"_executions", qd.Help()+" (executions)", labels, nil),
+		errors:       prometheus.NewDesc(name+"_errors", qd.Help()+" (errors)", labels, nil),
+		rowsAffected: prometheus.NewDesc(name+"_rows_affected", qd.Help()+" (rows affected)", labels, nil),
+		rowsReturned: prometheus.NewDesc(name+"_rows_returned", qd.Help()+" (rows returned)", labels, nil),
+		shardQueries: prometheus.NewDesc(name+"_shard_queries", qd.Help()+" (shard queries)", labels, nil),
+	}
+
+	prometheus.MustRegister(collector)
+}
+
+// Describe implements Collector.
+func (c *queryDigestsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.latency
+	ch <- c.executions
+	ch <- c.errors
+	ch <- c.rowsAffected
+	ch <- c.rowsReturned
+	ch <- c.shardQueries
+}
+
+// Collect implements Collector.
+func (c *queryDigestsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range c.qd.Snapshot() {
+		metric, err := prometheus.NewConstSummary(c.latency,
+			uint64(s.LatencyCount()),
+			s.TotalTime.Seconds(),
+			map[float64]float64{
+				0.5:  s.P50.Seconds(),
+				0.95: s.P95.Seconds(),
+				0.99: s.P99.Seconds(),
+			},
+			s.Digest, s.Query)
+		if err != nil {
+			log.Errorf("Error adding metric: %s", c.latency)
+		} else {
+			ch <- metric
+		}
+		for desc, value := range map[*prometheus.Desc]int64{
+			c.executions:   s.Executions(),
+			c.errors:       s.Errors,
+			c.rowsAffected: s.RowsAffected,
+			c.rowsReturned: s.RowsReturned,
+			c.shardQueries: s.ShardQueries,
+		} {
+			metric, err := prometheus.NewConstMetric(desc, prometheus.CounterValue, float64(value), s.Digest, s.Query)
+			if err != nil {
+				log.Errorf("Error adding metric: %s", desc)
+			} else {
+				ch <- metric
+			}
+		}
+	}
+}
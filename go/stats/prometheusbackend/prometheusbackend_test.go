@@ -340,6 +340,32 @@ func TestPrometheusHistogram(t *testing.T) {
 	}
 }
 
+func TestPrometheusQueryDigests(t *testing.T) {
+	name := "blah_query_digests"
+	qd := stats.NewQueryDigests(name, "help", 10)
+	qd.Record("select 1", 10*time.Millisecond, 0, 1, 2, false)
+	qd.Record("select 1", 10*time.Millisecond, 0, 1, 2, true)
+	digest := qd.Snapshot()[0].Digest
+
+	response := testMetricsHandler(t)
+	var s []string
+
+	s = append(s, fmt.Sprintf("%s_%s_latency_seconds{digest=\"%s\",query=\"select 1\",quantile=\"0.5\"} %s", namespace, name, digest, "0.0075"))
+	s = append(s, fmt.Sprintf("%s_%s_latency_seconds{digest=\"%s\",query=\"select 1\",quantile=\"0.99\"} %s", namespace, name, digest, "0.00995"))
+	s = append(s, fmt.Sprintf("%s_%s_latency_seconds_sum{digest=\"%s\",query=\"select 1\"} %s", namespace, name, digest, "0.02"))
+	s = append(s, fmt.Sprintf("%s_%s_latency_seconds_count{digest=\"%s\",query=\"select 1\"} %d", namespace, name, digest, 2))
+	s = append(s, fmt.Sprintf("%s_%s_executions{digest=\"%s\",query=\"select 1\"} %d", namespace, name, digest, 2))
+	s = append(s, fmt.Sprintf("%s_%s_errors{digest=\"%s\",query=\"select 1\"} %d", namespace, name, digest, 1))
+	s = append(s, fmt.Sprintf("%s_%s_rows_returned{digest=\"%s\",query=\"select 1\"} %d", namespace, name, digest, 2))
+	s = append(s, fmt.Sprintf("%s_%s_shard_queries{digest=\"%s\",query=\"select 1\"} %d", namespace, name, digest, 4))
+
+	for _, line := range s {
+		if !strings.Contains(response.Body.String(), line) {
+			t.Fatalf("Expected result to contain %s, got %s", line, response.Body.String())
+		}
+	}
+}
+
 func testMetricsHandler(t *testing.T) *httptest.ResponseRecorder {
 	req, _ := http.NewRequest("GET", "/metrics", nil)
 	response := httptest.NewRecorder()
@@ -0,0 +1,339 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"container/heap"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// digestLatencyCutoffs are the upper bounds, in nanoseconds, of the
+// latency buckets of each query digest. They are finer than the cutoffs
+// of Timings because the latency quantiles are estimated from them.
+var digestLatencyCutoffs = []int64{
+	1e5, 2.5e5, 5e5,
+	1e6, 2.5e6, 5e6,
+	1e7, 2.5e7, 5e7,
+	1e8, 2.5e8, 5e8,
+	1e9, 2.5e9, 5e9,
+	1e10, 3e10,
+}
+
+// QueryDigests keeps the statistics of the most frequent queries, keyed
+// by their fingerprint: the query with its literals replaced by bind
+// variables. It keeps at most capacity fingerprints with the Space-Saving
+// algorithm: once it is full, a new fingerprint replaces the least
+// frequent one and inherits its count, so that the count of a frequent
+// query is never underestimated. The digests are also kept in a min-heap
+// of their counts, so that the least frequent one is found in constant
+// time and a recording costs O(log(capacity)).
+type QueryDigests struct {
+	help     string
+	capacity int
+
+	mu      sync.Mutex
+	digests map[string]*queryDigest
+	byCount digestHeap
+}
+
+type queryDigest struct {
+	// index is the position of the digest in the heap.
+	index        int
+	digest       string
+	query        string
+	count        int64
+	countError   int64
+	errors       int64
+	rowsAffected int64
+	rowsReturned int64
+	shardQueries int64
+	totalTime    int64
+	buckets      []int64
+}
+
+// QueryDigestStats is a snapshot of the statistics of one query digest.
+type QueryDigestStats struct {
+	// Digest is a hash of Query.
+	Digest string
+	// Query is the fingerprint of the query.
+	Query string
+	// Count is the number of executions. It includes CountError, which
+	// is the count inherited from the digests it replaced.
+	Count      int64
+	CountError int64
+	// Errors is the number of failed executions.
+	Errors       int64
+	RowsAffected int64
+	RowsReturned int64
+	// ShardQueries is the number of queries sent to the shards.
+	ShardQueries int64
+	// TotalTime and LatencyBuckets only cover the executions recorded
+	// since the digest was added.
+	TotalTime      time.Duration
+	LatencyBuckets []int64
+	P50            time.Duration
+	P95            time.Duration
+	P99            time.Duration
+}
+
+// NewQueryDigests creates a new QueryDigests which keeps at most capacity
+// digests, and publishes it if name is set.
+func NewQueryDigests(name, help string, capacity int) *QueryDigests {
+	qd := &QueryDigests{
+		help:     help,
+		capacity: capacity,
+		digests:  make(map[string]*queryDigest),
+	}
+	if name != "" {
+		publish(name, qd)
+	}
+	return qd
+}
+
+// Record adds an execution of the query with the given fingerprint.
+func (qd *QueryDigests) Record(query string, elapsed time.Duration, rowsAffected, rowsReturned, shardQueries int64, failed bool) {
+	qd.mu.Lock()
+	defer qd.mu.Unlock()
+
+	d, ok := qd.digests[query]
+	if !ok {
+		d = &queryDigest{
+			query:   query,
+			buckets: make([]int64, len(digestLatencyCutoffs)+1),
+		}
+		if len(qd.byCount) > 0 && len(qd.byCount) >= qd.capacity {
+			// Replace the least frequent digest.
+			min := heap.Pop(&qd.byCount).(*queryDigest)
+			delete(qd.digests, min.query)
+			d.count = min.count
+			d.countError = min.count
+		}
+		d.digest = queryDigestHash(query)
+		qd.digests[query] = d
+		heap.Push(&qd.byCount, d)
+	}
+
+	d.count++
+	heap.Fix(&qd.byCount, d.index)
+	if failed {
+		d.errors++
+	}
+	d.rowsAffected += rowsAffected
+	d.rowsReturned += rowsReturned
+	d.shardQueries += shardQueries
+	d.totalTime += int64(elapsed)
+	d.buckets[latencyBucket(int64(elapsed))]++
+}
+
+// Snapshot returns the statistics of the digests, sorted by decreasing
+// count.
+func (qd *QueryDigests) Snapshot() []QueryDigestStats {
+	qd.mu.Lock()
+	all := make([]QueryDigestStats, 0, len(qd.digests))
+	for _, d := range qd.digests {
+		all = append(all, QueryDigestStats{
+			Digest:         d.digest,
+			Query:          d.query,
+			Count:          d.count,
+			CountError:     d.countError,
+			Errors:         d.errors,
+			RowsAffected:   d.rowsAffected,
+			RowsReturned:   d.rowsReturned,
+			ShardQueries:   d.shardQueries,
+			TotalTime:      time.Duration(d.totalTime),
+			LatencyBuckets: append([]int64(nil), d.buckets...),
+		})
+	}
+	qd.mu.Unlock()
+
+	for i := range all {
+		all[i].setQuantiles()
+	}
+	sortQueryDigests(all)
+	return all
+}
+
+// Reset removes all the digests.
+func (qd *QueryDigests) Reset() {
+	qd.mu.Lock()
+	defer qd.mu.Unlock()
+	qd.digests = make(map[string]*queryDigest)
+	qd.byCount = nil
+}
+
+// Capacity returns the maximum number of digests.
+func (qd *QueryDigests) Capacity() int {
+	return qd.capacity
+}
+
+// Help returns the help string.
+func (qd *QueryDigests) Help() string {
+	return qd.help
+}
+
+// String is used by expvar.
+func (qd *QueryDigests) String() string {
+	b, err := json.Marshal(qd.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// digestHeap is a min-heap of digests by count. It implements
+// heap.Interface.
+type digestHeap []*queryDigest
+
+func (h digestHeap) Len() int           { return len(h) }
+func (h digestHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+
+func (h digestHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *digestHeap) Push(x interface{}) {
+	d := x.(*queryDigest)
+	d.index = len(*h)
+	*h = append(*h, d)
+}
+
+func (h *digestHeap) Pop() interface{} {
+	old := *h
+	d := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return d
+}
+
+// Executions returns the number of executions recorded since the digest
+// was added, which, unlike Count, only grows while the digest is kept.
+func (s *QueryDigestStats) Executions() int64 {
+	return s.Count - s.CountError
+}
+
+// MergeQueryDigests adds up the statistics of the digests of several
+// processes. The latency quantiles are estimated again from the sum of the
+// latency buckets.
+func MergeQueryDigests(lists ...[]QueryDigestStats) []QueryDigestStats {
+	merged := make(map[string]*QueryDigestStats)
+	for _, list := range lists {
+		for _, s := range list {
+			m, ok := merged[s.Digest]
+			if !ok {
+				m = &QueryDigestStats{
+					Digest:         s.Digest,
+					Query:          s.Query,
+					LatencyBuckets: make([]int64, len(digestLatencyCutoffs)+1),
+				}
+				merged[s.Digest] = m
+			}
+			m.Count += s.Count
+			m.CountError += s.CountError
+			m.Errors += s.Errors
+			m.RowsAffected += s.RowsAffected
+			m.RowsReturned += s.RowsReturned
+			m.ShardQueries += s.ShardQueries
+			m.TotalTime += s.TotalTime
+			for i := 0; i < len(s.LatencyBuckets) && i < len(m.LatencyBuckets); i++ {
+				m.LatencyBuckets[i] += s.LatencyBuckets[i]
+			}
+		}
+	}
+	all := make([]QueryDigestStats, 0, len(merged))
+	for _, m := range merged {
+		m.setQuantiles()
+		all = append(all, *m)
+	}
+	sortQueryDigests(all)
+	return all
+}
+
+// LatencyCount returns the number of executions in the latency buckets.
+func (s *QueryDigestStats) LatencyCount() int64 {
+	var count int64
+	for _, n := range s.LatencyBuckets {
+		count += n
+	}
+	return count
+}
+
+func (s *QueryDigestStats) setQuantiles() {
+	s.P50 = latencyQuantile(s.LatencyBuckets, 0.5)
+	s.P95 = latencyQuantile(s.LatencyBuckets, 0.95)
+	s.P99 = latencyQuantile(s.LatencyBuckets, 0.99)
+}
+
+func sortQueryDigests(all []QueryDigestStats) {
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Count != all[j].Count {
+			return all[i].Count > all[j].Count
+		}
+		return all[i].Query < all[j].Query
+	})
+}
+
+func queryDigestHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:8])
+}
+
+func latencyBucket(elapsed int64) int {
+	for i, cutoff := range digestLatencyCutoffs {
+		if elapsed <= cutoff {
+			return i
+		}
+	}
+	return len(digestLatencyCutoffs)
+}
+
+// latencyQuantile estimates the quantile q of the latencies in buckets,
+// assuming that they are evenly spread within each bucket. The latencies
+// above the highest cutoff are reported as the highest cutoff.
+func latencyQuantile(buckets []int64, q float64) time.Duration {
+	var total int64
+	for _, n := range buckets {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := q * float64(total)
+	var seen int64
+	for i, n := range buckets {
+		if n == 0 || float64(seen+n) < rank {
+			seen += n
+			continue
+		}
+		if i >= len(digestLatencyCutoffs) {
+			break
+		}
+		var lower int64
+		if i > 0 {
+			lower = digestLatencyCutoffs[i-1]
+		}
+		upper := digestLatencyCutoffs[i]
+		return time.Duration(lower + int64(float64(upper-lower)*(rank-float64(seen))/float64(n)))
+	}
+	return time.Duration(digestLatencyCutoffs[len(digestLatencyCutoffs)-1])
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryDigests(t *testing.T) {
+	clear()
+	qd := NewQueryDigests("QueryDigestsTest", "help", 10)
+	for i := 0; i < 100; i++ {
+		qd.Record("select a from t where id = :id", time.Duration(i+1)*time.Millisecond, 0, 1, 2, i%10 == 0)
+	}
+	qd.Record("update t set a = :a", 3*time.Second, 4, 0, 1, false)
+
+	all := qd.Snapshot()
+	require.Len(t, all, 2)
+	s := all[0]
+	assert.Equal(t, "select a from t where id = :id", s.Query)
+	assert.Equal(t, queryDigestHash(s.Query), s.Digest)
+	assert.Len(t, s.Digest, 16)
+	assert.EqualValues(t, 100, s.Count)
+	assert.EqualValues(t, 0, s.CountError)
+	assert.EqualValues(t, 10, s.Errors)
+	assert.EqualValues(t, 100, s.RowsReturned)
+	assert.EqualValues(t, 200, s.ShardQueries)
+	assert.Equal(t, 5050*time.Millisecond, s.TotalTime)
+	assert.EqualValues(t, 100, s.LatencyCount())
+	// The quantiles are interpolated within the 25ms-50ms, 50ms-100ms
+	// and 50ms-100ms buckets.
+	assert.Equal(t, 50*time.Millisecond, s.P50)
+	assert.Equal(t, 95*time.Millisecond, s.P95)
+	assert.Equal(t, 99*time.Millisecond, s.P99)
+
+	s = all[1]
+	assert.Equal(t, "update t set a = :a", s.Query)
+	assert.EqualValues(t, 1, s.Count)
+	assert.EqualValues(t, 4, s.RowsAffected)
+	assert.Equal(t, 4975*time.Millisecond, s.P99)
+
+	var exported []QueryDigestStats
+	require.NoError(t, json.Unmarshal([]byte(qd.String()), &exported))
+	assert.Equal(t, all, exported)
+
+	qd.Reset()
+	assert.Empty(t, qd.Snapshot())
+}
+
+func TestQueryDigestsEviction(t *testing.T) {
+	qd := NewQueryDigests("", "help", 2)
+	for i := 0; i < 3; i++ {
+		qd.Record("a", time.Millisecond, 0, 0, 1, false)
+	}
+	qd.Record("b", time.Millisecond, 0, 0, 1, false)
+	// c replaces b, the least frequent query, and inherits its count.
+	qd.Record("c", time.Millisecond, 0, 0, 1, false)
+	qd.Record("c", time.Millisecond, 0, 0, 1, false)
+
+	all := qd.Snapshot()
+	require.Len(t, all, 2)
+	assert.Equal(t, "a", all[0].Query)
+	assert.EqualValues(t, 3, all[0].Count)
+	assert.Equal(t, "c", all[1].Query)
+	assert.EqualValues(t, 3, all[1].Count)
+	assert.EqualValues(t, 1, all[1].CountError)
+	assert.EqualValues(t, 2, all[1].Executions())
+	assert.EqualValues(t, 2, all[1].LatencyCount())
+	assert.EqualValues(t, 2, all[1].ShardQueries)
+
+	// d replaces c, which is now the least frequent query.
+	qd.Record("a", time.Millisecond, 0, 0, 1, false)
+	qd.Record("d", time.Millisecond, 0, 0, 1, false)
+	all = qd.Snapshot()
+	require.Len(t, all, 2)
+	assert.Equal(t, "a", all[0].Query)
+	assert.EqualValues(t, 4, all[0].Count)
+	assert.Equal(t, "d", all[1].Query)
+	assert.EqualValues(t, 4, all[1].Count)
+	assert.EqualValues(t, 1, all[1].Executions())
+}
+
+func TestMergeQueryDigests(t *testing.T) {
+	qd1 := NewQueryDigests("", "help", 10)
+	qd2 := NewQueryDigests("", "help", 10)
+	for i := 0; i < 10; i++ {
+		qd1.Record("a", time.Millisecond, 0, 1, 1, false)
+		qd2.Record("a", 10*time.Second, 0, 1, 1, false)
+	}
+	qd2.Record("b", time.Millisecond, 0, 0, 1, true)
+
+	all := MergeQueryDigests(qd1.Snapshot(), qd2.Snapshot())
+	require.Len(t, all, 2)
+	a := all[0]
+	assert.Equal(t, "a", a.Query)
+	assert.EqualValues(t, 20, a.Count)
+	assert.EqualValues(t, 20, a.RowsReturned)
+	assert.Equal(t, 100010*time.Millisecond, a.TotalTime)
+	assert.Equal(t, time.Millisecond, a.P50)
+	assert.Equal(t, 9500*time.Millisecond, a.P95)
+	assert.Equal(t, "b", all[1].Query)
+	assert.EqualValues(t, 1, all[1].Errors)
+}
+
+func TestLatencyQuantile(t *testing.T) {
+	buckets := make([]int64, len(digestLatencyCutoffs)+1)
+	assert.Equal(t, time.Duration(0), latencyQuantile(buckets, 0.5))
+
+	buckets[len(buckets)-1] = 1
+	assert.Equal(t, 30*time.Second, latencyQuantile(buckets, 0.5))
+}
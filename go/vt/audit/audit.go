@@ -0,0 +1,322 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records who changed the schema, the data of the audited
+// tables, or the topology of a cluster, and when. Unlike the query logs,
+// which are sampled debugging streams, every matching operation is
+// recorded.
+//
+// The events are written as JSON lines to a file or to syslog. Each event
+// carries the hash of the previous one, so that a deleted, inserted or
+// modified line breaks the chain, which Verify detects. The chain can be
+// keyed with a secret so that it can't be recomputed by someone who only
+// has access to the log.
+package audit
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+var (
+	logFile         = flag.String("audit_log_file", "", "If set, the audit events are appended to this file as JSON lines.")
+	logSyslog       = flag.Bool("audit_log_syslog", false, "If set, the audit events are sent to syslog.")
+	logClasses      = flag.String("audit_log_classes", "ddl,dml,priv,vtctl,reparent", "Comma-separated list of the classes of audit events to record: ddl, dml, priv, vtctl and reparent.")
+	dmlTables       = flag.String("audit_log_dml_tables", "", "Comma-separated list of the tables whose DML statements are audited. A name ending with % is a prefix. No DML is audited if empty.")
+	ignoredCommands = flag.String("audit_log_ignored_vtctl_commands", "Get,List,Find,Validate,Help,Ping", "Comma-separated list of the prefixes of the vtctl commands which are not audited, typically the read-only ones.")
+	hmacKeyFile     = flag.String("audit_log_hmac_key_file", "", "If set, the hash chain of the audit events is keyed with the contents of this file, so that it can't be recomputed without the key.")
+)
+
+// The classes of audit events.
+const (
+	// ClassDDL is for the schema changes.
+	ClassDDL = "ddl"
+	// ClassDML is for the DML statements on the audited tables.
+	ClassDML = "dml"
+	// ClassPriv is for the GRANT and REVOKE statements.
+	ClassPriv = "priv"
+	// ClassVtctl is for the vtctl commands.
+	ClassVtctl = "vtctl"
+	// ClassReparent is for the reparents of the shards.
+	ClassReparent = "reparent"
+)
+
+// Event is an audit event. The fields which identify the caller are
+// filled in from the context by Record.
+type Event struct {
+	Seq       uint64    `json:"seq"`
+	Time      time.Time `json:"time"`
+	Component string    `json:"component"`
+	Class     string    `json:"class"`
+
+	// Who did it.
+	EffectiveCaller string `json:"effective_caller,omitempty"`
+	ImmediateCaller string `json:"immediate_caller,omitempty"`
+	ClientAddr      string `json:"client_addr,omitempty"`
+	TLSIdentity     string `json:"tls_identity,omitempty"`
+
+	// What was done.
+	StmtType     string   `json:"stmt_type,omitempty"`
+	Keyspace     string   `json:"keyspace,omitempty"`
+	Shard        string   `json:"shard,omitempty"`
+	Tables       []string `json:"tables,omitempty"`
+	SQL          string   `json:"sql,omitempty"`
+	RowsAffected uint64   `json:"rows_affected,omitempty"`
+	Command      []string `json:"command,omitempty"`
+	Operation    string   `json:"operation,omitempty"`
+	OldMaster    string   `json:"old_master,omitempty"`
+	NewMaster    string   `json:"new_master,omitempty"`
+	Error        string   `json:"error,omitempty"`
+
+	// PrevHash is the hash of the previous event.
+	PrevHash string `json:"prev_hash"`
+}
+
+// filter selects the events which are recorded.
+type filter struct {
+	classes map[string]bool
+	// dmlTables are in lower case. A name ending with % is a prefix.
+	dmlTables       []string
+	ignoredCommands []string
+}
+
+func newFilter(classes, tables, commands string) filter {
+	f := filter{classes: make(map[string]bool)}
+	for _, class := range splitList(classes) {
+		f.classes[strings.ToLower(class)] = true
+	}
+	for _, table := range splitList(tables) {
+		f.dmlTables = append(f.dmlTables, strings.ToLower(table))
+	}
+	f.ignoredCommands = splitList(commands)
+	return f
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// auditedTable returns true if the DML statements on the table are audited.
+func (f *filter) auditedTable(table string) bool {
+	table = strings.ToLower(table)
+	for _, t := range f.dmlTables {
+		if strings.HasSuffix(t, "%") {
+			if strings.HasPrefix(table, t[:len(t)-1]) {
+				return true
+			}
+		} else if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+// auditedCommand returns true if the vtctl command is audited.
+func (f *filter) auditedCommand(command string) bool {
+	command = strings.ToLower(command)
+	for _, prefix := range f.ignoredCommands {
+		if strings.HasPrefix(command, strings.ToLower(prefix)) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	mu      sync.Mutex
+	current *Logger
+)
+
+func init() {
+	servenv.OnRun(func() {
+		if err := Init(); err != nil {
+			log.Exitf("Unable to initialize the audit log: %v", err)
+		}
+	})
+	servenv.OnClose(Close)
+}
+
+// Init opens the audit log configured by the flags. The events are not
+// recorded if neither a file nor syslog is configured.
+func Init() error {
+	if *logFile == "" && !*logSyslog {
+		return nil
+	}
+	var key []byte
+	if *hmacKeyFile != "" {
+		var err error
+		if key, err = ioutil.ReadFile(*hmacKeyFile); err != nil {
+			return err
+		}
+	}
+	logger, err := newLogger(*logFile, *logSyslog, key, newFilter(*logClasses, *dmlTables, *ignoredCommands))
+	if err != nil {
+		return err
+	}
+	setLogger(logger)
+	return nil
+}
+
+// Close closes the audit log.
+func Close() {
+	setLogger(nil)
+}
+
+func setLogger(logger *Logger) {
+	mu.Lock()
+	old := current
+	current = logger
+	mu.Unlock()
+	if old != nil {
+		old.close()
+	}
+}
+
+func getLogger() *Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return current
+}
+
+// component is the name of the binary which records the events.
+var component = filepath.Base(os.Args[0])
+
+// Record fills in the caller of ev from ctx and records it, if its class
+// is audited.
+func Record(ctx context.Context, ev *Event) {
+	logger := getLogger()
+	if logger == nil || !logger.filter.classes[ev.Class] {
+		return
+	}
+	logger.record(ctx, ev)
+}
+
+func (logger *Logger) record(ctx context.Context, ev *Event) {
+	ev.Time = time.Now().UTC()
+	ev.Component = component
+	if ef := callerid.EffectiveCallerIDFromContext(ctx); ef != nil {
+		ev.EffectiveCaller = ef.Principal
+	}
+	if im := callerid.ImmediateCallerIDFromContext(ctx); im != nil {
+		ev.ImmediateCaller = im.Username
+	}
+	if ci, ok := callinfo.FromContext(ctx); ok {
+		ev.ClientAddr = ci.RemoteAddr()
+	}
+	ev.TLSIdentity = callinfo.TLSIdentity(ctx)
+	if err := logger.write(ev); err != nil {
+		log.Errorf("Unable to write the audit event %+v: %v", ev, err)
+	}
+}
+
+// Query records a statement executed on keyspace, if it is a schema
+// change, a privilege change or a DML statement on an audited table.
+// tables are the tables of the plan of the statement. sql should be
+// normalized, so that the values of the bind variables are not recorded.
+func Query(ctx context.Context, stmtType sqlparser.StatementType, keyspace, sql string, tables []string, rowsAffected uint64, err error) {
+	logger := getLogger()
+	if logger == nil {
+		return
+	}
+	var class string
+	switch stmtType {
+	case sqlparser.StmtDDL:
+		class = ClassDDL
+	case sqlparser.StmtPriv:
+		class = ClassPriv
+	case sqlparser.StmtInsert, sqlparser.StmtReplace, sqlparser.StmtUpdate, sqlparser.StmtDelete:
+		if len(logger.filter.dmlTables) == 0 {
+			return
+		}
+		class = ClassDML
+	default:
+		return
+	}
+	if !logger.filter.classes[class] {
+		return
+	}
+	if class == ClassDML {
+		audited := false
+		for _, table := range tables {
+			audited = audited || logger.filter.auditedTable(table)
+		}
+		if !audited {
+			return
+		}
+	}
+	ev := &Event{
+		Class:        class,
+		StmtType:     stmtType.String(),
+		Keyspace:     keyspace,
+		Tables:       sortedTables(tables),
+		SQL:          sql,
+		RowsAffected: rowsAffected,
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	logger.record(ctx, ev)
+}
+
+// sortedTables returns the distinct tables, sorted.
+func sortedTables(tables []string) []string {
+	names := make(map[string]bool)
+	var sorted []string
+	for _, table := range tables {
+		if !names[table] {
+			names[table] = true
+			sorted = append(sorted, table)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// Command records a vtctl command, unless it is ignored.
+func Command(ctx context.Context, args []string, err error) {
+	logger := getLogger()
+	if logger == nil || len(args) == 0 || !logger.filter.classes[ClassVtctl] || !logger.filter.auditedCommand(args[0]) {
+		return
+	}
+	ev := &Event{
+		Class:   ClassVtctl,
+		Command: args,
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	logger.record(ctx, ev)
+}
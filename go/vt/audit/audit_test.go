@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// setUpLogger installs a logger writing to a new file, and returns the
+// path of the file and a function which removes it.
+func setUpLogger(t *testing.T, key []byte, f filter) (string, func()) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	file := path.Join(dir, "audit.log")
+	logger, err := newLogger(file, false, key, f)
+	require.NoError(t, err)
+	setLogger(logger)
+	return file, func() {
+		Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func readEvents(t *testing.T, file string) []*Event {
+	data, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	var events []*Event
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		ev, _, err := parseLine(line)
+		require.NoError(t, err)
+		events = append(events, ev)
+	}
+	return events
+}
+
+func TestQuery(t *testing.T) {
+	file, cleanup := setUpLogger(t, nil, newFilter("ddl,dml", "user, order%", ""))
+	defer cleanup()
+
+	ctx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("alice", "", ""), &querypb.VTGateCallerID{Username: "app"})
+	Query(ctx, sqlparser.StmtDDL, "ks", "alter table user add column a int", []string{"user"}, 0, nil)
+	Query(ctx, sqlparser.StmtSelect, "ks", "select * from user", []string{"user"}, 0, nil)
+	Query(ctx, sqlparser.StmtUpdate, "ks", "update user set a = :vtg1 where id = :vtg2", []string{"user"}, 2, nil)
+	Query(ctx, sqlparser.StmtInsert, "ks", "insert into orders_2020(id) values (:vtg1)", []string{"orders_2020"}, 1, nil)
+	Query(ctx, sqlparser.StmtDelete, "ks", "delete from music where id = :vtg1", []string{"music"}, 1, nil)
+	Query(ctx, sqlparser.StmtPriv, "ks", "grant all on *.* to alice", nil, 0, nil)
+	Query(ctx, sqlparser.StmtDelete, "ks", "delete u from user as u join music as m on u.id = m.user_id", []string{"user", "music", "user"}, 0, errors.New("boom"))
+
+	events := readEvents(t, file)
+	require.Len(t, events, 4)
+	assert.Equal(t, uint64(1), events[0].Seq)
+	assert.Equal(t, ClassDDL, events[0].Class)
+	assert.Equal(t, "DDL", events[0].StmtType)
+	assert.Equal(t, []string{"user"}, events[0].Tables)
+	assert.Equal(t, "alice", events[0].EffectiveCaller)
+	assert.Equal(t, "app", events[0].ImmediateCaller)
+	assert.Equal(t, "ks", events[0].Keyspace)
+
+	assert.Equal(t, ClassDML, events[1].Class)
+	assert.Equal(t, "update user set a = :vtg1 where id = :vtg2", events[1].SQL)
+	assert.Equal(t, uint64(2), events[1].RowsAffected)
+	assert.Equal(t, []string{"orders_2020"}, events[2].Tables)
+	assert.Equal(t, []string{"music", "user"}, events[3].Tables)
+	assert.Equal(t, "boom", events[3].Error)
+}
+
+func TestCommandAndRecord(t *testing.T) {
+	file, cleanup := setUpLogger(t, nil, newFilter("vtctl", "", "Get,List"))
+	defer cleanup()
+
+	ctx := context.Background()
+	Command(ctx, []string{"GetTablet", "zone1-100"}, nil)
+	Command(ctx, []string{"ApplySchema", "-sql", "alter table t add column c int", "ks"}, nil)
+	Command(ctx, []string{"listalltablets"}, nil)
+	Record(ctx, &Event{Class: ClassReparent, Keyspace: "ks", Shard: "0"})
+
+	events := readEvents(t, file)
+	require.Len(t, events, 1)
+	assert.Equal(t, ClassVtctl, events[0].Class)
+	assert.Equal(t, []string{"ApplySchema", "-sql", "alter table t add column c int", "ks"}, events[0].Command)
+}
+
+func TestHashChain(t *testing.T) {
+	key := []byte("secret")
+	f := newFilter("ddl", "", "")
+	file, cleanup := setUpLogger(t, key, f)
+	defer cleanup()
+
+	ctx := context.Background()
+	Query(ctx, sqlparser.StmtDDL, "ks", "create table a(id int)", []string{"a"}, 0, nil)
+	Query(ctx, sqlparser.StmtDDL, "ks", "create table b(id int)", []string{"b"}, 0, nil)
+
+	// The chain continues in the same file after a restart.
+	logger, err := newLogger(file, false, key, f)
+	require.NoError(t, err)
+	setLogger(logger)
+	Query(ctx, sqlparser.StmtDDL, "ks", "create table c(id int)", []string{"c"}, 0, nil)
+
+	data, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	n, err := Verify(bytes.NewReader(data), key)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	events := readEvents(t, file)
+	assert.Equal(t, uint64(3), events[2].Seq)
+
+	// The hash can't be recomputed without the key.
+	_, err = Verify(bytes.NewReader(data), nil)
+	assert.EqualError(t, err, "line 1: the hash of the event does not match its content")
+
+	lines := strings.SplitAfter(string(data), "\n")
+
+	modified := strings.Replace(string(data), "create table b", "create table x", 1)
+	_, err = Verify(strings.NewReader(modified), key)
+	assert.EqualError(t, err, "line 2: the hash of the event does not match its content")
+
+	deleted := lines[0] + lines[2]
+	_, err = Verify(strings.NewReader(deleted), key)
+	assert.EqualError(t, err, "line 2: event 3 does not follow event 1")
+
+	// An event forged with the wrong key does not verify either.
+	ev := &Event{}
+	require.NoError(t, json.Unmarshal([]byte(strings.Replace(lines[0], `"seq":1`, `"seq":2`, 1)), ev))
+	forged, _, err := encodeLine(ev, []byte("guess"))
+	require.NoError(t, err)
+	_, err = Verify(strings.NewReader(lines[0]+string(forged)+lines[2]), key)
+	assert.EqualError(t, err, "line 2: the hash of the event does not match its content")
+}
+
+func TestTornLastLine(t *testing.T) {
+	f := newFilter("ddl", "", "")
+	file, cleanup := setUpLogger(t, nil, f)
+	defer cleanup()
+
+	ctx := context.Background()
+	Query(ctx, sqlparser.StmtDDL, "ks", "create table a(id int)", []string{"a"}, 0, nil)
+	Query(ctx, sqlparser.StmtDDL, "ks", "create table b(id int)", []string{"b"}, 0, nil)
+	data, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+
+	// A crash tore the last event: it is truncated after a restart, and
+	// the chain continues from the previous event.
+	require.NoError(t, ioutil.WriteFile(file, data[:len(data)-10], 0600))
+	logger, err := newLogger(file, false, nil, f)
+	require.NoError(t, err)
+	setLogger(logger)
+	Query(ctx, sqlparser.StmtDDL, "ks", "create table c(id int)", []string{"c"}, 0, nil)
+
+	data, err = ioutil.ReadFile(file)
+	require.NoError(t, err)
+	n, err := Verify(bytes.NewReader(data), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	events := readEvents(t, file)
+	assert.Equal(t, uint64(2), events[1].Seq)
+	assert.Equal(t, []string{"c"}, events[1].Tables)
+
+	// A last event which can't be parsed starts a new chain.
+	require.NoError(t, ioutil.WriteFile(file, append(data, "garbage\n"...), 0600))
+	logger, err = newLogger(file, false, nil, f)
+	require.NoError(t, err)
+	setLogger(logger)
+	Query(ctx, sqlparser.StmtDDL, "ks", "create table d(id int)", []string{"d"}, 0, nil)
+	data, err = ioutil.ReadFile(file)
+	require.NoError(t, err)
+	_, err = Verify(bytes.NewReader(data), nil)
+	assert.EqualError(t, err, "line 3: no hash in audit event: garbage")
+}
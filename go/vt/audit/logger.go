@@ -0,0 +1,261 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"log/syslog"
+	"os"
+	"sync"
+
+	"vitess.io/vitess/go/vt/log"
+)
+
+// hashField separates the hash of an event from the JSON object it is
+// computed from, at the end of each line.
+const hashField = `,"hash":"`
+
+// maxLineSize is the maximum size of an event, which bounds how much of
+// the end of the file is read to resume its hash chain.
+const maxLineSize = 1 << 20
+
+// Logger writes the chain of audit events.
+type Logger struct {
+	filter filter
+	key    []byte
+
+	mu       sync.Mutex
+	file     *os.File
+	syslog   *syslog.Writer
+	seq      uint64
+	prevHash string
+}
+
+// newLogger opens the file or syslog, or both. The events appended to an
+// existing file continue its chain.
+func newLogger(path string, toSyslog bool, key []byte, f filter) (*Logger, error) {
+	logger := &Logger{filter: f, key: key}
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return nil, err
+		}
+		logger.file = file
+		if err := logger.resume(path); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	if toSyslog {
+		w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, "vitess-audit")
+		if err != nil {
+			logger.close()
+			return nil, err
+		}
+		logger.syslog = w
+	}
+	return logger, nil
+}
+
+func (logger *Logger) close() {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if logger.file != nil {
+		logger.file.Close()
+		logger.file = nil
+	}
+	if logger.syslog != nil {
+		logger.syslog.Close()
+		logger.syslog = nil
+	}
+}
+
+// write assigns the next sequence number to ev, chains it to the previous
+// event and writes it.
+func (logger *Logger) write(ev *Event) error {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	ev.Seq = logger.seq + 1
+	ev.PrevHash = logger.prevHash
+	line, h, err := encodeLine(ev, logger.key)
+	if err != nil {
+		return err
+	}
+	if logger.file != nil {
+		if _, err := logger.file.Write(line); err != nil {
+			return err
+		}
+	}
+	if logger.syslog != nil {
+		if err := logger.syslog.Info(string(line)); err != nil {
+			return err
+		}
+	}
+	logger.seq, logger.prevHash = ev.Seq, h
+	return nil
+}
+
+// encodeLine returns the line of ev, followed by a newline, and its hash.
+// The hash covers the JSON object of the event, which includes the hash
+// of the previous event, and is added as its last field.
+func encodeLine(ev *Event, key []byte) ([]byte, string, error) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return nil, "", err
+	}
+	h := hashOf(data, key)
+	line := make([]byte, 0, len(data)+len(hashField)+len(h)+3)
+	line = append(line, data[:len(data)-1]...)
+	line = append(line, hashField...)
+	line = append(line, h...)
+	line = append(line, "\"}\n"...)
+	return line, h, nil
+}
+
+func hashOf(data, key []byte) string {
+	var hasher hash.Hash
+	if key != nil {
+		hasher = hmac.New(sha256.New, key)
+	} else {
+		hasher = sha256.New()
+	}
+	hasher.Write(data)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// parseLine returns the event of a line and its hash, without verifying it.
+func parseLine(line []byte) (*Event, string, error) {
+	data, h, err := splitLine(line)
+	if err != nil {
+		return nil, "", err
+	}
+	ev := &Event{}
+	if err := json.Unmarshal(data, ev); err != nil {
+		return nil, "", err
+	}
+	return ev, h, nil
+}
+
+// splitLine returns the JSON object the hash of a line is computed from,
+// and the hash.
+func splitLine(line []byte) ([]byte, string, error) {
+	line = bytes.TrimRight(line, "\r\n")
+	i := bytes.LastIndex(line, []byte(hashField))
+	if i < 0 || !bytes.HasSuffix(line, []byte("\"}")) {
+		return nil, "", fmt.Errorf("no hash in audit event: %s", line)
+	}
+	data := make([]byte, 0, i+1)
+	data = append(data, line[:i]...)
+	data = append(data, '}')
+	return data, string(line[i+len(hashField) : len(line)-2]), nil
+}
+
+// resume continues the chain of the last event of the file. A last line
+// which is not terminated by a newline was torn by a crash while it was
+// written: it is truncated. If the last event can't be parsed, a new chain
+// is started, and Verify reports the line which broke the previous one.
+func (logger *Logger) resume(path string) error {
+	for {
+		last, offset, torn, err := lastLine(logger.file)
+		if err != nil {
+			return err
+		}
+		if last == nil {
+			return nil
+		}
+		if torn {
+			log.Warningf("Truncating the torn last line of the audit log %s: %s", path, last)
+			if err := logger.file.Truncate(offset); err != nil {
+				return err
+			}
+			continue
+		}
+		ev, h, err := parseLine(last)
+		if err != nil {
+			log.Errorf("Can't resume the chain of the audit log %s, starting a new one: %v", path, err)
+			return nil
+		}
+		logger.seq, logger.prevHash = ev.Seq, h
+		return nil
+	}
+}
+
+// lastLine returns the last line of the file and its offset, or nil if it
+// is empty. torn is true if the line is not terminated by a newline.
+func lastLine(file *os.File) (line []byte, offset int64, torn bool, err error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, false, err
+	}
+	start := info.Size() - maxLineSize
+	if start < 0 {
+		start = 0
+	}
+	data := make([]byte, info.Size()-start)
+	if _, err := file.ReadAt(data, start); err != nil && err != io.EOF {
+		return nil, 0, false, err
+	}
+	torn = len(data) != 0 && data[len(data)-1] != '\n'
+	data = bytes.TrimRight(data, "\n")
+	if len(data) == 0 {
+		return nil, 0, false, nil
+	}
+	i := bytes.LastIndexByte(data, '\n') + 1
+	return data[i:], start + int64(i), torn, nil
+}
+
+// Verify checks the hash chain of the audit events read from r, keyed
+// with key if it is not nil. It returns an error naming the first line
+// which was modified, inserted or deleted, and the number of events.
+func Verify(r io.Reader, key []byte) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	var prev *Event
+	prevHash := ""
+	n := 0
+	for scanner.Scan() {
+		n++
+		data, h, err := splitLine(scanner.Bytes())
+		if err != nil {
+			return n, fmt.Errorf("line %d: %v", n, err)
+		}
+		if hashOf(data, key) != h {
+			return n, fmt.Errorf("line %d: the hash of the event does not match its content", n)
+		}
+		ev := &Event{}
+		if err := json.Unmarshal(data, ev); err != nil {
+			return n, fmt.Errorf("line %d: %v", n, err)
+		}
+		// The first event may continue a chain which was rotated away.
+		if prev != nil && (ev.PrevHash != prevHash || ev.Seq != prev.Seq+1) {
+			return n, fmt.Errorf("line %d: event %d does not follow event %d", n, ev.Seq, prev.Seq)
+		}
+		prev, prevHash = ev, h
+	}
+	if err := scanner.Err(); err != nil {
+		return n, err
+	}
+	return n, nil
+}
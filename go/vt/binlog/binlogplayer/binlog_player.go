@@ -90,6 +90,9 @@ type Stats struct {
 	CopyRowCount  *stats.Counter
 	CopyLoopCount *stats.Counter
 	ErrorCounts   *stats.CountersWithMultiLabels
+	// ThrottledTimings records, per phase, the time spent
+	// waiting for the lag throttler.
+	ThrottledTimings *stats.Timings
 }
 
 // SetLastPosition sets the last replication position.
@@ -131,6 +134,7 @@ func NewStats() *Stats {
 	bps.CopyRowCount = stats.NewCounter("", "")
 	bps.CopyLoopCount = stats.NewCounter("", "")
 	bps.ErrorCounts = stats.NewCountersWithMultiLabels("", "", []string{"type"})
+	bps.ThrottledTimings = stats.NewTimings("", "", "Phase")
 	return bps
 }
 
@@ -505,6 +509,8 @@ func (blp *BinlogPlayer) setVReplicationState(state, message string) error {
 // transaction_timestamp: timestamp of the transaction (from the master).
 // state: Running, Error or Stopped.
 // message: Reason for current state.
+// throttler_priority: optional column that sets how the lag throttler treats the stream:
+// normal (the default), low or high (never throttled).
 func CreateVReplicationTable() []string {
 	return []string{
 		"CREATE DATABASE IF NOT EXISTS _vt",
@@ -524,6 +530,7 @@ func CreateVReplicationTable() []string {
   state VARBINARY(100) NOT NULL,
   message VARBINARY(1000) DEFAULT NULL,
   db_name VARBINARY(255) NOT NULL,
+  throttler_priority VARBINARY(100) DEFAULT NULL,
   PRIMARY KEY (id)
 ) ENGINE=InnoDB`,
 	}
@@ -533,6 +540,7 @@ func CreateVReplicationTable() []string {
 var AlterVReplicationTable = []string{
 	"ALTER TABLE _vt.vreplication ADD COLUMN db_name VARBINARY(255) NOT NULL",
 	"ALTER TABLE _vt.vreplication MODIFY source BLOB NOT NULL",
+	"ALTER TABLE _vt.vreplication ADD COLUMN throttler_priority VARBINARY(100) DEFAULT NULL",
 }
 
 // VRSettings contains the settings of a vreplication table.
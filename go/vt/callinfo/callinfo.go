@@ -55,6 +55,19 @@ func FromContext(ctx context.Context) (CallInfo, bool) {
 	return ci, ok
 }
 
+// TLSIdentity returns the subject of the TLS client certificate of the call
+// stored in ctx, or "" if the client did not present one.
+func TLSIdentity(ctx context.Context) string {
+	ci, ok := FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if t, ok := ci.(interface{ TLSIdentity() string }); ok {
+		return t.TLSIdentity()
+	}
+	return ""
+}
+
 // HTMLFromContext returns that value of HTML() from the context, or "" if we're
 // not able to recover one
 func HTMLFromContext(ctx context.Context) template.HTML {
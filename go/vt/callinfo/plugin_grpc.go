@@ -24,6 +24,7 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 )
 
@@ -41,14 +42,18 @@ func GRPCCallInfo(ctx context.Context) context.Context {
 	peer, ok := peer.FromContext(ctx)
 	if ok {
 		callinfo.remoteAddr = peer.Addr.String()
+		if tlsInfo, ok := peer.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			callinfo.tlsIdentity = tlsInfo.State.PeerCertificates[0].Subject.String()
+		}
 	}
 
 	return NewContext(ctx, callinfo)
 }
 
 type gRPCCallInfoImpl struct {
-	method     string
-	remoteAddr string
+	method      string
+	remoteAddr  string
+	tlsIdentity string
 }
 
 func (gci *gRPCCallInfoImpl) RemoteAddr() string {
@@ -59,6 +64,10 @@ func (gci *gRPCCallInfoImpl) Username() string {
 	return "gRPC"
 }
 
+func (gci *gRPCCallInfoImpl) TLSIdentity() string {
+	return gci.tlsIdentity
+}
+
 func (gci *gRPCCallInfoImpl) Text() string {
 	return fmt.Sprintf("%s:%s(gRPC)", gci.remoteAddr, gci.method)
 }
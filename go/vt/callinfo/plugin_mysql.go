@@ -29,15 +29,20 @@ import (
 // MysqlCallInfo returns an augmented context with a CallInfo structure,
 // only for Mysql contexts.
 func MysqlCallInfo(ctx context.Context, c *mysql.Conn) context.Context {
-	return NewContext(ctx, &mysqlCallInfoImpl{
+	ci := &mysqlCallInfoImpl{
 		remoteAddr: c.RemoteAddr().String(),
 		user:       c.User,
-	})
+	}
+	if certs := c.GetTLSClientCerts(); len(certs) > 0 {
+		ci.tlsIdentity = certs[0].Subject.String()
+	}
+	return NewContext(ctx, ci)
 }
 
 type mysqlCallInfoImpl struct {
-	remoteAddr string
-	user       string
+	remoteAddr  string
+	user        string
+	tlsIdentity string
 }
 
 func (mci *mysqlCallInfoImpl) RemoteAddr() string {
@@ -48,6 +53,10 @@ func (mci *mysqlCallInfoImpl) Username() string {
 	return mci.user
 }
 
+func (mci *mysqlCallInfoImpl) TLSIdentity() string {
+	return mci.tlsIdentity
+}
+
 func (mci *mysqlCallInfoImpl) Text() string {
 	return fmt.Sprintf("%s@%s(Mysql)", mci.user, mci.remoteAddr)
 }
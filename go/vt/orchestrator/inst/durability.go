@@ -17,58 +17,41 @@ limitations under the License.
 package inst
 
 import (
-	"fmt"
-
 	"vitess.io/vitess/go/vt/orchestrator/external/golib/log"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 )
 
-//=======================================================================
-
-var (
-	durabilityPolicies  = make(map[string]durabler)
-	curDurabilityPolicy durabler
-)
-
-func init() {
-	registerDurability("none", &durabilityNone{})
-	registerDurability("semi_sync", &durabilitySemiSync{})
-	registerDurability("cross_cell", &durabilityCrossCell{})
-}
+// The durability policies are shared with the reparents of the wrangler
+// and the tablets, in reparentutil.
 
-type durabler interface {
-	promotionRule(*topodatapb.Tablet) CandidatePromotionRule
-	masterSemiSync(InstanceKey) int
-	replicaSemiSync(master, replica *topodatapb.Tablet) bool
-}
-
-func registerDurability(name string, d durabler) {
-	if durabilityPolicies[name] != nil {
-		log.Fatalf("durability policy %v already registered", name)
-	}
-	durabilityPolicies[name] = d
-}
-
-//=======================================================================
+var curDurabilityPolicy reparentutil.Durabler
 
+// SetDurabilityPolicy sets the durability policy.
 func SetDurabilityPolicy(name string) error {
-	curDurabilityPolicy = durabilityPolicies[name]
-	if curDurabilityPolicy == nil {
-		return fmt.Errorf("durability policy %v not found", name)
+	d, err := reparentutil.GetDurabilityPolicy(name)
+	if err != nil {
+		return err
 	}
+	curDurabilityPolicy = d
 	log.Infof("Durability setting: %v", name)
 	return nil
 }
 
 // PromotionRule returns the promotion rule for the instance.
 func PromotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule {
-	return curDurabilityPolicy.promotionRule(tablet)
+	return curDurabilityPolicy.PromotionRule(tablet)
 }
 
 // MasterSemiSync returns the master semi-sync setting for the instance.
 // 0 means none. Non-zero specifies the number of required ackers.
 func MasterSemiSync(instanceKey InstanceKey) int {
-	return curDurabilityPolicy.masterSemiSync(instanceKey)
+	// The policy gets a nil master if its tablet cannot be read.
+	master, err := ReadTablet(instanceKey)
+	if err != nil {
+		master = nil
+	}
+	return curDurabilityPolicy.MasterSemiSync(master)
 }
 
 // ReplicaSemiSync returns the replica semi-sync setting for the instance.
@@ -81,83 +64,11 @@ func ReplicaSemiSync(masterKey, replicaKey InstanceKey) bool {
 	if err != nil {
 		return false
 	}
-	return curDurabilityPolicy.replicaSemiSync(master, replica)
+	return curDurabilityPolicy.ReplicaSemiSync(master, replica)
 }
 
 // ReplicaSemiSyncFromTablet returns the replica semi-sync setting from the tablet record.
 // Prefer using this function if tablet record is available.
 func ReplicaSemiSyncFromTablet(master, replica *topodatapb.Tablet) bool {
-	return curDurabilityPolicy.replicaSemiSync(master, replica)
-}
-
-//=======================================================================
-
-type durabilityNone struct{}
-
-func (d *durabilityNone) promotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule {
-	switch tablet.Type {
-	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA:
-		return NeutralPromoteRule
-	}
-	return MustNotPromoteRule
-}
-
-func (d *durabilityNone) masterSemiSync(instanceKey InstanceKey) int {
-	return 0
-}
-
-func (d *durabilityNone) replicaSemiSync(master, replica *topodatapb.Tablet) bool {
-	return false
-}
-
-//=======================================================================
-
-type durabilitySemiSync struct{}
-
-func (d *durabilitySemiSync) promotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule {
-	switch tablet.Type {
-	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA:
-		return NeutralPromoteRule
-	}
-	return MustNotPromoteRule
-}
-
-func (d *durabilitySemiSync) masterSemiSync(instanceKey InstanceKey) int {
-	return 1
-}
-
-func (d *durabilitySemiSync) replicaSemiSync(master, replica *topodatapb.Tablet) bool {
-	switch replica.Type {
-	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA:
-		return true
-	}
-	return false
-}
-
-//=======================================================================
-
-type durabilityCrossCell struct{}
-
-func (d *durabilityCrossCell) promotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule {
-	switch tablet.Type {
-	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA:
-		return NeutralPromoteRule
-	}
-	return MustNotPromoteRule
-}
-
-func (d *durabilityCrossCell) masterSemiSync(instanceKey InstanceKey) int {
-	return 1
-}
-
-func (d *durabilityCrossCell) replicaSemiSync(master, replica *topodatapb.Tablet) bool {
-	// Prevent panics.
-	if master.Alias == nil || replica.Alias == nil {
-		return false
-	}
-	switch replica.Type {
-	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA:
-		return master.Alias.Cell != replica.Alias.Cell
-	}
-	return false
+	return curDurabilityPolicy.ReplicaSemiSync(master, replica)
 }
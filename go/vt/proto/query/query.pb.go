@@ -567,11 +567,14 @@ func (m *Target) GetCell() string {
 // structure, which is not secure at all, because it is provided
 // by the Vitess client.
 type VTGateCallerID struct {
-	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Groups               []string `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Username string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Groups   []string `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	// attributes are the attributes of the user given by the auth server.
+	// They can be used by the row policies of the VSchema.
+	Attributes           map[string]string `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *VTGateCallerID) Reset()         { *m = VTGateCallerID{} }
@@ -613,6 +616,13 @@ func (m *VTGateCallerID) GetGroups() []string {
 	return nil
 }
 
+func (m *VTGateCallerID) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
 // EventToken is a structure that describes a point in time in a
 // replication stream on one shard. The most recent known replication
 // position can be retrieved from vttablet when executing a query. It
@@ -4251,6 +4261,7 @@ func init() {
 	proto.RegisterEnum("query.StreamEvent_Statement_Category", StreamEvent_Statement_Category_name, StreamEvent_Statement_Category_value)
 	proto.RegisterType((*Target)(nil), "query.Target")
 	proto.RegisterType((*VTGateCallerID)(nil), "query.VTGateCallerID")
+	proto.RegisterMapType((map[string]string)(nil), "query.VTGateCallerID.AttributesEntry")
 	proto.RegisterType((*EventToken)(nil), "query.EventToken")
 	proto.RegisterType((*Value)(nil), "query.Value")
 	proto.RegisterType((*BindVariable)(nil), "query.BindVariable")
@@ -4316,202 +4327,204 @@ func init() {
 func init() { proto.RegisterFile("query.proto", fileDescriptor_5c6ac9b241082464) }
 
 var fileDescriptor_5c6ac9b241082464 = []byte{
-	// 3145 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4b, 0x70, 0x1b, 0x47,
-	0x7a, 0xd6, 0xe0, 0x45, 0xe0, 0x07, 0x01, 0x36, 0x9b, 0xa4, 0x04, 0x51, 0x7e, 0xd0, 0x63, 0xcb,
-	0x66, 0x98, 0x84, 0x92, 0x28, 0x59, 0x51, 0x6c, 0x27, 0xd1, 0x10, 0x1c, 0xca, 0x90, 0x80, 0x01,
-	0xd4, 0x18, 0x48, 0x96, 0x2a, 0x55, 0x53, 0x43, 0xa0, 0x05, 0x4e, 0x71, 0x80, 0x81, 0x66, 0x86,
-	0x94, 0x78, 0x53, 0xe2, 0x38, 0xce, 0x3b, 0xce, 0xd3, 0x71, 0x5c, 0x71, 0xa5, 0x2a, 0x87, 0x54,
-	0x2e, 0x39, 0xef, 0x79, 0x0f, 0x3e, 0xec, 0x61, 0xab, 0xf6, 0xb8, 0xbb, 0x87, 0xdd, 0x3d, 0x6c,
-	0xed, 0x9e, 0x5c, 0x5b, 0x7b, 0xd8, 0xc3, 0x1e, 0xb6, 0xb6, 0xfa, 0x31, 0x03, 0x80, 0x84, 0x25,
-	0x5a, 0x5e, 0xd7, 0x96, 0x64, 0xdd, 0xfa, 0x7f, 0xf4, 0xe3, 0xfb, 0xfa, 0x9f, 0xbf, 0x1f, 0xd3,
-	0x90, 0xbf, 0xbb, 0x4b, 0xfd, 0xfd, 0xd5, 0x81, 0xef, 0x85, 0x1e, 0x4e, 0x73, 0x61, 0xb1, 0x18,
-	0x7a, 0x03, 0xaf, 0x63, 0x87, 0xb6, 0x50, 0x2f, 0xe6, 0xf7, 0x42, 0x7f, 0xd0, 0x16, 0x82, 0xfa,
-	0x9e, 0x02, 0x19, 0xd3, 0xf6, 0xbb, 0x34, 0xc4, 0x8b, 0x90, 0xdd, 0xa1, 0xfb, 0xc1, 0xc0, 0x6e,
-	0xd3, 0x92, 0xb2, 0xa4, 0x2c, 0xe7, 0x48, 0x2c, 0xe3, 0x79, 0x48, 0x07, 0xdb, 0xb6, 0xdf, 0x29,
-	0x25, 0xb8, 0x41, 0x08, 0xf8, 0x75, 0xc8, 0x87, 0xf6, 0x96, 0x4b, 0x43, 0x2b, 0xdc, 0x1f, 0xd0,
-	0x52, 0x72, 0x49, 0x59, 0x2e, 0xae, 0xcd, 0xaf, 0xc6, 0xfd, 0x99, 0xdc, 0x68, 0xee, 0x0f, 0x28,
-	0x81, 0x30, 0x2e, 0x63, 0x0c, 0xa9, 0x36, 0x75, 0xdd, 0x52, 0x8a, 0xb7, 0xc5, 0xcb, 0xea, 0x06,
-	0x14, 0x6f, 0x98, 0x57, 0xec, 0x90, 0x96, 0x6d, 0xd7, 0xa5, 0x7e, 0x65, 0x83, 0x0d, 0x67, 0x37,
-	0xa0, 0x7e, 0xdf, 0xee, 0xc5, 0xc3, 0x89, 0x64, 0x7c, 0x1c, 0x32, 0x5d, 0xdf, 0xdb, 0x1d, 0x04,
-	0xa5, 0xc4, 0x52, 0x72, 0x39, 0x47, 0xa4, 0xa4, 0xfe, 0x29, 0x80, 0xbe, 0x47, 0xfb, 0xa1, 0xe9,
-	0xed, 0xd0, 0x3e, 0x7e, 0x0e, 0x72, 0xa1, 0xd3, 0xa3, 0x41, 0x68, 0xf7, 0x06, 0xbc, 0x89, 0x24,
-	0x19, 0x2a, 0x3e, 0x07, 0xd2, 0x22, 0x64, 0x07, 0x5e, 0xe0, 0x84, 0x8e, 0xd7, 0xe7, 0x78, 0x72,
-	0x24, 0x96, 0xd5, 0x3f, 0x86, 0xf4, 0x0d, 0xdb, 0xdd, 0xa5, 0xf8, 0x45, 0x48, 0x71, 0xc0, 0x0a,
-	0x07, 0x9c, 0x5f, 0x15, 0xa4, 0x73, 0x9c, 0xdc, 0xc0, 0xda, 0xde, 0x63, 0x9e, 0xbc, 0xed, 0x69,
-	0x22, 0x04, 0x75, 0x07, 0xa6, 0xd7, 0x9d, 0x7e, 0xe7, 0x86, 0xed, 0x3b, 0x8c, 0x8c, 0xc7, 0x6c,
-	0x06, 0xbf, 0x02, 0x19, 0x5e, 0x08, 0x4a, 0xc9, 0xa5, 0xe4, 0x72, 0x7e, 0x6d, 0x5a, 0x56, 0xe4,
-	0x63, 0x23, 0xd2, 0xa6, 0x7e, 0x53, 0x01, 0x58, 0xf7, 0x76, 0xfb, 0x9d, 0xeb, 0xcc, 0x88, 0x11,
-	0x24, 0x83, 0xbb, 0xae, 0x24, 0x92, 0x15, 0xf1, 0x35, 0x28, 0x6e, 0x39, 0xfd, 0x8e, 0xb5, 0x27,
-	0x87, 0x23, 0xb8, 0xcc, 0xaf, 0xbd, 0x22, 0x9b, 0x1b, 0x56, 0x5e, 0x1d, 0x1d, 0x75, 0xa0, 0xf7,
-	0x43, 0x7f, 0x9f, 0x14, 0xb6, 0x46, 0x75, 0x8b, 0x2d, 0xc0, 0x87, 0x9d, 0x58, 0xa7, 0x3b, 0x74,
-	0x3f, 0xea, 0x74, 0x87, 0xee, 0xe3, 0xdf, 0x19, 0x45, 0x94, 0x5f, 0x9b, 0x8b, 0xfa, 0x1a, 0xa9,
-	0x2b, 0x61, 0xbe, 0x91, 0xb8, 0xa4, 0xa8, 0xdf, 0x48, 0x43, 0x51, 0xbf, 0x4f, 0xdb, 0xbb, 0x21,
-	0xad, 0x0f, 0xd8, 0x1c, 0x04, 0xb8, 0x06, 0x33, 0x4e, 0xbf, 0xed, 0xee, 0x76, 0x68, 0xc7, 0xba,
-	0xe3, 0x50, 0xb7, 0x13, 0xf0, 0x38, 0x2a, 0xc6, 0xe3, 0x1e, 0xf7, 0x5f, 0xad, 0x48, 0xe7, 0x4d,
-	0xee, 0x4b, 0x8a, 0xce, 0x98, 0x8c, 0x57, 0x60, 0xb6, 0xed, 0x3a, 0xb4, 0x1f, 0x5a, 0x77, 0x18,
-	0x5e, 0xcb, 0xf7, 0xee, 0x05, 0xa5, 0xf4, 0x92, 0xb2, 0x9c, 0x25, 0x33, 0xc2, 0xb0, 0xc9, 0xf4,
-	0xc4, 0xbb, 0x17, 0xe0, 0x37, 0x20, 0x7b, 0xcf, 0xf3, 0x77, 0x5c, 0xcf, 0xee, 0x94, 0x32, 0xbc,
-	0xcf, 0x17, 0x26, 0xf7, 0x79, 0x53, 0x7a, 0x91, 0xd8, 0x1f, 0x2f, 0x03, 0x0a, 0xee, 0xba, 0x56,
-	0x40, 0x5d, 0xda, 0x0e, 0x2d, 0xd7, 0xe9, 0x39, 0x61, 0x29, 0xcb, 0x43, 0xb2, 0x18, 0xdc, 0x75,
-	0x9b, 0x5c, 0x5d, 0x65, 0x5a, 0x6c, 0xc1, 0x42, 0xe8, 0xdb, 0xfd, 0xc0, 0x6e, 0xb3, 0xc6, 0x2c,
-	0x27, 0xf0, 0x5c, 0x9b, 0x87, 0x63, 0x8e, 0x77, 0xb9, 0x32, 0xb9, 0x4b, 0x73, 0x58, 0xa5, 0x12,
-	0xd5, 0x20, 0xf3, 0xe1, 0x04, 0x2d, 0x3e, 0x07, 0x0b, 0xc1, 0x8e, 0x33, 0xb0, 0x78, 0x3b, 0xd6,
-	0xc0, 0xb5, 0xfb, 0x56, 0xdb, 0x6e, 0x6f, 0xd3, 0x12, 0x70, 0xd8, 0x98, 0x19, 0xf9, 0xbc, 0x37,
-	0x5c, 0xbb, 0x5f, 0x66, 0x16, 0xf5, 0x4d, 0x28, 0x8e, 0xf3, 0x88, 0x67, 0xa1, 0x60, 0xde, 0x6a,
-	0xe8, 0x96, 0x66, 0x6c, 0x58, 0x86, 0x56, 0xd3, 0xd1, 0x31, 0x5c, 0x80, 0x1c, 0x57, 0xd5, 0x8d,
-	0xea, 0x2d, 0xa4, 0xe0, 0x29, 0x48, 0x6a, 0xd5, 0x2a, 0x4a, 0xa8, 0x97, 0x20, 0x1b, 0x11, 0x82,
-	0x67, 0x20, 0xdf, 0x32, 0x9a, 0x0d, 0xbd, 0x5c, 0xd9, 0xac, 0xe8, 0x1b, 0xe8, 0x18, 0xce, 0x42,
-	0xaa, 0x5e, 0x35, 0x1b, 0x48, 0x11, 0x25, 0xad, 0x81, 0x12, 0xac, 0xe6, 0xc6, 0xba, 0x86, 0x92,
-	0xea, 0xff, 0x2a, 0x30, 0x3f, 0x09, 0x18, 0xce, 0xc3, 0xd4, 0x86, 0xbe, 0xa9, 0xb5, 0xaa, 0x26,
-	0x3a, 0x86, 0xe7, 0x60, 0x86, 0xe8, 0x0d, 0x5d, 0x33, 0xb5, 0xf5, 0xaa, 0x6e, 0x11, 0x5d, 0xdb,
-	0x40, 0x0a, 0xc6, 0x50, 0x64, 0x25, 0xab, 0x5c, 0xaf, 0xd5, 0x2a, 0xa6, 0xa9, 0x6f, 0xa0, 0x04,
-	0x9e, 0x07, 0xc4, 0x75, 0x2d, 0x63, 0xa8, 0x4d, 0x62, 0x04, 0xd3, 0x4d, 0x9d, 0x54, 0xb4, 0x6a,
-	0xe5, 0x36, 0x6b, 0x00, 0xa5, 0xf0, 0x4b, 0xf0, 0x7c, 0xb9, 0x6e, 0x34, 0x2b, 0x4d, 0x53, 0x37,
-	0x4c, 0xab, 0x69, 0x68, 0x8d, 0xe6, 0xdb, 0x75, 0x93, 0xb7, 0x2c, 0xc0, 0xa5, 0x71, 0x11, 0x40,
-	0x6b, 0x99, 0x75, 0xd1, 0x0e, 0xca, 0x5c, 0x4d, 0x65, 0x15, 0x94, 0xb8, 0x9a, 0xca, 0x26, 0x50,
-	0xf2, 0x6a, 0x2a, 0x9b, 0x44, 0x29, 0xf5, 0xc3, 0x04, 0xa4, 0x39, 0x57, 0x2c, 0xdd, 0x8d, 0x24,
-	0x31, 0x5e, 0x8e, 0x3f, 0xfd, 0xc4, 0x43, 0x3e, 0x7d, 0x9e, 0x31, 0x65, 0x12, 0x12, 0x02, 0x3e,
-	0x05, 0x39, 0xcf, 0xef, 0x5a, 0xc2, 0x22, 0xd2, 0x67, 0xd6, 0xf3, 0xbb, 0x3c, 0xcf, 0xb2, 0xd4,
-	0xc5, 0xb2, 0xee, 0x96, 0x1d, 0x50, 0x1e, 0xc1, 0x39, 0x12, 0xcb, 0xf8, 0x24, 0x30, 0x3f, 0x8b,
-	0x8f, 0x23, 0xc3, 0x6d, 0x53, 0x9e, 0xdf, 0x35, 0xd8, 0x50, 0x5e, 0x86, 0x42, 0xdb, 0x73, 0x77,
-	0x7b, 0x7d, 0xcb, 0xa5, 0xfd, 0x6e, 0xb8, 0x5d, 0x9a, 0x5a, 0x52, 0x96, 0x0b, 0x64, 0x5a, 0x28,
-	0xab, 0x5c, 0x87, 0x4b, 0x30, 0xd5, 0xde, 0xb6, 0xfd, 0x80, 0x8a, 0xa8, 0x2d, 0x90, 0x48, 0xe4,
-	0xbd, 0xd2, 0xb6, 0xd3, 0xb3, 0xdd, 0x80, 0x47, 0x68, 0x81, 0xc4, 0x32, 0x03, 0x71, 0xc7, 0xb5,
-	0xbb, 0x01, 0x8f, 0xac, 0x02, 0x11, 0x82, 0xfa, 0x07, 0x90, 0x24, 0xde, 0x3d, 0xd6, 0xa4, 0xe8,
-	0x30, 0x28, 0x29, 0x4b, 0xc9, 0x65, 0x4c, 0x22, 0x91, 0x65, 0x77, 0x99, 0xe0, 0x44, 0xde, 0x8b,
-	0x52, 0xda, 0xc7, 0x0a, 0xe4, 0x79, 0x60, 0x12, 0x1a, 0xec, 0xba, 0x21, 0x4b, 0x84, 0x32, 0x03,
-	0x28, 0x63, 0x89, 0x90, 0xd3, 0x4e, 0xa4, 0x8d, 0xe1, 0x63, 0x1f, 0xb5, 0x65, 0xdf, 0xb9, 0x43,
-	0xdb, 0x21, 0x15, 0xf9, 0x3e, 0x45, 0xa6, 0x99, 0x52, 0x93, 0x3a, 0x46, 0xac, 0xd3, 0x0f, 0xa8,
-	0x1f, 0x5a, 0x4e, 0x87, 0x53, 0x9e, 0x22, 0x59, 0xa1, 0xa8, 0x74, 0xf0, 0x0b, 0x90, 0xe2, 0x69,
-	0x21, 0xc5, 0x7b, 0x01, 0xd9, 0x0b, 0xf1, 0xee, 0x11, 0xae, 0xbf, 0x9a, 0xca, 0xa6, 0x51, 0x46,
-	0x7d, 0x0b, 0xa6, 0xf9, 0xe0, 0x6e, 0xda, 0x7e, 0xdf, 0xe9, 0x77, 0xf9, 0x2a, 0xe7, 0x75, 0xc4,
-	0xb4, 0x17, 0x08, 0x2f, 0x33, 0xcc, 0x3d, 0x1a, 0x04, 0x76, 0x97, 0xca, 0x55, 0x27, 0x12, 0xd5,
-	0xff, 0x4e, 0x42, 0xbe, 0x19, 0xfa, 0xd4, 0xee, 0xf1, 0x05, 0x0c, 0xbf, 0x05, 0x10, 0x84, 0x76,
-	0x48, 0x7b, 0xb4, 0x1f, 0x46, 0xf8, 0x9e, 0x93, 0x3d, 0x8f, 0xf8, 0xad, 0x36, 0x23, 0x27, 0x32,
-	0xe2, 0x8f, 0xd7, 0x20, 0x4f, 0x99, 0xd9, 0x0a, 0xd9, 0x42, 0x28, 0x93, 0xed, 0x6c, 0x94, 0x39,
-	0xe2, 0x15, 0x92, 0x00, 0x8d, 0xcb, 0x8b, 0x9f, 0x24, 0x20, 0x17, 0xb7, 0x86, 0x35, 0xc8, 0xb6,
-	0xed, 0x90, 0x76, 0x3d, 0x7f, 0x5f, 0xae, 0x4f, 0xa7, 0x1f, 0xd6, 0xfb, 0x6a, 0x59, 0x3a, 0x93,
-	0xb8, 0x1a, 0x7e, 0x1e, 0xc4, 0xa2, 0x2f, 0xa2, 0x4e, 0xe0, 0xcd, 0x71, 0x0d, 0x8f, 0xbb, 0x37,
-	0x00, 0x0f, 0x7c, 0xa7, 0x67, 0xfb, 0xfb, 0xd6, 0x0e, 0xdd, 0x8f, 0x72, 0x79, 0x72, 0xc2, 0x4c,
-	0x22, 0xe9, 0x77, 0x8d, 0xee, 0xcb, 0xec, 0x73, 0x69, 0xbc, 0xae, 0x8c, 0x96, 0xc3, 0xf3, 0x33,
-	0x52, 0x93, 0xaf, 0x8e, 0x41, 0xb4, 0x0e, 0xa6, 0x79, 0x60, 0xb1, 0xa2, 0xfa, 0x1a, 0x64, 0xa3,
-	0xc1, 0xe3, 0x1c, 0xa4, 0x75, 0xdf, 0xf7, 0x7c, 0x74, 0x8c, 0x27, 0xa1, 0x5a, 0x55, 0xe4, 0xb1,
-	0x8d, 0x0d, 0x96, 0xc7, 0x7e, 0x94, 0x88, 0x17, 0x23, 0x42, 0xef, 0xee, 0xd2, 0x20, 0xc4, 0x7f,
-	0x02, 0x73, 0x94, 0x87, 0x90, 0xb3, 0x47, 0xad, 0x36, 0xdf, 0xb9, 0xb0, 0x00, 0x52, 0x38, 0xdf,
-	0x33, 0xab, 0x62, 0xa3, 0x15, 0xed, 0x68, 0xc8, 0x6c, 0xec, 0x2b, 0x55, 0x1d, 0xac, 0xc3, 0x9c,
-	0xd3, 0xeb, 0xd1, 0x8e, 0x63, 0x87, 0xa3, 0x0d, 0x88, 0x09, 0x5b, 0x88, 0x16, 0xf6, 0xb1, 0x8d,
-	0x11, 0x99, 0x8d, 0x6b, 0xc4, 0xcd, 0x9c, 0x86, 0x4c, 0xc8, 0x37, 0x71, 0x3c, 0x76, 0xf3, 0x6b,
-	0x85, 0x28, 0xa1, 0x70, 0x25, 0x91, 0x46, 0xfc, 0x1a, 0x88, 0x2d, 0x21, 0x4f, 0x1d, 0xc3, 0x80,
-	0x18, 0xae, 0xf4, 0x44, 0xd8, 0xf1, 0x69, 0x28, 0x8e, 0xad, 0x41, 0x1d, 0x4e, 0x58, 0x92, 0x14,
-	0x46, 0x17, 0x94, 0x0e, 0x3e, 0x03, 0x53, 0x9e, 0x58, 0x7f, 0x78, 0x52, 0x19, 0x8e, 0x78, 0x7c,
-	0x71, 0x22, 0x91, 0x17, 0x7e, 0x11, 0xf2, 0x3e, 0x0d, 0xa8, 0xbf, 0x47, 0x3b, 0xac, 0xd1, 0x29,
-	0xde, 0x28, 0x44, 0xaa, 0x4a, 0x47, 0xfd, 0x23, 0x98, 0x89, 0x29, 0x0e, 0x06, 0x5e, 0x3f, 0xa0,
-	0x78, 0x05, 0x32, 0x3e, 0xff, 0xde, 0x25, 0xad, 0x58, 0xf6, 0x31, 0x92, 0x09, 0x88, 0xf4, 0x50,
-	0x3b, 0x30, 0x23, 0x34, 0x37, 0x9d, 0x70, 0x9b, 0xcf, 0x24, 0x3e, 0x0d, 0x69, 0xca, 0x0a, 0x07,
-	0x26, 0x85, 0x34, 0xca, 0xdc, 0x4e, 0x84, 0x75, 0xa4, 0x97, 0xc4, 0x23, 0x7b, 0xf9, 0x59, 0x02,
-	0xe6, 0xe4, 0x28, 0xd7, 0xed, 0xb0, 0xbd, 0xfd, 0x84, 0x46, 0xc3, 0xef, 0xc2, 0x14, 0xd3, 0x3b,
-	0xf1, 0x97, 0x33, 0x21, 0x1e, 0x22, 0x0f, 0x16, 0x11, 0x76, 0x60, 0x8d, 0x4c, 0xbf, 0xdc, 0x24,
-	0x15, 0xec, 0x60, 0x64, 0x85, 0x9e, 0x10, 0x38, 0x99, 0x47, 0x04, 0xce, 0xd4, 0x51, 0x02, 0x47,
-	0xdd, 0x80, 0xf9, 0x71, 0xc6, 0x65, 0x70, 0xfc, 0x1e, 0x4c, 0x89, 0x49, 0x89, 0x72, 0xe4, 0xa4,
-	0x79, 0x8b, 0x5c, 0xd4, 0x4f, 0x13, 0x30, 0x2f, 0xd3, 0xd7, 0xd7, 0xe3, 0x3b, 0x1e, 0xe1, 0x39,
-	0x7d, 0xa4, 0x0f, 0xf4, 0x68, 0xf3, 0xa7, 0x96, 0x61, 0xe1, 0x00, 0x8f, 0x8f, 0xf1, 0xb1, 0x7e,
-	0xa6, 0xc0, 0xf4, 0x3a, 0xed, 0x3a, 0xfd, 0x27, 0x74, 0x16, 0x46, 0xc8, 0x4d, 0x1d, 0x29, 0x88,
-	0x07, 0x50, 0x90, 0x78, 0x25, 0x5b, 0x87, 0xd9, 0x56, 0x26, 0x7d, 0x2d, 0x97, 0x60, 0x5a, 0x1e,
-	0xb3, 0x6d, 0xd7, 0xb1, 0x83, 0x18, 0xcf, 0x81, 0x73, 0xb6, 0xc6, 0x8c, 0x44, 0x9e, 0xc8, 0xb9,
-	0xa0, 0xfe, 0x58, 0x81, 0x42, 0xd9, 0xeb, 0xf5, 0x9c, 0xf0, 0x09, 0xe5, 0xf8, 0x30, 0x43, 0xa9,
-	0x49, 0xf1, 0x78, 0x0e, 0x8a, 0x11, 0x4c, 0x49, 0xed, 0x81, 0x95, 0x46, 0x39, 0xb4, 0xd2, 0xfc,
-	0x44, 0x81, 0x19, 0xe2, 0xb9, 0xee, 0x96, 0xdd, 0xde, 0x79, 0xba, 0xc9, 0x39, 0x0f, 0x68, 0x08,
-	0xf4, 0xa8, 0xf4, 0xfc, 0x52, 0x81, 0x62, 0xc3, 0xa7, 0x03, 0xdb, 0xa7, 0x4f, 0x35, 0x3b, 0x6c,
-	0x9b, 0xde, 0x09, 0xe5, 0x06, 0x27, 0x47, 0x78, 0x59, 0x9d, 0x85, 0x99, 0x18, 0xbb, 0x20, 0x4c,
-	0xfd, 0x9e, 0x02, 0x0b, 0x22, 0xc4, 0xa4, 0xa5, 0xf3, 0x84, 0xd2, 0x12, 0xe1, 0x4d, 0x8d, 0xe0,
-	0x2d, 0xc1, 0xf1, 0x83, 0xd8, 0x24, 0xec, 0x77, 0x13, 0x70, 0x22, 0x0a, 0x9e, 0x27, 0x1c, 0xf8,
-	0x97, 0x88, 0x87, 0x45, 0x28, 0x1d, 0x26, 0x41, 0x32, 0xf4, 0x41, 0x02, 0x4a, 0x65, 0x9f, 0xda,
-	0x21, 0x1d, 0xd9, 0x07, 0x3d, 0x3d, 0xb1, 0x81, 0xcf, 0xc1, 0xf4, 0xc0, 0xf6, 0x43, 0xa7, 0xed,
-	0x0c, 0x6c, 0x76, 0x14, 0x4d, 0xf3, 0x6d, 0xd6, 0x81, 0x06, 0xc6, 0x5c, 0xd4, 0x53, 0x70, 0x72,
-	0x02, 0x23, 0x92, 0xaf, 0x5f, 0x29, 0x80, 0x9b, 0xa1, 0xed, 0x87, 0x5f, 0x83, 0x75, 0x69, 0x62,
-	0x30, 0x2d, 0xc0, 0xdc, 0x18, 0xfe, 0x51, 0x5e, 0x68, 0xf8, 0xb5, 0x58, 0x92, 0x3e, 0x97, 0x97,
-	0x51, 0xfc, 0x92, 0x97, 0x1f, 0x28, 0xb0, 0x58, 0xf6, 0xc4, 0xe5, 0xe3, 0x53, 0xf9, 0x85, 0xa9,
-	0xcf, 0xc3, 0xa9, 0x89, 0x00, 0x25, 0x01, 0xdf, 0x57, 0xe0, 0x38, 0xa1, 0x76, 0xe7, 0xe9, 0x04,
-	0x7f, 0x1d, 0x4e, 0x1c, 0x02, 0x27, 0xf7, 0x28, 0x17, 0x21, 0xdb, 0xa3, 0xa1, 0xcd, 0x76, 0xb8,
-	0x12, 0xd2, 0x62, 0xd4, 0xee, 0xd0, 0xbb, 0x26, 0x3d, 0x48, 0xec, 0xab, 0xfe, 0x30, 0x01, 0x73,
-	0x7c, 0x9f, 0xfd, 0xec, 0x90, 0x77, 0xa4, 0x5b, 0x98, 0xcc, 0xc1, 0xcd, 0x1f, 0x73, 0x18, 0xf8,
-	0xd4, 0x8a, 0x6e, 0x07, 0xa6, 0xf8, 0x3f, 0x36, 0x18, 0xf8, 0xf4, 0xba, 0xd0, 0xa8, 0xdf, 0x52,
-	0x60, 0x7e, 0x9c, 0xe2, 0xf8, 0x44, 0xf3, 0x9b, 0xbe, 0x6d, 0x99, 0x90, 0x52, 0x92, 0x47, 0x39,
-	0x24, 0xa5, 0x8e, 0x7c, 0x48, 0xfa, 0x76, 0x02, 0x4a, 0xa3, 0x60, 0x9e, 0xdd, 0xe9, 0x8c, 0xdf,
-	0xe9, 0x7c, 0xd1, 0x5b, 0x3e, 0xf5, 0x3b, 0x0a, 0x9c, 0x9c, 0x40, 0xe8, 0x17, 0x0b, 0x91, 0x91,
-	0x9b, 0x9d, 0xc4, 0x23, 0x6f, 0x76, 0xbe, 0xfa, 0x20, 0xf9, 0xae, 0x02, 0xf3, 0x35, 0x71, 0x57,
-	0x2f, 0x6e, 0x3e, 0x9e, 0xdc, 0x1c, 0xcc, 0xaf, 0xe3, 0x53, 0xc3, 0x9f, 0x51, 0x6a, 0x19, 0x16,
-	0x0e, 0x40, 0x7b, 0x8c, 0xdb, 0x9c, 0x5f, 0x28, 0x30, 0x2b, 0x5b, 0xd1, 0x9e, 0xd8, 0xed, 0xcb,
-	0x04, 0x76, 0xf0, 0x0b, 0x90, 0x74, 0x3a, 0xd1, 0xbe, 0x77, 0xfc, 0x5f, 0x3b, 0x33, 0xa8, 0x97,
-	0x01, 0x8f, 0xe2, 0x7e, 0x0c, 0xea, 0x7e, 0x9a, 0x80, 0x05, 0x22, 0xb2, 0xef, 0xb3, 0xff, 0x0b,
-	0x5f, 0xf6, 0xff, 0xc2, 0xc3, 0x17, 0xae, 0x4f, 0xf9, 0x66, 0x6a, 0x9c, 0xea, 0xaf, 0x6e, 0xe9,
-	0x3a, 0xb0, 0xd0, 0x26, 0x0f, 0x2d, 0xb4, 0x8f, 0x9f, 0x8f, 0x3e, 0x4d, 0xc0, 0xa2, 0x04, 0xf2,
-	0x6c, 0xaf, 0x73, 0xf4, 0x88, 0xc8, 0x1c, 0x8a, 0x88, 0x9f, 0x2b, 0x70, 0x6a, 0x22, 0x91, 0xbf,
-	0xf5, 0x1d, 0xcd, 0x81, 0xe8, 0x49, 0x3d, 0x32, 0x7a, 0xd2, 0x47, 0x8e, 0x9e, 0xf7, 0x13, 0x50,
-	0x24, 0xd4, 0xa5, 0x76, 0xf0, 0x94, 0xdf, 0xee, 0x1d, 0xe0, 0x30, 0x7d, 0xe8, 0x9e, 0x73, 0x16,
-	0x66, 0x62, 0x22, 0xe4, 0x81, 0x8b, 0x1f, 0xd0, 0xd9, 0x3a, 0xf8, 0x36, 0xb5, 0xdd, 0x30, 0xda,
-	0x09, 0xaa, 0xff, 0x93, 0x80, 0x02, 0x61, 0x1a, 0xa7, 0x47, 0x9b, 0xa1, 0x1d, 0x06, 0xf8, 0x25,
-	0x98, 0xde, 0xe6, 0x2e, 0xd6, 0x30, 0x42, 0x72, 0x24, 0x2f, 0x74, 0xe2, 0xef, 0xe3, 0x1a, 0x2c,
-	0x04, 0xb4, 0xed, 0xf5, 0x3b, 0x81, 0xb5, 0x45, 0xb7, 0x9d, 0x7e, 0xc7, 0xea, 0xd9, 0x41, 0x48,
-	0x7d, 0x4e, 0x4b, 0x81, 0xcc, 0x49, 0xe3, 0x3a, 0xb7, 0xd5, 0xb8, 0x09, 0x9f, 0x85, 0xf9, 0x2d,
-	0xa7, 0xef, 0x7a, 0x5d, 0x6b, 0xe0, 0xda, 0xfb, 0xd4, 0x0f, 0xac, 0xb6, 0xb7, 0xdb, 0x17, 0x7c,
-	0xa4, 0x09, 0x16, 0xb6, 0x86, 0x30, 0x95, 0x99, 0x05, 0xdf, 0x86, 0x95, 0x89, 0xbd, 0x58, 0x77,
-	0x1c, 0x37, 0xa4, 0x3e, 0xed, 0x58, 0x3e, 0x1d, 0xb8, 0x4e, 0x5b, 0xbc, 0x23, 0x12, 0x44, 0xbd,
-	0x3a, 0xa1, 0xeb, 0x4d, 0xe9, 0x4e, 0x86, 0xde, 0xf8, 0x14, 0xe4, 0xda, 0x83, 0x5d, 0x6b, 0x97,
-	0x3f, 0x5a, 0x60, 0xfc, 0x29, 0x24, 0xdb, 0x1e, 0xec, 0xb6, 0x98, 0x8c, 0x11, 0x24, 0xef, 0x0e,
-	0x44, 0x72, 0x56, 0x08, 0x2b, 0xaa, 0x9f, 0x29, 0x50, 0xd4, 0xba, 0x5d, 0x9f, 0x76, 0xed, 0x50,
-	0xd2, 0x74, 0x16, 0xe6, 0x05, 0x25, 0xfb, 0x96, 0x0c, 0x57, 0x81, 0x47, 0x11, 0x78, 0xa4, 0x4d,
-	0xc4, 0xaa, 0xc0, 0x73, 0x01, 0x8e, 0xef, 0xf6, 0x27, 0xd6, 0x49, 0xf0, 0x3a, 0xf3, 0xb1, 0x75,
-	0xb4, 0xd6, 0x1f, 0xc2, 0xc9, 0xc9, 0x2c, 0xf4, 0x1c, 0xf1, 0x96, 0xaf, 0x40, 0x8e, 0x4f, 0x00,
-	0x5d, 0x73, 0xfa, 0x0f, 0xa9, 0x6a, 0xdf, 0xe7, 0x7c, 0x7d, 0x4e, 0x55, 0xfb, 0xbe, 0xfa, 0x7f,
-	0xf1, 0x3f, 0xc5, 0x28, 0x5c, 0xe2, 0xc4, 0x11, 0x05, 0xb2, 0xf2, 0xb0, 0x40, 0x2e, 0xc1, 0x14,
-	0x0b, 0x46, 0xa7, 0xdf, 0xe5, 0xe0, 0xb2, 0x24, 0x12, 0x71, 0x13, 0x5e, 0x95, 0xd8, 0xe9, 0xfd,
-	0x90, 0xfa, 0x7d, 0xdb, 0x75, 0xf7, 0x2d, 0x71, 0xfd, 0xd8, 0x0f, 0x69, 0xc7, 0x1a, 0xbe, 0x6d,
-	0x14, 0xe9, 0xe3, 0x65, 0xe1, 0xad, 0xc7, 0xce, 0x24, 0xf6, 0x35, 0xe3, 0x57, 0x8f, 0x6f, 0x42,
-	0xd1, 0x97, 0x41, 0x6c, 0x05, 0x6c, 0x7a, 0x64, 0xca, 0x9d, 0x8f, 0x5e, 0x4d, 0x8c, 0x46, 0x38,
-	0x29, 0xf8, 0x63, 0x01, 0xff, 0xd8, 0x09, 0xe7, 0x6a, 0x2a, 0x9b, 0x41, 0x53, 0xea, 0xff, 0x2b,
-	0x30, 0x37, 0xe1, 0xec, 0x1e, 0x5f, 0x0c, 0x28, 0x23, 0xf7, 0x8e, 0xbf, 0x0f, 0x69, 0xfe, 0xa0,
-	0x45, 0x3e, 0x91, 0x3a, 0x71, 0xf8, 0xe8, 0xcf, 0x1f, 0x9f, 0x10, 0xe1, 0xc5, 0xbe, 0x45, 0x8e,
-	0xa9, 0xcd, 0x2f, 0x1e, 0xa3, 0x8c, 0x9a, 0x67, 0x3a, 0x71, 0x17, 0x79, 0xf8, 0x26, 0x33, 0xf5,
-	0xc8, 0x9b, 0xcc, 0x95, 0x7f, 0x4e, 0x42, 0xae, 0xb6, 0xdf, 0xbc, 0xeb, 0x6e, 0xba, 0x76, 0x97,
-	0xbf, 0x0e, 0xa9, 0x35, 0xcc, 0x5b, 0xe8, 0x18, 0x9e, 0x85, 0x82, 0x51, 0x37, 0x2d, 0xa3, 0x55,
-	0xad, 0x5a, 0x9b, 0x55, 0xed, 0x0a, 0x52, 0x30, 0x82, 0xe9, 0x06, 0xa9, 0x58, 0xd7, 0xf4, 0x5b,
-	0x42, 0x93, 0xc0, 0x73, 0x30, 0xd3, 0x32, 0x2a, 0xd7, 0x5b, 0xfa, 0x50, 0x99, 0xc2, 0x0b, 0x30,
-	0x5b, 0x6b, 0x55, 0xcd, 0x4a, 0xa3, 0x3a, 0xa2, 0xce, 0xe2, 0x02, 0xe4, 0xd6, 0xab, 0xf5, 0x75,
-	0x21, 0x22, 0xd6, 0x7e, 0xcb, 0x68, 0x56, 0xae, 0x18, 0xfa, 0x86, 0x50, 0x2d, 0x31, 0xd5, 0x6d,
-	0x9d, 0xd4, 0x37, 0x2b, 0x51, 0x97, 0x97, 0x31, 0x82, 0xfc, 0x7a, 0xc5, 0xd0, 0x88, 0x6c, 0xe5,
-	0x81, 0x82, 0x8b, 0x90, 0xd3, 0x8d, 0x56, 0x4d, 0xca, 0x09, 0x5c, 0x82, 0x39, 0xad, 0x65, 0xd6,
-	0xad, 0x8a, 0x51, 0x26, 0x7a, 0x4d, 0x37, 0x4c, 0x69, 0x49, 0xe1, 0x39, 0x28, 0x9a, 0x95, 0x9a,
-	0xde, 0x34, 0xb5, 0x5a, 0x43, 0x2a, 0xd9, 0x28, 0xb2, 0x4d, 0x3d, 0xf2, 0x41, 0x78, 0x11, 0x16,
-	0x8c, 0xba, 0x25, 0x5f, 0xda, 0x59, 0x37, 0xb4, 0x6a, 0x4b, 0x97, 0xb6, 0x25, 0x7c, 0x02, 0x70,
-	0xdd, 0xb0, 0x5a, 0x8d, 0x0d, 0xcd, 0xd4, 0x2d, 0xa3, 0x7e, 0x53, 0x1a, 0x2e, 0xe3, 0x22, 0x64,
-	0x87, 0x23, 0x78, 0xc0, 0x58, 0x28, 0x34, 0x34, 0x62, 0x0e, 0xc1, 0x3e, 0x78, 0xc0, 0xc8, 0x82,
-	0x2b, 0xa4, 0xde, 0x6a, 0x0c, 0xdd, 0x66, 0x21, 0x2f, 0xc9, 0x92, 0xaa, 0x14, 0x53, 0xad, 0x57,
-	0x8c, 0x72, 0x3c, 0xbe, 0x07, 0xd9, 0xc5, 0x04, 0x52, 0x56, 0x76, 0x20, 0xc5, 0xa7, 0x23, 0x0b,
-	0x29, 0xa3, 0x6e, 0xe8, 0xe8, 0x18, 0x9e, 0x01, 0xa8, 0x34, 0x2b, 0x86, 0xa9, 0x5f, 0x21, 0x5a,
-	0x95, 0xc1, 0xe6, 0x8a, 0x88, 0x40, 0x86, 0x76, 0x1a, 0xa6, 0x2a, 0xcd, 0xcd, 0x6a, 0x5d, 0x33,
-	0x25, 0xcc, 0x4a, 0xf3, 0x7a, 0xab, 0x6e, 0x32, 0x23, 0xc2, 0x79, 0xc8, 0x54, 0x9a, 0xa6, 0xfe,
-	0x8e, 0xc9, 0x70, 0x71, 0x9b, 0x60, 0x15, 0x3d, 0xb8, 0xbc, 0xf2, 0x51, 0x12, 0x52, 0xfc, 0xd1,
-	0x72, 0x01, 0x72, 0x7c, 0xb6, 0xcd, 0x5b, 0x0d, 0xd6, 0x65, 0x0e, 0x52, 0x15, 0xc3, 0xbc, 0x84,
-	0xfe, 0x2c, 0x81, 0x01, 0xd2, 0x2d, 0x5e, 0xfe, 0xf3, 0x0c, 0x2b, 0x57, 0x0c, 0xf3, 0xdc, 0x45,
-	0xf4, 0x6e, 0x82, 0x35, 0xdb, 0x12, 0xc2, 0x5f, 0x44, 0x86, 0xb5, 0x0b, 0xe8, 0xbd, 0xd8, 0xb0,
-	0x76, 0x01, 0xfd, 0x65, 0x64, 0x38, 0xbf, 0x86, 0xde, 0x8f, 0x0d, 0xe7, 0xd7, 0xd0, 0x5f, 0x45,
-	0x86, 0x8b, 0x17, 0xd0, 0x5f, 0xc7, 0x86, 0x8b, 0x17, 0xd0, 0xdf, 0x64, 0x18, 0x16, 0x8e, 0xe4,
-	0xfc, 0x1a, 0xfa, 0xdb, 0x6c, 0x2c, 0x5d, 0xbc, 0x80, 0xfe, 0x2e, 0xcb, 0xe6, 0x3f, 0x9e, 0x55,
-	0xf4, 0xf7, 0x88, 0x0d, 0x93, 0x4d, 0x10, 0xfa, 0x07, 0x5e, 0x64, 0x26, 0xf4, 0x8f, 0x88, 0x61,
-	0x64, 0x5a, 0x2e, 0x7e, 0xc0, 0x2d, 0xb7, 0x74, 0x8d, 0xa0, 0x7f, 0xca, 0x88, 0x87, 0x95, 0xe5,
-	0x4a, 0x4d, 0xab, 0x22, 0xcc, 0x6b, 0x30, 0x56, 0xfe, 0xe5, 0x2c, 0x2b, 0xb2, 0xf0, 0x44, 0xff,
-	0xda, 0x60, 0x1d, 0xde, 0xd0, 0x48, 0xf9, 0x6d, 0x8d, 0xa0, 0x7f, 0x3b, 0xcb, 0x3a, 0xbc, 0xa1,
-	0x11, 0xc9, 0xd7, 0xbf, 0x37, 0x98, 0x23, 0x37, 0x7d, 0x78, 0x96, 0x0d, 0x5a, 0xea, 0xff, 0xa3,
-	0x81, 0xb3, 0x90, 0x5c, 0xaf, 0x98, 0xe8, 0x23, 0xde, 0x1b, 0x0b, 0x51, 0xf4, 0x9f, 0x88, 0x29,
-	0x9b, 0xba, 0x89, 0x3e, 0x66, 0xca, 0xb4, 0xd9, 0x6a, 0x54, 0x75, 0xf4, 0x1c, 0x1b, 0xdc, 0x15,
-	0xbd, 0x5e, 0xd3, 0x4d, 0x72, 0x0b, 0xfd, 0x17, 0x77, 0xbf, 0xda, 0xac, 0x1b, 0xe8, 0x13, 0x84,
-	0x8b, 0x00, 0xfa, 0x3b, 0x0d, 0xa2, 0x37, 0x9b, 0x95, 0xba, 0x81, 0x5e, 0x5c, 0xd9, 0x04, 0x74,
-	0x30, 0x1d, 0x30, 0x00, 0x2d, 0xe3, 0x9a, 0x51, 0xbf, 0x69, 0xa0, 0x63, 0x4c, 0x68, 0x10, 0xbd,
-	0xa1, 0x11, 0x1d, 0x29, 0x18, 0x20, 0x23, 0x9f, 0x6b, 0x26, 0xf0, 0x34, 0x64, 0x49, 0xbd, 0x5a,
-	0x5d, 0xd7, 0xca, 0xd7, 0x50, 0x72, 0xfd, 0x75, 0x98, 0x71, 0xbc, 0xd5, 0x3d, 0x27, 0xa4, 0x41,
-	0x20, 0x9e, 0xc5, 0xdf, 0x56, 0xa5, 0xe4, 0x78, 0x67, 0x44, 0xe9, 0x4c, 0xd7, 0x3b, 0xb3, 0x17,
-	0x9e, 0xe1, 0xd6, 0x33, 0x3c, 0x63, 0x6c, 0x65, 0xb8, 0x70, 0xfe, 0xd7, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0xc2, 0xa3, 0x7f, 0x77, 0x74, 0x2f, 0x00, 0x00,
+	// 3170 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4b, 0x70, 0x1b, 0xc7,
+	0x99, 0xd6, 0xe0, 0x45, 0xe0, 0x07, 0x01, 0x36, 0x9b, 0xa4, 0x04, 0x51, 0x7e, 0xd0, 0xb0, 0x65,
+	0x73, 0xb9, 0xbb, 0x94, 0x44, 0xc9, 0x5a, 0xad, 0x1f, 0xbb, 0x1a, 0x82, 0x43, 0x19, 0x12, 0x30,
+	0x80, 0x1a, 0x03, 0xc9, 0x52, 0x6d, 0xd5, 0xd4, 0x10, 0x68, 0x81, 0x53, 0x1c, 0x60, 0xc0, 0x99,
+	0x01, 0x25, 0xde, 0xb4, 0xeb, 0xf5, 0x7a, 0xdf, 0xeb, 0x7d, 0x7a, 0x1d, 0x57, 0x5c, 0xa9, 0xca,
+	0x21, 0x95, 0x4b, 0xce, 0x39, 0xe7, 0xe0, 0x43, 0x0e, 0xa9, 0xca, 0x31, 0xc9, 0x21, 0xc9, 0x21,
+	0x95, 0x9c, 0x52, 0xa9, 0x1c, 0x72, 0xc8, 0x21, 0x95, 0xea, 0xc7, 0x0c, 0x00, 0x12, 0x96, 0x68,
+	0x39, 0xae, 0x94, 0x64, 0xdd, 0xfa, 0x7f, 0xf4, 0xe3, 0xff, 0xfa, 0x9f, 0xff, 0xef, 0xe9, 0xfe,
+	0x21, 0xbb, 0x3b, 0xa0, 0xde, 0xfe, 0x6a, 0xdf, 0x73, 0x03, 0x17, 0x27, 0x39, 0xb1, 0x98, 0x0f,
+	0xdc, 0xbe, 0xdb, 0xb6, 0x02, 0x4b, 0xb0, 0x17, 0xb3, 0x7b, 0x81, 0xd7, 0x6f, 0x09, 0xa2, 0xf8,
+	0xae, 0x02, 0x29, 0xc3, 0xf2, 0x3a, 0x34, 0xc0, 0x8b, 0x90, 0xde, 0xa1, 0xfb, 0x7e, 0xdf, 0x6a,
+	0xd1, 0x82, 0xb2, 0xa4, 0x2c, 0x67, 0x48, 0x44, 0xe3, 0x79, 0x48, 0xfa, 0xdb, 0x96, 0xd7, 0x2e,
+	0xc4, 0xb8, 0x40, 0x10, 0xf8, 0x55, 0xc8, 0x06, 0xd6, 0x96, 0x43, 0x03, 0x33, 0xd8, 0xef, 0xd3,
+	0x42, 0x7c, 0x49, 0x59, 0xce, 0xaf, 0xcd, 0xaf, 0x46, 0xf3, 0x19, 0x5c, 0x68, 0xec, 0xf7, 0x29,
+	0x81, 0x20, 0x6a, 0x63, 0x0c, 0x89, 0x16, 0x75, 0x9c, 0x42, 0x82, 0x8f, 0xc5, 0xdb, 0xc5, 0xef,
+	0x2a, 0x90, 0xbf, 0x61, 0x5c, 0xb1, 0x02, 0x5a, 0xb2, 0x1c, 0x87, 0x7a, 0xe5, 0x0d, 0xb6, 0x9e,
+	0x81, 0x4f, 0xbd, 0x9e, 0xd5, 0x8d, 0xd6, 0x13, 0xd2, 0xf8, 0x38, 0xa4, 0x3a, 0x9e, 0x3b, 0xe8,
+	0xfb, 0x85, 0xd8, 0x52, 0x7c, 0x39, 0x43, 0x24, 0x85, 0x35, 0x00, 0x2b, 0x08, 0x3c, 0x7b, 0x6b,
+	0x10, 0x50, 0xbf, 0x10, 0x5f, 0x8a, 0x2f, 0x67, 0xd7, 0x4e, 0xaf, 0x0a, 0x50, 0xc6, 0x87, 0x5f,
+	0x55, 0x23, 0x3d, 0xad, 0x17, 0x78, 0xfb, 0x64, 0xa4, 0xe3, 0xe2, 0x9b, 0x30, 0x73, 0x40, 0x8c,
+	0x11, 0xc4, 0x77, 0xe8, 0xbe, 0x5c, 0x08, 0x6b, 0x32, 0x4c, 0xf6, 0x2c, 0x67, 0x40, 0x43, 0x4c,
+	0x38, 0xf1, 0x5a, 0xec, 0x92, 0x52, 0xfc, 0x1b, 0x00, 0x6d, 0x8f, 0xf6, 0x02, 0xc3, 0xdd, 0xa1,
+	0x3d, 0xfc, 0x0c, 0x64, 0x02, 0xbb, 0x4b, 0xfd, 0xc0, 0xea, 0xf6, 0x79, 0xff, 0x38, 0x19, 0x32,
+	0x3e, 0x05, 0xd9, 0x45, 0x48, 0xf7, 0x5d, 0xdf, 0x0e, 0x6c, 0xb7, 0xc7, 0x61, 0xcd, 0x90, 0x88,
+	0x2e, 0xfe, 0x15, 0x24, 0x6f, 0xb0, 0xa9, 0xf0, 0xf3, 0x90, 0xe0, 0xb8, 0x2b, 0x1c, 0xf7, 0xac,
+	0x34, 0x93, 0xc3, 0xcd, 0x05, 0xe3, 0x2b, 0x9c, 0x96, 0x2b, 0x2c, 0xee, 0xc0, 0xf4, 0xba, 0xdd,
+	0x6b, 0xdf, 0xb0, 0x3c, 0x9b, 0xed, 0xc9, 0x23, 0x0e, 0x83, 0x5f, 0x82, 0x14, 0x6f, 0x84, 0x30,
+	0x4f, 0x87, 0x30, 0x33, 0x26, 0x91, 0xb2, 0xe2, 0x77, 0x14, 0x80, 0x75, 0x77, 0xd0, 0x6b, 0x5f,
+	0x1f, 0x50, 0x81, 0xa2, 0xbf, 0xeb, 0x84, 0x28, 0xfa, 0xbb, 0x0e, 0xbe, 0x06, 0xf9, 0x2d, 0xbb,
+	0xd7, 0x36, 0xf7, 0xe4, 0x72, 0xc4, 0x8e, 0x66, 0xd7, 0x5e, 0x92, 0xc3, 0x0d, 0x3b, 0xaf, 0x8e,
+	0xae, 0x5a, 0x6e, 0x5a, 0x6e, 0x6b, 0x94, 0xb7, 0xd8, 0x04, 0x7c, 0x58, 0x69, 0xc2, 0xd6, 0xfd,
+	0xc9, 0xa8, 0x45, 0xd9, 0xb5, 0xb9, 0x70, 0xae, 0x91, 0xbe, 0xa3, 0xfb, 0xf9, 0xed, 0x24, 0xe4,
+	0xb5, 0x7b, 0xb4, 0x35, 0x08, 0x68, 0xad, 0xcf, 0xf6, 0xc0, 0xc7, 0x55, 0x98, 0xb1, 0x7b, 0x2d,
+	0x67, 0xd0, 0xa6, 0x6d, 0xf3, 0x8e, 0x4d, 0x9d, 0xb6, 0xcf, 0xdd, 0x39, 0x1f, 0xad, 0x7b, 0x5c,
+	0x7f, 0xb5, 0x2c, 0x95, 0x37, 0xb9, 0x2e, 0xc9, 0xdb, 0x63, 0x34, 0x5e, 0x81, 0xd9, 0x96, 0x63,
+	0xd3, 0x5e, 0x60, 0xde, 0x61, 0xf6, 0x9a, 0x9e, 0x7b, 0xd7, 0x2f, 0x24, 0x97, 0x94, 0xe5, 0x34,
+	0x99, 0x11, 0x82, 0x4d, 0xc6, 0x27, 0xee, 0x5d, 0x1f, 0xbf, 0x06, 0xe9, 0xbb, 0xae, 0xb7, 0xe3,
+	0xb8, 0x56, 0xbb, 0x90, 0xe2, 0x73, 0x3e, 0x37, 0x79, 0xce, 0x9b, 0x52, 0x8b, 0x44, 0xfa, 0x78,
+	0x19, 0x90, 0xbf, 0xeb, 0x98, 0x3e, 0x75, 0x68, 0x2b, 0x30, 0x1d, 0xbb, 0x6b, 0x07, 0x85, 0x34,
+	0x77, 0xc9, 0xbc, 0xbf, 0xeb, 0x34, 0x38, 0xbb, 0xc2, 0xb8, 0xd8, 0x84, 0x85, 0xc0, 0xb3, 0x7a,
+	0xbe, 0xd5, 0x62, 0x83, 0x99, 0xb6, 0xef, 0x3a, 0x16, 0x6b, 0x15, 0x32, 0x7c, 0xca, 0x95, 0xc9,
+	0x53, 0x1a, 0xc3, 0x2e, 0xe5, 0xb0, 0x07, 0x99, 0x0f, 0x26, 0x70, 0xf1, 0x39, 0x58, 0xf0, 0x77,
+	0xec, 0xbe, 0xc9, 0xc7, 0x31, 0xfb, 0x8e, 0xd5, 0x33, 0x5b, 0x56, 0x6b, 0x9b, 0x16, 0x80, 0x9b,
+	0x8d, 0x99, 0x90, 0xef, 0x7b, 0xdd, 0xb1, 0x7a, 0x25, 0x26, 0x29, 0xbe, 0x0e, 0xf9, 0x71, 0x1c,
+	0xf1, 0x2c, 0xe4, 0x8c, 0x5b, 0x75, 0xcd, 0x54, 0xf5, 0x0d, 0x53, 0x57, 0xab, 0x1a, 0x3a, 0x86,
+	0x73, 0x90, 0xe1, 0xac, 0x9a, 0x5e, 0xb9, 0x85, 0x14, 0x3c, 0x05, 0x71, 0xb5, 0x52, 0x41, 0xb1,
+	0xe2, 0x25, 0x48, 0x87, 0x80, 0xe0, 0x19, 0xc8, 0x36, 0xf5, 0x46, 0x5d, 0x2b, 0x95, 0x37, 0xcb,
+	0xda, 0x06, 0x3a, 0x86, 0xd3, 0x90, 0xa8, 0x55, 0x8c, 0x3a, 0x52, 0x44, 0x4b, 0xad, 0xa3, 0x18,
+	0xeb, 0xb9, 0xb1, 0xae, 0xa2, 0x78, 0xf1, 0x1b, 0x0a, 0xcc, 0x4f, 0x32, 0x0c, 0x67, 0x61, 0x6a,
+	0x43, 0xdb, 0x54, 0x9b, 0x15, 0x03, 0x1d, 0xc3, 0x73, 0x30, 0x43, 0xb4, 0xba, 0xa6, 0x1a, 0xea,
+	0x7a, 0x45, 0x33, 0x89, 0xa6, 0x6e, 0x20, 0x05, 0x63, 0xc8, 0xb3, 0x96, 0x59, 0xaa, 0x55, 0xab,
+	0x65, 0xc3, 0xd0, 0x36, 0x50, 0x0c, 0xcf, 0x03, 0xe2, 0xbc, 0xa6, 0x3e, 0xe4, 0xc6, 0x31, 0x82,
+	0xe9, 0x86, 0x46, 0xca, 0x6a, 0xa5, 0x7c, 0x9b, 0x0d, 0x80, 0x12, 0xf8, 0x05, 0x78, 0xb6, 0x54,
+	0xd3, 0x1b, 0xe5, 0x86, 0xa1, 0xe9, 0x86, 0xd9, 0xd0, 0xd5, 0x7a, 0xe3, 0xad, 0x9a, 0xc1, 0x47,
+	0x16, 0xc6, 0x25, 0x71, 0x1e, 0x40, 0x6d, 0x1a, 0x35, 0x31, 0x0e, 0x4a, 0x5d, 0x4d, 0xa4, 0x15,
+	0x14, 0xbb, 0x9a, 0x48, 0xc7, 0x50, 0xfc, 0x6a, 0x22, 0x1d, 0x47, 0x89, 0xe2, 0x07, 0x31, 0x48,
+	0x72, 0xac, 0x58, 0xd4, 0x1d, 0x09, 0xa5, 0xbc, 0x1d, 0x7d, 0xfa, 0xb1, 0x07, 0x7c, 0xfa, 0x3c,
+	0x70, 0xcb, 0x20, 0x24, 0x08, 0x7c, 0x0a, 0x32, 0xae, 0xd7, 0x31, 0x85, 0x44, 0x44, 0xf1, 0xb4,
+	0xeb, 0x75, 0x78, 0xb8, 0x67, 0xa1, 0x8b, 0x05, 0xff, 0x2d, 0xcb, 0xa7, 0xdc, 0x83, 0x33, 0x24,
+	0xa2, 0xf1, 0x49, 0x60, 0x7a, 0x26, 0x5f, 0x47, 0x8a, 0xcb, 0xa6, 0x5c, 0xaf, 0xa3, 0xb3, 0xa5,
+	0xbc, 0x08, 0xb9, 0x96, 0xeb, 0x0c, 0xba, 0x3d, 0xd3, 0xa1, 0xbd, 0x4e, 0xb0, 0x5d, 0x98, 0x5a,
+	0x52, 0x96, 0x73, 0x64, 0x5a, 0x30, 0x2b, 0x9c, 0x87, 0x0b, 0x30, 0xd5, 0xda, 0xb6, 0x3c, 0x9f,
+	0x0a, 0xaf, 0xcd, 0x91, 0x90, 0xe4, 0xb3, 0xd2, 0x96, 0xdd, 0xb5, 0x1c, 0x9f, 0x7b, 0x68, 0x8e,
+	0x44, 0x34, 0x33, 0xe2, 0x8e, 0x63, 0x75, 0x7c, 0xee, 0x59, 0x39, 0x22, 0x88, 0xe2, 0x5f, 0x40,
+	0x9c, 0xb8, 0x77, 0xd9, 0x90, 0x62, 0x42, 0xbf, 0xa0, 0x2c, 0xc5, 0x97, 0x31, 0x09, 0x49, 0x96,
+	0x63, 0x64, 0x80, 0x13, 0x71, 0x2f, 0x0c, 0x69, 0x1f, 0x29, 0x90, 0xe5, 0x8e, 0x49, 0xa8, 0x3f,
+	0x70, 0x02, 0x16, 0x08, 0x65, 0x04, 0x50, 0xc6, 0x02, 0x21, 0x87, 0x9d, 0x48, 0x19, 0xb3, 0x8f,
+	0x7d, 0xd4, 0xa6, 0x75, 0xe7, 0x0e, 0x6d, 0x05, 0x54, 0xc4, 0xfb, 0x04, 0x99, 0x66, 0x4c, 0x55,
+	0xf2, 0x18, 0xb0, 0x76, 0xcf, 0xa7, 0x5e, 0x60, 0xda, 0x6d, 0x0e, 0x79, 0x82, 0xa4, 0x05, 0xa3,
+	0xdc, 0xc6, 0xcf, 0x41, 0x82, 0x87, 0x85, 0x04, 0x9f, 0x05, 0xe4, 0x2c, 0xc4, 0xbd, 0x4b, 0x38,
+	0xff, 0x6a, 0x22, 0x9d, 0x44, 0xa9, 0xe2, 0x1b, 0x30, 0xcd, 0x17, 0x77, 0xd3, 0xf2, 0x7a, 0x76,
+	0xaf, 0xc3, 0x93, 0xad, 0xdb, 0x16, 0xdb, 0x9e, 0x23, 0xbc, 0xcd, 0x6c, 0xee, 0x52, 0xdf, 0xb7,
+	0x3a, 0x61, 0xee, 0x0a, 0xc9, 0xe2, 0xd7, 0xe2, 0x90, 0x6d, 0x04, 0x1e, 0xb5, 0xba, 0x3c, 0x81,
+	0xe1, 0x37, 0x00, 0xfc, 0xc0, 0x0a, 0x68, 0x97, 0xf6, 0x82, 0xd0, 0xbe, 0x67, 0xe4, 0xcc, 0x23,
+	0x7a, 0xab, 0x8d, 0x50, 0x89, 0x8c, 0xe8, 0xe3, 0x35, 0xc8, 0x52, 0x26, 0x36, 0x03, 0x96, 0x08,
+	0x65, 0xb0, 0x9d, 0x0d, 0x23, 0x47, 0x94, 0x21, 0x09, 0xd0, 0xa8, 0xbd, 0xf8, 0x71, 0x0c, 0x32,
+	0xd1, 0x68, 0x58, 0x85, 0x74, 0xcb, 0x0a, 0x68, 0xc7, 0xf5, 0xf6, 0x65, 0x7e, 0x3a, 0xfd, 0xa0,
+	0xd9, 0x57, 0x4b, 0x52, 0x99, 0x44, 0xdd, 0xf0, 0xb3, 0x20, 0xce, 0x1e, 0xc2, 0xeb, 0x84, 0xbd,
+	0x19, 0xce, 0xe1, 0x7e, 0xf7, 0x1a, 0xe0, 0xbe, 0x67, 0x77, 0x2d, 0x6f, 0xdf, 0xdc, 0xa1, 0xfb,
+	0x61, 0x2c, 0x8f, 0x4f, 0xd8, 0x49, 0x24, 0xf5, 0xae, 0xd1, 0x7d, 0x19, 0x7d, 0x2e, 0x8d, 0xf7,
+	0x95, 0xde, 0x72, 0x78, 0x7f, 0x46, 0x7a, 0xf2, 0xec, 0xe8, 0x87, 0x79, 0x30, 0xc9, 0x1d, 0x8b,
+	0x35, 0x8b, 0xaf, 0x40, 0x3a, 0x5c, 0x3c, 0xce, 0x40, 0x52, 0xf3, 0x3c, 0xd7, 0x43, 0xc7, 0x78,
+	0x10, 0xaa, 0x56, 0x44, 0x1c, 0xdb, 0xd8, 0x60, 0x71, 0xec, 0xa7, 0xb1, 0x28, 0x19, 0x11, 0xba,
+	0x3b, 0xa0, 0x7e, 0x80, 0xff, 0x1a, 0xe6, 0x28, 0x77, 0x21, 0x7b, 0x8f, 0x9a, 0x2d, 0x7e, 0xc0,
+	0x61, 0x0e, 0xa4, 0x70, 0xbc, 0x67, 0x56, 0xc5, 0x79, 0x2f, 0x3c, 0xf8, 0x90, 0xd9, 0x48, 0x57,
+	0xb2, 0xda, 0x58, 0x83, 0x39, 0xbb, 0xdb, 0xa5, 0x6d, 0xdb, 0x0a, 0x46, 0x07, 0x10, 0x1b, 0xb6,
+	0x30, 0xf1, 0xfc, 0x44, 0x66, 0xa3, 0x1e, 0xd1, 0x30, 0xa7, 0x21, 0x15, 0xf0, 0xb3, 0x24, 0xf7,
+	0xdd, 0xec, 0x5a, 0x2e, 0x0c, 0x28, 0x9c, 0x49, 0xa4, 0x10, 0xbf, 0x02, 0xe2, 0x64, 0xca, 0x43,
+	0xc7, 0xd0, 0x21, 0x86, 0x99, 0x9e, 0x08, 0x39, 0x3e, 0x0d, 0xf9, 0xb1, 0x1c, 0xd4, 0xe6, 0x80,
+	0xc5, 0x49, 0x6e, 0x84, 0x5b, 0x6e, 0xe3, 0x33, 0x30, 0xe5, 0x8a, 0xfc, 0x53, 0x48, 0x8d, 0xad,
+	0x78, 0x3c, 0x39, 0x91, 0x50, 0x0b, 0x3f, 0x0f, 0x59, 0x8f, 0xfa, 0xd4, 0xdb, 0xa3, 0x6d, 0x36,
+	0xe8, 0x14, 0x1f, 0x14, 0x42, 0x56, 0xb9, 0x5d, 0x7c, 0x13, 0x66, 0x22, 0x88, 0xfd, 0xbe, 0xdb,
+	0xf3, 0x29, 0x5e, 0x81, 0x94, 0xc7, 0xbf, 0x77, 0x09, 0x2b, 0x96, 0x73, 0x8c, 0x44, 0x02, 0x22,
+	0x35, 0x8a, 0x6d, 0x98, 0x11, 0x9c, 0x9b, 0x76, 0xb0, 0xcd, 0x77, 0x12, 0x9f, 0x86, 0x24, 0x65,
+	0x8d, 0x03, 0x9b, 0x42, 0xea, 0x25, 0x2e, 0x27, 0x42, 0x3a, 0x32, 0x4b, 0xec, 0xa1, 0xb3, 0xfc,
+	0x2a, 0x06, 0x73, 0x72, 0x95, 0xeb, 0x56, 0xd0, 0xda, 0x7e, 0x4c, 0xbd, 0xe1, 0x4f, 0x61, 0x8a,
+	0xf1, 0xed, 0xe8, 0xcb, 0x99, 0xe0, 0x0f, 0xa1, 0x06, 0xf3, 0x08, 0xcb, 0x37, 0x47, 0xb6, 0x5f,
+	0x1e, 0x92, 0x72, 0x96, 0x3f, 0x92, 0xa1, 0x27, 0x38, 0x4e, 0xea, 0x21, 0x8e, 0x33, 0x75, 0x14,
+	0xc7, 0x29, 0x6e, 0xc0, 0xfc, 0x38, 0xe2, 0xd2, 0x39, 0xfe, 0x0c, 0xa6, 0xc4, 0xa6, 0x84, 0x31,
+	0x72, 0xd2, 0xbe, 0x85, 0x2a, 0xc5, 0x4f, 0x62, 0x30, 0x2f, 0xc3, 0xd7, 0x97, 0xe3, 0x3b, 0x1e,
+	0xc1, 0x39, 0x79, 0xa4, 0x0f, 0xf4, 0x68, 0xfb, 0x57, 0x2c, 0xc1, 0xc2, 0x01, 0x1c, 0x1f, 0xe1,
+	0x63, 0xfd, 0xa5, 0x02, 0xd3, 0xeb, 0xb4, 0x63, 0xf7, 0x1e, 0xd3, 0x5d, 0x18, 0x01, 0x37, 0x71,
+	0x24, 0x27, 0xee, 0x43, 0x4e, 0xda, 0x2b, 0xd1, 0x3a, 0x8c, 0xb6, 0x32, 0xe9, 0x6b, 0xb9, 0x04,
+	0xd3, 0xf2, 0x6f, 0xdf, 0x72, 0x6c, 0xcb, 0x8f, 0xec, 0x39, 0xf0, 0xbb, 0xaf, 0x32, 0x21, 0xc9,
+	0x06, 0x43, 0xa2, 0xf8, 0x33, 0x05, 0x72, 0x25, 0xb7, 0xdb, 0xb5, 0x83, 0xc7, 0x14, 0xe3, 0xc3,
+	0x08, 0x25, 0x26, 0xf9, 0xe3, 0x39, 0xc8, 0x87, 0x66, 0x4a, 0x68, 0x0f, 0x64, 0x1a, 0xe5, 0x50,
+	0xa6, 0xf9, 0xb9, 0x02, 0x33, 0xc4, 0x75, 0x9c, 0x2d, 0xab, 0xb5, 0xf3, 0x64, 0x83, 0x73, 0x1e,
+	0xd0, 0xd0, 0xd0, 0xa3, 0xc2, 0xf3, 0x5b, 0x05, 0xf2, 0x75, 0x8f, 0xf6, 0x2d, 0x8f, 0x3e, 0xd1,
+	0xe8, 0xb0, 0x63, 0x7a, 0x3b, 0x90, 0x07, 0x9c, 0x0c, 0xe1, 0xed, 0xe2, 0x2c, 0xcc, 0x44, 0xb6,
+	0x0b, 0xc0, 0x8a, 0x3f, 0x54, 0x60, 0x41, 0xb8, 0x98, 0x94, 0xb4, 0x1f, 0x53, 0x58, 0x42, 0x7b,
+	0x13, 0x23, 0xf6, 0x16, 0xe0, 0xf8, 0x41, 0xdb, 0xa4, 0xd9, 0xef, 0xc4, 0xe0, 0x44, 0xe8, 0x3c,
+	0x8f, 0xb9, 0xe1, 0x9f, 0xc3, 0x1f, 0x16, 0xa1, 0x70, 0x18, 0x04, 0x89, 0xd0, 0xfb, 0x31, 0x28,
+	0x94, 0x3c, 0x6a, 0x05, 0x74, 0xe4, 0x1c, 0xf4, 0xe4, 0xf8, 0x06, 0x3e, 0x07, 0xd3, 0x7d, 0xcb,
+	0x0b, 0xec, 0x96, 0xdd, 0xb7, 0xd8, 0xaf, 0x68, 0x72, 0x29, 0x7e, 0x78, 0x80, 0x31, 0x95, 0xe2,
+	0x29, 0x38, 0x39, 0x01, 0x11, 0x89, 0xd7, 0xef, 0x14, 0xc0, 0x8d, 0xc0, 0xf2, 0x82, 0x2f, 0x41,
+	0x5e, 0x9a, 0xe8, 0x4c, 0x0b, 0x30, 0x37, 0x66, 0xff, 0x28, 0x2e, 0x34, 0xf8, 0x52, 0xa4, 0xa4,
+	0x4f, 0xc5, 0x65, 0xd4, 0x7e, 0x89, 0xcb, 0x8f, 0x15, 0x58, 0x2c, 0xb9, 0xe2, 0xf2, 0xf1, 0x89,
+	0xfc, 0xc2, 0x8a, 0xcf, 0xc2, 0xa9, 0x89, 0x06, 0x4a, 0x00, 0x7e, 0xa4, 0xc0, 0x71, 0x42, 0xad,
+	0xf6, 0x93, 0x69, 0xfc, 0x75, 0x38, 0x71, 0xc8, 0x38, 0x79, 0x46, 0xb9, 0x08, 0xe9, 0x2e, 0x0d,
+	0xac, 0xb6, 0x15, 0x58, 0xd2, 0xa4, 0xc5, 0x70, 0xdc, 0xa1, 0x76, 0x55, 0x6a, 0x90, 0x48, 0xb7,
+	0xf8, 0x93, 0x18, 0xcc, 0xf1, 0x73, 0xf6, 0xd3, 0x9f, 0xbc, 0x23, 0xdd, 0xc2, 0xa4, 0x0e, 0x1e,
+	0xfe, 0x98, 0x42, 0xdf, 0xa3, 0x66, 0x78, 0x3b, 0x30, 0xc5, 0x5f, 0xfa, 0xa0, 0xef, 0xd1, 0xeb,
+	0x82, 0xc3, 0x1e, 0x0d, 0xe7, 0xc7, 0x21, 0x8e, 0xfe, 0x68, 0xfe, 0xd0, 0xb7, 0x2d, 0x13, 0x42,
+	0x4a, 0xfc, 0x28, 0x3f, 0x49, 0x89, 0x23, 0xff, 0x24, 0x7d, 0x2f, 0x06, 0x85, 0x51, 0x63, 0x9e,
+	0xde, 0xe9, 0x8c, 0xdf, 0xe9, 0x7c, 0xd6, 0x5b, 0xbe, 0xe2, 0xf7, 0x15, 0x38, 0x39, 0x01, 0xd0,
+	0xcf, 0xe6, 0x22, 0x23, 0x37, 0x3b, 0xb1, 0x87, 0xde, 0xec, 0x7c, 0xf1, 0x4e, 0xf2, 0x03, 0x05,
+	0xe6, 0xab, 0xe2, 0xae, 0x5e, 0xdc, 0x7c, 0x3c, 0xbe, 0x31, 0x98, 0x5f, 0xc7, 0x27, 0x86, 0x8f,
+	0x51, 0xec, 0x36, 0xe7, 0x80, 0x69, 0x8f, 0x70, 0x9b, 0xf3, 0x1b, 0x05, 0x66, 0xe5, 0x28, 0x6a,
+	0x6b, 0xe7, 0xc9, 0x41, 0x07, 0x3f, 0x07, 0x71, 0xbb, 0x1d, 0x9e, 0x7b, 0xc7, 0xdf, 0xda, 0x99,
+	0xa0, 0x78, 0x19, 0xf0, 0xa8, 0xdd, 0x8f, 0x00, 0xdd, 0x2f, 0x62, 0xb0, 0x40, 0x44, 0xf4, 0x7d,
+	0xfa, 0xbe, 0xf0, 0x79, 0xdf, 0x17, 0x1e, 0x9c, 0xb8, 0x3e, 0xe1, 0x87, 0xa9, 0x71, 0xa8, 0xbf,
+	0xb8, 0xd4, 0x75, 0x20, 0xd1, 0xc6, 0x0f, 0x25, 0xda, 0x47, 0x8f, 0x47, 0x9f, 0xc4, 0x60, 0x51,
+	0x1a, 0xf2, 0xf4, 0xac, 0x73, 0x74, 0x8f, 0x48, 0x1d, 0xf2, 0x88, 0x5f, 0x2b, 0x70, 0x6a, 0x22,
+	0x90, 0x7f, 0xf4, 0x13, 0xcd, 0x01, 0xef, 0x49, 0x3c, 0xd4, 0x7b, 0x92, 0x47, 0xf6, 0x9e, 0xf7,
+	0x62, 0x90, 0x27, 0xd4, 0xa1, 0x96, 0xff, 0x84, 0xdf, 0xee, 0x1d, 0xc0, 0x30, 0x79, 0xe8, 0x9e,
+	0x73, 0x16, 0x66, 0x22, 0x20, 0xe4, 0x0f, 0x17, 0xff, 0x41, 0x67, 0x79, 0xf0, 0x2d, 0x6a, 0x39,
+	0x41, 0x78, 0x12, 0x2c, 0x7e, 0x3d, 0x06, 0x39, 0xc2, 0x38, 0x76, 0x97, 0xb2, 0x77, 0x6f, 0x1f,
+	0xbf, 0x00, 0xd3, 0xdb, 0x5c, 0xc5, 0x1c, 0x7a, 0x48, 0x86, 0x64, 0x05, 0x4f, 0xbc, 0x3e, 0xae,
+	0xc1, 0x82, 0x4f, 0x5b, 0x6e, 0xaf, 0xed, 0x9b, 0x5b, 0x74, 0x9b, 0x95, 0x5b, 0x75, 0x2d, 0x3f,
+	0xa0, 0x1e, 0x87, 0x25, 0x47, 0xe6, 0xa4, 0x70, 0x9d, 0xcb, 0xaa, 0x5c, 0x84, 0xcf, 0xc2, 0xfc,
+	0x96, 0xdd, 0x73, 0xdc, 0x0e, 0xab, 0xcd, 0xd9, 0xa7, 0x9e, 0x6f, 0xb6, 0xdc, 0x41, 0x4f, 0xe0,
+	0x91, 0x24, 0x58, 0xc8, 0xea, 0x42, 0x54, 0x62, 0x12, 0x7c, 0x1b, 0x56, 0x26, 0xce, 0x62, 0xde,
+	0xb1, 0x9d, 0x80, 0x7a, 0xb4, 0x6d, 0x7a, 0xb4, 0xef, 0xd8, 0x2d, 0x51, 0x47, 0x24, 0x80, 0x7a,
+	0x79, 0xc2, 0xd4, 0x9b, 0x52, 0x9d, 0x0c, 0xb5, 0x59, 0x65, 0x44, 0xab, 0x3f, 0x30, 0x07, 0xbc,
+	0x68, 0x81, 0xe1, 0xa7, 0x90, 0x74, 0xab, 0x3f, 0x68, 0x32, 0x9a, 0xbd, 0xa6, 0xef, 0xf6, 0x45,
+	0x70, 0x56, 0x08, 0x6b, 0xb2, 0x47, 0x9d, 0xbc, 0xda, 0xe9, 0x78, 0xb4, 0x63, 0x05, 0x12, 0xa6,
+	0xb3, 0x30, 0x2f, 0x20, 0xd9, 0x37, 0xa5, 0xbb, 0x0a, 0x7b, 0x14, 0x61, 0x8f, 0x94, 0x09, 0x5f,
+	0x15, 0xf6, 0x5c, 0x80, 0xe3, 0x83, 0xde, 0xc4, 0x3e, 0x31, 0xde, 0x67, 0x7e, 0xd0, 0x9b, 0xd0,
+	0xeb, 0x2f, 0xe1, 0xe4, 0x64, 0x14, 0xba, 0xb6, 0xa8, 0xe5, 0xcb, 0x91, 0xe3, 0x13, 0x8c, 0xae,
+	0xda, 0xbd, 0x07, 0x74, 0xb5, 0xee, 0x15, 0x12, 0x9f, 0xde, 0xd5, 0xba, 0x57, 0xfc, 0x66, 0xf4,
+	0xa6, 0x18, 0xba, 0x4b, 0x14, 0x38, 0x42, 0x47, 0x56, 0x1e, 0xe4, 0xc8, 0x05, 0x98, 0x62, 0xce,
+	0x68, 0xf7, 0x3a, 0xdc, 0xb8, 0x34, 0x09, 0x49, 0xdc, 0x80, 0x97, 0xa5, 0xed, 0xf4, 0x5e, 0x40,
+	0xbd, 0x9e, 0xe5, 0x38, 0xfb, 0xa6, 0xb8, 0x7e, 0xec, 0x05, 0xb4, 0x6d, 0x0e, 0x6b, 0x1b, 0x45,
+	0xf8, 0x78, 0x51, 0x68, 0x6b, 0x91, 0x32, 0x89, 0x74, 0x8d, 0x50, 0x15, 0xbf, 0x0e, 0x79, 0x4f,
+	0x3a, 0xb1, 0xe9, 0xb3, 0xed, 0x91, 0x21, 0x77, 0x5e, 0xae, 0x6e, 0xcc, 0xc3, 0x49, 0xce, 0x1b,
+	0x25, 0x1f, 0x3d, 0xe0, 0x5c, 0x4d, 0xa4, 0x53, 0x68, 0xaa, 0xf8, 0x2d, 0x05, 0xe6, 0x26, 0xfc,
+	0xbb, 0x47, 0x17, 0x03, 0xca, 0xc8, 0xbd, 0xe3, 0x9f, 0x43, 0x92, 0xad, 0x2f, 0x2c, 0x91, 0x3a,
+	0x71, 0xf8, 0xd7, 0x9f, 0xad, 0x89, 0x12, 0xa1, 0xc5, 0xbe, 0x45, 0x6e, 0x53, 0xcb, 0xa3, 0x56,
+	0x40, 0xc3, 0x88, 0x9a, 0x65, 0x3c, 0x71, 0x17, 0x79, 0xf8, 0x26, 0x33, 0xf1, 0xd0, 0x9b, 0xcc,
+	0x95, 0xff, 0x8a, 0x43, 0xa6, 0xba, 0xdf, 0xd8, 0x75, 0x36, 0x1d, 0xab, 0xc3, 0xab, 0x43, 0xaa,
+	0x75, 0xe3, 0x16, 0x3a, 0xc6, 0xca, 0xdf, 0xf4, 0x9a, 0x61, 0xea, 0xcd, 0x4a, 0xc5, 0xdc, 0xac,
+	0xa8, 0x57, 0x90, 0xc2, 0xea, 0xc8, 0xea, 0xa4, 0x6c, 0x5e, 0xd3, 0x6e, 0x09, 0x4e, 0x8c, 0x15,
+	0xa6, 0x35, 0xf5, 0xf2, 0xf5, 0xa6, 0x36, 0x64, 0x26, 0xf0, 0x02, 0xcc, 0x56, 0x9b, 0x15, 0xa3,
+	0x5c, 0xaf, 0x8c, 0xb0, 0xd3, 0xac, 0x78, 0x6e, 0xbd, 0x52, 0x5b, 0x17, 0x24, 0x62, 0xe3, 0x37,
+	0xf5, 0x46, 0xf9, 0x8a, 0xae, 0x6d, 0x08, 0xd6, 0x12, 0x63, 0xdd, 0xd6, 0x48, 0x6d, 0xb3, 0x1c,
+	0x4e, 0x79, 0x19, 0x23, 0xc8, 0xae, 0x97, 0x75, 0x95, 0xc8, 0x51, 0xee, 0x2b, 0x38, 0x0f, 0x19,
+	0x4d, 0x6f, 0x56, 0x25, 0x1d, 0xc3, 0x05, 0x98, 0x63, 0x75, 0x6a, 0x66, 0x59, 0x2f, 0x11, 0xad,
+	0xca, 0xca, 0xd9, 0x84, 0x24, 0x81, 0xe7, 0x20, 0x6f, 0x94, 0xab, 0x5a, 0xc3, 0x50, 0xab, 0x75,
+	0xc9, 0x64, 0xab, 0x48, 0x37, 0xb4, 0x50, 0x07, 0xe1, 0x45, 0x58, 0xd0, 0x6b, 0xa6, 0xac, 0xb4,
+	0x33, 0x6f, 0xa8, 0x95, 0xa6, 0x26, 0x65, 0x4b, 0xf8, 0x04, 0xe0, 0x9a, 0x6e, 0x36, 0xeb, 0x1b,
+	0xaa, 0xa1, 0x99, 0x7a, 0xed, 0xa6, 0x14, 0x5c, 0xc6, 0x79, 0x48, 0x0f, 0x57, 0x70, 0x9f, 0xa1,
+	0x90, 0xab, 0xab, 0xc4, 0x18, 0x1a, 0x7b, 0xff, 0x3e, 0x03, 0x0b, 0xae, 0x90, 0x5a, 0xb3, 0x3e,
+	0x54, 0x9b, 0x85, 0xac, 0x04, 0x4b, 0xb2, 0x12, 0x8c, 0xb5, 0x5e, 0xd6, 0x4b, 0xd1, 0xfa, 0xee,
+	0xa7, 0x17, 0x63, 0x48, 0x59, 0xd9, 0x81, 0x04, 0xdf, 0x8e, 0x34, 0x24, 0xf4, 0x9a, 0xce, 0x2a,
+	0x0f, 0x67, 0x00, 0xca, 0x8d, 0xb2, 0x6e, 0x68, 0x57, 0x88, 0x5a, 0x61, 0x66, 0x73, 0x46, 0x08,
+	0x20, 0xb3, 0x76, 0x1a, 0xa6, 0xca, 0x8d, 0xcd, 0x4a, 0x4d, 0x35, 0xa4, 0x99, 0xe5, 0xc6, 0xf5,
+	0x66, 0x8d, 0x15, 0x00, 0xde, 0x47, 0x38, 0x0b, 0x29, 0x56, 0xeb, 0xf7, 0xb6, 0xc1, 0xec, 0xe2,
+	0x32, 0x81, 0x2a, 0xba, 0x7f, 0x79, 0xe5, 0xc3, 0x38, 0x24, 0x78, 0xed, 0x74, 0x0e, 0x32, 0x7c,
+	0xb7, 0x59, 0x89, 0x23, 0x3a, 0x86, 0x33, 0x90, 0x28, 0xeb, 0xc6, 0x25, 0xf4, 0xb7, 0x31, 0x0c,
+	0x90, 0x6c, 0xf2, 0xf6, 0xdf, 0xa5, 0x58, 0xbb, 0xac, 0x1b, 0xe7, 0x2e, 0xa2, 0x77, 0x62, 0x6c,
+	0xd8, 0xa6, 0x20, 0xfe, 0x3e, 0x14, 0xac, 0x5d, 0x40, 0xef, 0x46, 0x82, 0xb5, 0x0b, 0xe8, 0x1f,
+	0x42, 0xc1, 0xf9, 0x35, 0xf4, 0x5e, 0x24, 0x38, 0xbf, 0x86, 0xfe, 0x31, 0x14, 0x5c, 0xbc, 0x80,
+	0xfe, 0x29, 0x12, 0x5c, 0xbc, 0x80, 0xfe, 0x39, 0xc5, 0x6c, 0xe1, 0x96, 0x9c, 0x5f, 0x43, 0xff,
+	0x92, 0x8e, 0xa8, 0x8b, 0x17, 0xd0, 0xbf, 0xa6, 0xd9, 0xfe, 0x47, 0xbb, 0x8a, 0xfe, 0x0d, 0xb1,
+	0x65, 0xb2, 0x0d, 0x42, 0xff, 0xce, 0x9b, 0x4c, 0x84, 0xfe, 0x03, 0x31, 0x1b, 0x19, 0x97, 0x93,
+	0xef, 0x73, 0xc9, 0x2d, 0x4d, 0x25, 0xe8, 0x3f, 0x53, 0xa2, 0xb0, 0xb2, 0x54, 0xae, 0xaa, 0x15,
+	0x84, 0x79, 0x0f, 0x86, 0xca, 0x7f, 0x9f, 0x65, 0x4d, 0xe6, 0x9e, 0xe8, 0x7f, 0xea, 0x6c, 0xc2,
+	0x1b, 0x2a, 0x29, 0xbd, 0xa5, 0x12, 0xf4, 0xbf, 0x67, 0xd9, 0x84, 0x37, 0x54, 0x22, 0xf1, 0xfa,
+	0xbf, 0x3a, 0x53, 0xe4, 0xa2, 0x0f, 0xce, 0xb2, 0x45, 0x4b, 0xfe, 0xff, 0xd7, 0x71, 0x1a, 0xe2,
+	0xeb, 0x65, 0x03, 0x7d, 0xc8, 0x67, 0x63, 0x2e, 0x8a, 0xbe, 0x82, 0x18, 0xb3, 0xa1, 0x19, 0xe8,
+	0x23, 0xc6, 0x4c, 0x1a, 0xcd, 0x7a, 0x45, 0x43, 0xcf, 0xb0, 0xc5, 0x5d, 0xd1, 0x6a, 0x55, 0xcd,
+	0x20, 0xb7, 0xd0, 0x57, 0xb9, 0xfa, 0xd5, 0x46, 0x4d, 0x47, 0x1f, 0x23, 0x56, 0x74, 0xa9, 0xbd,
+	0x5d, 0x27, 0x5a, 0xa3, 0x51, 0xae, 0xe9, 0xe8, 0xf9, 0x95, 0x4d, 0x40, 0x07, 0xc3, 0x01, 0x33,
+	0xa0, 0xa9, 0x5f, 0xd3, 0x6b, 0x37, 0x75, 0x74, 0x8c, 0x11, 0x75, 0xa2, 0xd5, 0x55, 0xa2, 0x21,
+	0x05, 0x03, 0xa4, 0x64, 0xb9, 0x66, 0x0c, 0x4f, 0x43, 0x9a, 0xd4, 0x2a, 0x95, 0x75, 0xb5, 0x74,
+	0x0d, 0xc5, 0xd7, 0x5f, 0x85, 0x19, 0xdb, 0x5d, 0xdd, 0xb3, 0x03, 0xea, 0xfb, 0xa2, 0x3a, 0xff,
+	0x76, 0x51, 0x52, 0xb6, 0x7b, 0x46, 0xb4, 0xce, 0x74, 0xdc, 0x33, 0x7b, 0xc1, 0x19, 0x2e, 0x3d,
+	0xc3, 0x23, 0xc6, 0x56, 0x8a, 0x13, 0xe7, 0x7f, 0x3f, 0x00, 0x19, 0x39, 0xd0, 0x14, 0xfb, 0x2f,
+	0x00, 0x00,
 }
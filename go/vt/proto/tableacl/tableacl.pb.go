@@ -29,9 +29,12 @@ type TableGroupSpec struct {
 	Readers              []string `protobuf:"bytes,3,rep,name=readers,proto3" json:"readers,omitempty"`
 	Writers              []string `protobuf:"bytes,4,rep,name=writers,proto3" json:"writers,omitempty"`
 	Admins               []string `protobuf:"bytes,5,rep,name=admins,proto3" json:"admins,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// column_rules restrict the columns of the tables that the readers can
+	// read.
+	ColumnRules          []*ColumnRule `protobuf:"bytes,6,rep,name=column_rules,json=columnRules,proto3" json:"column_rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *TableGroupSpec) Reset()         { *m = TableGroupSpec{} }
@@ -94,6 +97,74 @@ func (m *TableGroupSpec) GetAdmins() []string {
 	return nil
 }
 
+func (m *TableGroupSpec) GetColumnRules() []*ColumnRule {
+	if m != nil {
+		return m.ColumnRules
+	}
+	return nil
+}
+
+// ColumnRule restricts the access to some columns of the tables of a
+// group. The users who are not readers of the rule get the masked values
+// of the columns in the select expressions, and can't use the columns
+// anywhere else. If there is no mask, they can't read the columns at all.
+type ColumnRule struct {
+	Columns []string `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	// readers can read the columns as is.
+	Readers []string `protobuf:"bytes,2,rep,name=readers,proto3" json:"readers,omitempty"`
+	// mask is "redact", "hash" or "partial", or empty to deny the access.
+	Mask                 string   `protobuf:"bytes,3,opt,name=mask,proto3" json:"mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ColumnRule) Reset()         { *m = ColumnRule{} }
+func (m *ColumnRule) String() string { return proto.CompactTextString(m) }
+func (*ColumnRule) ProtoMessage()    {}
+func (*ColumnRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d0bedb248a1632e, []int{1}
+}
+
+func (m *ColumnRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ColumnRule.Unmarshal(m, b)
+}
+func (m *ColumnRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ColumnRule.Marshal(b, m, deterministic)
+}
+func (m *ColumnRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnRule.Merge(m, src)
+}
+func (m *ColumnRule) XXX_Size() int {
+	return xxx_messageInfo_ColumnRule.Size(m)
+}
+func (m *ColumnRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnRule proto.InternalMessageInfo
+
+func (m *ColumnRule) GetColumns() []string {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+func (m *ColumnRule) GetReaders() []string {
+	if m != nil {
+		return m.Readers
+	}
+	return nil
+}
+
+func (m *ColumnRule) GetMask() string {
+	if m != nil {
+		return m.Mask
+	}
+	return ""
+}
+
 type Config struct {
 	TableGroups          []*TableGroupSpec `protobuf:"bytes,1,rep,name=table_groups,json=tableGroups,proto3" json:"table_groups,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -105,7 +176,7 @@ func (m *Config) Reset()         { *m = Config{} }
 func (m *Config) String() string { return proto.CompactTextString(m) }
 func (*Config) ProtoMessage()    {}
 func (*Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7d0bedb248a1632e, []int{1}
+	return fileDescriptor_7d0bedb248a1632e, []int{2}
 }
 
 func (m *Config) XXX_Unmarshal(b []byte) error {
@@ -135,26 +206,30 @@ func (m *Config) GetTableGroups() []*TableGroupSpec {
 
 func init() {
 	proto.RegisterType((*TableGroupSpec)(nil), "tableacl.TableGroupSpec")
+	proto.RegisterType((*ColumnRule)(nil), "tableacl.ColumnRule")
 	proto.RegisterType((*Config)(nil), "tableacl.Config")
 }
 
 func init() { proto.RegisterFile("tableacl.proto", fileDescriptor_7d0bedb248a1632e) }
 
 var fileDescriptor_7d0bedb248a1632e = []byte{
-	// 232 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xc1, 0x4b, 0xc3, 0x30,
-	0x14, 0xc6, 0x89, 0x9d, 0xd5, 0xbd, 0xc9, 0x0e, 0x41, 0x34, 0xc7, 0x32, 0x10, 0x7b, 0x6a, 0x40,
-	0xf1, 0xe4, 0x4d, 0x11, 0x6f, 0x2a, 0xd5, 0x93, 0x97, 0x92, 0x6d, 0x6f, 0x25, 0xb0, 0x35, 0xe1,
-	0xbd, 0x38, 0xfd, 0x8f, 0xfc, 0x37, 0x25, 0x69, 0x3b, 0xf0, 0xf6, 0xfd, 0xf8, 0x25, 0xe1, 0xfb,
-	0x02, 0xf3, 0x60, 0x96, 0x5b, 0x34, 0xab, 0x6d, 0xe5, 0xc9, 0x05, 0x27, 0x4f, 0x47, 0x5e, 0xfc,
-	0x0a, 0x98, 0x7f, 0x44, 0x78, 0x26, 0xf7, 0xe5, 0xdf, 0x3d, 0xae, 0xa4, 0x84, 0x49, 0x67, 0x76,
-	0xa8, 0x44, 0x21, 0xca, 0x69, 0x9d, 0xb2, 0xbc, 0x83, 0xcb, 0x74, 0xa5, 0x89, 0xc4, 0x8d, 0xa3,
-	0xc6, 0x13, 0x6e, 0xec, 0x0f, 0xb2, 0x3a, 0x2a, 0xb2, 0x72, 0x5a, 0x9f, 0x27, 0xfd, 0x12, 0xed,
-	0x2b, 0xbd, 0x0d, 0x4e, 0x2a, 0x38, 0x21, 0x34, 0x6b, 0x24, 0x56, 0x59, 0x3a, 0x36, 0x62, 0x34,
-	0xdf, 0x64, 0x43, 0x34, 0x93, 0xde, 0x0c, 0x28, 0x2f, 0x20, 0x37, 0xeb, 0x9d, 0xed, 0x58, 0x1d,
-	0x27, 0x31, 0xd0, 0xe2, 0x09, 0xf2, 0x47, 0xd7, 0x6d, 0x6c, 0x2b, 0xef, 0xe1, 0xac, 0x2f, 0xd3,
-	0xc6, 0xce, 0xac, 0x44, 0x91, 0x95, 0xb3, 0x1b, 0x55, 0x1d, 0x46, 0xfe, 0x1f, 0x54, 0xcf, 0xc2,
-	0x81, 0xf9, 0xe1, 0xfa, 0xf3, 0x6a, 0x6f, 0x03, 0x32, 0x57, 0xd6, 0xe9, 0x3e, 0xe9, 0xd6, 0xe9,
-	0x7d, 0xd0, 0xe9, 0x6b, 0xf4, 0xf8, 0xc8, 0x32, 0x4f, 0x7c, 0xfb, 0x17, 0x00, 0x00, 0xff, 0xff,
-	0x09, 0x82, 0xf5, 0x82, 0x3c, 0x01, 0x00, 0x00,
+	// 288 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0x41, 0x4f, 0xb3, 0x40,
+	0x10, 0x86, 0x43, 0xe9, 0xc7, 0x67, 0x87, 0xa6, 0x87, 0x4d, 0xa3, 0x7b, 0x6c, 0x48, 0x8c, 0x9c,
+	0x20, 0xa9, 0x31, 0x1e, 0xbc, 0xd9, 0x18, 0x6f, 0x6a, 0xb0, 0x27, 0x2f, 0x64, 0x4b, 0xb7, 0x64,
+	0x23, 0xb0, 0x64, 0x67, 0xa9, 0xfe, 0x5c, 0x7f, 0x8a, 0xd9, 0x5d, 0xa0, 0xf6, 0xf6, 0x3e, 0x79,
+	0x60, 0x66, 0x78, 0x81, 0x85, 0x66, 0xbb, 0x8a, 0xb3, 0xa2, 0x4a, 0x5a, 0x25, 0xb5, 0x24, 0x17,
+	0x03, 0x47, 0x3f, 0x1e, 0x2c, 0xb6, 0x06, 0x9e, 0x95, 0xec, 0xda, 0xf7, 0x96, 0x17, 0x84, 0xc0,
+	0xb4, 0x61, 0x35, 0xa7, 0xde, 0xca, 0x8b, 0x67, 0x99, 0xcd, 0xe4, 0x0e, 0xae, 0xec, 0x2b, 0xb9,
+	0x21, 0xcc, 0xa5, 0xca, 0x5b, 0xc5, 0x0f, 0xe2, 0x9b, 0x23, 0x9d, 0xac, 0xfc, 0x78, 0x96, 0x2d,
+	0xad, 0x7e, 0x31, 0xf6, 0x55, 0xbd, 0xf5, 0x8e, 0x50, 0xf8, 0xaf, 0x38, 0xdb, 0x73, 0x85, 0xd4,
+	0xb7, 0x8f, 0x0d, 0x68, 0xcc, 0x97, 0x12, 0xda, 0x98, 0xa9, 0x33, 0x3d, 0x92, 0x4b, 0x08, 0xd8,
+	0xbe, 0x16, 0x0d, 0xd2, 0x7f, 0x56, 0xf4, 0x44, 0xee, 0x61, 0x5e, 0xc8, 0xaa, 0xab, 0x9b, 0x5c,
+	0x75, 0x15, 0x47, 0x1a, 0xac, 0xfc, 0x38, 0x5c, 0x2f, 0x93, 0xf1, 0xd3, 0x36, 0xd6, 0x66, 0x5d,
+	0xc5, 0xb3, 0xb0, 0x18, 0x33, 0x46, 0x5b, 0x80, 0x93, 0x32, 0x8b, 0x9d, 0x44, 0xea, 0xb9, 0xc5,
+	0x3d, 0xfe, 0x3d, 0x76, 0x72, 0x7e, 0x2c, 0x81, 0x69, 0xcd, 0xf0, 0x93, 0xfa, 0xae, 0x11, 0x93,
+	0xa3, 0x27, 0x08, 0x36, 0xb2, 0x39, 0x88, 0x92, 0x3c, 0xc0, 0xdc, 0x75, 0x53, 0x9a, 0x0a, 0xdd,
+	0xd8, 0x70, 0x4d, 0x4f, 0x87, 0x9d, 0xf7, 0x9b, 0x85, 0x7a, 0x64, 0x7c, 0xbc, 0xf9, 0xb8, 0x3e,
+	0x0a, 0xcd, 0x11, 0x13, 0x21, 0x53, 0x97, 0xd2, 0x52, 0xa6, 0x47, 0x9d, 0xda, 0x3f, 0x95, 0x0e,
+	0x43, 0x76, 0x81, 0xe5, 0xdb, 0xdf, 0x01, 0x00, 0xd6, 0x4c, 0xa6, 0x8b, 0xcb, 0x01, 0x00, 0x00,
 }
@@ -25,6 +25,48 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// DistributedTransactionAction is the action applied to a dtid by
+// ResolveDistributedTransaction.
+type DistributedTransactionAction int32
+
+const (
+	// UNKNOWN is the default value, which is rejected so that a caller
+	// that leaves the action unset can't destroy any 2PC state.
+	DistributedTransactionAction_UNKNOWN DistributedTransactionAction = 0
+	// CONCLUDE deletes the 2PC metadata held by the metadata manager.
+	DistributedTransactionAction_CONCLUDE DistributedTransactionAction = 1
+	// COMMIT_PREPARED commits the transaction prepared on a participant.
+	DistributedTransactionAction_COMMIT_PREPARED DistributedTransactionAction = 2
+	// ROLLBACK_PREPARED rolls back the transaction prepared on a participant.
+	DistributedTransactionAction_ROLLBACK_PREPARED DistributedTransactionAction = 3
+	// SET_ROLLBACK records the decision to roll back on the metadata manager.
+	DistributedTransactionAction_SET_ROLLBACK DistributedTransactionAction = 4
+)
+
+var DistributedTransactionAction_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "CONCLUDE",
+	2: "COMMIT_PREPARED",
+	3: "ROLLBACK_PREPARED",
+	4: "SET_ROLLBACK",
+}
+
+var DistributedTransactionAction_value = map[string]int32{
+	"UNKNOWN":           0,
+	"CONCLUDE":          1,
+	"COMMIT_PREPARED":   2,
+	"ROLLBACK_PREPARED": 3,
+	"SET_ROLLBACK":      4,
+}
+
+func (x DistributedTransactionAction) String() string {
+	return proto.EnumName(DistributedTransactionAction_name, int32(x))
+}
+
+func (DistributedTransactionAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ff9ac4f89e61ffa4, []int{0}
+}
+
 type TableDefinition struct {
 	// the table name
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -4469,7 +4511,178 @@ func (m *VExecResponse) GetResult() *query.QueryResult {
 	return nil
 }
 
+type GetDistributedTransactionsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDistributedTransactionsRequest) Reset()         { *m = GetDistributedTransactionsRequest{} }
+func (m *GetDistributedTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDistributedTransactionsRequest) ProtoMessage()    {}
+func (*GetDistributedTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff9ac4f89e61ffa4, []int{112}
+}
+
+func (m *GetDistributedTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDistributedTransactionsRequest.Unmarshal(m, b)
+}
+func (m *GetDistributedTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDistributedTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDistributedTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDistributedTransactionsRequest.Merge(m, src)
+}
+func (m *GetDistributedTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDistributedTransactionsRequest.Size(m)
+}
+func (m *GetDistributedTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDistributedTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDistributedTransactionsRequest proto.InternalMessageInfo
+
+type GetDistributedTransactionsResponse struct {
+	// transactions is the 2PC metadata this tablet holds as a
+	// metadata manager.
+	Transactions []*query.TransactionMetadata `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// prepared_dtids are the dtids prepared on this tablet as a
+	// resource manager, waiting for a commit or rollback decision.
+	PreparedDtids []string `protobuf:"bytes,2,rep,name=prepared_dtids,json=preparedDtids,proto3" json:"prepared_dtids,omitempty"`
+	// failed_dtids are the dtids whose redo logs could not be replayed
+	// on this tablet.
+	FailedDtids          []string `protobuf:"bytes,3,rep,name=failed_dtids,json=failedDtids,proto3" json:"failed_dtids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDistributedTransactionsResponse) Reset()         { *m = GetDistributedTransactionsResponse{} }
+func (m *GetDistributedTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDistributedTransactionsResponse) ProtoMessage()    {}
+func (*GetDistributedTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff9ac4f89e61ffa4, []int{113}
+}
+
+func (m *GetDistributedTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDistributedTransactionsResponse.Unmarshal(m, b)
+}
+func (m *GetDistributedTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDistributedTransactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDistributedTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDistributedTransactionsResponse.Merge(m, src)
+}
+func (m *GetDistributedTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDistributedTransactionsResponse.Size(m)
+}
+func (m *GetDistributedTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDistributedTransactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDistributedTransactionsResponse proto.InternalMessageInfo
+
+func (m *GetDistributedTransactionsResponse) GetTransactions() []*query.TransactionMetadata {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *GetDistributedTransactionsResponse) GetPreparedDtids() []string {
+	if m != nil {
+		return m.PreparedDtids
+	}
+	return nil
+}
+
+func (m *GetDistributedTransactionsResponse) GetFailedDtids() []string {
+	if m != nil {
+		return m.FailedDtids
+	}
+	return nil
+}
+
+type ResolveDistributedTransactionRequest struct {
+	Dtid                 string                       `protobuf:"bytes,1,opt,name=dtid,proto3" json:"dtid,omitempty"`
+	Action               DistributedTransactionAction `protobuf:"varint,2,opt,name=action,proto3,enum=tabletmanagerdata.DistributedTransactionAction" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ResolveDistributedTransactionRequest) Reset()         { *m = ResolveDistributedTransactionRequest{} }
+func (m *ResolveDistributedTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolveDistributedTransactionRequest) ProtoMessage()    {}
+func (*ResolveDistributedTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff9ac4f89e61ffa4, []int{114}
+}
+
+func (m *ResolveDistributedTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveDistributedTransactionRequest.Unmarshal(m, b)
+}
+func (m *ResolveDistributedTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveDistributedTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *ResolveDistributedTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveDistributedTransactionRequest.Merge(m, src)
+}
+func (m *ResolveDistributedTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolveDistributedTransactionRequest.Size(m)
+}
+func (m *ResolveDistributedTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveDistributedTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveDistributedTransactionRequest proto.InternalMessageInfo
+
+func (m *ResolveDistributedTransactionRequest) GetDtid() string {
+	if m != nil {
+		return m.Dtid
+	}
+	return ""
+}
+
+func (m *ResolveDistributedTransactionRequest) GetAction() DistributedTransactionAction {
+	if m != nil {
+		return m.Action
+	}
+	return DistributedTransactionAction_UNKNOWN
+}
+
+type ResolveDistributedTransactionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolveDistributedTransactionResponse) Reset()         { *m = ResolveDistributedTransactionResponse{} }
+func (m *ResolveDistributedTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*ResolveDistributedTransactionResponse) ProtoMessage()    {}
+func (*ResolveDistributedTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff9ac4f89e61ffa4, []int{115}
+}
+
+func (m *ResolveDistributedTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveDistributedTransactionResponse.Unmarshal(m, b)
+}
+func (m *ResolveDistributedTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveDistributedTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *ResolveDistributedTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveDistributedTransactionResponse.Merge(m, src)
+}
+func (m *ResolveDistributedTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_ResolveDistributedTransactionResponse.Size(m)
+}
+func (m *ResolveDistributedTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveDistributedTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveDistributedTransactionResponse proto.InternalMessageInfo
+
 func init() {
+	proto.RegisterEnum("tabletmanagerdata.DistributedTransactionAction", DistributedTransactionAction_name, DistributedTransactionAction_value)
 	proto.RegisterType((*TableDefinition)(nil), "tabletmanagerdata.TableDefinition")
 	proto.RegisterType((*SchemaDefinition)(nil), "tabletmanagerdata.SchemaDefinition")
 	proto.RegisterType((*SchemaChangeResult)(nil), "tabletmanagerdata.SchemaChangeResult")
@@ -4585,155 +4798,171 @@ func init() {
 	proto.RegisterType((*SlaveWasRestartedResponse)(nil), "tabletmanagerdata.SlaveWasRestartedResponse")
 	proto.RegisterType((*VExecRequest)(nil), "tabletmanagerdata.VExecRequest")
 	proto.RegisterType((*VExecResponse)(nil), "tabletmanagerdata.VExecResponse")
+	proto.RegisterType((*GetDistributedTransactionsRequest)(nil), "tabletmanagerdata.GetDistributedTransactionsRequest")
+	proto.RegisterType((*GetDistributedTransactionsResponse)(nil), "tabletmanagerdata.GetDistributedTransactionsResponse")
+	proto.RegisterType((*ResolveDistributedTransactionRequest)(nil), "tabletmanagerdata.ResolveDistributedTransactionRequest")
+	proto.RegisterType((*ResolveDistributedTransactionResponse)(nil), "tabletmanagerdata.ResolveDistributedTransactionResponse")
 }
 
 func init() { proto.RegisterFile("tabletmanagerdata.proto", fileDescriptor_ff9ac4f89e61ffa4) }
 
 var fileDescriptor_ff9ac4f89e61ffa4 = []byte{
-	// 2316 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xdd, 0x72, 0xdb, 0xc6,
-	0xf5, 0x1f, 0x52, 0x1f, 0x96, 0x0e, 0x3f, 0x44, 0x81, 0x94, 0x48, 0x51, 0xb1, 0x2c, 0xc3, 0x4e,
-	0xe2, 0x7f, 0x32, 0x7f, 0x2a, 0x91, 0x13, 0x4f, 0x26, 0x69, 0x3b, 0x95, 0x6d, 0xc9, 0x76, 0x2c,
-	0xc7, 0x0a, 0xe4, 0x8f, 0x4c, 0xa6, 0x53, 0x0c, 0x48, 0xac, 0x28, 0x8c, 0x40, 0x2c, 0xbc, 0xbb,
-	0xa4, 0xc4, 0x9b, 0x3e, 0x42, 0xfb, 0x02, 0x9d, 0xde, 0x74, 0xa6, 0xbd, 0xef, 0x43, 0xf4, 0x11,
-	0xd2, 0x47, 0xe9, 0x45, 0x2f, 0xda, 0xd9, 0xdd, 0x03, 0x10, 0x20, 0x20, 0x59, 0x56, 0xdd, 0x99,
-	0xdc, 0x68, 0xb0, 0xbf, 0xb3, 0xe7, 0x73, 0xcf, 0x9e, 0x73, 0x96, 0x82, 0xa6, 0x70, 0xba, 0x3e,
-	0x11, 0x03, 0x27, 0x70, 0xfa, 0x84, 0xb9, 0x8e, 0x70, 0x3a, 0x21, 0xa3, 0x82, 0x1a, 0xcb, 0x19,
-	0x42, 0xbb, 0xf4, 0x66, 0x48, 0xd8, 0x58, 0xd3, 0xdb, 0x55, 0x41, 0x43, 0x3a, 0xd9, 0xdf, 0x5e,
-	0x61, 0x24, 0xf4, 0xbd, 0x9e, 0x23, 0x3c, 0x1a, 0x24, 0xe0, 0x8a, 0x4f, 0xfb, 0x43, 0xe1, 0xf9,
-	0x7a, 0x69, 0xfe, 0xbb, 0x00, 0x4b, 0x2f, 0xa4, 0xe0, 0x87, 0xe4, 0xc8, 0x0b, 0x3c, 0xb9, 0xd9,
-	0x30, 0x60, 0x36, 0x70, 0x06, 0xa4, 0x55, 0xd8, 0x2c, 0xdc, 0x59, 0xb4, 0xd4, 0xb7, 0xb1, 0x0a,
-	0xf3, 0xbc, 0x77, 0x4c, 0x06, 0x4e, 0xab, 0xa8, 0x50, 0x5c, 0x19, 0x2d, 0xb8, 0xd6, 0xa3, 0xfe,
-	0x70, 0x10, 0xf0, 0xd6, 0xcc, 0xe6, 0xcc, 0x9d, 0x45, 0x2b, 0x5a, 0x1a, 0x1d, 0xa8, 0x87, 0xcc,
-	0x1b, 0x38, 0x6c, 0x6c, 0x9f, 0x90, 0xb1, 0x1d, 0xed, 0x9a, 0x55, 0xbb, 0x96, 0x91, 0xf4, 0x94,
-	0x8c, 0x1f, 0xe0, 0x7e, 0x03, 0x66, 0xc5, 0x38, 0x24, 0xad, 0x39, 0xad, 0x55, 0x7e, 0x1b, 0x37,
-	0xa0, 0x24, 0x4d, 0xb7, 0x7d, 0x12, 0xf4, 0xc5, 0x71, 0x6b, 0x7e, 0xb3, 0x70, 0x67, 0xd6, 0x02,
-	0x09, 0xed, 0x2b, 0xc4, 0x58, 0x87, 0x45, 0x46, 0x4f, 0xed, 0x1e, 0x1d, 0x06, 0xa2, 0x75, 0x4d,
-	0x91, 0x17, 0x18, 0x3d, 0x7d, 0x20, 0xd7, 0xc6, 0x6d, 0x98, 0x3f, 0xf2, 0x88, 0xef, 0xf2, 0xd6,
-	0xc2, 0xe6, 0xcc, 0x9d, 0xd2, 0x76, 0xb9, 0xa3, 0xe3, 0xb5, 0x27, 0x41, 0x0b, 0x69, 0xe6, 0x5f,
-	0x0a, 0x50, 0x3b, 0x54, 0xce, 0x24, 0x42, 0xf0, 0x31, 0x2c, 0x49, 0x2d, 0x5d, 0x87, 0x13, 0x1b,
-	0xfd, 0xd6, 0xd1, 0xa8, 0x46, 0xb0, 0x66, 0x31, 0x9e, 0x83, 0x3e, 0x17, 0xdb, 0x8d, 0x99, 0x79,
-	0xab, 0xa8, 0xd4, 0x99, 0x9d, 0xec, 0x51, 0x4e, 0x85, 0xda, 0xaa, 0x89, 0x34, 0xc0, 0x65, 0x40,
-	0x47, 0x84, 0x71, 0x8f, 0x06, 0xad, 0x19, 0xa5, 0x31, 0x5a, 0x4a, 0x43, 0x0d, 0xad, 0xf5, 0xc1,
-	0xb1, 0x13, 0xf4, 0x89, 0x45, 0xf8, 0xd0, 0x17, 0xc6, 0x63, 0xa8, 0x74, 0xc9, 0x11, 0x65, 0x29,
-	0x43, 0x4b, 0xdb, 0xb7, 0x72, 0xb4, 0x4f, 0xbb, 0x69, 0x95, 0x35, 0x27, 0xfa, 0xb2, 0x07, 0x65,
-	0xe7, 0x48, 0x10, 0x66, 0x27, 0x4e, 0xfa, 0x92, 0x82, 0x4a, 0x8a, 0x51, 0xc3, 0xe6, 0x3f, 0x0b,
-	0x50, 0x7d, 0xc9, 0x09, 0x3b, 0x20, 0x6c, 0xe0, 0x71, 0x8e, 0x29, 0x75, 0x4c, 0xb9, 0x88, 0x52,
-	0x4a, 0x7e, 0x4b, 0x6c, 0xc8, 0x09, 0xc3, 0x84, 0x52, 0xdf, 0xc6, 0xa7, 0xb0, 0x1c, 0x3a, 0x9c,
-	0x9f, 0x52, 0xe6, 0xda, 0xbd, 0x63, 0xd2, 0x3b, 0xe1, 0xc3, 0x81, 0x8a, 0xc3, 0xac, 0x55, 0x8b,
-	0x08, 0x0f, 0x10, 0x37, 0xbe, 0x07, 0x08, 0x99, 0x37, 0xf2, 0x7c, 0xd2, 0x27, 0x3a, 0xb1, 0x4a,
-	0xdb, 0x9f, 0xe7, 0x58, 0x9b, 0xb6, 0xa5, 0x73, 0x10, 0xf3, 0xec, 0x06, 0x82, 0x8d, 0xad, 0x84,
-	0x90, 0xf6, 0x2f, 0x61, 0x69, 0x8a, 0x6c, 0xd4, 0x60, 0xe6, 0x84, 0x8c, 0xd1, 0x72, 0xf9, 0x69,
-	0x34, 0x60, 0x6e, 0xe4, 0xf8, 0x43, 0x82, 0x96, 0xeb, 0xc5, 0xd7, 0xc5, 0xaf, 0x0a, 0xe6, 0x4f,
-	0x05, 0x28, 0x3f, 0xec, 0xbe, 0xc5, 0xef, 0x2a, 0x14, 0xdd, 0x2e, 0xf2, 0x16, 0xdd, 0x6e, 0x1c,
-	0x87, 0x99, 0x44, 0x1c, 0x9e, 0xe7, 0xb8, 0xb6, 0x95, 0xe3, 0x5a, 0x52, 0xd9, 0xff, 0xd2, 0xb1,
-	0x3f, 0x17, 0xa0, 0x34, 0xd1, 0xc4, 0x8d, 0x7d, 0xa8, 0x49, 0x3b, 0xed, 0x70, 0x82, 0xb5, 0x0a,
-	0xca, 0xca, 0x9b, 0x6f, 0x3d, 0x00, 0x6b, 0x69, 0x98, 0x5a, 0x73, 0x63, 0x0f, 0xaa, 0x6e, 0x37,
-	0x25, 0x4b, 0xdf, 0xa0, 0x1b, 0x6f, 0xf1, 0xd8, 0xaa, 0xb8, 0x89, 0x15, 0x37, 0x3f, 0x86, 0xd2,
-	0x81, 0x17, 0xf4, 0x2d, 0xf2, 0x66, 0x48, 0xb8, 0x90, 0x57, 0x29, 0x74, 0xc6, 0x3e, 0x75, 0x5c,
-	0x74, 0x32, 0x5a, 0x9a, 0x77, 0xa0, 0xac, 0x37, 0xf2, 0x90, 0x06, 0x9c, 0x5c, 0xb0, 0xf3, 0x13,
-	0x28, 0x1f, 0xfa, 0x84, 0x84, 0x91, 0xcc, 0x36, 0x2c, 0xb8, 0x43, 0xa6, 0x8a, 0xaa, 0xda, 0x3a,
-	0x63, 0xc5, 0x6b, 0x73, 0x09, 0x2a, 0xb8, 0x57, 0x8b, 0x35, 0xff, 0x51, 0x00, 0x63, 0xf7, 0x8c,
-	0xf4, 0x86, 0x82, 0x3c, 0xa6, 0xf4, 0x24, 0x92, 0x91, 0x57, 0x5f, 0x37, 0x00, 0x42, 0x87, 0x39,
-	0x03, 0x22, 0x08, 0xd3, 0xee, 0x2f, 0x5a, 0x09, 0xc4, 0x38, 0x80, 0x45, 0x72, 0x26, 0x98, 0x63,
-	0x93, 0x60, 0xa4, 0x2a, 0x6d, 0x69, 0xfb, 0x6e, 0x4e, 0x74, 0xb2, 0xda, 0x3a, 0xbb, 0x92, 0x6d,
-	0x37, 0x18, 0xe9, 0x9c, 0x58, 0x20, 0xb8, 0x6c, 0x7f, 0x03, 0x95, 0x14, 0xe9, 0x9d, 0xf2, 0xe1,
-	0x08, 0xea, 0x29, 0x55, 0x18, 0xc7, 0x1b, 0x50, 0x22, 0x67, 0x9e, 0xb0, 0xb9, 0x70, 0xc4, 0x90,
-	0x63, 0x80, 0x40, 0x42, 0x87, 0x0a, 0x51, 0x6d, 0x44, 0xb8, 0x74, 0x28, 0xe2, 0x36, 0xa2, 0x56,
-	0x88, 0x13, 0x16, 0xdd, 0x02, 0x5c, 0x99, 0x23, 0xa8, 0x3d, 0x22, 0x42, 0xd7, 0x95, 0x28, 0x7c,
-	0xab, 0x30, 0xaf, 0x1c, 0xd7, 0x19, 0xb7, 0x68, 0xe1, 0xca, 0xb8, 0x05, 0x15, 0x2f, 0xe8, 0xf9,
-	0x43, 0x97, 0xd8, 0x23, 0x8f, 0x9c, 0x72, 0xa5, 0x62, 0xc1, 0x2a, 0x23, 0xf8, 0x4a, 0x62, 0xc6,
-	0x87, 0x50, 0x25, 0x67, 0x7a, 0x13, 0x0a, 0xd1, 0x6d, 0xab, 0x82, 0xa8, 0x2a, 0xd0, 0xdc, 0x24,
-	0xb0, 0x9c, 0xd0, 0x8b, 0xde, 0x1d, 0xc0, 0xb2, 0xae, 0x8c, 0x89, 0x62, 0xff, 0x2e, 0xd5, 0xb6,
-	0xc6, 0xa7, 0x10, 0xb3, 0x09, 0x2b, 0x8f, 0x88, 0x48, 0xa4, 0x30, 0xfa, 0x68, 0xfe, 0x08, 0xab,
-	0xd3, 0x04, 0x34, 0xe2, 0xd7, 0x50, 0x4a, 0x5f, 0x3a, 0xa9, 0x7e, 0x23, 0x47, 0x7d, 0x92, 0x39,
-	0xc9, 0x62, 0x36, 0xc0, 0x38, 0x24, 0xc2, 0x22, 0x8e, 0xfb, 0x3c, 0xf0, 0xc7, 0x91, 0xc6, 0x15,
-	0xa8, 0xa7, 0x50, 0x4c, 0xe1, 0x09, 0xfc, 0x9a, 0x79, 0x82, 0x44, 0xbb, 0x57, 0xa1, 0x91, 0x86,
-	0x71, 0xfb, 0xb7, 0xb0, 0xac, 0x9b, 0xd3, 0x8b, 0x71, 0x18, 0x6d, 0x36, 0xbe, 0x84, 0x92, 0x36,
-	0xcf, 0x56, 0x0d, 0x5e, 0x9a, 0x5c, 0xdd, 0x6e, 0x74, 0xe2, 0x79, 0x45, 0xc5, 0x5c, 0x28, 0x0e,
-	0x10, 0xf1, 0xb7, 0xb4, 0x33, 0x29, 0x6b, 0x62, 0x90, 0x45, 0x8e, 0x18, 0xe1, 0xc7, 0x32, 0xa5,
-	0x92, 0x06, 0xa5, 0x61, 0xdc, 0xde, 0x84, 0x15, 0x6b, 0x18, 0x3c, 0x26, 0x8e, 0x2f, 0x8e, 0x55,
-	0xe3, 0x88, 0x18, 0x5a, 0xb0, 0x3a, 0x4d, 0x40, 0x96, 0x2f, 0xa0, 0xf5, 0xa4, 0x1f, 0x50, 0x46,
-	0x34, 0x71, 0x97, 0x31, 0xca, 0x52, 0x25, 0x45, 0x08, 0xc2, 0x82, 0x49, 0xa1, 0x50, 0x4b, 0x73,
-	0x1d, 0xd6, 0x72, 0xb8, 0x50, 0xe4, 0xd7, 0xd2, 0x68, 0x59, 0x4f, 0xd2, 0x99, 0x7c, 0x0b, 0x2a,
-	0xa7, 0x8e, 0x27, 0xec, 0x90, 0xf2, 0x49, 0x32, 0x2d, 0x5a, 0x65, 0x09, 0x1e, 0x20, 0xa6, 0x3d,
-	0x4b, 0xf2, 0xa2, 0xcc, 0x6d, 0x58, 0x3d, 0x60, 0xe4, 0xc8, 0xf7, 0xfa, 0xc7, 0x53, 0x17, 0x44,
-	0xce, 0x64, 0x2a, 0x70, 0xd1, 0x0d, 0x89, 0x96, 0x66, 0x1f, 0x9a, 0x19, 0x1e, 0xcc, 0xab, 0x7d,
-	0xa8, 0xea, 0x5d, 0x36, 0x53, 0x73, 0x45, 0x54, 0xcf, 0x3f, 0x3c, 0x37, 0xb3, 0x93, 0x53, 0x88,
-	0x55, 0xe9, 0x25, 0x56, 0xdc, 0xfc, 0x57, 0x01, 0x8c, 0x9d, 0x30, 0xf4, 0xc7, 0x69, 0xcb, 0x6a,
-	0x30, 0xc3, 0xdf, 0xf8, 0x51, 0x89, 0xe1, 0x6f, 0x7c, 0x59, 0x62, 0x8e, 0x28, 0xeb, 0x11, 0xbc,
-	0xac, 0x7a, 0x21, 0xc7, 0x00, 0xc7, 0xf7, 0xe9, 0xa9, 0x9d, 0x98, 0x61, 0x55, 0x65, 0x58, 0xb0,
-	0x6a, 0x8a, 0x60, 0x4d, 0xf0, 0xec, 0x00, 0x34, 0xfb, 0xbe, 0x06, 0xa0, 0xb9, 0x2b, 0x0e, 0x40,
-	0x7f, 0x2d, 0x40, 0x3d, 0xe5, 0x3d, 0xc6, 0xf8, 0xe7, 0x37, 0xaa, 0xd5, 0x61, 0x79, 0x9f, 0xf6,
-	0x4e, 0x74, 0xd5, 0x8b, 0xae, 0x46, 0x03, 0x8c, 0x24, 0x38, 0xb9, 0x78, 0x2f, 0x03, 0x3f, 0xb3,
-	0x79, 0x15, 0x1a, 0x69, 0x18, 0xb7, 0xff, 0xad, 0x00, 0x2d, 0x6c, 0x11, 0x7b, 0x44, 0xf4, 0x8e,
-	0x77, 0xf8, 0xc3, 0x6e, 0x9c, 0x07, 0x0d, 0x98, 0x53, 0xa3, 0xb8, 0x0a, 0x40, 0xd9, 0xd2, 0x0b,
-	0xa3, 0x09, 0xd7, 0xdc, 0xae, 0xad, 0x5a, 0x23, 0x76, 0x07, 0xb7, 0xfb, 0x9d, 0x6c, 0x8e, 0x6b,
-	0xb0, 0x30, 0x70, 0xce, 0x6c, 0x46, 0x4f, 0x39, 0x0e, 0x83, 0xd7, 0x06, 0xce, 0x99, 0x45, 0x4f,
-	0xb9, 0x1a, 0xd4, 0x3d, 0xae, 0x26, 0xf0, 0xae, 0x17, 0xf8, 0xb4, 0xcf, 0xd5, 0xf1, 0x2f, 0x58,
-	0x55, 0x84, 0xef, 0x6b, 0x54, 0xde, 0x35, 0xa6, 0xae, 0x51, 0xf2, 0x70, 0x17, 0xac, 0x32, 0x4b,
-	0xdc, 0x2d, 0xf3, 0x11, 0xac, 0xe5, 0xd8, 0x8c, 0xa7, 0xf7, 0x09, 0xcc, 0xeb, 0xab, 0x81, 0xc7,
-	0x66, 0xe0, 0x73, 0xe2, 0x7b, 0xf9, 0x17, 0xaf, 0x01, 0xee, 0x30, 0x7f, 0x5f, 0x80, 0xeb, 0x69,
-	0x49, 0x3b, 0xbe, 0x2f, 0x07, 0x30, 0xfe, 0xfe, 0x43, 0x90, 0xf1, 0x6c, 0x36, 0xc7, 0xb3, 0x7d,
-	0xd8, 0x38, 0xcf, 0x9e, 0x2b, 0xb8, 0xf7, 0x74, 0xfa, 0x6c, 0x77, 0xc2, 0xf0, 0x62, 0xc7, 0x92,
-	0xf6, 0x17, 0x53, 0xf6, 0x67, 0x83, 0xae, 0x84, 0x5d, 0xc1, 0xaa, 0x36, 0xb4, 0x12, 0x75, 0x41,
-	0x4f, 0x1c, 0x51, 0x9a, 0xee, 0xc3, 0x5a, 0x0e, 0x0d, 0x95, 0x6c, 0xc9, 0xe9, 0x23, 0x9e, 0x58,
-	0x4a, 0xdb, 0xcd, 0xce, 0xf4, 0xdb, 0x19, 0x19, 0x70, 0x9b, 0xbc, 0x0b, 0xcf, 0x1c, 0x2e, 0xaf,
-	0x51, 0x4a, 0xc9, 0x33, 0x68, 0xa4, 0x61, 0x94, 0xff, 0xe5, 0x94, 0xfc, 0xeb, 0x19, 0xf9, 0x29,
-	0xb6, 0x48, 0x4b, 0x13, 0x56, 0x34, 0x1e, 0xf5, 0x82, 0x48, 0xcf, 0x17, 0xb0, 0x3a, 0x4d, 0x40,
-	0x4d, 0x6d, 0x58, 0x98, 0x6a, 0x26, 0xf1, 0x5a, 0x72, 0xbd, 0x76, 0x3c, 0xb1, 0x47, 0xa7, 0xe5,
-	0x5d, 0xc8, 0xb5, 0x06, 0xcd, 0x0c, 0x17, 0x5e, 0xf1, 0x16, 0xac, 0x1e, 0x0a, 0x1a, 0x26, 0xe2,
-	0x1a, 0x19, 0xb8, 0x06, 0xcd, 0x0c, 0x05, 0x99, 0x7e, 0x0b, 0xd7, 0xa7, 0x48, 0xcf, 0xbc, 0xc0,
-	0x1b, 0x0c, 0x07, 0x97, 0x30, 0xc6, 0xb8, 0x09, 0xaa, 0x37, 0xda, 0xc2, 0x1b, 0x90, 0x68, 0x88,
-	0x9c, 0xb1, 0x4a, 0x12, 0x7b, 0xa1, 0x21, 0xf3, 0x17, 0xb0, 0x71, 0x9e, 0xfc, 0x4b, 0xc4, 0x48,
-	0x19, 0xee, 0x30, 0x91, 0xe3, 0x53, 0x1b, 0x5a, 0x59, 0x12, 0x3a, 0xd5, 0x85, 0x9b, 0xd3, 0xb4,
-	0x97, 0x81, 0xf0, 0xfc, 0x1d, 0x59, 0x6a, 0xdf, 0x93, 0x63, 0xb7, 0xc1, 0xbc, 0x48, 0x07, 0x5a,
-	0xd2, 0x00, 0xe3, 0x11, 0x89, 0xf6, 0xc4, 0x89, 0xf9, 0x29, 0xd4, 0x53, 0x28, 0x46, 0xa2, 0x01,
-	0x73, 0x8e, 0xeb, 0xb2, 0x68, 0x4c, 0xd0, 0x0b, 0x19, 0x03, 0x8b, 0x70, 0x72, 0x4e, 0x0c, 0xb2,
-	0x24, 0xd4, 0xbc, 0x05, 0xcd, 0x57, 0x09, 0x5c, 0x5e, 0xe9, 0xdc, 0x92, 0xb0, 0x88, 0x25, 0xc1,
-	0xdc, 0x83, 0x56, 0x96, 0xe1, 0x4a, 0xc5, 0xe8, 0x7a, 0x52, 0xce, 0x24, 0x5b, 0x23, 0xf5, 0x55,
-	0x28, 0x7a, 0x2e, 0x3e, 0x46, 0x8a, 0x9e, 0x9b, 0x3a, 0x88, 0xe2, 0x54, 0x02, 0x6c, 0xc2, 0xc6,
-	0x79, 0xc2, 0xd0, 0xcf, 0x3a, 0x2c, 0x3f, 0x09, 0x3c, 0xa1, 0x2f, 0x60, 0x14, 0x98, 0xcf, 0xc0,
-	0x48, 0x82, 0x97, 0xc8, 0xb4, 0x9f, 0x0a, 0xb0, 0x71, 0x40, 0xc3, 0xa1, 0xaf, 0xa6, 0xd5, 0xd0,
-	0x61, 0x24, 0x10, 0xdf, 0xd2, 0x21, 0x0b, 0x1c, 0x3f, 0xb2, 0xfb, 0x23, 0x58, 0x92, 0xf9, 0x60,
-	0xf7, 0x18, 0x71, 0x04, 0x71, 0xed, 0x20, 0x7a, 0x51, 0x55, 0x24, 0xfc, 0x40, 0xa3, 0xdf, 0x71,
-	0xf9, 0xea, 0x72, 0x7a, 0x52, 0x68, 0xb2, 0x71, 0x80, 0x86, 0x54, 0xf3, 0xf8, 0x0a, 0xca, 0x03,
-	0x65, 0x99, 0xed, 0xf8, 0x9e, 0xa3, 0x1b, 0x48, 0x69, 0x7b, 0x65, 0x7a, 0x02, 0xdf, 0x91, 0x44,
-	0xab, 0xa4, 0xb7, 0xaa, 0x85, 0xf1, 0x39, 0x34, 0x12, 0xa5, 0x6a, 0x32, 0xa8, 0xce, 0x2a, 0x1d,
-	0xf5, 0x04, 0x2d, 0x9e, 0x57, 0x6f, 0xc2, 0x8d, 0x73, 0xfd, 0xc2, 0x10, 0xfe, 0xa9, 0xa0, 0xc3,
-	0x85, 0x81, 0x8e, 0xfc, 0xfd, 0x7f, 0x98, 0xd7, 0xfb, 0xf1, 0xd0, 0xcf, 0x31, 0x10, 0x37, 0x9d,
-	0x6b, 0x5b, 0xf1, 0x5c, 0xdb, 0xf2, 0x22, 0x3a, 0x93, 0x13, 0x51, 0x59, 0xdf, 0x53, 0xf6, 0x4d,
-	0x46, 0xa0, 0x87, 0x64, 0x40, 0x05, 0x49, 0x1f, 0xfe, 0x1f, 0x0a, 0xd0, 0x48, 0xe3, 0x78, 0xfe,
-	0x77, 0xa1, 0xee, 0x92, 0x90, 0x91, 0x9e, 0x52, 0x96, 0x4e, 0x85, 0xfb, 0xc5, 0x56, 0xc1, 0x32,
-	0x26, 0xe4, 0xd8, 0xc6, 0xfb, 0x50, 0xc1, 0xc3, 0xc2, 0x9e, 0x51, 0xbc, 0x4c, 0xcf, 0xc0, 0x03,
-	0xd6, 0x2b, 0x79, 0x85, 0x5f, 0x06, 0x2e, 0xcd, 0x33, 0xb6, 0x0d, 0xad, 0x2c, 0x09, 0xfd, 0x5b,
-	0x8f, 0x9b, 0xe4, 0x6b, 0x87, 0x1f, 0x30, 0x2a, 0xb7, 0xb8, 0x11, 0xe3, 0x07, 0xd0, 0xce, 0x23,
-	0x22, 0xeb, 0xdf, 0x0b, 0x50, 0x3b, 0x24, 0xe9, 0x5b, 0xf1, 0xae, 0x07, 0x9a, 0x73, 0x3a, 0xc5,
-	0xbc, 0x7c, 0xbf, 0x07, 0x4d, 0xf5, 0x4c, 0x90, 0x01, 0x62, 0x22, 0xe7, 0x8d, 0xb0, 0xa2, 0xc8,
-	0xd3, 0xd5, 0x32, 0xfb, 0xdc, 0x9a, 0xcd, 0x79, 0x6e, 0xd5, 0x61, 0x39, 0xe1, 0x07, 0x7a, 0xf7,
-	0x34, 0xe9, 0xbb, 0x45, 0x94, 0xde, 0x38, 0x32, 0xef, 0xe8, 0xa6, 0x79, 0x1d, 0xd6, 0x73, 0x85,
-	0xa1, 0xae, 0xdf, 0xc9, 0x3a, 0x9f, 0x6a, 0x60, 0x3b, 0x81, 0xfb, 0x88, 0x88, 0xd4, 0xa8, 0x61,
-	0xfc, 0x00, 0x2b, 0x5c, 0xd0, 0x30, 0xe9, 0xbc, 0x3d, 0xa0, 0x6e, 0xf4, 0xba, 0xbe, 0x9d, 0x33,
-	0xc1, 0xa4, 0x9b, 0x22, 0x75, 0x89, 0x55, 0xe7, 0x59, 0x50, 0x3e, 0x5e, 0x6e, 0x5d, 0x68, 0x40,
-	0xfc, 0x43, 0x44, 0xe5, 0x78, 0xdc, 0x65, 0x9e, 0x6b, 0x5f, 0x6a, 0x76, 0x52, 0xf9, 0x5e, 0xd6,
-	0x1c, 0xf8, 0x63, 0xd0, 0xaf, 0xe2, 0xb1, 0x48, 0xa7, 0xf8, 0x47, 0x6f, 0x33, 0x3a, 0x3b, 0x1f,
-	0x61, 0x1e, 0xa6, 0x0b, 0x89, 0x9c, 0x74, 0xa6, 0x09, 0x97, 0xa8, 0xc8, 0x87, 0x50, 0xb9, 0xef,
-	0xf4, 0x4e, 0x86, 0xf1, 0x24, 0xbb, 0x09, 0xa5, 0x1e, 0x0d, 0x7a, 0x43, 0xc6, 0x48, 0xd0, 0x1b,
-	0x63, 0xed, 0x4d, 0x42, 0x72, 0x87, 0x7a, 0x8e, 0xea, 0x74, 0xc1, 0x37, 0x6c, 0x12, 0x32, 0xef,
-	0x41, 0x35, 0x12, 0x8a, 0x26, 0xdc, 0x86, 0x39, 0x32, 0x9a, 0x24, 0x4b, 0xb5, 0x13, 0xfd, 0x43,
-	0x66, 0x57, 0xa2, 0x96, 0x26, 0x62, 0xa7, 0x15, 0x94, 0x91, 0x3d, 0x46, 0x07, 0x29, 0xbb, 0xcc,
-	0x1d, 0x79, 0x4d, 0x33, 0xb4, 0x77, 0x12, 0xdf, 0x00, 0xe3, 0xd0, 0x77, 0x46, 0x24, 0x3d, 0xbf,
-	0xee, 0x41, 0x3d, 0x85, 0x5e, 0x75, 0x3c, 0x36, 0xa0, 0x26, 0x4f, 0x4e, 0xc9, 0x8a, 0x64, 0xcb,
-	0x7b, 0x35, 0xc1, 0x30, 0xd7, 0x7f, 0xd0, 0x73, 0xa2, 0x02, 0xdf, 0xef, 0x18, 0x78, 0x4f, 0x4e,
-	0x6b, 0xd3, 0x92, 0x2f, 0x91, 0x04, 0xca, 0x4c, 0x87, 0x89, 0x94, 0xed, 0x32, 0x5a, 0x09, 0x10,
-	0x8d, 0xff, 0x0d, 0xac, 0x4f, 0xd0, 0xf7, 0x3e, 0xee, 0x6d, 0xc0, 0x07, 0xf9, 0xd2, 0x51, 0xbb,
-	0xa1, 0x7f, 0x19, 0x95, 0xd4, 0xf8, 0xfc, 0xfe, 0x4f, 0xff, 0x6a, 0x89, 0xd8, 0x85, 0x43, 0xde,
-	0x1f, 0x0b, 0x50, 0x93, 0x2d, 0x2e, 0xe9, 0xe7, 0xcf, 0xa8, 0x01, 0xe3, 0x90, 0x95, 0x0e, 0xb8,
-	0x1c, 0xce, 0x25, 0x90, 0xd3, 0x9c, 0xe4, 0x70, 0x9e, 0x21, 0x21, 0xdb, 0x93, 0x09, 0xed, 0xbf,
-	0x2d, 0xdd, 0xeb, 0xb0, 0x96, 0x23, 0x2a, 0xce, 0x87, 0xf2, 0xab, 0xb7, 0x4e, 0xbd, 0x32, 0x2d,
-	0x4e, 0x29, 0x3b, 0x39, 0xf2, 0xe9, 0x69, 0x34, 0x7c, 0x46, 0x6b, 0x49, 0x3b, 0x21, 0x63, 0x1e,
-	0x3a, 0x3d, 0x82, 0xbf, 0x83, 0xc7, 0x6b, 0xf3, 0x1b, 0xa8, 0xbc, 0xba, 0xea, 0x88, 0x7c, 0xff,
-	0xb3, 0x1f, 0x3b, 0x23, 0x4f, 0x10, 0xce, 0x3b, 0x1e, 0xdd, 0xd2, 0x5f, 0x5b, 0x7d, 0xba, 0x35,
-	0x12, 0x5b, 0xea, 0xbf, 0xc0, 0x5b, 0x99, 0x9f, 0x8d, 0xba, 0xf3, 0x8a, 0x70, 0xf7, 0x3f, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x82, 0x1c, 0x56, 0x23, 0x8f, 0x1e, 0x00, 0x00,
+	// 2510 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0x5b, 0x73, 0xdb, 0xc6,
+	0x15, 0x2e, 0xa8, 0x8b, 0xa5, 0xc3, 0x8b, 0x28, 0x90, 0x12, 0x29, 0xca, 0x96, 0x65, 0xd8, 0x8e,
+	0x5d, 0x67, 0x2a, 0x25, 0x72, 0xe2, 0xc9, 0x24, 0x6d, 0xa6, 0xba, 0xdb, 0xb1, 0x6e, 0x81, 0x24,
+	0x3b, 0x93, 0xe9, 0x14, 0x03, 0x12, 0x2b, 0x09, 0x23, 0x10, 0x0b, 0xef, 0x2e, 0x48, 0xf1, 0xa5,
+	0x2f, 0x7d, 0x6f, 0xff, 0x40, 0xa7, 0x2f, 0x9d, 0x69, 0xdf, 0xfa, 0xd0, 0x1f, 0xd1, 0x9f, 0x90,
+	0xfe, 0x94, 0x3e, 0xf4, 0xa1, 0x9d, 0xbd, 0x00, 0x04, 0x48, 0xe8, 0x62, 0xd5, 0x93, 0xc9, 0x8b,
+	0x06, 0xfb, 0x9d, 0xfb, 0xd9, 0xb3, 0x67, 0x0f, 0x40, 0x41, 0x8d, 0xd9, 0x4d, 0x0f, 0xb1, 0xb6,
+	0xed, 0xdb, 0xa7, 0x88, 0x38, 0x36, 0xb3, 0x97, 0x02, 0x82, 0x19, 0xd6, 0xa7, 0x87, 0x08, 0x8d,
+	0xfc, 0xbb, 0x10, 0x91, 0x9e, 0xa4, 0x37, 0x4a, 0x0c, 0x07, 0xb8, 0xcf, 0xdf, 0x98, 0x21, 0x28,
+	0xf0, 0xdc, 0x96, 0xcd, 0x5c, 0xec, 0x27, 0xe0, 0xa2, 0x87, 0x4f, 0x43, 0xe6, 0x7a, 0x72, 0x69,
+	0xfc, 0x57, 0x83, 0xa9, 0x23, 0xae, 0x78, 0x03, 0x9d, 0xb8, 0xbe, 0xcb, 0x99, 0x75, 0x1d, 0x46,
+	0x7d, 0xbb, 0x8d, 0xea, 0xda, 0xa2, 0xf6, 0x74, 0xd2, 0x14, 0xcf, 0xfa, 0x2c, 0x8c, 0xd3, 0xd6,
+	0x19, 0x6a, 0xdb, 0xf5, 0x9c, 0x40, 0xd5, 0x4a, 0xaf, 0xc3, 0x9d, 0x16, 0xf6, 0xc2, 0xb6, 0x4f,
+	0xeb, 0x23, 0x8b, 0x23, 0x4f, 0x27, 0xcd, 0x68, 0xa9, 0x2f, 0x41, 0x25, 0x20, 0x6e, 0xdb, 0x26,
+	0x3d, 0xeb, 0x1c, 0xf5, 0xac, 0x88, 0x6b, 0x54, 0x70, 0x4d, 0x2b, 0xd2, 0x6b, 0xd4, 0x5b, 0x57,
+	0xfc, 0x3a, 0x8c, 0xb2, 0x5e, 0x80, 0xea, 0x63, 0xd2, 0x2a, 0x7f, 0xd6, 0xef, 0x43, 0x9e, 0xbb,
+	0x6e, 0x79, 0xc8, 0x3f, 0x65, 0x67, 0xf5, 0xf1, 0x45, 0xed, 0xe9, 0xa8, 0x09, 0x1c, 0xda, 0x11,
+	0x88, 0x3e, 0x0f, 0x93, 0x04, 0x77, 0xad, 0x16, 0x0e, 0x7d, 0x56, 0xbf, 0x23, 0xc8, 0x13, 0x04,
+	0x77, 0xd7, 0xf9, 0x5a, 0x7f, 0x04, 0xe3, 0x27, 0x2e, 0xf2, 0x1c, 0x5a, 0x9f, 0x58, 0x1c, 0x79,
+	0x9a, 0x5f, 0x29, 0x2c, 0xc9, 0x7c, 0x6d, 0x71, 0xd0, 0x54, 0x34, 0xe3, 0xaf, 0x1a, 0x94, 0x0f,
+	0x45, 0x30, 0x89, 0x14, 0x3c, 0x81, 0x29, 0x6e, 0xa5, 0x69, 0x53, 0x64, 0xa9, 0xb8, 0x65, 0x36,
+	0x4a, 0x11, 0x2c, 0x45, 0xf4, 0x7d, 0x90, 0xfb, 0x62, 0x39, 0xb1, 0x30, 0xad, 0xe7, 0x84, 0x39,
+	0x63, 0x69, 0x78, 0x2b, 0x07, 0x52, 0x6d, 0x96, 0x59, 0x1a, 0xa0, 0x3c, 0xa1, 0x1d, 0x44, 0xa8,
+	0x8b, 0xfd, 0xfa, 0x88, 0xb0, 0x18, 0x2d, 0xb9, 0xa3, 0xba, 0xb4, 0xba, 0x7e, 0x66, 0xfb, 0xa7,
+	0xc8, 0x44, 0x34, 0xf4, 0x98, 0xfe, 0x12, 0x8a, 0x4d, 0x74, 0x82, 0x49, 0xca, 0xd1, 0xfc, 0xca,
+	0xc3, 0x0c, 0xeb, 0x83, 0x61, 0x9a, 0x05, 0x29, 0xa9, 0x62, 0xd9, 0x82, 0x82, 0x7d, 0xc2, 0x10,
+	0xb1, 0x12, 0x3b, 0x7d, 0x43, 0x45, 0x79, 0x21, 0x28, 0x61, 0xe3, 0xdf, 0x1a, 0x94, 0x8e, 0x29,
+	0x22, 0x07, 0x88, 0xb4, 0x5d, 0x4a, 0x55, 0x49, 0x9d, 0x61, 0xca, 0xa2, 0x92, 0xe2, 0xcf, 0x1c,
+	0x0b, 0x29, 0x22, 0xaa, 0xa0, 0xc4, 0xb3, 0xfe, 0x31, 0x4c, 0x07, 0x36, 0xa5, 0x5d, 0x4c, 0x1c,
+	0xab, 0x75, 0x86, 0x5a, 0xe7, 0x34, 0x6c, 0x8b, 0x3c, 0x8c, 0x9a, 0xe5, 0x88, 0xb0, 0xae, 0x70,
+	0xfd, 0x5b, 0x80, 0x80, 0xb8, 0x1d, 0xd7, 0x43, 0xa7, 0x48, 0x16, 0x56, 0x7e, 0xe5, 0xd3, 0x0c,
+	0x6f, 0xd3, 0xbe, 0x2c, 0x1d, 0xc4, 0x32, 0x9b, 0x3e, 0x23, 0x3d, 0x33, 0xa1, 0xa4, 0xf1, 0x2b,
+	0x98, 0x1a, 0x20, 0xeb, 0x65, 0x18, 0x39, 0x47, 0x3d, 0xe5, 0x39, 0x7f, 0xd4, 0xab, 0x30, 0xd6,
+	0xb1, 0xbd, 0x10, 0x29, 0xcf, 0xe5, 0xe2, 0xcb, 0xdc, 0x17, 0x9a, 0xf1, 0x83, 0x06, 0x85, 0x8d,
+	0xe6, 0x35, 0x71, 0x97, 0x20, 0xe7, 0x34, 0x95, 0x6c, 0xce, 0x69, 0xc6, 0x79, 0x18, 0x49, 0xe4,
+	0x61, 0x3f, 0x23, 0xb4, 0xe5, 0x8c, 0xd0, 0x36, 0x9a, 0x3f, 0x4e, 0x60, 0x7f, 0xd1, 0x20, 0xdf,
+	0xb7, 0x44, 0xf5, 0x1d, 0x28, 0x73, 0x3f, 0xad, 0xa0, 0x8f, 0xd5, 0x35, 0xe1, 0xe5, 0x83, 0x6b,
+	0x37, 0xc0, 0x9c, 0x0a, 0x53, 0x6b, 0xaa, 0x6f, 0x41, 0xc9, 0x69, 0xa6, 0x74, 0xc9, 0x13, 0x74,
+	0xff, 0x9a, 0x88, 0xcd, 0xa2, 0x93, 0x58, 0x51, 0xe3, 0x09, 0xe4, 0x0f, 0x5c, 0xff, 0xd4, 0x44,
+	0xef, 0x42, 0x44, 0x19, 0x3f, 0x4a, 0x81, 0xdd, 0xf3, 0xb0, 0xed, 0xa8, 0x20, 0xa3, 0xa5, 0xf1,
+	0x14, 0x0a, 0x92, 0x91, 0x06, 0xd8, 0xa7, 0xe8, 0x0a, 0xce, 0x67, 0x50, 0x38, 0xf4, 0x10, 0x0a,
+	0x22, 0x9d, 0x0d, 0x98, 0x70, 0x42, 0x22, 0x9a, 0xaa, 0x60, 0x1d, 0x31, 0xe3, 0xb5, 0x31, 0x05,
+	0x45, 0xc5, 0x2b, 0xd5, 0x1a, 0xff, 0xd2, 0x40, 0xdf, 0xbc, 0x40, 0xad, 0x90, 0xa1, 0x97, 0x18,
+	0x9f, 0x47, 0x3a, 0xb2, 0xfa, 0xeb, 0x02, 0x40, 0x60, 0x13, 0xbb, 0x8d, 0x18, 0x22, 0x32, 0xfc,
+	0x49, 0x33, 0x81, 0xe8, 0x07, 0x30, 0x89, 0x2e, 0x18, 0xb1, 0x2d, 0xe4, 0x77, 0x44, 0xa7, 0xcd,
+	0xaf, 0x3c, 0xcf, 0xc8, 0xce, 0xb0, 0xb5, 0xa5, 0x4d, 0x2e, 0xb6, 0xe9, 0x77, 0x64, 0x4d, 0x4c,
+	0x20, 0xb5, 0x6c, 0x7c, 0x05, 0xc5, 0x14, 0xe9, 0xbd, 0xea, 0xe1, 0x04, 0x2a, 0x29, 0x53, 0x2a,
+	0x8f, 0xf7, 0x21, 0x8f, 0x2e, 0x5c, 0x66, 0x51, 0x66, 0xb3, 0x90, 0xaa, 0x04, 0x01, 0x87, 0x0e,
+	0x05, 0x22, 0xae, 0x11, 0xe6, 0xe0, 0x90, 0xc5, 0xd7, 0x88, 0x58, 0x29, 0x1c, 0x91, 0xe8, 0x14,
+	0xa8, 0x95, 0xd1, 0x81, 0xf2, 0x36, 0x62, 0xb2, 0xaf, 0x44, 0xe9, 0x9b, 0x85, 0x71, 0x11, 0xb8,
+	0xac, 0xb8, 0x49, 0x53, 0xad, 0xf4, 0x87, 0x50, 0x74, 0xfd, 0x96, 0x17, 0x3a, 0xc8, 0xea, 0xb8,
+	0xa8, 0x4b, 0x85, 0x89, 0x09, 0xb3, 0xa0, 0xc0, 0x37, 0x1c, 0xd3, 0x1f, 0x43, 0x09, 0x5d, 0x48,
+	0x26, 0xa5, 0x44, 0x5e, 0x5b, 0x45, 0x85, 0x8a, 0x06, 0x4d, 0x0d, 0x04, 0xd3, 0x09, 0xbb, 0x2a,
+	0xba, 0x03, 0x98, 0x96, 0x9d, 0x31, 0xd1, 0xec, 0xdf, 0xa7, 0xdb, 0x96, 0xe9, 0x00, 0x62, 0xd4,
+	0x60, 0x66, 0x1b, 0xb1, 0x44, 0x09, 0xab, 0x18, 0x8d, 0xef, 0x61, 0x76, 0x90, 0xa0, 0x9c, 0xf8,
+	0x35, 0xe4, 0xd3, 0x87, 0x8e, 0x9b, 0x5f, 0xc8, 0x30, 0x9f, 0x14, 0x4e, 0x8a, 0x18, 0x55, 0xd0,
+	0x0f, 0x11, 0x33, 0x91, 0xed, 0xec, 0xfb, 0x5e, 0x2f, 0xb2, 0x38, 0x03, 0x95, 0x14, 0xaa, 0x4a,
+	0xb8, 0x0f, 0xbf, 0x25, 0x2e, 0x43, 0x11, 0xf7, 0x2c, 0x54, 0xd3, 0xb0, 0x62, 0xff, 0x06, 0xa6,
+	0xe5, 0xe5, 0x74, 0xd4, 0x0b, 0x22, 0x66, 0xfd, 0x73, 0xc8, 0x4b, 0xf7, 0x2c, 0x71, 0xc1, 0x73,
+	0x97, 0x4b, 0x2b, 0xd5, 0xa5, 0x78, 0x5e, 0x11, 0x39, 0x67, 0x42, 0x02, 0x58, 0xfc, 0xcc, 0xfd,
+	0x4c, 0xea, 0xea, 0x3b, 0x64, 0xa2, 0x13, 0x82, 0xe8, 0x19, 0x2f, 0xa9, 0xa4, 0x43, 0x69, 0x58,
+	0xb1, 0xd7, 0x60, 0xc6, 0x0c, 0xfd, 0x97, 0xc8, 0xf6, 0xd8, 0x99, 0xb8, 0x38, 0x22, 0x81, 0x3a,
+	0xcc, 0x0e, 0x12, 0x94, 0xc8, 0x67, 0x50, 0x7f, 0x75, 0xea, 0x63, 0x82, 0x24, 0x71, 0x93, 0x10,
+	0x4c, 0x52, 0x2d, 0x85, 0x31, 0x44, 0xfc, 0x7e, 0xa3, 0x10, 0x4b, 0x63, 0x1e, 0xe6, 0x32, 0xa4,
+	0x94, 0xca, 0x2f, 0xb9, 0xd3, 0xbc, 0x9f, 0xa4, 0x2b, 0xf9, 0x21, 0x14, 0xbb, 0xb6, 0xcb, 0xac,
+	0x00, 0xd3, 0x7e, 0x31, 0x4d, 0x9a, 0x05, 0x0e, 0x1e, 0x28, 0x4c, 0x46, 0x96, 0x94, 0x55, 0x3a,
+	0x57, 0x60, 0xf6, 0x80, 0xa0, 0x13, 0xcf, 0x3d, 0x3d, 0x1b, 0x38, 0x20, 0x7c, 0x26, 0x13, 0x89,
+	0x8b, 0x4e, 0x48, 0xb4, 0x34, 0x4e, 0xa1, 0x36, 0x24, 0xa3, 0xea, 0x6a, 0x07, 0x4a, 0x92, 0xcb,
+	0x22, 0x62, 0xae, 0x88, 0xfa, 0xf9, 0xe3, 0x4b, 0x2b, 0x3b, 0x39, 0x85, 0x98, 0xc5, 0x56, 0x62,
+	0x45, 0x8d, 0xff, 0x68, 0xa0, 0xaf, 0x06, 0x81, 0xd7, 0x4b, 0x7b, 0x56, 0x86, 0x11, 0xfa, 0xce,
+	0x8b, 0x5a, 0x0c, 0x7d, 0xe7, 0xf1, 0x16, 0x73, 0x82, 0x49, 0x0b, 0xa9, 0xc3, 0x2a, 0x17, 0x7c,
+	0x0c, 0xb0, 0x3d, 0x0f, 0x77, 0xad, 0xc4, 0x0c, 0x2b, 0x3a, 0xc3, 0x84, 0x59, 0x16, 0x04, 0xb3,
+	0x8f, 0x0f, 0x0f, 0x40, 0xa3, 0x1f, 0x6a, 0x00, 0x1a, 0xbb, 0xe5, 0x00, 0xf4, 0x37, 0x0d, 0x2a,
+	0xa9, 0xe8, 0x55, 0x8e, 0x7f, 0x7a, 0xa3, 0x5a, 0x05, 0xa6, 0x77, 0x70, 0xeb, 0x5c, 0x76, 0xbd,
+	0xe8, 0x68, 0x54, 0x41, 0x4f, 0x82, 0xfd, 0x83, 0x77, 0xec, 0x7b, 0x43, 0xcc, 0xb3, 0x50, 0x4d,
+	0xc3, 0x8a, 0xfd, 0x1f, 0x1a, 0xd4, 0xd5, 0x15, 0xb1, 0x85, 0x58, 0xeb, 0x6c, 0x95, 0x6e, 0x34,
+	0xe3, 0x3a, 0xa8, 0xc2, 0x98, 0x18, 0xc5, 0x45, 0x02, 0x0a, 0xa6, 0x5c, 0xe8, 0x35, 0xb8, 0xe3,
+	0x34, 0x2d, 0x71, 0x35, 0xaa, 0xdb, 0xc1, 0x69, 0xee, 0xf1, 0xcb, 0x71, 0x0e, 0x26, 0xda, 0xf6,
+	0x85, 0x45, 0x70, 0x97, 0xaa, 0x61, 0xf0, 0x4e, 0xdb, 0xbe, 0x30, 0x71, 0x97, 0x8a, 0x41, 0xdd,
+	0xa5, 0x62, 0x02, 0x6f, 0xba, 0xbe, 0x87, 0x4f, 0xa9, 0xd8, 0xfe, 0x09, 0xb3, 0xa4, 0xe0, 0x35,
+	0x89, 0xf2, 0xb3, 0x46, 0xc4, 0x31, 0x4a, 0x6e, 0xee, 0x84, 0x59, 0x20, 0x89, 0xb3, 0x65, 0x6c,
+	0xc3, 0x5c, 0x86, 0xcf, 0x6a, 0xf7, 0x9e, 0xc1, 0xb8, 0x3c, 0x1a, 0x6a, 0xdb, 0x74, 0xf5, 0x3a,
+	0xf1, 0x2d, 0xff, 0xab, 0x8e, 0x81, 0xe2, 0x30, 0xfe, 0xa0, 0xc1, 0xbd, 0xb4, 0xa6, 0x55, 0xcf,
+	0xe3, 0x03, 0x18, 0xfd, 0xf0, 0x29, 0x18, 0x8a, 0x6c, 0x34, 0x23, 0xb2, 0x1d, 0x58, 0xb8, 0xcc,
+	0x9f, 0x5b, 0x84, 0xf7, 0x7a, 0x70, 0x6f, 0x57, 0x83, 0xe0, 0xea, 0xc0, 0x92, 0xfe, 0xe7, 0x52,
+	0xfe, 0x0f, 0x27, 0x5d, 0x28, 0xbb, 0x85, 0x57, 0x0d, 0xa8, 0x27, 0xfa, 0x82, 0x9c, 0x38, 0xa2,
+	0x32, 0xdd, 0x81, 0xb9, 0x0c, 0x9a, 0x32, 0xb2, 0xcc, 0xa7, 0x8f, 0x78, 0x62, 0xc9, 0xaf, 0xd4,
+	0x96, 0x06, 0xdf, 0x9d, 0x95, 0x80, 0x62, 0xe3, 0x67, 0x61, 0xd7, 0xa6, 0xfc, 0x18, 0xa5, 0x8c,
+	0xec, 0x42, 0x35, 0x0d, 0x2b, 0xfd, 0x9f, 0x0f, 0xe8, 0xbf, 0x37, 0xa4, 0x3f, 0x25, 0x16, 0x59,
+	0xa9, 0xc1, 0x8c, 0xc4, 0xa3, 0xbb, 0x20, 0xb2, 0xf3, 0x19, 0xcc, 0x0e, 0x12, 0x94, 0xa5, 0x06,
+	0x4c, 0x0c, 0x5c, 0x26, 0xf1, 0x9a, 0x4b, 0xbd, 0xb5, 0x5d, 0xb6, 0x85, 0x07, 0xf5, 0x5d, 0x29,
+	0x35, 0x07, 0xb5, 0x21, 0x29, 0x75, 0xc4, 0xeb, 0x30, 0x7b, 0xc8, 0x70, 0x90, 0xc8, 0x6b, 0xe4,
+	0xe0, 0x1c, 0xd4, 0x86, 0x28, 0x4a, 0xe8, 0xb7, 0x70, 0x6f, 0x80, 0xb4, 0xeb, 0xfa, 0x6e, 0x3b,
+	0x6c, 0xdf, 0xc0, 0x19, 0xfd, 0x01, 0x88, 0xbb, 0xd1, 0x62, 0x6e, 0x1b, 0x45, 0x43, 0xe4, 0x88,
+	0x99, 0xe7, 0xd8, 0x91, 0x84, 0x8c, 0x5f, 0xc2, 0xc2, 0x65, 0xfa, 0x6f, 0x90, 0x23, 0xe1, 0xb8,
+	0x4d, 0x58, 0x46, 0x4c, 0x0d, 0xa8, 0x0f, 0x93, 0x54, 0x50, 0x4d, 0x78, 0x30, 0x48, 0x3b, 0xf6,
+	0x99, 0xeb, 0xad, 0xf2, 0x56, 0xfb, 0x81, 0x02, 0x7b, 0x04, 0xc6, 0x55, 0x36, 0x94, 0x27, 0x55,
+	0xd0, 0xb7, 0x51, 0xc4, 0x13, 0x17, 0xe6, 0xc7, 0x50, 0x49, 0xa1, 0x2a, 0x13, 0x55, 0x18, 0xb3,
+	0x1d, 0x87, 0x44, 0x63, 0x82, 0x5c, 0xf0, 0x1c, 0x98, 0x88, 0xa2, 0x4b, 0x72, 0x30, 0x4c, 0x52,
+	0x96, 0x97, 0xa1, 0xf6, 0x26, 0x81, 0xf3, 0x23, 0x9d, 0xd9, 0x12, 0x26, 0x55, 0x4b, 0x30, 0xb6,
+	0xa0, 0x3e, 0x2c, 0x70, 0xab, 0x66, 0x74, 0x2f, 0xa9, 0xa7, 0x5f, 0xad, 0x91, 0xf9, 0x12, 0xe4,
+	0x5c, 0x47, 0xbd, 0x8c, 0xe4, 0x5c, 0x27, 0xb5, 0x11, 0xb9, 0x81, 0x02, 0x58, 0x84, 0x85, 0xcb,
+	0x94, 0xa9, 0x38, 0x2b, 0x30, 0xfd, 0xca, 0x77, 0x99, 0x3c, 0x80, 0x51, 0x62, 0x3e, 0x01, 0x3d,
+	0x09, 0xde, 0xa0, 0xd2, 0x7e, 0xd0, 0x60, 0xe1, 0x00, 0x07, 0xa1, 0x27, 0xa6, 0xd5, 0xc0, 0x26,
+	0xc8, 0x67, 0xdf, 0xe0, 0x90, 0xf8, 0xb6, 0x17, 0xf9, 0xfd, 0x11, 0x4c, 0xf1, 0x7a, 0xb0, 0x5a,
+	0x04, 0xd9, 0x0c, 0x39, 0x96, 0x1f, 0xbd, 0x51, 0x15, 0x39, 0xbc, 0x2e, 0xd1, 0x3d, 0xca, 0xdf,
+	0xba, 0xec, 0x16, 0x57, 0x9a, 0xbc, 0x38, 0x40, 0x42, 0xe2, 0xf2, 0xf8, 0x02, 0x0a, 0x6d, 0xe1,
+	0x99, 0x65, 0x7b, 0xae, 0x2d, 0x2f, 0x90, 0xfc, 0xca, 0xcc, 0xe0, 0x04, 0xbe, 0xca, 0x89, 0x66,
+	0x5e, 0xb2, 0x8a, 0x85, 0xfe, 0x29, 0x54, 0x13, 0xad, 0xaa, 0x3f, 0xa8, 0x8e, 0x0a, 0x1b, 0x95,
+	0x04, 0x2d, 0x9e, 0x57, 0x1f, 0xc0, 0xfd, 0x4b, 0xe3, 0x52, 0x29, 0xfc, 0xb3, 0x26, 0xd3, 0xa5,
+	0x12, 0x1d, 0xc5, 0xfb, 0x0b, 0x18, 0x97, 0xfc, 0x75, 0xed, 0x2a, 0x07, 0x15, 0xd3, 0xa5, 0xbe,
+	0xe5, 0x2e, 0xf5, 0x2d, 0x2b, 0xa3, 0x23, 0x19, 0x19, 0xe5, 0xfd, 0x3d, 0xe5, 0x5f, 0x7f, 0x04,
+	0xda, 0x40, 0x6d, 0xcc, 0x50, 0x7a, 0xf3, 0xff, 0xa8, 0x41, 0x35, 0x8d, 0xab, 0xfd, 0x7f, 0x0e,
+	0x15, 0x07, 0x05, 0x04, 0xb5, 0x84, 0xb1, 0x74, 0x29, 0xac, 0xe5, 0xea, 0x9a, 0xa9, 0xf7, 0xc9,
+	0xb1, 0x8f, 0x6b, 0x50, 0x54, 0x9b, 0xa5, 0xee, 0x8c, 0xdc, 0x4d, 0xee, 0x8c, 0x42, 0x3b, 0xb1,
+	0xe2, 0x47, 0xf8, 0xd8, 0x77, 0x70, 0x96, 0xb3, 0x0d, 0xa8, 0x0f, 0x93, 0x54, 0x7c, 0xf3, 0xf1,
+	0x25, 0xf9, 0xd6, 0xa6, 0x07, 0x04, 0x73, 0x16, 0x27, 0x12, 0xbc, 0x0b, 0x8d, 0x2c, 0xa2, 0x12,
+	0xfd, 0x27, 0xff, 0x8a, 0x8a, 0xd2, 0xa7, 0xe2, 0x7d, 0x37, 0x34, 0x63, 0x77, 0x72, 0x59, 0xf5,
+	0xfe, 0x02, 0x6a, 0xe2, 0x35, 0x81, 0x27, 0x88, 0xb0, 0x8c, 0x77, 0x84, 0x19, 0x41, 0x1e, 0xec,
+	0x96, 0xc3, 0xaf, 0x5b, 0xa3, 0x19, 0xaf, 0x5b, 0x15, 0x98, 0x4e, 0xc4, 0xa1, 0xa2, 0x7b, 0x9d,
+	0x8c, 0xdd, 0x44, 0xc2, 0x2e, 0x72, 0x6e, 0x17, 0xa6, 0x71, 0x0f, 0xe6, 0x33, 0x95, 0x29, 0x5b,
+	0xbf, 0xe3, 0x7d, 0x3e, 0x75, 0x81, 0xad, 0xfa, 0x0e, 0xff, 0x18, 0x91, 0x1c, 0x35, 0xf4, 0xef,
+	0x60, 0x86, 0x32, 0x1c, 0x24, 0x83, 0xb7, 0xda, 0xd8, 0x89, 0xde, 0xae, 0x1f, 0x65, 0x4c, 0x30,
+	0xe9, 0x4b, 0x11, 0x3b, 0xc8, 0xac, 0xd0, 0x61, 0x90, 0xbf, 0xbc, 0x3c, 0xbc, 0xd2, 0x81, 0xf8,
+	0x43, 0x44, 0xf1, 0xac, 0xd7, 0x24, 0xae, 0x63, 0xdd, 0x68, 0x76, 0x12, 0xf5, 0x5e, 0x90, 0x12,
+	0x12, 0xd1, 0xbf, 0x8e, 0xc7, 0x22, 0x59, 0xe2, 0x1f, 0x5d, 0xe7, 0xf4, 0xf0, 0x7c, 0xa4, 0xea,
+	0x30, 0xdd, 0x48, 0xf8, 0xa4, 0x33, 0x48, 0xb8, 0x41, 0x47, 0x3e, 0x84, 0xe2, 0x9a, 0xdd, 0x3a,
+	0x0f, 0xe3, 0x49, 0x76, 0x11, 0xf2, 0x2d, 0xec, 0xb7, 0x42, 0x42, 0x90, 0xdf, 0xea, 0xa9, 0xde,
+	0x9b, 0x84, 0x38, 0x87, 0x78, 0x1d, 0x95, 0xe5, 0xa2, 0xde, 0x61, 0x93, 0x90, 0xf1, 0x02, 0x4a,
+	0x91, 0x52, 0xe5, 0xc2, 0x23, 0x18, 0x43, 0x9d, 0x7e, 0xb1, 0x94, 0x96, 0xa2, 0x1f, 0x64, 0x36,
+	0x39, 0x6a, 0x4a, 0xa2, 0xba, 0x69, 0x19, 0x26, 0x68, 0x8b, 0xe0, 0x76, 0xca, 0x2f, 0x63, 0x15,
+	0xe6, 0x32, 0x68, 0xef, 0xa5, 0x9e, 0x7f, 0x03, 0xf2, 0xec, 0x0e, 0x4a, 0xcf, 0xaf, 0x5b, 0x50,
+	0x49, 0xa1, 0xb7, 0x1d, 0x8f, 0x75, 0x28, 0xf3, 0x9d, 0x13, 0xba, 0x22, 0xdd, 0xfc, 0x5c, 0xf5,
+	0x31, 0x55, 0xeb, 0xdf, 0x41, 0x2d, 0x06, 0x3f, 0xec, 0x18, 0xf8, 0x02, 0xea, 0xc3, 0x9a, 0x6f,
+	0x50, 0x04, 0xc2, 0x4d, 0x9b, 0xb0, 0x94, 0xef, 0x3c, 0x5b, 0x09, 0x50, 0x39, 0xff, 0x1b, 0x98,
+	0xef, 0xa3, 0x1f, 0x7c, 0xdc, 0x5b, 0x80, 0xbb, 0xd9, 0xda, 0x95, 0x75, 0x5d, 0x7e, 0x19, 0xe5,
+	0xd4, 0x78, 0xff, 0x7e, 0x0e, 0xd3, 0x09, 0xec, 0xca, 0x21, 0xef, 0x4f, 0x1a, 0x94, 0xf9, 0x15,
+	0x97, 0x8c, 0xf3, 0x27, 0x74, 0x01, 0xab, 0x21, 0x2b, 0x9d, 0x70, 0x3e, 0x9c, 0x73, 0x20, 0xe3,
+	0x72, 0xe2, 0xc3, 0xf9, 0x10, 0x49, 0x89, 0xbd, 0xea, 0xd3, 0xfe, 0xdf, 0xd6, 0x3d, 0x0f, 0x73,
+	0x19, 0xaa, 0xe2, 0x7a, 0x28, 0xbc, 0xb9, 0x76, 0xea, 0xe5, 0x65, 0xd1, 0xc5, 0xe4, 0xfc, 0xc4,
+	0xc3, 0xdd, 0x68, 0xf8, 0x8c, 0xd6, 0x9c, 0x76, 0x8e, 0x7a, 0x34, 0xb0, 0x5b, 0x48, 0x7d, 0x07,
+	0x8f, 0xd7, 0xc6, 0x57, 0x50, 0x7c, 0x73, 0xeb, 0x11, 0xf9, 0x21, 0x3c, 0xd8, 0x46, 0x6c, 0xc3,
+	0xa5, 0x8c, 0xb8, 0xcd, 0x90, 0x21, 0xe7, 0x88, 0xd8, 0x3e, 0x95, 0x13, 0x62, 0x5c, 0x3d, 0x7f,
+	0xd7, 0xc0, 0xb8, 0x8a, 0x4b, 0xd9, 0xfd, 0x1a, 0x0a, 0x2c, 0x81, 0xab, 0xcf, 0x84, 0x0d, 0x65,
+	0x3d, 0x21, 0xb2, 0x8b, 0x98, 0xcd, 0x33, 0x69, 0xa6, 0xf8, 0xf9, 0x17, 0xf8, 0x80, 0x88, 0xc1,
+	0xd0, 0xb1, 0x1c, 0xe6, 0x3a, 0xd1, 0xaf, 0x1d, 0xc5, 0x08, 0xdd, 0xe0, 0x20, 0x3f, 0x22, 0x27,
+	0xb6, 0xeb, 0xc5, 0x4c, 0xf2, 0x33, 0x7d, 0x5e, 0x62, 0x82, 0xc5, 0xf8, 0xbd, 0x06, 0x8f, 0x4c,
+	0x44, 0xb1, 0xd7, 0x41, 0xd9, 0x4e, 0x27, 0x7e, 0x70, 0xe1, 0x4a, 0xa2, 0x1f, 0x5c, 0xf8, 0xb3,
+	0xbe, 0x0d, 0xe3, 0x92, 0x49, 0xec, 0x42, 0x29, 0xfb, 0xd7, 0xb5, 0x4c, 0xad, 0xab, 0x52, 0xb7,
+	0x12, 0x37, 0x9e, 0xc0, 0xe3, 0x6b, 0x9c, 0x90, 0x89, 0x7b, 0xd6, 0x85, 0xbb, 0x57, 0x29, 0xd4,
+	0xf3, 0x70, 0xe7, 0x78, 0xef, 0xf5, 0xde, 0xfe, 0xdb, 0xbd, 0xf2, 0xcf, 0xf4, 0x02, 0x4c, 0xac,
+	0xef, 0xef, 0xad, 0xef, 0x1c, 0x6f, 0x6c, 0x96, 0x35, 0xbd, 0x02, 0x53, 0xeb, 0xfb, 0xbb, 0xbb,
+	0xaf, 0x8e, 0xac, 0x03, 0x73, 0xf3, 0x60, 0xd5, 0xdc, 0xdc, 0x28, 0xe7, 0xf4, 0x19, 0x98, 0x36,
+	0xf7, 0x77, 0x76, 0xd6, 0x56, 0xd7, 0x5f, 0xf7, 0xe1, 0x11, 0xbd, 0x0c, 0x85, 0xc3, 0xcd, 0x23,
+	0x2b, 0x22, 0x95, 0x47, 0xd7, 0x3e, 0xf9, 0x7e, 0xa9, 0xe3, 0x32, 0x44, 0xe9, 0x92, 0x8b, 0x97,
+	0xe5, 0xd3, 0xf2, 0x29, 0x5e, 0xee, 0xb0, 0x65, 0xf1, 0x3f, 0x00, 0xcb, 0x43, 0x81, 0x37, 0xc7,
+	0x05, 0xe1, 0xf9, 0xff, 0x06, 0x00, 0x4d, 0xa5, 0x6f, 0x7b, 0x8d, 0x20, 0x00, 0x00,
 }
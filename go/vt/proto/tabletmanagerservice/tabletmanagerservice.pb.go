@@ -29,78 +29,82 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("tabletmanagerservice.proto", fileDescriptor_9ee75fe63cfd9360) }
 
 var fileDescriptor_9ee75fe63cfd9360 = []byte{
-	// 1134 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x98, 0x6d, 0x6f, 0x23, 0x35,
-	0x10, 0xc7, 0xa9, 0x04, 0x27, 0xe1, 0xe3, 0xd1, 0x9c, 0x38, 0xa9, 0x48, 0x70, 0x70, 0x2d, 0x1c,
-	0xcd, 0x91, 0xdc, 0x03, 0xc7, 0xfb, 0xdc, 0x43, 0x7b, 0x45, 0x57, 0x11, 0x92, 0x6b, 0x8b, 0x40,
-	0x3a, 0xc9, 0x4d, 0xa6, 0xc9, 0xd2, 0xcd, 0x7a, 0xb1, 0x9d, 0x88, 0xbe, 0x42, 0xe2, 0x2d, 0x12,
-	0x9f, 0x8d, 0x8f, 0x84, 0xf6, 0xc1, 0xde, 0xf1, 0xee, 0xac, 0xb3, 0x79, 0x17, 0xe5, 0xff, 0xf3,
-	0x8c, 0x3d, 0x1e, 0x8f, 0x67, 0xcd, 0x76, 0x8d, 0xb8, 0x88, 0xc1, 0x2c, 0x45, 0x22, 0xe6, 0xa0,
-	0x34, 0xa8, 0x75, 0x34, 0x85, 0x7e, 0xaa, 0xa4, 0x91, 0xfc, 0x16, 0xa5, 0xed, 0xde, 0xf6, 0xfe,
-	0x9d, 0x09, 0x23, 0x0a, 0xfc, 0xd1, 0x7f, 0x3d, 0xf6, 0xfe, 0xeb, 0x5c, 0x3b, 0x29, 0x34, 0x7e,
-	0xcc, 0xde, 0x1e, 0x45, 0xc9, 0x9c, 0x7f, 0xde, 0x6f, 0x8e, 0xc9, 0x84, 0x31, 0xfc, 0xb1, 0x02,
-	0x6d, 0x76, 0xbf, 0x68, 0xd5, 0x75, 0x2a, 0x13, 0x0d, 0x5f, 0xbd, 0xc5, 0x5f, 0xb1, 0x77, 0x26,
-	0x31, 0x40, 0xca, 0x29, 0x36, 0x57, 0xac, 0xb1, 0x3b, 0xed, 0x80, 0xb3, 0xf6, 0x86, 0xdd, 0x7c,
-	0xf1, 0x27, 0x4c, 0x57, 0x06, 0x5e, 0x4a, 0x79, 0xc5, 0xf7, 0x89, 0x21, 0x48, 0xb7, 0x96, 0xbf,
-	0xde, 0x84, 0x39, 0xfb, 0xbf, 0xb0, 0x77, 0x8f, 0xc0, 0x4c, 0xa6, 0x0b, 0x58, 0x0a, 0x7e, 0x97,
-	0x18, 0xe6, 0x54, 0x6b, 0x7b, 0x2f, 0x0c, 0x39, 0xcb, 0x73, 0xf6, 0xc1, 0x11, 0x98, 0x11, 0xa8,
-	0x65, 0xa4, 0x75, 0x24, 0x13, 0xcd, 0xef, 0xd1, 0x23, 0x11, 0x62, 0x7d, 0x7c, 0xdb, 0x81, 0xc4,
-	0x21, 0x9a, 0x80, 0x19, 0x83, 0x98, 0xfd, 0x94, 0xc4, 0xd7, 0x64, 0x88, 0x90, 0x1e, 0x0a, 0x91,
-	0x87, 0x39, 0xfb, 0x82, 0xbd, 0x57, 0x0a, 0xe7, 0x2a, 0x32, 0xc0, 0x03, 0x23, 0x73, 0xc0, 0x7a,
-	0xf8, 0x66, 0x23, 0xe7, 0x5c, 0xfc, 0xc6, 0xd8, 0xb3, 0x85, 0x48, 0xe6, 0xf0, 0xfa, 0x3a, 0x05,
-	0x4e, 0x45, 0xb8, 0x92, 0xad, 0xf9, 0xfd, 0x0d, 0x14, 0x9e, 0xff, 0x18, 0x2e, 0x15, 0xe8, 0xc5,
-	0xc4, 0x88, 0x96, 0xf9, 0x63, 0x20, 0x34, 0x7f, 0x9f, 0xc3, 0x7b, 0x3d, 0x5e, 0x25, 0x2f, 0x41,
-	0xc4, 0x66, 0xf1, 0x6c, 0x01, 0xd3, 0x2b, 0x72, 0xaf, 0x7d, 0x24, 0xb4, 0xd7, 0x75, 0xd2, 0x39,
-	0x4a, 0xd9, 0xc7, 0xc7, 0xf3, 0x44, 0x2a, 0x28, 0xe4, 0x17, 0x4a, 0x49, 0xc5, 0x7b, 0x84, 0x85,
-	0x06, 0x65, 0xdd, 0xdd, 0xef, 0x06, 0xfb, 0xd1, 0x8b, 0xa5, 0x98, 0x95, 0x67, 0x84, 0x8e, 0x5e,
-	0x05, 0x84, 0xa3, 0x87, 0x39, 0xe7, 0xe2, 0x77, 0xf6, 0xe1, 0x48, 0xc1, 0x65, 0x1c, 0xcd, 0x17,
-	0xf6, 0x24, 0x52, 0x41, 0xa9, 0x31, 0xd6, 0xd1, 0x41, 0x17, 0x14, 0x1f, 0x96, 0x61, 0x9a, 0xc6,
-	0xd7, 0xa5, 0x1f, 0x2a, 0x89, 0x90, 0x1e, 0x3a, 0x2c, 0x1e, 0x86, 0x33, 0xf9, 0x95, 0x9c, 0x5e,
-	0xe5, 0xd5, 0x55, 0x93, 0x99, 0x5c, 0xc9, 0xa1, 0x4c, 0xc6, 0x14, 0xde, 0x8b, 0xd3, 0x24, 0xae,
-	0xcc, 0x53, 0xd3, 0xc2, 0x40, 0x68, 0x2f, 0x7c, 0x0e, 0x27, 0x58, 0x59, 0x28, 0x0f, 0xc1, 0x4c,
-	0x17, 0x43, 0xfd, 0xfc, 0x42, 0x90, 0x09, 0xd6, 0xa0, 0x42, 0x09, 0x46, 0xc0, 0xce, 0xe3, 0x5f,
-	0xec, 0x53, 0x5f, 0x1e, 0xc6, 0xf1, 0x48, 0x45, 0x6b, 0xcd, 0x1f, 0x6c, 0xb4, 0x64, 0x51, 0xeb,
-	0xfb, 0xe1, 0x16, 0x23, 0xda, 0x97, 0x3c, 0x4c, 0xd3, 0x0e, 0x4b, 0x1e, 0xa6, 0x69, 0xf7, 0x25,
-	0xe7, 0x30, 0xf6, 0x38, 0x86, 0x34, 0x8e, 0xa6, 0xc2, 0x44, 0x32, 0xc9, 0x8a, 0xc9, 0x4a, 0x93,
-	0x1e, 0x1b, 0x54, 0xc8, 0x23, 0x01, 0xe3, 0xcc, 0x39, 0x11, 0xda, 0x80, 0x2a, 0x9d, 0x51, 0x99,
-	0x83, 0x81, 0x50, 0xe6, 0xf8, 0x1c, 0xae, 0x81, 0x85, 0x32, 0x92, 0x3a, 0xca, 0x26, 0x41, 0xd6,
-	0x40, 0x1f, 0x09, 0xd5, 0xc0, 0x3a, 0x89, 0xcb, 0xc5, 0xb9, 0x88, 0xcc, 0xa1, 0xac, 0x3c, 0x51,
-	0xe3, 0x6b, 0x4c, 0xa8, 0x5c, 0x34, 0x50, 0xec, 0x6b, 0x62, 0x64, 0x8a, 0x42, 0x4b, 0xfa, 0xaa,
-	0x31, 0x21, 0x5f, 0x0d, 0x14, 0x1f, 0x84, 0x9a, 0x78, 0x12, 0x25, 0xd1, 0x72, 0xb5, 0x24, 0x0f,
-	0x02, 0x8d, 0x86, 0x0e, 0x42, 0xdb, 0x08, 0x37, 0x81, 0x25, 0xfb, 0x68, 0x62, 0x84, 0x32, 0x78,
-	0xb5, 0xf4, 0x12, 0x7c, 0xc8, 0x3a, 0xed, 0x75, 0x62, 0x9d, 0xbb, 0x7f, 0x76, 0xd8, 0x6e, 0x5d,
-	0x3e, 0x4d, 0x4c, 0x14, 0x0f, 0x2f, 0x0d, 0x28, 0xfe, 0x7d, 0x07, 0x6b, 0x15, 0x6e, 0xe7, 0xf0,
-	0x64, 0xcb, 0x51, 0xf8, 0x62, 0x38, 0x02, 0x4b, 0x69, 0xf2, 0x62, 0x40, 0x7a, 0xe8, 0x62, 0xf0,
-	0x30, 0x1c, 0xdc, 0x33, 0x34, 0x87, 0xac, 0x3c, 0x90, 0xc1, 0xad, 0x43, 0xa1, 0xe0, 0x36, 0x59,
-	0x9c, 0x4c, 0x58, 0xad, 0x32, 0x9c, 0x4c, 0x26, 0x1a, 0x0d, 0x25, 0x53, 0xdb, 0x08, 0xbc, 0xde,
-	0x31, 0x68, 0xd8, 0x98, 0x4c, 0x75, 0x28, 0xb4, 0xde, 0x26, 0x8b, 0xef, 0xdd, 0xe3, 0x24, 0x32,
-	0x45, 0xd1, 0x20, 0xef, 0xdd, 0x4a, 0x0e, 0xdd, 0xbb, 0x98, 0x72, 0xc6, 0xff, 0xde, 0x61, 0xb7,
-	0x47, 0x32, 0x5d, 0xc5, 0x79, 0xd7, 0x97, 0x0a, 0x05, 0x89, 0xf9, 0x51, 0xae, 0x54, 0x22, 0x62,
-	0x4e, 0x05, 0xa7, 0x85, 0xb5, 0x7e, 0x1f, 0x6d, 0x33, 0x04, 0x27, 0x68, 0x36, 0xb9, 0x72, 0xf9,
-	0xbc, 0x6d, 0xf2, 0xa5, 0x1e, 0x4a, 0x50, 0x0f, 0xc3, 0x57, 0xc4, 0x73, 0x58, 0x4a, 0x03, 0x65,
-	0x0c, 0xa9, 0x91, 0x18, 0x08, 0x5d, 0x11, 0x3e, 0x87, 0x73, 0xe2, 0x34, 0x99, 0x49, 0xcf, 0xcd,
-	0x01, 0xd9, 0x9b, 0xf8, 0x50, 0x28, 0x27, 0x9a, 0xac, 0x73, 0xa7, 0x19, 0x2f, 0x97, 0x79, 0x2e,
-	0xf4, 0x48, 0xc9, 0x0c, 0x9a, 0xf1, 0xc0, 0xd5, 0x89, 0x30, 0xeb, 0xf2, 0xbb, 0x8e, 0x34, 0xfe,
-	0xa0, 0x9c, 0x80, 0xcd, 0xc3, 0xbb, 0xf4, 0x27, 0x90, 0xbf, 0xaa, 0xbd, 0x30, 0xe4, 0x2c, 0xaf,
-	0xd9, 0x27, 0x95, 0xe7, 0x31, 0xe8, 0xac, 0xaa, 0xc1, 0x8c, 0x87, 0x67, 0xe8, 0x38, 0xeb, 0xad,
-	0xdf, 0x15, 0x77, 0x7e, 0xff, 0xdd, 0x61, 0x9f, 0xd5, 0xee, 0x8e, 0x61, 0x32, 0xcb, 0x3e, 0x79,
-	0x8b, 0x5e, 0xe2, 0xc9, 0xe6, 0xbb, 0x06, 0xf3, 0x76, 0x22, 0x3f, 0x6c, 0x3b, 0x0c, 0x77, 0x1a,
-	0x65, 0xe0, 0xed, 0x61, 0xb8, 0x47, 0x7e, 0x03, 0x60, 0x24, 0xd4, 0x69, 0xd4, 0x49, 0xe7, 0xe8,
-	0x67, 0x76, 0xe3, 0xa9, 0x98, 0x5e, 0xad, 0x52, 0x4e, 0x3d, 0x55, 0x14, 0x92, 0x35, 0xfc, 0x65,
-	0x80, 0xb0, 0x06, 0x1f, 0xec, 0x70, 0x95, 0xb5, 0x7e, 0xda, 0x48, 0x05, 0x87, 0x4a, 0x2e, 0x4b,
-	0xeb, 0x2d, 0xb5, 0xce, 0xa7, 0xc2, 0xad, 0x5f, 0x03, 0x46, 0x3e, 0xdf, 0xb0, 0x9b, 0x93, 0x58,
-	0xac, 0xa1, 0xdc, 0x2f, 0xf2, 0x81, 0xa0, 0xd2, 0x83, 0x0f, 0x04, 0x18, 0xf3, 0x52, 0xde, 0xc8,
-	0x34, 0x17, 0xe9, 0x94, 0xb7, 0x6a, 0x30, 0xe5, 0x2b, 0xc8, 0xef, 0x48, 0xca, 0xbf, 0x6d, 0x33,
-	0x74, 0x10, 0x1a, 0x5b, 0x6b, 0x83, 0x7a, 0x9d, 0x58, 0x7c, 0x89, 0xe4, 0xbd, 0x42, 0xb1, 0x92,
-	0xbd, 0xb6, 0x56, 0xc2, 0x5b, 0xca, 0xfe, 0x06, 0xca, 0x19, 0xbf, 0x66, 0xb7, 0xaa, 0xff, 0x51,
-	0x9f, 0xd3, 0x0f, 0x1a, 0x68, 0x76, 0x38, 0x83, 0xce, 0x7c, 0xfd, 0x91, 0x2b, 0xd3, 0x75, 0xeb,
-	0x23, 0x57, 0xae, 0x6e, 0x7a, 0xe4, 0x2a, 0x21, 0x6c, 0x39, 0xbb, 0x4d, 0xda, 0xb7, 0xde, 0xa9,
-	0x21, 0xcb, 0x08, 0xf2, 0xb6, 0x3e, 0xfb, 0x0b, 0x97, 0xee, 0x83, 0xb6, 0x94, 0x24, 0x0a, 0x77,
-	0xaf, 0x13, 0x8b, 0x3f, 0xc9, 0xac, 0x5a, 0x95, 0xd6, 0x90, 0x8d, 0x46, 0x61, 0xbd, 0xdf, 0x0d,
-	0xc6, 0xef, 0xa4, 0x67, 0x79, 0x17, 0x48, 0xbd, 0x93, 0x9e, 0xe1, 0xd6, 0xef, 0x4e, 0x3b, 0x60,
-	0xad, 0x3d, 0x7d, 0xfc, 0xeb, 0xc3, 0x75, 0x64, 0x40, 0xeb, 0x7e, 0x24, 0x07, 0xc5, 0xaf, 0xc1,
-	0x5c, 0x0e, 0xd6, 0x66, 0x90, 0x3f, 0xf9, 0x0e, 0xa8, 0x07, 0xe2, 0x8b, 0x1b, 0xb9, 0xf6, 0xf8,
-	0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8a, 0x3f, 0xf9, 0x7a, 0x5b, 0x16, 0x00, 0x00,
+	// 1187 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x98, 0xdf, 0x6f, 0x23, 0x35,
+	0x10, 0xc7, 0xa9, 0x04, 0x27, 0x61, 0x7e, 0x9b, 0x13, 0x27, 0x15, 0x01, 0x07, 0xd7, 0xc2, 0xd1,
+	0xdc, 0x25, 0xf7, 0x13, 0x78, 0xcd, 0x5d, 0xaf, 0xbd, 0xa2, 0xab, 0x08, 0x49, 0x7f, 0x20, 0x90,
+	0x90, 0xdc, 0x64, 0x9a, 0x2c, 0xdd, 0xac, 0x17, 0xdb, 0x89, 0xe8, 0x13, 0x12, 0xaf, 0x48, 0x3c,
+	0xf1, 0xaf, 0xf2, 0x8e, 0x76, 0xb3, 0xf6, 0x8e, 0x77, 0x67, 0x9d, 0xcd, 0x5b, 0x94, 0xef, 0x67,
+	0x66, 0xfc, 0x63, 0x3c, 0x9e, 0x35, 0xdb, 0x36, 0xe2, 0x22, 0x06, 0x33, 0x17, 0x89, 0x98, 0x82,
+	0xd2, 0xa0, 0x96, 0xd1, 0x18, 0xba, 0xa9, 0x92, 0x46, 0xf2, 0x9b, 0x94, 0xb6, 0x7d, 0xcb, 0xfb,
+	0x77, 0x22, 0x8c, 0x58, 0xe1, 0x8f, 0xfe, 0xbb, 0xcf, 0xde, 0x39, 0xc9, 0xb5, 0xe3, 0x95, 0xc6,
+	0x8f, 0xd8, 0xeb, 0x83, 0x28, 0x99, 0xf2, 0x4f, 0xbb, 0x75, 0x9b, 0x4c, 0x18, 0xc2, 0xef, 0x0b,
+	0xd0, 0x66, 0xfb, 0xb3, 0x46, 0x5d, 0xa7, 0x32, 0xd1, 0xf0, 0xc5, 0x6b, 0xfc, 0x15, 0x7b, 0x63,
+	0x14, 0x03, 0xa4, 0x9c, 0x62, 0x73, 0xc5, 0x3a, 0xbb, 0xdd, 0x0c, 0x38, 0x6f, 0xbf, 0xb2, 0xb7,
+	0x5e, 0xfc, 0x01, 0xe3, 0x85, 0x81, 0x97, 0x52, 0x5e, 0xf1, 0x5d, 0xc2, 0x04, 0xe9, 0xd6, 0xf3,
+	0x97, 0xeb, 0x30, 0xe7, 0xff, 0x27, 0xf6, 0xe6, 0x21, 0x98, 0xd1, 0x78, 0x06, 0x73, 0xc1, 0xef,
+	0x10, 0x66, 0x4e, 0xb5, 0xbe, 0x77, 0xc2, 0x90, 0xf3, 0x3c, 0x65, 0xef, 0x1e, 0x82, 0x19, 0x80,
+	0x9a, 0x47, 0x5a, 0x47, 0x32, 0xd1, 0xfc, 0x2e, 0x6d, 0x89, 0x10, 0x1b, 0xe3, 0xeb, 0x16, 0x24,
+	0x5e, 0xa2, 0x11, 0x98, 0x21, 0x88, 0xc9, 0x0f, 0x49, 0x7c, 0x4d, 0x2e, 0x11, 0xd2, 0x43, 0x4b,
+	0xe4, 0x61, 0xce, 0xbf, 0x60, 0x6f, 0x17, 0xc2, 0xb9, 0x8a, 0x0c, 0xf0, 0x80, 0x65, 0x0e, 0xd8,
+	0x08, 0x5f, 0xad, 0xe5, 0x5c, 0x88, 0x5f, 0x18, 0x7b, 0x3e, 0x13, 0xc9, 0x14, 0x4e, 0xae, 0x53,
+	0xe0, 0xd4, 0x0a, 0x97, 0xb2, 0x75, 0xbf, 0xbb, 0x86, 0xc2, 0xe3, 0x1f, 0xc2, 0xa5, 0x02, 0x3d,
+	0x1b, 0x19, 0xd1, 0x30, 0x7e, 0x0c, 0x84, 0xc6, 0xef, 0x73, 0x78, 0xaf, 0x87, 0x8b, 0xe4, 0x25,
+	0x88, 0xd8, 0xcc, 0x9e, 0xcf, 0x60, 0x7c, 0x45, 0xee, 0xb5, 0x8f, 0x84, 0xf6, 0xba, 0x4a, 0xba,
+	0x40, 0x29, 0xfb, 0xe0, 0x68, 0x9a, 0x48, 0x05, 0x2b, 0xf9, 0x85, 0x52, 0x52, 0xf1, 0x0e, 0xe1,
+	0xa1, 0x46, 0xd9, 0x70, 0xf7, 0xda, 0xc1, 0xfe, 0xea, 0xc5, 0x52, 0x4c, 0x8a, 0x33, 0x42, 0xaf,
+	0x5e, 0x09, 0x84, 0x57, 0x0f, 0x73, 0x2e, 0xc4, 0x6f, 0xec, 0xbd, 0x81, 0x82, 0xcb, 0x38, 0x9a,
+	0xce, 0xec, 0x49, 0xa4, 0x16, 0xa5, 0xc2, 0xd8, 0x40, 0x7b, 0x6d, 0x50, 0x7c, 0x58, 0xfa, 0x69,
+	0x1a, 0x5f, 0x17, 0x71, 0xa8, 0x24, 0x42, 0x7a, 0xe8, 0xb0, 0x78, 0x18, 0xce, 0xe4, 0x57, 0x72,
+	0x7c, 0x95, 0x57, 0x57, 0x4d, 0x66, 0x72, 0x29, 0x87, 0x32, 0x19, 0x53, 0x78, 0x2f, 0x4e, 0x93,
+	0xb8, 0x74, 0x4f, 0x0d, 0x0b, 0x03, 0xa1, 0xbd, 0xf0, 0x39, 0x9c, 0x60, 0x45, 0xa1, 0x3c, 0x00,
+	0x33, 0x9e, 0xf5, 0xf5, 0xfe, 0x85, 0x20, 0x13, 0xac, 0x46, 0x85, 0x12, 0x8c, 0x80, 0x5d, 0xc4,
+	0x3f, 0xd9, 0x47, 0xbe, 0xdc, 0x8f, 0xe3, 0x81, 0x8a, 0x96, 0x9a, 0x3f, 0x58, 0xeb, 0xc9, 0xa2,
+	0x36, 0xf6, 0xc3, 0x0d, 0x2c, 0x9a, 0xa7, 0xdc, 0x4f, 0xd3, 0x16, 0x53, 0xee, 0xa7, 0x69, 0xfb,
+	0x29, 0xe7, 0x30, 0x8e, 0x38, 0x84, 0x34, 0x8e, 0xc6, 0xc2, 0x44, 0x32, 0x19, 0x19, 0x61, 0x16,
+	0x9a, 0x8c, 0x58, 0xa3, 0x42, 0x11, 0x09, 0x18, 0x67, 0xce, 0xb1, 0xd0, 0x06, 0x54, 0x11, 0x8c,
+	0xca, 0x1c, 0x0c, 0x84, 0x32, 0xc7, 0xe7, 0x70, 0x0d, 0x5c, 0x29, 0x03, 0xa9, 0xa3, 0x6c, 0x10,
+	0x64, 0x0d, 0xf4, 0x91, 0x50, 0x0d, 0xac, 0x92, 0xb8, 0x5c, 0x9c, 0x8b, 0xc8, 0x1c, 0xc8, 0x32,
+	0x12, 0x65, 0x5f, 0x61, 0x42, 0xe5, 0xa2, 0x86, 0xe2, 0x58, 0x23, 0x23, 0x53, 0xb4, 0xb4, 0x64,
+	0xac, 0x0a, 0x13, 0x8a, 0x55, 0x43, 0xf1, 0x41, 0xa8, 0x88, 0xc7, 0x51, 0x12, 0xcd, 0x17, 0x73,
+	0xf2, 0x20, 0xd0, 0x68, 0xe8, 0x20, 0x34, 0x59, 0xb8, 0x01, 0xcc, 0xd9, 0xfb, 0x23, 0x23, 0x94,
+	0xc1, 0xb3, 0xa5, 0xa7, 0xe0, 0x43, 0x36, 0x68, 0xa7, 0x15, 0xeb, 0xc2, 0xfd, 0xbd, 0xc5, 0xb6,
+	0xab, 0xf2, 0x69, 0x62, 0xa2, 0xb8, 0x7f, 0x69, 0x40, 0xf1, 0x27, 0x2d, 0xbc, 0x95, 0xb8, 0x1d,
+	0xc3, 0xd3, 0x0d, 0xad, 0xf0, 0xc5, 0x70, 0x08, 0x96, 0xd2, 0xe4, 0xc5, 0x80, 0xf4, 0xd0, 0xc5,
+	0xe0, 0x61, 0x78, 0x71, 0xcf, 0xd0, 0x18, 0xb2, 0xf2, 0x40, 0x2e, 0x6e, 0x15, 0x0a, 0x2d, 0x6e,
+	0x9d, 0xc5, 0xc9, 0x84, 0xd5, 0x32, 0xc3, 0xc9, 0x64, 0xa2, 0xd1, 0x50, 0x32, 0x35, 0x59, 0xe0,
+	0xf9, 0x0e, 0x41, 0xc3, 0xda, 0x64, 0xaa, 0x42, 0xa1, 0xf9, 0xd6, 0x59, 0x7c, 0xef, 0x1e, 0x25,
+	0x91, 0x59, 0x15, 0x0d, 0xf2, 0xde, 0x2d, 0xe5, 0xd0, 0xbd, 0x8b, 0x29, 0xe7, 0xfc, 0xaf, 0x2d,
+	0x76, 0x6b, 0x20, 0xd3, 0x45, 0x2c, 0x0c, 0x0c, 0x21, 0x15, 0x0a, 0x12, 0xf3, 0xbd, 0x5c, 0xa8,
+	0x44, 0xc4, 0x9c, 0x5a, 0x9c, 0x06, 0xd6, 0xc6, 0x7d, 0xb4, 0x89, 0x09, 0x4e, 0xd0, 0x6c, 0x70,
+	0xc5, 0xf4, 0x79, 0xd3, 0xe0, 0x0b, 0x3d, 0x94, 0xa0, 0x1e, 0x86, 0xaf, 0x88, 0x7d, 0x98, 0x4b,
+	0x03, 0xc5, 0x1a, 0x52, 0x96, 0x18, 0x08, 0x5d, 0x11, 0x3e, 0x87, 0x73, 0xe2, 0x34, 0x99, 0x48,
+	0x2f, 0xcc, 0x1e, 0xd9, 0x9b, 0x4c, 0x24, 0x15, 0xaa, 0xd3, 0x8a, 0x75, 0xe1, 0x34, 0xe3, 0xc5,
+	0x34, 0xcf, 0x85, 0x1e, 0x28, 0x99, 0x41, 0x13, 0x1e, 0xb8, 0x3a, 0x11, 0x66, 0x43, 0xde, 0x6f,
+	0x49, 0xe3, 0x0f, 0xca, 0x11, 0xd8, 0x3c, 0xbc, 0x43, 0x7f, 0x02, 0xf9, 0xb3, 0xda, 0x09, 0x43,
+	0xce, 0xf3, 0x92, 0x7d, 0x58, 0x46, 0x1e, 0x82, 0x36, 0x42, 0x65, 0xf3, 0x09, 0x8f, 0xd0, 0x71,
+	0x36, 0x5a, 0xb7, 0x2d, 0xee, 0xe2, 0xfe, 0xb3, 0xc5, 0x3e, 0xae, 0xdc, 0x1d, 0xfd, 0x64, 0x92,
+	0x7d, 0xf2, 0xae, 0x7a, 0x89, 0xa7, 0xeb, 0xef, 0x1a, 0xcc, 0xdb, 0x81, 0x7c, 0xb3, 0xa9, 0x19,
+	0xee, 0x34, 0x8a, 0x85, 0xb7, 0x87, 0xe1, 0x2e, 0xf9, 0x0d, 0x80, 0x91, 0x50, 0xa7, 0x51, 0x25,
+	0x5d, 0xa0, 0x1f, 0xd9, 0x8d, 0x67, 0x62, 0x7c, 0xb5, 0x48, 0x39, 0xf5, 0x54, 0xb1, 0x92, 0xac,
+	0xe3, 0xcf, 0x03, 0x84, 0x75, 0xf8, 0x60, 0x8b, 0xab, 0xac, 0xf5, 0xd3, 0x46, 0x2a, 0x38, 0x50,
+	0x72, 0x5e, 0x78, 0x6f, 0xa8, 0x75, 0x3e, 0x15, 0x6e, 0xfd, 0x6a, 0x30, 0x8a, 0x99, 0x3d, 0x10,
+	0xc4, 0x62, 0x09, 0xc5, 0x7e, 0x91, 0x0f, 0x04, 0xa5, 0x1e, 0x7c, 0x20, 0xc0, 0x98, 0x97, 0xf2,
+	0x46, 0xa6, 0xb9, 0x48, 0xa7, 0xbc, 0x55, 0x83, 0x29, 0x5f, 0x42, 0x7e, 0x47, 0x52, 0xfc, 0x6d,
+	0x9b, 0xa1, 0xbd, 0x90, 0x6d, 0xa5, 0x0d, 0xea, 0xb4, 0x62, 0xf1, 0x25, 0x92, 0xf7, 0x0a, 0xab,
+	0x99, 0xec, 0x34, 0xb5, 0x12, 0xde, 0x54, 0x76, 0xd7, 0x50, 0xce, 0xf9, 0x35, 0xbb, 0x59, 0xfe,
+	0x8f, 0xfa, 0x9c, 0x6e, 0xd0, 0x41, 0xbd, 0xc3, 0xe9, 0xb5, 0xe6, 0xab, 0x8f, 0x5c, 0x99, 0xae,
+	0x1b, 0x1f, 0xb9, 0x72, 0x75, 0xdd, 0x23, 0x57, 0x01, 0x61, 0xcf, 0xd9, 0x6d, 0xd2, 0xbc, 0xf5,
+	0x4e, 0x0d, 0x79, 0x46, 0x90, 0xb7, 0xf5, 0xd9, 0x5f, 0xb8, 0x74, 0xef, 0x35, 0xa5, 0x24, 0x51,
+	0xb8, 0x3b, 0xad, 0x58, 0xfc, 0x49, 0x66, 0xd5, 0xb2, 0xb4, 0x86, 0x7c, 0xd4, 0x0a, 0xeb, 0xbd,
+	0x76, 0x30, 0x7e, 0x27, 0x3d, 0xcb, 0xbb, 0x40, 0xea, 0x9d, 0xf4, 0x0c, 0xb7, 0x7e, 0xb7, 0x9b,
+	0x01, 0xaf, 0x99, 0x3e, 0x04, 0xb3, 0x1f, 0x69, 0xa3, 0xa2, 0x8b, 0x85, 0x81, 0xc9, 0x89, 0x12,
+	0x89, 0x16, 0x63, 0x93, 0x3f, 0x3d, 0x3e, 0xa1, 0xf7, 0xb3, 0x01, 0x0f, 0x35, 0xd3, 0x21, 0x2b,
+	0x37, 0x9a, 0x7f, 0xb7, 0xd8, 0x27, 0x43, 0xd0, 0x32, 0x5e, 0x02, 0x0d, 0xf3, 0x6f, 0xe9, 0x2a,
+	0xd6, 0x6c, 0x61, 0xc7, 0xf4, 0xdd, 0xe6, 0x86, 0x76, 0x58, 0xcf, 0x1e, 0xff, 0xfc, 0x70, 0x19,
+	0x19, 0xd0, 0xba, 0x1b, 0xc9, 0xde, 0xea, 0x57, 0x6f, 0x2a, 0x7b, 0x4b, 0xd3, 0xcb, 0xdf, 0xc5,
+	0x7b, 0xd4, 0x2b, 0xfa, 0xc5, 0x8d, 0x5c, 0x7b, 0xfc, 0xff, 0x00, 0x6a, 0x06, 0xa5, 0x34, 0x80,
+	0x17, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -210,6 +214,11 @@ type TabletManagerClient interface {
 	SlaveWasRestarted(ctx context.Context, in *tabletmanagerdata.SlaveWasRestartedRequest, opts ...grpc.CallOption) (*tabletmanagerdata.SlaveWasRestartedResponse, error)
 	// Generic VExec request. Can be used for various purposes
 	VExec(ctx context.Context, in *tabletmanagerdata.VExecRequest, opts ...grpc.CallOption) (*tabletmanagerdata.VExecResponse, error)
+	// GetDistributedTransactions returns the 2PC metadata and prepared
+	// transactions stored on the tablet
+	GetDistributedTransactions(ctx context.Context, in *tabletmanagerdata.GetDistributedTransactionsRequest, opts ...grpc.CallOption) (*tabletmanagerdata.GetDistributedTransactionsResponse, error)
+	// ResolveDistributedTransaction applies an operator resolution to a dtid
+	ResolveDistributedTransaction(ctx context.Context, in *tabletmanagerdata.ResolveDistributedTransactionRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ResolveDistributedTransactionResponse, error)
 }
 
 type tabletManagerClient struct {
@@ -743,6 +752,24 @@ func (c *tabletManagerClient) VExec(ctx context.Context, in *tabletmanagerdata.V
 	return out, nil
 }
 
+func (c *tabletManagerClient) GetDistributedTransactions(ctx context.Context, in *tabletmanagerdata.GetDistributedTransactionsRequest, opts ...grpc.CallOption) (*tabletmanagerdata.GetDistributedTransactionsResponse, error) {
+	out := new(tabletmanagerdata.GetDistributedTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/tabletmanagerservice.TabletManager/GetDistributedTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tabletManagerClient) ResolveDistributedTransaction(ctx context.Context, in *tabletmanagerdata.ResolveDistributedTransactionRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ResolveDistributedTransactionResponse, error) {
+	out := new(tabletmanagerdata.ResolveDistributedTransactionResponse)
+	err := c.cc.Invoke(ctx, "/tabletmanagerservice.TabletManager/ResolveDistributedTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TabletManagerServer is the server API for TabletManager service.
 type TabletManagerServer interface {
 	// Ping returns the input payload
@@ -840,6 +867,11 @@ type TabletManagerServer interface {
 	SlaveWasRestarted(context.Context, *tabletmanagerdata.SlaveWasRestartedRequest) (*tabletmanagerdata.SlaveWasRestartedResponse, error)
 	// Generic VExec request. Can be used for various purposes
 	VExec(context.Context, *tabletmanagerdata.VExecRequest) (*tabletmanagerdata.VExecResponse, error)
+	// GetDistributedTransactions returns the 2PC metadata and prepared
+	// transactions stored on the tablet
+	GetDistributedTransactions(context.Context, *tabletmanagerdata.GetDistributedTransactionsRequest) (*tabletmanagerdata.GetDistributedTransactionsResponse, error)
+	// ResolveDistributedTransaction applies an operator resolution to a dtid
+	ResolveDistributedTransaction(context.Context, *tabletmanagerdata.ResolveDistributedTransactionRequest) (*tabletmanagerdata.ResolveDistributedTransactionResponse, error)
 }
 
 // UnimplementedTabletManagerServer can be embedded to have forward compatible implementations.
@@ -1005,6 +1037,12 @@ func (*UnimplementedTabletManagerServer) SlaveWasRestarted(ctx context.Context,
 func (*UnimplementedTabletManagerServer) VExec(ctx context.Context, req *tabletmanagerdata.VExecRequest) (*tabletmanagerdata.VExecResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VExec not implemented")
 }
+func (*UnimplementedTabletManagerServer) GetDistributedTransactions(ctx context.Context, req *tabletmanagerdata.GetDistributedTransactionsRequest) (*tabletmanagerdata.GetDistributedTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDistributedTransactions not implemented")
+}
+func (*UnimplementedTabletManagerServer) ResolveDistributedTransaction(ctx context.Context, req *tabletmanagerdata.ResolveDistributedTransactionRequest) (*tabletmanagerdata.ResolveDistributedTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDistributedTransaction not implemented")
+}
 
 func RegisterTabletManagerServer(s *grpc.Server, srv TabletManagerServer) {
 	s.RegisterService(&_TabletManager_serviceDesc, srv)
@@ -1970,6 +2008,42 @@ func _TabletManager_VExec_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TabletManager_GetDistributedTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tabletmanagerdata.GetDistributedTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TabletManagerServer).GetDistributedTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tabletmanagerservice.TabletManager/GetDistributedTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TabletManagerServer).GetDistributedTransactions(ctx, req.(*tabletmanagerdata.GetDistributedTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TabletManager_ResolveDistributedTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tabletmanagerdata.ResolveDistributedTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TabletManagerServer).ResolveDistributedTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tabletmanagerservice.TabletManager/ResolveDistributedTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TabletManagerServer).ResolveDistributedTransaction(ctx, req.(*tabletmanagerdata.ResolveDistributedTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TabletManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tabletmanagerservice.TabletManager",
 	HandlerType: (*TabletManagerServer)(nil),
@@ -2178,6 +2252,14 @@ var _TabletManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VExec",
 			Handler:    _TabletManager_VExec_Handler,
 		},
+		{
+			MethodName: "GetDistributedTransactions",
+			Handler:    _TabletManager_GetDistributedTransactions_Handler,
+		},
+		{
+			MethodName: "ResolveDistributedTransaction",
+			Handler:    _TabletManager_ResolveDistributedTransaction_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
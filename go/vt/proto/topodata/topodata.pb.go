@@ -968,6 +968,169 @@ func (m *ShardReplication_Node) GetTabletAlias() *TabletAlias {
 	return nil
 }
 
+// ReparentRecord describes a reparent of a shard, or a failed attempt.
+type ReparentRecord struct {
+	// operation is the reparent operation, e.g. PlannedReparentShard.
+	Operation string `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	// time is when the reparent started.
+	Time *vttime.Time `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// duration_ns is how long the reparent took, in nanoseconds.
+	DurationNs int64        `protobuf:"varint,3,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"`
+	OldMaster  *TabletAlias `protobuf:"bytes,4,opt,name=old_master,json=oldMaster,proto3" json:"old_master,omitempty"`
+	NewMaster  *TabletAlias `protobuf:"bytes,5,opt,name=new_master,json=newMaster,proto3" json:"new_master,omitempty"`
+	// old_master_position is the position of the old master when it
+	// was demoted, if known.
+	OldMasterPosition string `protobuf:"bytes,6,opt,name=old_master_position,json=oldMasterPosition,proto3" json:"old_master_position,omitempty"`
+	// new_master_position is the position of the new master when it
+	// was promoted.
+	NewMasterPosition string `protobuf:"bytes,7,opt,name=new_master_position,json=newMasterPosition,proto3" json:"new_master_position,omitempty"`
+	// reason explains why the master changed.
+	Reason string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	// caller is who asked for the reparent, if known.
+	Caller string `protobuf:"bytes,9,opt,name=caller,proto3" json:"caller,omitempty"`
+	// error is set if the reparent failed.
+	Error                string   `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReparentRecord) Reset()         { *m = ReparentRecord{} }
+func (m *ReparentRecord) String() string { return proto.CompactTextString(m) }
+func (*ReparentRecord) ProtoMessage()    {}
+func (*ReparentRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_52c350cb619f972e, []int{6}
+}
+
+func (m *ReparentRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReparentRecord.Unmarshal(m, b)
+}
+func (m *ReparentRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReparentRecord.Marshal(b, m, deterministic)
+}
+func (m *ReparentRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReparentRecord.Merge(m, src)
+}
+func (m *ReparentRecord) XXX_Size() int {
+	return xxx_messageInfo_ReparentRecord.Size(m)
+}
+func (m *ReparentRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReparentRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReparentRecord proto.InternalMessageInfo
+
+func (m *ReparentRecord) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *ReparentRecord) GetTime() *vttime.Time {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *ReparentRecord) GetDurationNs() int64 {
+	if m != nil {
+		return m.DurationNs
+	}
+	return 0
+}
+
+func (m *ReparentRecord) GetOldMaster() *TabletAlias {
+	if m != nil {
+		return m.OldMaster
+	}
+	return nil
+}
+
+func (m *ReparentRecord) GetNewMaster() *TabletAlias {
+	if m != nil {
+		return m.NewMaster
+	}
+	return nil
+}
+
+func (m *ReparentRecord) GetOldMasterPosition() string {
+	if m != nil {
+		return m.OldMasterPosition
+	}
+	return ""
+}
+
+func (m *ReparentRecord) GetNewMasterPosition() string {
+	if m != nil {
+		return m.NewMasterPosition
+	}
+	return ""
+}
+
+func (m *ReparentRecord) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ReparentRecord) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+func (m *ReparentRecord) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// ReparentHistory is the history of the reparents of a shard, oldest
+// first. It is stored next to the Shard record.
+type ReparentHistory struct {
+	Records              []*ReparentRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ReparentHistory) Reset()         { *m = ReparentHistory{} }
+func (m *ReparentHistory) String() string { return proto.CompactTextString(m) }
+func (*ReparentHistory) ProtoMessage()    {}
+func (*ReparentHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_52c350cb619f972e, []int{7}
+}
+
+func (m *ReparentHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReparentHistory.Unmarshal(m, b)
+}
+func (m *ReparentHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReparentHistory.Marshal(b, m, deterministic)
+}
+func (m *ReparentHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReparentHistory.Merge(m, src)
+}
+func (m *ReparentHistory) XXX_Size() int {
+	return xxx_messageInfo_ReparentHistory.Size(m)
+}
+func (m *ReparentHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReparentHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReparentHistory proto.InternalMessageInfo
+
+func (m *ReparentHistory) GetRecords() []*ReparentRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
 // ShardReference is used as a pointer from a SrvKeyspace to a Shard
 type ShardReference struct {
 	// Copied from Shard.
@@ -982,7 +1145,7 @@ func (m *ShardReference) Reset()         { *m = ShardReference{} }
 func (m *ShardReference) String() string { return proto.CompactTextString(m) }
 func (*ShardReference) ProtoMessage()    {}
 func (*ShardReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_52c350cb619f972e, []int{6}
+	return fileDescriptor_52c350cb619f972e, []int{8}
 }
 
 func (m *ShardReference) XXX_Unmarshal(b []byte) error {
@@ -1033,7 +1196,7 @@ func (m *ShardTabletControl) Reset()         { *m = ShardTabletControl{} }
 func (m *ShardTabletControl) String() string { return proto.CompactTextString(m) }
 func (*ShardTabletControl) ProtoMessage()    {}
 func (*ShardTabletControl) Descriptor() ([]byte, []int) {
-	return fileDescriptor_52c350cb619f972e, []int{7}
+	return fileDescriptor_52c350cb619f972e, []int{9}
 }
 
 func (m *ShardTabletControl) XXX_Unmarshal(b []byte) error {
@@ -1092,7 +1255,7 @@ func (m *SrvKeyspace) Reset()         { *m = SrvKeyspace{} }
 func (m *SrvKeyspace) String() string { return proto.CompactTextString(m) }
 func (*SrvKeyspace) ProtoMessage()    {}
 func (*SrvKeyspace) Descriptor() ([]byte, []int) {
-	return fileDescriptor_52c350cb619f972e, []int{8}
+	return fileDescriptor_52c350cb619f972e, []int{10}
 }
 
 func (m *SrvKeyspace) XXX_Unmarshal(b []byte) error {
@@ -1157,7 +1320,7 @@ func (m *SrvKeyspace_KeyspacePartition) Reset()         { *m = SrvKeyspace_Keysp
 func (m *SrvKeyspace_KeyspacePartition) String() string { return proto.CompactTextString(m) }
 func (*SrvKeyspace_KeyspacePartition) ProtoMessage()    {}
 func (*SrvKeyspace_KeyspacePartition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_52c350cb619f972e, []int{8, 0}
+	return fileDescriptor_52c350cb619f972e, []int{10, 0}
 }
 
 func (m *SrvKeyspace_KeyspacePartition) XXX_Unmarshal(b []byte) error {
@@ -1215,7 +1378,7 @@ func (m *SrvKeyspace_ServedFrom) Reset()         { *m = SrvKeyspace_ServedFrom{}
 func (m *SrvKeyspace_ServedFrom) String() string { return proto.CompactTextString(m) }
 func (*SrvKeyspace_ServedFrom) ProtoMessage()    {}
 func (*SrvKeyspace_ServedFrom) Descriptor() ([]byte, []int) {
-	return fileDescriptor_52c350cb619f972e, []int{8, 1}
+	return fileDescriptor_52c350cb619f972e, []int{10, 1}
 }
 
 func (m *SrvKeyspace_ServedFrom) XXX_Unmarshal(b []byte) error {
@@ -1271,7 +1434,7 @@ func (m *CellInfo) Reset()         { *m = CellInfo{} }
 func (m *CellInfo) String() string { return proto.CompactTextString(m) }
 func (*CellInfo) ProtoMessage()    {}
 func (*CellInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_52c350cb619f972e, []int{9}
+	return fileDescriptor_52c350cb619f972e, []int{11}
 }
 
 func (m *CellInfo) XXX_Unmarshal(b []byte) error {
@@ -1319,7 +1482,7 @@ func (m *CellsAlias) Reset()         { *m = CellsAlias{} }
 func (m *CellsAlias) String() string { return proto.CompactTextString(m) }
 func (*CellsAlias) ProtoMessage()    {}
 func (*CellsAlias) Descriptor() ([]byte, []int) {
-	return fileDescriptor_52c350cb619f972e, []int{10}
+	return fileDescriptor_52c350cb619f972e, []int{12}
 }
 
 func (m *CellsAlias) XXX_Unmarshal(b []byte) error {
@@ -1364,6 +1527,8 @@ func init() {
 	proto.RegisterType((*Keyspace_ServedFrom)(nil), "topodata.Keyspace.ServedFrom")
 	proto.RegisterType((*ShardReplication)(nil), "topodata.ShardReplication")
 	proto.RegisterType((*ShardReplication_Node)(nil), "topodata.ShardReplication.Node")
+	proto.RegisterType((*ReparentRecord)(nil), "topodata.ReparentRecord")
+	proto.RegisterType((*ReparentHistory)(nil), "topodata.ReparentHistory")
 	proto.RegisterType((*ShardReference)(nil), "topodata.ShardReference")
 	proto.RegisterType((*ShardTabletControl)(nil), "topodata.ShardTabletControl")
 	proto.RegisterType((*SrvKeyspace)(nil), "topodata.SrvKeyspace")
@@ -1376,90 +1541,100 @@ func init() {
 func init() { proto.RegisterFile("topodata.proto", fileDescriptor_52c350cb619f972e) }
 
 var fileDescriptor_52c350cb619f972e = []byte{
-	// 1349 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcf, 0x6e, 0xdb, 0x46,
-	0x13, 0x0f, 0xf5, 0xcf, 0xd4, 0x88, 0x92, 0x99, 0x8d, 0x63, 0x10, 0xfa, 0xbe, 0xa0, 0x86, 0x8a,
-	0xa0, 0x82, 0x8b, 0xca, 0xad, 0x93, 0xb4, 0x46, 0x8a, 0x02, 0x51, 0x64, 0xa5, 0x71, 0x6c, 0xcb,
-	0xc2, 0x4a, 0x46, 0x9b, 0x5e, 0x08, 0x5a, 0x5a, 0x3b, 0x84, 0x25, 0x52, 0xd9, 0x5d, 0x0b, 0x50,
-	0x5f, 0xa1, 0x87, 0xf6, 0xdc, 0x37, 0xe8, 0xfb, 0xf4, 0xd8, 0x4b, 0xfb, 0x1c, 0x3d, 0x14, 0x3b,
-	0x4b, 0x52, 0x94, 0x14, 0xa7, 0x4e, 0xe1, 0xdb, 0xcc, 0xec, 0xcc, 0x70, 0xe6, 0xb7, 0xbf, 0x99,
-	0x95, 0xa0, 0x22, 0xc3, 0x49, 0x38, 0xf4, 0xa4, 0xd7, 0x98, 0xf0, 0x50, 0x86, 0xc4, 0x8c, 0xf5,
-	0xaa, 0x35, 0x95, 0xd2, 0x1f, 0x33, 0x6d, 0xaf, 0xed, 0x82, 0x79, 0xc8, 0x66, 0xd4, 0x0b, 0x2e,
-	0x18, 0xd9, 0x80, 0xbc, 0x90, 0x1e, 0x97, 0x8e, 0xb1, 0x65, 0xd4, 0x2d, 0xaa, 0x15, 0x62, 0x43,
-	0x96, 0x05, 0x43, 0x27, 0x83, 0x36, 0x25, 0xd6, 0x1e, 0x41, 0xa9, 0xef, 0x9d, 0x8d, 0x98, 0x6c,
-	0x8e, 0x7c, 0x4f, 0x10, 0x02, 0xb9, 0x01, 0x1b, 0x8d, 0x30, 0xaa, 0x48, 0x51, 0x56, 0x41, 0x57,
-	0xbe, 0x0e, 0x2a, 0x53, 0x25, 0xd6, 0xfe, 0xce, 0x41, 0x41, 0x47, 0x91, 0x4f, 0x21, 0xef, 0xa9,
-	0x48, 0x8c, 0x28, 0xed, 0xde, 0x6f, 0x24, 0xb5, 0xa6, 0xd2, 0x52, 0xed, 0x43, 0xaa, 0x60, 0xbe,
-	0x09, 0x85, 0x0c, 0xbc, 0x31, 0xc3, 0x74, 0x45, 0x9a, 0xe8, 0x64, 0x0f, 0xcc, 0x49, 0xc8, 0xa5,
-	0x3b, 0xf6, 0x26, 0x4e, 0x6e, 0x2b, 0x5b, 0x2f, 0xed, 0x3e, 0x58, 0xce, 0xd5, 0xe8, 0x86, 0x5c,
-	0x1e, 0x7b, 0x93, 0x76, 0x20, 0xf9, 0x8c, 0xae, 0x4d, 0xb4, 0xa6, 0xb2, 0x5e, 0xb2, 0x99, 0x98,
-	0x78, 0x03, 0xe6, 0xe4, 0x75, 0xd6, 0x58, 0x47, 0x18, 0xde, 0x78, 0x7c, 0xe8, 0x14, 0xf0, 0x40,
-	0x2b, 0x64, 0x07, 0x8a, 0x97, 0x6c, 0xe6, 0x72, 0x85, 0x94, 0xb3, 0x86, 0x85, 0x93, 0xf9, 0xc7,
-	0x62, 0x0c, 0x31, 0x8d, 0x46, 0xb3, 0x0e, 0x39, 0x39, 0x9b, 0x30, 0xc7, 0xdc, 0x32, 0xea, 0x95,
-	0xdd, 0x8d, 0xe5, 0xc2, 0xfa, 0xb3, 0x09, 0xa3, 0xe8, 0x41, 0xea, 0x60, 0x0f, 0xcf, 0x5c, 0xd5,
-	0x91, 0x1b, 0x4e, 0x19, 0xe7, 0xfe, 0x90, 0x39, 0x45, 0xfc, 0x76, 0x65, 0x78, 0xd6, 0xf1, 0xc6,
-	0xec, 0x24, 0xb2, 0x92, 0x06, 0xe4, 0xa4, 0x77, 0x21, 0x1c, 0xc0, 0x66, 0xab, 0x2b, 0xcd, 0xf6,
-	0xbd, 0x0b, 0xa1, 0x3b, 0x45, 0x3f, 0xf2, 0x10, 0x2a, 0xe3, 0x99, 0x78, 0x3b, 0x72, 0x13, 0x08,
-	0x2d, 0xcc, 0x5b, 0x46, 0xeb, 0xcb, 0x18, 0xc7, 0x07, 0x00, 0xda, 0x4d, 0xc1, 0xe3, 0x94, 0xb7,
-	0x8c, 0x7a, 0x9e, 0x16, 0xd1, 0xa2, 0xd0, 0x23, 0x4d, 0xd8, 0x1c, 0x7b, 0x42, 0x32, 0xee, 0x4a,
-	0xc6, 0xc7, 0x2e, 0xd2, 0xc2, 0x55, 0x1c, 0x72, 0x2a, 0x88, 0x83, 0xd5, 0x88, 0x28, 0xd5, 0xf7,
-	0xc7, 0x8c, 0xde, 0xd3, 0xbe, 0x7d, 0xc6, 0xc7, 0x3d, 0xe5, 0xa9, 0x8c, 0xd5, 0xa7, 0x60, 0xa5,
-	0x2f, 0x42, 0xf1, 0xe3, 0x92, 0xcd, 0x22, 0xca, 0x28, 0x51, 0xa1, 0x3e, 0xf5, 0x46, 0x57, 0xfa,
-	0x92, 0xf3, 0x54, 0x2b, 0x4f, 0x33, 0x7b, 0x46, 0xf5, 0x2b, 0x28, 0x26, 0x7d, 0xfd, 0x5b, 0x60,
-	0x31, 0x15, 0xf8, 0x2a, 0x67, 0x66, 0xed, 0xdc, 0xab, 0x9c, 0x59, 0xb2, 0xad, 0xda, 0xef, 0x05,
-	0xc8, 0xf7, 0xf0, 0x22, 0xf7, 0xc0, 0x8a, 0xba, 0xb9, 0x01, 0x09, 0x4b, 0xda, 0x55, 0x13, 0xfd,
-	0x7a, 0x1c, 0xcc, 0x1b, 0xe2, 0xb0, 0xc8, 0xa2, 0xcc, 0x0d, 0x58, 0xf4, 0x0d, 0x58, 0x82, 0xf1,
-	0x29, 0x1b, 0xba, 0x8a, 0x2a, 0xc2, 0xc9, 0x2e, 0xdf, 0x3c, 0x36, 0xd5, 0xe8, 0xa1, 0x0f, 0x72,
-	0xaa, 0x24, 0x12, 0x59, 0x90, 0x67, 0x50, 0x16, 0xe1, 0x15, 0x1f, 0x30, 0x17, 0x59, 0x2c, 0xa2,
-	0x31, 0xf9, 0xdf, 0x4a, 0x3c, 0x3a, 0xa1, 0x4c, 0x2d, 0x31, 0x57, 0x04, 0x79, 0x01, 0xeb, 0x12,
-	0x01, 0x71, 0x07, 0x61, 0x20, 0x79, 0x38, 0x12, 0x4e, 0x61, 0x79, 0xd4, 0x74, 0x0e, 0x8d, 0x5b,
-	0x4b, 0x7b, 0xd1, 0x8a, 0x4c, 0xab, 0x82, 0x6c, 0xc3, 0x5d, 0x5f, 0xb8, 0x11, 0x7e, 0xaa, 0x44,
-	0x3f, 0xb8, 0xc0, 0x39, 0x32, 0xe9, 0xba, 0x2f, 0x8e, 0xd1, 0xde, 0xd3, 0xe6, 0xea, 0x6b, 0x80,
-	0x79, 0x43, 0xe4, 0x09, 0x94, 0xa2, 0x0a, 0x70, 0x9e, 0x8c, 0xf7, 0xcc, 0x13, 0xc8, 0x44, 0x56,
-	0xbc, 0x50, 0xab, 0x48, 0x38, 0x99, 0xad, 0xac, 0xe2, 0x05, 0x2a, 0xd5, 0x5f, 0x0d, 0x28, 0xa5,
-	0x9a, 0x8d, 0x17, 0x95, 0x91, 0x2c, 0xaa, 0x85, 0xd5, 0x90, 0xb9, 0x6e, 0x35, 0x64, 0xaf, 0x5d,
-	0x0d, 0xb9, 0x1b, 0x5c, 0xea, 0x26, 0x14, 0xb0, 0x50, 0xe1, 0xe4, 0xb1, 0xb6, 0x48, 0xab, 0xfe,
-	0x66, 0x40, 0x79, 0x01, 0xc5, 0x5b, 0xed, 0x9d, 0x7c, 0x06, 0xe4, 0x6c, 0xe4, 0x0d, 0x2e, 0x47,
-	0xbe, 0x90, 0x8a, 0x50, 0xba, 0x84, 0x1c, 0xba, 0xdc, 0x4d, 0x9d, 0x60, 0x52, 0xa1, 0xaa, 0x3c,
-	0xe7, 0xe1, 0x8f, 0x2c, 0xc0, 0x0d, 0x69, 0xd2, 0x48, 0x4b, 0xc6, 0x2a, 0x6f, 0x17, 0x6a, 0x7f,
-	0x64, 0xf1, 0xfd, 0xd0, 0xe8, 0x7c, 0x0e, 0x1b, 0x08, 0x88, 0x1f, 0x5c, 0xb8, 0x83, 0x70, 0x74,
-	0x35, 0x0e, 0x70, 0xa9, 0x45, 0xc3, 0x4a, 0xe2, 0xb3, 0x16, 0x1e, 0xa9, 0xbd, 0x46, 0x5e, 0xad,
-	0x46, 0x60, 0x9f, 0x19, 0xec, 0xd3, 0x59, 0x00, 0x11, 0xbf, 0x71, 0xa0, 0x39, 0xbe, 0x94, 0x0b,
-	0x7b, 0x7e, 0x96, 0x4c, 0xca, 0x39, 0x0f, 0xc7, 0x62, 0xf5, 0x41, 0x88, 0x73, 0x44, 0xc3, 0xf2,
-	0x82, 0x87, 0xe3, 0x78, 0x58, 0x94, 0x2c, 0xc8, 0xd7, 0x50, 0x8e, 0x6f, 0x5a, 0x97, 0x91, 0xc7,
-	0x32, 0x36, 0x57, 0x53, 0x60, 0x11, 0xd6, 0x65, 0x4a, 0x23, 0x1f, 0x43, 0xf9, 0xcc, 0x13, 0xcc,
-	0x4d, 0xb8, 0xa3, 0x5f, 0x0f, 0x4b, 0x19, 0x13, 0x84, 0xbe, 0x80, 0xb2, 0x08, 0xbc, 0x89, 0x78,
-	0x13, 0x46, 0x8b, 0x63, 0xed, 0x1d, 0x8b, 0xc3, 0x8a, 0x5d, 0x70, 0x73, 0x5e, 0xc5, 0xb3, 0xa0,
-	0x6a, 0xbc, 0x5d, 0x3e, 0xa4, 0x99, 0x9e, 0x5d, 0x64, 0xba, 0xbe, 0xe4, 0xda, 0x4f, 0x06, 0xd8,
-	0x7a, 0x29, 0xb0, 0xc9, 0xc8, 0x1f, 0x78, 0xd2, 0x0f, 0x03, 0xf2, 0x04, 0xf2, 0x41, 0x38, 0x64,
-	0x6a, 0x73, 0x2a, 0x84, 0x3f, 0x5a, 0xda, 0x03, 0x29, 0xd7, 0x46, 0x27, 0x1c, 0x32, 0xaa, 0xbd,
-	0xab, 0xcf, 0x20, 0xa7, 0x54, 0xb5, 0x7f, 0xa3, 0x16, 0x6e, 0xb2, 0x7f, 0xe5, 0x5c, 0xa9, 0x9d,
-	0x42, 0x25, 0xfa, 0xc2, 0x39, 0xe3, 0x2c, 0x18, 0x30, 0xf5, 0xd3, 0x23, 0xc5, 0x30, 0x94, 0x3f,
-	0x78, 0xc5, 0xd6, 0x7e, 0x36, 0x80, 0x60, 0xde, 0xc5, 0xd1, 0xbb, 0x8d, 0xdc, 0xe4, 0x31, 0x6c,
-	0xbe, 0xbd, 0x62, 0x7c, 0xa6, 0x37, 0xde, 0x80, 0xb9, 0x43, 0x5f, 0xa8, 0xaf, 0xe8, 0x0d, 0x62,
-	0xd2, 0x0d, 0x3c, 0xed, 0xe9, 0xc3, 0xfd, 0xe8, 0xac, 0xf6, 0x57, 0x0e, 0x4a, 0x3d, 0x3e, 0x4d,
-	0x68, 0xf3, 0x2d, 0xc0, 0xc4, 0xe3, 0xd2, 0x57, 0x98, 0xc6, 0xb0, 0x7f, 0x92, 0x82, 0x7d, 0xee,
-	0x9a, 0x30, 0xb4, 0x1b, 0xfb, 0xd3, 0x54, 0xe8, 0xb5, 0x13, 0x9a, 0xf9, 0xe0, 0x09, 0xcd, 0xfe,
-	0x87, 0x09, 0x6d, 0x42, 0x29, 0x35, 0xa1, 0xd1, 0x80, 0x6e, 0xbd, 0xbb, 0x8f, 0xd4, 0x8c, 0xc2,
-	0x7c, 0x46, 0xab, 0x7f, 0x1a, 0x70, 0x77, 0xa5, 0x45, 0x35, 0x15, 0xa9, 0x47, 0xf2, 0xfd, 0x53,
-	0x31, 0x7f, 0x1d, 0x49, 0x0b, 0x6c, 0xac, 0xd2, 0xe5, 0x31, 0xa1, 0xf4, 0x80, 0x94, 0xd2, 0x7d,
-	0x2d, 0x32, 0x8e, 0xae, 0x8b, 0x05, 0x5d, 0x90, 0x2e, 0xdc, 0xd7, 0x49, 0x96, 0x5f, 0x49, 0xfd,
-	0x52, 0xff, 0x7f, 0x29, 0xd3, 0xe2, 0x23, 0x79, 0x4f, 0xac, 0xd8, 0x44, 0xd5, 0xbd, 0x8d, 0x89,
-	0x7f, 0xcf, 0x2b, 0x16, 0xad, 0xee, 0x43, 0x30, 0x5b, 0x6c, 0x34, 0x3a, 0x08, 0xce, 0x43, 0xf5,
-	0x3b, 0x11, 0x71, 0xe1, 0xae, 0x37, 0x1c, 0x72, 0x26, 0x44, 0xc4, 0xfa, 0xb2, 0xb6, 0x36, 0xb5,
-	0x51, 0x8d, 0x04, 0x0f, 0x43, 0x19, 0x25, 0x44, 0x39, 0x5a, 0x14, 0x35, 0x00, 0x95, 0x4c, 0xe8,
-	0x1f, 0x4a, 0xef, 0x5c, 0x37, 0xdb, 0x75, 0xb0, 0xd2, 0xfb, 0x93, 0x00, 0x14, 0x3a, 0x27, 0xf4,
-	0xb8, 0x79, 0x64, 0xdf, 0x21, 0x16, 0x98, 0xbd, 0x4e, 0xb3, 0xdb, 0x7b, 0x79, 0xd2, 0xb7, 0x8d,
-	0xed, 0x5d, 0xa8, 0x2c, 0xd2, 0x89, 0x14, 0x21, 0x7f, 0xda, 0xe9, 0xb5, 0xfb, 0xf6, 0x1d, 0x15,
-	0x76, 0x7a, 0xd0, 0xe9, 0x7f, 0xf9, 0xd8, 0x36, 0x94, 0xf9, 0xf9, 0xeb, 0x7e, 0xbb, 0x67, 0x67,
-	0xb6, 0x7f, 0x31, 0x00, 0xe6, 0x58, 0x90, 0x12, 0xac, 0x9d, 0x76, 0x0e, 0x3b, 0x27, 0xdf, 0x75,
-	0x74, 0xc8, 0x71, 0xb3, 0xd7, 0x6f, 0x53, 0xdb, 0x50, 0x07, 0xb4, 0xdd, 0x3d, 0x3a, 0x68, 0x35,
-	0xed, 0x8c, 0x3a, 0xa0, 0xfb, 0x27, 0x9d, 0xa3, 0xd7, 0x76, 0x16, 0x73, 0x35, 0xfb, 0xad, 0x97,
-	0x5a, 0xec, 0x75, 0x9b, 0xb4, 0x6d, 0xe7, 0x88, 0x0d, 0x56, 0xfb, 0xfb, 0x6e, 0x9b, 0x1e, 0x1c,
-	0xb7, 0x3b, 0xfd, 0xe6, 0x91, 0x9d, 0x57, 0x31, 0xcf, 0x9b, 0xad, 0xc3, 0xd3, 0xae, 0x5d, 0xd0,
-	0xc9, 0x7a, 0xfd, 0x13, 0xda, 0xb6, 0xd7, 0x94, 0xb2, 0x4f, 0x9b, 0x07, 0x9d, 0xf6, 0xbe, 0x6d,
-	0x56, 0x33, 0xb6, 0xf1, 0x7c, 0x0f, 0xd6, 0xfd, 0xb0, 0x31, 0xf5, 0x25, 0x13, 0x42, 0xff, 0xdd,
-	0xfa, 0xe1, 0x61, 0xa4, 0xf9, 0xe1, 0x8e, 0x96, 0x76, 0x2e, 0xc2, 0x9d, 0xa9, 0xdc, 0xc1, 0xd3,
-	0x9d, 0xf8, 0x52, 0xcf, 0x0a, 0xa8, 0x3f, 0xfa, 0x27, 0x00, 0x00, 0xff, 0xff, 0x51, 0xac, 0x2b,
-	0xc1, 0xc6, 0x0d, 0x00, 0x00,
+	// 1520 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x4f, 0x6f, 0xdb, 0xc6,
+	0x12, 0x0f, 0xf5, 0xcf, 0xd2, 0xe8, 0x8f, 0xe9, 0x8d, 0x63, 0x10, 0x7a, 0x09, 0x62, 0xe8, 0x21,
+	0x78, 0x86, 0x1f, 0x9e, 0xfc, 0x9e, 0x93, 0xbc, 0x1a, 0x29, 0x0a, 0x44, 0xb1, 0x95, 0xda, 0xb1,
+	0x2d, 0x0b, 0x2b, 0x19, 0x6d, 0x7a, 0x21, 0x68, 0x71, 0xed, 0x10, 0xa6, 0xb8, 0xcc, 0x2e, 0xed,
+	0x40, 0xbd, 0xf6, 0xd8, 0x43, 0x7b, 0xee, 0x37, 0xe8, 0xf7, 0xe9, 0xb1, 0x97, 0xf6, 0x73, 0xf4,
+	0x50, 0xec, 0x2c, 0x49, 0x51, 0xb2, 0xe3, 0x3a, 0x85, 0x6f, 0x3b, 0xb3, 0x33, 0xc3, 0x99, 0xd9,
+	0xdf, 0xfc, 0x76, 0x09, 0x8d, 0x88, 0x87, 0xdc, 0x75, 0x22, 0xa7, 0x1d, 0x0a, 0x1e, 0x71, 0x52,
+	0x4e, 0xe4, 0x66, 0xed, 0x32, 0x8a, 0xbc, 0x31, 0xd3, 0xfa, 0xd6, 0x26, 0x94, 0xf7, 0xd9, 0x84,
+	0x3a, 0xc1, 0x19, 0x23, 0xcb, 0x50, 0x94, 0x91, 0x23, 0x22, 0xcb, 0x58, 0x35, 0xd6, 0x6a, 0x54,
+	0x0b, 0xc4, 0x84, 0x3c, 0x0b, 0x5c, 0x2b, 0x87, 0x3a, 0xb5, 0x6c, 0x3d, 0x85, 0xea, 0xd0, 0x39,
+	0xf1, 0x59, 0xd4, 0xf1, 0x3d, 0x47, 0x12, 0x02, 0x85, 0x11, 0xf3, 0x7d, 0xf4, 0xaa, 0x50, 0x5c,
+	0x2b, 0xa7, 0x0b, 0x4f, 0x3b, 0xd5, 0xa9, 0x5a, 0xb6, 0xfe, 0x28, 0x40, 0x49, 0x7b, 0x91, 0x7f,
+	0x43, 0xd1, 0x51, 0x9e, 0xe8, 0x51, 0xdd, 0x7c, 0xd0, 0x4e, 0x73, 0xcd, 0x84, 0xa5, 0xda, 0x86,
+	0x34, 0xa1, 0xfc, 0x8e, 0xcb, 0x28, 0x70, 0xc6, 0x0c, 0xc3, 0x55, 0x68, 0x2a, 0x93, 0x2d, 0x28,
+	0x87, 0x5c, 0x44, 0xf6, 0xd8, 0x09, 0xad, 0xc2, 0x6a, 0x7e, 0xad, 0xba, 0xf9, 0x68, 0x3e, 0x56,
+	0xbb, 0xcf, 0x45, 0x74, 0xe8, 0x84, 0xdd, 0x20, 0x12, 0x13, 0xba, 0x10, 0x6a, 0x49, 0x45, 0x3d,
+	0x67, 0x13, 0x19, 0x3a, 0x23, 0x66, 0x15, 0x75, 0xd4, 0x44, 0xc6, 0x36, 0xbc, 0x73, 0x84, 0x6b,
+	0x95, 0x70, 0x43, 0x0b, 0x64, 0x03, 0x2a, 0xe7, 0x6c, 0x62, 0x0b, 0xd5, 0x29, 0x6b, 0x01, 0x13,
+	0x27, 0xd3, 0x8f, 0x25, 0x3d, 0xc4, 0x30, 0xb8, 0x22, 0x6b, 0x50, 0x88, 0x26, 0x21, 0xb3, 0xca,
+	0xab, 0xc6, 0x5a, 0x63, 0x73, 0x79, 0x3e, 0xb1, 0xe1, 0x24, 0x64, 0x14, 0x2d, 0xc8, 0x1a, 0x98,
+	0xee, 0x89, 0xad, 0x2a, 0xb2, 0xf9, 0x25, 0x13, 0xc2, 0x73, 0x99, 0x55, 0xc1, 0x6f, 0x37, 0xdc,
+	0x93, 0x9e, 0x33, 0x66, 0x47, 0xb1, 0x96, 0xb4, 0xa1, 0x10, 0x39, 0x67, 0xd2, 0x02, 0x2c, 0xb6,
+	0x79, 0xa5, 0xd8, 0xa1, 0x73, 0x26, 0x75, 0xa5, 0x68, 0x47, 0x9e, 0x40, 0x63, 0x3c, 0x91, 0xef,
+	0x7d, 0x3b, 0x6d, 0x61, 0x0d, 0xe3, 0xd6, 0x51, 0xbb, 0x9b, 0xf4, 0xf1, 0x11, 0x80, 0x36, 0x53,
+	0xed, 0xb1, 0xea, 0xab, 0xc6, 0x5a, 0x91, 0x56, 0x50, 0xa3, 0xba, 0x47, 0x3a, 0xb0, 0x32, 0x76,
+	0x64, 0xc4, 0x84, 0x1d, 0x31, 0x31, 0xb6, 0x11, 0x16, 0xb6, 0xc2, 0x90, 0xd5, 0xc0, 0x3e, 0xd4,
+	0xda, 0x31, 0xa4, 0x86, 0xde, 0x98, 0xd1, 0xfb, 0xda, 0x76, 0xc8, 0xc4, 0x78, 0xa0, 0x2c, 0x95,
+	0xb2, 0xf9, 0x02, 0x6a, 0xd9, 0x83, 0x50, 0xf8, 0x38, 0x67, 0x93, 0x18, 0x32, 0x6a, 0xa9, 0xba,
+	0x7e, 0xe9, 0xf8, 0x17, 0xfa, 0x90, 0x8b, 0x54, 0x0b, 0x2f, 0x72, 0x5b, 0x46, 0xf3, 0x33, 0xa8,
+	0xa4, 0x75, 0xfd, 0x95, 0x63, 0x25, 0xe3, 0xf8, 0xa6, 0x50, 0xce, 0x9b, 0x85, 0x37, 0x85, 0x72,
+	0xd5, 0xac, 0xb5, 0x7e, 0x29, 0x41, 0x71, 0x80, 0x07, 0xb9, 0x05, 0xb5, 0xb8, 0x9a, 0x5b, 0x80,
+	0xb0, 0xaa, 0x4d, 0x51, 0xb8, 0xa1, 0x0f, 0xe5, 0x5b, 0xf6, 0x61, 0x16, 0x45, 0xb9, 0x5b, 0xa0,
+	0xe8, 0x0b, 0xa8, 0x49, 0x26, 0x2e, 0x99, 0x6b, 0x2b, 0xa8, 0x48, 0x2b, 0x3f, 0x7f, 0xf2, 0x58,
+	0x54, 0x7b, 0x80, 0x36, 0x88, 0xa9, 0xaa, 0x4c, 0xd7, 0x92, 0xbc, 0x84, 0xba, 0xe4, 0x17, 0x62,
+	0xc4, 0x6c, 0x44, 0xb1, 0x8c, 0xc7, 0xe4, 0x1f, 0x57, 0xfc, 0xd1, 0x08, 0xd7, 0xb4, 0x26, 0xa7,
+	0x82, 0x24, 0xaf, 0x61, 0x31, 0xc2, 0x86, 0xd8, 0x23, 0x1e, 0x44, 0x82, 0xfb, 0xd2, 0x2a, 0xcd,
+	0x8f, 0x9a, 0x8e, 0xa1, 0xfb, 0xb6, 0xad, 0xad, 0x68, 0x23, 0xca, 0x8a, 0x92, 0xac, 0xc3, 0x92,
+	0x27, 0xed, 0xb8, 0x7f, 0x2a, 0x45, 0x2f, 0x38, 0xc3, 0x39, 0x2a, 0xd3, 0x45, 0x4f, 0x1e, 0xa2,
+	0x7e, 0xa0, 0xd5, 0xcd, 0xb7, 0x00, 0xd3, 0x82, 0xc8, 0x73, 0xa8, 0xc6, 0x19, 0xe0, 0x3c, 0x19,
+	0x37, 0xcc, 0x13, 0x44, 0xe9, 0x5a, 0xe1, 0x42, 0x51, 0x91, 0xb4, 0x72, 0xab, 0x79, 0x85, 0x0b,
+	0x14, 0x9a, 0x3f, 0x19, 0x50, 0xcd, 0x14, 0x9b, 0x10, 0x95, 0x91, 0x12, 0xd5, 0x0c, 0x35, 0xe4,
+	0x3e, 0x46, 0x0d, 0xf9, 0x8f, 0x52, 0x43, 0xe1, 0x16, 0x87, 0xba, 0x02, 0x25, 0x4c, 0x54, 0x5a,
+	0x45, 0xcc, 0x2d, 0x96, 0x9a, 0x3f, 0x1b, 0x50, 0x9f, 0xe9, 0xe2, 0x9d, 0xd6, 0x4e, 0xfe, 0x03,
+	0xe4, 0xc4, 0x77, 0x46, 0xe7, 0xbe, 0x27, 0x23, 0x05, 0x28, 0x9d, 0x42, 0x01, 0x4d, 0x96, 0x32,
+	0x3b, 0x18, 0x54, 0xaa, 0x2c, 0x4f, 0x05, 0xff, 0x96, 0x05, 0xc8, 0x90, 0x65, 0x1a, 0x4b, 0xe9,
+	0x58, 0x15, 0xcd, 0x52, 0xeb, 0xd7, 0x3c, 0xde, 0x1f, 0xba, 0x3b, 0xff, 0x85, 0x65, 0x6c, 0x88,
+	0x17, 0x9c, 0xd9, 0x23, 0xee, 0x5f, 0x8c, 0x03, 0x24, 0xb5, 0x78, 0x58, 0x49, 0xb2, 0xb7, 0x8d,
+	0x5b, 0x8a, 0xd7, 0xc8, 0x9b, 0xab, 0x1e, 0x58, 0x67, 0x0e, 0xeb, 0xb4, 0x66, 0x9a, 0x88, 0xdf,
+	0xd8, 0xd3, 0x18, 0x9f, 0x8b, 0x85, 0x35, 0xbf, 0x4c, 0x27, 0xe5, 0x54, 0xf0, 0xb1, 0xbc, 0x7a,
+	0x21, 0x24, 0x31, 0xe2, 0x61, 0x79, 0x2d, 0xf8, 0x38, 0x19, 0x16, 0xb5, 0x96, 0xe4, 0x73, 0xa8,
+	0x27, 0x27, 0xad, 0xd3, 0x28, 0x62, 0x1a, 0x2b, 0x57, 0x43, 0x60, 0x12, 0xb5, 0xf3, 0x8c, 0x44,
+	0xfe, 0x09, 0xf5, 0x13, 0x47, 0x32, 0x3b, 0xc5, 0x8e, 0xbe, 0x3d, 0x6a, 0x4a, 0x99, 0x76, 0xe8,
+	0x7f, 0x50, 0x97, 0x81, 0x13, 0xca, 0x77, 0x3c, 0x26, 0x8e, 0x85, 0x6b, 0x88, 0xa3, 0x96, 0x98,
+	0x20, 0x73, 0x5e, 0x24, 0xb3, 0xa0, 0x72, 0xbc, 0x5b, 0x3c, 0x64, 0x91, 0x9e, 0x9f, 0x45, 0xba,
+	0x3e, 0xe4, 0xd6, 0xf7, 0x06, 0x98, 0x9a, 0x14, 0x58, 0xe8, 0x7b, 0x23, 0x27, 0xf2, 0x78, 0x40,
+	0x9e, 0x43, 0x31, 0xe0, 0x2e, 0x53, 0xcc, 0xa9, 0x3a, 0xfc, 0x78, 0x8e, 0x07, 0x32, 0xa6, 0xed,
+	0x1e, 0x77, 0x19, 0xd5, 0xd6, 0xcd, 0x97, 0x50, 0x50, 0xa2, 0xe2, 0xdf, 0xb8, 0x84, 0xdb, 0xf0,
+	0x6f, 0x34, 0x15, 0x5a, 0xdf, 0xe5, 0xa1, 0x41, 0x59, 0xe8, 0x08, 0x16, 0x44, 0x94, 0x8d, 0xb8,
+	0x70, 0xc9, 0x43, 0xa8, 0xf0, 0x90, 0x09, 0xfc, 0x5a, 0x8c, 0xb3, 0xa9, 0x82, 0xac, 0x42, 0x01,
+	0xbb, 0x9c, 0xbb, 0xa6, 0xcb, 0xb8, 0x43, 0x1e, 0x43, 0xd5, 0xbd, 0xd0, 0xd6, 0x76, 0x20, 0xb1,
+	0x0b, 0x79, 0x0a, 0x89, 0xaa, 0x27, 0xc9, 0x33, 0x00, 0xee, 0xbb, 0x31, 0x6f, 0x59, 0x85, 0x9b,
+	0x72, 0xad, 0x70, 0xdf, 0xd5, 0x3c, 0xa6, 0xbc, 0x02, 0xf6, 0x21, 0xf1, 0x2a, 0xde, 0xe8, 0x15,
+	0xb0, 0x0f, 0xb1, 0x57, 0x1b, 0xee, 0x4f, 0xbf, 0x65, 0x87, 0x5c, 0x7a, 0x58, 0x96, 0x06, 0xd2,
+	0x52, 0x1a, 0xbd, 0x1f, 0x6f, 0x28, 0xfb, 0xe9, 0x57, 0xa6, 0xf6, 0x0b, 0xda, 0x3e, 0x8d, 0x9b,
+	0xda, 0xaf, 0x40, 0x49, 0x30, 0x47, 0xf2, 0x00, 0xef, 0xab, 0x0a, 0x8d, 0x25, 0xa5, 0x1f, 0x39,
+	0xbe, 0xcf, 0x44, 0xfc, 0xea, 0x88, 0x25, 0x85, 0x1a, 0x26, 0x04, 0x17, 0x16, 0x68, 0xb6, 0x43,
+	0xa1, 0xd5, 0x85, 0xc5, 0xe4, 0x10, 0x76, 0x3d, 0x19, 0x71, 0x31, 0x21, 0x9b, 0xb0, 0x20, 0xf0,
+	0x3c, 0x12, 0x4c, 0x64, 0x26, 0x77, 0xf6, 0xc0, 0x68, 0x62, 0xd8, 0x3a, 0x86, 0x46, 0x0c, 0x97,
+	0x53, 0x26, 0x58, 0x30, 0x62, 0xea, 0x1d, 0x99, 0xa1, 0x0b, 0x5c, 0x7f, 0xf2, 0x7d, 0xd9, 0xfa,
+	0xc1, 0x00, 0x82, 0x71, 0x67, 0x79, 0xf4, 0x2e, 0x62, 0x93, 0x67, 0xb0, 0xf2, 0xfe, 0x82, 0x89,
+	0x89, 0xbe, 0xbe, 0x46, 0xcc, 0x76, 0x3d, 0xa9, 0xbe, 0xa2, 0xaf, 0x83, 0x32, 0x5d, 0xc6, 0xdd,
+	0x81, 0xde, 0xdc, 0x89, 0xf7, 0x5a, 0xbf, 0x17, 0xa0, 0x3a, 0x10, 0x97, 0x29, 0x07, 0x7c, 0x09,
+	0x10, 0x3a, 0x22, 0xc2, 0x23, 0x49, 0xfa, 0xf5, 0xaf, 0xcc, 0x0c, 0x4d, 0x4d, 0x53, 0xba, 0xe9,
+	0x27, 0xf6, 0x34, 0xe3, 0xfa, 0x51, 0xba, 0xcd, 0x7d, 0x32, 0xdd, 0xe6, 0xff, 0x06, 0xdd, 0x76,
+	0xa0, 0x9a, 0xa1, 0xdb, 0x98, 0x6d, 0x57, 0xaf, 0xaf, 0x23, 0x43, 0xb8, 0x30, 0x25, 0xdc, 0xe6,
+	0x6f, 0x06, 0x2c, 0x5d, 0x29, 0x51, 0x51, 0x5c, 0xe6, 0xc5, 0x73, 0x33, 0xc5, 0x4d, 0x9f, 0x3a,
+	0x64, 0x1b, 0x4c, 0xcc, 0xd2, 0x16, 0x09, 0xa0, 0x34, 0xdb, 0xcd, 0x80, 0x71, 0x16, 0x71, 0x74,
+	0x51, 0xce, 0xc8, 0x92, 0xf4, 0xe1, 0x81, 0x0e, 0x32, 0xff, 0xe4, 0xd1, 0xcf, 0xae, 0x87, 0x73,
+	0x91, 0x66, 0x5f, 0x3c, 0xf7, 0xe5, 0x15, 0x9d, 0x6c, 0xda, 0x77, 0x41, 0xdf, 0x37, 0x3c, 0x49,
+	0xe2, 0x7b, 0x78, 0x1f, 0xca, 0xdb, 0xcc, 0xf7, 0xf7, 0x82, 0x53, 0xae, 0x1e, 0xfd, 0xd8, 0x17,
+	0x61, 0x3b, 0xae, 0x2b, 0x98, 0x94, 0x31, 0xea, 0xeb, 0x5a, 0xdb, 0xd1, 0x4a, 0x35, 0x12, 0x82,
+	0xf3, 0x28, 0x0e, 0x88, 0xeb, 0x98, 0xf5, 0x5b, 0x00, 0x2a, 0x98, 0xd4, 0xaf, 0xde, 0x6b, 0xef,
+	0x8e, 0xf5, 0x35, 0xa8, 0x65, 0x2f, 0x43, 0x02, 0x50, 0xea, 0x1d, 0xd1, 0xc3, 0xce, 0x81, 0x79,
+	0x8f, 0xd4, 0xa0, 0x3c, 0xe8, 0x75, 0xfa, 0x83, 0xdd, 0xa3, 0xa1, 0x69, 0xac, 0x6f, 0x42, 0x63,
+	0x16, 0x4e, 0xa4, 0x02, 0xc5, 0xe3, 0xde, 0xa0, 0x3b, 0x34, 0xef, 0x29, 0xb7, 0xe3, 0xbd, 0xde,
+	0xf0, 0xff, 0xcf, 0x4c, 0x43, 0xa9, 0x5f, 0xbd, 0x1d, 0x76, 0x07, 0x66, 0x6e, 0xfd, 0x47, 0x03,
+	0x60, 0xda, 0x0b, 0x52, 0x85, 0x85, 0xe3, 0xde, 0x7e, 0xef, 0xe8, 0xab, 0x9e, 0x76, 0x39, 0xec,
+	0x0c, 0x86, 0x5d, 0x6a, 0x1a, 0x6a, 0x83, 0x76, 0xfb, 0x07, 0x7b, 0xdb, 0x1d, 0x33, 0xa7, 0x36,
+	0xe8, 0xce, 0x51, 0xef, 0xe0, 0xad, 0x99, 0xc7, 0x58, 0x9d, 0xe1, 0xf6, 0xae, 0x5e, 0x0e, 0xfa,
+	0x1d, 0xda, 0x35, 0x0b, 0xc4, 0x84, 0x5a, 0xf7, 0xeb, 0x7e, 0x97, 0xee, 0x1d, 0x76, 0x7b, 0xc3,
+	0xce, 0x81, 0x59, 0x54, 0x3e, 0xaf, 0x3a, 0xdb, 0xfb, 0xc7, 0x7d, 0xb3, 0xa4, 0x83, 0x0d, 0x86,
+	0x47, 0xb4, 0x6b, 0x2e, 0x28, 0x61, 0x87, 0x76, 0xf6, 0x7a, 0xdd, 0x1d, 0xb3, 0xdc, 0xcc, 0x99,
+	0xc6, 0xab, 0x2d, 0x58, 0xf4, 0x78, 0xfb, 0xd2, 0x8b, 0x98, 0x94, 0xfa, 0xdf, 0xf9, 0x9b, 0x27,
+	0xb1, 0xe4, 0xf1, 0x0d, 0xbd, 0xda, 0x38, 0xe3, 0x1b, 0x97, 0xd1, 0x06, 0xee, 0x6e, 0x24, 0x87,
+	0x7a, 0x52, 0x42, 0xf9, 0xe9, 0x9f, 0x03, 0x00, 0xa0, 0xfe, 0xce, 0xb2, 0x93, 0x0f, 0x00, 0x00,
 }
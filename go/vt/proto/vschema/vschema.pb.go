@@ -265,10 +265,20 @@ type Table struct {
 	// column_list_authoritative is set to true if columns is
 	// an authoritative list for the table. This allows
 	// us to expand 'select *' expressions.
-	ColumnListAuthoritative bool     `protobuf:"varint,6,opt,name=column_list_authoritative,json=columnListAuthoritative,proto3" json:"column_list_authoritative,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
-	XXX_unrecognized        []byte   `json:"-"`
-	XXX_sizecache           int32    `json:"-"`
+	ColumnListAuthoritative bool `protobuf:"varint,6,opt,name=column_list_authoritative,json=columnListAuthoritative,proto3" json:"column_list_authoritative,omitempty"`
+	// primary_key lists the columns of the primary key of the table.
+	// It is needed by DMLs that are planned by first selecting the
+	// rows to modify, like multi-shard DMLs with a LIMIT.
+	PrimaryKey []string `protobuf:"bytes,7,rep,name=primary_key,json=primaryKey,proto3" json:"primary_key,omitempty"`
+	// result_cache is set to true if the results of the queries that
+	// read the table may be kept in the vtgate result cache.
+	ResultCache bool `protobuf:"varint,8,opt,name=result_cache,json=resultCache,proto3" json:"result_cache,omitempty"`
+	// row_policies restrict the rows of the table that the users can
+	// read and write.
+	RowPolicies          []*RowPolicy `protobuf:"bytes,9,rep,name=row_policies,json=rowPolicies,proto3" json:"row_policies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Table) Reset()         { *m = Table{} }
@@ -338,6 +348,96 @@ func (m *Table) GetColumnListAuthoritative() bool {
 	return false
 }
 
+func (m *Table) GetPrimaryKey() []string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return nil
+}
+
+func (m *Table) GetResultCache() bool {
+	if m != nil {
+		return m.ResultCache
+	}
+	return false
+}
+
+func (m *Table) GetRowPolicies() []*RowPolicy {
+	if m != nil {
+		return m.RowPolicies
+	}
+	return nil
+}
+
+// RowPolicy restricts the rows of a table that some users can read and
+// write to the rows which match a predicate. The predicate is added to
+// the SELECT, UPDATE and DELETE statements on the table, and the rows
+// written by the INSERT and UPDATE statements are verified against it.
+type RowPolicy struct {
+	// name identifies the policy in the errors.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// predicate is a boolean expression on the columns of the table,
+	// like "tenant_id = :__vt_user_attr_tenant". The bind variables
+	// named __vt_user_attr_<attribute> are the attributes of the user
+	// given by the auth server, or NULL if the user has no such attribute.
+	// The rows written are only verified against the conditions of the
+	// form "column = value" of the predicate, so the writes are denied if
+	// it has other conditions.
+	Predicate string `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	// roles lists the users and groups the policy applies to. It applies
+	// to all the users if it is empty.
+	Roles                []string `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RowPolicy) Reset()         { *m = RowPolicy{} }
+func (m *RowPolicy) String() string { return proto.CompactTextString(m) }
+func (*RowPolicy) ProtoMessage()    {}
+func (*RowPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f6849254fea3e77, []int{5}
+}
+
+func (m *RowPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowPolicy.Unmarshal(m, b)
+}
+func (m *RowPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowPolicy.Marshal(b, m, deterministic)
+}
+func (m *RowPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowPolicy.Merge(m, src)
+}
+func (m *RowPolicy) XXX_Size() int {
+	return xxx_messageInfo_RowPolicy.Size(m)
+}
+func (m *RowPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowPolicy proto.InternalMessageInfo
+
+func (m *RowPolicy) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RowPolicy) GetPredicate() string {
+	if m != nil {
+		return m.Predicate
+	}
+	return ""
+}
+
+func (m *RowPolicy) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
 // ColumnVindex is used to associate a column to a vindex.
 type ColumnVindex struct {
 	// Legacy implementation, moving forward all vindexes should define a list of columns.
@@ -355,7 +455,7 @@ func (m *ColumnVindex) Reset()         { *m = ColumnVindex{} }
 func (m *ColumnVindex) String() string { return proto.CompactTextString(m) }
 func (*ColumnVindex) ProtoMessage()    {}
 func (*ColumnVindex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3f6849254fea3e77, []int{5}
+	return fileDescriptor_3f6849254fea3e77, []int{6}
 }
 
 func (m *ColumnVindex) XXX_Unmarshal(b []byte) error {
@@ -411,7 +511,7 @@ func (m *AutoIncrement) Reset()         { *m = AutoIncrement{} }
 func (m *AutoIncrement) String() string { return proto.CompactTextString(m) }
 func (*AutoIncrement) ProtoMessage()    {}
 func (*AutoIncrement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3f6849254fea3e77, []int{6}
+	return fileDescriptor_3f6849254fea3e77, []int{7}
 }
 
 func (m *AutoIncrement) XXX_Unmarshal(b []byte) error {
@@ -459,7 +559,7 @@ func (m *Column) Reset()         { *m = Column{} }
 func (m *Column) String() string { return proto.CompactTextString(m) }
 func (*Column) ProtoMessage()    {}
 func (*Column) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3f6849254fea3e77, []int{7}
+	return fileDescriptor_3f6849254fea3e77, []int{8}
 }
 
 func (m *Column) XXX_Unmarshal(b []byte) error {
@@ -508,7 +608,7 @@ func (m *SrvVSchema) Reset()         { *m = SrvVSchema{} }
 func (m *SrvVSchema) String() string { return proto.CompactTextString(m) }
 func (*SrvVSchema) ProtoMessage()    {}
 func (*SrvVSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3f6849254fea3e77, []int{8}
+	return fileDescriptor_3f6849254fea3e77, []int{9}
 }
 
 func (m *SrvVSchema) XXX_Unmarshal(b []byte) error {
@@ -552,6 +652,7 @@ func init() {
 	proto.RegisterType((*Vindex)(nil), "vschema.Vindex")
 	proto.RegisterMapType((map[string]string)(nil), "vschema.Vindex.ParamsEntry")
 	proto.RegisterType((*Table)(nil), "vschema.Table")
+	proto.RegisterType((*RowPolicy)(nil), "vschema.RowPolicy")
 	proto.RegisterType((*ColumnVindex)(nil), "vschema.ColumnVindex")
 	proto.RegisterType((*AutoIncrement)(nil), "vschema.AutoIncrement")
 	proto.RegisterType((*Column)(nil), "vschema.Column")
@@ -562,48 +663,54 @@ func init() {
 func init() { proto.RegisterFile("vschema.proto", fileDescriptor_3f6849254fea3e77) }
 
 var fileDescriptor_3f6849254fea3e77 = []byte{
-	// 673 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xcf, 0x4e, 0xdb, 0x4e,
-	0x10, 0x96, 0x13, 0x62, 0x92, 0x31, 0x09, 0xbf, 0xdf, 0x0a, 0xa8, 0x1b, 0x84, 0x88, 0x2c, 0xda,
-	0xa6, 0x3d, 0x24, 0x52, 0x50, 0x25, 0x9a, 0x8a, 0xaa, 0x14, 0x71, 0x40, 0x45, 0x6a, 0x65, 0x10,
-	0x87, 0x5e, 0x2c, 0xe3, 0x6c, 0x61, 0x45, 0xe2, 0x35, 0xbb, 0x6b, 0x97, 0x3c, 0x4a, 0xaf, 0x7d,
-	0xad, 0x3e, 0x42, 0x5f, 0xa2, 0xf2, 0xfe, 0x31, 0x1b, 0x48, 0x6f, 0x3b, 0x3b, 0xf3, 0x7d, 0xf3,
-	0xed, 0xec, 0xcc, 0x40, 0xbb, 0xe0, 0xc9, 0x0d, 0x9e, 0xc5, 0x83, 0x8c, 0x51, 0x41, 0xd1, 0xaa,
-	0x36, 0xbb, 0xde, 0x5d, 0x8e, 0xd9, 0x5c, 0xdd, 0x06, 0x63, 0x58, 0x0b, 0x69, 0x2e, 0x48, 0x7a,
-	0x1d, 0xe6, 0x53, 0xcc, 0xd1, 0x1b, 0x68, 0xb0, 0xf2, 0xe0, 0x3b, 0xbd, 0x7a, 0xdf, 0x1b, 0x6d,
-	0x0c, 0x0c, 0x89, 0x15, 0x15, 0xaa, 0x90, 0xe0, 0x14, 0x3c, 0xeb, 0x16, 0xed, 0x00, 0x7c, 0x67,
-	0x74, 0x16, 0x89, 0xf8, 0x6a, 0x8a, 0x7d, 0xa7, 0xe7, 0xf4, 0x5b, 0x61, 0xab, 0xbc, 0xb9, 0x28,
-	0x2f, 0xd0, 0x36, 0xb4, 0x04, 0x55, 0x4e, 0xee, 0xd7, 0x7a, 0xf5, 0x7e, 0x2b, 0x6c, 0x0a, 0x2a,
-	0x7d, 0x3c, 0xf8, 0x53, 0x83, 0xe6, 0x67, 0x3c, 0xe7, 0x59, 0x9c, 0x60, 0xe4, 0xc3, 0x2a, 0xbf,
-	0x89, 0xd9, 0x04, 0x4f, 0x24, 0x4b, 0x33, 0x34, 0x26, 0x7a, 0x0f, 0xcd, 0x82, 0xa4, 0x13, 0x7c,
-	0xaf, 0x29, 0xbc, 0xd1, 0x6e, 0x25, 0xd0, 0xc0, 0x07, 0x97, 0x3a, 0xe2, 0x24, 0x15, 0x6c, 0x1e,
-	0x56, 0x00, 0xf4, 0x16, 0x5c, 0x9d, 0xbd, 0x2e, 0xa1, 0x3b, 0x4f, 0xa1, 0x4a, 0x8d, 0x02, 0xea,
-	0x60, 0x74, 0x00, 0x3e, 0xc3, 0x77, 0x39, 0x61, 0x38, 0xc2, 0xf7, 0xd9, 0x94, 0x24, 0x44, 0x44,
-	0x4c, 0x3d, 0xdb, 0x5f, 0x91, 0xf2, 0xb6, 0xb4, 0xff, 0x44, 0xbb, 0x75, 0x51, 0xba, 0x67, 0xd0,
-	0x5e, 0xd0, 0x82, 0xfe, 0x83, 0xfa, 0x2d, 0x9e, 0xeb, 0xd2, 0x94, 0x47, 0xf4, 0x02, 0x1a, 0x45,
-	0x3c, 0xcd, 0xb1, 0x5f, 0xeb, 0x39, 0x7d, 0x6f, 0xb4, 0x5e, 0x49, 0x52, 0xc0, 0x50, 0x79, 0xc7,
-	0xb5, 0x03, 0xa7, 0x7b, 0x0a, 0x9e, 0x25, 0x6f, 0x09, 0xd7, 0xde, 0x22, 0x57, 0xa7, 0xe2, 0x92,
-	0x30, 0x8b, 0x2a, 0xf8, 0xe5, 0x80, 0xab, 0x12, 0x20, 0x04, 0x2b, 0x62, 0x9e, 0x99, 0xef, 0x92,
-	0x67, 0xb4, 0x0f, 0x6e, 0x16, 0xb3, 0x78, 0x66, 0x6a, 0xbc, 0xfd, 0x48, 0xd5, 0xe0, 0xab, 0xf4,
-	0xea, 0x32, 0xa9, 0x50, 0xb4, 0x01, 0x0d, 0xfa, 0x23, 0xc5, 0xcc, 0xaf, 0x4b, 0x26, 0x65, 0x74,
-	0xdf, 0x81, 0x67, 0x05, 0x2f, 0x11, 0xbd, 0x61, 0x8b, 0x6e, 0xd9, 0x22, 0x7f, 0xd6, 0xa0, 0xa1,
-	0x3a, 0x67, 0x99, 0xc6, 0x0f, 0xb0, 0x9e, 0xd0, 0x69, 0x3e, 0x4b, 0xa3, 0x47, 0x0d, 0xb1, 0x59,
-	0x89, 0x3d, 0x96, 0x7e, 0x5d, 0xc8, 0x4e, 0x62, 0x59, 0x98, 0xa3, 0x43, 0xe8, 0xc4, 0xb9, 0xa0,
-	0x11, 0x49, 0x13, 0x86, 0x67, 0x38, 0x15, 0x52, 0xb7, 0x37, 0xda, 0xaa, 0xe0, 0x47, 0xb9, 0xa0,
-	0xa7, 0xc6, 0x1b, 0xb6, 0x63, 0xdb, 0x44, 0xaf, 0x61, 0x55, 0x11, 0x72, 0x7f, 0x45, 0xa6, 0x5d,
-	0x7f, 0x94, 0x36, 0x34, 0x7e, 0xb4, 0x05, 0x6e, 0x46, 0xd2, 0x14, 0x4f, 0xfc, 0x86, 0xd4, 0xaf,
-	0x2d, 0x34, 0x86, 0xe7, 0xfa, 0x05, 0x53, 0xc2, 0x45, 0x14, 0xe7, 0xe2, 0x86, 0x32, 0x22, 0x62,
-	0x41, 0x0a, 0xec, 0xbb, 0xb2, 0xb1, 0x9e, 0xa9, 0x80, 0x33, 0xc2, 0xc5, 0x91, 0xed, 0x0e, 0x2e,
-	0x60, 0xcd, 0x7e, 0x5d, 0x99, 0x43, 0x85, 0xea, 0x1a, 0x69, 0xab, 0xac, 0x5c, 0x1a, 0xcf, 0x4c,
-	0x71, 0xe5, 0xb9, 0x9c, 0x2e, 0x23, 0xbd, 0x2e, 0xa7, 0xd0, 0x98, 0xc1, 0x31, 0xb4, 0x17, 0x1e,
-	0xfd, 0x4f, 0xda, 0x2e, 0x34, 0x39, 0xbe, 0xcb, 0x71, 0x9a, 0x18, 0xea, 0xca, 0x0e, 0x0e, 0xc1,
-	0x3d, 0x5e, 0x4c, 0xee, 0x58, 0xc9, 0x77, 0xf5, 0x57, 0x96, 0xa8, 0xce, 0xc8, 0x1b, 0xa8, 0x55,
-	0x74, 0x31, 0xcf, 0xb0, 0xfa, 0xd7, 0xe0, 0xb7, 0x03, 0x70, 0xce, 0x8a, 0xcb, 0x73, 0x59, 0x4c,
-	0xf4, 0x11, 0x5a, 0xb7, 0x7a, 0x38, 0xcd, 0x4a, 0x0a, 0xaa, 0x4a, 0x3f, 0xc4, 0x55, 0x13, 0xac,
-	0x9b, 0xf2, 0x01, 0x84, 0xc6, 0xd0, 0xd6, 0xd3, 0x1a, 0xa9, 0xc5, 0xa6, 0xa6, 0x63, 0x73, 0xd9,
-	0x62, 0xe3, 0xe1, 0x1a, 0xb3, 0xac, 0xee, 0x17, 0xe8, 0x2c, 0x12, 0x2f, 0x69, 0xe0, 0x57, 0x8b,
-	0x53, 0xf7, 0xff, 0x93, 0xa5, 0x62, 0xf5, 0xf4, 0xa7, 0x97, 0xdf, 0xf6, 0x0a, 0x22, 0x30, 0xe7,
-	0x03, 0x42, 0x87, 0xea, 0x34, 0xbc, 0xa6, 0xc3, 0x42, 0x0c, 0xe5, 0x36, 0x1e, 0x6a, 0xec, 0x95,
-	0x2b, 0xcd, 0xfd, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xb8, 0xa7, 0x99, 0x19, 0xc3, 0x05, 0x00,
-	0x00,
+	// 770 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xef, 0x4e, 0xdb, 0x48,
+	0x10, 0x57, 0x12, 0xf2, 0xc7, 0xe3, 0x24, 0xdc, 0xad, 0x80, 0xf3, 0x85, 0x43, 0xe4, 0x2c, 0xee,
+	0x2e, 0xd7, 0x0f, 0x89, 0x14, 0x84, 0x44, 0x53, 0x51, 0x95, 0x46, 0x7c, 0x40, 0x20, 0x15, 0x19,
+	0xc4, 0x87, 0x7e, 0xb1, 0x8c, 0xb3, 0x25, 0x2b, 0x12, 0xaf, 0xd9, 0x5d, 0x07, 0xfc, 0x1e, 0x7d,
+	0x82, 0xbe, 0x56, 0x1f, 0xa1, 0x2f, 0x51, 0x79, 0x77, 0xed, 0x6c, 0x20, 0xfd, 0xb6, 0x33, 0xbf,
+	0x99, 0xdf, 0xfe, 0x3c, 0xb3, 0x33, 0x86, 0xd6, 0x82, 0x87, 0x53, 0x3c, 0x0f, 0xfa, 0x31, 0xa3,
+	0x82, 0xa2, 0xba, 0x36, 0x3b, 0xf6, 0x63, 0x82, 0x59, 0xaa, 0xbc, 0xee, 0x08, 0x9a, 0x1e, 0x4d,
+	0x04, 0x89, 0xee, 0xbd, 0x64, 0x86, 0x39, 0x7a, 0x03, 0x55, 0x96, 0x1d, 0x9c, 0x52, 0xb7, 0xd2,
+	0xb3, 0x87, 0x5b, 0xfd, 0x9c, 0xc4, 0x88, 0xf2, 0x54, 0x88, 0x7b, 0x0e, 0xb6, 0xe1, 0x45, 0x7b,
+	0x00, 0x5f, 0x18, 0x9d, 0xfb, 0x22, 0xb8, 0x9b, 0x61, 0xa7, 0xd4, 0x2d, 0xf5, 0x2c, 0xcf, 0xca,
+	0x3c, 0x37, 0x99, 0x03, 0xed, 0x82, 0x25, 0xa8, 0x02, 0xb9, 0x53, 0xee, 0x56, 0x7a, 0x96, 0xd7,
+	0x10, 0x54, 0x62, 0xdc, 0xfd, 0x51, 0x86, 0xc6, 0x05, 0x4e, 0x79, 0x1c, 0x84, 0x18, 0x39, 0x50,
+	0xe7, 0xd3, 0x80, 0x4d, 0xf0, 0x44, 0xb2, 0x34, 0xbc, 0xdc, 0x44, 0xef, 0xa0, 0xb1, 0x20, 0xd1,
+	0x04, 0x3f, 0x6b, 0x0a, 0x7b, 0xb8, 0x5f, 0x08, 0xcc, 0xd3, 0xfb, 0xb7, 0x3a, 0xe2, 0x2c, 0x12,
+	0x2c, 0xf5, 0x8a, 0x04, 0x74, 0x04, 0x35, 0x7d, 0x7b, 0x45, 0xa6, 0xee, 0xbd, 0x4e, 0x55, 0x6a,
+	0x54, 0xa2, 0x0e, 0x46, 0xc7, 0xe0, 0x30, 0xfc, 0x98, 0x10, 0x86, 0x7d, 0xfc, 0x1c, 0xcf, 0x48,
+	0x48, 0x84, 0xcf, 0xd4, 0x67, 0x3b, 0x1b, 0x52, 0xde, 0x8e, 0xc6, 0xcf, 0x34, 0xac, 0x8b, 0xd2,
+	0xb9, 0x84, 0xd6, 0x8a, 0x16, 0xf4, 0x1b, 0x54, 0x1e, 0x70, 0xaa, 0x4b, 0x93, 0x1d, 0xd1, 0x3f,
+	0x50, 0x5d, 0x04, 0xb3, 0x04, 0x3b, 0xe5, 0x6e, 0xa9, 0x67, 0x0f, 0x37, 0x0b, 0x49, 0x2a, 0xd1,
+	0x53, 0xe8, 0xa8, 0x7c, 0x5c, 0xea, 0x9c, 0x83, 0x6d, 0xc8, 0x5b, 0xc3, 0x75, 0xb0, 0xca, 0xd5,
+	0x2e, 0xb8, 0x64, 0x9a, 0x41, 0xe5, 0x7e, 0x2b, 0x41, 0x4d, 0x5d, 0x80, 0x10, 0x6c, 0x88, 0x34,
+	0xce, 0xdb, 0x25, 0xcf, 0xe8, 0x10, 0x6a, 0x71, 0xc0, 0x82, 0x79, 0x5e, 0xe3, 0xdd, 0x17, 0xaa,
+	0xfa, 0x57, 0x12, 0xd5, 0x65, 0x52, 0xa1, 0x68, 0x0b, 0xaa, 0xf4, 0x29, 0xc2, 0xcc, 0xa9, 0x48,
+	0x26, 0x65, 0x74, 0xde, 0x82, 0x6d, 0x04, 0xaf, 0x11, 0xbd, 0x65, 0x8a, 0xb6, 0x4c, 0x91, 0x5f,
+	0x2b, 0x50, 0x55, 0x2f, 0x67, 0x9d, 0xc6, 0xf7, 0xb0, 0x19, 0xd2, 0x59, 0x32, 0x8f, 0xfc, 0x17,
+	0x0f, 0x62, 0xbb, 0x10, 0x3b, 0x96, 0xb8, 0x2e, 0x64, 0x3b, 0x34, 0x2c, 0xcc, 0xd1, 0x09, 0xb4,
+	0x83, 0x44, 0x50, 0x9f, 0x44, 0x21, 0xc3, 0x73, 0x1c, 0x09, 0xa9, 0xdb, 0x1e, 0xee, 0x14, 0xe9,
+	0xa7, 0x89, 0xa0, 0xe7, 0x39, 0xea, 0xb5, 0x02, 0xd3, 0x44, 0xff, 0x43, 0x5d, 0x11, 0x72, 0x67,
+	0xa3, 0x5b, 0x59, 0xe9, 0x9c, 0xba, 0xd6, 0xcb, 0x71, 0xb4, 0x03, 0xb5, 0x98, 0x44, 0x11, 0x9e,
+	0x38, 0x55, 0xa9, 0x5f, 0x5b, 0x68, 0x04, 0x7f, 0xea, 0x2f, 0x98, 0x11, 0x2e, 0xfc, 0x20, 0x11,
+	0x53, 0xca, 0x88, 0x08, 0x04, 0x59, 0x60, 0xa7, 0x26, 0x1f, 0xd6, 0x1f, 0x2a, 0xe0, 0x92, 0x70,
+	0x71, 0x6a, 0xc2, 0x68, 0x1f, 0xec, 0x98, 0x91, 0x79, 0xc0, 0x52, 0x3f, 0xab, 0x67, 0x5d, 0x4e,
+	0x13, 0x68, 0xd7, 0x05, 0x4e, 0xd1, 0xdf, 0xd0, 0x64, 0x98, 0x27, 0x33, 0xe1, 0x87, 0x41, 0x38,
+	0xc5, 0x4e, 0x43, 0xf2, 0xd9, 0xca, 0x37, 0xce, 0x5c, 0xe8, 0x08, 0x9a, 0x8c, 0x3e, 0xf9, 0x31,
+	0xcd, 0xde, 0x2c, 0xe6, 0x8e, 0x25, 0xbf, 0x03, 0x19, 0x03, 0xff, 0x74, 0x95, 0x61, 0xa9, 0x67,
+	0x33, 0x7d, 0x24, 0x98, 0xbb, 0xd7, 0x60, 0x15, 0x48, 0xd6, 0x99, 0x28, 0x98, 0x17, 0x9d, 0xc9,
+	0xce, 0xe8, 0x2f, 0xb0, 0x62, 0x86, 0x27, 0x24, 0x0c, 0x44, 0xde, 0xd5, 0xa5, 0x23, 0xeb, 0x37,
+	0xa3, 0xf9, 0x0c, 0x5a, 0x9e, 0x32, 0xdc, 0x1b, 0x68, 0x9a, 0xdd, 0xca, 0x6a, 0xa6, 0x3e, 0x5d,
+	0x33, 0x6b, 0xab, 0xb8, 0xaf, 0x6c, 0xdc, 0xe7, 0x2c, 0x5b, 0xa1, 0x38, 0x73, 0xd3, 0x1d, 0x43,
+	0x6b, 0xa5, 0x89, 0xbf, 0xa4, 0xed, 0x40, 0x83, 0xe3, 0xc7, 0x04, 0x47, 0x61, 0x4e, 0x5d, 0xd8,
+	0xee, 0x09, 0xd4, 0xc6, 0xab, 0x97, 0x9b, 0x1f, 0xbb, 0xaf, 0x9f, 0x66, 0x96, 0xd5, 0x1e, 0xda,
+	0x7d, 0xb5, 0x5a, 0x6f, 0xd2, 0x18, 0xab, 0x77, 0xea, 0x7e, 0x2f, 0x01, 0x5c, 0xb3, 0xc5, 0xed,
+	0xb5, 0x2c, 0x2a, 0xfa, 0x00, 0xd6, 0x83, 0x5e, 0x36, 0xf9, 0x8a, 0x75, 0x8b, 0x8a, 0x2f, 0xe3,
+	0x8a, 0x8d, 0xa4, 0x87, 0x6c, 0x99, 0x84, 0x46, 0xd0, 0xd2, 0xdb, 0xc7, 0x57, 0x8b, 0x5a, 0x4d,
+	0xfb, 0xf6, 0xba, 0x45, 0xcd, 0xbd, 0x26, 0x33, 0xac, 0xce, 0x27, 0x68, 0xaf, 0x12, 0xaf, 0x19,
+	0xc8, 0xff, 0x56, 0xb7, 0xc8, 0xef, 0xaf, 0x96, 0xa4, 0x31, 0xa3, 0x1f, 0xff, 0xfd, 0x7c, 0xb0,
+	0x20, 0x02, 0x73, 0xde, 0x27, 0x74, 0xa0, 0x4e, 0x83, 0x7b, 0x3a, 0x58, 0x88, 0x81, 0xfc, 0xbb,
+	0x0c, 0x74, 0xee, 0x5d, 0x4d, 0x9a, 0x87, 0x3f, 0x07, 0x00, 0x7a, 0x5c, 0x6f, 0x16, 0x93, 0x06,
+	0x00, 0x00,
 }
@@ -150,7 +150,10 @@ type Session struct {
 	// lock_session keep tracks of shard on which the lock query is sent.
 	LockSession *Session_ShardSession `protobuf:"bytes,18,opt,name=lock_session,json=lockSession,proto3" json:"lock_session,omitempty"`
 	// last_lock_heartbeat keep tracks of when last lock heartbeat was sent.
-	LastLockHeartbeat    int64    `protobuf:"varint,19,opt,name=last_lock_heartbeat,json=lastLockHeartbeat,proto3" json:"last_lock_heartbeat,omitempty"`
+	LastLockHeartbeat int64 `protobuf:"varint,19,opt,name=last_lock_heartbeat,json=lastLockHeartbeat,proto3" json:"last_lock_heartbeat,omitempty"`
+	// last_write keeps track of when the session last changed rows, in
+	// unix seconds. Its reads skip the vtgate result cache for a while after.
+	LastWrite            int64    `protobuf:"varint,20,opt,name=last_write,json=lastWrite,proto3" json:"last_write,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -307,6 +310,13 @@ func (m *Session) GetLastLockHeartbeat() int64 {
 	return 0
 }
 
+func (m *Session) GetLastWrite() int64 {
+	if m != nil {
+		return m.LastWrite
+	}
+	return 0
+}
+
 type Session_ShardSession struct {
 	Target        *query.Target         `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
 	TransactionId int64                 `protobuf:"varint,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
@@ -1016,82 +1026,83 @@ func init() {
 func init() { proto.RegisterFile("vtgate.proto", fileDescriptor_aab96496ceaf1ebb) }
 
 var fileDescriptor_aab96496ceaf1ebb = []byte{
-	// 1225 bytes of a gzipped FileDescriptorProto
+	// 1236 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xef, 0x6e, 0x1b, 0x45,
-	0x10, 0xef, 0xf9, 0xbf, 0xc7, 0xff, 0xae, 0x9b, 0xb4, 0x5c, 0x4d, 0x01, 0xcb, 0x6d, 0x55, 0xb7,
-	0x20, 0x1b, 0x05, 0x81, 0x2a, 0x04, 0x42, 0x89, 0xe3, 0x16, 0x57, 0x49, 0x1d, 0xd6, 0x4e, 0x2a,
-	0x21, 0xd0, 0xe9, 0xe2, 0xdb, 0x3a, 0xab, 0x38, 0xb7, 0xd7, 0xdd, 0xb5, 0x83, 0x9f, 0x82, 0x4f,
-	0x7c, 0xe1, 0x05, 0x78, 0x04, 0xde, 0x81, 0x6f, 0xbc, 0x11, 0xda, 0xdd, 0x3b, 0xfb, 0x62, 0x02,
-	0x4d, 0x5b, 0xe5, 0x8b, 0xb5, 0x3b, 0x33, 0x3b, 0x3b, 0xf3, 0xfb, 0xcd, 0xcc, 0xad, 0xa1, 0x3c,
-	0x97, 0x13, 0x4f, 0x92, 0x76, 0xc8, 0x99, 0x64, 0x28, 0x67, 0x76, 0x75, 0xfb, 0x98, 0x06, 0x53,
-	0x36, 0xf1, 0x3d, 0xe9, 0x19, 0x4d, 0xbd, 0xf4, 0x7a, 0x46, 0xf8, 0x22, 0xda, 0x54, 0x25, 0x0b,
-	0x59, 0x52, 0x39, 0x97, 0x3c, 0x1c, 0x9b, 0x4d, 0xf3, 0x37, 0x80, 0xfc, 0x90, 0x08, 0x41, 0x59,
-	0x80, 0x1e, 0x40, 0x95, 0x06, 0xae, 0xe4, 0x5e, 0x20, 0xbc, 0xb1, 0xa4, 0x2c, 0x70, 0xac, 0x86,
-	0xd5, 0x2a, 0xe0, 0x0a, 0x0d, 0x46, 0x2b, 0x21, 0xea, 0x42, 0x55, 0x9c, 0x78, 0xdc, 0x77, 0x85,
-	0x39, 0x27, 0x9c, 0x54, 0x23, 0xdd, 0x2a, 0x6d, 0xdd, 0x6d, 0x47, 0xd1, 0x45, 0xfe, 0xda, 0x43,
-	0x65, 0x15, 0x6d, 0x70, 0x45, 0x24, 0x76, 0x02, 0x7d, 0x0c, 0xe0, 0xcd, 0x24, 0x1b, 0xb3, 0xb3,
-	0x33, 0x2a, 0x9d, 0x8c, 0xbe, 0x27, 0x21, 0x41, 0xf7, 0xa0, 0x22, 0x3d, 0x3e, 0x21, 0xd2, 0x15,
-	0x92, 0xd3, 0x60, 0xe2, 0x64, 0x1b, 0x56, 0xab, 0x88, 0xcb, 0x46, 0x38, 0xd4, 0x32, 0xd4, 0x81,
-	0x3c, 0x0b, 0xa5, 0x0e, 0x21, 0xd7, 0xb0, 0x5a, 0xa5, 0xad, 0x5b, 0x6d, 0x93, 0x78, 0xef, 0x17,
-	0x32, 0x9e, 0x49, 0x32, 0x30, 0x4a, 0x1c, 0x5b, 0xa1, 0x1d, 0xb0, 0x13, 0xe9, 0xb9, 0x67, 0xcc,
-	0x27, 0x4e, 0xbe, 0x61, 0xb5, 0xaa, 0x5b, 0x1f, 0xc4, 0xc1, 0x27, 0x32, 0xdd, 0x67, 0x3e, 0xc1,
-	0x35, 0x79, 0x51, 0x80, 0x3a, 0x50, 0x38, 0xf7, 0x78, 0x40, 0x83, 0x89, 0x70, 0x0a, 0x3a, 0xf1,
-	0x8d, 0xe8, 0xd6, 0x1f, 0xd4, 0xef, 0x4b, 0xa3, 0xc3, 0x4b, 0x23, 0xf4, 0x1d, 0x94, 0x43, 0x4e,
-	0x56, 0x68, 0x15, 0xaf, 0x80, 0x56, 0x29, 0xe4, 0x64, 0x89, 0xd5, 0x36, 0x54, 0x42, 0x26, 0xe4,
-	0xca, 0x03, 0x5c, 0xc1, 0x43, 0x59, 0x1d, 0x59, 0xba, 0xb8, 0x0f, 0xd5, 0xa9, 0x27, 0xa4, 0x4b,
-	0x03, 0x41, 0xb8, 0x74, 0xa9, 0xef, 0x94, 0x1a, 0x56, 0x2b, 0x83, 0xcb, 0x4a, 0xda, 0xd7, 0xc2,
-	0xbe, 0x8f, 0x3e, 0x02, 0x78, 0xc5, 0x66, 0x81, 0xef, 0x72, 0x76, 0x2e, 0x9c, 0xb2, 0xb6, 0x28,
-	0x6a, 0x09, 0x66, 0xe7, 0x02, 0xb9, 0x70, 0x7b, 0x26, 0x08, 0x77, 0x7d, 0xf2, 0x8a, 0x06, 0xc4,
-	0x77, 0xe7, 0x1e, 0xa7, 0xde, 0xf1, 0x94, 0x08, 0xa7, 0xa2, 0x03, 0x7a, 0xb4, 0x1e, 0xd0, 0xa1,
-	0x20, 0x7c, 0xd7, 0x18, 0x1f, 0xc5, 0xb6, 0xbd, 0x40, 0xf2, 0x05, 0xde, 0x9c, 0x5d, 0xa2, 0x42,
-	0x03, 0xb0, 0xc5, 0x42, 0x48, 0x72, 0x96, 0x70, 0x5d, 0xd5, 0xae, 0xef, 0xff, 0x2b, 0x57, 0x6d,
-	0xb7, 0xe6, 0xb5, 0x26, 0x2e, 0x4a, 0xd1, 0x87, 0x50, 0xe4, 0xec, 0xdc, 0x1d, 0xb3, 0x59, 0x20,
-	0x9d, 0x5a, 0xc3, 0x6a, 0xa5, 0x71, 0x81, 0xb3, 0xf3, 0xae, 0xda, 0xab, 0x12, 0x14, 0xde, 0x9c,
-	0x84, 0x8c, 0x06, 0x52, 0x38, 0x76, 0x23, 0xdd, 0x2a, 0xe2, 0x84, 0x04, 0xb5, 0xc0, 0xa6, 0x81,
-	0xcb, 0x89, 0x20, 0x7c, 0x4e, 0x7c, 0x77, 0xcc, 0x82, 0xc0, 0xb9, 0xa9, 0x0b, 0xb5, 0x4a, 0x03,
-	0x1c, 0x89, 0xbb, 0x2c, 0x08, 0x14, 0xc3, 0x53, 0x36, 0x3e, 0x8d, 0x09, 0x72, 0x90, 0x2e, 0xc6,
-	0x37, 0x30, 0xac, 0x4e, 0xc4, 0x9d, 0xd7, 0x86, 0x0d, 0x4d, 0x8f, 0xf6, 0x72, 0x42, 0x3c, 0x2e,
-	0x8f, 0x89, 0x27, 0x9d, 0x0d, 0x1d, 0xf1, 0x4d, 0xa5, 0xda, 0x63, 0xe3, 0xd3, 0xef, 0x63, 0x45,
-	0xfd, 0x4f, 0x0b, 0xca, 0x49, 0x6f, 0xe8, 0x01, 0xe4, 0x4c, 0x67, 0xe8, 0x96, 0x2d, 0x6d, 0x55,
-	0xa2, 0x92, 0x1c, 0x69, 0x21, 0x8e, 0x94, 0xaa, 0xc3, 0x93, 0xf5, 0x4f, 0x7d, 0x27, 0xa5, 0xaf,
-	0xa8, 0x24, 0xa4, 0x7d, 0x1f, 0x3d, 0x81, 0xb2, 0x54, 0x00, 0x4a, 0xd7, 0x9b, 0x52, 0x4f, 0x38,
-	0xe9, 0xa8, 0xb9, 0x96, 0x83, 0x64, 0xa4, 0xb5, 0xdb, 0x4a, 0x89, 0x4b, 0x72, 0xb5, 0x41, 0x9f,
-	0x40, 0x69, 0x09, 0x18, 0xf5, 0x75, 0x5f, 0xa7, 0x31, 0xc4, 0xa2, 0xbe, 0x5f, 0xff, 0x09, 0xee,
-	0xfc, 0x67, 0x55, 0x20, 0x1b, 0xd2, 0xa7, 0x64, 0xa1, 0x53, 0x28, 0x62, 0xb5, 0x44, 0x8f, 0x20,
-	0x3b, 0xf7, 0xa6, 0x33, 0xa2, 0xe3, 0x5c, 0x75, 0xda, 0x0e, 0x0d, 0x96, 0x67, 0xb1, 0xb1, 0xf8,
-	0x3a, 0xf5, 0xc4, 0xaa, 0xef, 0xc0, 0xe6, 0x65, 0x85, 0x71, 0x89, 0xe3, 0xcd, 0xa4, 0xe3, 0x62,
-	0xc2, 0xc7, 0xf3, 0x4c, 0x21, 0x6d, 0x67, 0x9a, 0x7f, 0xa4, 0xa0, 0x1a, 0x4d, 0x11, 0x4c, 0x5e,
-	0xcf, 0x88, 0x90, 0xe8, 0x33, 0x28, 0x8e, 0xbd, 0xe9, 0x94, 0x70, 0x95, 0x99, 0x81, 0xb9, 0xd6,
-	0x36, 0xb3, 0xb4, 0xab, 0xe5, 0xfd, 0x5d, 0x5c, 0x30, 0x16, 0x7d, 0x1f, 0x3d, 0x82, 0x7c, 0x5c,
-	0x0e, 0xa9, 0xa5, 0x6d, 0xb2, 0x1c, 0x70, 0xac, 0x47, 0x0f, 0x21, 0xab, 0xd3, 0x8a, 0x70, 0xbe,
-	0x19, 0x27, 0xa9, 0x1a, 0x4f, 0xcf, 0x14, 0x6c, 0xf4, 0xe8, 0x4b, 0x88, 0xc0, 0x76, 0xe5, 0x22,
-	0x24, 0x1a, 0xdd, 0xea, 0xd6, 0xe6, 0x3a, 0x2d, 0xa3, 0x45, 0x48, 0x30, 0xc8, 0xe5, 0x5a, 0xb1,
-	0x7e, 0x4a, 0x16, 0x22, 0xf4, 0xc6, 0xc4, 0xd5, 0x53, 0x58, 0x4f, 0xcb, 0x22, 0xae, 0xc4, 0x52,
-	0x5d, 0x4a, 0xc9, 0x69, 0x9a, 0xbf, 0xca, 0x34, 0x7d, 0x9e, 0x29, 0x64, 0xed, 0x5c, 0xf3, 0x57,
-	0x0b, 0x6a, 0x4b, 0xa4, 0x44, 0xc8, 0x02, 0xa1, 0x6e, 0xcc, 0x12, 0xce, 0x19, 0x5f, 0x83, 0x09,
-	0x1f, 0x74, 0x7b, 0x4a, 0x8c, 0x8d, 0xf6, 0x6d, 0x30, 0x7a, 0x0c, 0x39, 0x4e, 0xc4, 0x6c, 0x2a,
-	0x23, 0x90, 0x50, 0x72, 0xe6, 0x62, 0xad, 0xc1, 0x91, 0x45, 0xf3, 0xef, 0x14, 0x6c, 0x44, 0x11,
-	0xed, 0x78, 0x72, 0x7c, 0x72, 0xed, 0x04, 0x7e, 0x0a, 0x79, 0x15, 0x0d, 0x25, 0xaa, 0x55, 0xd2,
-	0x97, 0x53, 0x18, 0x5b, 0xbc, 0x07, 0x89, 0x9e, 0xb8, 0xf0, 0x71, 0xce, 0x9a, 0x8f, 0xb3, 0x27,
-	0x92, 0x1f, 0xe7, 0x6b, 0xe2, 0xba, 0xf9, 0xbb, 0x05, 0x9b, 0x17, 0x31, 0xbd, 0x36, 0xaa, 0x3f,
-	0x87, 0xbc, 0x21, 0x32, 0x46, 0xf3, 0x76, 0x14, 0x9b, 0xa1, 0xf9, 0x25, 0x95, 0x27, 0xc6, 0x75,
-	0x6c, 0xa6, 0x9a, 0x75, 0x73, 0x28, 0x39, 0xf1, 0xce, 0xde, 0xab, 0x65, 0x97, 0x7d, 0x98, 0x7a,
-	0xbb, 0x3e, 0x4c, 0xbf, 0x73, 0x1f, 0x66, 0xde, 0xc0, 0x4d, 0xf6, 0x4a, 0xaf, 0x9a, 0x04, 0xb6,
-	0xb9, 0xff, 0xc7, 0xb6, 0xd9, 0x85, 0x5b, 0x6b, 0x40, 0x45, 0x34, 0xae, 0xfa, 0xcb, 0x7a, 0x63,
-	0x7f, 0xfd, 0x0c, 0x77, 0x30, 0x11, 0x6c, 0x3a, 0x27, 0x89, 0xca, 0x7b, 0x37, 0xc8, 0x11, 0x64,
-	0x7c, 0x19, 0x7d, 0x86, 0x8a, 0x58, 0xaf, 0x9b, 0x77, 0xa1, 0x7e, 0x99, 0x7b, 0x13, 0x68, 0xf3,
-	0x2f, 0x0b, 0xaa, 0x47, 0x26, 0x87, 0x77, 0xbb, 0x72, 0x8d, 0xbc, 0xd4, 0x15, 0xc9, 0x7b, 0x08,
-	0xd9, 0xf9, 0x44, 0x85, 0x1a, 0x0f, 0xe9, 0xc4, 0xa3, 0xfb, 0xe8, 0x99, 0xa4, 0x3e, 0x36, 0x7a,
-	0x85, 0xe4, 0x2b, 0x3a, 0x95, 0x84, 0x6b, 0x76, 0x15, 0x92, 0x09, 0xcb, 0xa7, 0x5a, 0x83, 0x23,
-	0x8b, 0xe6, 0xb7, 0x50, 0x5b, 0xe6, 0xb2, 0x22, 0x82, 0xcc, 0x89, 0x7a, 0x91, 0x58, 0xba, 0xf8,
-	0x2f, 0x1c, 0x3f, 0xea, 0x29, 0x15, 0x8e, 0x2c, 0x1e, 0xef, 0x42, 0x6d, 0xed, 0xb9, 0x8a, 0x6a,
-	0x50, 0x3a, 0x7c, 0x31, 0x3c, 0xe8, 0x75, 0xfb, 0x4f, 0xfb, 0xbd, 0x5d, 0xfb, 0x06, 0x02, 0xc8,
-	0x0d, 0xfb, 0x2f, 0x9e, 0xed, 0xf5, 0x6c, 0x0b, 0x15, 0x21, 0xbb, 0x7f, 0xb8, 0x37, 0xea, 0xdb,
-	0x29, 0xb5, 0x1c, 0xbd, 0x1c, 0x1c, 0x74, 0xed, 0xf4, 0xe3, 0x6f, 0xa0, 0xd4, 0xd5, 0x8f, 0xee,
-	0x01, 0xf7, 0x09, 0x57, 0x07, 0x5e, 0x0c, 0xf0, 0xfe, 0xf6, 0x9e, 0x7d, 0x03, 0xe5, 0x21, 0x7d,
-	0x80, 0xd5, 0xc9, 0x02, 0x64, 0x0e, 0x06, 0xc3, 0x91, 0x9d, 0x42, 0x55, 0x80, 0xed, 0xc3, 0xd1,
-	0xa0, 0x3b, 0xd8, 0xdf, 0xef, 0x8f, 0xec, 0xf4, 0xce, 0x57, 0x50, 0xa3, 0xac, 0x3d, 0xa7, 0x92,
-	0x08, 0x61, 0xfe, 0x53, 0xfc, 0x78, 0x2f, 0xda, 0x51, 0xd6, 0x31, 0xab, 0xce, 0x84, 0x75, 0xe6,
-	0xb2, 0xa3, 0xb5, 0x1d, 0x53, 0x9a, 0xc7, 0x39, 0xbd, 0xfb, 0xe2, 0x9f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x4c, 0x61, 0xae, 0x24, 0xd3, 0x0c, 0x00, 0x00,
+	0x10, 0xef, 0xf9, 0xfc, 0x77, 0xfc, 0xef, 0xba, 0x71, 0xcb, 0x35, 0x14, 0xb0, 0xdc, 0x56, 0x75,
+	0x0b, 0xb2, 0x51, 0x10, 0xa8, 0x42, 0x20, 0x94, 0x38, 0x6e, 0x71, 0x95, 0xd4, 0x61, 0xed, 0x24,
+	0x12, 0x02, 0x9d, 0x2e, 0xbe, 0xad, 0xb3, 0x8a, 0x73, 0xeb, 0xee, 0xae, 0x1d, 0xfc, 0x14, 0x7c,
+	0xe7, 0x05, 0x78, 0x01, 0x24, 0xde, 0x81, 0x6f, 0xbc, 0x11, 0xda, 0xdd, 0x3b, 0xfb, 0x62, 0x02,
+	0x4d, 0x5b, 0xf5, 0x8b, 0x75, 0xf3, 0x67, 0xe7, 0x66, 0x7e, 0xbf, 0x99, 0xb9, 0x35, 0x94, 0xe6,
+	0x72, 0xec, 0x4b, 0xd2, 0x9a, 0x72, 0x26, 0x19, 0xca, 0x1a, 0x69, 0xd3, 0x39, 0xa1, 0xe1, 0x84,
+	0x8d, 0x03, 0x5f, 0xfa, 0xc6, 0xb2, 0x59, 0x7c, 0x35, 0x23, 0x7c, 0x11, 0x09, 0x15, 0xc9, 0xa6,
+	0x2c, 0x69, 0x9c, 0x4b, 0x3e, 0x1d, 0x19, 0xa1, 0xf1, 0x07, 0x40, 0x6e, 0x40, 0x84, 0xa0, 0x2c,
+	0x44, 0x0f, 0xa0, 0x42, 0x43, 0x4f, 0x72, 0x3f, 0x14, 0xfe, 0x48, 0x52, 0x16, 0xba, 0x56, 0xdd,
+	0x6a, 0xe6, 0x71, 0x99, 0x86, 0xc3, 0x95, 0x12, 0x75, 0xa0, 0x22, 0x4e, 0x7d, 0x1e, 0x78, 0xc2,
+	0x9c, 0x13, 0x6e, 0xaa, 0x6e, 0x37, 0x8b, 0x5b, 0x77, 0x5b, 0x51, 0x76, 0x51, 0xbc, 0xd6, 0x40,
+	0x79, 0x45, 0x02, 0x2e, 0x8b, 0x84, 0x24, 0xd0, 0xc7, 0x00, 0xfe, 0x4c, 0xb2, 0x11, 0x3b, 0x3f,
+	0xa7, 0xd2, 0x4d, 0xeb, 0xf7, 0x24, 0x34, 0xe8, 0x1e, 0x94, 0xa5, 0xcf, 0xc7, 0x44, 0x7a, 0x42,
+	0x72, 0x1a, 0x8e, 0xdd, 0x4c, 0xdd, 0x6a, 0x16, 0x70, 0xc9, 0x28, 0x07, 0x5a, 0x87, 0xda, 0x90,
+	0x63, 0x53, 0xa9, 0x53, 0xc8, 0xd6, 0xad, 0x66, 0x71, 0xeb, 0x56, 0xcb, 0x14, 0xde, 0xfd, 0x85,
+	0x8c, 0x66, 0x92, 0xf4, 0x8d, 0x11, 0xc7, 0x5e, 0x68, 0x07, 0x9c, 0x44, 0x79, 0xde, 0x39, 0x0b,
+	0x88, 0x9b, 0xab, 0x5b, 0xcd, 0xca, 0xd6, 0x07, 0x71, 0xf2, 0x89, 0x4a, 0xf7, 0x59, 0x40, 0x70,
+	0x55, 0x5e, 0x56, 0xa0, 0x36, 0xe4, 0x2f, 0x7c, 0x1e, 0xd2, 0x70, 0x2c, 0xdc, 0xbc, 0x2e, 0x7c,
+	0x23, 0x7a, 0xeb, 0x0f, 0xea, 0xf7, 0xd8, 0xd8, 0xf0, 0xd2, 0x09, 0x7d, 0x07, 0xa5, 0x29, 0x27,
+	0x2b, 0xb4, 0x0a, 0xd7, 0x40, 0xab, 0x38, 0xe5, 0x64, 0x89, 0xd5, 0x36, 0x94, 0xa7, 0x4c, 0xc8,
+	0x55, 0x04, 0xb8, 0x46, 0x84, 0x92, 0x3a, 0xb2, 0x0c, 0x71, 0x1f, 0x2a, 0x13, 0x5f, 0x48, 0x8f,
+	0x86, 0x82, 0x70, 0xe9, 0xd1, 0xc0, 0x2d, 0xd6, 0xad, 0x66, 0x1a, 0x97, 0x94, 0xb6, 0xa7, 0x95,
+	0xbd, 0x00, 0x7d, 0x04, 0xf0, 0x92, 0xcd, 0xc2, 0xc0, 0xe3, 0xec, 0x42, 0xb8, 0x25, 0xed, 0x51,
+	0xd0, 0x1a, 0xcc, 0x2e, 0x04, 0xf2, 0xe0, 0xf6, 0x4c, 0x10, 0xee, 0x05, 0xe4, 0x25, 0x0d, 0x49,
+	0xe0, 0xcd, 0x7d, 0x4e, 0xfd, 0x93, 0x09, 0x11, 0x6e, 0x59, 0x27, 0xf4, 0x68, 0x3d, 0xa1, 0x43,
+	0x41, 0xf8, 0xae, 0x71, 0x3e, 0x8a, 0x7d, 0xbb, 0xa1, 0xe4, 0x0b, 0x5c, 0x9b, 0x5d, 0x61, 0x42,
+	0x7d, 0x70, 0xc4, 0x42, 0x48, 0x72, 0x9e, 0x08, 0x5d, 0xd1, 0xa1, 0xef, 0xff, 0xab, 0x56, 0xed,
+	0xb7, 0x16, 0xb5, 0x2a, 0x2e, 0x6b, 0xd1, 0x87, 0x50, 0xe0, 0xec, 0xc2, 0x1b, 0xb1, 0x59, 0x28,
+	0xdd, 0x6a, 0xdd, 0x6a, 0xda, 0x38, 0xcf, 0xd9, 0x45, 0x47, 0xc9, 0xaa, 0x05, 0x85, 0x3f, 0x27,
+	0x53, 0x46, 0x43, 0x29, 0x5c, 0xa7, 0x6e, 0x37, 0x0b, 0x38, 0xa1, 0x41, 0x4d, 0x70, 0x68, 0xe8,
+	0x71, 0x22, 0x08, 0x9f, 0x93, 0xc0, 0x1b, 0xb1, 0x30, 0x74, 0x6f, 0xea, 0x46, 0xad, 0xd0, 0x10,
+	0x47, 0xea, 0x0e, 0x0b, 0x43, 0xc5, 0xf0, 0x84, 0x8d, 0xce, 0x62, 0x82, 0x5c, 0x54, 0xb7, 0x5e,
+	0xcb, 0x4f, 0x51, 0x9d, 0x88, 0x04, 0xd4, 0x82, 0x0d, 0x4d, 0x8f, 0x8e, 0x72, 0x4a, 0x7c, 0x2e,
+	0x4f, 0x88, 0x2f, 0xdd, 0x0d, 0x9d, 0xf1, 0x4d, 0x65, 0xda, 0x63, 0xa3, 0xb3, 0xef, 0x63, 0x83,
+	0x22, 0x4a, 0xfb, 0x5f, 0x70, 0x2a, 0x89, 0x5b, 0xd3, 0x6e, 0x05, 0xa5, 0x39, 0x56, 0x8a, 0xcd,
+	0x3f, 0x2d, 0x28, 0x25, 0x5f, 0x86, 0x1e, 0x40, 0xd6, 0x0c, 0x8e, 0x9e, 0xe8, 0xe2, 0x56, 0x39,
+	0xea, 0xd8, 0xa1, 0x56, 0xe2, 0xc8, 0xa8, 0x16, 0x40, 0x72, 0x3c, 0x68, 0xe0, 0xa6, 0x74, 0xe8,
+	0x72, 0x42, 0xdb, 0x0b, 0xd0, 0x13, 0x28, 0x49, 0x85, 0xaf, 0xf4, 0xfc, 0x09, 0xf5, 0x85, 0x6b,
+	0x47, 0xb3, 0xb7, 0xdc, 0x33, 0x43, 0x6d, 0xdd, 0x56, 0x46, 0x5c, 0x94, 0x2b, 0x01, 0x7d, 0x02,
+	0xc5, 0x25, 0x9e, 0x34, 0xd0, 0x63, 0x6f, 0x63, 0x88, 0x55, 0xbd, 0x60, 0xf3, 0x27, 0xb8, 0xf3,
+	0x9f, 0x4d, 0x83, 0x1c, 0xb0, 0xcf, 0xc8, 0x42, 0x97, 0x50, 0xc0, 0xea, 0x11, 0x3d, 0x82, 0xcc,
+	0xdc, 0x9f, 0xcc, 0x88, 0xce, 0x73, 0x35, 0x88, 0x3b, 0x34, 0x5c, 0x9e, 0xc5, 0xc6, 0xe3, 0xeb,
+	0xd4, 0x13, 0x6b, 0x73, 0x07, 0x6a, 0x57, 0xf5, 0xcd, 0x15, 0x81, 0x6b, 0xc9, 0xc0, 0x85, 0x44,
+	0x8c, 0xe7, 0xe9, 0xbc, 0xed, 0xa4, 0x1b, 0xbf, 0xa7, 0xa0, 0x12, 0x2d, 0x19, 0x4c, 0x5e, 0xcd,
+	0x88, 0x90, 0xe8, 0x33, 0x28, 0x8c, 0xfc, 0xc9, 0x84, 0x70, 0x55, 0x99, 0x81, 0xb9, 0xda, 0x32,
+	0xab, 0xb6, 0xa3, 0xf5, 0xbd, 0x5d, 0x9c, 0x37, 0x1e, 0xbd, 0x00, 0x3d, 0x82, 0x5c, 0xdc, 0x2d,
+	0xa9, 0xa5, 0x6f, 0xb2, 0x5b, 0x70, 0x6c, 0x47, 0x0f, 0x21, 0xa3, 0xcb, 0x8a, 0x70, 0xbe, 0x19,
+	0x17, 0xa9, 0xe6, 0x52, 0xaf, 0x1c, 0x6c, 0xec, 0xe8, 0x4b, 0x88, 0xc0, 0xf6, 0xe4, 0x62, 0x4a,
+	0x34, 0xba, 0x95, 0xad, 0xda, 0x3a, 0x2d, 0xc3, 0xc5, 0x94, 0x60, 0x90, 0xcb, 0x67, 0xc5, 0xfa,
+	0x19, 0x59, 0x88, 0xa9, 0x3f, 0x22, 0x9e, 0x5e, 0xd2, 0x7a, 0x99, 0x16, 0x70, 0x39, 0xd6, 0xea,
+	0x56, 0x4a, 0x2e, 0xdb, 0xdc, 0x75, 0x96, 0xed, 0xf3, 0x74, 0x3e, 0xe3, 0x64, 0x1b, 0xbf, 0x5a,
+	0x50, 0x5d, 0x22, 0x25, 0xa6, 0x2c, 0x14, 0xea, 0x8d, 0x19, 0xc2, 0x39, 0xe3, 0x6b, 0x30, 0xe1,
+	0x83, 0x4e, 0x57, 0xa9, 0xb1, 0xb1, 0xbe, 0x09, 0x46, 0x8f, 0x21, 0xcb, 0x89, 0x98, 0x4d, 0x64,
+	0x04, 0x12, 0x4a, 0xae, 0x64, 0xac, 0x2d, 0x38, 0xf2, 0x68, 0xfc, 0x9d, 0x82, 0x8d, 0x28, 0xa3,
+	0x1d, 0x5f, 0x8e, 0x4e, 0xdf, 0x3b, 0x81, 0x9f, 0x42, 0x4e, 0x65, 0x43, 0x89, 0x1a, 0x15, 0xfb,
+	0x6a, 0x0a, 0x63, 0x8f, 0x77, 0x20, 0xd1, 0x17, 0x97, 0xbe, 0xdd, 0x19, 0xf3, 0xed, 0xf6, 0x45,
+	0xf2, 0xdb, 0xfd, 0x9e, 0xb8, 0x6e, 0xfc, 0x66, 0x41, 0xed, 0x32, 0xa6, 0xef, 0x8d, 0xea, 0xcf,
+	0x21, 0x67, 0x88, 0x8c, 0xd1, 0xbc, 0x1d, 0xe5, 0x66, 0x68, 0x3e, 0xa6, 0xf2, 0xd4, 0x84, 0x8e,
+	0xdd, 0xd4, 0xb0, 0xd6, 0x06, 0x92, 0x13, 0xff, 0xfc, 0x9d, 0x46, 0x76, 0x39, 0x87, 0xa9, 0x37,
+	0x9b, 0x43, 0xfb, 0xad, 0xe7, 0x30, 0xfd, 0x1a, 0x6e, 0x32, 0xd7, 0xba, 0xf4, 0x24, 0xb0, 0xcd,
+	0xfe, 0x3f, 0xb6, 0x8d, 0x0e, 0xdc, 0x5a, 0x03, 0x2a, 0xa2, 0x71, 0x35, 0x5f, 0xd6, 0x6b, 0xe7,
+	0xeb, 0x67, 0xb8, 0x83, 0x89, 0x60, 0x93, 0x39, 0x49, 0x74, 0xde, 0xdb, 0x41, 0x8e, 0x20, 0x1d,
+	0xc8, 0xe8, 0x33, 0x54, 0xc0, 0xfa, 0xb9, 0x71, 0x17, 0x36, 0xaf, 0x0a, 0x6f, 0x12, 0x6d, 0xfc,
+	0x65, 0x41, 0xe5, 0xc8, 0xd4, 0xf0, 0x76, 0xaf, 0x5c, 0x23, 0x2f, 0x75, 0x4d, 0xf2, 0x1e, 0x42,
+	0x66, 0x3e, 0x56, 0xa9, 0xc6, 0x4b, 0x3a, 0x71, 0x27, 0x3f, 0x7a, 0x26, 0x69, 0x80, 0x8d, 0x5d,
+	0x21, 0xf9, 0x92, 0x4e, 0x24, 0xe1, 0x6e, 0x3a, 0x42, 0x32, 0xe1, 0xf9, 0x54, 0x5b, 0x70, 0xe4,
+	0xd1, 0xf8, 0x16, 0xaa, 0xcb, 0x5a, 0x56, 0x44, 0x90, 0x39, 0x51, 0x17, 0x16, 0xab, 0x6e, 0xaf,
+	0x1f, 0x3f, 0xea, 0x2a, 0x13, 0x8e, 0x3c, 0x1e, 0xef, 0x42, 0x75, 0xed, 0x36, 0x8b, 0xaa, 0x50,
+	0x3c, 0x7c, 0x31, 0x38, 0xe8, 0x76, 0x7a, 0x4f, 0x7b, 0xdd, 0x5d, 0xe7, 0x06, 0x02, 0xc8, 0x0e,
+	0x7a, 0x2f, 0x9e, 0xed, 0x75, 0x1d, 0x0b, 0x15, 0x20, 0xb3, 0x7f, 0xb8, 0x37, 0xec, 0x39, 0x29,
+	0xf5, 0x38, 0x3c, 0xee, 0x1f, 0x74, 0x1c, 0xfb, 0xf1, 0x37, 0x50, 0xec, 0xe8, 0x3b, 0x79, 0x9f,
+	0x07, 0x84, 0xab, 0x03, 0x2f, 0xfa, 0x78, 0x7f, 0x7b, 0xcf, 0xb9, 0x81, 0x72, 0x60, 0x1f, 0x60,
+	0x75, 0x32, 0x0f, 0xe9, 0x83, 0xfe, 0x60, 0xe8, 0xa4, 0x50, 0x05, 0x60, 0xfb, 0x70, 0xd8, 0xef,
+	0xf4, 0xf7, 0xf7, 0x7b, 0x43, 0xc7, 0xde, 0xf9, 0x0a, 0xaa, 0x94, 0xb5, 0xe6, 0x54, 0x12, 0x21,
+	0xcc, 0x5f, 0x8e, 0x1f, 0xef, 0x45, 0x12, 0x65, 0x6d, 0xf3, 0xd4, 0x1e, 0xb3, 0xf6, 0x5c, 0xb6,
+	0xb5, 0xb5, 0x6d, 0x5a, 0xf3, 0x24, 0xab, 0xa5, 0x2f, 0xfe, 0x19, 0x00, 0xb1, 0x0a, 0x8c, 0x3d,
+	0xf2, 0x0c, 0x00, 0x00,
 }
@@ -36,6 +36,9 @@ const (
 	DirectiveIgnoreMaxPayloadSize = "IGNORE_MAX_PAYLOAD_SIZE"
 	// DirectiveIgnoreMaxMemoryRows skips memory row validation when set.
 	DirectiveIgnoreMaxMemoryRows = "IGNORE_MAX_MEMORY_ROWS"
+	// DirectiveResultCache allows vtgate to keep the result of a SELECT in
+	// its result cache.
+	DirectiveResultCache = "RESULT_CACHE"
 )
 
 func isNonSpace(r rune) bool {
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tableacl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/vt/tableacl/acl"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
+)
+
+// The masks which can be applied to the restricted columns.
+const (
+	// MaskRedact replaces the values with a constant string.
+	MaskRedact = "redact"
+	// MaskHash replaces the values with their SHA-256 hash.
+	MaskHash = "hash"
+	// MaskPartial only shows the last 4 characters of the values.
+	MaskPartial = "partial"
+)
+
+type columnRule struct {
+	// columns are in lower case.
+	columns []string
+	readers acl.ACL
+	mask    string
+}
+
+// ColumnRestrictions maps the columns of a table that a user can't read as
+// is, in lower case, to their mask. An empty mask denies the access to the
+// column.
+type ColumnRestrictions map[string]string
+
+func validateColumnRule(rule *tableaclpb.ColumnRule) error {
+	if len(rule.Columns) == 0 {
+		return fmt.Errorf("column rule has no columns")
+	}
+	switch rule.Mask {
+	case "", MaskRedact, MaskHash, MaskPartial:
+	default:
+		return fmt.Errorf("invalid mask %q for columns %v", rule.Mask, rule.Columns)
+	}
+	return nil
+}
+
+// RestrictedColumns returns the columns of the table that the caller can't
+// read as is, or nil if there are none.
+func RestrictedColumns(table string, callerID *querypb.VTGateCallerID) ColumnRestrictions {
+	return currentTableACL.RestrictedColumns(table, callerID)
+}
+
+func (tacl *tableACL) RestrictedColumns(table string, callerID *querypb.VTGateCallerID) ColumnRestrictions {
+	tacl.RLock()
+	defer tacl.RUnlock()
+	entry := tacl.find(table)
+	if entry == nil {
+		return nil
+	}
+	return entry.restrictedColumns(callerID)
+}
+
+// ColumnRestrictionsKey returns a string which identifies the restricted
+// columns of all the tables for the caller, or an empty string if there
+// are none. Queries planned for callers with the same key are rewritten
+// the same way.
+func ColumnRestrictionsKey(callerID *querypb.VTGateCallerID) string {
+	return currentTableACL.ColumnRestrictionsKey(callerID)
+}
+
+func (tacl *tableACL) ColumnRestrictionsKey(callerID *querypb.VTGateCallerID) string {
+	tacl.RLock()
+	defer tacl.RUnlock()
+	var key strings.Builder
+	for i := range tacl.entries {
+		restricted := tacl.entries[i].restrictedColumns(callerID)
+		if len(restricted) == 0 {
+			continue
+		}
+		key.WriteString(tacl.entries[i].tableNameOrPrefix + restricted.String() + ";")
+	}
+	return key.String()
+}
+
+// String lists the restricted columns with their mask, sorted by column.
+func (cr ColumnRestrictions) String() string {
+	columns := make([]string, 0, len(cr))
+	for column := range cr {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	var s strings.Builder
+	for _, column := range columns {
+		s.WriteString(" " + column + "=" + cr[column])
+	}
+	return s.String()
+}
+
+func (entry *aclEntry) restrictedColumns(callerID *querypb.VTGateCallerID) ColumnRestrictions {
+	if callerID == nil {
+		callerID = &querypb.VTGateCallerID{}
+	}
+	var restricted ColumnRestrictions
+	for _, rule := range entry.columnRules {
+		if rule.readers.IsMember(callerID) {
+			continue
+		}
+		if restricted == nil {
+			restricted = make(ColumnRestrictions)
+		}
+		for _, column := range rule.columns {
+			// The access denied by a rule can't be granted by another.
+			if mask, ok := restricted[column]; ok && mask == "" {
+				continue
+			}
+			restricted[column] = rule.mask
+		}
+	}
+	return restricted
+}
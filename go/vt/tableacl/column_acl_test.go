@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tableacl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/tableacl/simpleacl"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
+)
+
+var columnACLConfig = &tableaclpb.Config{
+	TableGroups: []*tableaclpb.TableGroupSpec{{
+		Name:                 "customers",
+		TableNamesOrPrefixes: []string{"customer", "contact%"},
+		Readers:              []string{"app", "support", "analyst"},
+		ColumnRules: []*tableaclpb.ColumnRule{{
+			Columns: []string{"Email"},
+			Readers: []string{"app"},
+			Mask:    MaskHash,
+		}, {
+			Columns: []string{"phone"},
+			Readers: []string{"app", "support"},
+			Mask:    MaskPartial,
+		}, {
+			Columns: []string{"ssn"},
+			Readers: []string{"app"},
+		}},
+	}, {
+		Name:                 "orders",
+		TableNamesOrPrefixes: []string{"orders"},
+		Readers:              []string{"app", "support", "analyst"},
+	}},
+}
+
+func TestRestrictedColumns(t *testing.T) {
+	tacl := tableACL{factory: &simpleacl.Factory{}}
+	require.NoError(t, tacl.Set(columnACLConfig))
+
+	app := &querypb.VTGateCallerID{Username: "app"}
+	support := &querypb.VTGateCallerID{Username: "bob", Groups: []string{"support"}}
+	analyst := &querypb.VTGateCallerID{Username: "analyst"}
+
+	assert.Nil(t, tacl.RestrictedColumns("customer", app))
+	assert.Equal(t, ColumnRestrictions{"email": MaskHash, "ssn": ""}, tacl.RestrictedColumns("customer", support))
+	assert.Equal(t, ColumnRestrictions{"email": MaskHash, "phone": MaskPartial, "ssn": ""}, tacl.RestrictedColumns("contact_info", analyst))
+	assert.Nil(t, tacl.RestrictedColumns("orders", analyst))
+	assert.Nil(t, tacl.RestrictedColumns("unknown", analyst))
+	assert.Equal(t, ColumnRestrictions{"email": MaskHash, "phone": MaskPartial, "ssn": ""}, tacl.RestrictedColumns("customer", nil))
+
+	assert.Equal(t, "", tacl.ColumnRestrictionsKey(app))
+	assert.Equal(t, "contact% email=hash ssn=;customer email=hash ssn=;", tacl.ColumnRestrictionsKey(support))
+}
+
+func TestColumnRuleValidation(t *testing.T) {
+	tacl := tableACL{factory: &simpleacl.Factory{}}
+	config := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{{
+			Name:                 "customers",
+			TableNamesOrPrefixes: []string{"customer"},
+			ColumnRules:          []*tableaclpb.ColumnRule{{Columns: []string{"email"}, Mask: "shuffle"}},
+		}},
+	}
+	assert.EqualError(t, tacl.Set(config), `table group customers: invalid mask "shuffle" for columns [email]`)
+
+	config.TableGroups[0].ColumnRules = []*tableaclpb.ColumnRule{{Mask: MaskRedact}}
+	assert.EqualError(t, tacl.Set(config), "table group customers: column rule has no columns")
+}
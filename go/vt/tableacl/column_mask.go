@@ -0,0 +1,422 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tableacl
+
+import (
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// EnforceColumnRestrictions rewrites stmt for a user who can't read the
+// columns returned by restricted for each table. The masked columns read
+// by the select expressions are replaced by their mask. An error naming
+// the column is returned if a denied column is read, or if a masked column
+// is used anywhere else, such as in a predicate. The masks already applied
+// are left as is, so that the rewrite can be repeated. It returns true if
+// stmt was changed.
+func EnforceColumnRestrictions(stmt sqlparser.Statement, restricted func(table string) ColumnRestrictions) (bool, error) {
+	ce := &columnEnforcer{restricted: restricted}
+	var err error
+	switch stmt := stmt.(type) {
+	case sqlparser.SelectStatement:
+		err = ce.selectStatement(stmt, nil)
+	case *sqlparser.Insert:
+		if rows, ok := stmt.Rows.(sqlparser.SelectStatement); ok {
+			err = ce.selectStatement(rows, nil)
+		}
+	case *sqlparser.Update:
+		var scope *columnScope
+		if scope, err = ce.newScope(stmt.TableExprs, nil); err != nil {
+			return false, err
+		}
+		nodes := []sqlparser.SQLNode{stmt.OrderBy}
+		for _, expr := range stmt.Exprs {
+			nodes = append(nodes, expr.Expr)
+		}
+		if stmt.Where != nil {
+			nodes = append(nodes, stmt.Where.Expr)
+		}
+		err = ce.check(scope, nodes...)
+	case *sqlparser.Delete:
+		var scope *columnScope
+		if scope, err = ce.newScope(stmt.TableExprs, nil); err != nil {
+			return false, err
+		}
+		nodes := []sqlparser.SQLNode{stmt.OrderBy}
+		if stmt.Where != nil {
+			nodes = append(nodes, stmt.Where.Expr)
+		}
+		err = ce.check(scope, nodes...)
+	}
+	if err != nil {
+		return false, err
+	}
+	return ce.changed, nil
+}
+
+type columnEnforcer struct {
+	restricted func(table string) ColumnRestrictions
+	changed    bool
+}
+
+// columnScope lists the tables whose columns can be referenced by an
+// expression: the tables of the FROM clause of its query, and of the
+// queries it is a subquery of.
+type columnScope struct {
+	tables []scopeTable
+	outer  *columnScope
+}
+
+type scopeTable struct {
+	// qualifier is the alias or the name of the table.
+	qualifier  string
+	name       string
+	restricted ColumnRestrictions
+}
+
+func (ce *columnEnforcer) selectStatement(stmt sqlparser.SelectStatement, outer *columnScope) error {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Select:
+		return ce.selectQuery(stmt, outer)
+	case *sqlparser.Union:
+		if err := ce.selectStatement(stmt.FirstStatement, outer); err != nil {
+			return err
+		}
+		for _, us := range stmt.UnionSelects {
+			if err := ce.selectStatement(us.Statement, outer); err != nil {
+				return err
+			}
+		}
+	case *sqlparser.ParenSelect:
+		return ce.selectStatement(stmt.Select, outer)
+	}
+	return nil
+}
+
+func (ce *columnEnforcer) selectQuery(sel *sqlparser.Select, outer *columnScope) error {
+	scope, err := ce.newScope(sel.From, outer)
+	if err != nil {
+		return err
+	}
+	for _, expr := range sel.SelectExprs {
+		switch expr := expr.(type) {
+		case *sqlparser.StarExpr:
+			for _, t := range scope.tables {
+				if len(t.restricted) == 0 {
+					continue
+				}
+				if expr.TableName.IsEmpty() || expr.TableName.Name.String() == t.qualifier {
+					return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "table acl error: columns of table %s are restricted, select * is not allowed", t.name)
+				}
+			}
+		case *sqlparser.AliasedExpr:
+			masked, err := ce.mask(expr.Expr, scope)
+			if err != nil {
+				return err
+			}
+			if col, ok := expr.Expr.(*sqlparser.ColName); ok && masked != expr.Expr && expr.As.IsEmpty() {
+				// Keep the name of the column in the result.
+				expr.As = col.Name
+			}
+			expr.Expr = masked
+		}
+	}
+	nodes := []sqlparser.SQLNode{sel.GroupBy, sel.OrderBy}
+	if sel.Where != nil {
+		nodes = append(nodes, sel.Where.Expr)
+	}
+	if sel.Having != nil {
+		nodes = append(nodes, sel.Having.Expr)
+	}
+	if err := ce.check(scope, nodes...); err != nil {
+		return err
+	}
+	return ce.checkJoinConditions(sel.From, scope)
+}
+
+// newScope returns the scope of the tables of a FROM clause. The derived
+// tables are rewritten on their own.
+func (ce *columnEnforcer) newScope(exprs sqlparser.TableExprs, outer *columnScope) (*columnScope, error) {
+	scope := &columnScope{outer: outer}
+	for _, expr := range exprs {
+		if err := ce.addTables(scope, expr); err != nil {
+			return nil, err
+		}
+	}
+	return scope, nil
+}
+
+func (ce *columnEnforcer) addTables(scope *columnScope, expr sqlparser.TableExpr) error {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		switch table := expr.Expr.(type) {
+		case sqlparser.TableName:
+			t := scopeTable{
+				qualifier:  table.Name.String(),
+				name:       table.Name.String(),
+				restricted: ce.restricted(table.Name.String()),
+			}
+			if !expr.As.IsEmpty() {
+				t.qualifier = expr.As.String()
+			}
+			scope.tables = append(scope.tables, t)
+		case *sqlparser.Subquery:
+			if err := ce.selectStatement(table.Select, scope.outer); err != nil {
+				return err
+			}
+			scope.tables = append(scope.tables, scopeTable{qualifier: expr.As.String(), name: expr.As.String()})
+		}
+	case *sqlparser.ParenTableExpr:
+		for _, e := range expr.Exprs {
+			if err := ce.addTables(scope, e); err != nil {
+				return err
+			}
+		}
+	case *sqlparser.JoinTableExpr:
+		if err := ce.addTables(scope, expr.LeftExpr); err != nil {
+			return err
+		}
+		return ce.addTables(scope, expr.RightExpr)
+	}
+	return nil
+}
+
+func (ce *columnEnforcer) checkJoinConditions(exprs sqlparser.TableExprs, scope *columnScope) error {
+	for _, expr := range exprs {
+		switch expr := expr.(type) {
+		case *sqlparser.ParenTableExpr:
+			if err := ce.checkJoinConditions(expr.Exprs, scope); err != nil {
+				return err
+			}
+		case *sqlparser.JoinTableExpr:
+			if err := ce.checkJoinConditions(sqlparser.TableExprs{expr.LeftExpr, expr.RightExpr}, scope); err != nil {
+				return err
+			}
+			if err := ce.check(scope, expr.Condition.On); err != nil {
+				return err
+			}
+			for _, col := range expr.Condition.Using {
+				if err := scope.checkAccess(&sqlparser.ColName{Name: col}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// check returns an error if the nodes use a restricted column.
+func (ce *columnEnforcer) check(scope *columnScope, nodes ...sqlparser.SQLNode) error {
+	return sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName:
+			return false, scope.checkAccess(node)
+		case *sqlparser.Subquery:
+			return false, ce.selectStatement(node.Select, scope)
+		}
+		return true, nil
+	}, nodes...)
+}
+
+// mask returns expr with its masked columns replaced by their mask.
+func (ce *columnEnforcer) mask(expr sqlparser.Expr, scope *columnScope) (sqlparser.Expr, error) {
+	var err error
+	result := sqlparser.Rewrite(expr, func(cursor *sqlparser.Cursor) bool {
+		if err != nil {
+			return false
+		}
+		switch node := cursor.Node().(type) {
+		case *sqlparser.Subquery:
+			err = ce.selectStatement(node.Select, scope)
+			return false
+		case *sqlparser.FuncExpr:
+			return !scope.isMask(node)
+		case *sqlparser.ColName:
+			t, mask, ok := scope.resolve(node)
+			if !ok {
+				return false
+			}
+			if mask == "" {
+				err = deniedColumnError(node, t)
+				return false
+			}
+			cursor.Replace(maskColumn(node, mask))
+			ce.changed = true
+			return false
+		}
+		return true
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.(sqlparser.Expr), nil
+}
+
+// resolve returns the table of the scope which restricts the column, and
+// the mask of the column. If the column is not qualified, it is
+// conservatively assumed to belong to any table which restricts it.
+func (scope *columnScope) resolve(col *sqlparser.ColName) (*scopeTable, string, bool) {
+	name := strings.ToLower(col.Name.String())
+	var found *scopeTable
+	var foundMask string
+	for s := scope; s != nil; s = s.outer {
+		qualified := false
+		for i := range s.tables {
+			t := &s.tables[i]
+			if !col.Qualifier.IsEmpty() {
+				if col.Qualifier.Name.String() != t.qualifier {
+					continue
+				}
+				qualified = true
+			}
+			mask, ok := t.restricted[name]
+			if !ok {
+				continue
+			}
+			if mask == "" {
+				return t, "", true
+			}
+			if found == nil {
+				found, foundMask = t, mask
+			}
+		}
+		if qualified {
+			break
+		}
+	}
+	return found, foundMask, found != nil
+}
+
+// checkAccess returns an error if the column is restricted.
+func (scope *columnScope) checkAccess(col *sqlparser.ColName) error {
+	t, mask, ok := scope.resolve(col)
+	switch {
+	case !ok:
+		return nil
+	case mask == "":
+		return deniedColumnError(col, t)
+	}
+	return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "table acl error: column %s of table %s is masked and can only be selected", col.Name.String(), t.name)
+}
+
+// isMask returns true if f is the mask of a masked column: it has the
+// structure of the expression built by maskColumn for the mask of the
+// column it reads.
+func (scope *columnScope) isMask(f *sqlparser.FuncExpr) bool {
+	col, mask := maskedColumn(f)
+	if col == nil {
+		return false
+	}
+	_, colMask, ok := scope.resolve(col)
+	return ok && colMask == mask
+}
+
+// maskedColumn returns the column and the mask of f if it is a hash or
+// partial mask built by maskColumn, or nil.
+func maskedColumn(f *sqlparser.FuncExpr) (*sqlparser.ColName, string) {
+	if args := funcArgs(f, "sha2", 2); args != nil {
+		col, ok := args[0].(*sqlparser.ColName)
+		if ok && isLiteral(args[1], sqlparser.IntVal, "256") {
+			return col, MaskHash
+		}
+		return nil, ""
+	}
+	// concat(repeat('*', greatest(char_length(col) - 4, 0)), right(col, 4))
+	args := funcArgs(f, "concat", 2)
+	if args == nil {
+		return nil, ""
+	}
+	repeat := funcArgs(args[0], "repeat", 2)
+	right := funcArgs(args[1], "right", 2)
+	if repeat == nil || right == nil || !isLiteral(repeat[0], sqlparser.StrVal, "*") || !isLiteral(right[1], sqlparser.IntVal, "4") {
+		return nil, ""
+	}
+	greatest := funcArgs(repeat[1], "greatest", 2)
+	if greatest == nil || !isLiteral(greatest[1], sqlparser.IntVal, "0") {
+		return nil, ""
+	}
+	minus, ok := greatest[0].(*sqlparser.BinaryExpr)
+	if !ok || minus.Operator != sqlparser.MinusOp || !isLiteral(minus.Right, sqlparser.IntVal, "4") {
+		return nil, ""
+	}
+	length := funcArgs(minus.Left, "char_length", 1)
+	if length == nil {
+		return nil, ""
+	}
+	col, ok := length[0].(*sqlparser.ColName)
+	if !ok || !col.Equal(asColName(right[0])) {
+		return nil, ""
+	}
+	return col, MaskPartial
+}
+
+// funcArgs returns the arguments of expr if it is a call of the function
+// with n arguments, or nil.
+func funcArgs(expr sqlparser.Expr, name string, n int) []sqlparser.Expr {
+	f, ok := expr.(*sqlparser.FuncExpr)
+	if !ok || !f.Qualifier.IsEmpty() || f.Distinct || !f.Name.EqualString(name) || len(f.Exprs) != n {
+		return nil
+	}
+	args := make([]sqlparser.Expr, 0, n)
+	for _, e := range f.Exprs {
+		arg, ok := e.(*sqlparser.AliasedExpr)
+		if !ok || !arg.As.IsEmpty() {
+			return nil
+		}
+		args = append(args, arg.Expr)
+	}
+	return args
+}
+
+func isLiteral(expr sqlparser.Expr, typ sqlparser.ValType, val string) bool {
+	lit, ok := expr.(*sqlparser.Literal)
+	return ok && lit.Type == typ && string(lit.Val) == val
+}
+
+func asColName(expr sqlparser.Expr) *sqlparser.ColName {
+	col, _ := expr.(*sqlparser.ColName)
+	return col
+}
+
+func deniedColumnError(col *sqlparser.ColName, t *scopeTable) error {
+	return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "table acl error: access denied to column %s of table %s", col.Name.String(), t.name)
+}
+
+// maskColumn returns the expression which computes the mask of the column.
+func maskColumn(col *sqlparser.ColName, mask string) sqlparser.Expr {
+	name := sqlparser.String(col)
+	var expr string
+	switch mask {
+	case MaskHash:
+		expr = fmt.Sprintf("sha2(%s, 256)", name)
+	case MaskPartial:
+		expr = fmt.Sprintf("concat(repeat('*', greatest(char_length(%s) - 4, 0)), right(%s, 4))", name, name)
+	default:
+		return sqlparser.NewStrLiteral([]byte("****"))
+	}
+	stmt, err := sqlparser.Parse("select " + expr + " from dual")
+	if err != nil {
+		// The masks are valid expressions for any column name.
+		panic(fmt.Sprintf("BUG: invalid mask %s: %v", expr, err))
+	}
+	return stmt.(*sqlparser.Select).SelectExprs[0].(*sqlparser.AliasedExpr).Expr
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tableacl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestEnforceColumnRestrictions(t *testing.T) {
+	restricted := func(table string) ColumnRestrictions {
+		if table == "customer" {
+			return ColumnRestrictions{"email": MaskHash, "phone": MaskPartial, "note": MaskRedact, "ssn": ""}
+		}
+		return nil
+	}
+
+	tcases := []struct {
+		in, out string
+		err     string
+	}{{
+		in:  "select id, name from customer where id = 1",
+		out: "select id, name from customer where id = 1",
+	}, {
+		in:  "select id, email, note from customer",
+		out: "select id, sha2(email, 256) as email, '****' as note from customer",
+	}, {
+		in:  "select c.phone as p, upper(Email) from customer as c",
+		out: "select concat(repeat('*', greatest(char_length(c.phone) - 4, 0)), right(c.phone, 4)) as p, upper(sha2(Email, 256)) from customer as c",
+	}, {
+		// The masks are not applied twice.
+		in:  "select sha2(email, 256) as email, concat(repeat('*', greatest(char_length(c.phone) - 4, 0)), right(c.phone, 4)) from customer as c",
+		out: "select sha2(email, 256) as email, concat(repeat('*', greatest(char_length(c.phone) - 4, 0)), right(c.phone, 4)) from customer as c",
+	}, {
+		// A mask only matches the mask of its column.
+		in:  "select sha2(phone, 256) from customer",
+		out: "select sha2(concat(repeat('*', greatest(char_length(phone) - 4, 0)), right(phone, 4)), 256) from customer",
+	}, {
+		in:  "select concat(repeat('*', greatest(char_length(phone) - 4, 0)), right(email, 4)) from customer",
+		out: "select concat(repeat('*', greatest(char_length(concat(repeat('*', greatest(char_length(phone) - 4, 0)), right(phone, 4))) - 4, 0)), right(sha2(email, 256), 4)) from customer",
+	}, {
+		in:  "select sha2(email, 224) from customer",
+		out: "select sha2(sha2(email, 256), 224) from customer",
+	}, {
+		in:  "select o.email from orders as o join customer as c on o.customer_id = c.id",
+		out: "select o.email from orders as o join customer as c on o.customer_id = c.id",
+	}, {
+		in:  "select id from orders where customer_id in (select id from customer where email = 'a@b.c')",
+		err: "table acl error: column email of table customer is masked and can only be selected",
+	}, {
+		in:  "select d.email from (select email from customer) as d",
+		out: "select d.email from (select sha2(email, 256) as email from customer) as d",
+	}, {
+		in:  "select email from customer union select email from orders",
+		out: "select sha2(email, 256) as email from customer union select email from orders",
+	}, {
+		in:  "insert into orders(email) select email from customer",
+		out: "insert into orders(email) select sha2(email, 256) as email from customer",
+	}, {
+		in:  "select ssn from customer",
+		err: "table acl error: access denied to column ssn of table customer",
+	}, {
+		in:  "select id from customer order by phone",
+		err: "table acl error: column phone of table customer is masked and can only be selected",
+	}, {
+		in:  "select o.id from orders as o join customer as c on o.ssn = c.ssn",
+		err: "table acl error: access denied to column ssn of table customer",
+	}, {
+		in:  "select o.id from orders as o join customer as c using (ssn)",
+		err: "table acl error: access denied to column ssn of table customer",
+	}, {
+		in:  "select * from customer",
+		err: "table acl error: columns of table customer are restricted, select * is not allowed",
+	}, {
+		in:  "select o.* from orders as o join customer as c on o.customer_id = c.id",
+		out: "select o.* from orders as o join customer as c on o.customer_id = c.id",
+	}, {
+		in:  "update customer set name = 'x' where email = 'a@b.c'",
+		err: "table acl error: column email of table customer is masked and can only be selected",
+	}, {
+		in:  "update customer set email = 'a@b.c' where id = 1",
+		out: "update customer set email = 'a@b.c' where id = 1",
+	}, {
+		in:  "update customer set note = ssn where id = 1",
+		err: "table acl error: access denied to column ssn of table customer",
+	}, {
+		in:  "delete from customer where ssn = '1'",
+		err: "table acl error: access denied to column ssn of table customer",
+	}}
+	for _, tcase := range tcases {
+		stmt, err := sqlparser.Parse(tcase.in)
+		require.NoError(t, err)
+		changed, err := EnforceColumnRestrictions(stmt, restricted)
+		if tcase.err != "" {
+			assert.EqualError(t, err, tcase.err, tcase.in)
+			continue
+		}
+		require.NoError(t, err, tcase.in)
+		assert.Equal(t, tcase.out, sqlparser.String(stmt), tcase.in)
+		assert.Equal(t, tcase.in != tcase.out, changed, tcase.in)
+	}
+}
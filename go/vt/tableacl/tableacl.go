@@ -21,9 +21,13 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"os/signal"
 	"sort"
 	"strings"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/tchap/go-patricia/patricia"
@@ -44,6 +48,7 @@ type aclEntry struct {
 	tableNameOrPrefix string
 	groupName         string
 	acl               map[Role]acl.ACL
+	columnRules       []columnRule
 }
 
 type aclEntries []aclEntry
@@ -93,7 +98,10 @@ var currentTableACL tableACL
 //       "table_names_or_prefixes": ["name1"],
 //       "readers": ["client1"],
 //       "writers": ["client1"],
-//       "admins": ["client1"]
+//       "admins": ["client1"],
+//       "column_rules": [
+//         {"columns": ["email"], "readers": ["client1"], "mask": "hash"}
+//       ]
 //     }
 //   ]
 // }
@@ -122,6 +130,29 @@ func (tacl *tableACL) init(configFile string, aclCB func()) error {
 	return tacl.Set(config)
 }
 
+// InitAndReload calls init, which loads the config file with Init, and
+// calls it again on SIGHUP, and every reloadInterval if it is not zero.
+func InitAndReload(init func(), reloadInterval time.Duration) {
+	init()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	go func() {
+		for range sigChan {
+			init()
+		}
+	}()
+
+	if reloadInterval != 0 {
+		ticker := time.NewTicker(reloadInterval)
+		go func() {
+			for range ticker.C {
+				sigChan <- syscall.SIGHUP
+			}
+		}()
+	}
+}
+
 func (tacl *tableACL) SetCallback(callback func()) {
 	tacl.Lock()
 	defer tacl.Unlock()
@@ -153,6 +184,22 @@ func load(config *tableaclpb.Config, newACL func([]string) (acl.ACL, error)) (en
 		if err != nil {
 			return nil, err
 		}
+		var columnRules []columnRule
+		for _, rule := range group.ColumnRules {
+			readers, err := newACL(rule.Readers)
+			if err != nil {
+				return nil, err
+			}
+			columns := make([]string, 0, len(rule.Columns))
+			for _, column := range rule.Columns {
+				columns = append(columns, strings.ToLower(column))
+			}
+			columnRules = append(columnRules, columnRule{
+				columns: columns,
+				readers: readers,
+				mask:    rule.Mask,
+			})
+		}
 		for _, tableNameOrPrefix := range group.TableNamesOrPrefixes {
 			entries = append(entries, aclEntry{
 				tableNameOrPrefix: tableNameOrPrefix,
@@ -162,6 +209,7 @@ func load(config *tableaclpb.Config, newACL func([]string) (acl.ACL, error)) (en
 					WRITER: writers,
 					ADMIN:  admins,
 				},
+				columnRules: columnRules,
 			})
 		}
 	}
@@ -209,6 +257,11 @@ func (tacl *tableACL) Valid() bool {
 func ValidateProto(config *tableaclpb.Config) (err error) {
 	t := patricia.NewTrie()
 	for _, group := range config.TableGroups {
+		for _, rule := range group.ColumnRules {
+			if err := validateColumnRule(rule); err != nil {
+				return fmt.Errorf("table group %s: %v", group.Name, err)
+			}
+		}
 		for _, name := range group.TableNamesOrPrefixes {
 			var prefix patricia.Prefix
 			if strings.HasSuffix(name, "%") {
@@ -242,30 +295,37 @@ func Authorized(table string, role Role) *ACLResult {
 func (tacl *tableACL) Authorized(table string, role Role) *ACLResult {
 	tacl.RLock()
 	defer tacl.RUnlock()
+	if entry := tacl.find(table); entry != nil {
+		if acl, ok := entry.acl[role]; ok {
+			return &ACLResult{
+				ACL:       acl,
+				GroupName: entry.groupName,
+			}
+		}
+	}
+	return &ACLResult{
+		ACL:       acl.DenyAllACL{},
+		GroupName: "",
+	}
+}
+
+// find returns the entry of the table, or nil. The caller must hold the
+// lock.
+func (tacl *tableACL) find(table string) *aclEntry {
 	start := 0
 	end := len(tacl.entries)
 	for start < end {
 		mid := start + (end-start)/2
 		val := tacl.entries[mid].tableNameOrPrefix
 		if table == val || (strings.HasSuffix(val, "%") && strings.HasPrefix(table, val[:len(val)-1])) {
-			acl, ok := tacl.entries[mid].acl[role]
-			if ok {
-				return &ACLResult{
-					ACL:       acl,
-					GroupName: tacl.entries[mid].groupName,
-				}
-			}
-			break
+			return &tacl.entries[mid]
 		} else if table < val {
 			end = mid
 		} else {
 			start = mid + 1
 		}
 	}
-	return &ACLResult{
-		ACL:       acl.DenyAllACL{},
-		GroupName: "",
-	}
+	return nil
 }
 
 // GetCurrentConfig returns a copy of current tableacl configuration.
@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"errors"
+	"time"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// apiPrefix is the prefix of the URLs of the API of the members. The
+// operation is the last part of the URL, and the request and response
+// are JSON objects.
+const apiPrefix = "/raft2topo/v1/"
+
+// The operations of the API. The ones which change the state are
+// replicated through the raft log, with the request as command.
+const (
+	// opCreate, opUpdate and opDelete change a file.
+	opCreate = "create"
+	opUpdate = "update"
+	opDelete = "delete"
+
+	// opGet and opList read a file or a directory.
+	opGet  = "get"
+	opList = "list"
+
+	// opLock waits until the lock of a path is taken by the session.
+	// The lock is identified by the token chosen by the client.
+	opLock = "lock"
+	// opUnlock releases a lock of the session.
+	opUnlock = "unlock"
+	// opCheckLock returns an error if the lock is not held any more.
+	opCheckLock = "checklock"
+	// opLockHolder returns the contents of the lock of a path.
+	opLockHolder = "lockholder"
+
+	// opOpenSession and opCloseSession start and end a session. The
+	// locks of a session are released when it ends, or when it isn't
+	// kept alive for its TTL.
+	opOpenSession  = "opensession"
+	opCloseSession = "closesession"
+	opKeepAlive    = "keepalive"
+
+	// opWatch streams the contents of a file, one JSON response per line.
+	opWatch = "watch"
+)
+
+// request is the body of the requests of the API. It is also the
+// command of the raft log for the operations which change the state.
+type request struct {
+	Op       string `json:"op"`
+	Path     string `json:"path,omitempty"`
+	Contents []byte `json:"contents,omitempty"`
+	// Version is the raft index of the expected version of a file, or
+	// 0 for an unconditional update or delete.
+	Version  uint64        `json:"version,omitempty"`
+	Session  string        `json:"session,omitempty"`
+	TTL      time.Duration `json:"ttl,omitempty"`
+	Token    string        `json:"token,omitempty"`
+	Holder   string        `json:"holder,omitempty"`
+	CheckDir bool          `json:"check_dir,omitempty"`
+}
+
+// response is the body of the responses of the API, and the result of
+// applying a command.
+type response struct {
+	Contents []byte     `json:"contents,omitempty"`
+	Version  uint64     `json:"version,omitempty"`
+	Entries  []dirEntry `json:"entries,omitempty"`
+	Holder   string     `json:"holder,omitempty"`
+
+	// Held is set when a lock can't be taken because it is held.
+	Held bool `json:"held,omitempty"`
+
+	// Code is set for the errors which are topo errors. The client
+	// builds them with its own path.
+	Code *topo.ErrorCode `json:"code,omitempty"`
+	// Error is set for the other errors.
+	Error string `json:"error,omitempty"`
+	// UnknownSession is set when the session of a request was
+	// closed, or expired.
+	UnknownSession bool `json:"unknown_session,omitempty"`
+	// NotLeader is set when the member which received the request is
+	// not the leader, so the client tries another one.
+	NotLeader bool `json:"not_leader,omitempty"`
+}
+
+// dirEntry is an entry of a directory.
+type dirEntry struct {
+	Name string `json:"name"`
+	Dir  bool   `json:"dir,omitempty"`
+}
+
+// errNotLeader is returned to the client by the members which are not
+// the leader.
+var errNotLeader = errors.New("not the leader of the raft group")
+
+// errUnknownSession is returned when the session of the client was
+// closed, or expired, and its locks were released.
+var errUnknownSession = errors.New("unknown session")
+
+func codeResponse(code topo.ErrorCode) *response {
+	return &response{Code: &code}
+}
+
+func errorResponse(err error) *response {
+	return &response{Error: err.Error()}
+}
+
+// err returns the error of the response for the path of the client.
+func (resp *response) err(filePath string) error {
+	switch {
+	case resp.Code != nil:
+		return topo.NewError(*resp.Code, filePath)
+	case resp.NotLeader:
+		return errNotLeader
+	case resp.UnknownSession:
+		return errUnknownSession
+	case resp.Error != "":
+		return errors.New(resp.Error)
+	}
+	return nil
+}
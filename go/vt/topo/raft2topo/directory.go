@@ -0,0 +1,46 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// ListDir is part of the topo.Conn interface. The locks and elections
+// are not stored as files, so there are no ephemeral entries.
+func (s *Server) ListDir(ctx context.Context, dirPath string, full bool) ([]topo.DirEntry, error) {
+	resp, err := s.call(ctx, &request{Op: opList, Path: s.fullPath(dirPath)})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.err(dirPath); err != nil {
+		return nil, err
+	}
+	result := make([]topo.DirEntry, len(resp.Entries))
+	for i, e := range resp.Entries {
+		result[i].Name = e.Name
+		if full {
+			result[i].Type = topo.TypeFile
+			if e.Dir {
+				result[i].Type = topo.TypeDirectory
+			}
+		}
+	}
+	return result, nil
+}
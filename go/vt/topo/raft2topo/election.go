@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"path"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+const (
+	// electionsPath is the directory of the election locks.
+	electionsPath = "elections"
+)
+
+// NewMasterParticipation is part of the topo.Conn interface.
+func (s *Server) NewMasterParticipation(name, id string) (topo.MasterParticipation, error) {
+	return &raftMasterParticipation{
+		s:            s,
+		name:         name,
+		id:           id,
+		electionPath: s.fullPath(path.Join(electionsPath, name)),
+		stop:         make(chan struct{}),
+	}, nil
+}
+
+// raftMasterParticipation implements topo.MasterParticipation.
+//
+// The master holds the lock of the election, whose contents is its id.
+// It is the master until Stop is called, or its session is lost.
+type raftMasterParticipation struct {
+	s            *Server
+	name         string
+	id           string
+	electionPath string
+
+	// stop is closed when Stop is called.
+	stop chan struct{}
+	// wg tracks the current attempt or mastership.
+	wg sync.WaitGroup
+}
+
+// WaitForMastership is part of the topo.MasterParticipation interface.
+func (mp *raftMasterParticipation) WaitForMastership() (context.Context, error) {
+	select {
+	case <-mp.stop:
+		return nil, topo.NewError(topo.Interrupted, "mastership")
+	default:
+	}
+
+	mp.wg.Add(1)
+	lockCtx, lockCancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-mp.stop:
+			lockCancel()
+		case <-lockCtx.Done():
+		}
+	}()
+
+	ld, err := mp.s.lock(lockCtx, mp.electionPath, mp.name, mp.id, false /* checkDir */)
+	if err != nil {
+		lockCancel()
+		mp.wg.Done()
+		return nil, err
+	}
+
+	// We are the master until we stop, or lose the session.
+	go func() {
+		defer mp.wg.Done()
+		select {
+		case <-mp.stop:
+		case <-ld.sess.lost:
+		}
+		lockCancel()
+		// The lock is released with the session after its TTL
+		// anyway, so there is no point in trying longer.
+		unlockCtx, unlockCancel := context.WithTimeout(context.Background(), *sessionTTL)
+		defer unlockCancel()
+		if err := ld.Unlock(unlockCtx); err != nil {
+			log.Warningf("raft2topo: cannot release election lock %v: %v", mp.electionPath, err)
+		}
+	}()
+	return lockCtx, nil
+}
+
+// Stop is part of the topo.MasterParticipation interface.
+func (mp *raftMasterParticipation) Stop() {
+	close(mp.stop)
+	mp.wg.Wait()
+}
+
+// GetCurrentMasterID is part of the topo.MasterParticipation interface.
+func (mp *raftMasterParticipation) GetCurrentMasterID(ctx context.Context) (string, error) {
+	resp, err := mp.s.call(ctx, &request{Op: opLockHolder, Path: mp.electionPath})
+	if err != nil {
+		return "", err
+	}
+	if err := resp.err(mp.name); err != nil {
+		return "", err
+	}
+	return resp.Holder, nil
+}
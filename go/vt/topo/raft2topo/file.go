@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// Create is part of the topo.Conn interface.
+func (s *Server) Create(ctx context.Context, filePath string, contents []byte) (topo.Version, error) {
+	resp, err := s.call(ctx, &request{Op: opCreate, Path: s.fullPath(filePath), Contents: contents})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.err(filePath); err != nil {
+		return nil, err
+	}
+	return RaftVersion(resp.Version), nil
+}
+
+// Update is part of the topo.Conn interface.
+func (s *Server) Update(ctx context.Context, filePath string, contents []byte, version topo.Version) (topo.Version, error) {
+	resp, err := s.call(ctx, &request{Op: opUpdate, Path: s.fullPath(filePath), Contents: contents, Version: versionIndex(version)})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.err(filePath); err != nil {
+		return nil, err
+	}
+	return RaftVersion(resp.Version), nil
+}
+
+// Get is part of the topo.Conn interface.
+func (s *Server) Get(ctx context.Context, filePath string) ([]byte, topo.Version, error) {
+	resp, err := s.call(ctx, &request{Op: opGet, Path: s.fullPath(filePath)})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := resp.err(filePath); err != nil {
+		return nil, nil, err
+	}
+	if resp.Contents == nil {
+		resp.Contents = []byte{}
+	}
+	return resp.Contents, RaftVersion(resp.Version), nil
+}
+
+// Delete is part of the topo.Conn interface.
+func (s *Server) Delete(ctx context.Context, filePath string, version topo.Version) error {
+	resp, err := s.call(ctx, &request{Op: opDelete, Path: s.fullPath(filePath), Version: versionIndex(version)})
+	if err != nil {
+		return err
+	}
+	return resp.err(filePath)
+}
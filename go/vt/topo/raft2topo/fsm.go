@@ -0,0 +1,402 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/orchestrator/external/raft"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// state is the replicated state of the raft group. The paths are
+// absolute, and include the root of the cells. The directories only
+// exist through the files they contain, so they disappear with their
+// last file.
+type state struct {
+	Files    map[string]*fileEntry    `json:"files"`
+	Locks    map[string]*lockEntry    `json:"locks"`
+	Sessions map[string]*sessionEntry `json:"sessions"`
+}
+
+type fileEntry struct {
+	Contents []byte `json:"contents"`
+	// Version is the raft index of the last change.
+	Version uint64 `json:"version"`
+}
+
+type lockEntry struct {
+	Session  string `json:"session"`
+	Token    string `json:"token"`
+	Contents string `json:"contents"`
+}
+
+type sessionEntry struct {
+	TTL time.Duration `json:"ttl"`
+}
+
+func newState() *state {
+	return &state{
+		Files:    make(map[string]*fileEntry),
+		Locks:    make(map[string]*lockEntry),
+		Sessions: make(map[string]*sessionEntry),
+	}
+}
+
+// fsm is the raft.FSM of a member. Besides the state, it has the watches
+// and the lock waiters of the member, which are notified by the applied
+// commands.
+type fsm struct {
+	mu    sync.Mutex
+	state *state
+	// watches has the notification channels of the watches of each
+	// path. They have a capacity of 1, and the watches read the file
+	// when notified, so they may skip versions.
+	watches map[string]map[chan struct{}]bool
+	// lockReleased is closed and replaced when a lock is released.
+	lockReleased chan struct{}
+}
+
+func newFSM() *fsm {
+	return &fsm{
+		state:        newState(),
+		watches:      make(map[string]map[chan struct{}]bool),
+		lockReleased: make(chan struct{}),
+	}
+}
+
+// Apply is part of the raft.FSM interface. It returns a *response.
+func (f *fsm) Apply(l *raft.Log) interface{} {
+	req := &request{}
+	if err := json.Unmarshal(l.Data, req); err != nil {
+		return errorResponse(fmt.Errorf("cannot decode command: %v", err))
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch req.Op {
+	case opCreate:
+		return f.create(req, l.Index)
+	case opUpdate:
+		return f.update(req, l.Index)
+	case opDelete:
+		return f.delete(req)
+	case opLock:
+		return f.lock(req)
+	case opUnlock:
+		return f.unlock(req)
+	case opOpenSession:
+		f.state.Sessions[req.Session] = &sessionEntry{TTL: req.TTL}
+		return &response{}
+	case opCloseSession:
+		f.closeSession(req.Session)
+		return &response{}
+	}
+	return errorResponse(fmt.Errorf("unknown command %v", req.Op))
+}
+
+// checkParents returns an error if one of the parents of filePath is a
+// file.
+func (f *fsm) checkParents(filePath string) *response {
+	for dir := parentDir(filePath); dir != "/"; dir = parentDir(dir) {
+		if _, ok := f.state.Files[dir]; ok {
+			return errorResponse(fmt.Errorf("trying to create file %v in a path that contains files", filePath))
+		}
+	}
+	return nil
+}
+
+// isDir returns true if there is a file under dirPath.
+func (f *fsm) isDir(dirPath string) bool {
+	prefix := dirPrefix(dirPath)
+	for p := range f.state.Files {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fsm) create(req *request, index uint64) *response {
+	if _, ok := f.state.Files[req.Path]; ok || f.isDir(req.Path) {
+		return codeResponse(topo.NodeExists)
+	}
+	if resp := f.checkParents(req.Path); resp != nil {
+		return resp
+	}
+	f.state.Files[req.Path] = &fileEntry{Contents: req.Contents, Version: index}
+	f.notifyWatches(req.Path)
+	return &response{Version: index}
+}
+
+func (f *fsm) update(req *request, index uint64) *response {
+	file, ok := f.state.Files[req.Path]
+	switch {
+	case !ok && req.Version != 0:
+		return codeResponse(topo.NoNode)
+	case !ok && f.isDir(req.Path):
+		return errorResponse(fmt.Errorf("update(%v) failed: it's a directory", req.Path))
+	case !ok:
+		if resp := f.checkParents(req.Path); resp != nil {
+			return resp
+		}
+	case req.Version != 0 && req.Version != file.Version:
+		return codeResponse(topo.BadVersion)
+	}
+	f.state.Files[req.Path] = &fileEntry{Contents: req.Contents, Version: index}
+	f.notifyWatches(req.Path)
+	return &response{Version: index}
+}
+
+func (f *fsm) delete(req *request) *response {
+	file, ok := f.state.Files[req.Path]
+	switch {
+	case !ok && f.isDir(req.Path):
+		return errorResponse(fmt.Errorf("delete(%v) failed: it's a directory", req.Path))
+	case !ok:
+		return codeResponse(topo.NoNode)
+	case req.Version != 0 && req.Version != file.Version:
+		return codeResponse(topo.BadVersion)
+	}
+	delete(f.state.Files, req.Path)
+	f.notifyWatches(req.Path)
+	return &response{}
+}
+
+func (f *fsm) lock(req *request) *response {
+	if _, ok := f.state.Sessions[req.Session]; !ok {
+		return &response{UnknownSession: true}
+	}
+	if req.CheckDir && !f.isDir(req.Path) {
+		return codeResponse(topo.NoNode)
+	}
+	if l, ok := f.state.Locks[req.Path]; ok {
+		if l.Token == req.Token {
+			// The lock was taken by a retry of the request.
+			return &response{}
+		}
+		return &response{Held: true}
+	}
+	f.state.Locks[req.Path] = &lockEntry{
+		Session:  req.Session,
+		Token:    req.Token,
+		Contents: req.Holder,
+	}
+	return &response{}
+}
+
+func (f *fsm) unlock(req *request) *response {
+	l, ok := f.state.Locks[req.Path]
+	if !ok || l.Token != req.Token {
+		return errorResponse(fmt.Errorf("lock on %v is not held", req.Path))
+	}
+	delete(f.state.Locks, req.Path)
+	f.notifyLockReleased()
+	return &response{}
+}
+
+// closeSession ends a session, and releases its locks.
+func (f *fsm) closeSession(session string) {
+	delete(f.state.Sessions, session)
+	released := false
+	for p, l := range f.state.Locks {
+		if l.Session == session {
+			delete(f.state.Locks, p)
+			released = true
+		}
+	}
+	if released {
+		f.notifyLockReleased()
+	}
+}
+
+func (f *fsm) notifyWatches(filePath string) {
+	for ch := range f.watches[filePath] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (f *fsm) notifyLockReleased() {
+	close(f.lockReleased)
+	f.lockReleased = make(chan struct{})
+}
+
+// get returns a file, or nil and the response of the error.
+func (f *fsm) get(filePath string) (*fileEntry, *response) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, ok := f.state.Files[filePath]
+	if !ok {
+		if f.isDir(filePath) {
+			return nil, errorResponse(fmt.Errorf("cannot Get() directory %v", filePath))
+		}
+		return nil, codeResponse(topo.NoNode)
+	}
+	return file, nil
+}
+
+// list returns the entries of a directory, sorted by name.
+func (f *fsm) list(dirPath string) *response {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.state.Files[dirPath]; ok {
+		return errorResponse(fmt.Errorf("node %v is not a directory", dirPath))
+	}
+	prefix := dirPrefix(dirPath)
+	dirs := make(map[string]bool)
+	for p := range f.state.Files {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		name := p[len(prefix):]
+		if i := strings.IndexByte(name, '/'); i >= 0 {
+			dirs[name[:i]] = true
+		} else {
+			dirs[name] = false
+		}
+	}
+	if len(dirs) == 0 {
+		return codeResponse(topo.NoNode)
+	}
+	resp := &response{Entries: make([]dirEntry, 0, len(dirs))}
+	for name, dir := range dirs {
+		resp.Entries = append(resp.Entries, dirEntry{Name: name, Dir: dir})
+	}
+	sort.Slice(resp.Entries, func(i, j int) bool {
+		return resp.Entries[i].Name < resp.Entries[j].Name
+	})
+	return resp
+}
+
+// lockState returns the lock of a path or nil, and a channel which is
+// closed when a lock is released.
+func (f *fsm) lockState(lockPath string) (*lockEntry, <-chan struct{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.state.Locks[lockPath], f.lockReleased
+}
+
+// sessions returns the sessions and their TTL.
+func (f *fsm) sessions() map[string]time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sessions := make(map[string]time.Duration, len(f.state.Sessions))
+	for id, s := range f.state.Sessions {
+		sessions[id] = s.TTL
+	}
+	return sessions
+}
+
+func (f *fsm) hasSession(session string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.state.Sessions[session]
+	return ok
+}
+
+// addWatch registers a watch of a file. The returned function removes it.
+func (f *fsm) addWatch(filePath string) (<-chan struct{}, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	if f.watches[filePath] == nil {
+		f.watches[filePath] = make(map[chan struct{}]bool)
+	}
+	f.watches[filePath][ch] = true
+	return ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.watches[filePath], ch)
+		if len(f.watches[filePath]) == 0 {
+			delete(f.watches, filePath)
+		}
+	}
+}
+
+// Snapshot is part of the raft.FSM interface.
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, err := json.Marshal(f.state)
+	if err != nil {
+		return nil, err
+	}
+	return &fsmSnapshot{data: data}, nil
+}
+
+// Restore is part of the raft.FSM interface.
+func (f *fsm) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	st := newState()
+	if err := json.Unmarshal(data, st); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state = st
+	for filePath := range f.watches {
+		f.notifyWatches(filePath)
+	}
+	f.notifyLockReleased()
+	return nil
+}
+
+type fsmSnapshot struct {
+	data []byte
+}
+
+// Persist is part of the raft.FSMSnapshot interface.
+func (s *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	if _, err := sink.Write(s.data); err != nil {
+		sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
+
+// Release is part of the raft.FSMSnapshot interface.
+func (s *fsmSnapshot) Release() {}
+
+// parentDir returns the parent directory of an absolute path.
+func parentDir(p string) string {
+	i := strings.LastIndexByte(p, '/')
+	if i <= 0 {
+		return "/"
+	}
+	return p[:i]
+}
+
+// dirPrefix returns the prefix of the paths of the files under a directory.
+func dirPrefix(dirPath string) string {
+	if dirPath == "/" {
+		return "/"
+	}
+	return dirPath + "/"
+}
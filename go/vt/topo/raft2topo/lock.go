@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// raftLockDescriptor implements topo.LockDescriptor.
+type raftLockDescriptor struct {
+	s        *Server
+	sess     *session
+	lockPath string
+	token    string
+}
+
+// Lock is part of the topo.Conn interface.
+func (s *Server) Lock(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	return s.lock(ctx, s.fullPath(dirPath), dirPath, contents, true /* checkDir */)
+}
+
+// lock takes the lock of lockPath for the session of the client. If
+// checkDir is set, lockPath must be a directory.
+func (s *Server) lock(ctx context.Context, lockPath, nodePath, contents string, checkDir bool) (*raftLockDescriptor, error) {
+	sess, err := s.getSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ld := &raftLockDescriptor{
+		s:        s,
+		sess:     sess,
+		lockPath: lockPath,
+		token:    newToken(),
+	}
+	resp, err := s.call(ctx, &request{
+		Op:       opLock,
+		Path:     lockPath,
+		Session:  sess.id,
+		Token:    ld.token,
+		Holder:   contents,
+		CheckDir: checkDir,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			// The lock may have been taken while the
+			// request was canceled.
+			ld.release()
+		}
+		return nil, convertError(err, nodePath)
+	}
+	if err := resp.err(nodePath); err != nil {
+		return nil, err
+	}
+	return ld, nil
+}
+
+// release releases the lock if it was taken, for a request which failed.
+func (ld *raftLockDescriptor) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := ld.s.call(ctx, &request{Op: opUnlock, Path: ld.lockPath, Token: ld.token}); err != nil {
+		log.Warningf("raft2topo: cannot release lock %v: %v", ld.lockPath, err)
+	}
+}
+
+// Check is part of the topo.LockDescriptor interface.
+func (ld *raftLockDescriptor) Check(ctx context.Context) error {
+	select {
+	case <-ld.sess.lost:
+		return fmt.Errorf("lost the session of lock %v", ld.lockPath)
+	default:
+	}
+	resp, err := ld.s.call(ctx, &request{Op: opCheckLock, Path: ld.lockPath, Token: ld.token})
+	if err != nil {
+		return err
+	}
+	return resp.err(ld.lockPath)
+}
+
+// Unlock is part of the topo.LockDescriptor interface.
+func (ld *raftLockDescriptor) Unlock(ctx context.Context) error {
+	resp, err := ld.s.call(ctx, &request{Op: opUnlock, Path: ld.lockPath, Token: ld.token})
+	if err != nil {
+		return err
+	}
+	return resp.err(ld.lockPath)
+}
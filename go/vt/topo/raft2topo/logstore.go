@@ -0,0 +1,302 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+
+	"vitess.io/vitess/go/vt/orchestrator/external/raft"
+)
+
+const (
+	logFileName    = "raft.log"
+	stableFileName = "stable.json"
+)
+
+// fileStore is the raft.LogStore and raft.StableStore of a member. It
+// keeps everything in memory, and makes it durable in the data directory:
+// the log entries are appended to a file of length-prefixed JSON records,
+// which is rewritten when entries are deleted, and the stable values are
+// rewritten to a JSON file when they change.
+type fileStore struct {
+	*raft.InmemStore
+
+	dir string
+
+	// mu protects the files, and the consistency between the
+	// InmemStore and the files.
+	mu      sync.Mutex
+	logFile *os.File
+	stable  stableValues
+}
+
+// logRecord is a record of the log file.
+type logRecord struct {
+	Log *raft.Log `json:"log"`
+}
+
+// stableValues are the contents of the stable file.
+type stableValues struct {
+	KV    map[string][]byte `json:"kv"`
+	KVInt map[string]uint64 `json:"kv_int"`
+}
+
+// newFileStore opens the store of the data directory, and loads it.
+func newFileStore(dir string) (*fileStore, error) {
+	fs := &fileStore{
+		InmemStore: raft.NewInmemStore(),
+		dir:        dir,
+		stable: stableValues{
+			KV:    make(map[string][]byte),
+			KVInt: make(map[string]uint64),
+		},
+	}
+	if err := fs.loadStable(); err != nil {
+		return nil, err
+	}
+	if err := fs.loadLogs(); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (fs *fileStore) loadStable() error {
+	data, err := ioutil.ReadFile(path.Join(fs.dir, stableFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &fs.stable); err != nil {
+		return fmt.Errorf("cannot parse %v: %v", stableFileName, err)
+	}
+	for k, v := range fs.stable.KV {
+		if err := fs.InmemStore.Set([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	for k, v := range fs.stable.KVInt {
+		if err := fs.InmemStore.SetUint64([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadLogs replays the log file. A record which was only partially
+// written, because the process died while appending it, is dropped.
+func (fs *fileStore) loadLogs() error {
+	file, err := os.OpenFile(path.Join(fs.dir, logFileName), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(file)
+	var offset int64
+	for {
+		rec, n, err := readRecord(r)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("cannot read %v at offset %v: %v", logFileName, offset, err)
+		}
+		if err := fs.InmemStore.StoreLog(rec.Log); err != nil {
+			file.Close()
+			return err
+		}
+		offset += n
+	}
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+	fs.logFile = file
+	return nil
+}
+
+func readRecord(r io.Reader) (*logRecord, int64, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, 0, err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, 0, err
+	}
+	rec := &logRecord{}
+	if err := json.Unmarshal(data, rec); err != nil {
+		return nil, 0, err
+	}
+	return rec, int64(4 + size), nil
+}
+
+func appendRecord(w io.Writer, log *raft.Log) error {
+	data, err := json.Marshal(&logRecord{Log: log})
+	if err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(data))); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// StoreLog is part of the raft.LogStore interface.
+func (fs *fileStore) StoreLog(log *raft.Log) error {
+	return fs.StoreLogs([]*raft.Log{log})
+}
+
+// StoreLogs is part of the raft.LogStore interface.
+func (fs *fileStore) StoreLogs(logs []*raft.Log) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	w := bufio.NewWriter(fs.logFile)
+	for _, log := range logs {
+		if err := appendRecord(w, log); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := fs.logFile.Sync(); err != nil {
+		return err
+	}
+	return fs.InmemStore.StoreLogs(logs)
+}
+
+// DeleteRange is part of the raft.LogStore interface. It rewrites the
+// log file with the remaining entries. This happens after a snapshot,
+// to compact the log, or when a follower drops conflicting entries, so
+// it is rare.
+func (fs *fileStore) DeleteRange(min, max uint64) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := fs.InmemStore.DeleteRange(min, max); err != nil {
+		return err
+	}
+	first, err := fs.InmemStore.FirstIndex()
+	if err != nil {
+		return err
+	}
+	last, err := fs.InmemStore.LastIndex()
+	if err != nil {
+		return err
+	}
+
+	tmpName := path.Join(fs.dir, logFileName+".tmp")
+	tmp, err := os.OpenFile(tmpName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	for i := first; i != 0 && i <= last; i++ {
+		log := &raft.Log{}
+		if err := fs.InmemStore.GetLog(i, log); err != nil {
+			// The range of the InmemStore may have holes.
+			continue
+		}
+		if err := appendRecord(w, log); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := os.Rename(tmpName, path.Join(fs.dir, logFileName)); err != nil {
+		tmp.Close()
+		return err
+	}
+	fs.logFile.Close()
+	fs.logFile = tmp
+	return nil
+}
+
+// Set is part of the raft.StableStore interface.
+func (fs *fileStore) Set(key []byte, val []byte) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.stable.KV[string(key)] = val
+	if err := fs.saveStable(); err != nil {
+		return err
+	}
+	return fs.InmemStore.Set(key, val)
+}
+
+// SetUint64 is part of the raft.StableStore interface.
+func (fs *fileStore) SetUint64(key []byte, val uint64) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.stable.KVInt[string(key)] = val
+	if err := fs.saveStable(); err != nil {
+		return err
+	}
+	return fs.InmemStore.SetUint64(key, val)
+}
+
+// saveStable atomically rewrites the stable file. fs.mu must be held.
+func (fs *fileStore) saveStable() error {
+	data, err := json.Marshal(&fs.stable)
+	if err != nil {
+		return err
+	}
+	tmpName := path.Join(fs.dir, stableFileName+".tmp")
+	tmp, err := os.OpenFile(tmpName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path.Join(fs.dir, stableFileName))
+}
+
+// Close closes the log file.
+func (fs *fileStore) Close() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.logFile.Close()
+}
@@ -0,0 +1,469 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/orchestrator/external/raft"
+)
+
+const (
+	// raftTimeout is how long a command waits to be committed.
+	raftTimeout = 10 * time.Second
+
+	// retainSnapshotCount is the number of snapshots kept in the data
+	// directory.
+	retainSnapshotCount = 2
+
+	// expireInterval is how often the leader expires the sessions which
+	// were not kept alive.
+	expireInterval = time.Second
+)
+
+// MemberConfig is the configuration of a member of the raft group.
+type MemberConfig struct {
+	// DataDir is the directory of the raft log and snapshots. If it
+	// is empty, they are only kept in memory, which is meant for tests.
+	DataDir string
+
+	// RaftAddr is the host:port of the raft transport of the member.
+	RaftAddr string
+
+	// Peers are the raft addresses of the members of the group. A
+	// member without other peers elects itself.
+	Peers []string
+
+	// APIAddr is the address the API listens on, if not empty. The
+	// clients use the list of the API addresses of the members as
+	// server address.
+	APIAddr string
+
+	// CertPath and KeyPath are the cert and key of the member, and
+	// CAPath is the CA which signed the certs of the other members
+	// and of the clients. When they are set, the raft transport and
+	// the API use TLS, and require a cert signed by the CA from the
+	// other side. Without them, the member is only meant for tests.
+	CertPath string
+	KeyPath  string
+	CAPath   string
+
+	// Transport replaces the TCP raft transport. It is used by tests.
+	Transport raft.Transport
+}
+
+// Member is a member of the raft group. It replicates the state of the
+// topology, and serves the API to the clients. The leader serves the
+// changes, the reads and the start of the watches, so that the clients
+// read their own changes. A watch keeps being served by its member after
+// it loses the leadership, as its state is still replicated.
+type Member struct {
+	raft      *raft.Raft
+	fsm       *fsm
+	transport raft.Transport
+	store     *fileStore
+	listener  net.Listener
+	server    *http.Server
+
+	leaderNotify chan bool
+	done         chan struct{}
+	wg           sync.WaitGroup
+
+	// mu protects the following fields.
+	mu sync.Mutex
+	// ready is set while the member is the leader, once it has applied
+	// the entries of the previous terms, so it can serve reads.
+	ready bool
+	// lastSeen has the time of the last keep-alive of the sessions. It
+	// is only used on the leader, and reset when it is elected.
+	lastSeen map[string]time.Time
+}
+
+// NewMember starts a member of the raft group.
+func NewMember(config *MemberConfig) (*Member, error) {
+	m := &Member{
+		fsm:          newFSM(),
+		transport:    config.Transport,
+		leaderNotify: make(chan bool, 1),
+		done:         make(chan struct{}),
+		lastSeen:     make(map[string]time.Time),
+	}
+
+	serverTLS, clientTLS, err := memberTLSConfigs(config)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := raft.DefaultConfig()
+	conf.ShutdownOnRemove = false
+	conf.NotifyCh = m.leaderNotify
+
+	if m.transport == nil && serverTLS != nil {
+		if m.transport, err = newTLSTransport(config.RaftAddr, serverTLS, clientTLS); err != nil {
+			return nil, err
+		}
+	}
+	if m.transport == nil {
+		advertise, err := net.ResolveTCPAddr("tcp", config.RaftAddr)
+		if err != nil {
+			return nil, err
+		}
+		transport, err := raft.NewTCPTransport(config.RaftAddr, advertise, 3, raftTimeout, os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+		m.transport = transport
+	}
+
+	peers := []string{m.transport.LocalAddr()}
+	for _, peer := range config.Peers {
+		peers = raft.AddUniquePeer(peers, strings.TrimSpace(peer))
+	}
+	peerStore := &raft.StaticPeers{}
+	if err := peerStore.SetPeers(peers); err != nil {
+		m.closeTransport()
+		return nil, err
+	}
+	if len(peers) == 1 {
+		conf.EnableSingleNode = true
+		conf.DisableBootstrapAfterElect = false
+	}
+
+	var logs raft.LogStore
+	var stable raft.StableStore
+	var snapshots raft.SnapshotStore
+	if config.DataDir == "" {
+		inmem := raft.NewInmemStore()
+		logs, stable = inmem, inmem
+		snapshots = raft.NewDiscardSnapshotStore()
+	} else {
+		if err := os.MkdirAll(config.DataDir, 0700); err != nil {
+			m.closeTransport()
+			return nil, err
+		}
+		store, err := newFileStore(config.DataDir)
+		if err != nil {
+			m.closeTransport()
+			return nil, err
+		}
+		m.store = store
+		logs, stable = store, store
+		if snapshots, err = raft.NewFileSnapshotStore(config.DataDir, retainSnapshotCount, os.Stderr); err != nil {
+			m.closeTransport()
+			store.Close()
+			return nil, err
+		}
+	}
+
+	if m.raft, err = raft.NewRaft(conf, m.fsm, logs, stable, snapshots, peerStore, m.transport); err != nil {
+		m.closeTransport()
+		if m.store != nil {
+			m.store.Close()
+		}
+		return nil, fmt.Errorf("cannot create raft: %v", err)
+	}
+
+	m.wg.Add(2)
+	go m.watchLeadership()
+	go m.expireSessions()
+
+	if config.APIAddr != "" {
+		if m.listener, err = net.Listen("tcp", config.APIAddr); err != nil {
+			m.Close()
+			return nil, err
+		}
+		if serverTLS != nil {
+			m.listener = tls.NewListener(m.listener, serverTLS)
+		}
+		m.server = &http.Server{Handler: m}
+		go m.server.Serve(m.listener)
+	}
+	return m, nil
+}
+
+// APIAddr returns the address the API listens on.
+func (m *Member) APIAddr() string {
+	return m.listener.Addr().String()
+}
+
+// Close stops the member.
+func (m *Member) Close() {
+	close(m.done)
+	if m.server != nil {
+		m.server.Close()
+	}
+	if err := m.raft.Shutdown().Error(); err != nil {
+		log.Errorf("cannot shut down raft: %v", err)
+	}
+	m.wg.Wait()
+	m.closeTransport()
+	if m.store != nil {
+		m.store.Close()
+	}
+}
+
+func (m *Member) closeTransport() {
+	if c, ok := m.transport.(raft.WithClose); ok {
+		c.Close()
+	}
+}
+
+// watchLeadership maintains ready.
+func (m *Member) watchLeadership() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.done:
+			return
+		case leader := <-m.leaderNotify:
+			m.setReady(false)
+			if !leader {
+				continue
+			}
+			// The entries of the previous terms are applied once
+			// an entry of this term is.
+			if err := m.raft.Barrier(raftTimeout).Error(); err != nil {
+				log.Warningf("raft2topo: barrier failed after the election: %v", err)
+				continue
+			}
+			if m.raft.State() == raft.Leader {
+				m.setReady(true)
+			}
+		}
+	}
+}
+
+func (m *Member) setReady(ready bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ready = ready
+	if ready {
+		// The sessions get a full TTL from the new leader.
+		m.lastSeen = make(map[string]time.Time)
+	}
+}
+
+func (m *Member) isReady() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ready
+}
+
+// expireSessions closes the sessions which were not kept alive for their
+// TTL, which releases their locks.
+func (m *Member) expireSessions() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+		if !m.isReady() {
+			continue
+		}
+		sessions := m.fsm.sessions()
+		now := time.Now()
+		var expired []string
+		m.mu.Lock()
+		for id, ttl := range sessions {
+			seen, ok := m.lastSeen[id]
+			if !ok {
+				m.lastSeen[id] = now
+			} else if now.Sub(seen) > ttl {
+				expired = append(expired, id)
+			}
+		}
+		for id := range m.lastSeen {
+			if _, ok := sessions[id]; !ok {
+				delete(m.lastSeen, id)
+			}
+		}
+		m.mu.Unlock()
+		for _, id := range expired {
+			log.Infof("raft2topo: session %v expired", id)
+			if resp := m.apply(&request{Op: opCloseSession, Session: id}); resp.Error != "" {
+				log.Warningf("raft2topo: cannot close session %v: %v", id, resp.Error)
+			}
+		}
+	}
+}
+
+// apply replicates a command, and returns its result.
+func (m *Member) apply(req *request) *response {
+	if !m.isReady() {
+		return &response{NotLeader: true}
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return errorResponse(err)
+	}
+	f := m.raft.Apply(data, raftTimeout)
+	if err := f.Error(); err != nil {
+		if err == raft.ErrNotLeader || err == raft.ErrLeadershipLost {
+			return &response{NotLeader: true}
+		}
+		return errorResponse(err)
+	}
+	return f.Response().(*response)
+}
+
+// ServeHTTP serves the API.
+func (m *Member) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	req := &request{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Op = strings.TrimPrefix(r.URL.Path, apiPrefix)
+
+	var resp *response
+	switch req.Op {
+	case opCreate, opUpdate, opDelete, opUnlock, opOpenSession, opCloseSession:
+		resp = m.apply(req)
+	case opLock:
+		resp = m.lock(r.Context(), req)
+	case opWatch:
+		m.watch(w, r, req)
+		return
+	case opGet, opList, opCheckLock, opLockHolder, opKeepAlive:
+		resp = m.read(req)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Warningf("raft2topo: cannot write the response of %v: %v", req.Op, err)
+	}
+}
+
+// read serves the requests which don't change the state.
+func (m *Member) read(req *request) *response {
+	if !m.isReady() {
+		return &response{NotLeader: true}
+	}
+	switch req.Op {
+	case opGet:
+		file, resp := m.fsm.get(req.Path)
+		if resp != nil {
+			return resp
+		}
+		return &response{Contents: file.Contents, Version: file.Version}
+	case opList:
+		return m.fsm.list(req.Path)
+	case opCheckLock:
+		if l, _ := m.fsm.lockState(req.Path); l == nil || l.Token != req.Token {
+			return errorResponse(fmt.Errorf("lock on %v was lost", req.Path))
+		}
+		return &response{}
+	case opLockHolder:
+		resp := &response{}
+		if l, _ := m.fsm.lockState(req.Path); l != nil {
+			resp.Holder = l.Contents
+		}
+		return resp
+	case opKeepAlive:
+		if !m.fsm.hasSession(req.Session) {
+			return &response{UnknownSession: true}
+		}
+		m.mu.Lock()
+		m.lastSeen[req.Session] = time.Now()
+		m.mu.Unlock()
+		return &response{}
+	}
+	return errorResponse(fmt.Errorf("unknown operation %v", req.Op))
+}
+
+// lock waits until the lock is taken, or the client goes away.
+func (m *Member) lock(ctx context.Context, req *request) *response {
+	for {
+		// Get the channel before trying, so that a release in
+		// between is not missed.
+		_, released := m.fsm.lockState(req.Path)
+		resp := m.apply(req)
+		if !resp.Held {
+			return resp
+		}
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return errorResponse(ctx.Err())
+		case <-m.done:
+			return &response{NotLeader: true}
+		}
+	}
+}
+
+// watch streams the contents of a file, until it is deleted or the client
+// goes away.
+func (m *Member) watch(w http.ResponseWriter, r *http.Request, req *request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	if !m.isReady() {
+		enc.Encode(&response{NotLeader: true})
+		return
+	}
+	notify, remove := m.fsm.addWatch(req.Path)
+	defer remove()
+
+	var version uint64
+	for {
+		file, resp := m.fsm.get(req.Path)
+		if resp != nil {
+			enc.Encode(resp)
+			flusher.Flush()
+			return
+		}
+		if file.Version != version {
+			if err := enc.Encode(&response{Contents: file.Contents, Version: file.Version}); err != nil {
+				return
+			}
+			flusher.Flush()
+			version = file.Version
+		}
+		select {
+		case <-notify:
+		case <-r.Context().Done():
+			return
+		case <-m.done:
+			return
+		}
+	}
+}
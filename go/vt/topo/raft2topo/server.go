@@ -0,0 +1,345 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package raft2topo implements topo.Server with an embedded raft group as
+the backend, so that a small deployment doesn't need a separate
+consensus service.
+
+A few processes, typically the vtctlds, run a Member each. The members
+replicate the files, the locks and the sessions of the clients with the
+raft implementation of the orchestrator, and serve them over an HTTP
+API. The server address of a cell is the comma-separated list of the API
+addresses of the members. The clients find the leader by trying them in
+turn.
+
+The locks belong to a session of the client, which is kept alive while
+the client is. When the client dies, the leader closes the session after
+its TTL, which releases its locks. The elections are locks whose
+contents is the id of the master.
+*/
+package raft2topo
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vttls"
+)
+
+var (
+	sessionTTL   = flag.Duration("topo_raft2_session_ttl", 30*time.Second, "The TTL of the session of a raft2 topo client. The locks of a client which dies are released after it.")
+	retryTimeout = flag.Duration("topo_raft2_retry_timeout", 30*time.Second, "How long a raft2 topo client retries a request while no member of the raft group is the leader.")
+
+	clientCertPath = flag.String("topo_raft2_tls_cert", "", "path to the client cert to use to connect to the raft2 topo members, requires topo_raft2_tls_key, enables TLS")
+	clientKeyPath  = flag.String("topo_raft2_tls_key", "", "path to the client key to use to connect to the raft2 topo members, enables TLS")
+	serverCaPath   = flag.String("topo_raft2_tls_ca", "", "path to the ca to use to validate the member certs when connecting to the raft2 topo members")
+)
+
+// retryDelay is how long the client waits after every member failed.
+const retryDelay = 100 * time.Millisecond
+
+// Factory is the raft2 topo.Factory implementation.
+type Factory struct{}
+
+// HasGlobalReadOnlyCell is part of the topo.Factory interface.
+func (f Factory) HasGlobalReadOnlyCell(serverAddr, root string) bool {
+	return false
+}
+
+// Create is part of the topo.Factory interface.
+func (f Factory) Create(cell, serverAddr, root string) (topo.Conn, error) {
+	return NewServer(serverAddr, root)
+}
+
+// Server is the implementation of topo.Conn for a raft group.
+type Server struct {
+	// addrs are the API addresses of the members.
+	addrs []string
+	// root is the root path for this client.
+	root   string
+	client *http.Client
+	// scheme is https when the client uses TLS.
+	scheme string
+
+	// mu protects the following fields.
+	mu sync.Mutex
+	// leader is the index of the member which is tried first.
+	leader int
+	// session is the session of the locks, opened by the first one.
+	session *session
+}
+
+// session is a session of the client, kept alive until it is closed.
+type session struct {
+	id string
+	// lost is closed when the session is closed or expired.
+	lost chan struct{}
+	// stop is closed to stop the keep-alives.
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewServer returns a new raft2topo.Server, which uses the process-wide
+// TLS settings.
+func NewServer(serverAddr, root string) (*Server, error) {
+	return NewServerWithOpts(serverAddr, root, *clientCertPath, *clientKeyPath, *serverCaPath)
+}
+
+// NewServerWithOpts returns a new raft2topo.Server. The members require
+// a client cert when they use TLS.
+func NewServerWithOpts(serverAddr, root, certPath, keyPath, caPath string) (*Server, error) {
+	var addrs []string
+	for _, addr := range strings.Split(serverAddr, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no raft2 topo member in server address %q", serverAddr)
+	}
+	s := &Server{
+		addrs:  addrs,
+		root:   root,
+		client: &http.Client{},
+		scheme: "http",
+	}
+
+	// If TLS is enabled, attach TLS config info.
+	if certPath != "" && keyPath != "" {
+		tlsConfig, err := vttls.ClientConfig(certPath, keyPath, caPath, "")
+		if err != nil {
+			return nil, err
+		}
+		s.client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+		s.scheme = "https"
+	}
+	return s, nil
+}
+
+// Close implements topo.Conn.Close. It closes the session, which releases
+// its locks.
+func (s *Server) Close() {
+	s.mu.Lock()
+	sess := s.session
+	s.session = nil
+	s.mu.Unlock()
+	if sess != nil {
+		close(sess.stop)
+		<-sess.done
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := s.call(ctx, &request{Op: opCloseSession, Session: sess.id}); err != nil {
+			log.Warningf("raft2topo: cannot close session %v: %v", sess.id, err)
+		}
+	}
+	s.client.CloseIdleConnections()
+}
+
+// fullPath returns the path of the state for a path of the client.
+func (s *Server) fullPath(p string) string {
+	return path.Join("/", s.root, p)
+}
+
+// member returns the API address of the member tried first.
+func (s *Server) member() (int, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leader, s.addrs[s.leader]
+}
+
+// next makes the member after i the one tried first.
+func (s *Server) next(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.leader == i {
+		s.leader = (i + 1) % len(s.addrs)
+	}
+}
+
+// post sends a request to a member. The body of the response is returned
+// for the watches, which read it as a stream.
+func (s *Server) post(ctx context.Context, addr string, req *request) (*http.Response, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest("POST", s.scheme+"://"+addr+apiPrefix+req.Op, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := s.client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		return nil, fmt.Errorf("member %v returned %v", addr, httpResp.Status)
+	}
+	return httpResp, nil
+}
+
+// tryMembers calls f with the members in turn, starting with the last
+// leader, until it succeeds, or the retry timeout. The returned error is
+// the one of the last member.
+func (s *Server) tryMembers(ctx context.Context, nodePath string, f func(addr string) error) error {
+	deadline := time.Now().Add(*retryTimeout)
+	for failed := 1; ; failed++ {
+		i, addr := s.member()
+		err := f(addr)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return convertError(ctx.Err(), nodePath)
+		}
+		s.next(i)
+		if failed%len(s.addrs) != 0 {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("no leader in the raft group: %v", err)
+		}
+		select {
+		case <-time.After(retryDelay):
+		case <-ctx.Done():
+			return convertError(ctx.Err(), nodePath)
+		}
+	}
+}
+
+// call sends a request to the leader.
+func (s *Server) call(ctx context.Context, req *request) (*response, error) {
+	var resp *response
+	err := s.tryMembers(ctx, req.Path, func(addr string) error {
+		var err error
+		if resp, err = s.callMember(ctx, addr, req); err == nil && resp.NotLeader {
+			return errNotLeader
+		}
+		return err
+	})
+	return resp, err
+}
+
+func (s *Server) callMember(ctx context.Context, addr string, req *request) (*response, error) {
+	httpResp, err := s.post(ctx, addr, req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	resp := &response{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// getSession returns the session of the client, and opens it if needed.
+func (s *Server) getSession(ctx context.Context) (*session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.session != nil {
+		select {
+		case <-s.session.lost:
+			// The session was lost, open a new one.
+			close(s.session.stop)
+			s.session = nil
+		default:
+			return s.session, nil
+		}
+	}
+	sess := &session{
+		id:   newToken(),
+		lost: make(chan struct{}),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	s.mu.Unlock()
+	resp, err := s.call(ctx, &request{Op: opOpenSession, Session: sess.id, TTL: *sessionTTL})
+	if err == nil {
+		err = resp.err("")
+	}
+	s.mu.Lock()
+	if err != nil {
+		return nil, err
+	}
+	if s.session != nil {
+		// Another lock opened a session at the same time, use
+		// it, and let ours expire.
+		return s.session, nil
+	}
+	s.session = sess
+	go s.keepAlive(sess, *sessionTTL)
+	return sess, nil
+}
+
+// keepAlive keeps the session alive, until it is stopped or lost.
+func (s *Server) keepAlive(sess *session, ttl time.Duration) {
+	defer close(sess.done)
+	lastAlive := time.Now()
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sess.stop:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), ttl/3)
+		resp, err := s.call(ctx, &request{Op: opKeepAlive, Session: sess.id})
+		cancel()
+		switch {
+		case err == nil && resp.UnknownSession:
+			log.Warningf("raft2topo: session %v expired", sess.id)
+			close(sess.lost)
+			return
+		case err == nil:
+			lastAlive = time.Now()
+		case time.Since(lastAlive) > ttl:
+			// The leader expires the session.
+			log.Warningf("raft2topo: session %v was not kept alive: %v", sess.id, err)
+			close(sess.lost)
+			return
+		}
+	}
+}
+
+// newToken returns a random identifier for sessions and locks.
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func init() {
+	topo.RegisterFactory("raft2", Factory{})
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/orchestrator/external/raft"
+	"vitess.io/vitess/go/vt/tlstest"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/test"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// startGroup starts a raft group of n members connected with in-memory
+// transports, and waits for its leader.
+func startGroup(t *testing.T, n int) ([]*Member, string) {
+	transports := make([]*raft.InmemTransport, n)
+	var peers []string
+	for i := range transports {
+		var addr string
+		addr, transports[i] = raft.NewInmemTransport(fmt.Sprintf("member%v", i))
+		peers = append(peers, addr)
+	}
+	for _, t1 := range transports {
+		for _, t2 := range transports {
+			if t1 != t2 {
+				t1.Connect(t2.LocalAddr(), t2)
+			}
+		}
+	}
+
+	members := make([]*Member, n)
+	var addrs []string
+	for i := range members {
+		m, err := NewMember(&MemberConfig{
+			Peers:     peers,
+			APIAddr:   "127.0.0.1:0",
+			Transport: transports[i],
+		})
+		if err != nil {
+			t.Fatalf("NewMember() failed: %v", err)
+		}
+		members[i] = m
+		addrs = append(addrs, m.APIAddr())
+	}
+	waitForLeader(t, members)
+	return members, strings.Join(addrs, ",")
+}
+
+func waitForLeader(t *testing.T, members []*Member) *Member {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, m := range members {
+			if m.isReady() {
+				return m
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no leader elected in time")
+	return nil
+}
+
+func TestRaft2Topo(t *testing.T) {
+	members, serverAddr := startGroup(t, 3)
+	defer func() {
+		for _, m := range members {
+			if m != nil {
+				m.Close()
+			}
+		}
+	}()
+
+	testIndex := 0
+	newServer := func() *topo.Server {
+		// Each test will use its own sub-directories.
+		testRoot := fmt.Sprintf("/test-%v", testIndex)
+		testIndex++
+
+		ts, err := topo.OpenServer("raft2", serverAddr, path.Join(testRoot, topo.GlobalCell))
+		if err != nil {
+			t.Fatalf("OpenServer() failed: %v", err)
+		}
+		if err := ts.CreateCellInfo(context.Background(), test.LocalCellName, &topodatapb.CellInfo{
+			ServerAddress: serverAddr,
+			Root:          path.Join(testRoot, test.LocalCellName),
+		}); err != nil {
+			t.Fatalf("CreateCellInfo() failed: %v", err)
+		}
+		return ts
+	}
+
+	// Run the TopoServerTestSuite tests.
+	test.TopoServerTestSuite(t, func() *topo.Server {
+		return newServer()
+	})
+
+	// Run raft2-specific tests.
+	testSessionExpiry(t, serverAddr)
+	testLeaderFailover(t, members, serverAddr)
+}
+
+// testSessionExpiry checks the locks of a client which stops keeping its
+// session alive are released.
+func testSessionExpiry(t *testing.T, serverAddr string) {
+	ctx := context.Background()
+	oldTTL := *sessionTTL
+	*sessionTTL = 2 * time.Second
+	defer func() {
+		*sessionTTL = oldTTL
+	}()
+
+	s1, err := NewServer(serverAddr, "/expiry")
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	s2, err := NewServer(serverAddr, "/expiry")
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	defer s2.Close()
+	if _, err := s1.Create(ctx, "/dir/file", []byte("a")); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if _, err := s1.Lock(ctx, "/dir", "s1"); err != nil {
+		t.Fatalf("Lock() failed: %v", err)
+	}
+
+	// s1 dies: its session isn't kept alive, nor closed.
+	sess := s1.session
+	close(sess.stop)
+	<-sess.done
+
+	lockCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	start := time.Now()
+	ld, err := s2.Lock(lockCtx, "/dir", "s2")
+	if err != nil {
+		t.Fatalf("Lock() after expiry failed: %v", err)
+	}
+	if time.Since(start) < *sessionTTL {
+		t.Errorf("lock was released before the TTL of the session")
+	}
+	if err := ld.Unlock(ctx); err != nil {
+		t.Errorf("Unlock() failed: %v", err)
+	}
+}
+
+// testLeaderFailover checks the clients use the new leader when the
+// leader goes away.
+func testLeaderFailover(t *testing.T, members []*Member, serverAddr string) {
+	ctx := context.Background()
+	s, err := NewServer(serverAddr, "/failover")
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	defer s.Close()
+	if _, err := s.Create(ctx, "/file", []byte("a")); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	current, changes, cancel := s.Watch(ctx, "/file")
+	if current.Err != nil {
+		t.Fatalf("Watch() failed: %v", current.Err)
+	}
+
+	leader := waitForLeader(t, members)
+	for i, m := range members {
+		if m == leader {
+			m.Close()
+			members[i] = nil
+		}
+	}
+
+	if _, err := s.Update(ctx, "/file", []byte("b"), nil); err != nil {
+		t.Fatalf("Update() after failover failed: %v", err)
+	}
+	contents, _, err := s.Get(ctx, "/file")
+	if err != nil || string(contents) != "b" {
+		t.Fatalf("Get() after failover returned %q, %v", contents, err)
+	}
+	for wd := range changes {
+		if wd.Err != nil {
+			t.Fatalf("watch failed: %v", wd.Err)
+		}
+		if string(wd.Contents) == "b" {
+			break
+		}
+	}
+	cancel()
+}
+
+func TestRaft2TopoDataDir(t *testing.T) {
+	ctx := context.Background()
+	dataDir, err := ioutil.TempDir("", "raft2topo")
+	if err != nil {
+		t.Fatalf("cannot create tempdir: %v", err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	start := func() (*Member, *Server) {
+		_, transport := raft.NewInmemTransport("member")
+		m, err := NewMember(&MemberConfig{
+			DataDir:   dataDir,
+			APIAddr:   "127.0.0.1:0",
+			Transport: transport,
+		})
+		if err != nil {
+			t.Fatalf("NewMember() failed: %v", err)
+		}
+		waitForLeader(t, []*Member{m})
+		s, err := NewServer(m.APIAddr(), "/global")
+		if err != nil {
+			t.Fatalf("NewServer() failed: %v", err)
+		}
+		return m, s
+	}
+
+	m, s := start()
+	version, err := s.Create(ctx, "/keyspaces/ks/Keyspace", []byte("a"))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	s.Close()
+	m.Close()
+
+	// The file is still there after a restart.
+	m, s = start()
+	defer m.Close()
+	defer s.Close()
+	contents, got, err := s.Get(ctx, "/keyspaces/ks/Keyspace")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if string(contents) != "a" || got.String() != version.String() {
+		t.Errorf("Get() returned %q, %v, want %q, %v", contents, got, "a", version)
+	}
+}
+
+func TestRaft2TopoTLS(t *testing.T) {
+	ctx := context.Background()
+	root, err := ioutil.TempDir("", "raft2topo")
+	if err != nil {
+		t.Fatalf("cannot create tempdir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	tlstest.CreateCA(root)
+	tlstest.CreateSignedCert(root, tlstest.CA, "01", "member", "localhost")
+	tlstest.CreateSignedCert(root, tlstest.CA, "02", "client", "localhost")
+	ca := path.Join(root, "ca-cert.pem")
+
+	config := &MemberConfig{
+		APIAddr:  "localhost:0",
+		CertPath: path.Join(root, "member-cert.pem"),
+		KeyPath:  path.Join(root, "member-key.pem"),
+		CAPath:   ca,
+	}
+	if _, err := NewMember(&MemberConfig{CertPath: config.CertPath}); err == nil {
+		t.Errorf("NewMember() without a key and a CA succeeded")
+	}
+	_, config.Transport = raft.NewInmemTransport("member")
+	m, err := NewMember(config)
+	if err != nil {
+		t.Fatalf("NewMember() failed: %v", err)
+	}
+	defer m.Close()
+	waitForLeader(t, []*Member{m})
+	_, port, _ := net.SplitHostPort(m.APIAddr())
+	addr := net.JoinHostPort("localhost", port)
+
+	// A client with a cert signed by the CA is served.
+	s, err := NewServerWithOpts(addr, "/tls", path.Join(root, "client-cert.pem"), path.Join(root, "client-key.pem"), ca)
+	if err != nil {
+		t.Fatalf("NewServerWithOpts() failed: %v", err)
+	}
+	defer s.Close()
+	if _, err := s.Create(ctx, "/file", []byte("a")); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	// A client without a cert is not.
+	oldTimeout := *retryTimeout
+	*retryTimeout = 0
+	defer func() {
+		*retryTimeout = oldTimeout
+	}()
+	s2, err := NewServerWithOpts(addr, "/tls", "", "", "")
+	if err != nil {
+		t.Fatalf("NewServerWithOpts() failed: %v", err)
+	}
+	defer s2.Close()
+	if _, _, err := s2.Get(ctx, "/file"); err == nil {
+		t.Errorf("Get() without a client cert succeeded")
+	}
+
+	// The members connect to each other with their certs.
+	server, client, err := memberTLSConfigs(config)
+	if err != nil {
+		t.Fatalf("memberTLSConfigs() failed: %v", err)
+	}
+	listener, err := tls.Listen("tcp", "localhost:0", server)
+	if err != nil {
+		t.Fatalf("Listen() failed: %v", err)
+	}
+	stream := &tlsStreamLayer{Listener: listener, advertise: listener.Addr(), client: client}
+	defer stream.Close()
+	go func() {
+		if conn, err := stream.Accept(); err == nil {
+			conn.Write([]byte("x"))
+			conn.Close()
+		}
+	}()
+	_, port, _ = net.SplitHostPort(listener.Addr().String())
+	conn, err := stream.Dial(net.JoinHostPort("localhost", port), time.Second)
+	if err != nil {
+		t.Fatalf("Dial() failed: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		t.Errorf("Read() failed: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"vitess.io/vitess/go/vt/orchestrator/external/raft"
+	"vitess.io/vitess/go/vt/vttls"
+)
+
+// memberTLSConfigs returns the TLS configs of the API and the raft
+// transport of a member, or nil if it doesn't use TLS. The CA verifies
+// the certs of both the other members and the clients, which must all
+// present one.
+func memberTLSConfigs(config *MemberConfig) (server, client *tls.Config, err error) {
+	if config.CertPath == "" && config.KeyPath == "" && config.CAPath == "" {
+		return nil, nil, nil
+	}
+	if config.CertPath == "" || config.KeyPath == "" || config.CAPath == "" {
+		return nil, nil, fmt.Errorf("the cert, the key and the CA of a raft2 topo member must all be set to use TLS")
+	}
+	if server, err = vttls.ServerConfig(config.CertPath, config.KeyPath, config.CAPath); err != nil {
+		return nil, nil, err
+	}
+	if client, err = vttls.ClientConfig(config.CertPath, config.KeyPath, config.CAPath, ""); err != nil {
+		return nil, nil, err
+	}
+	return server, client, nil
+}
+
+// tlsStreamLayer is a raft.StreamLayer which connects the members with
+// mutual TLS.
+type tlsStreamLayer struct {
+	net.Listener
+	advertise net.Addr
+	client    *tls.Config
+}
+
+// newTLSTransport returns a raft transport over TCP connections
+// secured with TLS.
+func newTLSTransport(bindAddr string, server, client *tls.Config) (*raft.NetworkTransport, error) {
+	advertise, err := net.ResolveTCPAddr("tcp", bindAddr)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := tls.Listen("tcp", bindAddr, server)
+	if err != nil {
+		return nil, err
+	}
+	stream := &tlsStreamLayer{
+		Listener:  listener,
+		advertise: advertise,
+		client:    client,
+	}
+	return raft.NewNetworkTransport(stream, 3, raftTimeout, os.Stderr), nil
+}
+
+// Dial is part of the raft.StreamLayer interface.
+func (l *tlsStreamLayer) Dial(address string, timeout time.Duration) (net.Conn, error) {
+	return tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", address, l.client)
+}
+
+// Addr is part of the net.Listener interface. It returns the address
+// the other members connect to.
+func (l *tlsStreamLayer) Addr() net.Addr {
+	return l.advertise
+}
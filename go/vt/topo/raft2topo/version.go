@@ -0,0 +1,40 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// RaftVersion is the version of a file: the index of the entry of the
+// raft log which last changed it.
+type RaftVersion uint64
+
+// String is part of the topo.Version interface.
+func (v RaftVersion) String() string {
+	return fmt.Sprintf("%v", uint64(v))
+}
+
+// versionIndex returns the raft index of a version, or 0 if it is nil.
+func versionIndex(version topo.Version) uint64 {
+	if version == nil {
+		return 0
+	}
+	return uint64(version.(RaftVersion))
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft2topo
+
+import (
+	"encoding/json"
+	"io"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// watchStream is the stream of the contents of a watched file.
+type watchStream struct {
+	body io.ReadCloser
+	dec  *json.Decoder
+}
+
+// Watch is part of the topo.Conn interface. The watch reconnects to
+// another member if its member goes away.
+func (s *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData, <-chan *topo.WatchData, topo.CancelFunc) {
+	// The stream outlives ctx, which is only used for the setup.
+	watchCtx, cancel := context.WithCancel(context.Background())
+	setupDone := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-setupDone:
+		}
+	}()
+	stream, first, err := s.startWatch(watchCtx, filePath)
+	close(setupDone)
+	if err == nil {
+		err = first.err(filePath)
+	}
+	if err != nil {
+		if stream != nil {
+			stream.body.Close()
+		}
+		cancel()
+		return &topo.WatchData{Err: err}, nil, nil
+	}
+
+	current := watchData(first)
+	changes := make(chan *topo.WatchData, 10)
+	go s.watchLoop(watchCtx, filePath, stream, first.Version, changes)
+	return current, changes, topo.CancelFunc(cancel)
+}
+
+// startWatch opens a stream on a member, and returns its first response.
+func (s *Server) startWatch(ctx context.Context, filePath string) (*watchStream, *response, error) {
+	var stream *watchStream
+	var first *response
+	err := s.tryMembers(ctx, filePath, func(addr string) error {
+		httpResp, err := s.post(ctx, addr, &request{Op: opWatch, Path: s.fullPath(filePath)})
+		if err != nil {
+			return err
+		}
+		ws := &watchStream{
+			body: httpResp.Body,
+			dec:  json.NewDecoder(httpResp.Body),
+		}
+		resp := &response{}
+		if err := ws.dec.Decode(resp); err != nil {
+			ws.body.Close()
+			return err
+		}
+		if resp.NotLeader {
+			ws.body.Close()
+			return errNotLeader
+		}
+		stream, first = ws, resp
+		return nil
+	})
+	return stream, first, err
+}
+
+// watchLoop sends the changes of the stream, and reconnects it until the
+// watch is canceled, the file is deleted, or no member is available.
+func (s *Server) watchLoop(ctx context.Context, filePath string, stream *watchStream, version uint64, changes chan<- *topo.WatchData) {
+	defer close(changes)
+	for {
+		resp := &response{}
+		err := stream.dec.Decode(resp)
+		if err != nil {
+			stream.body.Close()
+			if ctx.Err() != nil {
+				changes <- &topo.WatchData{Err: topo.NewError(topo.Interrupted, filePath)}
+				return
+			}
+			log.Infof("raft2topo: watch of %v interrupted, reconnecting: %v", filePath, err)
+			if stream, resp, err = s.startWatch(ctx, filePath); err != nil {
+				changes <- &topo.WatchData{Err: err}
+				return
+			}
+		}
+		if err := resp.err(filePath); err != nil {
+			stream.body.Close()
+			changes <- &topo.WatchData{Err: err}
+			return
+		}
+		if resp.Version != version {
+			version = resp.Version
+			changes <- watchData(resp)
+		}
+	}
+}
+
+func watchData(resp *response) *topo.WatchData {
+	if resp.Contents == nil {
+		resp.Contents = []byte{}
+	}
+	return &topo.WatchData{
+		Contents: resp.Contents,
+		Version:  RaftVersion(resp.Version),
+	}
+}
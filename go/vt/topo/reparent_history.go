@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"flag"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// ReparentHistorySize is the number of reparent records kept per shard.
+var ReparentHistorySize = flag.Int("reparent_history_size", 100, "number of reparent records kept in the topology for each shard")
+
+func reparentHistoryFilePath(keyspace, shard string) string {
+	return path.Join(KeyspacesPath, keyspace, ShardsPath, shard, ReparentHistoryFile)
+}
+
+// AddReparentRecord appends a record to the reparent history of a
+// shard, dropping the oldest records beyond ReparentHistorySize.
+func (ts *Server) AddReparentRecord(ctx context.Context, keyspace, shard string, record *topodatapb.ReparentRecord) error {
+	nodePath := reparentHistoryFilePath(keyspace, shard)
+	for {
+		data, version, err := ts.globalCell.Get(ctx, nodePath)
+		history := &topodatapb.ReparentHistory{}
+		switch {
+		case IsErrType(err, NoNode):
+			// Empty node, version is nil
+		case err == nil:
+			if err = proto.Unmarshal(data, history); err != nil {
+				return vterrors.Wrap(err, "bad ReparentHistory data")
+			}
+		default:
+			return err
+		}
+
+		history.Records = append(history.Records, record)
+		if n := len(history.Records) - *ReparentHistorySize; n > 0 && *ReparentHistorySize > 0 {
+			history.Records = history.Records[n:]
+		}
+		data, err = proto.Marshal(history)
+		if err != nil {
+			return err
+		}
+
+		if version == nil {
+			_, err = ts.globalCell.Create(ctx, nodePath, data)
+			if IsErrType(err, NodeExists) {
+				// Node was created by another process, try again.
+				continue
+			}
+			return err
+		}
+		_, err = ts.globalCell.Update(ctx, nodePath, data, version)
+		if IsErrType(err, BadVersion) {
+			// Node was updated by another process, try again.
+			continue
+		}
+		return err
+	}
+}
+
+// GetReparentHistory returns the reparent history of a shard, oldest
+// record first. It is empty if the shard was never reparented.
+func (ts *Server) GetReparentHistory(ctx context.Context, keyspace, shard string) (*topodatapb.ReparentHistory, error) {
+	data, _, err := ts.globalCell.Get(ctx, reparentHistoryFilePath(keyspace, shard))
+	history := &topodatapb.ReparentHistory{}
+	switch {
+	case IsErrType(err, NoNode):
+		return history, nil
+	case err != nil:
+		return nil, err
+	}
+	if err := proto.Unmarshal(data, history); err != nil {
+		return nil, vterrors.Wrap(err, "bad ReparentHistory data")
+	}
+	return history, nil
+}
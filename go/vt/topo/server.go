@@ -75,6 +75,7 @@ const (
 	SrvVSchemaFile       = "SrvVSchema"
 	SrvKeyspaceFile      = "SrvKeyspace"
 	RoutingRulesFile     = "RoutingRules"
+	ReparentHistoryFile  = "ReparentHistory"
 )
 
 // Path for all object types.
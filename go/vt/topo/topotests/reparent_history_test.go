@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotests
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains tests for the reparent_history.go file.
+
+func TestReparentHistory(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	if err := ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	if err := ts.CreateShard(ctx, "ks", "0"); err != nil {
+		t.Fatalf("CreateShard failed: %v", err)
+	}
+
+	history, err := ts.GetReparentHistory(ctx, "ks", "0")
+	if err != nil || len(history.Records) != 0 {
+		t.Fatalf("GetReparentHistory on a new shard = %v, %v, want empty", history, err)
+	}
+
+	oldSize := *topo.ReparentHistorySize
+	*topo.ReparentHistorySize = 3
+	defer func() {
+		*topo.ReparentHistorySize = oldSize
+	}()
+	for i := 0; i < 5; i++ {
+		if err := ts.AddReparentRecord(ctx, "ks", "0", &topodatapb.ReparentRecord{
+			Operation: "PlannedReparentShard",
+			Reason:    fmt.Sprintf("reparent %v", i),
+		}); err != nil {
+			t.Fatalf("AddReparentRecord failed: %v", err)
+		}
+	}
+	history, err = ts.GetReparentHistory(ctx, "ks", "0")
+	if err != nil {
+		t.Fatalf("GetReparentHistory failed: %v", err)
+	}
+	var got []string
+	for _, r := range history.Records {
+		got = append(got, r.Reason)
+	}
+	if want := "[reparent 2 reparent 3 reparent 4]"; fmt.Sprint(got) != want {
+		t.Errorf("GetReparentHistory returned %v, want %v", got, want)
+	}
+
+	// The history goes away with the shard.
+	if err := ts.DeleteShard(ctx, "ks", "0"); err != nil {
+		t.Fatalf("DeleteShard failed: %v", err)
+	}
+	if err := ts.CreateShard(ctx, "ks", "0"); err != nil {
+		t.Fatalf("CreateShard failed: %v", err)
+	}
+	history, err = ts.GetReparentHistory(ctx, "ks", "0")
+	if err != nil || len(history.Records) != 0 {
+		t.Errorf("GetReparentHistory on a recreated shard = %v, %v, want empty", history, err)
+	}
+}
@@ -32,4 +32,9 @@ type Reparent struct {
 	ShardInfo            topo.ShardInfo
 	OldMaster, NewMaster topodatapb.Tablet
 	ExternalID           string
+
+	// OldMasterPosition and NewMasterPosition are the replication
+	// positions of the old master when it was demoted, and of the new
+	// master when it was promoted, if known.
+	OldMasterPosition, NewMasterPosition string
 }
@@ -0,0 +1,744 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// This file contains the snapshots of the topology: versioned dumps of
+// the global and cell topology, stored in the backup storage, which can
+// be compared and restored.
+
+const (
+	// TopoSnapshotDir is the directory of the backup storage the
+	// snapshots are stored in.
+	TopoSnapshotDir = "topo_snapshots"
+
+	// topoSnapshotFile is the file of the backup which has the snapshot.
+	topoSnapshotFile = "topo"
+
+	// snapshotAttempts is how many times the topology is dumped before
+	// giving up on getting a consistent snapshot.
+	snapshotAttempts = 5
+
+	// diffContext is the number of unchanged lines shown around the
+	// changed lines of a diff.
+	diffContext = 3
+)
+
+// SnapshotKinds are the files of the topology which are in a snapshot.
+// The tablets and the replication graph are not: they are maintained by
+// the tablets themselves.
+var SnapshotKinds = []string{
+	topo.CellInfoFile,
+	topo.CellsAliasFile,
+	topo.KeyspaceFile,
+	topo.ShardFile,
+	topo.VSchemaFile,
+	topo.RoutingRulesFile,
+	topo.SrvKeyspaceFile,
+	topo.SrvVSchemaFile,
+}
+
+// TopoSnapshot is a consistent dump of the topology.
+type TopoSnapshot struct {
+	// Time is when the snapshot was taken.
+	Time time.Time `json:"time"`
+	// Cells has the files of each cell, by path. The global topology
+	// is in topo.GlobalCell.
+	Cells map[string]map[string]*SnapshotFile `json:"cells"`
+}
+
+// SnapshotFile is a file of a snapshot.
+type SnapshotFile struct {
+	Data []byte `json:"data"`
+	// Version is the version of the file in the topology.
+	Version string `json:"version"`
+}
+
+// Name returns the name of the snapshot in the backup storage.
+func (s *TopoSnapshot) Name() string {
+	return s.Time.UTC().Format("2006-01-02.150405")
+}
+
+// SnapshotFilter selects files of snapshots. An empty list selects
+// everything.
+type SnapshotFilter struct {
+	// Kinds are the names of the files, from SnapshotKinds.
+	Kinds []string
+	// Keyspaces restricts the files to the ones of these keyspaces.
+	Keyspaces []string
+	// Cells restricts the files to the ones of these cells.
+	// topo.GlobalCell selects the global topology.
+	Cells []string
+}
+
+// Validate returns an error if the filter has an unknown kind.
+func (f *SnapshotFilter) Validate() error {
+	for _, kind := range f.Kinds {
+		if !contains(SnapshotKinds, kind) {
+			return fmt.Errorf("unknown topo file kind %v, expected one of %v", kind, strings.Join(SnapshotKinds, ","))
+		}
+	}
+	return nil
+}
+
+// matches returns true if the filter selects a file.
+func (f *SnapshotFilter) matches(cell, filePath string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Kinds) > 0 && !contains(f.Kinds, path.Base(filePath)) {
+		return false
+	}
+	if len(f.Keyspaces) > 0 && !contains(f.Keyspaces, keyspaceOfPath(filePath)) {
+		return false
+	}
+	if len(f.Cells) > 0 && !contains(f.Cells, cell) {
+		return false
+	}
+	return true
+}
+
+// TakeTopoSnapshot dumps the global topology and the topology of the
+// cells, or of all the cells if cells is empty. The topology is dumped
+// until two dumps in a row have the same files and versions, so no change
+// happened in the middle of the returned one.
+func TakeTopoSnapshot(ctx context.Context, ts *topo.Server, cells []string) (*TopoSnapshot, error) {
+	previous, err := dumpTopo(ctx, ts, cells)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < snapshotAttempts; i++ {
+		snapshot, err := dumpTopo(ctx, ts, cells)
+		if err != nil {
+			return nil, err
+		}
+		if sameVersions(previous, snapshot) {
+			return snapshot, nil
+		}
+		previous = snapshot
+	}
+	return nil, fmt.Errorf("the topology kept changing during %v attempts to take a snapshot", snapshotAttempts)
+}
+
+// dumpTopo reads the files of a snapshot.
+func dumpTopo(ctx context.Context, ts *topo.Server, cells []string) (*TopoSnapshot, error) {
+	snapshot := &TopoSnapshot{
+		Time:  time.Now(),
+		Cells: make(map[string]map[string]*SnapshotFile),
+	}
+
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return nil, err
+	}
+	global := make(map[string]*SnapshotFile)
+	snapshot.Cells[topo.GlobalCell] = global
+	if err := readFile(ctx, conn, global, topo.RoutingRulesFile); err != nil {
+		return nil, err
+	}
+	cellNames, err := listDir(ctx, conn, topo.CellsPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, cell := range cellNames {
+		if err := readFile(ctx, conn, global, path.Join(topo.CellsPath, cell, topo.CellInfoFile)); err != nil {
+			return nil, err
+		}
+	}
+	aliases, err := listDir(ctx, conn, topo.CellsAliasesPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		if err := readFile(ctx, conn, global, path.Join(topo.CellsAliasesPath, alias, topo.CellsAliasFile)); err != nil {
+			return nil, err
+		}
+	}
+	keyspaces, err := listDir(ctx, conn, topo.KeyspacesPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, keyspace := range keyspaces {
+		keyspacePath := path.Join(topo.KeyspacesPath, keyspace)
+		for _, name := range []string{topo.KeyspaceFile, topo.VSchemaFile} {
+			if err := readFile(ctx, conn, global, path.Join(keyspacePath, name)); err != nil {
+				return nil, err
+			}
+		}
+		shards, err := listDir(ctx, conn, path.Join(keyspacePath, topo.ShardsPath))
+		if err != nil {
+			return nil, err
+		}
+		for _, shard := range shards {
+			if err := readFile(ctx, conn, global, path.Join(keyspacePath, topo.ShardsPath, shard, topo.ShardFile)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(cells) == 0 {
+		cells = cellNames
+	}
+	for _, cell := range cells {
+		conn, err := ts.ConnForCell(ctx, cell)
+		if err != nil {
+			return nil, err
+		}
+		files := make(map[string]*SnapshotFile)
+		snapshot.Cells[cell] = files
+		if err := readFile(ctx, conn, files, topo.SrvVSchemaFile); err != nil {
+			return nil, err
+		}
+		keyspaces, err := listDir(ctx, conn, topo.KeyspacesPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, keyspace := range keyspaces {
+			if err := readFile(ctx, conn, files, path.Join(topo.KeyspacesPath, keyspace, topo.SrvKeyspaceFile)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// listDir returns the names of the entries of a directory, or nothing if
+// it doesn't exist.
+func listDir(ctx context.Context, conn topo.Conn, dirPath string) ([]string, error) {
+	entries, err := conn.ListDir(ctx, dirPath, false /*full*/)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	return names, nil
+}
+
+// readFile adds a file to the files of a cell, if it exists.
+func readFile(ctx context.Context, conn topo.Conn, files map[string]*SnapshotFile, filePath string) error {
+	data, version, err := conn.Get(ctx, filePath)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		return nil
+	case err != nil:
+		return err
+	}
+	files[filePath] = &SnapshotFile{
+		Data:    data,
+		Version: version.String(),
+	}
+	return nil
+}
+
+// sameVersions returns true if two snapshots have the same files, with
+// the same versions.
+func sameVersions(a, b *TopoSnapshot) bool {
+	if len(a.Cells) != len(b.Cells) {
+		return false
+	}
+	for cell, aFiles := range a.Cells {
+		bFiles, ok := b.Cells[cell]
+		if !ok || len(aFiles) != len(bFiles) {
+			return false
+		}
+		for filePath, aFile := range aFiles {
+			bFile, ok := bFiles[filePath]
+			if !ok || aFile.Version != bFile.Version {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SaveTopoSnapshot stores a snapshot in the backup storage, and returns
+// its name.
+func SaveTopoSnapshot(ctx context.Context, bs backupstorage.BackupStorage, snapshot *TopoSnapshot) (string, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+	name := snapshot.Name()
+	bh, err := bs.StartBackup(ctx, TopoSnapshotDir, name)
+	if err != nil {
+		return "", err
+	}
+	w, err := bh.AddFile(ctx, topoSnapshotFile, int64(len(data)))
+	if err != nil {
+		bh.AbortBackup(ctx)
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		bh.AbortBackup(ctx)
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		bh.AbortBackup(ctx)
+		return "", err
+	}
+	if err := bh.EndBackup(ctx); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// ListTopoSnapshots returns the names of the snapshots in the backup
+// storage, oldest first.
+func ListTopoSnapshots(ctx context.Context, bs backupstorage.BackupStorage) ([]string, error) {
+	bhs, err := bs.ListBackups(ctx, TopoSnapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(bhs))
+	for _, bh := range bhs {
+		names = append(names, bh.Name())
+	}
+	return names, nil
+}
+
+// LoadTopoSnapshot reads a snapshot from the backup storage.
+func LoadTopoSnapshot(ctx context.Context, bs backupstorage.BackupStorage, name string) (*TopoSnapshot, error) {
+	bhs, err := bs.ListBackups(ctx, TopoSnapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, bh := range bhs {
+		if bh.Name() != name {
+			continue
+		}
+		r, err := bh.ReadFile(ctx, topoSnapshotFile)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		snapshot := &TopoSnapshot{}
+		if err := json.Unmarshal(data, snapshot); err != nil {
+			return nil, fmt.Errorf("cannot decode topo snapshot %v: %v", name, err)
+		}
+		return snapshot, nil
+	}
+	return nil, fmt.Errorf("no topo snapshot named %v", name)
+}
+
+// DiffTopoSnapshots returns a human-readable diff of the files selected
+// by the filter, from one snapshot to another. The proto files are
+// compared in text format. It returns an empty string if they are the
+// same.
+func DiffTopoSnapshots(from, to *TopoSnapshot, filter *SnapshotFilter) string {
+	buf := &bytes.Buffer{}
+	for _, cell := range sortedCells(from, to) {
+		fromFiles := from.Cells[cell]
+		toFiles := to.Cells[cell]
+		var paths []string
+		for filePath := range fromFiles {
+			paths = append(paths, filePath)
+		}
+		for filePath := range toFiles {
+			if _, ok := fromFiles[filePath]; !ok {
+				paths = append(paths, filePath)
+			}
+		}
+		sort.Strings(paths)
+
+		for _, filePath := range paths {
+			if !filter.matches(cell, filePath) {
+				continue
+			}
+			fromFile := fromFiles[filePath]
+			toFile := toFiles[filePath]
+			switch {
+			case fromFile == nil:
+				fmt.Fprintf(buf, "%v:%v added (version %v)\n", cell, filePath, toFile.Version)
+				writeLineDiff(buf, nil, decodeLines(filePath, toFile.Data))
+			case toFile == nil:
+				fmt.Fprintf(buf, "%v:%v removed (version %v)\n", cell, filePath, fromFile.Version)
+				writeLineDiff(buf, decodeLines(filePath, fromFile.Data), nil)
+			case !bytes.Equal(fromFile.Data, toFile.Data):
+				fmt.Fprintf(buf, "%v:%v changed (version %v -> %v)\n", cell, filePath, fromFile.Version, toFile.Version)
+				writeLineDiff(buf, decodeLines(filePath, fromFile.Data), decodeLines(filePath, toFile.Data))
+			}
+		}
+	}
+	return buf.String()
+}
+
+// sortedCells returns the cells of two snapshots, the global cell first.
+func sortedCells(a, b *TopoSnapshot) []string {
+	var cells []string
+	for cell := range a.Cells {
+		if cell != topo.GlobalCell {
+			cells = append(cells, cell)
+		}
+	}
+	for cell := range b.Cells {
+		if _, ok := a.Cells[cell]; !ok && cell != topo.GlobalCell {
+			cells = append(cells, cell)
+		}
+	}
+	sort.Strings(cells)
+	return append([]string{topo.GlobalCell}, cells...)
+}
+
+// decodeLines returns the lines of the text format of a topo file.
+func decodeLines(filePath string, data []byte) []string {
+	text := string(data)
+	if p := newTopoMessage(path.Base(filePath)); p != nil {
+		if err := proto.Unmarshal(data, p); err == nil {
+			text = proto.MarshalTextString(p)
+		}
+	}
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// newTopoMessage returns the proto of a topo file, or nil if it is
+// unknown.
+func newTopoMessage(name string) proto.Message {
+	switch name {
+	case topo.CellInfoFile:
+		return new(topodatapb.CellInfo)
+	case topo.CellsAliasFile:
+		return new(topodatapb.CellsAlias)
+	case topo.KeyspaceFile:
+		return new(topodatapb.Keyspace)
+	case topo.ShardFile:
+		return new(topodatapb.Shard)
+	case topo.VSchemaFile:
+		return new(vschemapb.Keyspace)
+	case topo.RoutingRulesFile:
+		return new(vschemapb.RoutingRules)
+	case topo.SrvKeyspaceFile:
+		return new(topodatapb.SrvKeyspace)
+	case topo.SrvVSchemaFile:
+		return new(vschemapb.SrvVSchema)
+	}
+	return nil
+}
+
+// writeLineDiff writes the diff of two texts, with the lines of the
+// longest common subsequence unchanged. Only the unchanged lines close
+// to a change are written.
+func writeLineDiff(buf *bytes.Buffer, a, b []string) {
+	// lcs[i][j] is the length of the longest common subsequence
+	// of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	var changed []bool
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			changed = append(changed, false)
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+a[i])
+			changed = append(changed, true)
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			changed = append(changed, true)
+			j++
+		}
+	}
+
+	// Show the lines within diffContext of a change.
+	show := make([]bool, len(lines))
+	for k, c := range changed {
+		if !c {
+			continue
+		}
+		for l := k - diffContext; l <= k+diffContext; l++ {
+			if l >= 0 && l < len(lines) {
+				show[l] = true
+			}
+		}
+	}
+	skipped := false
+	for k, line := range lines {
+		if !show[k] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			buf.WriteString("  ...\n")
+			skipped = false
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	if skipped {
+		buf.WriteString("  ...\n")
+	}
+}
+
+// RestoreTopoSnapshot writes back the files of a snapshot selected by the
+// filter which are different in the topology. The files which are not
+// in the snapshot are left alone. The files of a keyspace are restored
+// with the keyspace locked, and the shard records with their shard locked
+// too. A file which changed since it was read is not overwritten. The
+// master of the shards is kept, unless restoreMasterAlias is set. If a
+// VSchema or the routing rules are restored, but no SrvVSchema is
+// selected, the SrvVSchemas are rebuilt. With dryRun, the changes are
+// only logged.
+func RestoreTopoSnapshot(ctx context.Context, log logutil.Logger, ts *topo.Server, snapshot *TopoSnapshot, filter *SnapshotFilter, restoreMasterAlias, dryRun bool) error {
+	// Find the files to restore, grouped by keyspace.
+	current, err := dumpTopo(ctx, ts, nil)
+	if err != nil {
+		return err
+	}
+	type change struct {
+		cell, filePath string
+		data           []byte
+		// version is the version of the file which was read, or
+		// empty if it doesn't exist.
+		version string
+	}
+	byKeyspace := make(map[string][]change)
+	srvVSchemaSelected := false
+	for _, cell := range sortedCells(snapshot, current) {
+		for filePath, file := range current.Cells[cell] {
+			if _, ok := snapshot.Cells[cell][filePath]; !ok && filter.matches(cell, filePath) {
+				log.Infof("%v:%v is not in the snapshot, leaving it (version %v)", cell, filePath, file.Version)
+			}
+		}
+		for filePath, file := range snapshot.Cells[cell] {
+			if !filter.matches(cell, filePath) {
+				continue
+			}
+			if path.Base(filePath) == topo.SrvVSchemaFile {
+				srvVSchemaSelected = true
+			}
+			c := change{cell: cell, filePath: filePath, data: file.Data}
+			if cur, ok := current.Cells[cell][filePath]; ok {
+				if !restoreMasterAlias {
+					if c.data, err = keepShardMaster(filePath, file.Data, cur.Data); err != nil {
+						return err
+					}
+				}
+				if bytes.Equal(cur.Data, c.data) {
+					continue
+				}
+				c.version = cur.Version
+			}
+			keyspace := keyspaceOfPath(filePath)
+			byKeyspace[keyspace] = append(byKeyspace[keyspace], c)
+		}
+	}
+
+	rebuildSrvVSchema := false
+	restoreFile := func(ctx context.Context, c change) error {
+		conn, err := ts.ConnForCell(ctx, c.cell)
+		if err != nil {
+			return err
+		}
+		// The file is read again with its lock held, and only
+		// written if it didn't change since.
+		data, version, err := conn.Get(ctx, c.filePath)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			if c.version != "" {
+				return fmt.Errorf("%v:%v was deleted during the restore", c.cell, c.filePath)
+			}
+			_, err = conn.Create(ctx, c.filePath, c.data)
+		case err != nil:
+			return err
+		case version.String() != c.version:
+			return fmt.Errorf("%v:%v changed during the restore", c.cell, c.filePath)
+		case bytes.Equal(data, c.data):
+			return nil
+		default:
+			_, err = conn.Update(ctx, c.filePath, c.data, version)
+		}
+		return err
+	}
+	restore := func(ctx context.Context, changes []change) error {
+		sort.Slice(changes, func(i, j int) bool {
+			if changes[i].cell != changes[j].cell {
+				return changes[i].cell < changes[j].cell
+			}
+			return changes[i].filePath < changes[j].filePath
+		})
+		for _, c := range changes {
+			if dryRun {
+				log.Printf("would restore %v:%v\n", c.cell, c.filePath)
+				continue
+			}
+			var err error
+			if keyspace, shard := shardOfPath(c.filePath); shard != "" {
+				err = restoreShard(ctx, ts, keyspace, shard, func(ctx context.Context) error {
+					return restoreFile(ctx, c)
+				})
+			} else {
+				err = restoreFile(ctx, c)
+			}
+			if err != nil {
+				return fmt.Errorf("cannot restore %v:%v: %v", c.cell, c.filePath, err)
+			}
+			log.Infof("restored %v:%v", c.cell, c.filePath)
+			switch path.Base(c.filePath) {
+			case topo.VSchemaFile, topo.RoutingRulesFile:
+				rebuildSrvVSchema = true
+			}
+		}
+		return nil
+	}
+
+	keyspaces := make([]string, 0, len(byKeyspace))
+	for keyspace := range byKeyspace {
+		keyspaces = append(keyspaces, keyspace)
+	}
+	sort.Strings(keyspaces)
+	for _, keyspace := range keyspaces {
+		if _, ok := current.Cells[topo.GlobalCell][path.Join(topo.KeyspacesPath, keyspace, topo.KeyspaceFile)]; keyspace == "" || !ok {
+			// There is no keyspace to lock.
+			if err := restore(ctx, byKeyspace[keyspace]); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := restoreKeyspace(ctx, ts, keyspace, func(ctx context.Context) error {
+			return restore(ctx, byKeyspace[keyspace])
+		}); err != nil {
+			return err
+		}
+	}
+
+	if rebuildSrvVSchema && !srvVSchemaSelected {
+		log.Infof("rebuilding the SrvVSchemas")
+		return ts.RebuildSrvVSchema(ctx, nil)
+	}
+	return nil
+}
+
+// restoreKeyspace runs f with the keyspace locked.
+func restoreKeyspace(ctx context.Context, ts *topo.Server, keyspace string, f func(ctx context.Context) error) (err error) {
+	ctx, unlock, lockErr := ts.LockKeyspace(ctx, keyspace, "TopoRestore")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+	return f(ctx)
+}
+
+// restoreShard runs f with the shard locked.
+func restoreShard(ctx context.Context, ts *topo.Server, keyspace, shard string, f func(ctx context.Context) error) (err error) {
+	ctx, unlock, lockErr := ts.LockShard(ctx, keyspace, shard, "TopoRestore")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+	return f(ctx)
+}
+
+// keepShardMaster returns the data of a shard record of a snapshot with
+// the master of the current record, so that a restore doesn't change
+// the master. The data of the other files is returned as is.
+func keepShardMaster(filePath string, data, current []byte) ([]byte, error) {
+	if _, shard := shardOfPath(filePath); shard == "" {
+		return data, nil
+	}
+	si := &topodatapb.Shard{}
+	if err := proto.Unmarshal(data, si); err != nil {
+		return nil, fmt.Errorf("cannot decode %v: %v", filePath, err)
+	}
+	cur := &topodatapb.Shard{}
+	if err := proto.Unmarshal(current, cur); err != nil {
+		return nil, fmt.Errorf("cannot decode %v: %v", filePath, err)
+	}
+	si.MasterAlias = cur.MasterAlias
+	si.MasterTermStartTime = cur.MasterTermStartTime
+	return proto.Marshal(si)
+}
+
+// shardOfPath returns the keyspace and the shard of the path of a shard
+// record, or empty strings for the other files.
+func shardOfPath(filePath string) (string, string) {
+	parts := strings.Split(filePath, "/")
+	if len(parts) == 5 && parts[0] == topo.KeyspacesPath && parts[2] == topo.ShardsPath && parts[4] == topo.ShardFile {
+		return parts[1], parts[3]
+	}
+	return "", ""
+}
+
+// keyspaceOfPath returns the keyspace of the path of a topo file, or an
+// empty string if it doesn't belong to a keyspace.
+func keyspaceOfPath(filePath string) string {
+	parts := strings.Split(filePath, "/")
+	if len(parts) > 2 && parts[0] == topo.KeyspacesPath {
+		return parts[1]
+	}
+	return ""
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/filebackupstorage"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestTopoSnapshot(t *testing.T) {
+	ctx := context.Background()
+	root, err := ioutil.TempDir("", "toposnapshot")
+	if err != nil {
+		t.Fatalf("cannot create tempdir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	oldRoot := *filebackupstorage.FileBackupStorageRoot
+	*filebackupstorage.FileBackupStorageRoot = root
+	defer func() {
+		*filebackupstorage.FileBackupStorageRoot = oldRoot
+	}()
+	bs := &filebackupstorage.FileBackupStorage{}
+
+	ts := memorytopo.NewServer("cell1", "cell2")
+	goodVSchema := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash": {Type: "hash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}}},
+		},
+	}
+	if err := ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	if err := ts.CreateShard(ctx, "ks", "-80"); err != nil {
+		t.Fatalf("CreateShard failed: %v", err)
+	}
+	if err := ts.SaveVSchema(ctx, "ks", goodVSchema); err != nil {
+		t.Fatalf("SaveVSchema failed: %v", err)
+	}
+	if err := ts.SaveRoutingRules(ctx, &vschemapb.RoutingRules{
+		Rules: []*vschemapb.RoutingRule{{FromTable: "t2", ToTables: []string{"ks.t1"}}},
+	}); err != nil {
+		t.Fatalf("SaveRoutingRules failed: %v", err)
+	}
+	if err := ts.RebuildSrvVSchema(ctx, nil); err != nil {
+		t.Fatalf("RebuildSrvVSchema failed: %v", err)
+	}
+
+	snapshot, err := TakeTopoSnapshot(ctx, ts, nil)
+	if err != nil {
+		t.Fatalf("TakeTopoSnapshot failed: %v", err)
+	}
+	for _, want := range []struct{ cell, path string }{
+		{topo.GlobalCell, "RoutingRules"},
+		{topo.GlobalCell, "cells/cell1/CellInfo"},
+		{topo.GlobalCell, "keyspaces/ks/Keyspace"},
+		{topo.GlobalCell, "keyspaces/ks/VSchema"},
+		{topo.GlobalCell, "keyspaces/ks/shards/-80/Shard"},
+		{"cell1", "SrvVSchema"},
+		{"cell2", "SrvVSchema"},
+	} {
+		if _, ok := snapshot.Cells[want.cell][want.path]; !ok {
+			t.Errorf("snapshot is missing %v:%v", want.cell, want.path)
+		}
+	}
+
+	name, err := SaveTopoSnapshot(ctx, bs, snapshot)
+	if err != nil {
+		t.Fatalf("SaveTopoSnapshot failed: %v", err)
+	}
+	names, err := ListTopoSnapshots(ctx, bs)
+	if err != nil || len(names) != 1 || names[0] != name {
+		t.Fatalf("ListTopoSnapshots returned %v, %v, want [%v]", names, err, name)
+	}
+	loaded, err := LoadTopoSnapshot(ctx, bs, name)
+	if err != nil {
+		t.Fatalf("LoadTopoSnapshot failed: %v", err)
+	}
+	if !loaded.Time.Equal(snapshot.Time) || !sameVersions(loaded, snapshot) {
+		t.Errorf("LoadTopoSnapshot returned a different snapshot")
+	}
+
+	// Apply a bad VSchema.
+	if err := ts.SaveVSchema(ctx, "ks", &vschemapb.Keyspace{Sharded: true}); err != nil {
+		t.Fatalf("SaveVSchema failed: %v", err)
+	}
+	if err := ts.RebuildSrvVSchema(ctx, nil); err != nil {
+		t.Fatalf("RebuildSrvVSchema failed: %v", err)
+	}
+	current, err := TakeTopoSnapshot(ctx, ts, nil)
+	if err != nil {
+		t.Fatalf("TakeTopoSnapshot failed: %v", err)
+	}
+	diff := DiffTopoSnapshots(loaded, current, &SnapshotFilter{Cells: []string{topo.GlobalCell}})
+	if !strings.HasPrefix(diff, "global:keyspaces/ks/VSchema changed") || !strings.Contains(diff, "- vindexes: <") {
+		t.Errorf("unexpected diff:\n%v", diff)
+	}
+	if strings.Contains(diff, "SrvVSchema") {
+		t.Errorf("diff has a cell file:\n%v", diff)
+	}
+	if diff := DiffTopoSnapshots(loaded, loaded, nil); diff != "" {
+		t.Errorf("diff of a snapshot with itself:\n%v", diff)
+	}
+
+	// A dry run doesn't change anything.
+	filter := &SnapshotFilter{Kinds: []string{topo.VSchemaFile}}
+	logger := logutil.NewMemoryLogger()
+	if err := RestoreTopoSnapshot(ctx, logger, ts, loaded, filter, false, true); err != nil {
+		t.Fatalf("RestoreTopoSnapshot(dry run) failed: %v", err)
+	}
+	if !strings.Contains(logger.String(), "would restore global:keyspaces/ks/VSchema") {
+		t.Errorf("unexpected dry run log:\n%v", logger.String())
+	}
+	vschema, err := ts.GetVSchema(ctx, "ks")
+	if err != nil || len(vschema.Vindexes) != 0 {
+		t.Errorf("dry run changed the VSchema: %v, %v", vschema, err)
+	}
+
+	// Restore the VSchema, the SrvVSchemas are rebuilt.
+	if err := RestoreTopoSnapshot(ctx, logutil.NewMemoryLogger(), ts, loaded, filter, false, false); err != nil {
+		t.Fatalf("RestoreTopoSnapshot failed: %v", err)
+	}
+	vschema, err = ts.GetVSchema(ctx, "ks")
+	if err != nil || !proto.Equal(vschema, goodVSchema) {
+		t.Errorf("VSchema after restore = %v, %v, want %v", vschema, err, goodVSchema)
+	}
+	srvVSchema, err := ts.GetSrvVSchema(ctx, "cell2")
+	if err != nil || !proto.Equal(srvVSchema.Keyspaces["ks"], goodVSchema) {
+		t.Errorf("SrvVSchema after restore = %v, %v", srvVSchema, err)
+	}
+	restored, err := TakeTopoSnapshot(ctx, ts, nil)
+	if err != nil {
+		t.Fatalf("TakeTopoSnapshot failed: %v", err)
+	}
+	if diff := DiffTopoSnapshots(loaded, restored, nil); diff != "" {
+		t.Errorf("topology differs from the snapshot after restore:\n%v", diff)
+	}
+
+	// The master of a shard is kept, unless it is restored too.
+	master := &topodatapb.TabletAlias{Cell: "cell1", Uid: 100}
+	if _, err := ts.UpdateShardFields(ctx, "ks", "-80", func(si *topo.ShardInfo) error {
+		si.MasterAlias = master
+		si.IsMasterServing = false
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateShardFields failed: %v", err)
+	}
+	filter = &SnapshotFilter{Kinds: []string{topo.ShardFile}}
+	if err := RestoreTopoSnapshot(ctx, logutil.NewMemoryLogger(), ts, loaded, filter, false, false); err != nil {
+		t.Fatalf("RestoreTopoSnapshot failed: %v", err)
+	}
+	si, err := ts.GetShard(ctx, "ks", "-80")
+	if err != nil || !proto.Equal(si.MasterAlias, master) || !si.IsMasterServing {
+		t.Errorf("shard after restore = %v, %v, want the master %v and a serving master", si, err, master)
+	}
+	if err := RestoreTopoSnapshot(ctx, logutil.NewMemoryLogger(), ts, loaded, filter, true, false); err != nil {
+		t.Fatalf("RestoreTopoSnapshot(restoreMasterAlias) failed: %v", err)
+	}
+	si, err = ts.GetShard(ctx, "ks", "-80")
+	if err != nil || si.MasterAlias != nil {
+		t.Errorf("shard after restoring its master = %v, %v, want no master", si, err)
+	}
+}
+
+func TestWriteLineDiff(t *testing.T) {
+	a := strings.Split("a b c d e f g h i j k l m n o p", " ")
+	b := strings.Split("a b c d x f g h i j k l m n o p q", " ")
+	buf := &bytes.Buffer{}
+	writeLineDiff(buf, a, b)
+	want := "  ...\n  b\n  c\n  d\n- e\n+ x\n  f\n  g\n  h\n  ...\n  n\n  o\n  p\n+ q\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeLineDiff() =\n%v\nwant:\n%v", got, want)
+	}
+}
@@ -100,5 +100,30 @@ Vitess supports positional or named arguments. However, intermixing is not allow
 within a statement. If using named arguments, the ':' and '@' prefixes are optional.
 If they're specified, the driver will strip them off before sending the request over
 to VTGate.
+
+
+Column types
+
+The rows implement the column type interfaces of database/sql/driver, like
+RowsColumnTypeDatabaseTypeName and RowsColumnTypeNullable, from the metadata
+of the fields of the results. The nullability, length, precision and scale are
+only known for the columns of tables, and only if the ColumnTypes field of the
+Configuration is set, as vtgate only sends the types of the fields by default.
+
+
+Change streams
+
+OpenVStream opens a stream of the change events of shards from vtgate, given
+the same JSON string as sql.Open():
+
+  stream, err := vitessdriver.OpenVStream(ctx, dsn, topodatapb.TabletType_REPLICA, vgtid, filter)
+  if err != nil {
+    return err
+  }
+  defer stream.Close()
+  for {
+    events, err := stream.Recv()
+    // ...
+  }
 */
 package vitessdriver
@@ -26,6 +26,8 @@ import (
 	"google.golang.org/grpc"
 	"vitess.io/vitess/go/vt/vtgate/grpcvtgateconn"
 	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
 var (
@@ -35,10 +37,21 @@ var (
 
 // Type-check interfaces.
 var (
-	_ driver.QueryerContext   = &conn{}
-	_ driver.ExecerContext    = &conn{}
-	_ driver.StmtQueryContext = &stmt{}
-	_ driver.StmtExecContext  = &stmt{}
+	_ driver.QueryerContext    = &conn{}
+	_ driver.ExecerContext     = &conn{}
+	_ driver.SessionResetter   = &conn{}
+	_ driver.NamedValueChecker = &conn{}
+	_ driver.StmtQueryContext  = &stmt{}
+	_ driver.StmtExecContext   = &stmt{}
+
+	_ driver.RowsColumnTypeDatabaseTypeName = &rows{}
+	_ driver.RowsColumnTypeNullable         = &rows{}
+	_ driver.RowsColumnTypeLength           = &rows{}
+	_ driver.RowsColumnTypePrecisionScale   = &rows{}
+	_ driver.RowsColumnTypeDatabaseTypeName = &streamingRows{}
+	_ driver.RowsColumnTypeNullable         = &streamingRows{}
+	_ driver.RowsColumnTypeLength           = &streamingRows{}
+	_ driver.RowsColumnTypePrecisionScale   = &streamingRows{}
 )
 
 func init() {
@@ -148,6 +161,13 @@ type Configuration struct {
 	// Default: false
 	Streaming bool
 
+	// ColumnTypes is true when vtgate is asked for all the metadata
+	// of the fields of the results, like their nullability, length,
+	// precision and scale, for the column types of the rows. It makes
+	// the responses larger.
+	// Default: false
+	ColumnTypes bool
+
 	// DefaultLocation is the timezone string that will be used
 	// when converting DATETIME and DATE into time.Time.
 	// This setting has no effect if ConvertDatetime is not set.
@@ -204,7 +224,47 @@ func (c *conn) dial() error {
 	if err != nil {
 		return err
 	}
-	c.session = c.conn.Session(c.Target, nil)
+	c.session = c.newSession()
+	return nil
+}
+
+// newSession returns a new session, which asks for all the metadata of the
+// fields of the results if ColumnTypes is set.
+func (c *conn) newSession() *vtgateconn.VTGateSession {
+	if !c.ColumnTypes {
+		return c.conn.Session(c.Target, nil)
+	}
+	return c.conn.Session(c.Target, &querypb.ExecuteOptions{
+		IncludedFields: querypb.ExecuteOptions_ALL,
+	})
+}
+
+// ResetSession implements driver.SessionResetter. It starts a new session
+// before the connection is reused, so that the session variables and the
+// target set by its previous user are discarded. A transaction which was
+// left open is first rolled back through the outgoing session, which is
+// the only one that knows its shard sessions.
+func (c *conn) ResetSession(ctx context.Context) error {
+	outgoing := c.session
+	if pb := outgoing.SessionPb(); pb.InTransaction || len(pb.ShardSessions) > 0 {
+		if _, err := outgoing.Execute(ctx, "rollback", nil); err != nil {
+			return driver.ErrBadConn
+		}
+	}
+	c.session = c.newSession()
+	return nil
+}
+
+// CheckNamedValue implements driver.NamedValueChecker. It accepts all the
+// values bind variables can be built from, like uint64 values with the high
+// bit set and slices for tuples, which database/sql would reject.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	bv, err := c.convert.BuildBindVariable(nv.Value)
+	if err != nil {
+		// Let database/sql convert the value, e.g. a driver.Valuer.
+		return driver.ErrSkip
+	}
+	nv.Value = bv
 	return nil
 }
 
@@ -293,14 +353,14 @@ func (c *conn) Query(query string, args []driver.Value) (driver.Rows, error) {
 		if err != nil {
 			return nil, err
 		}
-		return newStreamingRows(stream, c.convert), nil
+		return newStreamingRows(stream, c.convert, c.ColumnTypes), nil
 	}
 
 	qr, err := c.session.Execute(ctx, query, bindVars)
 	if err != nil {
 		return nil, err
 	}
-	return newRows(qr, c.convert), nil
+	return newRows(qr, c.convert, c.ColumnTypes), nil
 }
 
 func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
@@ -314,14 +374,14 @@ func (c *conn) QueryContext(ctx context.Context, query string, args []driver.Nam
 		if err != nil {
 			return nil, err
 		}
-		return newStreamingRows(stream, c.convert), nil
+		return newStreamingRows(stream, c.convert, c.ColumnTypes), nil
 	}
 
 	qr, err := c.session.Execute(ctx, query, bv)
 	if err != nil {
 		return nil, err
 	}
-	return newRows(qr, c.convert), nil
+	return newRows(qr, c.convert, c.ColumnTypes), nil
 }
 
 type stmt struct {
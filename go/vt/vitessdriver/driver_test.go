@@ -28,6 +28,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stretchr/testify/require"
@@ -35,6 +36,7 @@ import (
 
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	"vitess.io/vitess/go/vt/vtgate/grpcvtgateservice"
 )
 
@@ -224,7 +226,7 @@ func TestConfigurationToJSON(t *testing.T) {
 		Streaming:       true,
 		DefaultLocation: "Local",
 	}
-	want := `{"Protocol":"some-invalid-protocol","Address":"","Target":"ks2","Streaming":true,"DefaultLocation":"Local"}`
+	want := `{"Protocol":"some-invalid-protocol","Address":"","Target":"ks2","Streaming":true,"ColumnTypes":false,"DefaultLocation":"Local"}`
 
 	json, err := config.toJSON()
 	if err != nil {
@@ -625,3 +627,103 @@ func TestTxExecStreamingNotAllowed(t *testing.T) {
 		t.Errorf("err: %v, does not contain %s", err, want)
 	}
 }
+
+func TestResetSession(t *testing.T) {
+	db, err := Open(testAddress, "@master")
+	require.NoError(t, err)
+	defer db.Close()
+	dbc, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer dbc.Close()
+
+	err = dbc.Raw(func(driverConn interface{}) error {
+		c := driverConn.(*conn)
+
+		// A transaction which was left open is rolled back.
+		if _, err := c.Exec("begin", nil); err != nil {
+			return err
+		}
+		if _, err := c.Exec("txRequest", []driver.Value{int64(0)}); err != nil {
+			return err
+		}
+		if err := c.ResetSession(context.Background()); err != nil {
+			return err
+		}
+		want := &vtgatepb.Session{
+			TargetString: "@master",
+			Autocommit:   true,
+		}
+		if got := c.session.SessionPb(); !proto.Equal(got, want) {
+			t.Errorf("session after ResetSession: %v, want %v", got, want)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestColumnTypesSession(t *testing.T) {
+	db, err := OpenWithConfiguration(Configuration{
+		Address:     testAddress,
+		Target:      "@master",
+		ColumnTypes: true,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	dbc, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer dbc.Close()
+
+	// The sessions ask for all the metadata of the fields, including
+	// the ones started by ResetSession.
+	err = dbc.Raw(func(driverConn interface{}) error {
+		c := driverConn.(*conn)
+		if got := c.session.SessionPb().Options; !proto.Equal(got, allFieldsOptions) {
+			t.Errorf("session options: %v, want %v", got, allFieldsOptions)
+		}
+		if err := c.ResetSession(context.Background()); err != nil {
+			return err
+		}
+		if got := c.session.SessionPb().Options; !proto.Equal(got, allFieldsOptions) {
+			t.Errorf("session options after ResetSession: %v, want %v", got, allFieldsOptions)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+type testValuer string
+
+func (v testValuer) Value() (driver.Value, error) {
+	return string(v), nil
+}
+
+func TestCheckNamedValue(t *testing.T) {
+	c := &conn{convert: &converter{location: time.UTC}}
+	for _, tcase := range []struct {
+		in   interface{}
+		want interface{}
+		err  error
+	}{{
+		in:   uint64(18446744073709551615),
+		want: sqltypes.Uint64BindVariable(18446744073709551615),
+	}, {
+		in: []int64{1, 2},
+		want: &querypb.BindVariable{
+			Type:   querypb.Type_TUPLE,
+			Values: []*querypb.Value{sqltypes.ValueToProto(sqltypes.NewInt64(1)), sqltypes.ValueToProto(sqltypes.NewInt64(2))},
+		},
+	}, {
+		in:  testValuer("a"),
+		err: driver.ErrSkip,
+	}} {
+		nv := &driver.NamedValue{Ordinal: 1, Value: tcase.in}
+		err := c.CheckNamedValue(nv)
+		if err != tcase.err {
+			t.Errorf("CheckNamedValue(%v): %v, want %v", tcase.in, err, tcase.err)
+			continue
+		}
+		if err == nil && !proto.Equal(nv.Value.(*querypb.BindVariable), tcase.want.(*querypb.BindVariable)) {
+			t.Errorf("CheckNamedValue(%v): %v, want %v", tcase.in, nv.Value, tcase.want)
+		}
+	}
+}
@@ -139,7 +139,16 @@ func (f *fakeVTGateService) ResolveTransaction(ctx context.Context, dtid string)
 	return nil
 }
 
+// VStream is part of the VTGateService interface
 func (f *fakeVTGateService) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, send func([]*binlogdatapb.VEvent) error) error {
+	if tabletType != topodatapb.TabletType_REPLICA {
+		return fmt.Errorf("VStream: unexpected tablet type %v", tabletType)
+	}
+	for _, events := range vstreamEvents {
+		if err := send(events); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -284,6 +293,10 @@ var result2 = sqltypes.Result{
 	},
 }
 
+var allFieldsOptions = &querypb.ExecuteOptions{
+	IncludedFields: querypb.ExecuteOptions_ALL,
+}
+
 var session1 = &vtgatepb.Session{
 	InTransaction: true,
 	TargetString:  "@rdonly",
@@ -305,3 +318,12 @@ var session2 = &vtgatepb.Session{
 }
 
 var dtid2 = "aa"
+
+var vstreamEvents = [][]*binlogdatapb.VEvent{{
+	{Type: binlogdatapb.VEventType_BEGIN},
+	{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "t1"}},
+	{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "0", Gtid: "pos1"}},
+	}},
+	{Type: binlogdatapb.VEventType_COMMIT},
+}}
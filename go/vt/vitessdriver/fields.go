@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vitessdriver
+
+import (
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// This file implements the column type methods of the rows from the
+// metadata of the fields. The driver asks vtgate for all the metadata of
+// the fields, but only the type is known for the fields which don't come
+// from a table.
+
+// databaseTypeNames maps the field types to the MySQL type names.
+var databaseTypeNames = map[querypb.Type]string{
+	sqltypes.Null:      "NULL",
+	sqltypes.Int8:      "TINYINT",
+	sqltypes.Uint8:     "UNSIGNED TINYINT",
+	sqltypes.Int16:     "SMALLINT",
+	sqltypes.Uint16:    "UNSIGNED SMALLINT",
+	sqltypes.Int24:     "MEDIUMINT",
+	sqltypes.Uint24:    "UNSIGNED MEDIUMINT",
+	sqltypes.Int32:     "INT",
+	sqltypes.Uint32:    "UNSIGNED INT",
+	sqltypes.Int64:     "BIGINT",
+	sqltypes.Uint64:    "UNSIGNED BIGINT",
+	sqltypes.Float32:   "FLOAT",
+	sqltypes.Float64:   "DOUBLE",
+	sqltypes.Timestamp: "TIMESTAMP",
+	sqltypes.Date:      "DATE",
+	sqltypes.Time:      "TIME",
+	sqltypes.Datetime:  "DATETIME",
+	sqltypes.Year:      "YEAR",
+	sqltypes.Decimal:   "DECIMAL",
+	sqltypes.Text:      "TEXT",
+	sqltypes.Blob:      "BLOB",
+	sqltypes.VarChar:   "VARCHAR",
+	sqltypes.VarBinary: "VARBINARY",
+	sqltypes.Char:      "CHAR",
+	sqltypes.Binary:    "BINARY",
+	sqltypes.Bit:       "BIT",
+	sqltypes.Enum:      "ENUM",
+	sqltypes.Set:       "SET",
+	sqltypes.Geometry:  "GEOMETRY",
+	sqltypes.TypeJSON:  "JSON",
+}
+
+// columnTypeDatabaseTypeName implements the
+// driver.RowsColumnTypeDatabaseTypeName interface for field.
+func columnTypeDatabaseTypeName(field *querypb.Field) string {
+	if name, ok := databaseTypeNames[field.Type]; ok {
+		return name
+	}
+	return field.Type.String()
+}
+
+// columnTypeNullable implements the driver.RowsColumnTypeNullable
+// interface for field. It's only known if vtgate was asked for all the
+// metadata of the fields with columnTypes.
+func columnTypeNullable(field *querypb.Field, columnTypes bool) (nullable, ok bool) {
+	if !columnTypes {
+		return false, false
+	}
+	return field.Flags&uint32(querypb.MySqlFlag_NOT_NULL_FLAG) == 0, true
+}
+
+// columnTypeLength implements the driver.RowsColumnTypeLength interface
+// for field. The length of the text types is in bytes. It's only known
+// if vtgate was asked for all the metadata of the fields with columnTypes.
+func columnTypeLength(field *querypb.Field, columnTypes bool) (length int64, ok bool) {
+	if !columnTypes {
+		return 0, false
+	}
+	switch field.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary, sqltypes.Text, sqltypes.Blob:
+		return int64(field.ColumnLength), true
+	}
+	return 0, false
+}
+
+// columnTypePrecisionScale implements the
+// driver.RowsColumnTypePrecisionScale interface for field. It's only
+// known if vtgate was asked for all the metadata of the fields with
+// columnTypes.
+func columnTypePrecisionScale(field *querypb.Field, columnTypes bool) (precision, scale int64, ok bool) {
+	if !columnTypes || field.Type != sqltypes.Decimal {
+		return 0, 0, false
+	}
+	// The length of a DECIMAL(M,D) column counts the decimal point and
+	// the sign.
+	precision = int64(field.ColumnLength)
+	if field.Decimals > 0 {
+		precision--
+	}
+	if field.Flags&uint32(querypb.MySqlFlag_UNSIGNED_FLAG) == 0 {
+		precision--
+	}
+	if precision < 0 {
+		precision = 0
+	}
+	return precision, int64(field.Decimals), true
+}
@@ -26,14 +26,16 @@ import (
 // rows creates a database/sql/driver compliant Row iterator
 // for a non-streaming QueryResult.
 type rows struct {
-	convert *converter
-	qr      *sqltypes.Result
-	index   int
+	convert     *converter
+	qr          *sqltypes.Result
+	index       int
+	columnTypes bool
 }
 
-// newRows creates a new rows from qr.
-func newRows(qr *sqltypes.Result, c *converter) driver.Rows {
-	return &rows{qr: qr, convert: c}
+// newRows creates a new rows from qr. columnTypes tells whether vtgate
+// was asked for all the metadata of the fields.
+func newRows(qr *sqltypes.Result, c *converter, columnTypes bool) driver.Rows {
+	return &rows{qr: qr, convert: c, columnTypes: columnTypes}
 }
 
 func (ri *rows) Columns() []string {
@@ -58,3 +60,19 @@ func (ri *rows) Next(dest []driver.Value) error {
 	ri.index++
 	return nil
 }
+
+func (ri *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return columnTypeDatabaseTypeName(ri.qr.Fields[index])
+}
+
+func (ri *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return columnTypeNullable(ri.qr.Fields[index], ri.columnTypes)
+}
+
+func (ri *rows) ColumnTypeLength(index int) (length int64, ok bool) {
+	return columnTypeLength(ri.qr.Fields[index], ri.columnTypes)
+}
+
+func (ri *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	return columnTypePrecisionScale(ri.qr.Fields[index], ri.columnTypes)
+}
@@ -85,7 +85,7 @@ func logMismatchedTypes(t *testing.T, gotRow, wantRow []driver.Value) {
 }
 
 func TestRows(t *testing.T) {
-	ri := newRows(&rowsResult1, &converter{})
+	ri := newRows(&rowsResult1, &converter{}, false)
 	wantCols := []string{
 		"field1",
 		"field2",
@@ -134,3 +134,86 @@ func TestRows(t *testing.T) {
 
 	_ = ri.Close()
 }
+
+var columnTypesFields = []*querypb.Field{
+	{
+		Name:         "id",
+		Type:         sqltypes.Uint64,
+		Table:        "t1",
+		ColumnLength: 20,
+		Flags:        uint32(querypb.MySqlFlag_NOT_NULL_FLAG | querypb.MySqlFlag_UNSIGNED_FLAG),
+	},
+	{
+		Name:         "name",
+		Type:         sqltypes.VarChar,
+		Table:        "t1",
+		ColumnLength: 256,
+	},
+	{
+		Name:         "price",
+		Type:         sqltypes.Decimal,
+		Table:        "t1",
+		ColumnLength: 12,
+		Decimals:     2,
+		Flags:        uint32(querypb.MySqlFlag_NOT_NULL_FLAG),
+	},
+	{
+		Name: "count(*)",
+		Type: sqltypes.Int64,
+	},
+}
+
+// testColumnTypes checks the column types of ri, which has the
+// columnTypesFields. Only the type names are known without columnTypes.
+func testColumnTypes(t *testing.T, ri driver.Rows, columnTypes bool) {
+	t.Helper()
+	require.Equal(t, []string{"id", "name", "price", "count(*)"}, ri.Columns())
+
+	typeNames := ri.(driver.RowsColumnTypeDatabaseTypeName)
+	nullables := ri.(driver.RowsColumnTypeNullable)
+	lengths := ri.(driver.RowsColumnTypeLength)
+	precisionScales := ri.(driver.RowsColumnTypePrecisionScale)
+	for i, want := range []struct {
+		typeName         string
+		nullable         bool
+		length           int64
+		lengthOK         bool
+		precision, scale int64
+		precisionScaleOK bool
+	}{
+		{typeName: "UNSIGNED BIGINT"},
+		{typeName: "VARCHAR", nullable: true, length: 256, lengthOK: true},
+		{typeName: "DECIMAL", precision: 10, scale: 2, precisionScaleOK: true},
+		{typeName: "BIGINT", nullable: true},
+	} {
+		if got := typeNames.ColumnTypeDatabaseTypeName(i); got != want.typeName {
+			t.Errorf("ColumnTypeDatabaseTypeName(%d): %v, want %v", i, got, want.typeName)
+		}
+		if !columnTypes {
+			if nullable, ok := nullables.ColumnTypeNullable(i); nullable || ok {
+				t.Errorf("ColumnTypeNullable(%d): %v, %v, want false, false", i, nullable, ok)
+			}
+			if length, ok := lengths.ColumnTypeLength(i); length != 0 || ok {
+				t.Errorf("ColumnTypeLength(%d): %v, %v, want 0, false", i, length, ok)
+			}
+			if precision, scale, ok := precisionScales.ColumnTypePrecisionScale(i); precision != 0 || scale != 0 || ok {
+				t.Errorf("ColumnTypePrecisionScale(%d): %v, %v, %v, want 0, 0, false", i, precision, scale, ok)
+			}
+			continue
+		}
+		if nullable, ok := nullables.ColumnTypeNullable(i); nullable != want.nullable || !ok {
+			t.Errorf("ColumnTypeNullable(%d): %v, %v, want %v, true", i, nullable, ok, want.nullable)
+		}
+		if length, ok := lengths.ColumnTypeLength(i); length != want.length || ok != want.lengthOK {
+			t.Errorf("ColumnTypeLength(%d): %v, %v, want %v, %v", i, length, ok, want.length, want.lengthOK)
+		}
+		if precision, scale, ok := precisionScales.ColumnTypePrecisionScale(i); precision != want.precision || scale != want.scale || ok != want.precisionScaleOK {
+			t.Errorf("ColumnTypePrecisionScale(%d): %v, %v, %v, want %v, %v, %v", i, precision, scale, ok, want.precision, want.scale, want.precisionScaleOK)
+		}
+	}
+}
+
+func TestRowsColumnTypes(t *testing.T) {
+	testColumnTypes(t, newRows(&sqltypes.Result{Fields: columnTypesFields}, &converter{}, true), true)
+	testColumnTypes(t, newRows(&sqltypes.Result{Fields: columnTypesFields}, &converter{}, false), false)
+}
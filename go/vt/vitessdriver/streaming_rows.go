@@ -28,19 +28,22 @@ import (
 // streamingRows creates a database/sql/driver compliant Row iterator
 // for a streaming query.
 type streamingRows struct {
-	stream  sqltypes.ResultStream
-	failed  error
-	fields  []*querypb.Field
-	qr      *sqltypes.Result
-	index   int
-	convert *converter
+	stream      sqltypes.ResultStream
+	failed      error
+	fields      []*querypb.Field
+	qr          *sqltypes.Result
+	index       int
+	convert     *converter
+	columnTypes bool
 }
 
-// newStreamingRows creates a new streamingRows from stream.
-func newStreamingRows(stream sqltypes.ResultStream, conv *converter) driver.Rows {
+// newStreamingRows creates a new streamingRows from stream. columnTypes
+// tells whether vtgate was asked for all the metadata of the fields.
+func newStreamingRows(stream sqltypes.ResultStream, conv *converter, columnTypes bool) driver.Rows {
 	return &streamingRows{
-		stream:  stream,
-		convert: conv,
+		stream:      stream,
+		convert:     conv,
+		columnTypes: columnTypes,
 	}
 }
 
@@ -87,6 +90,25 @@ func (ri *streamingRows) Next(dest []driver.Value) error {
 	return nil
 }
 
+// The column type methods are called after Columns, which fetched the
+// fields.
+
+func (ri *streamingRows) ColumnTypeDatabaseTypeName(index int) string {
+	return columnTypeDatabaseTypeName(ri.fields[index])
+}
+
+func (ri *streamingRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return columnTypeNullable(ri.fields[index], ri.columnTypes)
+}
+
+func (ri *streamingRows) ColumnTypeLength(index int) (length int64, ok bool) {
+	return columnTypeLength(ri.fields[index], ri.columnTypes)
+}
+
+func (ri *streamingRows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	return columnTypePrecisionScale(ri.fields[index], ri.columnTypes)
+}
+
 // checkFields fetches the first packet from the channel, which
 // should contain the field info.
 func (ri *streamingRows) checkFields() error {
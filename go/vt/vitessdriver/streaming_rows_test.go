@@ -85,7 +85,7 @@ func TestStreamingRows(t *testing.T) {
 	c <- &packet2
 	c <- &packet3
 	close(c)
-	ri := newStreamingRows(&adapter{c: c, err: io.EOF}, &converter{})
+	ri := newStreamingRows(&adapter{c: c, err: io.EOF}, &converter{}, false)
 	wantCols := []string{
 		"field1",
 		"field2",
@@ -133,7 +133,7 @@ func TestStreamingRowsReversed(t *testing.T) {
 	c <- &packet2
 	c <- &packet3
 	close(c)
-	ri := newStreamingRows(&adapter{c: c, err: io.EOF}, &converter{})
+	ri := newStreamingRows(&adapter{c: c, err: io.EOF}, &converter{}, false)
 	defer ri.Close()
 
 	wantRow := []driver.Value{
@@ -164,7 +164,7 @@ func TestStreamingRowsReversed(t *testing.T) {
 func TestStreamingRowsError(t *testing.T) {
 	c := make(chan *sqltypes.Result)
 	close(c)
-	ri := newStreamingRows(&adapter{c: c, err: errors.New("error before fields")}, &converter{})
+	ri := newStreamingRows(&adapter{c: c, err: errors.New("error before fields")}, &converter{}, false)
 
 	gotCols := ri.Columns()
 	if gotCols != nil {
@@ -181,7 +181,7 @@ func TestStreamingRowsError(t *testing.T) {
 	c = make(chan *sqltypes.Result, 1)
 	c <- &packet1
 	close(c)
-	ri = newStreamingRows(&adapter{c: c, err: errors.New("error after fields")}, &converter{})
+	ri = newStreamingRows(&adapter{c: c, err: errors.New("error after fields")}, &converter{}, false)
 	wantCols := []string{
 		"field1",
 		"field2",
@@ -208,7 +208,7 @@ func TestStreamingRowsError(t *testing.T) {
 	c <- &packet1
 	c <- &packet2
 	close(c)
-	ri = newStreamingRows(&adapter{c: c, err: errors.New("error after rows")}, &converter{})
+	ri = newStreamingRows(&adapter{c: c, err: errors.New("error after rows")}, &converter{}, false)
 	gotRow = make([]driver.Value, 3)
 	err = ri.Next(gotRow)
 	require.NoError(t, err)
@@ -222,7 +222,7 @@ func TestStreamingRowsError(t *testing.T) {
 	c = make(chan *sqltypes.Result, 1)
 	c <- &packet2
 	close(c)
-	ri = newStreamingRows(&adapter{c: c, err: io.EOF}, &converter{})
+	ri = newStreamingRows(&adapter{c: c, err: io.EOF}, &converter{}, false)
 	gotRow = make([]driver.Value, 3)
 	err = ri.Next(gotRow)
 	wantErr = "first packet did not return fields"
@@ -231,3 +231,12 @@ func TestStreamingRowsError(t *testing.T) {
 	}
 	_ = ri.Close()
 }
+
+func TestStreamingRowsColumnTypes(t *testing.T) {
+	for _, columnTypes := range []bool{true, false} {
+		c := make(chan *sqltypes.Result, 1)
+		c <- &sqltypes.Result{Fields: columnTypesFields}
+		close(c)
+		testColumnTypes(t, newStreamingRows(&adapter{c: c, err: io.EOF}, &converter{}, columnTypes), columnTypes)
+	}
+}
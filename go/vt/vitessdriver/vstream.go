@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vitessdriver
+
+import (
+	"context"
+	"encoding/json"
+
+	"vitess.io/vitess/go/vt/vtgate/grpcvtgateconn"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// VStream is a stream of change events from vtgate.
+type VStream struct {
+	conn   *vtgateconn.VTGateConn
+	reader vtgateconn.VStreamReader
+	cancel context.CancelFunc
+}
+
+// OpenVStream opens a VStream of the changes of the shards of vgtid,
+// starting at their positions in vgtid, from the tablets of type
+// tabletType. The events are filtered by filter.
+//
+// dsn is the JSON string which is passed to sql.Open(). Only the protocol
+// and the address of vtgate are used.
+func OpenVStream(ctx context.Context, dsn string, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter) (*VStream, error) {
+	var c Configuration
+	if err := json.Unmarshal([]byte(dsn), &c); err != nil {
+		return nil, err
+	}
+	return OpenVStreamWithConfiguration(ctx, c, tabletType, vgtid, filter)
+}
+
+// OpenVStreamWithConfiguration is the same as OpenVStream(), but takes a
+// Configuration struct instead of a JSON string.
+func OpenVStreamWithConfiguration(ctx context.Context, c Configuration, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter) (*VStream, error) {
+	c.setDefaults()
+	if len(c.GRPCDialOptions) != 0 {
+		vtgateconn.RegisterDialer(c.Protocol, grpcvtgateconn.DialWithOpts(context.TODO(), c.GRPCDialOptions...))
+	}
+
+	conn, err := vtgateconn.DialProtocol(ctx, c.Protocol, c.Address)
+	if err != nil {
+		return nil, err
+	}
+	streamCtx, cancel := context.WithCancel(ctx)
+	reader, err := conn.VStream(streamCtx, tabletType, vgtid, filter)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, err
+	}
+	return &VStream{
+		conn:   conn,
+		reader: reader,
+		cancel: cancel,
+	}, nil
+}
+
+// Recv returns the next events of the stream. The events of a transaction
+// include a VGTID event, which has the positions to restart the stream
+// from. Recv returns io.EOF if the stream ended.
+func (s *VStream) Recv() ([]*binlogdatapb.VEvent, error) {
+	return s.reader.Recv()
+}
+
+// Close ends the stream, and closes its connection to vtgate.
+func (s *VStream) Close() error {
+	s.cancel()
+	s.conn.Close()
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vitessdriver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestOpenVStream(t *testing.T) {
+	dsn := fmt.Sprintf(`{"address": "%s", "target": "ks"}`, testAddress)
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "0", Gtid: "current"}},
+	}
+	stream, err := OpenVStream(context.Background(), dsn, topodatapb.TabletType_REPLICA, vgtid, nil)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	var got [][]*binlogdatapb.VEvent
+	for {
+		events, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, events)
+	}
+	require.Len(t, got, len(vstreamEvents))
+	for i := range got {
+		require.Len(t, got[i], len(vstreamEvents[i]))
+		for j := range got[i] {
+			if !proto.Equal(got[i][j], vstreamEvents[i][j]) {
+				t.Errorf("event %d/%d: %v, want %v", i, j, got[i][j], vstreamEvents[i][j])
+			}
+		}
+	}
+
+	_, err = OpenVStream(context.Background(), "{", topodatapb.TabletType_REPLICA, vgtid, nil)
+	require.Error(t, err)
+}
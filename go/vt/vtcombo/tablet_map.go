@@ -711,6 +711,14 @@ func (itmc *internalTabletManagerClient) VReplicationWaitForPos(ctx context.Cont
 	return fmt.Errorf("not implemented in vtcombo")
 }
 
+func (itmc *internalTabletManagerClient) GetDistributedTransactions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error) {
+	return nil, fmt.Errorf("not implemented in vtcombo")
+}
+
+func (itmc *internalTabletManagerClient) ResolveDistributedTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error {
+	return fmt.Errorf("not implemented in vtcombo")
+}
+
 func (itmc *internalTabletManagerClient) ResetReplication(ctx context.Context, tablet *topodatapb.Tablet) error {
 	return fmt.Errorf("not implemented in vtcombo")
 }
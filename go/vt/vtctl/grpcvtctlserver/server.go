@@ -25,6 +25,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"vitess.io/vitess/go/vt/callinfo"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topo"
@@ -73,8 +74,8 @@ func (s *VtctlServer) ExecuteVtctlCommand(args *vtctldatapb.ExecuteVtctlCommandR
 	defer tmc.Close()
 	wr := wrangler.New(logger, s.ts, tmc)
 
-	// execute the command
-	return vtctl.RunCommand(stream.Context(), wr, args.Args)
+	// execute the command, the call info identifies the client in the audit log
+	return vtctl.RunCommand(callinfo.GRPCCallInfo(stream.Context()), wr, args.Args)
 }
 
 // StartServer registers the VtctlServer for RPCs
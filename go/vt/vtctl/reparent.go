@@ -44,13 +44,18 @@ func init() {
 	addCommand("Shards", command{
 		"PlannedReparentShard",
 		commandPlannedReparentShard,
-		"-keyspace_shard=<keyspace/shard> [-new_master=<tablet alias>] [-avoid_master=<tablet alias>] [-wait_replicas_timeout=<duration>]",
-		"Reparents the shard to the new master, or away from old master. Both old and new master need to be up and running."})
+		"[-dry_run] -keyspace_shard=<keyspace/shard> [-new_master=<tablet alias>] [-avoid_master=<tablet alias>] [-wait_replicas_timeout=<duration>]",
+		"Reparents the shard to the new master, or away from old master. Both old and new master need to be up and running. With -dry_run, only prints the steps of the reparent."})
 	addCommand("Shards", command{
 		"EmergencyReparentShard",
 		commandEmergencyReparentShard,
-		"-keyspace_shard=<keyspace/shard> [-new_master=<tablet alias>] [-wait_replicas_timeout=<duration>] [-ignore_replicas=<tablet alias list>]",
-		"Reparents the shard to the new master. Assumes the old master is dead and not responding."})
+		"[-dry_run] -keyspace_shard=<keyspace/shard> [-new_master=<tablet alias>] [-wait_replicas_timeout=<duration>] [-ignore_replicas=<tablet alias list>]",
+		"Reparents the shard to the new master. Assumes the old master is dead and not responding. With -dry_run, only prints the steps of the reparent, without stopping replication."})
+	addCommand("Shards", command{
+		"GetReparentHistory",
+		commandGetReparentHistory,
+		"<keyspace/shard>",
+		"Outputs a JSON structure that contains the history of the reparents of the shard, oldest first."})
 	addCommand("Shards", command{
 		"TabletExternallyReparented",
 		commandTabletExternallyReparented,
@@ -120,6 +125,7 @@ func commandPlannedReparentShard(ctx context.Context, wr *wrangler.Wrangler, sub
 	keyspaceShard := subFlags.String("keyspace_shard", "", "keyspace/shard of the shard that needs to be reparented")
 	newMaster := subFlags.String("new_master", "", "alias of a tablet that should be the new master")
 	avoidMaster := subFlags.String("avoid_master", "", "alias of a tablet that should not be the master, i.e. reparent to any other tablet if this one is the master")
+	dryRun := subFlags.Bool("dry_run", false, "only print the steps of the reparent, without taking them")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -151,6 +157,14 @@ func commandPlannedReparentShard(ctx context.Context, wr *wrangler.Wrangler, sub
 			return err
 		}
 	}
+	if *dryRun {
+		plan, err := wr.PlannedReparentShardDryRun(ctx, keyspace, shard, newMasterAlias, avoidMasterAlias, *waitReplicasTimeout)
+		if err != nil {
+			return err
+		}
+		printReparentPlan(wr, plan)
+		return nil
+	}
 	return wr.PlannedReparentShard(ctx, keyspace, shard, newMasterAlias, avoidMasterAlias, *waitReplicasTimeout)
 }
 
@@ -168,6 +182,7 @@ func commandEmergencyReparentShard(ctx context.Context, wr *wrangler.Wrangler, s
 	keyspaceShard := subFlags.String("keyspace_shard", "", "keyspace/shard of the shard that needs to be reparented")
 	newMaster := subFlags.String("new_master", "", "optional alias of a tablet that should be the new master. If not specified, Vitess will select the best candidate")
 	ignoreReplicasList := subFlags.String("ignore_replicas", "", "comma-separated list of replica tablet aliases to ignore during emergency reparent")
+	dryRun := subFlags.Bool("dry_run", false, "only print the steps of the reparent, without taking them")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -194,9 +209,42 @@ func commandEmergencyReparentShard(ctx context.Context, wr *wrangler.Wrangler, s
 		}
 	}
 	unreachableReplicas := topoproto.ParseTabletSet(*ignoreReplicasList)
+	if *dryRun {
+		plan, err := wr.EmergencyReparentShardDryRun(ctx, keyspace, shard, tabletAlias, *waitReplicasTimeout, unreachableReplicas)
+		if err != nil {
+			return err
+		}
+		printReparentPlan(wr, plan)
+		return nil
+	}
 	return wr.EmergencyReparentShard(ctx, keyspace, shard, tabletAlias, *waitReplicasTimeout, unreachableReplicas)
 }
 
+// printReparentPlan prints the numbered steps of a reparent dry run.
+func printReparentPlan(wr *wrangler.Wrangler, plan []string) {
+	for i, step := range plan {
+		wr.Logger().Printf("%v. %v\n", i+1, step)
+	}
+}
+
+func commandGetReparentHistory(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("action GetReparentHistory requires <keyspace/shard>")
+	}
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	history, err := wr.TopoServer().GetReparentHistory(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), history)
+}
+
 func commandTabletExternallyReparented(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
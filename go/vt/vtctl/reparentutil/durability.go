@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package reparentutil has the durability policies shared by the reparents
+of the wrangler, the semi-sync setup of the tablets and vtorc.
+
+A durability policy decides which tablets can be promoted to master, and
+which ones ack the semi-sync transactions of the master. The built-in
+policies are none, semi_sync and cross_cell, others can be registered by
+plugins. A tablet can override the promotion rule of its type with the
+promotion_rule tag of its tablet record, e.g. with
+-init_tags=promotion_rule:prefer.
+*/
+package reparentutil
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/vt/log"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const (
+	// DurabilityNone is the policy which doesn't use semi-sync. The
+	// tablets don't manage semi-sync with it.
+	DurabilityNone = "none"
+	// DurabilitySemiSync is the policy where the master-eligible tablets
+	// ack the transactions of the master.
+	DurabilitySemiSync = "semi_sync"
+	// DurabilityCrossCell is the policy where only the master-eligible
+	// tablets of the other cells ack the transactions of the master.
+	DurabilityCrossCell = "cross_cell"
+
+	// PromotionRuleTag is the tag of a tablet with its promotion rule.
+	PromotionRuleTag = "promotion_rule"
+)
+
+var durabilityPolicy = flag.String("durability_policy", DurabilityNone, "The durability policy of the reparents and of the semi-sync setup of the tablets: none, semi_sync, cross_cell, or one registered by a plugin.")
+
+// Durabler is a durability policy.
+type Durabler interface {
+	// PromotionRule returns the promotion rule of a tablet.
+	PromotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule
+	// MasterSemiSync returns the number of semi-sync acks the master
+	// waits for, 0 to disable semi-sync. The tablet is nil if it is
+	// unknown.
+	MasterSemiSync(master *topodatapb.Tablet) int
+	// ReplicaSemiSync returns true if the replica acks the transactions
+	// of the master. The master is nil if it is unknown.
+	ReplicaSemiSync(master, replica *topodatapb.Tablet) bool
+}
+
+var durabilityPolicies = make(map[string]Durabler)
+
+func init() {
+	RegisterDurability(DurabilityNone, &durabilityNone{})
+	RegisterDurability(DurabilitySemiSync, &durabilitySemiSync{})
+	RegisterDurability(DurabilityCrossCell, &durabilityCrossCell{})
+}
+
+// RegisterDurability registers a durability policy. It is meant to be
+// called in the init function of a plugin.
+func RegisterDurability(name string, d Durabler) {
+	if durabilityPolicies[name] != nil {
+		log.Fatalf("durability policy %v already registered", name)
+	}
+	durabilityPolicies[name] = d
+}
+
+// GetDurabilityPolicy returns a registered durability policy.
+func GetDurabilityPolicy(name string) (Durabler, error) {
+	d, ok := durabilityPolicies[name]
+	if !ok {
+		var names []string
+		for name := range durabilityPolicies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("durability policy %v not found, expected one of %v", name, strings.Join(names, ", "))
+	}
+	return d, nil
+}
+
+// CurrentDurabilityPolicy returns the name of the durability policy of
+// the -durability_policy flag, and the policy.
+func CurrentDurabilityPolicy() (string, Durabler, error) {
+	d, err := GetDurabilityPolicy(*durabilityPolicy)
+	return *durabilityPolicy, d, err
+}
+
+// IsMasterEligible returns true if a tablet of this type can be promoted
+// to master.
+func IsMasterEligible(tabletType topodatapb.TabletType) bool {
+	switch tabletType {
+	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA:
+		return true
+	}
+	return false
+}
+
+// typePromotionRule returns the promotion rule of a tablet for the
+// policies which only promote the master-eligible tablets. Their rule is
+// neutral, unless their promotion_rule tag has a rule which
+// ParseCandidatePromotionRule accepts.
+func typePromotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule {
+	if !IsMasterEligible(tablet.Type) {
+		return MustNotPromoteRule
+	}
+	tag, ok := tablet.Tags[PromotionRuleTag]
+	if !ok {
+		return NeutralPromoteRule
+	}
+	rule, err := ParseCandidatePromotionRule(tag)
+	if err != nil {
+		log.Warningf("ignoring the %v tag of tablet %v: %v", PromotionRuleTag, tablet.Alias, err)
+		return NeutralPromoteRule
+	}
+	return rule
+}
+
+//=======================================================================
+
+type durabilityNone struct{}
+
+func (d *durabilityNone) PromotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule {
+	return typePromotionRule(tablet)
+}
+
+func (d *durabilityNone) MasterSemiSync(master *topodatapb.Tablet) int {
+	return 0
+}
+
+func (d *durabilityNone) ReplicaSemiSync(master, replica *topodatapb.Tablet) bool {
+	return false
+}
+
+//=======================================================================
+
+type durabilitySemiSync struct{}
+
+func (d *durabilitySemiSync) PromotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule {
+	return typePromotionRule(tablet)
+}
+
+func (d *durabilitySemiSync) MasterSemiSync(master *topodatapb.Tablet) int {
+	return 1
+}
+
+func (d *durabilitySemiSync) ReplicaSemiSync(master, replica *topodatapb.Tablet) bool {
+	return IsMasterEligible(replica.Type)
+}
+
+//=======================================================================
+
+type durabilityCrossCell struct{}
+
+func (d *durabilityCrossCell) PromotionRule(tablet *topodatapb.Tablet) CandidatePromotionRule {
+	return typePromotionRule(tablet)
+}
+
+func (d *durabilityCrossCell) MasterSemiSync(master *topodatapb.Tablet) int {
+	return 1
+}
+
+func (d *durabilityCrossCell) ReplicaSemiSync(master, replica *topodatapb.Tablet) bool {
+	// Prevent panics.
+	if master == nil || master.Alias == nil || replica.Alias == nil {
+		return false
+	}
+	return IsMasterEligible(replica.Type) && master.Alias.Cell != replica.Alias.Cell
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reparentutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func tablet(cell string, tabletType topodatapb.TabletType, rule string) *topodatapb.Tablet {
+	t := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{Cell: cell, Uid: 1},
+		Type:  tabletType,
+	}
+	if rule != "" {
+		t.Tags = map[string]string{PromotionRuleTag: rule}
+	}
+	return t
+}
+
+func TestDurabilityPolicies(t *testing.T) {
+	master := tablet("cell1", topodatapb.TabletType_MASTER, "")
+	sameCellReplica := tablet("cell1", topodatapb.TabletType_REPLICA, "")
+	otherCellReplica := tablet("cell2", topodatapb.TabletType_REPLICA, "")
+	rdonly := tablet("cell2", topodatapb.TabletType_RDONLY, "")
+
+	testcases := []struct {
+		policy         string
+		masterSemiSync int
+		replicaAcks    []bool
+	}{{
+		policy:         DurabilityNone,
+		masterSemiSync: 0,
+		replicaAcks:    []bool{false, false, false},
+	}, {
+		policy:         DurabilitySemiSync,
+		masterSemiSync: 1,
+		replicaAcks:    []bool{true, true, false},
+	}, {
+		policy:         DurabilityCrossCell,
+		masterSemiSync: 1,
+		replicaAcks:    []bool{false, true, false},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.policy, func(t *testing.T) {
+			d, err := GetDurabilityPolicy(tc.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.masterSemiSync, d.MasterSemiSync(master))
+			for i, replica := range []*topodatapb.Tablet{sameCellReplica, otherCellReplica, rdonly} {
+				assert.Equal(t, tc.replicaAcks[i], d.ReplicaSemiSync(master, replica), "replica %v", i)
+			}
+			// An unknown master doesn't ack for cross_cell.
+			if tc.policy == DurabilityCrossCell {
+				assert.False(t, d.ReplicaSemiSync(nil, otherCellReplica))
+			}
+			assert.Equal(t, NeutralPromoteRule, d.PromotionRule(sameCellReplica))
+			assert.Equal(t, MustNotPromoteRule, d.PromotionRule(rdonly))
+		})
+	}
+
+	_, err := GetDurabilityPolicy("unknown")
+	assert.EqualError(t, err, "durability policy unknown not found, expected one of cross_cell, none, semi_sync")
+}
+
+func TestPromotionRuleTag(t *testing.T) {
+	d, err := GetDurabilityPolicy(DurabilitySemiSync)
+	require.NoError(t, err)
+
+	testcases := []struct {
+		tablet *topodatapb.Tablet
+		want   CandidatePromotionRule
+	}{{
+		tablet: tablet("cell1", topodatapb.TabletType_REPLICA, "prefer"),
+		want:   PreferPromoteRule,
+	}, {
+		// Like ParseCandidatePromotionRule, must is not supported.
+		tablet: tablet("cell1", topodatapb.TabletType_REPLICA, "must"),
+		want:   NeutralPromoteRule,
+	}, {
+		tablet: tablet("cell1", topodatapb.TabletType_REPLICA, "must_not"),
+		want:   MustNotPromoteRule,
+	}, {
+		// Unknown rules are ignored.
+		tablet: tablet("cell1", topodatapb.TabletType_REPLICA, "always"),
+		want:   NeutralPromoteRule,
+	}, {
+		// The tag cannot make an ineligible tablet eligible.
+		tablet: tablet("cell1", topodatapb.TabletType_RDONLY, "prefer"),
+		want:   MustNotPromoteRule,
+	}}
+	for _, tc := range testcases {
+		assert.Equal(t, tc.want, d.PromotionRule(tc.tablet), "tags %v", tc.tablet.Tags)
+	}
+
+	rule := PreferPromoteRule
+	assert.True(t, rule.BetterThan(NeutralPromoteRule))
+	assert.False(t, rule.BetterThan(MustPromoteRule))
+}
@@ -22,13 +22,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path"
+	"strings"
 
 	"github.com/golang/protobuf/jsonpb"
 
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/wrangler"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -53,6 +56,30 @@ func init() {
 		commandTopoCp,
 		"[-cell <cell>] [-to_topo] <src> <dst>",
 		"Copies a file from topo to local file structure, or the other way around"})
+
+	addCommand(topoGroupName, command{
+		"TopoSnapshot",
+		commandTopoSnapshot,
+		"[-cells <cell1>,<cell2>,...]",
+		"Takes a consistent snapshot of the global topology and of the topology of the cells (keyspaces, shards, VSchemas, routing rules, cell info, SrvKeyspaces and SrvVSchemas), stores it in the backup storage, and displays its name."})
+
+	addCommand(topoGroupName, command{
+		"TopoListSnapshots",
+		commandTopoListSnapshots,
+		"",
+		"Lists the topology snapshots in the backup storage, oldest first."})
+
+	addCommand(topoGroupName, command{
+		"TopoDiff",
+		commandTopoDiff,
+		"[-kinds <kind1>,<kind2>,...] [-keyspaces <keyspace1>,<keyspace2>,...] [-cells <cell1>,<cell2>,...] <snapshot> [<snapshot>]",
+		"Displays the differences between a topology snapshot and the current topology, or another snapshot. The kinds are the names of the topology files, e.g. VSchema or RoutingRules. The global cell is '" + topo.GlobalCell + "'."})
+
+	addCommand(topoGroupName, command{
+		"TopoRestore",
+		commandTopoRestore,
+		"[-kinds <kind1>,<kind2>,...] [-keyspaces <keyspace1>,<keyspace2>,...] [-cells <cell1>,<cell2>,...] [-restore_master_alias] [-dry_run] <snapshot>",
+		"Restores the files of a topology snapshot which are different in the topology, optionally only some kinds of files, or the ones of some keyspaces or cells. The files created after the snapshot are not removed. The master of the shards is kept, unless -restore_master_alias is set. The SrvVSchemas are rebuilt if VSchemas or routing rules are restored without them."})
 }
 
 // DecodeContent uses the filename to imply a type, and proto-decodes
@@ -80,6 +107,8 @@ func DecodeContent(filename string, data []byte, json bool) (string, error) {
 		p = new(topodatapb.SrvKeyspace)
 	case topo.RoutingRulesFile:
 		p = new(vschemapb.RoutingRules)
+	case topo.ReparentHistoryFile:
+		p = new(topodatapb.ReparentHistory)
 	default:
 		if json {
 			return "", fmt.Errorf("unknown topo protobuf type for %v", name)
@@ -286,3 +315,143 @@ func (d JsonTopologyDecoder) decode(topoPaths []string, conn topo.Conn, ctx cont
 	}
 	return nil
 }
+
+func commandTopoSnapshot(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	cellsStr := subFlags.String("cells", "", "Specifies a comma-separated list of cells to snapshot, besides the global topology. Defaults to all cells.")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("TopoSnapshot doesn't take any argument")
+	}
+	var cells []string
+	if *cellsStr != "" {
+		cells = strings.Split(*cellsStr, ",")
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+	snapshot, err := topotools.TakeTopoSnapshot(ctx, wr.TopoServer(), cells)
+	if err != nil {
+		return err
+	}
+	name, err := topotools.SaveTopoSnapshot(ctx, bs, snapshot)
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("%v\n", name)
+	return nil
+}
+
+func commandTopoListSnapshots(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("TopoListSnapshots doesn't take any argument")
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+	names, err := topotools.ListTopoSnapshots(ctx, bs)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		wr.Logger().Printf("%v\n", name)
+	}
+	return nil
+}
+
+// snapshotFilterFlags adds the flags of a topotools.SnapshotFilter.
+func snapshotFilterFlags(subFlags *flag.FlagSet) func() (*topotools.SnapshotFilter, error) {
+	kinds := subFlags.String("kinds", "", "Specifies a comma-separated list of kinds of topology files, e.g. VSchema,RoutingRules. Defaults to all kinds.")
+	keyspaces := subFlags.String("keyspaces", "", "Specifies a comma-separated list of keyspaces. Only the files of these keyspaces are selected.")
+	cells := subFlags.String("cells", "", "Specifies a comma-separated list of cells, '"+topo.GlobalCell+"' for the global topology. Defaults to all cells.")
+	return func() (*topotools.SnapshotFilter, error) {
+		filter := &topotools.SnapshotFilter{}
+		if *kinds != "" {
+			filter.Kinds = strings.Split(*kinds, ",")
+		}
+		if *keyspaces != "" {
+			filter.Keyspaces = strings.Split(*keyspaces, ",")
+		}
+		if *cells != "" {
+			filter.Cells = strings.Split(*cells, ",")
+		}
+		return filter, filter.Validate()
+	}
+}
+
+func commandTopoDiff(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	getFilter := snapshotFilterFlags(subFlags)
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 && subFlags.NArg() != 2 {
+		return fmt.Errorf("the <snapshot> argument is required for the TopoDiff command")
+	}
+	filter, err := getFilter()
+	if err != nil {
+		return err
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+	from, err := topotools.LoadTopoSnapshot(ctx, bs, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	var to *topotools.TopoSnapshot
+	if subFlags.NArg() == 2 {
+		to, err = topotools.LoadTopoSnapshot(ctx, bs, subFlags.Arg(1))
+	} else {
+		to, err = topotools.TakeTopoSnapshot(ctx, wr.TopoServer(), nil)
+	}
+	if err != nil {
+		return err
+	}
+	diff := topotools.DiffTopoSnapshots(from, to, filter)
+	if diff == "" {
+		wr.Logger().Printf("no differences\n")
+		return nil
+	}
+	wr.Logger().Printf("%v", diff)
+	return nil
+}
+
+func commandTopoRestore(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	getFilter := snapshotFilterFlags(subFlags)
+	restoreMasterAlias := subFlags.Bool("restore_master_alias", false, "Also restores the master of the shards, which is kept otherwise.")
+	dryRun := subFlags.Bool("dry_run", false, "Only displays the files which would be restored.")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <snapshot> argument is required for the TopoRestore command")
+	}
+	filter, err := getFilter()
+	if err != nil {
+		return err
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+	snapshot, err := topotools.LoadTopoSnapshot(ctx, bs, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return topotools.RestoreTopoSnapshot(ctx, wr.Logger(), wr.TopoServer(), snapshot, filter, *restoreMasterAlias, *dryRun)
+}
@@ -102,6 +102,7 @@ import (
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/sync2"
+	"vitess.io/vitess/go/vt/audit"
 	hk "vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
@@ -479,6 +480,19 @@ var commands = []commandGroup{
 				"Outputs a JSON structure that contains information about the ShardReplication."},
 		},
 	},
+	{
+		"Distributed Transactions", []command{
+			{"ListDistributedTransactions", commandListDistributedTransactions,
+				"<keyspace>",
+				"Outputs a JSON list of the unresolved distributed (2PC) transactions coordinated by the shards of the keyspace, with the state of each participant."},
+			{"GetDistributedTransaction", commandGetDistributedTransaction,
+				"<dtid>",
+				"Outputs a JSON structure that contains the state of the distributed (2PC) transaction on its metadata manager and on each participant."},
+			{"ResolveDistributedTransaction", commandResolveDistributedTransaction,
+				"-action=<conclude|commit|rollback> <dtid>",
+				"Forces the resolution of a distributed (2PC) transaction. The action is only applied if all participants agree with it: conclude requires every participant to be resolved, commit requires the commit decision to be recorded, and rollback requires it not to be."},
+		},
+	},
 	{
 		"Workflow", []command{
 			{"VExec", commandVExec,
@@ -2935,6 +2949,48 @@ func commandHelp(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.Flag
 	return nil
 }
 
+func commandListDistributedTransactions(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the ListDistributedTransactions command")
+	}
+	transactions, err := wr.ListDistributedTransactions(ctx, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), transactions)
+}
+
+func commandGetDistributedTransaction(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <dtid> argument is required for the GetDistributedTransaction command")
+	}
+	transaction, err := wr.GetDistributedTransaction(ctx, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), transaction)
+}
+
+func commandResolveDistributedTransaction(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	action := subFlags.String("action", "", "Resolution to apply: conclude, commit or rollback")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <dtid> argument is required for the ResolveDistributedTransaction command")
+	}
+	if *action == "" {
+		return fmt.Errorf("the -action flag is required for the ResolveDistributedTransaction command")
+	}
+	return wr.ResolveDistributedTransaction(ctx, subFlags.Arg(0), *action)
+}
+
 func commandVExec(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	json := subFlags.Bool("json", false, "Output JSON instead of human-readable table")
 	dryRun := subFlags.Bool("dry_run", false, "Does a dry run of VExec and only reports the final query and list of masters on which it will be applied")
@@ -3237,7 +3293,9 @@ func RunCommand(ctx context.Context, wr *wrangler.Wrangler, args []string) error
 					wr.Logger().Printf("%s\n\n", cmd.help)
 					subFlags.PrintDefaults()
 				}
-				return cmd.method(ctx, wr, subFlags, args[1:])
+				err := cmd.method(ctx, wr, subFlags, args[1:])
+				audit.Command(ctx, args, err)
+				return err
 			}
 		}
 	}
@@ -0,0 +1,6 @@
+select * from ordered_tbl where id between 1000 and 2000;
+select * from ordered_tbl where id >= 4611686018427387904 and id < 9223372036854775808;
+select * from ordered_tbl where id > 13835058055282163712;
+select * from ordered_tbl where id >= 9223372036854775808 or id < 1000;
+delete from ordered_tbl where id between 4611686018427387904 and 4611686018427388904;
+update ordered_tbl set val = 'x' where id <= 100;
@@ -45,6 +45,12 @@ create table music_extra (
 	primary key (id)
 ) Engine=InnoDB;
 
+create table ordered_tbl (
+	id bigint unsigned,
+	val varchar(64),
+	primary key (id)
+) Engine=InnoDB;
+
 create table table_not_in_vschema (
 	id bigint,
 	primary key (id)
@@ -178,6 +178,7 @@ func Stop() {
 		for _, conn := range explainTopo.TabletConns {
 			conn.db.Close()
 		}
+		explainTopo = nil
 	}
 }
 
@@ -277,7 +278,7 @@ func Run(sql string) ([]*Explain, error) {
 }
 
 func explain(sql string) (*Explain, error) {
-	plans, tabletActions, err := vtgateExecute(sql)
+	plans, tabletActions, err := vtgateExecute(sql, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -126,6 +126,7 @@ func TestExplain(t *testing.T) {
 		{"updatesharded", defaultTestOpts()},
 		{"deletesharded", defaultTestOpts()},
 		{"comments", defaultTestOpts()},
+		{"selectrange", defaultTestOpts()},
 		{"options", &Options{
 			ReplicationMode: "STATEMENT",
 			NumShards:       4,
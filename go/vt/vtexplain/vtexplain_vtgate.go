@@ -37,6 +37,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
 
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
@@ -183,12 +184,12 @@ func getShardRanges(ks string, vschema *vschemapb.Keyspace, ksShardMap map[strin
 	return shards, nil
 }
 
-func vtgateExecute(sql string) ([]*engine.Plan, map[string]*TabletActions, error) {
+func vtgateExecute(sql string, bindVars map[string]*querypb.BindVariable) ([]*engine.Plan, map[string]*TabletActions, error) {
 	// use the plan cache to get the set of plans used for this query, then
 	// clear afterwards for the next run
 	planCache := vtgateExecutor.Plans()
 
-	_, err := vtgateExecutor.Execute(context.Background(), "VtexplainExecute", vtgate.NewSafeSession(vtgateSession), sql, nil)
+	_, err := vtgateExecutor.Execute(context.Background(), "VtexplainExecute", vtgate.NewSafeSession(vtgateSession), sql, bindVars)
 	if err != nil {
 		for _, tc := range explainTopo.TabletConns {
 			tc.tabletQueries = nil
@@ -0,0 +1,746 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtexplain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"vitess.io/vitess/go/jsonutil"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/sync2"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// The query log formats ParseQueryLog understands.
+const (
+	// QueryLogFormatText is the vtgate query log with -querylog-format=text.
+	QueryLogFormatText = "text"
+
+	// QueryLogFormatJSON is the vtgate query log with -querylog-format=json.
+	QueryLogFormatJSON = "json"
+
+	// QueryLogFormatGeneral is the MySQL general query log.
+	QueryLogFormatGeneral = "general"
+
+	// QueryLogFormatSlow is the MySQL slow query log.
+	QueryLogFormatSlow = "slow"
+)
+
+// The changes of a query between two workload reports.
+const (
+	ChangeBetter = "better"
+	ChangeWorse  = "worse"
+	ChangeSame   = "same"
+)
+
+// LoggedQuery is a query read from a query log.
+type LoggedQuery struct {
+	SQL string
+
+	// BindVars are the bind variables of the query, if the log has them.
+	BindVars map[string]*querypb.BindVariable
+}
+
+// ParseQueryLog reads the queries of a query log in the given format.
+func ParseQueryLog(r io.Reader, format string) ([]*LoggedQuery, error) {
+	switch format {
+	case QueryLogFormatText:
+		return parseVtgateTextLog(r)
+	case QueryLogFormatJSON:
+		return parseVtgateJSONLog(r)
+	case QueryLogFormatGeneral:
+		return parseGeneralLog(r)
+	case QueryLogFormatSlow:
+		return parseSlowLog(r)
+	}
+	return nil, fmt.Errorf("unknown query log format %q", format)
+}
+
+func newLogScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return scanner
+}
+
+// vtgateTextLogSQLField is the index of the SQL in the tab-separated fields
+// of a line of the vtgate text query log.
+const vtgateTextLogSQLField = 12
+
+func parseVtgateTextLog(r io.Reader) ([]*LoggedQuery, error) {
+	var queries []*LoggedQuery
+	scanner := newLogScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) <= vtgateTextLogSQLField {
+			return nil, fmt.Errorf("line %v: not a vtgate query log line", lineNum)
+		}
+		sql, err := strconv.Unquote(fields[vtgateTextLogSQLField])
+		if err != nil {
+			return nil, fmt.Errorf("line %v: cannot unquote the SQL: %v", lineNum, err)
+		}
+		// The bind variables of the text format are printed as a go
+		// map, which can't be read back.
+		queries = append(queries, &LoggedQuery{SQL: sql})
+	}
+	return queries, scanner.Err()
+}
+
+func parseVtgateJSONLog(r io.Reader) ([]*LoggedQuery, error) {
+	var queries []*LoggedQuery
+	scanner := newLogScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var entry struct {
+			SQL      string
+			BindVars json.RawMessage
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("line %v: %v", lineNum, err)
+		}
+		queries = append(queries, &LoggedQuery{
+			SQL:      entry.SQL,
+			BindVars: parseJSONBindVars(entry.BindVars),
+		})
+	}
+	return queries, scanner.Err()
+}
+
+// parseJSONBindVars reads the bind variables of the vtgate json query log.
+// Redacted bind variables are ignored.
+func parseJSONBindVars(data json.RawMessage) map[string]*querypb.BindVariable {
+	var logged map[string]struct {
+		Type  string
+		Value json.RawMessage
+	}
+	if err := json.Unmarshal(data, &logged); err != nil || len(logged) == 0 {
+		return nil
+	}
+	bindVars := make(map[string]*querypb.BindVariable, len(logged))
+	for name, bv := range logged {
+		typ, ok := querypb.Type_value[bv.Type]
+		if !ok {
+			continue
+		}
+		// Numbers are logged as json numbers, everything else as strings.
+		value := string(bv.Value)
+		var s string
+		if err := json.Unmarshal(bv.Value, &s); err == nil {
+			value = s
+		}
+		bindVars[name] = &querypb.BindVariable{
+			Type:  querypb.Type(typ),
+			Value: []byte(value),
+		}
+	}
+	return bindVars
+}
+
+// generalLogLine matches the lines of the MySQL general query log which
+// start a command. They are the tab-separated time, which is empty if it
+// didn't change since the previous line, the thread id followed by the
+// command, and its argument.
+var generalLogLine = regexp.MustCompile(`^[^\t]*\t+\s*\d+ ([A-Za-z][A-Za-z ]*?)(?:\t(.*))?$`)
+
+// parseGeneralLog reads the queries of all the threads of the log, in the
+// order they are logged. Their transaction statements are interleaved, so
+// AnalyzeWorkload skips them.
+func parseGeneralLog(r io.Reader) ([]*LoggedQuery, error) {
+	var queries []*LoggedQuery
+	var current *strings.Builder
+	flush := func() {
+		if current != nil {
+			if sql := strings.TrimSpace(current.String()); sql != "" {
+				queries = append(queries, &LoggedQuery{SQL: sql})
+			}
+		}
+		current = nil
+	}
+
+	scanner := newLogScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := generalLogLine.FindStringSubmatch(line); match != nil {
+			flush()
+			if match[1] == "Query" || match[1] == "Execute" {
+				current = &strings.Builder{}
+				current.WriteString(match[2])
+			}
+			continue
+		}
+		// Multi-line queries continue on the next lines. Anything
+		// else, like the headers of the log, is skipped.
+		if current != nil {
+			current.WriteString("\n")
+			current.WriteString(line)
+		}
+	}
+	flush()
+	return queries, scanner.Err()
+}
+
+// slowLogHeaders are the prefixes of the lines of the MySQL slow query log
+// which are not part of a query.
+var slowLogHeaders = []string{
+	"#",
+	"SET timestamp=",
+	"use ",
+	"Tcp port:",
+	"Time ",
+}
+
+func parseSlowLog(r io.Reader) ([]*LoggedQuery, error) {
+	var queries []*LoggedQuery
+	var current strings.Builder
+	scanner := newLogScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if current.Len() == 0 && isSlowLogHeader(line) {
+			continue
+		}
+		if current.Len() != 0 {
+			current.WriteString("\n")
+		}
+		current.WriteString(line)
+
+		// Queries end with a semicolon.
+		trimmed := strings.TrimSpace(line)
+		if strings.HasSuffix(trimmed, ";") {
+			sql := strings.TrimSuffix(strings.TrimSpace(current.String()), ";")
+			if sql != "" {
+				queries = append(queries, &LoggedQuery{SQL: sql})
+			}
+			current.Reset()
+		}
+	}
+	return queries, scanner.Err()
+}
+
+func isSlowLogHeader(line string) bool {
+	if strings.TrimSpace(line) == "" || strings.Contains(line, ", Version: ") {
+		return true
+	}
+	for _, prefix := range slowLogHeaders {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryStats is the analysis of the queries of a workload which have the
+// same normalized SQL.
+type QueryStats struct {
+	// SQL is the normalized query.
+	SQL string
+
+	// Count is the number of times the query is in the workload.
+	Count int
+
+	// PlanType describes the routes of the vtgate plan, e.g.
+	// SelectEqualUnique, or SelectScatter+SelectEqualUnique for a join.
+	PlanType string
+
+	// ShardFanout is the largest number of shards one execution of the
+	// query was sent to.
+	ShardFanout int
+
+	// TabletQueries is the number of queries sent to the tablets by all
+	// the executions of the query.
+	TabletQueries int
+
+	// ShardLoad is the number of tablet queries per shard.
+	ShardLoad map[string]int
+
+	// Error is set if vtgate can't execute the query.
+	Error string
+}
+
+// WorkloadReport is the analysis of a workload.
+type WorkloadReport struct {
+	// Queries are sorted by decreasing number of tablet queries.
+	Queries []*QueryStats
+
+	// TotalQueries is the number of queries of the workload.
+	TotalQueries int
+
+	// Errors is the number of queries vtgate can't execute.
+	Errors int
+
+	// ShardLoad is the number of tablet queries per shard.
+	ShardLoad map[string]int
+}
+
+// AnalyzeWorkload explains each distinct normalized query of the workload
+// once in the environment set up by Init, and aggregates its executions.
+//
+// The shards of an execution are computed from its own values when the
+// plan routes on a vindex which doesn't need to look anything up, like
+// hash. The executions of the other queries are assumed to have the
+// tablet queries of the explained one. Bind variables which are missing
+// from the log, like in the vtgate text query log, are set to 1, which
+// skews the per-shard load of these queries towards one shard.
+//
+// The transaction statements are skipped: the executions are explained
+// independently of the sessions they belong to.
+func AnalyzeWorkload(queries []*LoggedQuery) *WorkloadReport {
+	report := &WorkloadReport{
+		ShardLoad: make(map[string]int),
+	}
+	byQuery := make(map[string]*explainedQuery)
+	for _, q := range queries {
+		normalized, bindVars, err := prepareLoggedQuery(q)
+		if err == errTransactionStatement {
+			continue
+		}
+		report.TotalQueries++
+		eq, ok := byQuery[normalized]
+		if !ok {
+			eq = &explainedQuery{stats: &QueryStats{
+				SQL:       normalized,
+				ShardLoad: make(map[string]int),
+			}}
+			if err == nil {
+				eq.explain(bindVars)
+			} else {
+				eq.stats.Error = err.Error()
+			}
+			byQuery[normalized] = eq
+			report.Queries = append(report.Queries, eq.stats)
+		}
+		qs := eq.stats
+		qs.Count++
+		if qs.Error != "" {
+			report.Errors++
+			continue
+		}
+
+		load := eq.shardLoad(bindVars)
+		if len(load) > qs.ShardFanout {
+			qs.ShardFanout = len(load)
+		}
+		for shard, n := range load {
+			qs.TabletQueries += n
+			qs.ShardLoad[shard] += n
+			report.ShardLoad[shard] += n
+		}
+	}
+
+	sort.SliceStable(report.Queries, func(i, j int) bool {
+		return report.Queries[i].TabletQueries > report.Queries[j].TabletQueries
+	})
+	return report
+}
+
+// errTransactionStatement is returned by prepareLoggedQuery for the
+// statements which are skipped.
+var errTransactionStatement = errors.New("transaction statement")
+
+// prepareLoggedQuery parses a logged query once, and returns its
+// normalized SQL and the bind variables of the execution: the literals
+// replaced by the normalization, the logged ones, and the ones which are
+// missing set to 1. If the query can't be parsed, the normalized SQL is
+// the logged one.
+func prepareLoggedQuery(q *LoggedQuery) (string, map[string]*querypb.BindVariable, error) {
+	stmt, err := sqlparser.Parse(q.SQL)
+	if err != nil {
+		return strings.TrimSpace(q.SQL), nil, err
+	}
+	switch stmt.(type) {
+	case *sqlparser.Begin, *sqlparser.Commit, *sqlparser.Rollback, *sqlparser.Savepoint, *sqlparser.SRollback, *sqlparser.Release:
+		return "", nil, errTransactionStatement
+	}
+	bindVars := make(map[string]*querypb.BindVariable, len(q.BindVars))
+	for name, bv := range q.BindVars {
+		bindVars[name] = bv
+	}
+	sqlparser.Normalize(stmt, bindVars, "v")
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case sqlparser.Argument:
+			name := string(node[1:])
+			if _, ok := bindVars[name]; !ok {
+				bindVars[name] = sqltypes.Int64BindVariable(1)
+			}
+		case sqlparser.ListArg:
+			name := string(node[2:])
+			if _, ok := bindVars[name]; !ok {
+				bindVars[name] = &querypb.BindVariable{
+					Type:   querypb.Type_TUPLE,
+					Values: []*querypb.Value{{Type: querypb.Type_INT64, Value: []byte("1")}},
+				}
+			}
+		}
+		return true, nil
+	}, stmt)
+	return sqlparser.String(stmt), bindVars, nil
+}
+
+// explainedQuery is a distinct normalized query of a workload, with the
+// explanation of its first execution.
+type explainedQuery struct {
+	stats *QueryStats
+
+	// route is the only primitive which routes the query, if it
+	// routes on the values of a vindex.
+	route *vindexRoute
+
+	// tabletQueries is the number of tablet queries per shard of the
+	// explained execution.
+	tabletQueries map[string]int
+}
+
+// vindexRoute is how a primitive routes on the values of a vindex.
+type vindexRoute struct {
+	keyspace string
+	vindex   vindexes.SingleColumn
+	values   sqltypes.PlanValue
+}
+
+// explain explains the query with the bind variables of its first
+// execution.
+func (eq *explainedQuery) explain(bindVars map[string]*querypb.BindVariable) {
+	if vtgateSession == nil || !vtgateSession.GetInTransaction() {
+		batchTime = sync2.NewBatcher(*batchInterval)
+	}
+	plans, tabletActions, err := vtgateExecute(eq.stats.SQL, bindVars)
+	if err != nil {
+		eq.stats.Error = err.Error()
+		return
+	}
+	eq.stats.PlanType = planType(plans)
+	eq.tabletQueries = make(map[string]int, len(tabletActions))
+	for shard, actions := range tabletActions {
+		eq.tabletQueries[shard] = len(actions.TabletQueries)
+	}
+	if len(plans) == 1 && plans[0].Instructions != nil {
+		eq.route = findVindexRoute(plans[0].Instructions)
+	}
+}
+
+// findVindexRoute returns how the only routing primitive of the plan
+// routes on the values of a vindex, or nil if it doesn't, or if the vindex
+// needs to look up the keyspace ids.
+func findVindexRoute(instructions engine.Primitive) *vindexRoute {
+	var routes []*vindexRoute
+	routing := 0
+	engine.Find(func(p engine.Primitive) bool {
+		var keyspace *vindexes.Keyspace
+		var vindex vindexes.Vindex
+		var values []sqltypes.PlanValue
+		switch p := p.(type) {
+		case *engine.Route:
+			routing++
+			switch p.Opcode {
+			case engine.SelectEqual, engine.SelectEqualUnique, engine.SelectIN:
+				keyspace, vindex, values = p.Keyspace, p.Vindex, p.Values
+			}
+		case *engine.Update:
+			routing++
+			if p.Opcode == engine.Equal || p.Opcode == engine.In {
+				keyspace, vindex, values = p.Keyspace, p.Vindex, p.Values
+			}
+		case *engine.Delete:
+			routing++
+			if p.Opcode == engine.Equal || p.Opcode == engine.In {
+				keyspace, vindex, values = p.Keyspace, p.Vindex, p.Values
+			}
+		case *engine.Insert, *engine.Send:
+			routing++
+		}
+		if single, ok := vindex.(vindexes.SingleColumn); ok && !single.NeedsVCursor() && keyspace != nil && len(values) == 1 {
+			routes = append(routes, &vindexRoute{keyspace: keyspace.Name, vindex: single, values: values[0]})
+		}
+		return false
+	}, instructions)
+	if routing != 1 || len(routes) != 1 {
+		return nil
+	}
+	return routes[0]
+}
+
+// shardLoad returns the number of tablet queries per shard of an
+// execution of the query.
+func (eq *explainedQuery) shardLoad(bindVars map[string]*querypb.BindVariable) map[string]int {
+	if eq.route != nil {
+		if load := eq.route.shardLoad(bindVars, eq.tabletQueries); load != nil {
+			return load
+		}
+	}
+	return eq.tabletQueries
+}
+
+// shardLoad maps the values of the execution to their shards, which each
+// get the average number of tablet queries per shard of the explained
+// execution. It returns nil if a value doesn't map to a keyspace id.
+func (r *vindexRoute) shardLoad(bindVars map[string]*querypb.BindVariable, explained map[string]int) map[string]int {
+	var ids []sqltypes.Value
+	if r.values.IsList() {
+		var err error
+		if ids, err = r.values.ResolveList(bindVars); err != nil {
+			return nil
+		}
+	} else {
+		id, err := r.values.ResolveValue(bindVars)
+		if err != nil {
+			return nil
+		}
+		ids = []sqltypes.Value{id}
+	}
+	destinations, err := r.vindex.Map(nil, ids)
+	if err != nil {
+		return nil
+	}
+	perShard := 1
+	if total := sumLoad(explained); len(explained) != 0 && total > len(explained) {
+		perShard = total / len(explained)
+	}
+	load := make(map[string]int)
+	for _, dest := range destinations {
+		ksid, ok := dest.(key.DestinationKeyspaceID)
+		if !ok {
+			return nil
+		}
+		shard := ""
+		for name, ref := range explainTopo.KeyspaceShards[r.keyspace] {
+			if key.KeyRangeContains(ref.KeyRange, ksid) {
+				shard = name
+				break
+			}
+		}
+		if shard == "" {
+			return nil
+		}
+		load[r.keyspace+"/"+shard] = perShard
+	}
+	return load
+}
+
+func sumLoad(load map[string]int) int {
+	total := 0
+	for _, n := range load {
+		total += n
+	}
+	return total
+}
+
+// planType describes the routes of the plans, which are the leaves of the
+// primitive trees.
+func planType(plans []*engine.Plan) string {
+	var routes []string
+	var walk func(pd engine.PrimitiveDescription)
+	walk = func(pd engine.PrimitiveDescription) {
+		if len(pd.Inputs) == 0 {
+			if pd.Variant != "" {
+				routes = append(routes, pd.Variant)
+			} else {
+				routes = append(routes, pd.OperatorType)
+			}
+			return
+		}
+		for _, input := range pd.Inputs {
+			walk(input)
+		}
+	}
+	for _, plan := range plans {
+		if plan.Instructions != nil {
+			walk(engine.PrimitiveToPlanDescription(plan.Instructions))
+		}
+	}
+	return strings.Join(routes, "+")
+}
+
+// WorkloadAsText returns a text representation of the report.
+func WorkloadAsText(report *WorkloadReport) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%v queries, %v distinct, %v errors\n\n", report.TotalQueries, len(report.Queries), report.Errors)
+
+	w := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "COUNT\tPLAN\tSHARDS\tTABLET QUERIES\tQUERY\n")
+	for _, qs := range report.Queries {
+		plan := qs.PlanType
+		if qs.Error != "" {
+			plan = "ERROR"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", qs.Count, plan, qs.ShardFanout, qs.TabletQueries, qs.SQL)
+	}
+	w.Flush()
+
+	if report.Errors != 0 {
+		fmt.Fprintf(&b, "\nErrors:\n")
+		for _, qs := range report.Queries {
+			if qs.Error != "" {
+				fmt.Fprintf(&b, "%v\n  %v\n", qs.SQL, qs.Error)
+			}
+		}
+	}
+
+	total := 0
+	shards := make([]string, 0, len(report.ShardLoad))
+	for shard, load := range report.ShardLoad {
+		shards = append(shards, shard)
+		total += load
+	}
+	sort.Strings(shards)
+	fmt.Fprintf(&b, "\nShard load:\n")
+	w = tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	for _, shard := range shards {
+		load := report.ShardLoad[shard]
+		fmt.Fprintf(w, "%v\t%v\t%.1f%%\n", shard, load, 100*float64(load)/float64(total))
+	}
+	w.Flush()
+	return b.String()
+}
+
+// WorkloadAsJSON returns a json representation of the report.
+func WorkloadAsJSON(report *WorkloadReport) string {
+	reportJSON, _ := jsonutil.MarshalIndentNoEscape(report, "", "    ")
+	return string(reportJSON)
+}
+
+// QueryComparison compares the analysis of a normalized query with two
+// VSchemas.
+type QueryComparison struct {
+	SQL string
+
+	// Before and After are nil if the query isn't in the report.
+	Before *QueryStats
+	After  *QueryStats
+
+	// Change is one of ChangeBetter, ChangeWorse or ChangeSame.
+	Change string
+}
+
+// CompareWorkloadReports compares the queries of two reports of the same
+// workload. The queries which got worse come first, then the ones which
+// got better.
+func CompareWorkloadReports(before, after *WorkloadReport) []*QueryComparison {
+	var comparisons []*QueryComparison
+	byQuery := make(map[string]*QueryComparison)
+	for _, qs := range before.Queries {
+		qc := &QueryComparison{SQL: qs.SQL, Before: qs}
+		byQuery[qs.SQL] = qc
+		comparisons = append(comparisons, qc)
+	}
+	for _, qs := range after.Queries {
+		qc, ok := byQuery[qs.SQL]
+		if !ok {
+			qc = &QueryComparison{SQL: qs.SQL}
+			byQuery[qs.SQL] = qc
+			comparisons = append(comparisons, qc)
+		}
+		qc.After = qs
+	}
+	for _, qc := range comparisons {
+		qc.Change = compareQueryStats(qc.Before, qc.After)
+	}
+
+	rank := map[string]int{ChangeWorse: 0, ChangeBetter: 1, ChangeSame: 2}
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		return rank[comparisons[i].Change] < rank[comparisons[j].Change]
+	})
+	return comparisons
+}
+
+// compareQueryStats ranks a query by whether vtgate can execute it, then
+// by its shard fan-out and then by its number of tablet queries.
+func compareQueryStats(before, after *QueryStats) string {
+	if before == nil || after == nil {
+		return ChangeSame
+	}
+	switch {
+	case before.Error != "" && after.Error == "":
+		return ChangeBetter
+	case before.Error == "" && after.Error != "":
+		return ChangeWorse
+	case after.ShardFanout < before.ShardFanout:
+		return ChangeBetter
+	case after.ShardFanout > before.ShardFanout:
+		return ChangeWorse
+	case after.TabletQueries < before.TabletQueries:
+		return ChangeBetter
+	case after.TabletQueries > before.TabletQueries:
+		return ChangeWorse
+	}
+	return ChangeSame
+}
+
+// ComparisonAsText returns a text representation of the comparison.
+func ComparisonAsText(comparisons []*QueryComparison) string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "CHANGE\tBEFORE\tAFTER\tQUERY\n")
+	for _, qc := range comparisons {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", qc.Change, describeQueryStats(qc.Before), describeQueryStats(qc.After), qc.SQL)
+	}
+	w.Flush()
+	return b.String()
+}
+
+func describeQueryStats(qs *QueryStats) string {
+	switch {
+	case qs == nil:
+		return "-"
+	case qs.Error != "":
+		return "ERROR"
+	}
+	return fmt.Sprintf("%v, %v shards, %v tablet queries", qs.PlanType, qs.ShardFanout, qs.TabletQueries)
+}
+
+// ComparisonAsJSON returns a json representation of the comparison.
+func ComparisonAsJSON(comparisons []*QueryComparison) string {
+	comparisonJSON, _ := jsonutil.MarshalIndentNoEscape(comparisons, "", "    ")
+	return string(comparisonJSON)
+}
+
+// CompareVSchemas analyzes the workload with each VSchema in turn, and
+// compares the reports. The environment set up by a previous Init is
+// stopped, and the one of the second VSchema is left running.
+func CompareVSchemas(queries []*LoggedQuery, beforeVSchema, afterVSchema, sqlSchema, ksShardMap string, opts *Options) ([]*QueryComparison, error) {
+	Stop()
+	if err := Init(beforeVSchema, sqlSchema, ksShardMap, opts); err != nil {
+		return nil, err
+	}
+	before := AnalyzeWorkload(queries)
+	Stop()
+	if err := Init(afterVSchema, sqlSchema, ksShardMap, opts); err != nil {
+		return nil, err
+	}
+	after := AnalyzeWorkload(queries)
+	return CompareWorkloadReports(before, after), nil
+}
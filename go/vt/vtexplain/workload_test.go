@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtexplain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryLog(t *testing.T) {
+	testcases := []struct {
+		format string
+		log    string
+		want   []string
+	}{{
+		format: QueryLogFormatText,
+		log: "Execute\t127.0.0.1:1234\tuser\t'user'\t''\t2020-10-19 08:00:00.000000\t2020-10-19 08:00:00.001000\t0.001000\t0.000100\t0.000800\t0.000000\tSELECT\t\"select * from user where id = :vtg1\"\tmap[vtg1:type:INT64 value:\"1\" ]\t1\t0\t\"\"\t\"ks\"\t\"user\"\t\"MASTER\"\t\n" +
+			"Execute\t127.0.0.1:1234\tuser\t'user'\t''\t2020-10-19 08:00:00.000000\t2020-10-19 08:00:00.001000\t0.001000\t0.000100\t0.000800\t0.000000\tINSERT\t\"insert into t1 values ('a\\tb')\"\tmap[]\t1\t1\t\"\"\t\"ks\"\t\"t1\"\t\"MASTER\"\t\n",
+		want: []string{
+			"select * from user where id = :vtg1",
+			"insert into t1 values ('a\tb')",
+		},
+	}, {
+		format: QueryLogFormatJSON,
+		log:    `{"Method": "Execute", "SQL": "select * from user where id = :vtg1", "BindVars": {"vtg1": {"type": "INT64", "value": 5}}, "ShardQueries": 1}` + "\n",
+		want:   []string{"select * from user where id = :vtg1"},
+	}, {
+		format: QueryLogFormatGeneral,
+		log: "/usr/sbin/mysqld, Version: 5.7.31-log (MySQL Community Server (GPL)). started with:\n" +
+			"Tcp port: 3306  Unix socket: /var/run/mysqld/mysqld.sock\n" +
+			"Time                 Id Command    Argument\n" +
+			"2020-10-19T08:00:00.123456Z\t   12 Connect\tuser@localhost on ks using Socket\n" +
+			"2020-10-19T08:00:00.123457Z\t   12 Query\tselect * from user where id = 1\n" +
+			"201019  8:00:01\t   12 Query\tselect *\n" +
+			"from music\n" +
+			"\t\t   12 Quit\t\n",
+		want: []string{
+			"select * from user where id = 1",
+			"select *\nfrom music",
+		},
+	}, {
+		format: QueryLogFormatSlow,
+		log: "/usr/sbin/mysqld, Version: 5.7.31-log (MySQL Community Server (GPL)). started with:\n" +
+			"Tcp port: 3306  Unix socket: /var/run/mysqld/mysqld.sock\n" +
+			"Time                 Id Command    Argument\n" +
+			"# Time: 2020-10-19T08:00:00.123456Z\n" +
+			"# User@Host: user[user] @ localhost []  Id:    12\n" +
+			"# Query_time: 2.000000  Lock_time: 0.000100 Rows_sent: 1  Rows_examined: 100000\n" +
+			"use ks;\n" +
+			"SET timestamp=1603094400;\n" +
+			"select * from user where id = 1;\n" +
+			"# Query_time: 3.000000  Lock_time: 0.000100 Rows_sent: 1  Rows_examined: 100000\n" +
+			"SET timestamp=1603094401;\n" +
+			"select *\n" +
+			"from music;\n",
+		want: []string{
+			"select * from user where id = 1",
+			"select *\nfrom music",
+		},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.format, func(t *testing.T) {
+			queries, err := ParseQueryLog(strings.NewReader(tcase.log), tcase.format)
+			require.NoError(t, err)
+			var got []string
+			for _, q := range queries {
+				got = append(got, q.SQL)
+			}
+			assert.Equal(t, tcase.want, got)
+		})
+	}
+
+	queries, err := ParseQueryLog(strings.NewReader(testcases[1].log), QueryLogFormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "5", string(queries[0].BindVars["vtg1"].Value))
+
+	_, err = ParseQueryLog(strings.NewReader(""), "binlog")
+	assert.EqualError(t, err, `unknown query log format "binlog"`)
+}
+
+const (
+	workloadSchema = "create table t (id bigint, name varchar(64), val int, primary key (id));"
+
+	byIDVSchema = `{"ks": {"sharded": true, "vindexes": {"hash": {"type": "hash"}, "md5": {"type": "unicode_loose_md5"}},
+		"tables": {"t": {"column_vindexes": [{"column": "id", "name": "hash"}]}}}}`
+
+	byNameVSchema = `{"ks": {"sharded": true, "vindexes": {"hash": {"type": "hash"}, "md5": {"type": "unicode_loose_md5"}},
+		"tables": {"t": {"column_vindexes": [{"column": "name", "name": "md5"}]}}}}`
+)
+
+func TestAnalyzeWorkload(t *testing.T) {
+	opts := &Options{
+		ReplicationMode: "ROW",
+		NumShards:       4,
+		ExecutionMode:   ModeMulti,
+	}
+	require.NoError(t, Init(byIDVSchema, workloadSchema, "", opts))
+	defer Stop()
+
+	queries := []*LoggedQuery{
+		{SQL: "begin"},
+		{SQL: "select * from t where id = 1"},
+		{SQL: "select * from t where id = 4"},
+		{SQL: "commit"},
+		{SQL: "select * from t where name = 'a'"},
+		{SQL: "select * from t where id = :vtg1"},
+		{SQL: "select * from unknown_table"},
+	}
+	report := AnalyzeWorkload(queries)
+	assert.Equal(t, 5, report.TotalQueries)
+	assert.Equal(t, 1, report.Errors)
+	require.Len(t, report.Queries, 4)
+
+	byName := report.Queries[0]
+	assert.Equal(t, "select * from t where name = :v1", byName.SQL)
+	assert.Equal(t, "SelectScatter", byName.PlanType)
+	assert.Equal(t, 4, byName.ShardFanout)
+	assert.Equal(t, 4, byName.TabletQueries)
+
+	byID := report.Queries[1]
+	assert.Equal(t, "select * from t where id = :v1", byID.SQL)
+	assert.Equal(t, 2, byID.Count)
+	assert.Equal(t, "SelectEqualUnique", byID.PlanType)
+	assert.Equal(t, 1, byID.ShardFanout)
+	assert.Equal(t, 2, byID.TabletQueries)
+	// The executions are routed with their own values.
+	assert.Equal(t, map[string]int{"ks/-40": 1, "ks/c0-": 1}, byID.ShardLoad)
+
+	unknown := report.Queries[3]
+	assert.Contains(t, unknown.Error, "table unknown_table not found")
+
+	load := 0
+	for _, l := range report.ShardLoad {
+		load += l
+	}
+	assert.Equal(t, 7, load)
+
+	text := WorkloadAsText(report)
+	assert.Contains(t, text, "5 queries, 4 distinct, 1 errors")
+	assert.Contains(t, text, "Shard load:\nks/-40")
+
+	comparisons, err := CompareVSchemas(queries, byIDVSchema, byNameVSchema, workloadSchema, "", opts)
+	require.NoError(t, err)
+	changes := make(map[string]string)
+	for _, qc := range comparisons {
+		changes[qc.SQL] = qc.Change
+	}
+	assert.Equal(t, map[string]string{
+		"select * from t where id = :v1":   ChangeWorse,
+		"select * from t where id = :vtg1": ChangeWorse,
+		"select * from t where name = :v1": ChangeBetter,
+		"select * from unknown_table":      ChangeSame,
+	}, changes)
+	assert.Equal(t, ChangeWorse, comparisons[0].Change)
+	assert.Contains(t, ComparisonAsText(comparisons), "worse   SelectEqualUnique, 1 shards, 2 tablet queries  SelectScatter, 4 shards, 8 tablet queries")
+}
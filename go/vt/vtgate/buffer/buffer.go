@@ -20,6 +20,19 @@ limitations under the License.
 // becomes unavailable), the buffer will automatically retry buffered requests
 // after the end of the failover was detected.
 //
+// The buffer also holds requests while the writes of a MoveTables or Reshard
+// workflow are switched to the target keyspace or shards. Such a cutover
+// denies the moved tables or stops serving the source shards, and it ends when
+// the routing rules or the SrvKeyspace reflect the new routing. Since the
+// buffered requests must not go to their original shard again, they are
+// returned ErrRoutingChanged. vtgate plans a single statement which failed
+// with it again, unless the statement was part of a transaction. Streaming
+// requests and statements which ran on several shards are not planned again,
+// and the application gets the UNAVAILABLE code of ErrRoutingChanged, which
+// means that it can retry the request. Requests in a transaction are never
+// buffered, and VStream requests follow the journal events of the cutover
+// instead.
+//
 // Buffering (stalling) requests will increase the number of requests in flight
 // within vtgate and at upstream layers. Therefore, it is important to limit
 // the size of the buffer and the buffering duration (window) per request.
@@ -32,6 +45,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 
 	"vitess.io/vitess/go/sync2"
@@ -41,6 +55,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -50,6 +65,11 @@ var (
 	contextCanceledError = vterrors.New(vtrpcpb.Code_UNAVAILABLE, "context was canceled before failover finished")
 )
 
+// ErrRoutingChanged is returned to the requests which were buffered during
+// a MoveTables or Reshard cutover. It keeps its UNAVAILABLE code when it's
+// wrapped, so the request can be retried.
+var ErrRoutingChanged = vterrors.New(vtrpcpb.Code_UNAVAILABLE, "routing changed while the request was buffered during a cutover, retry the request")
+
 // bufferMode specifies how the buffer is configured for a given shard.
 type bufferMode int
 
@@ -95,10 +115,22 @@ type Buffer struct {
 	// progress.
 	// Key Format: "<keyspace>/<shard>"
 	buffers map[string]*shardBuffer
+	// shardServing is passed to new shardBuffer objects. It is set by
+	// SetShardServingFunc().
+	shardServing ShardServingFunc
+	// routingRules are the routing rules of the last SrvVSchema passed to
+	// ProcessSrvVSchema().
+	routingRules *vschemapb.RoutingRules
 	// stopped is true after Shutdown() was run.
 	stopped bool
 }
 
+// ShardServingFunc returns false if keyspace/shard no longer serves MASTER
+// traffic according to the SrvKeyspace which vtgate uses to route requests,
+// e.g. after the writes of a Reshard workflow were switched to the target
+// shards.
+type ShardServingFunc func(keyspace, shard string) bool
+
 // New creates a new Buffer object.
 func New() *Buffer {
 	return newWithNow(time.Now)
@@ -153,6 +185,15 @@ func newWithNow(now func() time.Time) *Buffer {
 	}
 }
 
+// SetShardServingFunc sets the function which is used to detect the end of
+// a Reshard cutover while requests for a shard are buffered.
+// It must be called before the first request goes through the buffer.
+func (b *Buffer) SetShardServingFunc(f ShardServingFunc) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.shardServing = f
+}
+
 // mode determines for the given keyspace and shard if buffering, dry-run
 // buffering or no buffering at all should be enabled.
 func (b *Buffer) mode(keyspace, shard string) bufferMode {
@@ -188,14 +229,16 @@ type RetryDoneFunc context.CancelFunc
 // WaitForFailoverEnd blocks until a pending buffering due to a failover for
 // keyspace/shard is over.
 // If there is no ongoing failover, "err" is checked. If it's caused by a
-// failover, buffering may be started.
-// It returns an error if buffering failed (e.g. buffer full).
+// failover or a cutover, buffering may be started.
+// It returns an error if buffering failed (e.g. buffer full). If the routing
+// changed while the request was buffered, the error satisfies
+// IsRoutingChangedError() and the request must be planned again.
 // If it does not return an error, it may return a RetryDoneFunc which must be
 // called after the request was retried.
 func (b *Buffer) WaitForFailoverEnd(ctx context.Context, keyspace, shard string, err error) (RetryDoneFunc, error) {
-	// If an err is given, it must be related to a failover.
+	// If an err is given, it must be related to a failover or a cutover.
 	// We never buffer requests with other errors.
-	if err != nil && !causedByFailover(err) {
+	if err != nil && !causedByFailover(err) && !causedByCutover(err) {
 		return nil, nil
 	}
 
@@ -257,6 +300,43 @@ func (b *Buffer) StatsUpdate(ts *discovery.LegacyTabletStats) {
 	sb.recordExternallyReparentedTimestamp(timestamp, ts.Tablet.Alias)
 }
 
+// ProcessSrvVSchema ends the buffering for shards with requests whose tables
+// were denied during a MoveTables cutover, once the routing rules changed.
+func (b *Buffer) ProcessSrvVSchema(srvVSchema *vschemapb.SrvVSchema) {
+	routingRules := srvVSchema.RoutingRules
+	if routingRules == nil {
+		routingRules = &vschemapb.RoutingRules{}
+	}
+
+	b.mu.Lock()
+	// The first SrvVSchema only records the routing rules.
+	changed := b.routingRules != nil && !proto.Equal(b.routingRules, routingRules)
+	b.routingRules = routingRules
+	var sbs []*shardBuffer
+	if changed && !b.stopped {
+		for _, sb := range b.buffers {
+			sbs = append(sbs, sb)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, sb := range sbs {
+		sb.stopBufferingDueToRoutingRulesChange()
+	}
+}
+
+// IsRoutingChangedError returns true if "err" is ErrRoutingChanged, or
+// wraps it with vterrors.Wrap(). Errors which were aggregated from several
+// shards do not wrap it anymore.
+func IsRoutingChangedError(err error) bool {
+	for ; err != nil; err = vterrors.Cause(err) {
+		if err == ErrRoutingChanged {
+			return true
+		}
+	}
+	return false
+}
+
 // causedByFailover returns true if "err" was supposedly caused by a failover.
 // To simplify things, we've merged the detection for different MySQL flavors
 // in one function. Supported flavors: MariaDB, MySQL, Google internal.
@@ -288,6 +368,17 @@ func causedByFailover(err error) bool {
 	return false
 }
 
+// causedByCutover returns true if "err" was caused by the tables of the request
+// being denied on the source shards during a MoveTables cutover.
+// The cutovers of a Reshard stop the query service of the source shards and
+// are detected by causedByFailover().
+func causedByCutover(err error) bool {
+	if vterrors.Code(err) != vtrpcpb.Code_FAILED_PRECONDITION {
+		return false
+	}
+	return strings.Contains(err.Error(), "disallowed due to rule: enforce blacklisted tables")
+}
+
 // getOrCreateBuffer returns the ShardBuffer for the given keyspace and shard.
 // It returns nil if Buffer is shut down and all calls should be ignored.
 func (b *Buffer) getOrCreateBuffer(keyspace, shard string) *shardBuffer {
@@ -309,7 +400,7 @@ func (b *Buffer) getOrCreateBuffer(keyspace, shard string) *shardBuffer {
 	// Look it up again because it could have been created in the meantime.
 	sb, ok = b.buffers[key]
 	if !ok {
-		sb = newShardBuffer(b.mode(keyspace, shard), keyspace, shard, b.now, b.bufferSizeSema)
+		sb = newShardBuffer(b.mode(keyspace, shard), keyspace, shard, b.now, b.bufferSizeSema, b.shardServing)
 		b.buffers[key] = sb
 	}
 	return sb
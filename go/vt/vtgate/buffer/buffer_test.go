@@ -26,12 +26,14 @@ import (
 
 	"golang.org/x/net/context"
 
+	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -45,6 +47,8 @@ const (
 var (
 	failoverErr = vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION,
 		"vttablet: rpc error: code = 9 desc = gRPCServerError: retry: operation not allowed in state SHUTTING_DOWN")
+	deniedTablesErr = vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION,
+		"vttablet: rpc error: code = FailedPrecondition desc = disallowed due to rule: enforce blacklisted tables (CallerID: userData1)")
 	nonFailoverErr = vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION,
 		"vttablet: rpc error: code = 9 desc = gRPCServerError: retry: TODO(mberlin): Insert here any realistic error not caused by a failover")
 
@@ -902,6 +906,154 @@ func TestShutdown(t *testing.T) {
 	}
 }
 
+// TestCutoverDeniedTables tests that requests whose tables were denied during
+// a MoveTables cutover are buffered until the routing rules change.
+func TestCutoverDeniedTables(t *testing.T) {
+	resetVariables()
+	defer checkVariables(t)
+
+	flag.Set("enable_buffer", "true")
+	defer resetFlagsForTesting()
+	b := New()
+	b.ProcessSrvVSchema(&vschemapb.SrvVSchema{})
+
+	stopped1 := make(chan error)
+	go func() {
+		_, err := b.WaitForFailoverEnd(context.Background(), keyspace, shard, deniedTablesErr)
+		stopped1 <- err
+	}()
+	if err := waitForRequestsInFlight(b, 1); err != nil {
+		t.Fatal(err)
+	}
+	stopped2 := issueRequest(context.Background(), t, b, nil)
+	if err := waitForRequestsInFlight(b, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same routing rules do not stop the buffering.
+	b.ProcessSrvVSchema(&vschemapb.SrvVSchema{})
+	if err := waitForRequestsInFlight(b, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Switch the routing of the table.
+	b.ProcessSrvVSchema(&vschemapb.SrvVSchema{
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{{FromTable: "t1", ToTables: []string{"ks2.t1"}}},
+		},
+	})
+	if err := <-stopped1; !IsRoutingChangedError(err) {
+		t.Fatalf("buffered request should have returned a routing changed error: %v", err)
+	}
+	if err := <-stopped2; !IsRoutingChangedError(err) {
+		t.Fatalf("buffered request should have returned a routing changed error: %v", err)
+	}
+	if got, want := stops.Counts()[statsKeyJoined+"."+string(stopRoutingRulesChanged)], int64(1); got != want {
+		t.Fatalf("buffering stop was not tracked: got = %v, want = %v", got, want)
+	}
+	if err := waitForState(b, stateIdle); err != nil {
+		t.Fatal(err)
+	}
+	if err := waitForPoolSlots(b, *size); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestCutoverFailoverIgnoresRoutingRules tests that a change of the routing
+// rules does not stop the buffering of a failover.
+func TestCutoverFailoverIgnoresRoutingRules(t *testing.T) {
+	resetVariables()
+	defer checkVariables(t)
+
+	flag.Set("enable_buffer", "true")
+	defer resetFlagsForTesting()
+	b := New()
+	b.ProcessSrvVSchema(&vschemapb.SrvVSchema{})
+
+	stopped := issueRequest(context.Background(), t, b, failoverErr)
+	if err := waitForRequestsInFlight(b, 1); err != nil {
+		t.Fatal(err)
+	}
+	b.ProcessSrvVSchema(&vschemapb.SrvVSchema{
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{{FromTable: "t1", ToTables: []string{"ks2.t1"}}},
+		},
+	})
+	if err := waitForState(b, stateBuffering); err != nil {
+		t.Fatal(err)
+	}
+
+	b.StatsUpdate(&discovery.LegacyTabletStats{
+		Tablet:                              newMaster,
+		Target:                              &querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: topodatapb.TabletType_MASTER},
+		TabletExternallyReparentedTimestamp: 1, // Use any value > 0.
+	})
+	if err := <-stopped; err != nil {
+		t.Fatalf("request should have been buffered and not returned an error: %v", err)
+	}
+	if err := waitForState(b, stateIdle); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestCutoverShardNotServing tests that buffering stops when the shard no
+// longer serves MASTER traffic after a Reshard cutover.
+func TestCutoverShardNotServing(t *testing.T) {
+	resetVariables()
+	defer checkVariables(t)
+
+	flag.Set("enable_buffer", "true")
+	defer resetFlagsForTesting()
+	b := New()
+	var serving sync2.AtomicBool
+	serving.Set(true)
+	b.SetShardServingFunc(func(ks, s string) bool {
+		return ks != keyspace || s != shard || serving.Get()
+	})
+
+	stopped := issueRequest(context.Background(), t, b, failoverErr)
+	if err := waitForRequestsInFlight(b, 1); err != nil {
+		t.Fatal(err)
+	}
+	// Buffering continues while the shard is serving.
+	time.Sleep(2 * shardServingCheckInterval)
+	if err := waitForRequestsInFlight(b, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Migrate the MASTER traffic to other shards.
+	serving.Set(false)
+	if err := <-stopped; !IsRoutingChangedError(err) {
+		t.Fatalf("buffered request should have returned a routing changed error: %v", err)
+	}
+	if got, want := stops.Counts()[statsKeyJoined+"."+string(stopShardNotServing)], int64(1); got != want {
+		t.Fatalf("buffering stop was not tracked: got = %v, want = %v", got, want)
+	}
+	if err := waitForState(b, stateIdle); err != nil {
+		t.Fatal(err)
+	}
+	if err := waitForPoolSlots(b, *size); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsRoutingChangedError(t *testing.T) {
+	wrapped := vterrors.Wrapf(ErrRoutingChanged, "target: ks1.0.master")
+	if !IsRoutingChangedError(wrapped) {
+		t.Fatalf("IsRoutingChangedError(%v) = false, want true", wrapped)
+	}
+	if got, want := vterrors.Code(wrapped), vtrpcpb.Code_UNAVAILABLE; got != want {
+		t.Fatalf("wrong code for %v: got = %v, want = %v", wrapped, got, want)
+	}
+	// An error with the same message is not enough.
+	copied := vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "target: ks1.0.master: %v", ErrRoutingChanged)
+	for _, err := range []error{nil, failoverErr, deniedTablesErr, copied} {
+		if IsRoutingChangedError(err) {
+			t.Fatalf("IsRoutingChangedError(%v) = true, want false", err)
+		}
+	}
+}
+
 // resetVariables resets the task level variables. The code does not reset these
 // with very failover.
 func resetVariables() {
@@ -62,6 +62,9 @@ type shardBuffer struct {
 	now      func() time.Time
 	// bufferSizeSema is the shared pool of slots. See "Buffer.bufferSizeSema".
 	bufferSizeSema *sync2.Semaphore
+	// shardServing is checked by the timeout thread to detect the end of a
+	// Reshard cutover. It may be nil.
+	shardServing ShardServingFunc
 	// statsKey is used to update the stats variables.
 	statsKey []string
 	// statsKeyJoined is all elements of "statsKey" in one string, joined by ".".
@@ -90,6 +93,9 @@ type shardBuffer struct {
 	lastReparent time.Time
 	// currentMaster is tracked to determine when to update "lastReparent".
 	currentMaster *topodatapb.TabletAlias
+	// deniedTables is true if a request of the current buffering failed
+	// because its tables were denied during a MoveTables cutover.
+	deniedTables bool
 	// timeoutThread will be set while a failover is in progress and the object is
 	// in the BUFFERING state.
 	timeoutThread *timeoutThread
@@ -120,7 +126,7 @@ type entry struct {
 	bufferCancel func()
 }
 
-func newShardBuffer(mode bufferMode, keyspace, shard string, now func() time.Time, bufferSizeSema *sync2.Semaphore) *shardBuffer {
+func newShardBuffer(mode bufferMode, keyspace, shard string, now func() time.Time, bufferSizeSema *sync2.Semaphore, shardServing ShardServingFunc) *shardBuffer {
 	statsKey := []string{keyspace, shard}
 	initVariablesForShard(statsKey)
 
@@ -130,6 +136,7 @@ func newShardBuffer(mode bufferMode, keyspace, shard string, now func() time.Tim
 		shard:          shard,
 		now:            now,
 		bufferSizeSema: bufferSizeSema,
+		shardServing:   shardServing,
 		statsKey:       statsKey,
 		statsKeyJoined: fmt.Sprintf("%s.%s", keyspace, shard),
 		logTooRecent:   logutil.NewThrottledLogger(fmt.Sprintf("FailoverTooRecent-%v", topoproto.KeyspaceShardString(keyspace, shard)), 5*time.Second),
@@ -217,6 +224,9 @@ func (sb *shardBuffer) waitForFailoverEnd(ctx context.Context, keyspace, shard s
 
 		sb.startBufferingLocked(err)
 	}
+	if err != nil && causedByCutover(err) {
+		sb.deniedTables = true
+	}
 
 	if sb.mode == bufferDryRun {
 		sb.mu.Unlock()
@@ -269,6 +279,7 @@ func (sb *shardBuffer) startBufferingLocked(err error) {
 	sb.logErrorIfStateNotLocked(stateIdle)
 	sb.state = stateBuffering
 	sb.queue = make([]*entry, 0)
+	sb.deniedTables = false
 
 	sb.timeoutThread = newTimeoutThread(sb)
 	sb.timeoutThread.start()
@@ -388,7 +399,13 @@ func (sb *shardBuffer) wait(ctx context.Context, e *entry) (RetryDoneFunc, error
 		sb.remove(e)
 		return nil, vterrors.Errorf(vterrors.Code(contextCanceledError), "%v: %v", contextCanceledError, ctx.Err())
 	case <-e.done:
-		return e.bufferCancel, e.err
+		if e.err != nil {
+			// The request will not be retried. Finish it right away such
+			// that its slot in the buffer can be reused.
+			e.bufferCancel()
+			return nil, e.err
+		}
+		return e.bufferCancel, nil
 	}
 }
 
@@ -507,6 +524,31 @@ func (sb *shardBuffer) stopBufferingDueToMaxDuration() {
 		fmt.Sprintf("stopping buffering because failover did not finish in time (%v)", *maxFailoverDuration))
 }
 
+// stopBufferingIfShardNotServing is used by timeoutThread to stop buffering
+// once the shard no longer serves MASTER traffic i.e. a Reshard cutover ended.
+// It returns true if buffering was stopped.
+func (sb *shardBuffer) stopBufferingIfShardNotServing() bool {
+	if sb.shardServing(sb.keyspace, sb.shard) {
+		return false
+	}
+
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	sb.stopBufferingLocked(stopShardNotServing, "shard no longer serves MASTER traffic")
+	return true
+}
+
+func (sb *shardBuffer) stopBufferingDueToRoutingRulesChange() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if !sb.deniedTables {
+		return
+	}
+	sb.stopBufferingLocked(stopRoutingRulesChanged, "routing rules changed")
+}
+
 func (sb *shardBuffer) stopBufferingLocked(reason stopReason, details string) {
 	if sb.state != stateBuffering {
 		return
@@ -544,12 +586,19 @@ func (sb *shardBuffer) stopBufferingLocked(reason stopReason, details string) {
 	}
 	log.Infof("%v for shard: %s after: %.1f seconds due to: %v. Draining %d buffered requests now.", msg, topoproto.KeyspaceShardString(sb.keyspace, sb.shard), d.Seconds(), details, len(q))
 
+	// After a cutover, the buffered requests must not be retried against this
+	// shard. Instead, they get an error and are planned again.
+	var err error
+	if reason == stopShardNotServing || reason == stopRoutingRulesChanged {
+		err = ErrRoutingChanged
+	}
+
 	// Start the drain. (Use a new Go routine to release the lock.)
 	sb.wg.Add(1)
-	go sb.drain(q)
+	go sb.drain(q, err)
 }
 
-func (sb *shardBuffer) drain(q []*entry) {
+func (sb *shardBuffer) drain(q []*entry, err error) {
 	defer sb.wg.Done()
 
 	// stop must be called outside of the lock because the thread may access
@@ -559,7 +608,7 @@ func (sb *shardBuffer) drain(q []*entry) {
 	start := sb.now()
 	// TODO(mberlin): Parallelize the drain by pumping the data through a channel.
 	for _, e := range q {
-		sb.unblockAndWait(e, nil /* err */, true /* releaseSlot */, true /* blockingWait */)
+		sb.unblockAndWait(e, err, true /* releaseSlot */, true /* blockingWait */)
 	}
 	d := sb.now().Sub(start)
 	log.Infof("Draining finished for shard: %s Took: %v for: %d requests.", topoproto.KeyspaceShardString(sb.keyspace, sb.shard), d, len(q))
@@ -21,15 +21,22 @@ import (
 	"time"
 )
 
+// shardServingCheckInterval is how often the timeout thread checks if the
+// shard still serves MASTER traffic.
+const shardServingCheckInterval = 100 * time.Millisecond
+
 // timeoutThread captures the state of the timeout thread.
 // The thread actively removes the head of the queue when that entry exceeds
-// its buffering window.
+// its buffering window. It also stops the buffering when the shard no longer
+// serves MASTER traffic.
 // For each active failover there will be one thread (Go routine).
 type timeoutThread struct {
 	sb *shardBuffer
 	// maxDuration enforces that a failover stops after
 	// -buffer_max_failover_duration at most.
 	maxDuration *time.Timer
+	// shardServingCheck is nil if the shardBuffer has no ShardServingFunc.
+	shardServingCheck *time.Ticker
 	// stopChan will be closed when the thread should stop e.g. before the drain.
 	stopChan chan struct{}
 	wg       sync.WaitGroup
@@ -44,12 +51,16 @@ type timeoutThread struct {
 }
 
 func newTimeoutThread(sb *shardBuffer) *timeoutThread {
-	return &timeoutThread{
+	tt := &timeoutThread{
 		sb:            sb,
 		maxDuration:   time.NewTimer(*maxFailoverDuration),
 		stopChan:      make(chan struct{}),
 		queueNotEmpty: make(chan struct{}),
 	}
+	if sb.shardServing != nil {
+		tt.shardServingCheck = time.NewTicker(shardServingCheckInterval)
+	}
+	return tt
 }
 
 func (tt *timeoutThread) start() {
@@ -75,6 +86,9 @@ func (tt *timeoutThread) notifyQueueNotEmpty() {
 func (tt *timeoutThread) run() {
 	defer tt.wg.Done()
 	defer tt.maxDuration.Stop()
+	if tt.shardServingCheck != nil {
+		defer tt.shardServingCheck.Stop()
+	}
 
 	// While this thread is running, it can be in two states:
 	for {
@@ -107,6 +121,8 @@ func (tt *timeoutThread) waitForEntry(e *entry) bool {
 	case <-tt.stopChan:
 		// Failover ended before timeout. Do nothing.
 		return true
+	case <-tt.shardServingCheckChan():
+		return tt.sb.stopBufferingIfShardNotServing()
 	// b) Entry-specific checks.
 	case <-e.done:
 		// Entry was drained or evicted. Get the next entry.
@@ -140,9 +156,20 @@ func (tt *timeoutThread) waitForNonEmptyQueue() bool {
 	case <-tt.stopChan:
 		// Failover ended before timeout. Do nothing.
 		return true
+	case <-tt.shardServingCheckChan():
+		return tt.sb.stopBufferingIfShardNotServing()
 	// b) State-specific check.
 	case <-queueNotEmpty:
 		// At least one entry present. Check its timeout in the next iteration.
 		return false
 	}
 }
+
+// shardServingCheckChan returns the channel of the shardServingCheck ticker or
+// nil, which blocks forever, if there is none.
+func (tt *timeoutThread) shardServingCheckChan() <-chan time.Time {
+	if tt.shardServingCheck == nil {
+		return nil
+	}
+	return tt.shardServingCheck.C
+}
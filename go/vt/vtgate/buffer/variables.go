@@ -105,10 +105,14 @@ var (
 // stopReason is used in "stopsByReason" as "Reason" label.
 type stopReason string
 
-var stopReasons = []stopReason{stopFailoverEndDetected, stopMaxFailoverDurationExceeded, stopShutdown}
+var stopReasons = []stopReason{stopFailoverEndDetected, stopShardNotServing, stopRoutingRulesChanged, stopMaxFailoverDurationExceeded, stopShutdown}
 
 const (
-	stopFailoverEndDetected         stopReason = "NewMasterSeen"
+	stopFailoverEndDetected stopReason = "NewMasterSeen"
+	// stopShardNotServing and stopRoutingRulesChanged are the ends of a
+	// Reshard and a MoveTables cutover.
+	stopShardNotServing             stopReason = "ShardNotServing"
+	stopRoutingRulesChanged         stopReason = "RoutingRulesChanged"
 	stopMaxFailoverDurationExceeded stopReason = "MaxDurationExceeded"
 	stopShutdown                    stopReason = "Shutdown"
 )
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/tableacl"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// InitTableACL loads the table ACL config file whose column rules are
+// enforced when planning the queries. The file is reloaded like the table
+// ACL of the tablets: on SIGHUP, and every reloadInterval if it is not zero.
+func (vtg *VTGate) InitTableACL(configFile string, reloadInterval time.Duration) {
+	tableacl.InitAndReload(func() {
+		vtg.executor.initTableACL(configFile)
+	}, reloadInterval)
+}
+
+func (e *Executor) initTableACL(configFile string) {
+	// The plans are rebuilt with the new rules.
+	err := tableacl.Init(configFile, e.plans.Clear)
+	if err != nil {
+		log.Errorf("Fail to initialize Table ACL: %v", err)
+	}
+}
+
+// columnRestrictions enforces the column rules of the table ACL for the
+// caller of the query.
+type columnRestrictions struct {
+	caller *querypb.VTGateCallerID
+	key    string
+}
+
+// newColumnRestrictions returns nil if no column is restricted for the
+// caller.
+func newColumnRestrictions(ctx context.Context) *columnRestrictions {
+	caller := callerid.ImmediateCallerIDFromContext(ctx)
+	key := tableacl.ColumnRestrictionsKey(caller)
+	if key == "" {
+		return nil
+	}
+	return &columnRestrictions{caller: caller, key: key}
+}
+
+// planKey returns the part of the plan key which identifies the restricted
+// columns.
+func (cr *columnRestrictions) planKey() string {
+	if cr == nil {
+		return ""
+	}
+	return "[columns " + cr.key + "]"
+}
+
+// rewrite masks the restricted columns selected by stmt, and returns an
+// error if a denied column is used. It returns true if stmt was changed.
+func (cr *columnRestrictions) rewrite(stmt sqlparser.Statement) (bool, error) {
+	if cr == nil {
+		return false, nil
+	}
+	return tableacl.EnforceColumnRestrictions(stmt, func(table string) tableacl.ColumnRestrictions {
+		return tableacl.RestrictedColumns(table, cr.caller)
+	})
+}
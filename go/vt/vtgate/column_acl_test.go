@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/tableacl"
+	"vitess.io/vitess/go/vt/tableacl/simpleacl"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestColumnACL(t *testing.T) {
+	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int63())
+	tableacl.Register(aclName, &simpleacl.Factory{})
+	tableacl.SetDefaultACL(aclName)
+	config := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{{
+			Name:                 "users",
+			TableNamesOrPrefixes: []string{"user"},
+			ColumnRules: []*tableaclpb.ColumnRule{{
+				Columns: []string{"name"},
+				Readers: []string{"admin"},
+				Mask:    tableacl.MaskPartial,
+			}, {
+				Columns: []string{"ssn"},
+				Readers: []string{"admin"},
+			}},
+		}},
+	}
+	require.NoError(t, tableacl.InitFromProto(config))
+	defer func() {
+		_ = tableacl.InitFromProto(&tableaclpb.Config{})
+	}()
+
+	executor, sbc1, _, _ := createLegacyExecutorEnv()
+	app := &querypb.VTGateCallerID{Username: "app"}
+	admin := &querypb.VTGateCallerID{Username: "admin"}
+
+	tcases := []struct {
+		caller *querypb.VTGateCallerID
+		sql    string
+		want   string
+	}{{
+		caller: app,
+		sql:    "select id, name from user where id = 1",
+		want:   "select id, concat(repeat('*', greatest(char_length(name) - 4, 0)), right(name, 4)) as name from user where id = 1",
+	}, {
+		caller: admin,
+		sql:    "select id, name from user where id = 1",
+		want:   "select id, name from user where id = 1",
+	}}
+	for _, tcase := range tcases {
+		sbc1.Queries = nil
+		_, err := rowPolicyExec(executor, tcase.caller, tcase.sql)
+		require.NoError(t, err, tcase.sql)
+		require.NotEmpty(t, sbc1.Queries, tcase.sql)
+		assert.Equal(t, tcase.want, sbc1.Queries[len(sbc1.Queries)-1].Sql, tcase.sql)
+	}
+
+	_, err := rowPolicyExec(executor, app, "select id from user where ssn = '1'")
+	require.EqualError(t, err, "table acl error: access denied to column ssn of table user")
+	assert.Equal(t, vtrpcpb.Code_PERMISSION_DENIED, vterrors.Code(err))
+	_, err = rowPolicyExec(executor, admin, "select id from user where ssn = '1'")
+	require.NoError(t, err)
+
+	// The plans of the callers with different grants are not shared.
+	_, err = rowPolicyExec(executor, app, "select id from user where ssn = '1'")
+	require.Error(t, err)
+}
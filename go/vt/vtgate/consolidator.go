@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const pathConsolidations = "/debug/consolidations"
+
+var consolidatorWaits = stats.NewTimings("ConsolidatorWaits", "Time queries waited for an identical query to finish in the vtgate consolidator", "Type")
+
+// canConsolidate returns true if the result of plan can be shared with
+// the identical queries which run at the same time. Only the reads outside
+// of transactions and reserved connections are consolidated, and the reads
+// which change the state of the database, like sequences or locks, are not.
+func (e *Executor) canConsolidate(plan *engine.Plan, vcursor *vcursorImpl, safeSession *SafeSession) bool {
+	if e.consolidator == nil || plan.Type != sqlparser.StmtSelect || plan.Instructions == nil {
+		return false
+	}
+	if e.consolidateOnlyReplicas && vcursor.TabletType() == topodatapb.TabletType_MASTER {
+		return false
+	}
+	if safeSession.InTransaction() || safeSession.InReservedConn() {
+		return false
+	}
+	return !engine.Exists(func(p engine.Primitive) bool {
+		switch p := p.(type) {
+		case *engine.Route:
+			return p.Opcode == engine.SelectNext || p.ScatterErrorsAsWarnings
+		case *engine.Insert, *engine.Update, *engine.Delete, *engine.Lock, *engine.Send:
+			return true
+		}
+		return false
+	}, plan.Instructions)
+}
+
+// executeConsolidated runs execute unless an identical query of the same
+// immediate caller is already running, in which case it waits for it and
+// returns a copy of its result. The callers are not consolidated together
+// because they may not be allowed to read the same tables.
+func (e *Executor) executeConsolidated(plan *engine.Plan, vcursor *vcursorImpl, bindVars map[string]*querypb.BindVariable, execute func() (*sqltypes.Result, error)) (*sqltypes.Result, error) {
+	caller := callerid.ImmediateCallerIDFromContext(vcursor.Context())
+	q, original := e.consolidator.Create(resultCacheKey(vcursor.planPrefixKey(), caller, plan.Original, bindVars))
+	if original {
+		defer q.Broadcast()
+		qr, err := execute()
+		q.Result, q.Err = qr, err
+		return qr, err
+	}
+
+	startTime := time.Now()
+	q.Wait()
+	consolidatorWaits.Record("Consolidations", startTime)
+	if q.Err != nil {
+		return nil, q.Err
+	}
+	return q.Result.(*sqltypes.Result).Copy(), nil
+}
+
+// handleConsolidations shows how often the recent queries were consolidated.
+func (e *Executor) handleConsolidations(response http.ResponseWriter) {
+	response.Header().Set("Content-Type", "text/plain")
+	if e.consolidator == nil {
+		response.Write([]byte("consolidator is disabled\n"))
+		return
+	}
+	items := e.consolidator.Items()
+	if len(items) == 0 {
+		response.Write([]byte("empty\n"))
+		return
+	}
+	response.Write([]byte(fmt.Sprintf("Length: %d\n", len(items))))
+	for _, v := range items {
+		// The keys are made of the target, the query and its bind variables.
+		target, query, bindVars := splitResultCacheKey(v.Query)
+		if *streamlog.RedactDebugUIQueries {
+			query, _ = sqlparser.RedactSQLQuery(query)
+			bindVars = ""
+		}
+		response.Write([]byte(fmt.Sprintf("%v: [%s] %s%s\n", v.Count, target, query, bindVars)))
+	}
+}
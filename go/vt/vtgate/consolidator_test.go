@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/sync2"
+	"vitess.io/vitess/go/vt/callerid"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestCanConsolidate(t *testing.T) {
+	executor, _, _, _ := createLegacyExecutorEnv()
+	executor.consolidator = sync2.NewConsolidator()
+
+	tcases := []struct {
+		sql     string
+		session *vtgatepb.Session
+		want    bool
+	}{{
+		sql:  "select id from user where id = 1",
+		want: true,
+	}, {
+		sql:  "select u.id from user u join music m on u.id = m.id",
+		want: true,
+	}, {
+		sql:     "select id from user where id = 1",
+		session: &vtgatepb.Session{InTransaction: true},
+	}, {
+		sql:     "select id from user where id = 1",
+		session: &vtgatepb.Session{InReservedConn: true},
+	}, {
+		sql: "select next :n values from user_seq",
+	}, {
+		sql: "select /*vt+ SCATTER_ERRORS_AS_WARNINGS */ id from user",
+	}, {
+		sql: "select get_lock('lock', 10) from dual",
+	}, {
+		sql: "insert into main1(id) values (1)",
+	}}
+	for _, tcase := range tcases {
+		session := tcase.session
+		if session == nil {
+			session = &vtgatepb.Session{Autocommit: true}
+		}
+		safeSession := NewSafeSession(session)
+		vcursor, err := newVCursorImpl(context.Background(), safeSession, makeComments(""), executor, nil, executor.vm, executor.VSchema(), executor.resolver.resolver, executor.serv)
+		require.NoError(t, err)
+		plan, err := executor.getPlan(vcursor, tcase.sql, makeComments(""), nil, false, nil)
+		require.NoError(t, err, tcase.sql)
+		assert.Equal(t, tcase.want, executor.canConsolidate(plan, vcursor, safeSession), tcase.sql)
+	}
+}
+
+func TestCanConsolidateOnlyReplicas(t *testing.T) {
+	executor, _, _, _ := createLegacyExecutorEnv()
+	executor.consolidator = sync2.NewConsolidator()
+	executor.consolidateOnlyReplicas = true
+
+	for target, want := range map[string]bool{"@master": false, "@replica": true} {
+		safeSession := NewSafeSession(&vtgatepb.Session{TargetString: target, Autocommit: true})
+		vcursor, err := newVCursorImpl(context.Background(), safeSession, makeComments(""), executor, nil, executor.vm, executor.VSchema(), executor.resolver.resolver, executor.serv)
+		require.NoError(t, err)
+		plan, err := executor.getPlan(vcursor, "select id from user where id = 1", makeComments(""), nil, false, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, executor.canConsolidate(plan, vcursor, safeSession), target)
+	}
+}
+
+func TestExecuteConsolidated(t *testing.T) {
+	executor, _, _, _ := createLegacyExecutorEnv()
+	executor.consolidator = sync2.NewConsolidator()
+	safeSession := NewSafeSession(&vtgatepb.Session{Autocommit: true})
+	vcursor, err := newVCursorImpl(context.Background(), safeSession, makeComments(""), executor, nil, executor.vm, executor.VSchema(), executor.resolver.resolver, executor.serv)
+	require.NoError(t, err)
+	plan, err := executor.getPlan(vcursor, "select id from user where id = 1", makeComments(""), nil, false, nil)
+	require.NoError(t, err)
+
+	want := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	executions := sync2.NewAtomicInt64(0)
+	execute := func() (*sqltypes.Result, error) {
+		executions.Add(1)
+		close(started)
+		<-finish
+		return want, nil
+	}
+
+	originalResult := make(chan *sqltypes.Result)
+	go func() {
+		qr, err := executor.executeConsolidated(plan, vcursor, nil, execute)
+		assert.NoError(t, err)
+		originalResult <- qr
+	}()
+	<-started
+
+	waiterResult := make(chan *sqltypes.Result)
+	go func() {
+		qr, err := executor.executeConsolidated(plan, vcursor, nil, execute)
+		assert.NoError(t, err)
+		waiterResult <- qr
+	}()
+	// Wait for the second query to be counted as consolidated.
+	for len(executor.consolidator.Items()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The same query of another caller runs on its own.
+	callerCtx := callerid.NewContext(context.Background(), nil, &querypb.VTGateCallerID{Username: "other"})
+	otherVCursor, err := newVCursorImpl(callerCtx, safeSession, makeComments(""), executor, nil, executor.vm, executor.VSchema(), executor.resolver.resolver, executor.serv)
+	require.NoError(t, err)
+	qr, err := executor.executeConsolidated(plan, otherVCursor, nil, func() (*sqltypes.Result, error) {
+		executions.Add(1)
+		return want, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, want == qr)
+	close(finish)
+
+	assert.True(t, want == <-originalResult)
+	got := <-waiterResult
+	assert.Equal(t, want, got)
+	assert.False(t, want == got, "the waiter must get a copy of the result")
+	assert.EqualValues(t, 2, executions.Get())
+
+	// The debug page lists the consolidated queries.
+	request := httptest.NewRequest("GET", pathConsolidations, nil)
+	response := httptest.NewRecorder()
+	executor.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+	body, _ := ioutil.ReadAll(response.Body)
+	assert.Equal(t, "Length: 1\n1: [@master] select id from user where id = 1\n", string(body))
+}
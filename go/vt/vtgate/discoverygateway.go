@@ -37,6 +37,7 @@ import (
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -114,6 +115,8 @@ func NewDiscoveryGateway(ctx context.Context, hc discovery.LegacyHealthCheck, se
 		buffer:            buffer.New(),
 	}
 
+	dg.buffer.SetShardServingFunc(newShardServingFunc(serv, cell))
+
 	// Set listener which will update LegacyTabletStatsCache and MasterBuffer.
 	// We set sendDownEvents=true because it's required by LegacyTabletStatsCache.
 	hc.SetListener(dg, true /* sendDownEvents */)
@@ -211,6 +214,12 @@ func (dg *DiscoveryGateway) WaitForTablets(ctx context.Context, tabletTypesToWai
 	return dg.tsc.WaitForAllServingTablets(ctx, filteredTargets)
 }
 
+// ProcessSrvVSchema forwards a new SrvVSchema to the buffer, which ends the
+// buffering of MoveTables cutovers when the routing rules changed.
+func (dg *DiscoveryGateway) ProcessSrvVSchema(srvVSchema *vschemapb.SrvVSchema) {
+	dg.buffer.ProcessSrvVSchema(srvVSchema)
+}
+
 // Close shuts down underlying connections.
 // This function hides the inner implementation.
 func (dg *DiscoveryGateway) Close(ctx context.Context) error {
@@ -267,6 +276,12 @@ func (dg *DiscoveryGateway) withRetry(ctx context.Context, target *querypb.Targe
 		if !bufferedOnce && !inTransaction && target.TabletType == topodatapb.TabletType_MASTER {
 			// The next call blocks if we should buffer during a failover.
 			retryDone, bufferErr := dg.buffer.WaitForFailoverEnd(ctx, target.Keyspace, target.Shard, err)
+			if buffer.IsRoutingChangedError(bufferErr) {
+				// The request must be planned again. Keep the error,
+				// such that the executor can recognize it.
+				err = vterrors.Wrapf(bufferErr, "original err: %v", err)
+				break
+			}
 			if bufferErr != nil {
 				// Buffering failed e.g. buffer is already full. Do not retry.
 				err = vterrors.Errorf(
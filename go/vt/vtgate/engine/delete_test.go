@@ -87,6 +87,81 @@ func TestDeleteEqual(t *testing.T) {
 	expectError(t, "Execute", err, "execDeleteEqual: missing bind var aa")
 }
 
+func TestDeleteMultiColumn(t *testing.T) {
+	vindex, _ := vindexes.NewRegionExperimental("", map[string]string{"region_bytes": "1"})
+	del := &Delete{
+		DML: DML{
+			Opcode: Equal,
+			Keyspace: &vindexes.Keyspace{
+				Name:    "ks",
+				Sharded: true,
+			},
+			Query:  "dummy_delete",
+			Vindex: vindex,
+			Values: []sqltypes.PlanValue{{Value: sqltypes.NewInt64(1)}, {Value: sqltypes.NewInt64(1)}},
+		},
+	}
+
+	vc := newDMLTestVCursor("-20", "20-")
+	_, err := del.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyspaceID(01166b40b44aba4bd6)`,
+		`ExecuteMultiShard ks.-20: dummy_delete {} true true`,
+	})
+
+	del.Opcode = In
+	del.Values = []sqltypes.PlanValue{{Value: sqltypes.NewInt64(1)}, {Values: []sqltypes.PlanValue{{Value: sqltypes.NewInt64(1)}, {Value: sqltypes.NewInt64(2)}}}}
+	vc.Rewind()
+	_, err = del.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyspaceID(01166b40b44aba4bd6),DestinationKeyspaceID(0106e7ea22ce92708f)`,
+		`ExecuteMultiShard ks.-20: dummy_delete {} true true`,
+	})
+
+	del.Opcode = Prefix
+	del.Values = []sqltypes.PlanValue{{Value: sqltypes.NewInt64(0x20)}}
+	vc.Rewind()
+	_, err = del.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyRange(20-21)`,
+		`ExecuteMultiShard ks.-20: dummy_delete {} ks.20-: dummy_delete {} true false`,
+	})
+}
+
+func TestDeleteRange(t *testing.T) {
+	vindex, _ := vindexes.NewNumeric("", nil)
+	del := &Delete{
+		DML: DML{
+			Opcode: Range,
+			Keyspace: &vindexes.Keyspace{
+				Name:    "ks",
+				Sharded: true,
+			},
+			Query:  "dummy_delete",
+			Vindex: vindex.(vindexes.SingleColumn),
+			Values: []sqltypes.PlanValue{{Value: sqltypes.NewInt64(1000)}, {Key: "end"}},
+		},
+	}
+
+	vc := newDMLTestVCursor("-20", "20-")
+	_, err := del.Execute(vc, map[string]*querypb.BindVariable{"end": sqltypes.Int64BindVariable(2000)}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyRange(00000000000003e8-00000000000007d1)`,
+		`ExecuteMultiShard ks.-20: dummy_delete {end: type:INT64 value:"2000" } ks.20-: dummy_delete {end: type:INT64 value:"2000" } true false`,
+	})
+
+	// A vindex that is not ordered cannot route a range.
+	vindex, _ = vindexes.NewHash("hash", nil)
+	del.Vindex = vindex.(vindexes.SingleColumn)
+	vc.Rewind()
+	_, err = del.Execute(vc, map[string]*querypb.BindVariable{"end": sqltypes.Int64BindVariable(2000)}, false)
+	require.EqualError(t, err, "execDeleteIn: vindex hash cannot map a range of values")
+}
+
 func TestDeleteEqualNoRoute(t *testing.T) {
 	vindex, _ := vindexes.NewLookupUnique("", map[string]string{
 		"table": "lkp",
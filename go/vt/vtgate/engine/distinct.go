@@ -0,0 +1,248 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var _ Primitive = (*Distinct)(nil)
+
+// Distinct is a primitive that removes duplicate rows.
+// Two rows are duplicates if the values of all their Cols
+// compare equal. Text columns cannot be compared by vtgate,
+// so the planner supplies their weight_string instead.
+type Distinct struct {
+	Input Primitive
+
+	// Cols are the columns that are compared to detect duplicates.
+	Cols []int
+
+	// Ordered is set if the input is sorted on Cols. Duplicates are
+	// then adjacent, and can be removed without remembering the rows
+	// that were already returned.
+	Ordered bool `json:",omitempty"`
+
+	// TruncateColumnCount specifies the number of columns to return
+	// in the final result. Rest of the columns are truncated
+	// from the result received. If 0, no truncation happens.
+	TruncateColumnCount int `json:",omitempty"`
+}
+
+// RouteType returns a description of the query routing type used by the primitive.
+func (d *Distinct) RouteType() string {
+	return d.Input.RouteType()
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (d *Distinct) GetKeyspaceName() string {
+	return d.Input.GetKeyspaceName()
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (d *Distinct) GetTableName() string {
+	return d.Input.GetTableName()
+}
+
+// SetTruncateColumnCount sets the truncate column count.
+func (d *Distinct) SetTruncateColumnCount(count int) {
+	d.TruncateColumnCount = count
+}
+
+// Execute satisfies the Primitive interface.
+func (d *Distinct) Execute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	input, err := d.Input.Execute(vcursor, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+	dc := d.newChecker(vcursor)
+	defer dc.release()
+
+	result := &sqltypes.Result{
+		Fields:   input.Fields,
+		InsertID: input.InsertID,
+	}
+	for _, row := range input.Rows {
+		isNew, err := dc.isNew(row)
+		if err != nil {
+			return nil, err
+		}
+		if isNew {
+			result.Rows = append(result.Rows, row)
+		}
+	}
+	result.RowsAffected = uint64(len(result.Rows))
+	return result.Truncate(d.TruncateColumnCount), nil
+}
+
+// StreamExecute satisfies the Primitive interface.
+func (d *Distinct) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	dc := d.newChecker(vcursor)
+	defer dc.release()
+
+	return d.Input.StreamExecute(vcursor, bindVars, wantfields, func(qr *sqltypes.Result) error {
+		result := &sqltypes.Result{Fields: qr.Fields}
+		for _, row := range qr.Rows {
+			isNew, err := dc.isNew(row)
+			if err != nil {
+				return err
+			}
+			if isNew {
+				result.Rows = append(result.Rows, row)
+			}
+		}
+		if len(result.Fields) == 0 && len(result.Rows) == 0 {
+			return nil
+		}
+		return callback(result.Truncate(d.TruncateColumnCount))
+	})
+}
+
+// GetFields satisfies the Primitive interface.
+func (d *Distinct) GetFields(vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	qr, err := d.Input.GetFields(vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	return qr.Truncate(d.TruncateColumnCount), nil
+}
+
+// Inputs returns the input to distinct.
+func (d *Distinct) Inputs() []Primitive {
+	return []Primitive{d.Input}
+}
+
+// NeedsTransaction implements the Primitive interface.
+func (d *Distinct) NeedsTransaction() bool {
+	return d.Input.NeedsTransaction()
+}
+
+func (d *Distinct) description() PrimitiveDescription {
+	variant := "Hash"
+	if d.Ordered {
+		variant = "Ordered"
+	}
+	other := map[string]interface{}{
+		"Columns": GenericJoin(d.Cols, func(i interface{}) string { return fmt.Sprintf("%d", i) }),
+	}
+	if d.TruncateColumnCount > 0 {
+		other["ResultColumns"] = d.TruncateColumnCount
+	}
+	return PrimitiveDescription{
+		OperatorType: "Distinct",
+		Variant:      variant,
+		Other:        other,
+	}
+}
+
+func (d *Distinct) newChecker(vcursor VCursor) distinctChecker {
+	if d.Ordered {
+		return &orderedChecker{cols: d.Cols}
+	}
+	return &hashChecker{
+		cols:    d.Cols,
+		vcursor: vcursor,
+		budget:  vcursor.MemoryBudget(),
+		seen:    make(map[uint64][][]sqltypes.Value),
+	}
+}
+
+// distinctChecker tells whether a row is the first of its kind.
+type distinctChecker interface {
+	isNew(row []sqltypes.Value) (bool, error)
+	release()
+}
+
+// orderedChecker detects duplicates in rows sorted on cols
+// by comparing each row with the previous one.
+type orderedChecker struct {
+	cols []int
+	last []sqltypes.Value
+}
+
+func (oc *orderedChecker) isNew(row []sqltypes.Value) (bool, error) {
+	if oc.last != nil {
+		equal, err := rowsEqual(oc.cols, oc.last, row)
+		if err != nil || equal {
+			return false, err
+		}
+	}
+	oc.last = row
+	return true, nil
+}
+
+func (oc *orderedChecker) release() {}
+
+// hashChecker detects duplicates by remembering every distinct row,
+// bucketed by the hash code of their cols.
+type hashChecker struct {
+	cols     []int
+	vcursor  VCursor
+	budget   *MemoryBudget
+	reserved int64
+	count    int
+	seen     map[uint64][][]sqltypes.Value
+}
+
+func (hc *hashChecker) isNew(row []sqltypes.Value) (bool, error) {
+	var hash uint64
+	for _, col := range hc.cols {
+		h, err := evalengine.NullsafeHashcode(row[col])
+		if err != nil {
+			return false, err
+		}
+		hash = hash*31 + h
+	}
+	for _, seen := range hc.seen[hash] {
+		equal, err := rowsEqual(hc.cols, seen, row)
+		if err != nil || equal {
+			return false, err
+		}
+	}
+
+	size := rowMemorySize(row)
+	if err := hc.budget.Reserve(size); err != nil {
+		return false, err
+	}
+	hc.reserved += size
+	hc.count++
+	if hc.vcursor.ExceedsMaxMemoryRows(hc.count) {
+		return false, fmt.Errorf("in-memory row count exceeded allowed limit of %d", hc.vcursor.MaxMemoryRows())
+	}
+	hc.seen[hash] = append(hc.seen[hash], row)
+	return true, nil
+}
+
+func (hc *hashChecker) release() {
+	hc.budget.Release(hc.reserved)
+	hc.reserved = 0
+}
+
+func rowsEqual(cols []int, row1, row2 []sqltypes.Value) (bool, error) {
+	for _, col := range cols {
+		cmp, err := evalengine.NullsafeCompare(row1[col], row2[col])
+		if err != nil || cmp != 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestDistinctExecute(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"id|col|weight_string(col)",
+		"int64|varchar|varbinary",
+	)
+	testCases := []struct {
+		name    string
+		ordered bool
+		input   []string
+		want    []string
+	}{{
+		name: "hash",
+		input: []string{
+			"1|a|A",
+			"2|b|B",
+			"1|A|A",
+			"null|a|A",
+			"2|b|B",
+			"null|a|A",
+		},
+		want: []string{
+			"1|a",
+			"2|b",
+			"null|a",
+		},
+	}, {
+		name:    "ordered",
+		ordered: true,
+		input: []string{
+			"null|a|A",
+			"null|a|A",
+			"1|a|A",
+			"1|A|A",
+			"1|b|B",
+			"2|b|B",
+		},
+		want: []string{
+			"null|a",
+			"1|a",
+			"1|b",
+			"2|b",
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fp := &fakePrimitive{
+				results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, tc.input...)},
+			}
+			d := &Distinct{
+				Input:               fp,
+				Cols:                []int{0, 2},
+				Ordered:             tc.ordered,
+				TruncateColumnCount: 2,
+			}
+			want := sqltypes.MakeTestResult(fields[:2], tc.want...)
+
+			result, err := d.Execute(&loggingVCursor{}, nil, true)
+			require.NoError(t, err)
+			require.Equal(t, want, result)
+
+			fp.rewind()
+			result, err = wrapStreamExecute(d, &loggingVCursor{}, nil, true)
+			require.NoError(t, err)
+			require.Equal(t, want, result)
+		})
+	}
+}
+
+func TestDistinctMaxMemory(t *testing.T) {
+	saveMax := testMaxMemoryRows
+	testMaxMemoryRows = 2
+	defer func() {
+		testMaxMemoryRows = saveMax
+	}()
+
+	fields := sqltypes.MakeTestFields(
+		"id",
+		"int64",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"1",
+			"1",
+			"2",
+			"2",
+			"3",
+		)},
+	}
+	d := &Distinct{
+		Input: fp,
+		Cols:  []int{0},
+	}
+	err := d.StreamExecute(&loggingVCursor{}, nil, false, func(*sqltypes.Result) error { return nil })
+	require.EqualError(t, err, "in-memory row count exceeded allowed limit of 2")
+
+	// An ordered input does not need to keep any rows in memory.
+	fp.rewind()
+	d.Ordered = true
+	err = d.StreamExecute(&loggingVCursor{}, nil, false, func(*sqltypes.Result) error { return nil })
+	require.NoError(t, err)
+
+	// Each row takes 57 bytes.
+	fp.rewind()
+	d.Ordered = false
+	vc := &loggingVCursor{memoryBudget: NewMemoryBudget(100, nil, "")}
+	_, err = d.Execute(vc, nil, false)
+	require.EqualError(t, err, "in-memory byte count exceeded allowed limit of 100")
+	require.EqualValues(t, 0, vc.memoryBudget.Used())
+}
+
+func TestDistinctNotComparable(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"col",
+				"varchar",
+			),
+			"a",
+		)},
+	}
+	d := &Distinct{
+		Input: fp,
+		Cols:  []int{0},
+	}
+	_, err := d.Execute(&loggingVCursor{}, nil, false)
+	require.EqualError(t, err, "types are not hashable: VARCHAR")
+}
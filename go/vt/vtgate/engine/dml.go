@@ -40,10 +40,10 @@ type DML struct {
 	Query string
 
 	// Vindex specifies the vindex to be used.
-	Vindex vindexes.SingleColumn
+	Vindex vindexes.Vindex
 
 	// Values specifies the vindex values to use for routing.
-	// For now, only one value is specified.
+	// A multi-column vindex has one value per column.
 	Values []sqltypes.PlanValue
 
 	// Keyspace Id Vindex
@@ -86,6 +86,16 @@ const (
 	// Is used when the query explicitly sets a target destination:
 	// in the clause e.g: UPDATE `keyspace[-]`.x1 SET foo=1
 	ByDestination
+	// Prefix is for routing a dml statement that only constrains
+	// the prefix columns of a Prefixable Vindex to the shards that
+	// overlap the key ranges of their values.
+	// Requires: A Prefixable Vindex, and the prefix Values.
+	Prefix
+	// Range is for routing a dml statement that constrains the
+	// column of an Ordered Vindex to a range to the shards that
+	// overlap its key range.
+	// Requires: An Ordered Vindex, and the start and end Values.
+	Range
 )
 
 var opcodeName = map[DMLOpcode]string{
@@ -94,25 +104,38 @@ var opcodeName = map[DMLOpcode]string{
 	In:            "In",
 	Scatter:       "Scatter",
 	ByDestination: "ByDestination",
+	Prefix:        "Prefix",
+	Range:         "Range",
 }
 
 func (op DMLOpcode) String() string {
 	return opcodeName[op]
 }
 
-func resolveMultiValueShards(vcursor VCursor, keyspace *vindexes.Keyspace, query string, bindVars map[string]*querypb.BindVariable, pv sqltypes.PlanValue, vindex vindexes.SingleColumn) ([]*srvtopo.ResolvedShard, []*querypb.BoundQuery, error) {
-	keys, err := pv.ResolveList(bindVars)
-	if err != nil {
-		return nil, nil, vterrors.Wrap(err, "execDeleteIn")
+// resolveMultiValueShards returns the shards of the In, Prefix and
+// Range opcodes, and the query to send to each of them.
+func (dml *DML) resolveMultiValueShards(vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []*querypb.BoundQuery, error) {
+	var rss []*srvtopo.ResolvedShard
+	var err error
+	switch dml.Opcode {
+	case Prefix:
+		rss, err = resolvePrefixShards(vcursor, dml.Vindex, dml.Keyspace, dml.Values, bindVars)
+	case Range:
+		rss, err = resolveRangeShards(vcursor, dml.Vindex, dml.Keyspace, dml.Values, bindVars)
+	default:
+		var rows [][]sqltypes.Value
+		rows, err = resolveRows(dml.Values, bindVars)
+		if err == nil {
+			rss, err = resolveMultiShard(vcursor, dml.Vindex, dml.Keyspace, rows)
+		}
 	}
-	rss, err := resolveMultiShard(vcursor, vindex, keyspace, keys)
 	if err != nil {
 		return nil, nil, vterrors.Wrap(err, "execDeleteIn")
 	}
 	queries := make([]*querypb.BoundQuery, len(rss))
 	for i := range rss {
 		queries[i] = &querypb.BoundQuery{
-			Sql:           query,
+			Sql:           dml.Query,
 			BindVariables: bindVars,
 		}
 	}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var _ Primitive = (*DMLWithInput)(nil)
+
+// DMLWithInput is a primitive that modifies the rows selected by its Input.
+// It is used for DMLs that cannot be sent as is to the shards, like
+// multi-shard DMLs with a LIMIT, or multi-table DMLs whose tables live
+// on different shards. Input selects the primary key and the keyspace id
+// column of the rows to modify. The values of each of its columns are then
+// passed to the DML as a list bind variable, which the DML uses to route
+// the statement and to restrict it to the selected rows.
+type DMLWithInput struct {
+	// Input selects the rows to modify.
+	Input Primitive
+
+	// DML modifies the rows selected by Input.
+	DML Primitive
+
+	// BindVarNames are the names of the list bind variables
+	// passed to DML, one for each column of Input.
+	BindVarNames []string
+
+	txNeeded
+}
+
+// RouteType returns a description of the query routing type used by the primitive.
+func (dwi *DMLWithInput) RouteType() string {
+	return dwi.DML.RouteType()
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (dwi *DMLWithInput) GetKeyspaceName() string {
+	return dwi.DML.GetKeyspaceName()
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (dwi *DMLWithInput) GetTableName() string {
+	return dwi.DML.GetTableName()
+}
+
+// Execute satisfies the Primitive interface.
+func (dwi *DMLWithInput) Execute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool) (*sqltypes.Result, error) {
+	input, err := dwi.Input.Execute(vcursor, bindVars, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.Rows) == 0 {
+		return &sqltypes.Result{}, nil
+	}
+
+	dmlVars := make(map[string]*querypb.BindVariable, len(bindVars)+len(dwi.BindVarNames))
+	for k, v := range bindVars {
+		dmlVars[k] = v
+	}
+	for i, name := range dwi.BindVarNames {
+		dmlVars[name] = columnValues(input.Rows, i)
+	}
+	return dwi.DML.Execute(vcursor, dmlVars, false)
+}
+
+// columnValues returns the distinct non-null values of a column
+// as a list bind variable.
+func columnValues(rows [][]sqltypes.Value, col int) *querypb.BindVariable {
+	bv := &querypb.BindVariable{Type: querypb.Type_TUPLE}
+	seen := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		v := row[col]
+		if v.IsNull() {
+			continue
+		}
+		key := v.ToString()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		bv.Values = append(bv.Values, sqltypes.ValueToProto(v))
+	}
+	return bv
+}
+
+// StreamExecute satisfies the Primitive interface.
+func (dwi *DMLWithInput) StreamExecute(VCursor, map[string]*querypb.BindVariable, bool, func(*sqltypes.Result) error) error {
+	return fmt.Errorf("%s with input cannot be used for streaming", dwi.RouteType())
+}
+
+// GetFields satisfies the Primitive interface.
+func (dwi *DMLWithInput) GetFields(VCursor, map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return nil, fmt.Errorf("BUG: unreachable code for %s with input", dwi.RouteType())
+}
+
+// Inputs returns the input that selects the rows and the DML that modifies them.
+func (dwi *DMLWithInput) Inputs() []Primitive {
+	return []Primitive{dwi.Input, dwi.DML}
+}
+
+func (dwi *DMLWithInput) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType: "DMLWithInput",
+		Other: map[string]interface{}{
+			"BindVars": strings.Join(dwi.BindVarNames, ", "),
+		},
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestDMLWithInputExecute(t *testing.T) {
+	vindex, _ := vindexes.NewHash("", nil)
+	input := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"id|user_id",
+				"int64|int64",
+			),
+			"10|1",
+			"11|1",
+			"12|null",
+			"10|1",
+		)},
+	}
+	dwi := &DMLWithInput{
+		Input: input,
+		DML: &Delete{
+			DML: DML{
+				Opcode: In,
+				Keyspace: &vindexes.Keyspace{
+					Name:    "ks",
+					Sharded: true,
+				},
+				Query:  "dummy_delete",
+				Vindex: vindex.(vindexes.SingleColumn),
+				Values: []sqltypes.PlanValue{{ListKey: "__dml_vindex_vals"}},
+			},
+		},
+		BindVarNames: []string{"__dml_pk_vals", "__dml_vindex_vals"},
+	}
+	require.True(t, dwi.NeedsTransaction())
+
+	vc := newDMLTestVCursor("-20", "20-")
+	_, err := dwi.Execute(vc, map[string]*querypb.BindVariable{"a": sqltypes.Int64BindVariable(1)}, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{`Execute a: type:INT64 value:"1"  false`}, input.log)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard ks.-20: dummy_delete {__dml_pk_vals: type:TUPLE values:<type:INT64 value:"10" > values:<type:INT64 value:"11" > values:<type:INT64 value:"12" > ` +
+			`__dml_vindex_vals: type:TUPLE values:<type:INT64 value:"1" > a: type:INT64 value:"1" } true true`,
+	})
+
+	// No rows selected: nothing to modify.
+	input.results = []*sqltypes.Result{{}}
+	input.rewind()
+	vc.Rewind()
+	result, err := dwi.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.Equal(t, &sqltypes.Result{}, result)
+	vc.ExpectLog(t, nil)
+
+	// Input error.
+	input.results = nil
+	input.sendErr = errors.New("select error")
+	input.rewind()
+	_, err = dwi.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.EqualError(t, err, "select error")
+
+	err = dwi.StreamExecute(vc, map[string]*querypb.BindVariable{}, false, func(*sqltypes.Result) error { return nil })
+	require.EqualError(t, err, "DeleteIn with input cannot be used for streaming")
+}
@@ -139,6 +139,10 @@ func (t noopVCursor) ExceedsMaxMemoryRows(numRows int) bool {
 	return !testIgnoreMaxMemoryRows && numRows > testMaxMemoryRows
 }
 
+func (t noopVCursor) MemoryBudget() *MemoryBudget {
+	return nil
+}
+
 func (t noopVCursor) SetContextTimeout(timeout time.Duration) context.CancelFunc {
 	return func() {}
 }
@@ -210,6 +214,14 @@ type loggingVCursor struct {
 	log []string
 
 	resolvedTargetTabletType topodatapb.TabletType
+
+	memoryBudget *MemoryBudget
+
+	inTransaction bool
+}
+
+func (f *loggingVCursor) MemoryBudget() *MemoryBudget {
+	return f.memoryBudget
 }
 
 func (f *loggingVCursor) SetFoundRows(u uint64) {
@@ -217,7 +229,7 @@ func (f *loggingVCursor) SetFoundRows(u uint64) {
 }
 
 func (f *loggingVCursor) InTransactionAndIsDML() bool {
-	return false
+	return f.inTransaction
 }
 
 func (f *loggingVCursor) SetUDV(key string, value interface{}) error {
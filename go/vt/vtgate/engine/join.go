@@ -67,6 +67,10 @@ func (jn *Join) Execute(vcursor VCursor, bindVars map[string]*querypb.BindVariab
 		result.Fields = joinFields(lresult.Fields, rresult.Fields, jn.Cols)
 		return result, nil
 	}
+	// The joined rows are spilled to disk if they exceed the memory
+	// budget.
+	joined := newSpillBuffer(vcursor.MemoryBudget(), "Join")
+	defer joined.close()
 	for _, lrow := range lresult.Rows {
 		for k, col := range jn.Vars {
 			joinVars[k] = sqltypes.ValueBindVariable(lrow[col])
@@ -80,18 +84,26 @@ func (jn *Join) Execute(vcursor VCursor, bindVars map[string]*querypb.BindVariab
 			result.Fields = joinFields(lresult.Fields, rresult.Fields, jn.Cols)
 		}
 		for _, rrow := range rresult.Rows {
-			result.Rows = append(result.Rows, joinRows(lrow, rrow, jn.Cols))
+			if err := joined.add(joinRows(lrow, rrow, jn.Cols)); err != nil {
+				return nil, err
+			}
 		}
 		if jn.Opcode == LeftJoin && len(rresult.Rows) == 0 {
-			result.Rows = append(result.Rows, joinRows(lrow, nil, jn.Cols))
+			if err := joined.add(joinRows(lrow, nil, jn.Cols)); err != nil {
+				return nil, err
+			}
 			result.RowsAffected++
 		} else {
 			result.RowsAffected += uint64(len(rresult.Rows))
 		}
-		if vcursor.ExceedsMaxMemoryRows(len(result.Rows)) {
+		if vcursor.ExceedsMaxMemoryRows(joined.inMemory()) {
 			return nil, fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
 		}
 	}
+	result.Rows, err = joined.result()
+	if err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
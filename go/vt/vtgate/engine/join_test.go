@@ -18,6 +18,8 @@ package engine
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -202,6 +204,125 @@ func TestJoinExecuteMaxMemoryRows(t *testing.T) {
 	}
 }
 
+func TestJoinExecuteMaxMemoryBytes(t *testing.T) {
+	leftPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				sqltypes.MakeTestFields(
+					"col1|col2",
+					"int64|varchar",
+				),
+				"1|a",
+				"2|b",
+			),
+		},
+	}
+	rightFields := sqltypes.MakeTestFields(
+		"col3",
+		"varchar",
+	)
+	rightPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				rightFields,
+				"c",
+			),
+			sqltypes.MakeTestResult(
+				rightFields,
+				"d",
+			),
+		},
+	}
+	jn := &Join{
+		Opcode: NormalJoin,
+		Left:   leftPrim,
+		Right:  rightPrim,
+		Cols:   []int{-1, 1},
+		Vars: map[string]int{
+			"bv": 1,
+		},
+	}
+
+	// Each joined row takes 90 bytes.
+	vc := &loggingVCursor{memoryBudget: NewMemoryBudget(100, nil, "")}
+	_, err := jn.Execute(vc, map[string]*querypb.BindVariable{}, true)
+	require.EqualError(t, err, "in-memory byte count exceeded allowed limit of 100")
+	require.EqualValues(t, 0, vc.memoryBudget.Used())
+}
+
+func TestJoinExecuteSpill(t *testing.T) {
+	spillDir, err := ioutil.TempDir("", "join_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(spillDir)
+
+	leftPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				sqltypes.MakeTestFields(
+					"col1|col2",
+					"int64|varchar",
+				),
+				"1|a",
+				"2|b",
+				"3|c",
+			),
+		},
+	}
+	rightFields := sqltypes.MakeTestFields(
+		"col3",
+		"varchar",
+	)
+	rightPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				rightFields,
+				"d",
+			),
+			sqltypes.MakeTestResult(
+				rightFields,
+			),
+			sqltypes.MakeTestResult(
+				rightFields,
+				"e",
+				"f",
+			),
+		},
+	}
+	jn := &Join{
+		Opcode: LeftJoin,
+		Left:   leftPrim,
+		Right:  rightPrim,
+		Cols:   []int{-1, 1},
+		Vars: map[string]int{
+			"bv": 1,
+		},
+	}
+
+	// Each joined row takes about 90 bytes, so the second one spills.
+	spills := memorySpills.Counts()["Join"]
+	vc := &loggingVCursor{memoryBudget: NewMemoryBudget(100, nil, spillDir)}
+	result, err := jn.Execute(vc, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+	want := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"col1|col3",
+			"int64|varchar",
+		),
+		"1|d",
+		"2|null",
+		"3|e",
+		"3|f",
+	)
+	want.RowsAffected = 4
+	require.Equal(t, want, result)
+	require.EqualValues(t, 1, memorySpills.Counts()["Join"]-spills)
+	require.EqualValues(t, 0, vc.memoryBudget.Used())
+
+	files, err := ioutil.ReadDir(spillDir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
 func TestJoinExecuteNoResult(t *testing.T) {
 	leftPrim := &fakePrimitive{
 		results: []*sqltypes.Result{
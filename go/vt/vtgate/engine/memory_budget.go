@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/sync2"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// rowOverhead is the estimated size of the slice header of a row.
+	rowOverhead = 24
+	// valueOverhead is the estimated size of a sqltypes.Value, excluding
+	// the bytes of its value.
+	valueOverhead = 32
+)
+
+var (
+	memorySpills     = stats.NewCountersWithSingleLabel("MemorySpills", "Number of times a primitive spilled its in-memory rows to disk", "Operator")
+	memorySpillBytes = stats.NewCountersWithSingleLabel("MemorySpillBytes", "Number of in-memory bytes spilled to disk by primitives", "Operator")
+)
+
+// ProcessMemoryBudget limits the bytes held in memory by the primitives
+// of all the queries of the process.
+type ProcessMemoryBudget struct {
+	limit sync2.AtomicInt64
+	used  sync2.AtomicInt64
+}
+
+// NewProcessMemoryBudget creates a ProcessMemoryBudget with no limit.
+func NewProcessMemoryBudget() *ProcessMemoryBudget {
+	return &ProcessMemoryBudget{}
+}
+
+// SetLimit sets the limit in bytes. 0 means no limit.
+func (pb *ProcessMemoryBudget) SetLimit(limit int64) {
+	pb.limit.Set(limit)
+}
+
+// Limit returns the limit in bytes.
+func (pb *ProcessMemoryBudget) Limit() int64 {
+	return pb.limit.Get()
+}
+
+// Used returns the number of bytes currently reserved by all queries.
+func (pb *ProcessMemoryBudget) Used() int64 {
+	return pb.used.Get()
+}
+
+// MemoryBudget limits the bytes held in memory by the primitives of
+// a single query. Bytes reserved by a query also count against the
+// ProcessMemoryBudget it belongs to. A nil MemoryBudget has no limits.
+type MemoryBudget struct {
+	process  *ProcessMemoryBudget
+	limit    sync2.AtomicInt64
+	used     sync2.AtomicInt64
+	spillDir string
+}
+
+// NewMemoryBudget creates a MemoryBudget for one query. A limit of 0
+// means the query is only bounded by the process budget. If spillDir
+// is not empty, primitives that can spill their rows to disk do so
+// in that directory instead of failing when the budget is exceeded.
+// MemorySort spills sorted runs, and the non-streaming Join and
+// OrderedAggregate spill the rows they build. The streaming Join and
+// OrderedAggregate don't buffer rows.
+func NewMemoryBudget(limit int64, process *ProcessMemoryBudget, spillDir string) *MemoryBudget {
+	mb := &MemoryBudget{
+		process:  process,
+		spillDir: spillDir,
+	}
+	mb.limit.Set(limit)
+	return mb
+}
+
+// SetLimit changes the per-query limit. 0 means no limit.
+func (mb *MemoryBudget) SetLimit(limit int64) {
+	mb.limit.Set(limit)
+}
+
+// Used returns the number of bytes currently reserved by the query.
+func (mb *MemoryBudget) Used() int64 {
+	if mb == nil {
+		return 0
+	}
+	return mb.used.Get()
+}
+
+// CanSpill returns true if primitives are allowed to spill to disk.
+func (mb *MemoryBudget) CanSpill() bool {
+	return mb != nil && mb.spillDir != ""
+}
+
+// Reserve accounts for n more bytes held in memory. If this would exceed
+// the query or the process limit, nothing is reserved and an error is
+// returned.
+func (mb *MemoryBudget) Reserve(n int64) error {
+	if mb == nil {
+		return nil
+	}
+	if limit := mb.limit.Get(); mb.used.Add(n) > limit && limit > 0 {
+		mb.used.Add(-n)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "in-memory byte count exceeded allowed limit of %d", limit)
+	}
+	if mb.process == nil {
+		return nil
+	}
+	if limit := mb.process.limit.Get(); mb.process.used.Add(n) > limit && limit > 0 {
+		mb.process.used.Add(-n)
+		mb.used.Add(-n)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "in-memory byte count of all queries exceeded allowed limit of %d", limit)
+	}
+	return nil
+}
+
+// Release returns n bytes to the budget.
+func (mb *MemoryBudget) Release(n int64) {
+	if mb == nil {
+		return
+	}
+	mb.used.Add(-n)
+	if mb.process != nil {
+		mb.process.used.Add(-n)
+	}
+}
+
+// ReleaseAll returns all the bytes still reserved by the query. It must
+// be called once the query is done.
+func (mb *MemoryBudget) ReleaseAll() {
+	if mb == nil {
+		return
+	}
+	mb.Release(mb.used.Get())
+}
+
+// rowMemorySize estimates the number of bytes a row holds in memory.
+func rowMemorySize(row []sqltypes.Value) int64 {
+	size := int64(rowOverhead)
+	for _, v := range row {
+		size += valueOverhead + int64(v.Len())
+	}
+	return size
+}
+
+// rowsMemorySize estimates the number of bytes rows hold in memory.
+func rowsMemorySize(rows [][]sqltypes.Value) int64 {
+	var size int64
+	for _, row := range rows {
+		size += rowMemorySize(row)
+	}
+	return size
+}
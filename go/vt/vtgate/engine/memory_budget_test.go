@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	process := NewProcessMemoryBudget()
+	process.SetLimit(150)
+	q1 := NewMemoryBudget(100, process, "")
+	q2 := NewMemoryBudget(0, process, "")
+
+	require.NoError(t, q1.Reserve(60))
+	assert.EqualError(t, q1.Reserve(60), "in-memory byte count exceeded allowed limit of 100")
+	require.NoError(t, q2.Reserve(80))
+	assert.EqualError(t, q2.Reserve(20), "in-memory byte count of all queries exceeded allowed limit of 150")
+	assert.EqualValues(t, 60, q1.Used())
+	assert.EqualValues(t, 80, q2.Used())
+	assert.EqualValues(t, 140, process.Used())
+
+	q1.Release(50)
+	require.NoError(t, q2.Reserve(20))
+	assert.EqualValues(t, 110, process.Used())
+
+	q1.ReleaseAll()
+	q2.ReleaseAll()
+	assert.EqualValues(t, 0, q1.Used())
+	assert.EqualValues(t, 0, q2.Used())
+	assert.EqualValues(t, 0, process.Used())
+
+	// A nil budget has no limits.
+	var nilBudget *MemoryBudget
+	require.NoError(t, nilBudget.Reserve(1000))
+	assert.False(t, nilBudget.CanSpill())
+	nilBudget.ReleaseAll()
+}
+
+func TestSpillFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	want := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|name|price",
+			"int64|varchar|decimal",
+		),
+		"1|a|1.5",
+		"2||null",
+		"3|ccc|-2",
+	).Rows
+
+	sf, err := newSpillFile(dir)
+	require.NoError(t, err)
+	for _, row := range want {
+		require.NoError(t, sf.write(row))
+	}
+	require.NoError(t, sf.rewind())
+	var got [][]sqltypes.Value
+	for {
+		row, err := sf.read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, row)
+	}
+	assert.Equal(t, want, got)
+
+	sf.close()
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
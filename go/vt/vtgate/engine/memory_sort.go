@@ -19,6 +19,7 @@ package engine
 import (
 	"container/heap"
 	"fmt"
+	"io"
 	"math"
 	"reflect"
 	"sort"
@@ -32,6 +33,10 @@ import (
 
 var _ Primitive = (*MemorySort)(nil)
 
+// spillMergeBatchSize is the number of rows sent per result
+// when merging the runs spilled to disk.
+const spillMergeBatchSize = 1000
+
 // MemorySort is a primitive that performs in-memory sorting.
 type MemorySort struct {
 	UpperLimit sqltypes.PlanValue
@@ -75,6 +80,15 @@ func (ms *MemorySort) Execute(vcursor VCursor, bindVars map[string]*querypb.Bind
 	if err != nil {
 		return nil, err
 	}
+	budget := vcursor.MemoryBudget()
+	size := rowsMemorySize(result.Rows)
+	if err := budget.Reserve(size); err != nil {
+		if budget.CanSpill() {
+			return ms.externalSort(budget, result, count)
+		}
+		return nil, err
+	}
+	defer budget.Release(size)
 	sh := &sortHeap{
 		rows:    result.Rows,
 		orderBy: ms.OrderBy,
@@ -91,6 +105,66 @@ func (ms *MemorySort) Execute(vcursor VCursor, bindVars map[string]*querypb.Bind
 	return result.Truncate(ms.TruncateColumnCount), nil
 }
 
+// externalSort sorts the rows of the result with an external merge
+// sort, when they don't fit in the memory budget: the rows that fit
+// are sorted and written to disk as runs, which are then merged.
+func (ms *MemorySort) externalSort(budget *MemoryBudget, result *sqltypes.Result, count int) (*sqltypes.Result, error) {
+	sh := &sortHeap{
+		orderBy: ms.OrderBy,
+	}
+	var reserved int64
+	var runs []*spillFile
+	defer func() {
+		budget.Release(reserved)
+		for _, run := range runs {
+			run.close()
+		}
+	}()
+
+	rows := result.Rows
+	result.Rows = nil
+	for i, row := range rows {
+		size := rowMemorySize(row)
+		if err := budget.Reserve(size); err != nil {
+			if len(sh.rows) == 0 {
+				return nil, err
+			}
+			run, err := spillRun(budget.spillDir, sh, count)
+			if err != nil {
+				return nil, err
+			}
+			runs = append(runs, run)
+			memorySpills.Add("MemorySort", 1)
+			memorySpillBytes.Add("MemorySort", reserved)
+			budget.Release(reserved)
+			reserved = 0
+			if err := budget.Reserve(size); err != nil {
+				return nil, err
+			}
+		}
+		reserved += size
+		sh.rows = append(sh.rows, row)
+		// The spilled rows can be freed.
+		rows[i] = nil
+	}
+	sh.reverse = false
+	sort.Sort(sh)
+	if sh.err != nil {
+		return nil, sh.err
+	}
+	err := mergeRuns(runs, sh.rows, ms.OrderBy, count, func(qr *sqltypes.Result) error {
+		result.Rows = append(result.Rows, qr.Rows...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > count {
+		result.RowsAffected = uint64(count)
+	}
+	return result.Truncate(ms.TruncateColumnCount), nil
+}
+
 // StreamExecute satisfies the Primitive interface.
 func (ms *MemorySort) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
 	count, err := ms.fetchCount(bindVars)
@@ -108,6 +182,20 @@ func (ms *MemorySort) StreamExecute(vcursor VCursor, bindVars map[string]*queryp
 		orderBy: ms.OrderBy,
 		reverse: true,
 	}
+
+	// If the rows exceed the memory budget and spilling is allowed,
+	// they are written to disk as sorted runs, which are merged
+	// with the remaining rows at the end.
+	budget := vcursor.MemoryBudget()
+	var reserved int64
+	var runs []*spillFile
+	defer func() {
+		budget.Release(reserved)
+		for _, run := range runs {
+			run.close()
+		}
+	}()
+
 	err = ms.Input.StreamExecute(vcursor, bindVars, wantfields, func(qr *sqltypes.Result) error {
 		if len(qr.Fields) != 0 {
 			if err := cb(&sqltypes.Result{Fields: qr.Fields}); err != nil {
@@ -115,10 +203,32 @@ func (ms *MemorySort) StreamExecute(vcursor VCursor, bindVars map[string]*queryp
 			}
 		}
 		for _, row := range qr.Rows {
+			size := rowMemorySize(row)
+			if err := budget.Reserve(size); err != nil {
+				if !budget.CanSpill() || len(sh.rows) == 0 {
+					return err
+				}
+				run, err := spillRun(budget.spillDir, sh, count)
+				if err != nil {
+					return err
+				}
+				runs = append(runs, run)
+				memorySpills.Add("MemorySort", 1)
+				memorySpillBytes.Add("MemorySort", reserved)
+				budget.Release(reserved)
+				reserved = 0
+				if err := budget.Reserve(size); err != nil {
+					return err
+				}
+			}
+			reserved += size
 			heap.Push(sh, row)
 		}
 		for len(sh.rows) > count {
-			_ = heap.Pop(sh)
+			row := heap.Pop(sh).([]sqltypes.Value)
+			size := rowMemorySize(row)
+			budget.Release(size)
+			reserved -= size
 		}
 		if vcursor.ExceedsMaxMemoryRows(len(sh.rows)) {
 			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
@@ -138,7 +248,10 @@ func (ms *MemorySort) StreamExecute(vcursor VCursor, bindVars map[string]*queryp
 		// Unreachable.
 		return sh.err
 	}
-	return cb(&sqltypes.Result{Rows: sh.rows})
+	if len(runs) == 0 {
+		return cb(&sqltypes.Result{Rows: sh.rows})
+	}
+	return mergeRuns(runs, sh.rows, ms.OrderBy, count, cb)
 }
 
 // GetFields satisfies the Primitive interface.
@@ -275,3 +388,101 @@ func (sh *sortHeap) Pop() interface{} {
 	sh.rows = sh.rows[:n-1]
 	return x
 }
+
+// spillRun sorts the rows of the reversed heap and writes at most count
+// of them to a new spill file in dir. The heap is left empty.
+func spillRun(dir string, sh *sortHeap, count int) (*spillFile, error) {
+	sh.reverse = false
+	sort.Sort(sh)
+	sh.reverse = true
+	if sh.err != nil {
+		return nil, sh.err
+	}
+	rows := sh.rows
+	if len(rows) > count {
+		rows = rows[:count]
+	}
+	run, err := newSpillFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := run.write(row); err != nil {
+			run.close()
+			return nil, err
+		}
+	}
+	sh.rows = nil
+	return run, nil
+}
+
+// mergeRuns merge-sorts the spilled runs with the sorted in-memory rows,
+// and sends up to count rows to the callback.
+func mergeRuns(runs []*spillFile, rows [][]sqltypes.Value, orderBy []OrderbyParams, count int, callback func(*sqltypes.Result) error) error {
+	for _, run := range runs {
+		if err := run.rewind(); err != nil {
+			return err
+		}
+	}
+	// The in-memory rows are identified by len(runs).
+	next := func(id int) ([]sqltypes.Value, error) {
+		if id < len(runs) {
+			return runs[id].read()
+		}
+		if len(rows) == 0 {
+			return nil, io.EOF
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	}
+
+	sh := &scatterHeap{
+		rows:    make([]streamRow, 0, len(runs)+1),
+		orderBy: orderBy,
+	}
+	for id := 0; id <= len(runs); id++ {
+		row, err := next(id)
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		sh.rows = append(sh.rows, streamRow{row: row, id: id})
+	}
+	heap.Init(sh)
+	if sh.err != nil {
+		return sh.err
+	}
+
+	result := &sqltypes.Result{}
+	for sent := 0; sh.Len() > 0 && sent < count; sent++ {
+		sr := heap.Pop(sh).(streamRow)
+		if sh.err != nil {
+			return sh.err
+		}
+		result.Rows = append(result.Rows, sr.row)
+		if len(result.Rows) == spillMergeBatchSize {
+			if err := callback(result); err != nil {
+				return err
+			}
+			result = &sqltypes.Result{}
+		}
+		row, err := next(sr.id)
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		heap.Push(sh, streamRow{row: row, id: sr.id})
+		if sh.err != nil {
+			return sh.err
+		}
+	}
+	if len(result.Rows) == 0 {
+		return nil
+	}
+	return callback(result)
+}
@@ -18,6 +18,8 @@ package engine
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"testing"
 
@@ -50,7 +52,7 @@ func TestMemorySortExecute(t *testing.T) {
 		Input: fp,
 	}
 
-	result, err := ms.Execute(nil, nil, false)
+	result, err := ms.Execute(noopVCursor{}, nil, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -75,7 +77,7 @@ func TestMemorySortExecute(t *testing.T) {
 	ms.UpperLimit = upperlimit
 	bv := map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(3)}
 
-	result, err = ms.Execute(nil, bv, false)
+	result, err = ms.Execute(noopVCursor{}, bv, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -209,7 +211,7 @@ func TestMemorySortExecuteTruncate(t *testing.T) {
 		TruncateColumnCount: 2,
 	}
 
-	result, err := ms.Execute(nil, nil, false)
+	result, err := ms.Execute(noopVCursor{}, nil, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -299,7 +301,7 @@ func TestMemorySortMultiColumn(t *testing.T) {
 		Input: fp,
 	}
 
-	result, err := ms.Execute(nil, nil, false)
+	result, err := ms.Execute(noopVCursor{}, nil, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -324,7 +326,7 @@ func TestMemorySortMultiColumn(t *testing.T) {
 	ms.UpperLimit = upperlimit
 	bv := map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(3)}
 
-	result, err = ms.Execute(nil, bv, false)
+	result, err = ms.Execute(noopVCursor{}, bv, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -414,7 +416,7 @@ func TestMemorySortExecuteNoVarChar(t *testing.T) {
 		Input: fp,
 	}
 
-	_, err := ms.Execute(nil, nil, false)
+	_, err := ms.Execute(noopVCursor{}, nil, false)
 	want := "types are not comparable: VARCHAR vs VARCHAR"
 	if err == nil || err.Error() != want {
 		t.Errorf("Execute err: %v, want %v", err, want)
@@ -428,3 +430,126 @@ func TestMemorySortExecuteNoVarChar(t *testing.T) {
 		t.Errorf("StreamExecute err: %v, want %v", err, want)
 	}
 }
+
+func TestMemorySortMaxMemoryBytes(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varbinary|decimal",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|1",
+			"b|2",
+			"a|1",
+			"c|4",
+			"c|3",
+		)},
+	}
+	ms := &MemorySort{
+		OrderBy: []OrderbyParams{{
+			Col: 1,
+		}},
+		Input: fp,
+	}
+
+	// Each row takes 90 bytes.
+	vc := &loggingVCursor{memoryBudget: NewMemoryBudget(200, nil, "")}
+	_, err := ms.Execute(vc, nil, false)
+	require.EqualError(t, err, "in-memory byte count exceeded allowed limit of 200")
+
+	fp.rewind()
+	err = ms.StreamExecute(vc, nil, false, func(qr *sqltypes.Result) error {
+		return nil
+	})
+	require.EqualError(t, err, "in-memory byte count exceeded allowed limit of 200")
+	require.EqualValues(t, 0, vc.memoryBudget.Used())
+}
+
+func TestMemorySortStreamExecuteSpill(t *testing.T) {
+	spillDir, err := ioutil.TempDir("", "memory_sort_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(spillDir)
+
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varbinary|decimal",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|1",
+			"b|2",
+			"a|1",
+			"c|4",
+			"c|3",
+			"d|null",
+			"e|5",
+		)},
+	}
+	ms := &MemorySort{
+		OrderBy: []OrderbyParams{{
+			Col: 1,
+		}},
+		Input: fp,
+	}
+
+	testCases := []struct {
+		upperLimit int64
+		want       *sqltypes.Result
+	}{{
+		upperLimit: 100,
+		want: sqltypes.MakeTestResult(
+			fields,
+			"d|null",
+			"a|1",
+			"a|1",
+			"b|2",
+			"c|3",
+			"c|4",
+			"e|5",
+		),
+	}, {
+		upperLimit: 3,
+		want: sqltypes.MakeTestResult(
+			fields,
+			"d|null",
+			"a|1",
+			"a|1",
+		),
+	}}
+	upperlimit, err := sqlparser.NewPlanValue(sqlparser.NewArgument([]byte(":__upper_limit")))
+	require.NoError(t, err)
+	ms.UpperLimit = upperlimit
+	for _, tc := range testCases {
+		fp.rewind()
+		spills := memorySpills.Counts()["MemorySort"]
+		// Each row takes 90 bytes, so at most two rows are held in memory.
+		vc := &loggingVCursor{memoryBudget: NewMemoryBudget(200, nil, spillDir)}
+		bv := map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(tc.upperLimit)}
+
+		result, err := wrapStreamExecute(ms, vc, bv, false)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, result)
+		require.EqualValues(t, 3, memorySpills.Counts()["MemorySort"]-spills)
+		require.EqualValues(t, 0, vc.memoryBudget.Used())
+
+		files, err := ioutil.ReadDir(spillDir)
+		require.NoError(t, err)
+		require.Empty(t, files)
+
+		// A non-streaming sort does an external merge sort.
+		fp.rewind()
+		spills = memorySpills.Counts()["MemorySort"]
+		result, err = ms.Execute(vc, bv, true)
+		require.NoError(t, err)
+		tc.want.RowsAffected = uint64(len(tc.want.Rows))
+		require.Equal(t, tc.want, result)
+		require.EqualValues(t, 3, memorySpills.Counts()["MemorySort"]-spills)
+		require.EqualValues(t, 0, vc.memoryBudget.Used())
+
+		files, err = ioutil.ReadDir(spillDir)
+		require.NoError(t, err)
+		require.Empty(t, files)
+	}
+}
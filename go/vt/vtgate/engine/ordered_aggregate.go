@@ -166,8 +166,18 @@ func (oa *OrderedAggregate) execute(vcursor VCursor, bindVars map[string]*queryp
 	}
 	out := &sqltypes.Result{
 		Fields: oa.convertFields(result.Fields),
-		Rows:   make([][]sqltypes.Value, 0, len(result.Rows)),
 	}
+	// The aggregated rows are held in memory along with the input
+	// rows, so they count against the memory budget, and are spilled
+	// to disk if they exceed it.
+	var budget *MemoryBudget
+	if vcursor != nil {
+		budget = vcursor.MemoryBudget()
+	}
+	aggregated := newSpillBuffer(budget, "OrderedAggregate")
+	defer aggregated.close()
+	appendRow := aggregated.add
+
 	// This code is similar to the one in StreamExecute.
 	var current []sqltypes.Value
 	var curDistinct sqltypes.Value
@@ -189,7 +199,9 @@ func (oa *OrderedAggregate) execute(vcursor VCursor, bindVars map[string]*queryp
 			}
 			continue
 		}
-		out.Rows = append(out.Rows, current)
+		if err := appendRow(current); err != nil {
+			return nil, err
+		}
 		current, curDistinct = oa.convertRow(row)
 	}
 
@@ -200,11 +212,19 @@ func (oa *OrderedAggregate) execute(vcursor VCursor, bindVars map[string]*queryp
 		if err != nil {
 			return nil, err
 		}
-		out.Rows = append(out.Rows, row)
+		if err := appendRow(row); err != nil {
+			return nil, err
+		}
 	}
 
 	if current != nil {
-		out.Rows = append(out.Rows, current)
+		if err := appendRow(current); err != nil {
+			return nil, err
+		}
+	}
+	out.Rows, err = aggregated.result()
+	if err != nil {
+		return nil, err
 	}
 	out.RowsAffected = uint64(len(out.Rows))
 	return out, nil
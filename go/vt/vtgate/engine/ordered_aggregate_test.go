@@ -18,6 +18,8 @@ package engine
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -107,6 +109,92 @@ func TestOrderedAggregateExecuteTruncate(t *testing.T) {
 	assert.Equal(wantResult, result)
 }
 
+func TestOrderedAggregateExecuteMaxMemoryBytes(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"col|count(*)",
+		"varbinary|decimal",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|1",
+			"b|2",
+			"c|3",
+		)},
+	}
+	oa := &OrderedAggregate{
+		Aggregates: []AggregateParams{{
+			Opcode: AggregateCount,
+			Col:    1,
+		}},
+		Keys:  []int{0},
+		Input: fp,
+	}
+
+	// Each aggregated row takes 90 bytes.
+	vc := &loggingVCursor{memoryBudget: NewMemoryBudget(200, nil, "")}
+	_, err := oa.Execute(vc, nil, false)
+	require.EqualError(t, err, "in-memory byte count exceeded allowed limit of 200")
+	require.EqualValues(t, 0, vc.memoryBudget.Used())
+
+	fp.rewind()
+	vc.memoryBudget.SetLimit(300)
+	result, err := oa.Execute(vc, nil, false)
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 3)
+	require.EqualValues(t, 0, vc.memoryBudget.Used())
+}
+
+func TestOrderedAggregateExecuteSpill(t *testing.T) {
+	spillDir, err := ioutil.TempDir("", "ordered_aggregate_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(spillDir)
+
+	fields := sqltypes.MakeTestFields(
+		"col|count(*)",
+		"varbinary|decimal",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|1",
+			"a|1",
+			"b|2",
+			"c|3",
+		)},
+	}
+	oa := &OrderedAggregate{
+		Aggregates: []AggregateParams{{
+			Opcode: AggregateCount,
+			Col:    1,
+		}},
+		Keys:  []int{0},
+		Input: fp,
+	}
+
+	// Each aggregated row takes 90 bytes, so the third one spills.
+	spills := memorySpills.Counts()["OrderedAggregate"]
+	spillBytes := memorySpillBytes.Counts()["OrderedAggregate"]
+	vc := &loggingVCursor{memoryBudget: NewMemoryBudget(200, nil, spillDir)}
+	result, err := oa.Execute(vc, nil, false)
+	require.NoError(t, err)
+	want := sqltypes.MakeTestResult(
+		fields,
+		"a|2",
+		"b|2",
+		"c|3",
+	)
+	want.RowsAffected = 3
+	require.Equal(t, want, result)
+	require.EqualValues(t, 1, memorySpills.Counts()["OrderedAggregate"]-spills)
+	require.EqualValues(t, 270, memorySpillBytes.Counts()["OrderedAggregate"]-spillBytes)
+	require.EqualValues(t, 0, vc.memoryBudget.Used())
+
+	files, err := ioutil.ReadDir(spillDir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
 func TestOrderedAggregateStreamExecute(t *testing.T) {
 	assert := assert.New(t)
 	fields := sqltypes.MakeTestFields(
@@ -60,6 +60,10 @@ type (
 		// if the max memory rows override directive is set to true
 		ExceedsMaxMemoryRows(numRows int) bool
 
+		// MemoryBudget returns the budget that limits the bytes
+		// held in memory by the primitives of the current query.
+		MemoryBudget() *MemoryBudget
+
 		// SetContextTimeout updates the context and sets a timeout.
 		SetContextTimeout(timeout time.Duration) context.CancelFunc
 
@@ -134,6 +138,12 @@ type (
 		Original     string                  // Original is the original query.
 		Instructions Primitive               // Instructions contains the instructions needed to fulfil the query.
 		BindVarNeeds *sqlparser.BindVarNeeds // Stores BindVars needed to be provided as part of expression rewriting
+		// ResultCacheTables lists the tables read by the query as keyspace.table
+		// if its result may be kept in the vtgate result cache.
+		ResultCacheTables []string
+		// Tables lists the tables of a DDL or DML query as they are named in
+		// it. They are recorded by the audit log.
+		Tables []string
 
 		mu           sync.Mutex    // Mutex to protect the fields below
 		ExecCount    uint64        // Count of times this plan was executed
@@ -243,6 +253,8 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 		ShardQueries uint64                `json:",omitempty"`
 		Rows         uint64                `json:",omitempty"`
 		Errors       uint64                `json:",omitempty"`
+
+		ResultCacheTables []string `json:",omitempty"`
 	}{
 		QueryType:    p.Type.String(),
 		Original:     p.Original,
@@ -252,6 +264,8 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 		ShardQueries: p.ShardQueries,
 		Rows:         p.Rows,
 		Errors:       p.Errors,
+
+		ResultCacheTables: p.ResultCacheTables,
 	}
 	return json.Marshal(marshalPlan)
 }
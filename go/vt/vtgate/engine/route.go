@@ -70,8 +70,9 @@ type Route struct {
 	FieldQuery string
 
 	// Vindex specifies the vindex to be used.
-	Vindex vindexes.SingleColumn
+	Vindex vindexes.Vindex
 	// Values specifies the vindex values to use for routing.
+	// A multi-column vindex has one value per column.
 	Values []sqltypes.PlanValue
 
 	// OrderBy specifies the key order for merge sorting. This will be
@@ -147,7 +148,8 @@ const (
 	SelectUnsharded = RouteOpcode(iota)
 	// SelectEqualUnique is for routing a query to
 	// a single shard. Requires: A Unique Vindex, and
-	// a single Value.
+	// a single Value, or one per column of a MultiColumn
+	// Vindex.
 	SelectEqualUnique
 	// SelectEqual is for routing a query using a
 	// non-unique vindex. Requires: A Vindex, and
@@ -168,6 +170,23 @@ const (
 	SelectReference
 	// SelectNone is used for queries that always return empty values
 	SelectNone
+	// SelectMultiEqual is for routing a query that constrains
+	// all the columns of a MultiColumn Vindex, some of them with
+	// an IN clause. Requires: A MultiColumn Vindex, and a Value
+	// or a Values list per column. The query is sent to the shards
+	// of all the combinations of the values.
+	SelectMultiEqual
+	// SelectPrefix is for routing a query that only constrains
+	// the prefix columns of a Prefixable Vindex to the shards that
+	// overlap the key ranges of their values. Requires: A Prefixable
+	// Vindex, and a Value or a Values list per prefix column.
+	SelectPrefix
+	// SelectRange is for routing a query that constrains the
+	// column of an Ordered Vindex to a range to the shards that
+	// overlap the key range of the range. Requires: An Ordered
+	// Vindex, and the Values of the start and the end of the
+	// range, which are NULL if it's unbounded on that side.
+	SelectRange
 	// NumRouteOpcodes is the number of opcodes
 	NumRouteOpcodes
 )
@@ -182,6 +201,9 @@ var routeName = map[RouteOpcode]string{
 	SelectDBA:         "SelectDBA",
 	SelectReference:   "SelectReference",
 	SelectNone:        "SelectNone",
+	SelectMultiEqual:  "SelectMultiEqual",
+	SelectPrefix:      "SelectPrefix",
+	SelectRange:       "SelectRange",
 }
 
 var (
@@ -242,6 +264,12 @@ func (route *Route) execute(vcursor VCursor, bindVars map[string]*querypb.BindVa
 		rss, bvs, err = route.paramsSelectEqual(vcursor, bindVars)
 	case SelectIN:
 		rss, bvs, err = route.paramsSelectIn(vcursor, bindVars)
+	case SelectMultiEqual:
+		rss, bvs, err = route.paramsSelectMultiEqual(vcursor, bindVars)
+	case SelectPrefix:
+		rss, bvs, err = route.paramsSelectPrefix(vcursor, bindVars)
+	case SelectRange:
+		rss, bvs, err = route.paramsSelectRange(vcursor, bindVars)
 	case SelectNone:
 		rss, bvs, err = nil, nil, nil
 	default:
@@ -305,6 +333,12 @@ func (route *Route) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.
 		rss, bvs, err = route.paramsSelectEqual(vcursor, bindVars)
 	case SelectIN:
 		rss, bvs, err = route.paramsSelectIn(vcursor, bindVars)
+	case SelectMultiEqual:
+		rss, bvs, err = route.paramsSelectMultiEqual(vcursor, bindVars)
+	case SelectPrefix:
+		rss, bvs, err = route.paramsSelectPrefix(vcursor, bindVars)
+	case SelectRange:
+		rss, bvs, err = route.paramsSelectRange(vcursor, bindVars)
 	default:
 		return fmt.Errorf("query %q cannot be used for streaming", route.Query)
 	}
@@ -432,11 +466,11 @@ func (route *Route) paramsAnyShard(vcursor VCursor, bindVars map[string]*querypb
 }
 
 func (route *Route) paramsSelectEqual(vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
-	key, err := route.Values[0].ResolveValue(bindVars)
+	rows, err := resolveRows(route.Values, bindVars)
 	if err != nil {
 		return nil, nil, vterrors.Wrap(err, "paramsSelectEqual")
 	}
-	rss, _, err := resolveShards(vcursor, route.Vindex, route.Keyspace, []sqltypes.Value{key})
+	rss, _, err := resolveShards(vcursor, route.Vindex, route.Keyspace, rows)
 	if err != nil {
 		return nil, nil, vterrors.Wrap(err, "paramsSelectEqual")
 	}
@@ -448,34 +482,155 @@ func (route *Route) paramsSelectEqual(vcursor VCursor, bindVars map[string]*quer
 }
 
 func (route *Route) paramsSelectIn(vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
-	keys, err := route.Values[0].ResolveList(bindVars)
+	rows, err := resolveRows(route.Values, bindVars)
 	if err != nil {
 		return nil, nil, vterrors.Wrap(err, "paramsSelectIn")
 	}
-	rss, values, err := resolveShards(vcursor, route.Vindex, route.Keyspace, keys)
+	rss, values, err := resolveShards(vcursor, route.Vindex, route.Keyspace, rows)
 	if err != nil {
 		return nil, nil, vterrors.Wrap(err, "paramsSelectIn")
 	}
 	return rss, shardVars(bindVars, values), nil
 }
 
-func resolveShards(vcursor VCursor, vindex vindexes.SingleColumn, keyspace *vindexes.Keyspace, vindexKeys []sqltypes.Value) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
-	// Convert vindexKeys to []*querypb.Value
-	ids := make([]*querypb.Value, len(vindexKeys))
-	for i, vik := range vindexKeys {
-		ids[i] = sqltypes.ValueToProto(vik)
+func (route *Route) paramsSelectMultiEqual(vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
+	rows, err := resolveRows(route.Values, bindVars)
+	if err != nil {
+		return nil, nil, vterrors.Wrap(err, "paramsSelectMultiEqual")
+	}
+	rss, _, err := resolveShards(vcursor, route.Vindex, route.Keyspace, rows)
+	if err != nil {
+		return nil, nil, vterrors.Wrap(err, "paramsSelectMultiEqual")
+	}
+	multiBindVars := make([]map[string]*querypb.BindVariable, len(rss))
+	for i := range multiBindVars {
+		multiBindVars[i] = bindVars
+	}
+	return rss, multiBindVars, nil
+}
+
+func (route *Route) paramsSelectPrefix(vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
+	rss, err := resolvePrefixShards(vcursor, route.Vindex, route.Keyspace, route.Values, bindVars)
+	if err != nil {
+		return nil, nil, vterrors.Wrap(err, "paramsSelectPrefix")
+	}
+	multiBindVars := make([]map[string]*querypb.BindVariable, len(rss))
+	for i := range multiBindVars {
+		multiBindVars[i] = bindVars
+	}
+	return rss, multiBindVars, nil
+}
+
+func (route *Route) paramsSelectRange(vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
+	rss, err := resolveRangeShards(vcursor, route.Vindex, route.Keyspace, route.Values, bindVars)
+	if err != nil {
+		return nil, nil, vterrors.Wrap(err, "paramsSelectRange")
+	}
+	multiBindVars := make([]map[string]*querypb.BindVariable, len(rss))
+	for i := range multiBindVars {
+		multiBindVars[i] = bindVars
+	}
+	return rss, multiBindVars, nil
+}
+
+// resolveRows resolves the vindex values into the rows of column values
+// to map. Each value of a list is a row for a single-column vindex. A
+// multi-column vindex has one value or list per column, and there is a
+// row for every combination of them.
+func resolveRows(values []sqltypes.PlanValue, bindVars map[string]*querypb.BindVariable) ([][]sqltypes.Value, error) {
+	rows := [][]sqltypes.Value{nil}
+	for _, pv := range values {
+		var colValues []sqltypes.Value
+		if pv.IsList() {
+			list, err := pv.ResolveList(bindVars)
+			if err != nil {
+				return nil, err
+			}
+			colValues = list
+		} else {
+			value, err := pv.ResolveValue(bindVars)
+			if err != nil {
+				return nil, err
+			}
+			colValues = []sqltypes.Value{value}
+		}
+		product := make([][]sqltypes.Value, 0, len(rows)*len(colValues))
+		for _, row := range rows {
+			for _, value := range colValues {
+				product = append(product, append(row[:len(row):len(row)], value))
+			}
+		}
+		rows = product
 	}
+	return rows, nil
+}
 
+func resolveShards(vcursor VCursor, vindex vindexes.Vindex, keyspace *vindexes.Keyspace, rows [][]sqltypes.Value) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
 	// Map using the Vindex
-	destinations, err := vindex.Map(vcursor, vindexKeys)
+	destinations, err := vindexes.Map(vindex, vcursor, rows)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// The ids of a single column vindex are also resolved
+	// for each shard, so that they can be sent to them.
+	var ids []*querypb.Value
+	if _, ok := vindex.(vindexes.SingleColumn); ok {
+		ids = make([]*querypb.Value, len(rows))
+		for i, row := range rows {
+			ids[i] = sqltypes.ValueToProto(row[0])
+		}
+	}
+
 	// And use the Resolver to map to ResolvedShards.
 	return vcursor.ResolveDestinations(keyspace.Name, ids, destinations)
 }
 
+// resolvePrefixShards returns the shards that overlap the key ranges
+// of the values of the prefix columns of the vindex.
+func resolvePrefixShards(vcursor VCursor, vindex vindexes.Vindex, keyspace *vindexes.Keyspace, values []sqltypes.PlanValue, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, error) {
+	prefixable, ok := vindex.(vindexes.Prefixable)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "vindex %s cannot map a prefix of its columns", vindex.String())
+	}
+	rows, err := resolveRows(values, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	destinations, err := prefixable.MapPrefix(vcursor, rows)
+	if err != nil {
+		return nil, err
+	}
+	rss, _, err := vcursor.ResolveDestinations(keyspace.Name, nil, destinations)
+	return rss, err
+}
+
+// resolveRangeShards returns the shards that overlap the key range
+// of the range of values of the column of the vindex.
+func resolveRangeShards(vcursor VCursor, vindex vindexes.Vindex, keyspace *vindexes.Keyspace, values []sqltypes.PlanValue, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, error) {
+	ordered, ok := vindex.(vindexes.Ordered)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "vindex %s cannot map a range of values", vindex.String())
+	}
+	if len(values) != 2 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "a range needs a start and an end value, got %d values", len(values))
+	}
+	start, err := values[0].ResolveValue(bindVars)
+	if err != nil {
+		return nil, err
+	}
+	end, err := values[1].ResolveValue(bindVars)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := ordered.MapRange(vcursor, start, end)
+	if err != nil {
+		return nil, err
+	}
+	rss, _, err := vcursor.ResolveDestinations(keyspace.Name, nil, []key.Destination{destination})
+	return rss, err
+}
+
 func (route *Route) sort(in *sqltypes.Result) (*sqltypes.Result, error) {
 	var err error
 	// Since Result is immutable, we make a copy.
@@ -517,8 +672,8 @@ func (route *Route) sort(in *sqltypes.Result) (*sqltypes.Result, error) {
 	return out, err
 }
 
-func resolveSingleShard(vcursor VCursor, vindex vindexes.SingleColumn, keyspace *vindexes.Keyspace, vindexKey sqltypes.Value) (*srvtopo.ResolvedShard, []byte, error) {
-	destinations, err := vindex.Map(vcursor, []sqltypes.Value{vindexKey})
+func resolveSingleShard(vcursor VCursor, vindex vindexes.Vindex, keyspace *vindexes.Keyspace, vindexKey []sqltypes.Value) (*srvtopo.ResolvedShard, []byte, error) {
+	destinations, err := vindexes.Map(vindex, vcursor, [][]sqltypes.Value{vindexKey})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -541,8 +696,8 @@ func resolveSingleShard(vcursor VCursor, vindex vindexes.SingleColumn, keyspace
 	return rss[0], ksid, nil
 }
 
-func resolveMultiShard(vcursor VCursor, vindex vindexes.SingleColumn, keyspace *vindexes.Keyspace, vindexKey []sqltypes.Value) ([]*srvtopo.ResolvedShard, error) {
-	destinations, err := vindex.Map(vcursor, vindexKey)
+func resolveMultiShard(vcursor VCursor, vindex vindexes.Vindex, keyspace *vindexes.Keyspace, vindexKeys [][]sqltypes.Value) ([]*srvtopo.ResolvedShard, error) {
+	destinations, err := vindexes.Map(vindex, vcursor, vindexKeys)
 	if err != nil {
 		return nil, err
 	}
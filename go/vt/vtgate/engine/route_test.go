@@ -451,6 +451,158 @@ func TestSelectINNonUnique(t *testing.T) {
 	expectResult(t, "sel.StreamExecute", result, defaultSelectResult)
 }
 
+func TestSelectEqualUniqueMultiColumn(t *testing.T) {
+	vindex, _ := vindexes.NewRegionExperimental("", map[string]string{"region_bytes": "1"})
+	sel := NewRoute(
+		SelectEqualUnique,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: true,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.Vindex = vindex
+	sel.Values = []sqltypes.PlanValue{{Value: sqltypes.NewInt64(1)}, {Value: sqltypes.NewInt64(1)}}
+
+	vc := &loggingVCursor{
+		shards:  []string{"-20", "20-"},
+		results: []*sqltypes.Result{defaultSelectResult},
+	}
+	result, err := sel.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyspaceID(01166b40b44aba4bd6)`,
+		`ExecuteMultiShard ks.-20: dummy_select {} false false`,
+	})
+	expectResult(t, "sel.Execute", result, defaultSelectResult)
+}
+
+func TestSelectMultiEqual(t *testing.T) {
+	vindex, _ := vindexes.NewRegionExperimental("", map[string]string{"region_bytes": "1"})
+	sel := NewRoute(
+		SelectMultiEqual,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: true,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.Vindex = vindex
+	sel.Values = []sqltypes.PlanValue{{
+		Values: []sqltypes.PlanValue{{
+			Value: sqltypes.NewInt64(1),
+		}, {
+			Value: sqltypes.NewInt64(0x40),
+		}},
+	}, {
+		ListKey: "ids",
+	}}
+	bv := map[string]*querypb.BindVariable{
+		"ids": sqltypes.TestBindVariable([]interface{}{1, 2}),
+	}
+
+	vc := &loggingVCursor{
+		shards:       []string{"-20", "20-"},
+		shardForKsid: []string{"-20", "-20", "20-", "20-"},
+		results:      []*sqltypes.Result{defaultSelectResult},
+	}
+	result, err := sel.Execute(vc, bv, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyspaceID(01166b40b44aba4bd6),DestinationKeyspaceID(0106e7ea22ce92708f),DestinationKeyspaceID(40166b40b44aba4bd6),DestinationKeyspaceID(4006e7ea22ce92708f)`,
+		`ExecuteMultiShard ` +
+			`ks.-20: dummy_select {ids: type:TUPLE values:<type:INT64 value:"1" > values:<type:INT64 value:"2" > } ` +
+			`ks.20-: dummy_select {ids: type:TUPLE values:<type:INT64 value:"1" > values:<type:INT64 value:"2" > } ` +
+			`false false`,
+	})
+	expectResult(t, "sel.Execute", result, defaultSelectResult)
+}
+
+func TestSelectPrefix(t *testing.T) {
+	vindex, _ := vindexes.NewRegionExperimental("", map[string]string{"region_bytes": "1"})
+	sel := NewRoute(
+		SelectPrefix,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: true,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.Vindex = vindex
+	sel.Values = []sqltypes.PlanValue{{Value: sqltypes.NewInt64(0x20)}}
+
+	vc := &loggingVCursor{
+		shards:  []string{"-20", "20-"},
+		results: []*sqltypes.Result{defaultSelectResult},
+	}
+	result, err := sel.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyRange(20-21)`,
+		`ExecuteMultiShard ks.-20: dummy_select {} ks.20-: dummy_select {} false false`,
+	})
+	expectResult(t, "sel.Execute", result, defaultSelectResult)
+
+	// A vindex that can't map a prefix fails.
+	sel.Vindex, _ = vindexes.NewHash("hash", nil)
+	vc.Rewind()
+	_, err = sel.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.EqualError(t, err, "paramsSelectPrefix: vindex hash cannot map a prefix of its columns")
+}
+
+func TestSelectRange(t *testing.T) {
+	vindex, _ := vindexes.NewTimeRange("time", map[string]string{"type": "datetime"})
+	sel := NewRoute(
+		SelectRange,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: true,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.Vindex = vindex
+	sel.Values = []sqltypes.PlanValue{
+		{Value: sqltypes.NewVarChar("2020-10-01")},
+		{Key: "end"},
+	}
+
+	vc := &loggingVCursor{
+		shards:  []string{"-20", "20-"},
+		results: []*sqltypes.Result{defaultSelectResult},
+	}
+	result, err := sel.Execute(vc, map[string]*querypb.BindVariable{
+		"end": sqltypes.StringBindVariable("2020-10-31 12:00:00"),
+	}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyRange(00004868-00004887)`,
+		`ExecuteMultiShard ks.-20: dummy_select {end: type:VARBINARY value:"2020-10-31 12:00:00" } ks.20-: dummy_select {end: type:VARBINARY value:"2020-10-31 12:00:00" } false false`,
+	})
+	expectResult(t, "sel.Execute", result, defaultSelectResult)
+
+	// An unbounded start.
+	sel.Values[0] = sqltypes.PlanValue{}
+	vc.Rewind()
+	_, err = wrapStreamExecute(sel, vc, map[string]*querypb.BindVariable{
+		"end": sqltypes.StringBindVariable("2020-10-31"),
+	}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyRange(-00004887)`,
+		`StreamExecuteMulti dummy_select ks.-20: {end: type:VARBINARY value:"2020-10-31" } ks.20-: {end: type:VARBINARY value:"2020-10-31" } `,
+	})
+
+	// A vindex that can't map a range fails.
+	sel.Vindex, _ = vindexes.NewHash("hash", nil)
+	vc.Rewind()
+	_, err = sel.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.EqualError(t, err, "paramsSelectRange: vindex hash cannot map a range of values")
+}
+
 func TestSelectNext(t *testing.T) {
 	sel := NewRoute(
 		SelectNext,
@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var _ Primitive = (*RowPolicyCheck)(nil)
+
+// RowPolicyCheck verifies that the rows written by its input satisfy the
+// row policies of their table before executing it.
+type RowPolicyCheck struct {
+	Input  Primitive
+	Checks []RowPolicyCondition
+}
+
+// RowPolicyCondition requires a value written to a column to be equal to
+// the value expected by a row policy. The check fails if either is NULL.
+type RowPolicyCondition struct {
+	Table    string
+	Policy   string
+	Column   string
+	Value    evalengine.Expr
+	Expected evalengine.Expr
+}
+
+// RouteType returns a description of the query routing type used by the primitive.
+func (rc *RowPolicyCheck) RouteType() string {
+	return rc.Input.RouteType()
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (rc *RowPolicyCheck) GetKeyspaceName() string {
+	return rc.Input.GetKeyspaceName()
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (rc *RowPolicyCheck) GetTableName() string {
+	return rc.Input.GetTableName()
+}
+
+// Execute satisfies the Primitive interface.
+func (rc *RowPolicyCheck) Execute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	if err := rc.check(bindVars); err != nil {
+		return nil, err
+	}
+	return rc.Input.Execute(vcursor, bindVars, wantfields)
+}
+
+// StreamExecute satisfies the Primitive interface.
+func (rc *RowPolicyCheck) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	if err := rc.check(bindVars); err != nil {
+		return err
+	}
+	return rc.Input.StreamExecute(vcursor, bindVars, wantfields, callback)
+}
+
+// GetFields satisfies the Primitive interface.
+func (rc *RowPolicyCheck) GetFields(vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return rc.Input.GetFields(vcursor, bindVars)
+}
+
+// Inputs returns the input of the check.
+func (rc *RowPolicyCheck) Inputs() []Primitive {
+	return []Primitive{rc.Input}
+}
+
+// NeedsTransaction implements the Primitive interface.
+func (rc *RowPolicyCheck) NeedsTransaction() bool {
+	return rc.Input.NeedsTransaction()
+}
+
+func (rc *RowPolicyCheck) description() PrimitiveDescription {
+	var checks []string
+	for _, c := range rc.Checks {
+		checks = append(checks, c.Table+"."+c.Column+" = "+c.Expected.String()+" ("+c.Policy+")")
+	}
+	return PrimitiveDescription{
+		OperatorType: "RowPolicyCheck",
+		Other: map[string]interface{}{
+			"Checks": checks,
+		},
+	}
+}
+
+func (rc *RowPolicyCheck) check(bindVars map[string]*querypb.BindVariable) error {
+	for _, c := range rc.Checks {
+		value, err := evalRowPolicyValue(c.Value, bindVars)
+		if err != nil {
+			return err
+		}
+		expected, err := evalRowPolicyValue(c.Expected, bindVars)
+		if err != nil {
+			return err
+		}
+		if !rowPolicyValuesEqual(value, expected) {
+			return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "row policy %s of table %s denies writing %s to column %s", c.Policy, c.Table, value.String(), c.Column)
+		}
+	}
+	return nil
+}
+
+// evalRowPolicyValue evaluates expr. Unlike the evaluation engine, it
+// supports bind variables which are NULL.
+func evalRowPolicyValue(expr evalengine.Expr, bindVars map[string]*querypb.BindVariable) (sqltypes.Value, error) {
+	if bv, ok := expr.(*evalengine.BindVariable); ok {
+		val, ok := bindVars[bv.Key]
+		if !ok {
+			return sqltypes.NULL, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "missing bind var %s", bv.Key)
+		}
+		return sqltypes.BindVariableToValue(val)
+	}
+	result, err := expr.Evaluate(evalengine.ExpressionEnv{BindVars: bindVars})
+	if err != nil {
+		return sqltypes.NULL, err
+	}
+	return result.Value(), nil
+}
+
+func rowPolicyValuesEqual(v1, v2 sqltypes.Value) bool {
+	if v1.IsNull() || v2.IsNull() {
+		return false
+	}
+	// User attributes are strings: convert them to the type of the other
+	// value if it is a number.
+	switch {
+	case sqltypes.IsNumber(v1.Type()) && !sqltypes.IsNumber(v2.Type()):
+		v2 = numberFromString(v2, v1.Type())
+	case sqltypes.IsNumber(v2.Type()) && !sqltypes.IsNumber(v1.Type()):
+		v1 = numberFromString(v1, v2.Type())
+	}
+	if v1.IsNull() || v2.IsNull() {
+		return false
+	}
+	cmp, err := evalengine.NullsafeCompare(v1, v2)
+	return err == nil && cmp == 0
+}
+
+// numberFromString converts v to a number of type typ, or returns NULL if
+// it is not a valid number.
+func numberFromString(v sqltypes.Value, typ querypb.Type) sqltypes.Value {
+	if sqltypes.IsFloat(typ) || typ == sqltypes.Decimal {
+		typ = sqltypes.Float64
+	}
+	n, err := sqltypes.NewValue(typ, v.ToBytes())
+	if err != nil {
+		return sqltypes.NULL
+	}
+	return n
+}
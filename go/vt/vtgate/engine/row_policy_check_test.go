@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestRowPolicyCheck(t *testing.T) {
+	result := &sqltypes.Result{RowsAffected: 1}
+	fp := &fakePrimitive{results: []*sqltypes.Result{result}}
+	rc := &RowPolicyCheck{
+		Input: fp,
+		Checks: []RowPolicyCondition{{
+			Table:    "t",
+			Policy:   "tenant",
+			Column:   "tenant_id",
+			Value:    evalengine.NewBindVar("v1"),
+			Expected: evalengine.NewBindVar("__vt_user_attr_tenant"),
+		}},
+	}
+
+	tcases := []struct {
+		value, attr *querypb.BindVariable
+		allowed     bool
+	}{{
+		value:   sqltypes.Int64BindVariable(1),
+		attr:    sqltypes.StringBindVariable("1"),
+		allowed: true,
+	}, {
+		value:   sqltypes.StringBindVariable("1"),
+		attr:    sqltypes.StringBindVariable("1"),
+		allowed: true,
+	}, {
+		value: sqltypes.Int64BindVariable(2),
+		attr:  sqltypes.StringBindVariable("1"),
+	}, {
+		value: sqltypes.Int64BindVariable(1),
+		attr:  sqltypes.StringBindVariable("a"),
+	}, {
+		value: sqltypes.Int64BindVariable(1),
+		attr:  sqltypes.NullBindVariable,
+	}, {
+		value: sqltypes.NullBindVariable,
+		attr:  sqltypes.StringBindVariable("1"),
+	}}
+	for _, tcase := range tcases {
+		fp.rewind()
+		bindVars := map[string]*querypb.BindVariable{
+			"v1":                    tcase.value,
+			"__vt_user_attr_tenant": tcase.attr,
+		}
+		got, err := rc.Execute(nil, bindVars, false)
+		if tcase.allowed {
+			require.NoError(t, err)
+			assert.Equal(t, result, got)
+			continue
+		}
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "row policy tenant of table t denies writing")
+	}
+
+	fp.rewind()
+	_, err := rc.Execute(nil, map[string]*querypb.BindVariable{"v1": sqltypes.Int64BindVariable(1)}, false)
+	require.EqualError(t, err, "missing bind var __vt_user_attr_tenant")
+}
+
+func TestRowPolicyCheckLiteral(t *testing.T) {
+	fp := &fakePrimitive{results: []*sqltypes.Result{{}}}
+	expected, err := evalengine.NewLiteralIntFromBytes([]byte("5"))
+	require.NoError(t, err)
+	rc := &RowPolicyCheck{
+		Input: fp,
+		Checks: []RowPolicyCondition{{
+			Table:    "t",
+			Policy:   "region",
+			Column:   "region",
+			Value:    evalengine.NewLiteralString([]byte("5")),
+			Expected: expected,
+		}},
+	}
+	err = rc.StreamExecute(nil, nil, false, func(*sqltypes.Result) error { return nil })
+	require.NoError(t, err)
+
+	rc.Checks[0].Value = evalengine.NewLiteralString([]byte("6"))
+	err = rc.StreamExecute(nil, nil, false, func(*sqltypes.Result) error { return nil })
+	require.EqualError(t, err, "row policy region of table t denies writing VARBINARY(\"6\") to column region")
+}
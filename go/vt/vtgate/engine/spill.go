@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// spillFile is a temporary file holding rows that a primitive could not
+// keep in memory. Rows are read back in the order they were written.
+// Each row is encoded as its number of columns, followed by the type,
+// length and raw bytes of every value.
+type spillFile struct {
+	file *os.File
+	w    *bufio.Writer
+	r    *bufio.Reader
+	buf  [binary.MaxVarintLen64]byte
+}
+
+func newSpillFile(dir string) (*spillFile, error) {
+	file, err := ioutil.TempFile(dir, "vtgate-spill-")
+	if err != nil {
+		return nil, err
+	}
+	return &spillFile{
+		file: file,
+		w:    bufio.NewWriter(file),
+	}, nil
+}
+
+func (sf *spillFile) writeUvarint(v uint64) error {
+	n := binary.PutUvarint(sf.buf[:], v)
+	_, err := sf.w.Write(sf.buf[:n])
+	return err
+}
+
+// write appends a row to the file.
+func (sf *spillFile) write(row []sqltypes.Value) error {
+	if err := sf.writeUvarint(uint64(len(row))); err != nil {
+		return err
+	}
+	for _, v := range row {
+		if err := sf.writeUvarint(uint64(v.Type())); err != nil {
+			return err
+		}
+		if err := sf.writeUvarint(uint64(v.Len())); err != nil {
+			return err
+		}
+		if _, err := sf.w.Write(v.Raw()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewind flushes the written rows and prepares the file for reading.
+func (sf *spillFile) rewind() error {
+	if err := sf.w.Flush(); err != nil {
+		return err
+	}
+	if _, err := sf.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	sf.r = bufio.NewReader(sf.file)
+	return nil
+}
+
+// read returns the next row of the file, or io.EOF once all rows were read.
+func (sf *spillFile) read() ([]sqltypes.Value, error) {
+	cols, err := binary.ReadUvarint(sf.r)
+	if err != nil {
+		return nil, err
+	}
+	row := make([]sqltypes.Value, cols)
+	for i := range row {
+		typ, err := binary.ReadUvarint(sf.r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		length, err := binary.ReadUvarint(sf.r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		var val []byte
+		if querypb.Type(typ) != sqltypes.Null {
+			val = make([]byte, length)
+			if _, err := io.ReadFull(sf.r, val); err != nil {
+				return nil, unexpectedEOF(err)
+			}
+		}
+		row[i] = sqltypes.MakeTrusted(querypb.Type(typ), val)
+	}
+	return row, nil
+}
+
+// close closes and removes the file.
+func (sf *spillFile) close() {
+	sf.file.Close()
+	os.Remove(sf.file.Name())
+}
+
+// spillBuffer accumulates the rows built by a primitive. The rows are
+// held in memory while the memory budget allows it. Once it is
+// exceeded, they are written to a spill file along with all the rows
+// added after, if the budget allows spilling.
+type spillBuffer struct {
+	budget   *MemoryBudget
+	operator string
+	rows     [][]sqltypes.Value
+	reserved int64
+	file     *spillFile
+}
+
+func newSpillBuffer(budget *MemoryBudget, operator string) *spillBuffer {
+	return &spillBuffer{
+		budget:   budget,
+		operator: operator,
+	}
+}
+
+// add appends a row to the buffer.
+func (sb *spillBuffer) add(row []sqltypes.Value) error {
+	if sb.file != nil {
+		memorySpillBytes.Add(sb.operator, rowMemorySize(row))
+		return sb.file.write(row)
+	}
+	size := rowMemorySize(row)
+	if err := sb.budget.Reserve(size); err != nil {
+		if !sb.budget.CanSpill() {
+			return err
+		}
+		return sb.spill(row)
+	}
+	sb.reserved += size
+	sb.rows = append(sb.rows, row)
+	return nil
+}
+
+// spill writes the rows held in memory and the row to a new spill
+// file, and releases their memory.
+func (sb *spillBuffer) spill(row []sqltypes.Value) error {
+	file, err := newSpillFile(sb.budget.spillDir)
+	if err != nil {
+		return err
+	}
+	sb.file = file
+	for _, r := range append(sb.rows, row) {
+		if err := file.write(r); err != nil {
+			return err
+		}
+	}
+	memorySpills.Add(sb.operator, 1)
+	memorySpillBytes.Add(sb.operator, sb.reserved+rowMemorySize(row))
+	sb.budget.Release(sb.reserved)
+	sb.reserved = 0
+	sb.rows = nil
+	return nil
+}
+
+// inMemory returns the number of rows held in memory.
+func (sb *spillBuffer) inMemory() int {
+	return len(sb.rows)
+}
+
+// result returns all the rows of the buffer in the order they were
+// added. The spilled rows are read back from disk: the result of a
+// non-streaming primitive is returned in memory to its caller.
+func (sb *spillBuffer) result() ([][]sqltypes.Value, error) {
+	if sb.file == nil {
+		return sb.rows, nil
+	}
+	if err := sb.file.rewind(); err != nil {
+		return nil, err
+	}
+	var rows [][]sqltypes.Value
+	for {
+		row, err := sb.file.read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+// close releases the memory and removes the spill file of the buffer.
+func (sb *spillBuffer) close() {
+	sb.budget.Release(sb.reserved)
+	sb.reserved = 0
+	if sb.file != nil {
+		sb.file.close()
+		sb.file = nil
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
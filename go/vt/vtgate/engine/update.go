@@ -17,8 +17,10 @@ limitations under the License.
 package engine
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -27,6 +29,7 @@ import (
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
@@ -50,6 +53,18 @@ type Update struct {
 	// ChangedVindexValues contains values for updated Vindexes during an update statement.
 	ChangedVindexValues map[string]*VindexValues
 
+	// MoveRowsQuery is set if the update changes the primary vindex column,
+	// which can move rows to another shard. It selects the columns of the
+	// table to update, followed by the new values of MoveColumns. Query is
+	// then not executed. Instead, every row whose keyspace id changes is
+	// deleted and inserted again with its new values, and the entries of
+	// all the owned vindexes are recreated. The other rows are updated in
+	// place.
+	MoveRowsQuery string
+
+	// MoveColumns are the columns assigned by the update.
+	MoveColumns []string
+
 	// Update does not take inputs
 	noInputs
 }
@@ -92,7 +107,7 @@ func (upd *Update) Execute(vcursor VCursor, bindVars map[string]*querypb.BindVar
 		return upd.execUpdateUnsharded(vcursor, bindVars)
 	case Equal:
 		return upd.execUpdateEqual(vcursor, bindVars)
-	case In:
+	case In, Prefix, Range:
 		return upd.execUpdateIn(vcursor, bindVars)
 	case Scatter:
 		return upd.execUpdateByDestination(vcursor, bindVars, key.DestinationAllShards{})
@@ -130,11 +145,11 @@ func (upd *Update) execUpdateUnsharded(vcursor VCursor, bindVars map[string]*que
 }
 
 func (upd *Update) execUpdateEqual(vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
-	key, err := upd.Values[0].ResolveValue(bindVars)
+	rows, err := resolveRows(upd.Values, bindVars)
 	if err != nil {
 		return nil, vterrors.Wrap(err, "execUpdateEqual")
 	}
-	rs, ksid, err := resolveSingleShard(vcursor, upd.Vindex, upd.Keyspace, key)
+	rs, ksid, err := resolveSingleShard(vcursor, upd.Vindex, upd.Keyspace, rows[0])
 	if err != nil {
 		return nil, vterrors.Wrap(err, "execUpdateEqual")
 	}
@@ -145,6 +160,9 @@ func (upd *Update) execUpdateEqual(vcursor VCursor, bindVars map[string]*querypb
 	if len(ksid) == 0 {
 		return &sqltypes.Result{}, nil
 	}
+	if upd.MoveRowsQuery != "" {
+		return upd.moveRows(vcursor, bindVars, []*srvtopo.ResolvedShard{rs})
+	}
 	if len(upd.ChangedVindexValues) != 0 {
 		if err := upd.updateVindexEntries(vcursor, bindVars, []*srvtopo.ResolvedShard{rs}); err != nil {
 			return nil, vterrors.Wrap(err, "execUpdateEqual")
@@ -154,7 +172,7 @@ func (upd *Update) execUpdateEqual(vcursor VCursor, bindVars map[string]*querypb
 }
 
 func (upd *Update) execUpdateIn(vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
-	rss, queries, err := resolveMultiValueShards(vcursor, upd.Keyspace, upd.Query, bindVars, upd.Values[0], upd.Vindex)
+	rss, queries, err := upd.resolveMultiValueShards(vcursor, bindVars)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +180,9 @@ func (upd *Update) execUpdateIn(vcursor VCursor, bindVars map[string]*querypb.Bi
 	if err != nil {
 		return nil, err
 	}
+	if upd.MoveRowsQuery != "" {
+		return upd.moveRows(vcursor, bindVars, rss)
+	}
 	if len(upd.ChangedVindexValues) != 0 {
 		if err := upd.updateVindexEntries(vcursor, bindVars, rss); err != nil {
 			return nil, vterrors.Wrap(err, "execUpdateIn")
@@ -179,6 +200,9 @@ func (upd *Update) execUpdateByDestination(vcursor VCursor, bindVars map[string]
 	if err != nil {
 		return nil, err
 	}
+	if upd.MoveRowsQuery != "" {
+		return upd.moveRows(vcursor, bindVars, rss)
+	}
 
 	queries := make([]*querypb.BoundQuery, len(rss))
 	for i := range rss {
@@ -269,6 +293,243 @@ func (upd *Update) updateVindexEntries(vcursor VCursor, bindVars map[string]*que
 	return nil
 }
 
+// moveRows performs an update that changes the primary vindex column.
+// Each row is read, and if the keyspace id of its new primary vindex
+// value changes, it's deleted and inserted again with its new values,
+// possibly in another shard. The other rows are updated in place. All
+// the moves must be part of a transaction, which is committed with
+// two-phase commit if the session requests it.
+// Note: the new values are computed by the select, from the values of
+// the row before the update. Unlike mysql, an assignment cannot see the
+// value set by a previous assignment of the same statement.
+// Like mysql, only the rows whose values change are counted as affected.
+func (upd *Update) moveRows(vcursor VCursor, bindVars map[string]*querypb.BindVariable, rss []*srvtopo.ResolvedShard) (*sqltypes.Result, error) {
+	if !vcursor.InTransactionAndIsDML() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "an update of the primary vindex column of table %s must run in a transaction", upd.GetTableName())
+	}
+	queries := make([]*querypb.BoundQuery, len(rss))
+	for i := range rss {
+		queries[i] = &querypb.BoundQuery{Sql: upd.MoveRowsQuery, BindVariables: bindVars}
+	}
+	qr, errs := vcursor.ExecuteMultiShard(rss, queries, false, false)
+	if err := vterrors.Aggregate(errs); err != nil {
+		return nil, vterrors.Wrap(err, "moveRows")
+	}
+	if len(qr.Rows) == 0 {
+		return &sqltypes.Result{}, nil
+	}
+
+	mover, err := upd.newRowMover(qr.Fields)
+	if err != nil {
+		return nil, err
+	}
+	var rowsAffected uint64
+	for _, row := range qr.Rows {
+		changed, err := mover.move(vcursor, row)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "moveRows")
+		}
+		rowsAffected += changed
+	}
+	return &sqltypes.Result{RowsAffected: rowsAffected}, nil
+}
+
+// rowMover moves the rows selected by Update.MoveRowsQuery to the shards
+// of their new values, or updates them in place.
+type rowMover struct {
+	upd         *Update
+	numCols     int
+	primaryCol  int
+	pkCols      []int
+	assignedCol []int
+	ownedCols   [][]int
+	deleteQuery string
+	insertQuery string
+	updateQuery string
+}
+
+func (upd *Update) newRowMover(fields []*querypb.Field) (*rowMover, error) {
+	rm := &rowMover{
+		upd:     upd,
+		numCols: len(fields) - len(upd.MoveColumns),
+	}
+	colNums := make(map[string]int, rm.numCols)
+	for i, field := range fields[:rm.numCols] {
+		colNums[strings.ToLower(field.Name)] = i
+	}
+	findCol := func(name string) (int, error) {
+		colNum, ok := colNums[strings.ToLower(name)]
+		if !ok {
+			return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "column %s not found in the rows of table %s", name, upd.GetTableName())
+		}
+		return colNum, nil
+	}
+
+	var err error
+	if rm.primaryCol, err = findCol(upd.Table.ColumnVindexes[0].Columns[0].String()); err != nil {
+		return nil, err
+	}
+	for _, col := range upd.MoveColumns {
+		colNum, err := findCol(col)
+		if err != nil {
+			return nil, err
+		}
+		rm.assignedCol = append(rm.assignedCol, colNum)
+	}
+	for _, colVindex := range upd.Table.Owned {
+		var cols []int
+		for _, col := range colVindex.Columns {
+			colNum, err := findCol(col.String())
+			if err != nil {
+				return nil, err
+			}
+			cols = append(cols, colNum)
+		}
+		rm.ownedCols = append(rm.ownedCols, cols)
+	}
+
+	buf := sqlparser.NewTrackedBuffer(nil)
+	for i, pk := range upd.Table.PrimaryKey {
+		colNum, err := findCol(pk.String())
+		if err != nil {
+			return nil, err
+		}
+		rm.pkCols = append(rm.pkCols, colNum)
+		if i > 0 {
+			buf.Myprintf(" and ")
+		}
+		buf.Myprintf("%v = %a", pk, fmt.Sprintf(":__pk%d", i))
+	}
+	pkWhere := buf.String()
+
+	buf = sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("delete from %v where %s", upd.Table.Name, pkWhere)
+	rm.deleteQuery = buf.String()
+
+	buf = sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("update %v set ", upd.Table.Name)
+	for i, colNum := range rm.assignedCol {
+		if i > 0 {
+			buf.Myprintf(", ")
+		}
+		buf.Myprintf("%v = %a", sqlparser.NewColIdent(fields[colNum].Name), fmt.Sprintf(":__col%d", colNum))
+	}
+	buf.Myprintf(" where %s", pkWhere)
+	rm.updateQuery = buf.String()
+
+	buf = sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("insert into %v(", upd.Table.Name)
+	for i, field := range fields[:rm.numCols] {
+		if i > 0 {
+			buf.Myprintf(", ")
+		}
+		buf.Myprintf("%v", sqlparser.NewColIdent(field.Name))
+	}
+	buf.Myprintf(") values (")
+	for i := 0; i < rm.numCols; i++ {
+		if i > 0 {
+			buf.Myprintf(", ")
+		}
+		buf.Myprintf("%a", fmt.Sprintf(":__col%d", i))
+	}
+	buf.Myprintf(")")
+	rm.insertQuery = buf.String()
+	return rm, nil
+}
+
+// move deletes the row from its shard and inserts it with its new values
+// if its keyspace id changes, or else updates it in place. It returns
+// the number of rows that changed: a moved row always changes, and
+// mysql tells if a row updated in place did.
+func (rm *rowMover) move(vcursor VCursor, row []sqltypes.Value) (uint64, error) {
+	upd := rm.upd
+	oldRow := row[:rm.numCols]
+	newRow := make([]sqltypes.Value, rm.numCols)
+	copy(newRow, oldRow)
+	for i, colNum := range rm.assignedCol {
+		newRow[colNum] = row[rm.numCols+i]
+	}
+
+	oldRS, oldKsid, err := resolveSingleShard(vcursor, upd.KsidVindex, upd.Keyspace, []sqltypes.Value{oldRow[rm.primaryCol]})
+	if err != nil {
+		return 0, err
+	}
+	newRS, newKsid, err := resolveSingleShard(vcursor, upd.KsidVindex, upd.Keyspace, []sqltypes.Value{newRow[rm.primaryCol]})
+	if err != nil {
+		return 0, err
+	}
+	if len(oldKsid) == 0 || len(newKsid) == 0 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "could not map %v to a keyspace id", newRow[rm.primaryCol])
+	}
+	pkVars := make(map[string]*querypb.BindVariable, len(rm.pkCols))
+	for i, colNum := range rm.pkCols {
+		pkVars[fmt.Sprintf("__pk%d", i)] = sqltypes.ValueBindVariable(oldRow[colNum])
+	}
+	colVars := make(map[string]*querypb.BindVariable, rm.numCols)
+	for i, v := range newRow {
+		colVars[fmt.Sprintf("__col%d", i)] = sqltypes.ValueBindVariable(v)
+	}
+
+	if bytes.Equal(oldKsid, newKsid) {
+		// The row stays where it is, and only the owned vindexes
+		// whose columns change are updated.
+		for i, colVindex := range upd.Table.Owned {
+			oldValues, newValues := rowValues(oldRow, rm.ownedCols[i]), rowValues(newRow, rm.ownedCols[i])
+			if valuesEqual(oldValues, newValues) {
+				continue
+			}
+			if err := colVindex.Vindex.(vindexes.Lookup).Update(vcursor, oldValues, oldKsid, newValues); err != nil {
+				return 0, err
+			}
+		}
+		for k, v := range pkVars {
+			colVars[k] = v
+		}
+		qr, err := execShard(vcursor, rm.updateQuery, colVars, oldRS, true /* rollbackOnError */, false /* canAutocommit */)
+		if err != nil {
+			return 0, err
+		}
+		return qr.RowsAffected, nil
+	}
+
+	for i, colVindex := range upd.Table.Owned {
+		if err := colVindex.Vindex.(vindexes.Lookup).Delete(vcursor, [][]sqltypes.Value{rowValues(oldRow, rm.ownedCols[i])}, oldKsid); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := execShard(vcursor, rm.deleteQuery, pkVars, oldRS, true /* rollbackOnError */, false /* canAutocommit */); err != nil {
+		return 0, err
+	}
+
+	for i, colVindex := range upd.Table.Owned {
+		if err := colVindex.Vindex.(vindexes.Lookup).Create(vcursor, [][]sqltypes.Value{rowValues(newRow, rm.ownedCols[i])}, [][]byte{newKsid}, false /* ignoreMode */); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := execShard(vcursor, rm.insertQuery, colVars, newRS, true /* rollbackOnError */, false /* canAutocommit */); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+func rowValues(row []sqltypes.Value, cols []int) []sqltypes.Value {
+	values := make([]sqltypes.Value, 0, len(cols))
+	for _, col := range cols {
+		values = append(values, row[col])
+	}
+	return values
+}
+
+// valuesEqual returns true if the values compare equal, nulls included.
+func valuesEqual(a, b []sqltypes.Value) bool {
+	for i := range a {
+		if cmp, err := evalengine.NullsafeCompare(a[i], b[i]); err != nil || cmp != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (upd *Update) description() PrimitiveDescription {
 	other := map[string]interface{}{
 		"Query":                upd.Query,
@@ -288,6 +549,10 @@ func (upd *Update) description() PrimitiveDescription {
 	if len(changedVindexes) > 0 {
 		other["ChangedVindexValues"] = changedVindexes
 	}
+	if upd.MoveRowsQuery != "" {
+		other["MoveRowsQuery"] = upd.MoveRowsQuery
+		other["MoveColumns"] = strings.Join(upd.MoveColumns, ", ")
+	}
 
 	return PrimitiveDescription{
 		OperatorType:     "Update",
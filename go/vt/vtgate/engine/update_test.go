@@ -61,6 +61,30 @@ func TestUpdateUnsharded(t *testing.T) {
 	expectError(t, "Execute", err, "Keyspace does not have exactly one shard: []")
 }
 
+func TestUpdateRange(t *testing.T) {
+	vindex, _ := vindexes.NewBinary("", nil)
+	upd := &Update{
+		DML: DML{
+			Opcode: Range,
+			Keyspace: &vindexes.Keyspace{
+				Name:    "ks",
+				Sharded: true,
+			},
+			Query:  "dummy_update",
+			Vindex: vindex.(vindexes.SingleColumn),
+			Values: []sqltypes.PlanValue{{Value: sqltypes.NewVarBinary("\x10")}, {Value: sqltypes.NULL}},
+		},
+	}
+
+	vc := newDMLTestVCursor("-20", "20-")
+	_, err := upd.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyRange(10-)`,
+		`ExecuteMultiShard ks.-20: dummy_update {} ks.20-: dummy_update {} true false`,
+	})
+}
+
 func TestUpdateEqual(t *testing.T) {
 	vindex, _ := vindexes.NewHash("", nil)
 	upd := &Update{
@@ -595,6 +619,117 @@ func TestUpdateInChangedVindex(t *testing.T) {
 	})
 }
 
+func TestUpdateEqualMoveRows(t *testing.T) {
+	ks := buildTestVSchema().Keyspaces["sharded"]
+	upd := &Update{
+		DML: DML{
+			Opcode:     Equal,
+			Keyspace:   ks.Keyspace,
+			Query:      "dummy_update",
+			Vindex:     ks.Vindexes["hash"].(vindexes.SingleColumn),
+			Values:     []sqltypes.PlanValue{{Value: sqltypes.NewInt64(1)}},
+			Table:      ks.Tables["t1"],
+			KsidVindex: ks.Vindexes["hash"].(vindexes.SingleColumn),
+		},
+		MoveRowsQuery: "dummy_move_rows",
+		MoveColumns:   []string{"id", "c3"},
+	}
+
+	vc := newDMLTestVCursor("-20", "20-")
+	vc.inTransaction = true
+	vc.results = []*sqltypes.Result{sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|c1|c2|c3|val|id|c3",
+			"int64|int64|int64|int64|varchar|int64|int64",
+		),
+		"1|4|5|6|a|2|7",
+	)}
+
+	result, err := upd.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, result.RowsAffected)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		// The full row is read, followed by the new values of id and c3.
+		`ExecuteMultiShard sharded.-20: dummy_move_rows {} false false`,
+		// The old and new shards of the row.
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(06e7ea22ce92708f)`,
+		// The row and its lookup entries are deleted with the old values.
+		`Execute delete from lkp2 where from1 = :from1 and from2 = :from2 and toc = :toc from1: type:INT64 value:"4" from2: type:INT64 value:"5" toc: type:VARBINARY value:"\026k@\264J\272K\326"  true`,
+		`Execute delete from lkp1 where from = :from and toc = :toc from: type:INT64 value:"6" toc: type:VARBINARY value:"\026k@\264J\272K\326"  true`,
+		`ExecuteMultiShard sharded.-20: delete from t1 where id = :__pk0 {__pk0: type:INT64 value:"1" } true false`,
+		// And inserted again with the new ones.
+		`Execute insert into lkp2(from1, from2, toc) values(:from1_0, :from2_0, :toc_0) from1_0: type:INT64 value:"4" from2_0: type:INT64 value:"5" toc_0: type:VARBINARY value:"\006\347\352\"\316\222p\217"  true`,
+		`Execute insert into lkp1(from, toc) values(:from_0, :toc_0) from_0: type:INT64 value:"7" toc_0: type:VARBINARY value:"\006\347\352\"\316\222p\217"  true`,
+		`ExecuteMultiShard sharded.-20: insert into t1(id, c1, c2, c3, val) values (:__col0, :__col1, :__col2, :__col3, :__col4) ` +
+			`{__col0: type:INT64 value:"2" __col1: type:INT64 value:"4" __col2: type:INT64 value:"5" __col3: type:INT64 value:"7" __col4: type:VARCHAR value:"a" } true false`,
+	})
+
+	// A row that keeps its keyspace id is updated in place.
+	vc = newDMLTestVCursor("-20", "20-")
+	vc.inTransaction = true
+	vc.results = []*sqltypes.Result{sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|c1|c2|c3|val|id|c3",
+			"int64|int64|int64|int64|varchar|int64|int64",
+		),
+		"1|4|5|6|a|1|7",
+	), nil, nil, {RowsAffected: 1}}
+	result, err = upd.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, result.RowsAffected)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard sharded.-20: dummy_move_rows {} false false`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		// Only the lookup entries of c3 change.
+		`Execute delete from lkp1 where from = :from and toc = :toc from: type:INT64 value:"6" toc: type:VARBINARY value:"\026k@\264J\272K\326"  true`,
+		`Execute insert into lkp1(from, toc) values(:from_0, :toc_0) from_0: type:INT64 value:"7" toc_0: type:VARBINARY value:"\026k@\264J\272K\326"  true`,
+		`ExecuteMultiShard sharded.-20: update t1 set id = :__col0, c3 = :__col3 where id = :__pk0 ` +
+			`{__col0: type:INT64 value:"1" __col1: type:INT64 value:"4" __col2: type:INT64 value:"5" __col3: type:INT64 value:"7" __col4: type:VARCHAR value:"a" __pk0: type:INT64 value:"1" } true false`,
+	})
+
+	// A row whose values don't change is not affected, like in mysql.
+	vc = newDMLTestVCursor("-20", "20-")
+	vc.inTransaction = true
+	vc.results = []*sqltypes.Result{sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|c1|c2|c3|val|id|c3",
+			"int64|int64|int64|int64|varchar|int64|int64",
+		),
+		"1|4|5|6|a|1|6",
+	), {RowsAffected: 0}}
+	result, err = upd.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, result.RowsAffected)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard sharded.-20: dummy_move_rows {} false false`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard sharded.-20: update t1 set id = :__col0, c3 = :__col3 where id = :__pk0 ` +
+			`{__col0: type:INT64 value:"1" __col1: type:INT64 value:"4" __col2: type:INT64 value:"5" __col3: type:INT64 value:"6" __col4: type:VARCHAR value:"a" __pk0: type:INT64 value:"1" } true false`,
+	})
+
+	// No rows to move.
+	vc = newDMLTestVCursor("-20", "20-")
+	vc.inTransaction = true
+	result, err = upd.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, result.RowsAffected)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard sharded.-20: dummy_move_rows {} false false`,
+	})
+
+	// Rows are only moved in a transaction.
+	vc = newDMLTestVCursor("-20", "20-")
+	_, err = upd.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.EqualError(t, err, "an update of the primary vindex column of table t1 must run in a transaction")
+}
+
 func TestUpdateNoStream(t *testing.T) {
 	upd := &Update{}
 	err := upd.StreamExecute(nil, nil, false, nil)
@@ -641,6 +776,7 @@ func buildTestVSchema() *vindexes.VSchema {
 							Name:    "onecol",
 							Columns: []string{"c3"},
 						}},
+						PrimaryKey: []string{"id"},
 					},
 					"t2": {
 						ColumnVindexes: []*vschemapb.ColumnVindex{{
@@ -18,7 +18,10 @@ package evalengine
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
+	"math"
 
 	"vitess.io/vitess/go/sqltypes"
 
@@ -203,6 +206,44 @@ func NullsafeCompare(v1, v2 sqltypes.Value) (int, error) {
 	return 0, fmt.Errorf("types are not comparable: %v vs %v", v1.Type(), v2.Type())
 }
 
+// NullsafeHashcode returns a hash of the value that is consistent with
+// NullsafeCompare: values that compare equal have the same hash code.
+// Numeric values are hashed as floats so that 1, 1.0 and a uint 1 share
+// the same hash code. Values that cannot be compared return an error.
+func NullsafeHashcode(v sqltypes.Value) (uint64, error) {
+	h := fnv.New64a()
+	switch {
+	case v.IsNull():
+		return 0, nil
+	case sqltypes.IsNumber(v.Type()):
+		lv, err := newEvalResult(v)
+		if err != nil {
+			return 0, err
+		}
+		var f float64
+		switch lv.typ {
+		case sqltypes.Int64:
+			f = float64(lv.ival)
+		case sqltypes.Uint64:
+			f = float64(lv.uval)
+		case sqltypes.Float64:
+			f = lv.fval
+		}
+		if f == 0 {
+			// Make sure that -0 and 0 hash the same.
+			f = 0
+		}
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(f))
+		h.Write(buf[:])
+	case isByteComparable(v):
+		h.Write(v.Raw())
+	default:
+		return 0, fmt.Errorf("types are not hashable: %v", v.Type())
+	}
+	return h.Sum64(), nil
+}
+
 // isByteComparable returns true if the type is binary or date/time.
 func isByteComparable(v sqltypes.Value) bool {
 	if v.IsBinary() {
@@ -573,6 +573,65 @@ func TestNullsafeCompare(t *testing.T) {
 	}
 }
 
+func TestNullsafeHashcode(t *testing.T) {
+	tcases := []struct {
+		v1, v2 sqltypes.Value
+		equal  bool
+		err    string
+	}{{
+		v1:    sqltypes.NULL,
+		v2:    sqltypes.NULL,
+		equal: true,
+	}, {
+		v1:    sqltypes.NewInt64(1),
+		v2:    sqltypes.NewUint64(1),
+		equal: true,
+	}, {
+		v1:    sqltypes.NewInt64(1),
+		v2:    sqltypes.TestValue(querypb.Type_DECIMAL, "1.00"),
+		equal: true,
+	}, {
+		v1:    sqltypes.NewFloat64(0),
+		v2:    sqltypes.TestValue(querypb.Type_FLOAT64, "-0"),
+		equal: true,
+	}, {
+		v1:    sqltypes.NewInt64(1),
+		v2:    sqltypes.NewInt64(2),
+		equal: false,
+	}, {
+		v1:    sqltypes.TestValue(querypb.Type_VARBINARY, "abcd"),
+		v2:    sqltypes.TestValue(querypb.Type_BINARY, "abcd"),
+		equal: true,
+	}, {
+		v1:    sqltypes.TestValue(querypb.Type_DATETIME, "1000-01-01 00:00:00"),
+		v2:    sqltypes.TestValue(querypb.Type_DATETIME, "2000-01-01 00:00:00"),
+		equal: false,
+	}, {
+		v1:  sqltypes.TestValue(querypb.Type_VARCHAR, "abcd"),
+		v2:  sqltypes.TestValue(querypb.Type_VARCHAR, "abcd"),
+		err: "types are not hashable: VARCHAR",
+	}}
+	for _, tcase := range tcases {
+		h1, err := NullsafeHashcode(tcase.v1)
+		if tcase.err != "" {
+			if err == nil || err.Error() != tcase.err {
+				t.Errorf("NullsafeHashcode(%v) error: %v, want %v", printValue(tcase.v1), err, tcase.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		h2, err := NullsafeHashcode(tcase.v2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (h1 == h2) != tcase.equal {
+			t.Errorf("NullsafeHashcode(%v) == NullsafeHashcode(%v): %v, want %v", printValue(tcase.v1), printValue(tcase.v2), h1 == h2, tcase.equal)
+		}
+	}
+}
+
 func TestCast(t *testing.T) {
 	tcases := []struct {
 		typ querypb.Type
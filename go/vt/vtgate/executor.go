@@ -41,14 +41,18 @@ import (
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/sync2"
+	"vitess.io/vitess/go/vt/audit"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder"
+	"vitess.io/vitess/go/vt/vtgate/quota"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
 
@@ -100,6 +104,18 @@ type Executor struct {
 	plans        *cache.LRUCache
 	vschemaStats *VSchemaStats
 
+	// resultCache is nil unless the result cache is enabled.
+	resultCache *resultCache
+	// quotas is nil unless the vtgate quotas are configured.
+	quotas *quota.Manager
+	// consolidator is nil unless the consolidator is enabled. If
+	// consolidateOnlyReplicas is set, the queries sent to the masters
+	// are not consolidated.
+	consolidator            *sync2.Consolidator
+	consolidateOnlyReplicas bool
+	// queryDigests is nil unless the query digests are enabled.
+	queryDigests *stats.QueryDigests
+
 	vm *VSchemaManager
 }
 
@@ -138,6 +154,9 @@ func NewExecutor(ctx context.Context, serv srvtopo.Server, cell string, resolver
 		http.Handle(pathQueryPlans, e)
 		http.Handle(pathScatterStats, e)
 		http.Handle(pathVSchema, e)
+		http.Handle(pathConsolidations, e)
+		http.Handle(pathQueryDigests, e)
+		http.Handle(pathTopQueries, e)
 	})
 	return e
 }
@@ -153,6 +172,7 @@ func (e *Executor) Execute(ctx context.Context, method string, safeSession *Safe
 	stmtType, result, err := e.execute(ctx, safeSession, sql, bindVars, logStats)
 	logStats.Error = err
 	saveSessionStats(safeSession, stmtType, result, err)
+	audit.Query(ctx, stmtType, logStats.Keyspace, logStats.SQL, logStats.Tables, logStats.RowsAffected, err)
 	if result != nil && len(result.Rows) > *warnMemoryRows {
 		warnings.Add("ResultsExceeded", 1)
 	}
@@ -185,6 +205,11 @@ func (e *Executor) execute(ctx context.Context, safeSession *SafeSession, sql st
 	if err == planbuilder.ErrPlanNotSupported {
 		return e.legacyExecute(ctx, safeSession, sql, bindVars, logStats)
 	}
+	if buffer.IsRoutingChangedError(err) && len(safeSession.ShardSessions) == 0 {
+		// The request was buffered during a MoveTables or Reshard cutover
+		// and did not run anywhere yet. Plan it again with the new routing.
+		stmtType, qr, err = e.newExecute(ctx, safeSession, sql, bindVars, logStats)
+	}
 	return stmtType, qr, err
 }
 
@@ -334,13 +359,22 @@ func (e *Executor) handleCommit(ctx context.Context, safeSession *SafeSession, l
 	logStats.ShardQueries = uint32(len(safeSession.ShardSessions))
 	e.updateQueryCounts("Commit", "", "", int64(logStats.ShardQueries))
 
-	err := e.txConn.Commit(ctx, safeSession)
+	err := e.commit(ctx, safeSession)
 	logStats.CommitTime = time.Since(execStart)
 	return &sqltypes.Result{}, err
 }
 
 //Commit commits the existing transactions
 func (e *Executor) Commit(ctx context.Context, safeSession *SafeSession) error {
+	return e.commit(ctx, safeSession)
+}
+
+func (e *Executor) commit(ctx context.Context, safeSession *SafeSession) error {
+	// The writes of the transaction become visible to the other sessions,
+	// which may have cached results without them in the meantime.
+	if e.resultCache != nil && safeSession.InTransaction() && safeSession.GetLastWrite() != 0 {
+		defer safeSession.RecordWrite()
+	}
 	return e.txConn.Commit(ctx, safeSession)
 }
 
@@ -1106,6 +1140,9 @@ func (e *Executor) StreamExecute(ctx context.Context, method string, safeSession
 	stmtType := sqlparser.Preview(sql)
 	logStats.StmtType = stmtType.String()
 	defer logStats.Send()
+	defer func() {
+		audit.Query(ctx, stmtType, logStats.Keyspace, logStats.SQL, logStats.Tables, logStats.RowsAffected, err)
+	}()
 
 	if bindVars == nil {
 		bindVars = make(map[string]*querypb.BindVariable)
@@ -1113,6 +1150,7 @@ func (e *Executor) StreamExecute(ctx context.Context, method string, safeSession
 	query, comments := sqlparser.SplitMarginComments(sql)
 	vcursor, _ := newVCursorImpl(ctx, safeSession, comments, e, logStats, e.vm, e.VSchema(), e.resolver.resolver, e.serv)
 	vcursor.SetIgnoreMaxMemoryRows(true)
+	defer vcursor.memoryBudget.ReleaseAll()
 	switch stmtType {
 	case sqlparser.StmtStream:
 		// this is a stream statement for messaging
@@ -1146,6 +1184,17 @@ func (e *Executor) StreamExecute(ctx context.Context, method string, safeSession
 		logStats.Error = err
 		return err
 	}
+	logStats.Keyspace = plan.Instructions.GetKeyspaceName()
+	logStats.Tables = plan.Tables
+
+	if e.quotas != nil {
+		release, err := e.quotas.Admit(ctx, quotaRequest(ctx, plan, safeSession))
+		if err != nil {
+			logStats.Error = err
+			return err
+		}
+		defer release()
+	}
 
 	err = e.addNeededBindVars(plan.BindVarNeeds, bindVars, safeSession)
 	if err != nil {
@@ -1205,6 +1254,7 @@ func (e *Executor) StreamExecute(ctx context.Context, method string, safeSession
 
 	logStats.ExecuteTime = time.Since(execStart)
 	e.updateQueryCounts(plan.Instructions.RouteType(), plan.Instructions.GetKeyspaceName(), plan.Instructions.GetTableName(), int64(logStats.ShardQueries))
+	e.recordQueryDigest(plan, logStats, int(foundRows), err)
 
 	// save session stats for future queries
 	if !safeSession.foundRowsHandled {
@@ -1317,7 +1367,13 @@ func (e *Executor) getPlan(vcursor *vcursorImpl, sql string, comments sqlparser.
 	ignoreMaxMemoryRows := sqlparser.IgnoreMaxMaxMemoryRowsDirective(stmt)
 	vcursor.SetIgnoreMaxMemoryRows(ignoreMaxMemoryRows)
 
-	planKey := vcursor.planPrefixKey() + ":" + sql
+	// The row policies which apply to the caller are part of the plan.
+	policies := newRowPolicies(vcursor.ctx, vcursor.vschema)
+	policies.setBindVars(bindVars)
+	// So are the columns of the table ACL the caller can't read.
+	restrictions := newColumnRestrictions(vcursor.ctx)
+
+	planKey := vcursor.planPrefixKey() + restrictions.planKey() + policies.planKey() + ":" + sql
 	if plan, ok := e.plans.Get(planKey); ok {
 		return plan.(*engine.Plan), nil
 	}
@@ -1339,14 +1395,28 @@ func (e *Executor) getPlan(vcursor *vcursorImpl, sql string, comments sqlparser.
 		logStats.BindVariables = bindVars
 	}
 
-	planKey = vcursor.planPrefixKey() + ":" + query
+	planKey = vcursor.planPrefixKey() + restrictions.planKey() + policies.planKey() + ":" + query
 	if plan, ok := e.plans.Get(planKey); ok {
 		return plan.(*engine.Plan), nil
 	}
+	masked, err := restrictions.rewrite(statement)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := policies.rewrite(statement, vcursor)
+	if err != nil {
+		return nil, err
+	}
+	if masked || policies != nil {
+		query = sqlparser.String(statement)
+	}
 	plan, err := planbuilder.BuildFromStmt(query, statement, vcursor, bindVarNeeds)
 	if err != nil {
 		return nil, err
 	}
+	if len(checks) != 0 && plan.Instructions != nil {
+		plan.Instructions = &engine.RowPolicyCheck{Input: plan.Instructions, Checks: checks}
+	}
 	if !skipQueryPlanCache && !sqlparser.SkipQueryPlanCacheDirective(statement) && plan.Instructions != nil {
 		e.plans.Set(planKey, plan)
 	}
@@ -1375,6 +1445,12 @@ func (e *Executor) ServeHTTP(response http.ResponseWriter, request *http.Request
 		returnAsJSON(response, e.VSchema())
 	case pathScatterStats:
 		e.WriteScatterStats(response)
+	case pathConsolidations:
+		e.handleConsolidations(response)
+	case pathQueryDigests:
+		returnAsJSON(response, e.queryDigestsSnapshot())
+	case pathTopQueries:
+		e.handleTopQueries(response, request)
 	default:
 		response.WriteHeader(http.StatusNotFound)
 	}
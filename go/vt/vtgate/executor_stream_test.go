@@ -17,12 +17,19 @@ limitations under the License.
 package vtgate
 
 import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/audit"
 	"vitess.io/vitess/go/vt/discovery"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -112,3 +119,33 @@ func executorStreamMessages(executor *Executor, sql string) (qr *sqltypes.Result
 	}
 	return qr, nil
 }
+
+func TestStreamExecuteAudit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "audit.log")
+	require.NoError(t, flag.Set("audit_log_file", file))
+	require.NoError(t, flag.Set("audit_log_dml_tables", "user"))
+	defer func() {
+		audit.Close()
+		flag.Set("audit_log_file", "")
+		flag.Set("audit_log_dml_tables", "")
+	}()
+	require.NoError(t, audit.Init())
+
+	executor, _, _, _ := createLegacyExecutorEnv()
+	_, err = executorStream(executor, "alter table TestExecutor.user add column a int")
+	require.NoError(t, err)
+	// The DML statements can't be streamed, but the attempts are recorded.
+	_, err = executorStream(executor, "update user set a = 2 where id = 1")
+	require.Error(t, err)
+
+	data, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	n, err := audit.Verify(bytes.NewReader(data), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Contains(t, string(data), `"class":"ddl","stmt_type":"DDL","keyspace":"TestExecutor","tables":["user"],"sql":"alter table TestExecutor.user add column a int"`)
+	assert.Contains(t, string(data), `"class":"dml","stmt_type":"UPDATE","keyspace":"TestExecutor","tables":["user"],"sql":"update user set a = 2 where id = 1"`)
+}
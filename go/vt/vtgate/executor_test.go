@@ -39,6 +39,9 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/buffer"
+	"vitess.io/vitess/go/vt/vtgate/quota"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
 
@@ -2255,3 +2258,80 @@ func exec(executor *Executor, session *SafeSession, sql string) (*sqltypes.Resul
 func makeComments(text string) sqlparser.MarginComments {
 	return sqlparser.MarginComments{Trailing: text}
 }
+
+func TestExecutorReplanAfterRoutingChange(t *testing.T) {
+	executor, _, _, sbclookup := createExecutorEnv()
+	session := NewSafeSession(&vtgatepb.Session{TargetString: "@master", Autocommit: true})
+
+	// A request which was buffered during a cutover is planned again once.
+	sbclookup.ShardErr = buffer.ErrRoutingChanged
+	_, err := executor.Execute(ctx, "TestExecute", session, "select id from main1", nil)
+	require.Error(t, err)
+	assert.EqualValues(t, 2, sbclookup.ExecCount.Get())
+
+	// An error with the same message is not retried.
+	sbclookup.ExecCount.Set(0)
+	sbclookup.ShardErr = vterrors.New(vtrpcpb.Code_UNAVAILABLE, buffer.ErrRoutingChanged.Error())
+	_, err = executor.Execute(ctx, "TestExecute", session, "select id from main1", nil)
+	require.Error(t, err)
+	assert.EqualValues(t, 1, sbclookup.ExecCount.Get())
+
+	// Other errors are not retried.
+	sbclookup.ExecCount.Set(0)
+	sbclookup.ShardErr = vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, "disallowed due to rule: enforce blacklisted tables")
+	_, err = executor.Execute(ctx, "TestExecute", session, "select id from main1", nil)
+	require.Error(t, err)
+	assert.EqualValues(t, 1, sbclookup.ExecCount.Get())
+}
+
+func TestExecutorQuotas(t *testing.T) {
+	executor, sbc1, _, _ := createLegacyExecutorEnv()
+	config, err := quota.ParseConfig([]byte(`{"quotas": [{"name": "user", "key": "table", "values": ["TestExecutor.user"], "qps": 1}]}`))
+	require.NoError(t, err)
+	executor.quotas = quota.NewManager(false)
+	executor.quotas.SetConfig(config)
+
+	_, err = executorExec(executor, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	count := sbc1.ExecCount.Get()
+	_, err = executorExec(executor, "select id from user where id = 1", nil)
+	assert.EqualError(t, err, "quota user exceeded: QPS limit for table TestExecutor.user")
+	assert.Equal(t, count, sbc1.ExecCount.Get())
+
+	// Other tables are not limited.
+	_, err = executorExec(executor, "select id from music where id = 1", nil)
+	require.NoError(t, err)
+}
+
+func TestQuotaRequest(t *testing.T) {
+	executor, _, _, _ := createLegacyExecutorEnv()
+	ctx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("principal", "", ""), callerid.NewImmediateCallerID("user1"))
+	session := NewSafeSession(&vtgatepb.Session{Options: &querypb.ExecuteOptions{Workload: querypb.ExecuteOptions_OLAP}})
+	vcursor, err := newVCursorImpl(ctx, session, makeComments(""), executor, nil, executor.vm, executor.VSchema(), executor.resolver.resolver, executor.serv)
+	require.NoError(t, err)
+
+	tcases := []struct {
+		sql  string
+		want *quota.Request
+	}{{
+		sql:  "select id from user where id = 1",
+		want: &quota.Request{Tables: []string{"TestExecutor.user"}},
+	}, {
+		sql:  "select u.id from user u join music m on u.id = m.id",
+		want: &quota.Request{Tables: []string{"TestExecutor.music", "TestExecutor.user"}, Scatter: true},
+	}, {
+		sql:  "delete from user",
+		want: &quota.Request{Tables: []string{"TestExecutor.user"}, Scatter: true},
+	}, {
+		sql:  "begin",
+		want: &quota.Request{},
+	}}
+	for _, tcase := range tcases {
+		plan, err := executor.getPlan(vcursor, tcase.sql, makeComments(""), nil, false, nil)
+		require.NoError(t, err, tcase.sql)
+		tcase.want.ImmediateCaller = "user1"
+		tcase.want.EffectiveCaller = "principal"
+		tcase.want.Workload = "OLAP"
+		assert.Equal(t, tcase.want, quotaRequest(ctx, plan, session), tcase.sql)
+	}
+}
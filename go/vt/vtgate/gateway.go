@@ -22,6 +22,7 @@ import (
 
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -117,3 +118,37 @@ func WaitForTablets(gw Gateway, tabletTypesToWait []topodatapb.TabletType) error
 	}
 	return err
 }
+
+// shardServingCheckTimeout bounds how long the buffer waits for a
+// SrvKeyspace while it checks if a shard still serves MASTER traffic.
+const shardServingCheckTimeout = 1 * time.Second
+
+// newShardServingFunc returns a buffer.ShardServingFunc which checks the
+// MASTER partition of the SrvKeyspace that is also used to resolve the
+// shards of a request.
+// If the SrvKeyspace cannot be read, the shard is assumed to be serving.
+func newShardServingFunc(serv srvtopo.Server, cell string) buffer.ShardServingFunc {
+	return func(keyspace, shard string) bool {
+		if serv == nil {
+			return true
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), shardServingCheckTimeout)
+		defer cancel()
+		srvKeyspace, err := serv.GetSrvKeyspace(ctx, cell, keyspace)
+		if err != nil {
+			return true
+		}
+		for _, partition := range srvKeyspace.Partitions {
+			if partition.ServedType != topodatapb.TabletType_MASTER {
+				continue
+			}
+			for _, shardReference := range partition.ShardReferences {
+				if shardReference.Name == shard {
+					return true
+				}
+			}
+			return false
+		}
+		return true
+	}
+}
@@ -42,6 +42,7 @@ type LogStats struct {
 	Keyspace      string
 	TabletType    string
 	Table         string
+	Tables        []string
 	StmtType      string
 	SQL           string
 	BindVariables map[string]*querypb.BindVariable
@@ -18,17 +18,22 @@ package vtgate
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"vitess.io/vitess/go/mysql"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder"
+	"vitess.io/vitess/go/vt/vtgate/quota"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
 func (e *Executor) newExecute(ctx context.Context, safeSession *SafeSession, sql string, bindVars map[string]*querypb.BindVariable, logStats *LogStats) (sqlparser.StatementType, *sqltypes.Result, error) {
@@ -49,6 +54,7 @@ func (e *Executor) newExecute(ctx context.Context, safeSession *SafeSession, sql
 	if err != nil {
 		return 0, nil, err
 	}
+	defer vcursor.memoryBudget.ReleaseAll()
 
 	// 2: Create a plan for the query
 	plan, err := e.getPlan(
@@ -105,6 +111,15 @@ func (e *Executor) newExecute(ctx context.Context, safeSession *SafeSession, sql
 		return sqlparser.StmtRelease, qr, err
 	}
 
+	if e.quotas != nil {
+		release, err := e.quotas.Admit(ctx, quotaRequest(ctx, plan, safeSession))
+		if err != nil {
+			logStats.Error = err
+			return 0, nil, err
+		}
+		defer release()
+	}
+
 	// 3: Prepare for execution
 	err = e.addNeededBindVars(plan.BindVarNeeds, bindVars, safeSession)
 	if err != nil {
@@ -172,14 +187,39 @@ type currFunc func(*LogStats, *SafeSession) (sqlparser.StatementType, *sqltypes.
 func (e *Executor) executePlan(ctx context.Context, plan *engine.Plan, vcursor *vcursorImpl, bindVars map[string]*querypb.BindVariable, execStart time.Time) currFunc {
 	return func(logStats *LogStats, safeSession *SafeSession) (sqlparser.StatementType, *sqltypes.Result, error) {
 		// 4: Execute!
-		qr, err := plan.Instructions.Execute(vcursor, bindVars, true)
+		execute := func() (*sqltypes.Result, error) {
+			if e.resultCache != nil && len(plan.ResultCacheTables) > 0 && !e.resultCache.skip(safeSession) {
+				return e.resultCache.execute(vcursor.planPrefixKey(), plan, vcursor, bindVars)
+			}
+			return plan.Instructions.Execute(vcursor, bindVars, true)
+		}
+		var qr *sqltypes.Result
+		var err error
+		if e.canConsolidate(plan, vcursor, safeSession) {
+			qr, err = e.executeConsolidated(plan, vcursor, bindVars, execute)
+		} else {
+			qr, err = execute()
+		}
+		if e.resultCache != nil {
+			switch plan.Type {
+			case sqlparser.StmtInsert, sqlparser.StmtReplace, sqlparser.StmtUpdate, sqlparser.StmtDelete:
+				// Even a failed statement may have changed rows.
+				safeSession.RecordWrite()
+			}
+		}
 
 		// 5: Log and add statistics
 		logStats.Keyspace = plan.Instructions.GetKeyspaceName()
 		logStats.Table = plan.Instructions.GetTableName()
+		logStats.Tables = plan.Tables
 		logStats.TabletType = vcursor.TabletType().String()
 		errCount := e.logExecutionEnd(logStats, execStart, plan, err, qr)
 		plan.AddStats(1, time.Since(logStats.StartTime), uint64(logStats.ShardQueries), logStats.RowsAffected, errCount)
+		var rowsReturned int
+		if qr != nil {
+			rowsReturned = len(qr.Rows)
+		}
+		e.recordQueryDigest(plan, logStats, rowsReturned, err)
 
 		// Check if there was partial DML execution. If so, rollback the transaction.
 		if err != nil && safeSession.InTransaction() && vcursor.rollbackOnPartialExec {
@@ -213,3 +253,51 @@ func (e *Executor) logPlanningFinished(logStats *LogStats, plan *engine.Plan) ti
 	logStats.PlanTime = execStart.Sub(logStats.StartTime)
 	return execStart
 }
+
+// quotaRequest describes the execution of plan for the vtgate quotas.
+func quotaRequest(ctx context.Context, plan *engine.Plan, safeSession *SafeSession) *quota.Request {
+	req := &quota.Request{
+		ImmediateCaller: callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx)),
+		EffectiveCaller: callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)),
+		Workload:        safeSession.GetOptions().GetWorkload().String(),
+	}
+	if plan.Instructions == nil {
+		return req
+	}
+	tables := make(map[string]bool)
+	addTable := func(keyspace *vindexes.Keyspace, names string) {
+		if keyspace == nil || names == "" {
+			return
+		}
+		// Routes name all the tables they join, separated by commas.
+		for _, name := range strings.Split(names, ",") {
+			tables[keyspace.Name+"."+strings.TrimSpace(name)] = true
+		}
+	}
+	addVSchemaTable := func(keyspace *vindexes.Keyspace, table *vindexes.Table) {
+		if table != nil {
+			addTable(keyspace, table.Name.String())
+		}
+	}
+	engine.Find(func(p engine.Primitive) bool {
+		switch p := p.(type) {
+		case *engine.Route:
+			addTable(p.Keyspace, p.TableName)
+			req.Scatter = req.Scatter || p.Opcode == engine.SelectScatter
+		case *engine.Update:
+			addVSchemaTable(p.Keyspace, p.Table)
+			req.Scatter = req.Scatter || p.Opcode == engine.Scatter
+		case *engine.Delete:
+			addVSchemaTable(p.Keyspace, p.Table)
+			req.Scatter = req.Scatter || p.Opcode == engine.Scatter
+		case *engine.Insert:
+			addVSchemaTable(p.Keyspace, p.Table)
+		}
+		return false
+	}, plan.Instructions)
+	for table := range tables {
+		req.Tables = append(req.Tables, table)
+	}
+	sort.Strings(req.Tables)
+	return req
+}
@@ -68,7 +68,9 @@ type builder interface {
 	PushSelect(pb *primitiveBuilder, expr *sqlparser.AliasedExpr, origin builder) (rc *resultColumn, colNumber int, err error)
 
 	// MakeDistinct makes the primitive handle the distinct clause.
-	MakeDistinct() error
+	// It returns the current primitive or a replacement if a new
+	// one was created.
+	MakeDistinct() (builder, error)
 	// PushGroupBy makes the primitive handle the GROUP BY clause.
 	PushGroupBy(sqlparser.GroupBy) error
 
@@ -282,19 +284,57 @@ var ErrPlanNotSupported = errors.New("plan building not supported")
 
 // BuildFromStmt builds a plan based on the AST provided.
 func BuildFromStmt(query string, stmt sqlparser.Statement, vschema ContextVSchema, bindVarNeeds *sqlparser.BindVarNeeds) (*engine.Plan, error) {
+	// The tables must be found before planning, which rewrites subqueries.
+	var cacheTables []string
+	if sel, ok := stmt.(*sqlparser.Select); ok {
+		cacheTables = resultCacheTables(sel, vschema, bindVarNeeds)
+	}
+	tables := statementTables(stmt)
 	instruction, err := createInstructionFor(query, stmt, vschema)
 	if err != nil {
 		return nil, err
 	}
 	plan := &engine.Plan{
-		Type:         sqlparser.ASTToStatementType(stmt),
-		Original:     query,
-		Instructions: instruction,
-		BindVarNeeds: bindVarNeeds,
+		Type:              sqlparser.ASTToStatementType(stmt),
+		Original:          query,
+		Instructions:      instruction,
+		BindVarNeeds:      bindVarNeeds,
+		ResultCacheTables: cacheTables,
+		Tables:            tables,
 	}
 	return plan, nil
 }
 
+// statementTables returns the names of the tables of a DDL or DML
+// statement.
+func statementTables(stmt sqlparser.Statement) []string {
+	var tables []string
+	visit := func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName, *sqlparser.StarExpr:
+			// Their qualifiers are not tables.
+			return false, nil
+		case sqlparser.TableNames:
+			// The targets of a multi-table delete are aliases.
+			return false, nil
+		case sqlparser.TableName:
+			if !node.IsEmpty() {
+				tables = append(tables, node.Name.String())
+			}
+		}
+		return true, nil
+	}
+	switch stmt := stmt.(type) {
+	case *sqlparser.DDL:
+		for _, table := range stmt.AffectedTables() {
+			tables = append(tables, table.Name.String())
+		}
+	case *sqlparser.Insert, *sqlparser.Update, *sqlparser.Delete:
+		_ = sqlparser.Walk(visit, stmt)
+	}
+	return tables
+}
+
 func buildRoutePlan(stmt sqlparser.Statement, vschema ContextVSchema, f func(statement sqlparser.Statement, schema ContextVSchema) (engine.Primitive, error)) (engine.Primitive, error) {
 	if vschema.Destination() != nil {
 		return buildPlanForBypass(stmt, vschema)
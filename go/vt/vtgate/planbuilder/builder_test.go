@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestStatementTables(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want []string
+	}{{
+		sql:  "alter table user add column a int",
+		want: []string{"user"},
+	}, {
+		sql:  "rename table a to b",
+		want: []string{"a", "b"},
+	}, {
+		sql:  "update user set a = 1 where id in (select user_id from music)",
+		want: []string{"user", "music"},
+	}, {
+		sql:  "insert into ks.user(id) values (1)",
+		want: []string{"user"},
+	}, {
+		sql:  "delete u from user as u join music as m on u.id = m.user_id where m.a = 1",
+		want: []string{"user", "music"},
+	}, {
+		sql: "select * from user",
+	}}
+	for _, tcase := range tests {
+		stmt, err := sqlparser.Parse(tcase.sql)
+		require.NoError(t, err)
+		assert.Equal(t, tcase.want, statementTables(stmt), tcase.sql)
+	}
+}
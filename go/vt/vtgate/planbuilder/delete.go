@@ -26,6 +26,13 @@ import (
 // buildDeletePlan builds the instructions for a DELETE statement.
 func buildDeletePlan(stmt sqlparser.Statement, vschema ContextVSchema) (engine.Primitive, error) {
 	del := stmt.(*sqlparser.Delete)
+	pb, err := analyzeMultiTableDML(vschema, del, del.TableExprs)
+	if err != nil {
+		return nil, err
+	}
+	if pb != nil {
+		return buildMultiTableDeletePlan(del, vschema, pb)
+	}
 	dml, ksidVindex, ksidCol, err := buildDMLPlan(vschema, "delete", del, del.TableExprs, del.Where, del.OrderBy, del.Limit, del.Comments, del.Targets)
 	if err != nil {
 		return nil, err
@@ -46,6 +53,20 @@ func buildDeletePlan(stmt sqlparser.Statement, vschema ContextVSchema) (engine.P
 		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Unknown table '%s' in MULTI DELETE", del.Targets[0].Name.String())
 	}
 
+	if edel.Opcode == engine.Scatter && del.Limit != nil {
+		// The rows to delete are selected first, because the limit
+		// applies to all the shards.
+		return buildDMLWithInputPlan(vschema, "delete", edel.Table, sqlparser.TableName{}, del.TableExprs, del.Where, del.OrderBy, del.Limit, func(where *sqlparser.Where) (engine.Primitive, error) {
+			return buildDeletePlan(&sqlparser.Delete{
+				Ignore:     del.Ignore,
+				Comments:   del.Comments,
+				Targets:    del.Targets,
+				TableExprs: del.TableExprs,
+				Where:      where,
+			}, vschema)
+		})
+	}
+
 	if len(edel.Table.Owned) > 0 {
 		edel.OwnedVindexQuery = generateDMLSubquery(del.Where, del.OrderBy, del.Limit, edel.Table, ksidCol)
 		edel.KsidVindex = ksidVindex
@@ -53,3 +74,35 @@ func buildDeletePlan(stmt sqlparser.Statement, vschema ContextVSchema) (engine.P
 
 	return edel, nil
 }
+
+// buildMultiTableDeletePlan builds the instructions for a DELETE statement
+// that joins tables of a sharded keyspace. If the tables are in the same
+// shard, the statement is sent as is. Otherwise, the rows to delete are
+// selected first, and then deleted by primary key.
+func buildMultiTableDeletePlan(del *sqlparser.Delete, vschema ContextVSchema, pb *primitiveBuilder) (engine.Primitive, error) {
+	if len(del.Targets) != 1 {
+		return nil, vterrors.New(vtrpc.Code_UNIMPLEMENTED, "unsupported: multi-table delete statement in sharded keyspace")
+	}
+	target := findDMLTable(pb.st, del.Targets[0])
+	if target == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Unknown table '%s' in MULTI DELETE", del.Targets[0].Name.String())
+	}
+	table := target.vschemaTable
+
+	if rb, ok := pb.bldr.(*route); ok && len(table.Owned) == 0 {
+		dml, err := buildColocatedDMLPlan(pb, rb, "delete", del, del.Where, del.Comments, table)
+		if err != nil {
+			return nil, err
+		}
+		return &engine.Delete{DML: *dml}, nil
+	}
+
+	return buildDMLWithInputPlan(vschema, "delete", table, target.alias, del.TableExprs, del.Where, del.OrderBy, del.Limit, func(where *sqlparser.Where) (engine.Primitive, error) {
+		return buildDeletePlan(&sqlparser.Delete{
+			Ignore:     del.Ignore,
+			Comments:   del.Comments,
+			TableExprs: dmlTarget(table),
+			Where:      where,
+		}, vschema)
+	})
+}
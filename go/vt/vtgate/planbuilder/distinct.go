@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"errors"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+)
+
+var _ builder = (*distinct)(nil)
+
+// distinct is the builder for engine.Distinct.
+// This gets built for a SELECT DISTINCT whose duplicates
+// cannot be removed by mysql, like the results of a
+// cross-shard join or subquery. The ORDER BY is pushed
+// into the input. If it covers every column, the input
+// is sorted and the engine primitive switches to its
+// ordered variant.
+type distinct struct {
+	resultsBuilder
+	eDistinct *engine.Distinct
+}
+
+// newDistinct builds a new distinct.
+func newDistinct(bldr builder) *distinct {
+	eDistinct := &engine.Distinct{}
+	d := &distinct{
+		resultsBuilder: newResultsBuilder(bldr, eDistinct),
+		eDistinct:      eDistinct,
+	}
+	for i := range d.resultColumns {
+		eDistinct.Cols = append(eDistinct.Cols, i)
+	}
+	return d
+}
+
+// Primitive satisfies the builder interface.
+func (d *distinct) Primitive() engine.Primitive {
+	d.eDistinct.Input = d.input.Primitive()
+	return d.eDistinct
+}
+
+// PushLock satisfies the builder interface.
+func (d *distinct) PushLock(lock sqlparser.Lock) error {
+	return d.input.PushLock(lock)
+}
+
+// PushFilter satisfies the builder interface.
+// A filter on the distinct rows can be applied before
+// removing the duplicates.
+func (d *distinct) PushFilter(pb *primitiveBuilder, filter sqlparser.Expr, whereType string, origin builder) error {
+	return d.input.PushFilter(pb, filter, whereType, origin)
+}
+
+// PushSelect satisfies the builder interface.
+func (d *distinct) PushSelect(_ *primitiveBuilder, expr *sqlparser.AliasedExpr, origin builder) (rc *resultColumn, colNumber int, err error) {
+	return nil, 0, errors.New("distinct.PushSelect: unreachable")
+}
+
+// MakeDistinct satisfies the builder interface.
+func (d *distinct) MakeDistinct() (builder, error) {
+	return d, nil
+}
+
+// PushGroupBy satisfies the builder interface.
+func (d *distinct) PushGroupBy(groupBy sqlparser.GroupBy) error {
+	if len(groupBy) == 0 {
+		return nil
+	}
+	return errors.New("distinct.PushGroupBy: unreachable")
+}
+
+// PushOrderBy satisfies the builder interface.
+// Removing duplicates preserves the order of the rows,
+// so the ORDER BY is pushed into the input.
+func (d *distinct) PushOrderBy(orderBy sqlparser.OrderBy) (builder, error) {
+	bldr, err := d.input.PushOrderBy(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	d.input = bldr
+	d.eDistinct.Ordered = d.isOrderedBy(orderBy)
+	return d, nil
+}
+
+// isOrderedBy returns true if the order by references every column
+// of the distinct.
+func (d *distinct) isOrderedBy(orderBy sqlparser.OrderBy) bool {
+	ordered := make(map[int]bool)
+	for _, order := range orderBy {
+		switch expr := order.Expr.(type) {
+		case *sqlparser.Literal:
+			colNumber, err := ResultFromNumber(d.resultColumns, expr)
+			if err != nil {
+				return false
+			}
+			ordered[colNumber] = true
+		case *sqlparser.ColName:
+			c, ok := expr.Metadata.(*column)
+			if !ok {
+				return false
+			}
+			for i, rc := range d.resultColumns {
+				if rc.column == c {
+					ordered[i] = true
+				}
+			}
+		}
+	}
+	for _, col := range d.eDistinct.Cols {
+		if !ordered[col] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetUpperLimit satisfies the builder interface.
+// The call is ignored because the limit applies to the
+// rows that remain after removing the duplicates.
+func (d *distinct) SetUpperLimit(_ sqlparser.Expr) {
+}
+
+// Wireup satisfies the builder interface.
+// If text columns are detected, then the function modifies
+// the primitive to pull a corresponding weight_string from mysql and
+// compare those instead. This is because we currently don't have the
+// ability to mimic mysql's collation behavior.
+func (d *distinct) Wireup(bldr builder, jt *jointab) error {
+	for i, col := range d.eDistinct.Cols {
+		rc := d.resultColumns[col]
+		if sqltypes.IsText(rc.column.typ) {
+			// If a weight string was previously requested, reuse it.
+			if weightcolNumber, ok := d.weightStrings[rc]; ok {
+				d.eDistinct.Cols[i] = weightcolNumber
+				continue
+			}
+			weightcolNumber, err := d.input.SupplyWeightString(col)
+			if err != nil {
+				return err
+			}
+			d.weightStrings[rc] = weightcolNumber
+			d.eDistinct.Cols[i] = weightcolNumber
+			d.eDistinct.TruncateColumnCount = len(d.resultColumns)
+		}
+	}
+	return d.input.Wireup(bldr, jt)
+}
@@ -18,6 +18,7 @@ package planbuilder
 
 import (
 	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -28,39 +29,94 @@ import (
 
 // getDMLRouting returns the vindex and values for the DML,
 // If it cannot find a unique vindex match, it returns an error.
-func getDMLRouting(where *sqlparser.Where, table *vindexes.Table) (engine.DMLOpcode, vindexes.SingleColumn, string, vindexes.SingleColumn, []sqltypes.PlanValue, error) {
+// The keyspace id vindex is the first unique single-column vindex.
+// It is nil if the primary vindex is multi-column and the table
+// has no owned vindexes, which need it.
+func getDMLRouting(where *sqlparser.Where, table *vindexes.Table) (engine.DMLOpcode, vindexes.SingleColumn, string, vindexes.Vindex, []sqltypes.PlanValue, error) {
 	var ksidVindex vindexes.SingleColumn
 	var ksidCol string
 	for _, index := range table.Ordered {
-		if !index.Vindex.IsUnique() {
-			continue
-		}
-		single, ok := index.Vindex.(vindexes.SingleColumn)
-		if !ok {
-			continue
-		}
-		if ksidCol == "" {
+		if single, ok := index.Vindex.(vindexes.SingleColumn); ok && single.IsUnique() {
 			ksidCol = sqlparser.String(index.Columns[0])
 			ksidVindex = single
+			break
 		}
-		if where == nil {
-			return engine.Scatter, ksidVindex, ksidCol, nil, nil, nil
+	}
+	if ksidVindex == nil {
+		if len(table.ColumnVindexes) == 0 {
+			return engine.Scatter, nil, "", nil, nil, vterrors.New(vtrpcpb.Code_INTERNAL, "table without a primary vindex is not expected")
+		}
+		if len(table.Owned) > 0 {
+			return engine.Scatter, nil, "", nil, nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: owned vindexes on table %s whose primary vindex is multi-column", table.Name.String())
 		}
+	}
+	if where == nil {
+		return engine.Scatter, ksidVindex, ksidCol, nil, nil, nil
+	}
 
-		if pv, ok := getMatch(where.Expr, index.Columns[0]); ok {
+	var prefixVindex, rangeVindex vindexes.Vindex
+	var prefixValues, rangeValues []sqltypes.PlanValue
+	for _, index := range table.Ordered {
+		if !index.Vindex.IsUnique() {
+			continue
+		}
+		values := make([]sqltypes.PlanValue, len(index.Columns))
+		matched := make([]bool, len(index.Columns))
+		known := 0
+		for i, col := range index.Columns {
+			values[i], matched[i] = getMatch(where.Expr, col)
+			if matched[i] {
+				known++
+			}
+		}
+		if known == len(index.Columns) {
 			opcode := engine.Equal
-			if pv.IsList() {
-				opcode = engine.In
+			for _, pv := range values {
+				if pv.IsList() {
+					opcode = engine.In
+				}
+			}
+			return opcode, ksidVindex, ksidCol, index.Vindex, values, nil
+		}
+		if prefixable, ok := index.Vindex.(vindexes.Prefixable); ok && prefixVindex == nil {
+			var pvs []sqltypes.PlanValue
+			for _, i := range prefixable.PrefixColumns() {
+				if !matched[i] {
+					pvs = nil
+					break
+				}
+				pvs = append(pvs, values[i])
+			}
+			if pvs != nil {
+				prefixVindex, prefixValues = index.Vindex, pvs
+			}
+		}
+		if _, ok := orderedVindex(index.Vindex, columnType(table, index.Columns[0])); ok && rangeVindex == nil {
+			if start, end, ok := getRange(where.Expr, index.Columns[0]); ok {
+				rangeVindex, rangeValues = index.Vindex, []sqltypes.PlanValue{start, end}
 			}
-			return opcode, ksidVindex, ksidCol, single, []sqltypes.PlanValue{pv}, nil
 		}
 	}
-	if ksidVindex == nil {
-		return engine.Scatter, nil, "", nil, nil, vterrors.New(vtrpcpb.Code_INTERNAL, "table without a primary vindex is not expected")
+	if prefixVindex != nil {
+		return engine.Prefix, ksidVindex, ksidCol, prefixVindex, prefixValues, nil
+	}
+	if rangeVindex != nil {
+		return engine.Range, ksidVindex, ksidCol, rangeVindex, rangeValues, nil
 	}
 	return engine.Scatter, ksidVindex, ksidCol, nil, nil, nil
 }
 
+// columnType returns the type of the column of the table, which is
+// NULL_TYPE if the vschema doesn't list it.
+func columnType(table *vindexes.Table, col sqlparser.ColIdent) querypb.Type {
+	for _, c := range table.Columns {
+		if c.Name.Equal(col) {
+			return c.Type
+		}
+	}
+	return sqltypes.Null
+}
+
 // getMatch returns the matched value if there is an equality
 // constraint on the specified column that can be used to
 // decide on a route.
@@ -95,6 +151,69 @@ func getMatch(node sqlparser.Expr, col sqlparser.ColIdent) (pv sqltypes.PlanValu
 	return sqltypes.PlanValue{}, false
 }
 
+// getRange returns the start and end values of the range constraints
+// on the specified column. A missing bound is a NULL value. The
+// bounds are treated as included, which may only add a shard to
+// the route.
+func getRange(node sqlparser.Expr, col sqlparser.ColIdent) (start, end sqltypes.PlanValue, ok bool) {
+	for _, filter := range splitAndExpression(nil, node) {
+		var from, to sqlparser.Expr
+		switch filter := filter.(type) {
+		case *sqlparser.ComparisonExpr:
+			left, right, operator := filter.Left, filter.Right, filter.Operator
+			if !nameMatch(left, col) {
+				left, right = right, left
+				switch operator {
+				case sqlparser.LessThanOp:
+					operator = sqlparser.GreaterThanOp
+				case sqlparser.LessEqualOp:
+					operator = sqlparser.GreaterEqualOp
+				case sqlparser.GreaterThanOp:
+					operator = sqlparser.LessThanOp
+				case sqlparser.GreaterEqualOp:
+					operator = sqlparser.LessEqualOp
+				}
+			}
+			if !nameMatch(left, col) {
+				continue
+			}
+			switch operator {
+			case sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp:
+				from = right
+			case sqlparser.LessThanOp, sqlparser.LessEqualOp:
+				to = right
+			default:
+				continue
+			}
+		case *sqlparser.RangeCond:
+			if filter.Operator != sqlparser.BetweenOp || !nameMatch(filter.Left, col) {
+				continue
+			}
+			from, to = filter.From, filter.To
+		default:
+			continue
+		}
+		if (from != nil && !sqlparser.IsValue(from)) || (to != nil && !sqlparser.IsValue(to)) {
+			continue
+		}
+		if from != nil {
+			pv, err := sqlparser.NewPlanValue(from)
+			if err != nil {
+				continue
+			}
+			start, ok = pv, true
+		}
+		if to != nil {
+			pv, err := sqlparser.NewPlanValue(to)
+			if err != nil {
+				continue
+			}
+			end, ok = pv, true
+		}
+	}
+	return start, end, ok
+}
+
 func nameMatch(node sqlparser.Expr, col sqlparser.ColIdent) bool {
 	colname, ok := node.(*sqlparser.ColName)
 	return ok && colname.Name.Equal(col)
@@ -161,11 +280,7 @@ func buildDMLPlan(vschema ContextVSchema, dmlType string, stmt sqlparser.Stateme
 	}
 
 	edml.Opcode = routingType
-	if routingType == engine.Scatter {
-		if limit != nil {
-			return nil, nil, "", vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: multi shard %s with limit", dmlType)
-		}
-	} else {
+	if routingType != engine.Scatter {
 		edml.Vindex = vindex
 		edml.Values = values
 	}
@@ -0,0 +1,270 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The list bind variables through which engine.DMLWithInput passes
+// the selected rows to the DML.
+const (
+	dmlPKBindVar     = "__dml_pk_vals"
+	dmlVindexBindVar = "__dml_vindex_vals"
+)
+
+// analyzeMultiTableDML processes the tables of a DML that references more
+// than one table. It returns nil if the DML can be planned like a single
+// table DML, which is the case if all its tables are in the same unsharded
+// keyspace.
+func analyzeMultiTableDML(vschema ContextVSchema, stmt sqlparser.Statement, tableExprs sqlparser.TableExprs) (*primitiveBuilder, error) {
+	if len(tableExprs) == 1 {
+		if _, ok := tableExprs[0].(*sqlparser.AliasedTableExpr); ok {
+			return nil, nil
+		}
+	}
+	pb := newPrimitiveBuilder(vschema, newJointab(sqlparser.GetBindvars(stmt)))
+	if err := pb.processTableExprs(tableExprs); err != nil {
+		return nil, err
+	}
+	if len(pb.st.tables) <= 1 {
+		return nil, nil
+	}
+	if rb, ok := pb.bldr.(*route); ok && !rb.eroute.Keyspace.Sharded {
+		return nil, nil
+	}
+	if hasSubquery(stmt) {
+		return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: subqueries in sharded DML")
+	}
+	return pb, nil
+}
+
+// findDMLTable returns the table of the symtab that a DML references as name.
+func findDMLTable(st *symtab, name sqlparser.TableName) *table {
+	for _, t := range st.AllTables() {
+		if t.vschemaTable == nil || t.alias.Name != name.Name {
+			continue
+		}
+		if name.Qualifier.IsEmpty() || name.Qualifier == t.alias.Qualifier {
+			return t
+		}
+	}
+	return nil
+}
+
+// buildColocatedDMLPlan builds a multi-table DML whose tables are all
+// in the same shard, and can therefore be sent as is to the shards.
+func buildColocatedDMLPlan(pb *primitiveBuilder, rb *route, dmlType string, stmt sqlparser.Statement, where *sqlparser.Where, comments sqlparser.Comments, table *vindexes.Table) (*engine.DML, error) {
+	if where != nil {
+		// Pushing the filter improves the route if it is a vindex match.
+		if err := pb.pushFilter(where.Expr, sqlparser.WhereStr); err != nil {
+			return nil, err
+		}
+	}
+	for _, sub := range rb.substitutions {
+		*sub.oldExpr = *sub.newExpr
+	}
+
+	edml := &engine.DML{
+		Keyspace: rb.eroute.Keyspace,
+		Table:    table,
+		// Generate query after all the analysis. Otherwise table name substitutions for
+		// routed tables won't happen.
+		Query: generateQuery(stmt),
+	}
+	directives := sqlparser.ExtractCommentDirectives(comments)
+	edml.MultiShardAutocommit = directives.IsSet(sqlparser.DirectiveMultiShardAutocommit)
+	edml.QueryTimeout = queryTimeout(directives)
+
+	if rb.eroute.TargetDestination != nil {
+		if rb.eroute.TargetTabletType != topodatapb.TabletType_MASTER {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: %s statement with a replica target", dmlType)
+		}
+		edml.Opcode = engine.ByDestination
+		edml.TargetDestination = rb.eroute.TargetDestination
+		return edml, nil
+	}
+
+	edml.Opcode = engine.Scatter
+	var opcode engine.DMLOpcode
+	values := rb.multiValues
+	switch rb.eroute.Opcode {
+	case engine.SelectEqualUnique:
+		opcode = engine.Equal
+		if values == nil {
+			values = []sqlparser.Expr{rb.condition}
+		}
+	case engine.SelectIN:
+		opcode, values = engine.In, []sqlparser.Expr{rb.condition.(*sqlparser.ComparisonExpr).Right}
+	case engine.SelectMultiEqual:
+		opcode = engine.In
+	case engine.SelectPrefix:
+		opcode = engine.Prefix
+	case engine.SelectRange:
+		opcode = engine.Range
+	default:
+		return edml, nil
+	}
+	var pvs []sqltypes.PlanValue
+	for _, value := range values {
+		pv, err := sqlparser.NewPlanValue(value)
+		if err != nil {
+			// The values are not known before execution.
+			return edml, nil
+		}
+		pvs = append(pvs, pv)
+	}
+	edml.Opcode = opcode
+	edml.Vindex = rb.eroute.Vindex
+	edml.Values = pvs
+	return edml, nil
+}
+
+// buildDMLWithInputPlan builds a plan that first selects the rows to modify,
+// and then modifies them by primary key. The select uses the original FROM
+// and WHERE clauses, ORDER BY and LIMIT, and returns the primary key and
+// the primary vindex columns of the table. qualifier is the name under which
+// the select references the table. buildDML builds the DML that modifies
+// the selected rows for the given WHERE clause.
+func buildDMLWithInputPlan(vschema ContextVSchema, dmlType string, table *vindexes.Table, qualifier sqlparser.TableName, tableExprs sqlparser.TableExprs, where *sqlparser.Where, orderBy sqlparser.OrderBy, limit *sqlparser.Limit, buildDML func(*sqlparser.Where) (engine.Primitive, error)) (engine.Primitive, error) {
+	if len(table.PrimaryKey) != 1 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: %s of table %s needs a single column primary_key in its vschema", dmlType, table.Name.String())
+	}
+	pkCol := table.PrimaryKey[0]
+	cols := []sqlparser.ColIdent{pkCol}
+	bindVars := []string{dmlPKBindVar}
+	if table.Keyspace.Sharded {
+		vindexCols, err := primaryVindexColumns(table)
+		if err != nil {
+			return nil, err
+		}
+		for _, vindexCol := range vindexCols {
+			if vindexCol.Equal(pkCol) {
+				continue
+			}
+			bindVar := dmlVindexBindVar
+			if len(cols) > 1 {
+				bindVar = fmt.Sprintf("%s%d", dmlVindexBindVar, len(cols)-1)
+			}
+			cols = append(cols, vindexCol)
+			bindVars = append(bindVars, bindVar)
+		}
+	}
+
+	var dmlWhere sqlparser.Expr
+	for i, col := range cols {
+		in := &sqlparser.ComparisonExpr{
+			Operator: sqlparser.InOp,
+			Left:     &sqlparser.ColName{Name: col},
+			Right:    sqlparser.ListArg("::" + bindVars[i]),
+		}
+		if dmlWhere == nil {
+			dmlWhere = in
+			continue
+		}
+		dmlWhere = &sqlparser.AndExpr{Left: dmlWhere, Right: in}
+	}
+	dml, err := buildDML(sqlparser.NewWhere(sqlparser.WhereClause, dmlWhere))
+	if err != nil {
+		return nil, err
+	}
+
+	// The clauses of the DML were already analyzed. The symbols
+	// they resolved to do not belong to the select.
+	clearSymbols(tableExprs, where, orderBy)
+	sel := &sqlparser.Select{
+		From:    tableExprs,
+		Where:   where,
+		OrderBy: orderBy,
+		Limit:   limit,
+		Lock:    sqlparser.ForUpdateLock,
+	}
+	for _, col := range cols {
+		sel.SelectExprs = append(sel.SelectExprs, &sqlparser.AliasedExpr{Expr: &sqlparser.ColName{Name: col, Qualifier: qualifier}})
+	}
+	// A scatter ORDER BY can only reference selected columns.
+	for _, order := range orderBy {
+		if _, ok := order.Expr.(*sqlparser.Literal); ok {
+			continue
+		}
+		sel.SelectExprs = append(sel.SelectExprs, &sqlparser.AliasedExpr{Expr: order.Expr})
+	}
+	input, err := buildSelectPlan("")(sel, vschema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &engine.DMLWithInput{
+		Input:        input,
+		DML:          dml,
+		BindVarNames: bindVars,
+	}, nil
+}
+
+// clearSymbols removes the symbols cached in the columns of the nodes
+// by a previous analysis, so that the nodes can be analyzed again.
+func clearSymbols(nodes ...sqlparser.SQLNode) {
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if col, ok := node.(*sqlparser.ColName); ok {
+			col.Metadata = nil
+		}
+		return true, nil
+	}, nodes...)
+}
+
+// primaryVindexColumns returns the columns of the primary vindex,
+// which decides the keyspace id of the rows of a sharded table.
+func primaryVindexColumns(table *vindexes.Table) ([]sqlparser.ColIdent, error) {
+	if len(table.ColumnVindexes) == 0 {
+		return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "table without a primary vindex is not expected")
+	}
+	return table.ColumnVindexes[0].Columns, nil
+}
+
+// isVindexColumnChanged returns true if the update sets a column of the vindexes.
+func isVindexColumnChanged(exprs sqlparser.UpdateExprs, colVindexes []*vindexes.ColumnVindex) bool {
+	for _, cv := range colVindexes {
+		for _, col := range cv.Columns {
+			for _, expr := range exprs {
+				if col.Equal(expr.Name.Name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// dmlTarget returns the table expression of the DML
+// that modifies the rows selected by a DMLWithInput.
+func dmlTarget(table *vindexes.Table) sqlparser.TableExprs {
+	return sqlparser.TableExprs{&sqlparser.AliasedTableExpr{
+		Expr: sqlparser.TableName{
+			Name:      table.Name,
+			Qualifier: sqlparser.NewTableIdent(table.Keyspace.Name),
+		},
+	}}
+}
@@ -125,6 +125,7 @@ func (pb *primitiveBuilder) processAliasedTable(tableExpr *sqlparser.AliasedTabl
 		rb, st := newRoute(&sqlparser.Select{From: []sqlparser.TableExpr{tableExpr}})
 		rb.substitutions = subroute.substitutions
 		rb.condition = subroute.condition
+		rb.multiValues = subroute.multiValues
 		rb.eroute = subroute.eroute
 		subroute.Redirect = rb
 
@@ -317,6 +318,7 @@ func (pb *primitiveBuilder) join(rpb *primitiveBuilder, ajoin *sqlparser.JoinTab
 	if lRoute.eroute.Opcode == engine.SelectReference {
 		// Swap the conditions & eroutes, and then merge.
 		lRoute.condition, rRoute.condition = rRoute.condition, lRoute.condition
+		lRoute.multiValues, rRoute.multiValues = rRoute.multiValues, lRoute.multiValues
 		lRoute.eroute, rRoute.eroute = rRoute.eroute, lRoute.eroute
 	}
 	lRoute.substitutions = append(lRoute.substitutions, rRoute.substitutions...)
@@ -190,8 +190,9 @@ func (jb *join) PushSelect(pb *primitiveBuilder, expr *sqlparser.AliasedExpr, or
 }
 
 // MakeDistinct satisfies the builder interface.
-func (jb *join) MakeDistinct() error {
-	return errors.New("unsupported: distinct on cross-shard join")
+// The duplicates are removed by a distinct primitive on top of the join.
+func (jb *join) MakeDistinct() (builder, error) {
+	return newDistinct(jb), nil
 }
 
 // PushGroupBy satisfies the builder interface.
@@ -88,6 +88,15 @@ func (pb *primitiveBuilder) checkAggregates(sel *sqlparser.Select) error {
 	// we need the ability to push down group by and
 	// order by clauses.
 	if !isRoute {
+		// A distinct without aggregates on a cross-shard join or subquery
+		// is handled later by a distinct primitive, once the select
+		// expressions are pushed.
+		if sel.Distinct && len(sel.GroupBy) == 0 && !nodeHasAggregates(sel.SelectExprs) {
+			switch pb.bldr.(type) {
+			case *join, *subquery:
+				return nil
+			}
+		}
 		return errors.New("unsupported: cross-shard query with aggregates")
 	}
 
@@ -356,18 +365,23 @@ func (oa *orderedAggregate) needDistinctHandling(pb *primitiveBuilder, funcExpr
 	return true, innerAliased, nil
 }
 
-func (oa *orderedAggregate) MakeDistinct() error {
+func (oa *orderedAggregate) MakeDistinct() (builder, error) {
 	for i, rc := range oa.resultColumns {
 		// If the column origin is oa (and not the underlying route),
 		// it means that it's an aggregate function supplied by oa.
 		// So, the distinct 'operator' cannot be pushed down into the
 		// route.
 		if rc.column.Origin() == oa {
-			return errors.New("unsupported: distinct cannot be combined with aggregate functions")
+			return nil, errors.New("unsupported: distinct cannot be combined with aggregate functions")
 		}
 		oa.eaggr.Keys = append(oa.eaggr.Keys, i)
 	}
-	return oa.input.MakeDistinct()
+	bldr, err := oa.input.MakeDistinct()
+	if err != nil {
+		return nil, err
+	}
+	oa.input = bldr
+	return oa, nil
 }
 
 // PushGroupBy satisfies the builder interface.
@@ -27,9 +27,12 @@ import (
 // and ensures that there are no subqueries.
 func (pb *primitiveBuilder) pushGroupBy(sel *sqlparser.Select) error {
 	if sel.Distinct {
-		if err := pb.bldr.MakeDistinct(); err != nil {
+		bldr, err := pb.bldr.MakeDistinct()
+		if err != nil {
 			return err
 		}
+		pb.bldr = bldr
+		pb.bldr.Reorder(0)
 	}
 
 	if err := pb.st.ResolveSymbols(sel.GroupBy); err != nil {
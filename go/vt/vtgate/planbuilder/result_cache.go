@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"sort"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// uncacheableFuncs are the functions whose result depends on the time, the
+// session or randomness, or which have side effects.
+var uncacheableFuncs = map[string]bool{
+	"benchmark":         true,
+	"connection_id":     true,
+	"curdate":           true,
+	"current_date":      true,
+	"current_role":      true,
+	"current_time":      true,
+	"current_timestamp": true,
+	"current_user":      true,
+	"curtime":           true,
+	"database":          true,
+	"found_rows":        true,
+	"get_lock":          true,
+	"is_free_lock":      true,
+	"is_used_lock":      true,
+	"last_insert_id":    true,
+	"localtime":         true,
+	"localtimestamp":    true,
+	"now":               true,
+	"rand":              true,
+	"random_bytes":      true,
+	"release_all_locks": true,
+	"release_lock":      true,
+	"row_count":         true,
+	"schema":            true,
+	"session_user":      true,
+	"sleep":             true,
+	"sysdate":           true,
+	"system_user":       true,
+	"unix_timestamp":    true,
+	"user":              true,
+	"utc_date":          true,
+	"utc_time":          true,
+	"utc_timestamp":     true,
+	"uuid":              true,
+	"uuid_short":        true,
+}
+
+// resultCacheTables returns the tables read by sel as keyspace.table if the
+// result of sel may be kept in the vtgate result cache. This is the case if
+// the query asks for it with the RESULT_CACHE comment directive, or if all
+// the tables it reads enabled result_cache in the VSchema.
+// Locking reads, SQL_NO_CACHE, partial scatter results, queries which
+// read anything else than VSchema tables and queries whose result depends
+// on the time, the session or randomness are never cached.
+func resultCacheTables(sel *sqlparser.Select, vschema ContextVSchema, bindVarNeeds *sqlparser.BindVarNeeds) []string {
+	if vschema.Destination() != nil {
+		return nil
+	}
+	// The session values of the rewritten variables and functions are
+	// passed as bind variables, which are not part of the cache key.
+	if bindVarNeeds != nil && bindVarNeeds.HasRewrites() {
+		return nil
+	}
+	directives := sqlparser.ExtractCommentDirectives(sel.Comments)
+	if directives.IsSet(sqlparser.DirectiveScatterErrorsAsWarnings) {
+		return nil
+	}
+	directive := directives.IsSet(sqlparser.DirectiveResultCache)
+
+	cacheable := true
+	allEnabled := true
+	tables := make(map[string]bool)
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			if node.Lock != sqlparser.NoLock || (node.Cache != nil && !*node.Cache) {
+				cacheable = false
+			}
+		case *sqlparser.FuncExpr:
+			if uncacheableFuncs[node.Name.Lowered()] {
+				cacheable = false
+			}
+		case *sqlparser.ColName:
+			if node.Name.AtCount() != sqlparser.NoAt {
+				cacheable = false
+			}
+		case *sqlparser.AliasedTableExpr:
+			tableName, ok := node.Expr.(sqlparser.TableName)
+			if !ok {
+				return true, nil
+			}
+			if tableName.Name.String() == "dual" && tableName.Qualifier.IsEmpty() {
+				return true, nil
+			}
+			table, vindex, _, _, dest, err := vschema.FindTableOrVindex(tableName)
+			if err != nil || table == nil || vindex != nil || dest != nil {
+				cacheable = false
+				return false, nil
+			}
+			tables[table.Keyspace.Name+"."+table.Name.String()] = true
+			if !table.ResultCache {
+				allEnabled = false
+			}
+		}
+		return cacheable, nil
+	}, sel)
+	if !cacheable || len(tables) == 0 || !(directive || allEnabled) {
+		return nil
+	}
+
+	result := make([]string, 0, len(tables))
+	for table := range tables {
+		result = append(result, table)
+	}
+	sort.Strings(result)
+	return result
+}
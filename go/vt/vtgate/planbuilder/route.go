@@ -24,6 +24,8 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
 var _ builder = (*route)(nil)
@@ -61,6 +63,23 @@ type route struct {
 	// to resolve the ERoute Values field.
 	condition sqlparser.Expr
 
+	// multiValues stores the AST values of the columns of a
+	// multi-column vindex, or the start and end of the range of
+	// the column of an ordered vindex, which are used instead of
+	// condition to resolve the ERoute Values field.
+	multiValues []sqlparser.Expr
+
+	// vindexValues accumulates the values that the filters give
+	// to the columns of the multi-column vindexes, until enough
+	// of them are known to route with the vindex.
+	vindexValues map[multiColumnVindex][]sqlparser.Expr
+
+	// vindexRanges accumulates the start and end that the filters
+	// give to the columns of the ordered vindexes, by table column.
+	// Bounds on different columns are never combined, even if they
+	// share a vindex.
+	vindexRanges map[*column][]sqlparser.Expr
+
 	// eroute is the primitive being built.
 	eroute *engine.Route
 }
@@ -159,9 +178,9 @@ func (rb *route) PushAnonymous(expr sqlparser.SelectExpr) *resultColumn {
 }
 
 // MakeDistinct satisfies the builder interface.
-func (rb *route) MakeDistinct() error {
+func (rb *route) MakeDistinct() (builder, error) {
 	rb.Select.(*sqlparser.Select).Distinct = true
-	return nil
+	return rb, nil
 }
 
 // PushGroupBy satisfies the builder interface.
@@ -258,6 +277,13 @@ func (rb *route) Wireup(bldr builder, jt *jointab) error {
 	// Precaution: update ERoute.Values only if it's not set already.
 	if rb.eroute.Values == nil {
 		// Resolve values stored in the builder.
+		for _, val := range rb.multiValues {
+			pv, err := rb.procureValues(bldr, jt, val)
+			if err != nil {
+				return err
+			}
+			rb.eroute.Values = append(rb.eroute.Values, pv)
+		}
 		switch vals := rb.condition.(type) {
 		case *sqlparser.ComparisonExpr:
 			pv, err := rb.procureValues(bldr, jt, vals.Right)
@@ -481,7 +507,7 @@ func (rb *route) JoinCanMerge(pb *primitiveBuilder, rrb *route, ajoin *sqlparser
 		return rb.eroute.Opcode == rrb.eroute.Opcode
 	case engine.SelectEqualUnique:
 		// Check if they target the same shard.
-		if rrb.eroute.Opcode == engine.SelectEqualUnique && rb.eroute.Vindex == rrb.eroute.Vindex && valEqual(rb.condition, rrb.condition) {
+		if rrb.eroute.Opcode == engine.SelectEqualUnique && rb.eroute.Vindex == rrb.eroute.Vindex && rb.valuesEqual(rrb) {
 			return true
 		}
 	case engine.SelectReference:
@@ -509,7 +535,7 @@ func (rb *route) SubqueryCanMerge(pb *primitiveBuilder, inner *route) bool {
 		return rb.eroute.Opcode == inner.eroute.Opcode || inner.eroute.Opcode == engine.SelectReference
 	case engine.SelectEqualUnique:
 		// Check if they target the same shard.
-		if inner.eroute.Opcode == engine.SelectEqualUnique && rb.eroute.Vindex == inner.eroute.Vindex && valEqual(rb.condition, inner.condition) {
+		if inner.eroute.Opcode == engine.SelectEqualUnique && rb.eroute.Vindex == inner.eroute.Vindex && rb.valuesEqual(inner) {
 			return true
 		}
 	case engine.SelectNext:
@@ -538,7 +564,7 @@ func (rb *route) UnionCanMerge(rrb *route) bool {
 		return rb.eroute.Opcode == rrb.eroute.Opcode
 	case engine.SelectEqualUnique:
 		// Check if they target the same shard.
-		if rrb.eroute.Opcode == engine.SelectEqualUnique && rb.eroute.Vindex == rrb.eroute.Vindex && valEqual(rb.condition, rrb.condition) {
+		if rrb.eroute.Opcode == engine.SelectEqualUnique && rb.eroute.Vindex == rrb.eroute.Vindex && rb.valuesEqual(rrb) {
 			return true
 		}
 	case engine.SelectNext:
@@ -547,6 +573,23 @@ func (rb *route) UnionCanMerge(rrb *route) bool {
 	return false
 }
 
+// valuesEqual returns true if the routes use the same values
+// for their vindex.
+func (rb *route) valuesEqual(rrb *route) bool {
+	if len(rb.multiValues) != len(rrb.multiValues) {
+		return false
+	}
+	if rb.multiValues == nil {
+		return valEqual(rb.condition, rrb.condition)
+	}
+	for i, val := range rb.multiValues {
+		if !valEqual(val, rrb.multiValues[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // canMergeOnFilter returns true if the join constraint makes the routes
 // mergeable by unique vindex. The constraint has to be an equality
 // like a.id = b.id where both columns have the same unique vindex.
@@ -586,49 +629,80 @@ func (rb *route) UpdatePlan(pb *primitiveBuilder, filter sqlparser.Expr) {
 		return
 	}
 	opcode, vindex, values := rb.computePlan(pb, filter)
-	if opcode == engine.SelectScatter {
-		return
-	}
 	// If we get SelectNone in next filters, override the previous route plan.
 	if opcode == engine.SelectNone {
 		rb.updateRoute(opcode, vindex, values)
 		return
 	}
-	switch rb.eroute.Opcode {
+	if opcode != engine.SelectScatter && isBetterPlan(opcode, vindex, rb.eroute.Opcode, rb.eroute.Vindex) {
+		rb.updateRoute(opcode, vindex, values)
+	}
+	mopcode, mvindex, mvalues := rb.computeMultiColumnPlan(pb, filter)
+	if mopcode != engine.SelectScatter && isBetterPlan(mopcode, mvindex, rb.eroute.Opcode, rb.eroute.Vindex) {
+		rb.updateMultiColumnRoute(mopcode, mvindex, mvalues)
+	}
+	ropcode, rvindex, rvalues := rb.computeRangePlan(pb, filter)
+	if ropcode != engine.SelectScatter && isBetterPlan(ropcode, rvindex, rb.eroute.Opcode, rb.eroute.Vindex) {
+		rb.updateMultiColumnRoute(ropcode, rvindex, rvalues)
+	}
+}
+
+// isBetterPlan returns true if routing with opcode and vindex is an
+// improvement over routing with the current opcode and vindex.
+func isBetterPlan(opcode engine.RouteOpcode, vindex vindexes.Vindex, current engine.RouteOpcode, currentVindex vindexes.Vindex) bool {
+	switch current {
 	case engine.SelectEqualUnique:
-		if opcode == engine.SelectEqualUnique && vindex.Cost() < rb.eroute.Vindex.Cost() {
-			rb.updateRoute(opcode, vindex, values)
-		}
+		return opcode == engine.SelectEqualUnique && vindex.Cost() < currentVindex.Cost()
 	case engine.SelectEqual:
 		switch opcode {
 		case engine.SelectEqualUnique:
-			rb.updateRoute(opcode, vindex, values)
+			return true
 		case engine.SelectEqual:
-			if vindex.Cost() < rb.eroute.Vindex.Cost() {
-				rb.updateRoute(opcode, vindex, values)
-			}
+			return vindex.Cost() < currentVindex.Cost()
 		}
-	case engine.SelectIN:
+	case engine.SelectIN, engine.SelectMultiEqual:
 		switch opcode {
 		case engine.SelectEqualUnique, engine.SelectEqual:
-			rb.updateRoute(opcode, vindex, values)
-		case engine.SelectIN:
-			if vindex.Cost() < rb.eroute.Vindex.Cost() {
-				rb.updateRoute(opcode, vindex, values)
-			}
+			return true
+		case engine.SelectIN, engine.SelectMultiEqual:
+			return vindex.Cost() < currentVindex.Cost()
+		}
+	case engine.SelectPrefix:
+		switch opcode {
+		case engine.SelectEqualUnique, engine.SelectEqual, engine.SelectIN, engine.SelectMultiEqual:
+			return true
+		case engine.SelectPrefix:
+			return vindex.Cost() < currentVindex.Cost()
+		}
+	case engine.SelectRange:
+		switch opcode {
+		case engine.SelectEqualUnique, engine.SelectEqual, engine.SelectIN, engine.SelectMultiEqual, engine.SelectPrefix:
+			return true
+		case engine.SelectRange:
+			// The range of the same vindex can only get narrower.
+			return vindex == currentVindex || vindex.Cost() < currentVindex.Cost()
 		}
 	case engine.SelectScatter:
 		switch opcode {
-		case engine.SelectEqualUnique, engine.SelectEqual, engine.SelectIN, engine.SelectNone:
-			rb.updateRoute(opcode, vindex, values)
+		case engine.SelectEqualUnique, engine.SelectEqual, engine.SelectIN, engine.SelectMultiEqual, engine.SelectPrefix, engine.SelectRange, engine.SelectNone:
+			return true
 		}
 	}
+	return false
 }
 
 func (rb *route) updateRoute(opcode engine.RouteOpcode, vindex vindexes.SingleColumn, condition sqlparser.Expr) {
 	rb.eroute.Opcode = opcode
 	rb.eroute.Vindex = vindex
 	rb.condition = condition
+	rb.multiValues = nil
+}
+
+func (rb *route) updateMultiColumnRoute(opcode engine.RouteOpcode, vindex vindexes.Vindex, values []sqlparser.Expr) {
+	rb.eroute.Opcode = opcode
+	rb.eroute.Vindex = vindex
+	rb.condition = nil
+	rb.multiValues = values
 }
 
 // computePlan computes the plan for the specified filter.
@@ -717,6 +791,182 @@ func (rb *route) computeINPlan(pb *primitiveBuilder, comparison *sqlparser.Compa
 	return engine.SelectScatter, nil, nil
 }
 
+// computeMultiColumnPlan records the value that an equality or IN
+// constraint gives to the columns of the multi-column vindexes, and
+// computes the best plan of the vindexes that can now be used.
+func (rb *route) computeMultiColumnPlan(pb *primitiveBuilder, filter sqlparser.Expr) (opcode engine.RouteOpcode, vindex vindexes.Vindex, values []sqlparser.Expr) {
+	comparison, ok := filter.(*sqlparser.ComparisonExpr)
+	if !ok {
+		return engine.SelectScatter, nil, nil
+	}
+	var vcs []vindexColumn
+	var value sqlparser.Expr
+	switch comparison.Operator {
+	case sqlparser.EqualOp:
+		vcs, value = pb.st.VindexColumns(comparison.Left, rb), comparison.Right
+		if vcs == nil {
+			vcs, value = pb.st.VindexColumns(comparison.Right, rb), comparison.Left
+		}
+		if !rb.exprIsValue(value) {
+			return engine.SelectScatter, nil, nil
+		}
+	case sqlparser.InOp:
+		vcs, value = pb.st.VindexColumns(comparison.Left, rb), comparison.Right
+		switch node := value.(type) {
+		case sqlparser.ValTuple:
+			for _, n := range node {
+				if !rb.exprIsValue(n) {
+					return engine.SelectScatter, nil, nil
+				}
+			}
+		case sqlparser.ListArg:
+		default:
+			return engine.SelectScatter, nil, nil
+		}
+	default:
+		return engine.SelectScatter, nil, nil
+	}
+
+	opcode = engine.SelectScatter
+	for _, vc := range vcs {
+		if rb.vindexValues == nil {
+			rb.vindexValues = make(map[multiColumnVindex][]sqlparser.Expr)
+		}
+		colValues := rb.vindexValues[vc.vindex]
+		if colValues == nil {
+			colValues = make([]sqlparser.Expr, len(vc.vindex.cv.Columns))
+			rb.vindexValues[vc.vindex] = colValues
+		}
+		colValues[vc.index] = value
+		vopcode, vvalues := multiColumnPlan(vc.vindex.cv.Vindex, colValues)
+		if vopcode != engine.SelectScatter && isBetterPlan(vopcode, vc.vindex.cv.Vindex, opcode, vindex) {
+			opcode, vindex, values = vopcode, vc.vindex.cv.Vindex, vvalues
+		}
+	}
+	return opcode, vindex, values
+}
+
+// multiColumnPlan returns the plan of a multi-column vindex given the
+// values of its columns, which are nil if they are not known.
+func multiColumnPlan(vindex vindexes.Vindex, colValues []sqlparser.Expr) (engine.RouteOpcode, []sqlparser.Expr) {
+	known := 0
+	isList := false
+	for _, value := range colValues {
+		if value == nil {
+			continue
+		}
+		known++
+		switch value.(type) {
+		case sqlparser.ValTuple, sqlparser.ListArg:
+			isList = true
+		}
+	}
+	// The values are copied because the columns can get other
+	// values from the next filters.
+	switch {
+	case known == len(colValues) && isList:
+		return engine.SelectMultiEqual, append([]sqlparser.Expr(nil), colValues...)
+	case known == len(colValues) && vindex.IsUnique():
+		return engine.SelectEqualUnique, append([]sqlparser.Expr(nil), colValues...)
+	case known == len(colValues):
+		return engine.SelectEqual, append([]sqlparser.Expr(nil), colValues...)
+	}
+	if prefixable, ok := vindex.(vindexes.Prefixable); ok {
+		var prefixValues []sqlparser.Expr
+		for _, i := range prefixable.PrefixColumns() {
+			if colValues[i] == nil {
+				return engine.SelectScatter, nil
+			}
+			prefixValues = append(prefixValues, colValues[i])
+		}
+		return engine.SelectPrefix, prefixValues
+	}
+	return engine.SelectScatter, nil
+}
+
+// computeRangePlan records the start or end that a range constraint
+// gives to the column of an ordered vindex, and computes the plan of
+// the range known so far. The bounds are treated as included, which
+// may only add a shard to the route.
+func (rb *route) computeRangePlan(pb *primitiveBuilder, filter sqlparser.Expr) (opcode engine.RouteOpcode, vindex vindexes.Vindex, values []sqlparser.Expr) {
+	var col, start, end sqlparser.Expr
+	switch node := filter.(type) {
+	case *sqlparser.ComparisonExpr:
+		left, right, operator := node.Left, node.Right, node.Operator
+		if pb.st.Vindex(left, rb) == nil {
+			left, right = right, left
+			switch operator {
+			case sqlparser.LessThanOp:
+				operator = sqlparser.GreaterThanOp
+			case sqlparser.LessEqualOp:
+				operator = sqlparser.GreaterEqualOp
+			case sqlparser.GreaterThanOp:
+				operator = sqlparser.LessThanOp
+			case sqlparser.GreaterEqualOp:
+				operator = sqlparser.LessEqualOp
+			}
+		}
+		switch operator {
+		case sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp:
+			col, start = left, right
+		case sqlparser.LessThanOp, sqlparser.LessEqualOp:
+			col, end = left, right
+		default:
+			return engine.SelectScatter, nil, nil
+		}
+	case *sqlparser.RangeCond:
+		if node.Operator != sqlparser.BetweenOp {
+			return engine.SelectScatter, nil, nil
+		}
+		col, start, end = node.Left, node.From, node.To
+	default:
+		return engine.SelectScatter, nil, nil
+	}
+
+	single := pb.st.Vindex(col, rb)
+	if single == nil {
+		return engine.SelectScatter, nil, nil
+	}
+	// Vindex has set the Metadata of the column.
+	c := col.(*sqlparser.ColName).Metadata.(*column)
+	ordered, ok := orderedVindex(single, c.typ)
+	if !ok {
+		return engine.SelectScatter, nil, nil
+	}
+	for _, value := range []sqlparser.Expr{start, end} {
+		if value != nil && !rb.exprIsValue(value) {
+			return engine.SelectScatter, nil, nil
+		}
+	}
+	if rb.vindexRanges == nil {
+		rb.vindexRanges = make(map[*column][]sqlparser.Expr)
+	}
+	bounds := rb.vindexRanges[c]
+	if bounds == nil {
+		bounds = []sqlparser.Expr{&sqlparser.NullVal{}, &sqlparser.NullVal{}}
+		rb.vindexRanges[c] = bounds
+	}
+	if start != nil {
+		bounds[0] = start
+	}
+	if end != nil {
+		bounds[1] = end
+	}
+	// The values are copied because the range can get other
+	// bounds from the next filters.
+	return engine.SelectRange, ordered, append([]sqlparser.Expr(nil), bounds...)
+}
+
+// orderedVindex returns the vindex as an Ordered vindex if it can
+// map the ranges of a column of the type.
+func orderedVindex(vindex vindexes.Vindex, typ querypb.Type) (vindexes.Ordered, bool) {
+	if typeOrdered, ok := vindex.(vindexes.TypeOrdered); ok && !typeOrdered.OrdersType(typ) {
+		return nil, false
+	}
+	ordered, ok := vindex.(vindexes.Ordered)
+	return ordered, ok
+}
+
 // computeNotInPlan looks for null values to produce a SelectNone if found
 func (rb *route) computeNotInPlan(right sqlparser.Expr) engine.RouteOpcode {
 	switch node := right.(type) {
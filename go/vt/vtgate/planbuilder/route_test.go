@@ -37,20 +37,25 @@ For easy reference, opcodes are:
 	SelectDBA         6
 	SelectReference   7
 	SelectNone        8
-	NumRouteOpcodes   9
+	SelectMultiEqual  9
+	SelectPrefix      10
+	NumRouteOpcodes   11
 */
 
 func TestJoinCanMerge(t *testing.T) {
 	testcases := [engine.NumRouteOpcodes][engine.NumRouteOpcodes]bool{
-		{true, false, false, false, false, false, false, true, false},
-		{false, true, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, true, true, false},
-		{true, true, true, true, true, true, true, true, true},
-		{false, false, false, false, false, false, false, true, false},
+		{true, false, false, false, false, false, false, true, false, false, false, false},
+		{false, true, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, true, true, false, false, false, false},
+		{true, true, true, true, true, true, true, true, true, true, true, true},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
 	}
 
 	ks := &vindexes.Keyspace{}
@@ -75,15 +80,18 @@ func TestJoinCanMerge(t *testing.T) {
 
 func TestSubqueryCanMerge(t *testing.T) {
 	testcases := [engine.NumRouteOpcodes][engine.NumRouteOpcodes]bool{
-		{true, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, false, false},
-		{false, false, false, false, false, false, true, true, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, true, false},
+		{true, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, true, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
 	}
 
 	ks := &vindexes.Keyspace{}
@@ -103,15 +111,18 @@ func TestSubqueryCanMerge(t *testing.T) {
 
 func TestUnionCanMerge(t *testing.T) {
 	testcases := [engine.NumRouteOpcodes][engine.NumRouteOpcodes]bool{
-		{true, false, false, false, false, false, false, false, false},
-		{false, false, false, false, false, false, false, false, false},
-		{false, false, false, false, false, false, false, false, false},
-		{false, false, false, false, false, false, false, false, false},
-		{false, false, false, false, false, false, false, false, false},
-		{false, false, false, false, false, false, false, false, false},
-		{false, false, false, false, false, false, true, false, false},
-		{false, false, false, false, false, false, false, true, false},
-		{false, false, false, false, false, false, false, false, false},
+		{true, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, true, false, false, false, false, false},
+		{false, false, false, false, false, false, false, true, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false, false, false, false, false, false},
 	}
 
 	ks := &vindexes.Keyspace{}
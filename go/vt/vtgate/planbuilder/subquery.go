@@ -113,8 +113,9 @@ func (sq *subquery) PushSelect(_ *primitiveBuilder, expr *sqlparser.AliasedExpr,
 }
 
 // MakeDistinct satisfies the builder interface.
-func (sq *subquery) MakeDistinct() error {
-	return errors.New("unsupported: distinct on cross-shard subquery")
+// The duplicates are removed by a distinct primitive on top of the subquery.
+func (sq *subquery) MakeDistinct() (builder, error) {
+	return newDistinct(sq), nil
 }
 
 // PushGroupBy satisfies the builder interface.
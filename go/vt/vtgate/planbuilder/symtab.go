@@ -108,9 +108,6 @@ func (st *symtab) AddVSchemaTable(alias sqlparser.TableName, vschemaTable *vinde
 
 	for _, cv := range vschemaTable.ColumnVindexes {
 		single, ok := cv.Vindex.(vindexes.SingleColumn)
-		if !ok {
-			continue
-		}
 		for i, cvcol := range cv.Columns {
 			col, err := t.mergeColumn(cvcol, &column{
 				origin: rb,
@@ -119,6 +116,13 @@ func (st *symtab) AddVSchemaTable(alias sqlparser.TableName, vschemaTable *vinde
 			if err != nil {
 				return err
 			}
+			if !ok {
+				col.vindexColumns = append(col.vindexColumns, vindexColumn{
+					vindex: multiColumnVindex{table: t, cv: cv},
+					index:  i,
+				})
+				continue
+			}
 			if i == 0 {
 				if col.vindex == nil || col.vindex.Cost() > single.Cost() {
 					col.vindex = single
@@ -410,6 +414,27 @@ func (st *symtab) Vindex(expr sqlparser.Expr, scope *route) vindexes.SingleColum
 	return c.vindex
 }
 
+// VindexColumns returns the multi-column vindexes the expression is
+// a column of, if it is a plain column reference that is part of the
+// specified route.
+func (st *symtab) VindexColumns(expr sqlparser.Expr, scope *route) []vindexColumn {
+	col, ok := expr.(*sqlparser.ColName)
+	if !ok {
+		return nil
+	}
+	if col.Metadata == nil {
+		// Find will set the Metadata.
+		if _, _, err := st.Find(col); err != nil {
+			return nil
+		}
+	}
+	c := col.Metadata.(*column)
+	if c.Origin() != scope {
+		return nil
+	}
+	return c.vindexColumns
+}
+
 // BuildColName builds a *sqlparser.ColName for the resultColumn specified
 // by the index. The built ColName will correctly reference the resultColumn
 // it was built from.
@@ -521,6 +546,24 @@ type column struct {
 	vindex    vindexes.SingleColumn
 	typ       querypb.Type
 	colNumber int
+
+	// vindexColumns lists the multi-column vindexes
+	// the column is part of.
+	vindexColumns []vindexColumn
+}
+
+// multiColumnVindex identifies a multi-column vindex of a
+// table. The same vindex can belong to more than one table
+// of a query, with different values for its columns.
+type multiColumnVindex struct {
+	table *table
+	cv    *vindexes.ColumnVindex
+}
+
+// vindexColumn is a column of a multi-column vindex.
+type vindexColumn struct {
+	vindex multiColumnVindex
+	index  int
 }
 
 // Origin returns the route that originates the column.
@@ -29,6 +29,13 @@ import (
 // buildUpdatePlan builds the instructions for an UPDATE statement.
 func buildUpdatePlan(stmt sqlparser.Statement, vschema ContextVSchema) (engine.Primitive, error) {
 	upd := stmt.(*sqlparser.Update)
+	pb, err := analyzeMultiTableDML(vschema, upd, upd.TableExprs)
+	if err != nil {
+		return nil, err
+	}
+	if pb != nil {
+		return buildMultiTableUpdatePlan(upd, vschema, pb)
+	}
 	dml, ksidVindex, ksidCol, err := buildDMLPlan(vschema, "update", upd, upd.TableExprs, upd.Where, upd.OrderBy, upd.Limit, upd.Comments, upd.Exprs)
 	if err != nil {
 		return nil, err
@@ -41,6 +48,28 @@ func buildUpdatePlan(stmt sqlparser.Statement, vschema ContextVSchema) (engine.P
 		return eupd, nil
 	}
 
+	if eupd.Opcode == engine.Scatter && upd.Limit != nil {
+		// The rows to update are selected first, because the limit
+		// applies to all the shards.
+		return buildDMLWithInputPlan(vschema, "update", eupd.Table, sqlparser.TableName{}, upd.TableExprs, upd.Where, upd.OrderBy, upd.Limit, func(where *sqlparser.Where) (engine.Primitive, error) {
+			return buildUpdatePlan(&sqlparser.Update{
+				Comments:   upd.Comments,
+				Ignore:     upd.Ignore,
+				TableExprs: upd.TableExprs,
+				Exprs:      upd.Exprs,
+				Where:      where,
+			}, vschema)
+		})
+	}
+
+	if isVindexColumnChanged(upd.Exprs, eupd.Table.ColumnVindexes[:1]) {
+		if err := buildMoveRowsQuery(eupd, upd); err != nil {
+			return nil, err
+		}
+		eupd.KsidVindex = ksidVindex
+		return eupd, nil
+	}
+
 	cvv, ovq, err := buildChangedVindexesValues(upd, eupd.Table, ksidCol)
 	if err != nil {
 		return nil, err
@@ -53,13 +82,129 @@ func buildUpdatePlan(stmt sqlparser.Statement, vschema ContextVSchema) (engine.P
 	return eupd, nil
 }
 
+// buildMultiTableUpdatePlan builds the instructions for an UPDATE statement
+// that joins tables of a sharded keyspace. Only one of the tables can be
+// updated. If the tables are in the same shard, the statement is sent as is.
+// Otherwise, the rows to update are selected first, and then updated by
+// primary key.
+func buildMultiTableUpdatePlan(upd *sqlparser.Update, vschema ContextVSchema, pb *primitiveBuilder) (engine.Primitive, error) {
+	var target *table
+	for _, expr := range upd.Exprs {
+		if expr.Name.Qualifier.IsEmpty() {
+			return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: unqualified column '%v' in multi-table update", expr.Name.Name)
+		}
+		t := findDMLTable(pb.st, expr.Name.Qualifier)
+		if t == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Unknown table '%s' in field list", expr.Name.Qualifier.Name.String())
+		}
+		if target != nil && t != target {
+			return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: multi-table update of more than one table in sharded keyspace")
+		}
+		target = t
+	}
+	table := target.vschemaTable
+
+	if rb, ok := pb.bldr.(*route); ok && !isVindexColumnChanged(upd.Exprs, table.ColumnVindexes) {
+		dml, err := buildColocatedDMLPlan(pb, rb, "update", upd, upd.Where, upd.Comments, table)
+		if err != nil {
+			return nil, err
+		}
+		return &engine.Update{DML: *dml}, nil
+	}
+
+	// The selected rows are updated without the other tables, so the
+	// new values can only reference the updated table. Its columns
+	// lose their qualifier because the table is not aliased anymore.
+	err := sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		col, ok := node.(*sqlparser.ColName)
+		if !ok || col.Qualifier.IsEmpty() {
+			return true, nil
+		}
+		if findDMLTable(pb.st, col.Qualifier) != target {
+			return false, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: multi-table update with a value from another table: %s", sqlparser.String(col))
+		}
+		col.Qualifier = sqlparser.TableName{}
+		return true, nil
+	}, upd.Exprs)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildDMLWithInputPlan(vschema, "update", table, target.alias, upd.TableExprs, upd.Where, upd.OrderBy, upd.Limit, func(where *sqlparser.Where) (engine.Primitive, error) {
+		return buildUpdatePlan(&sqlparser.Update{
+			Comments:   upd.Comments,
+			Ignore:     upd.Ignore,
+			TableExprs: dmlTarget(table),
+			Exprs:      upd.Exprs,
+			Where:      where,
+		}, vschema)
+	})
+}
+
+// buildMoveRowsQuery sets up an update that changes the primary vindex
+// column. Such an update can move rows to another shard, so the engine
+// selects all the columns of the rows with their new values, and then
+// deletes and inserts again the rows whose keyspace id changes. The
+// columns of the table must be listed in the vschema, so that none is
+// lost by a move.
+func buildMoveRowsQuery(eupd *engine.Update, upd *sqlparser.Update) error {
+	table := eupd.Table
+	vindex := table.ColumnVindexes[0]
+	if len(table.PrimaryKey) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: You can't update primary vindex columns of table %s without a primary_key in its vschema. Invalid update on vindex: %v", table.Name.String(), vindex.Name)
+	}
+	if !table.ColumnListAuthoritative || len(table.Columns) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: You can't update primary vindex columns of table %s without an authoritative column list in its vschema. Invalid update on vindex: %v", table.Name.String(), vindex.Name)
+	}
+	if _, ok := vindex.Vindex.(vindexes.SingleColumn); !ok {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: You can't update multi-column primary vindexes. Invalid update on vindex: %v", vindex.Name)
+	}
+	if upd.Limit != nil && len(upd.OrderBy) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: Need to provide order by clause when using limit. Invalid update on vindex: %v", vindex.Name)
+	}
+
+	buf := sqlparser.NewTrackedBuffer(dmlFormatter)
+	buf.Myprintf("select ")
+	for i, col := range table.Columns {
+		if i > 0 {
+			buf.Myprintf(", ")
+		}
+		buf.Myprintf("%v", col.Name)
+	}
+	for i, assignment := range upd.Exprs {
+		for _, prev := range upd.Exprs[:i] {
+			if prev.Name.Name.Equal(assignment.Name.Name) {
+				return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "column has duplicate set values: '%v'", assignment.Name.Name)
+			}
+		}
+		if !hasColumn(table, assignment.Name.Name) {
+			return vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "column %v not found in the column list of table %s", assignment.Name.Name, table.Name.String())
+		}
+		buf.Myprintf(", %v", assignment.Expr)
+		eupd.MoveColumns = append(eupd.MoveColumns, assignment.Name.Name.String())
+	}
+	buf.Myprintf(" from %v%v%v%v for update", upd.TableExprs, upd.Where, upd.OrderBy, upd.Limit)
+	eupd.MoveRowsQuery = buf.String()
+	return nil
+}
+
+// hasColumn returns true if the column is in the column list of the table.
+func hasColumn(table *vindexes.Table, name sqlparser.ColIdent) bool {
+	for _, col := range table.Columns {
+		if col.Name.Equal(name) {
+			return true
+		}
+	}
+	return false
+}
+
 // buildChangedVindexesValues adds to the plan all the lookup vindexes that are changing.
 // Updates can only be performed to secondary lookup vindexes with no complex expressions
 // in the set clause.
 func buildChangedVindexesValues(update *sqlparser.Update, table *vindexes.Table, ksidCol string) (map[string]*engine.VindexValues, string, error) {
 	changedVindexes := make(map[string]*engine.VindexValues)
 	buf, offset := initialQuery(ksidCol, table)
-	for i, vindex := range table.ColumnVindexes {
+	for _, vindex := range table.ColumnVindexes {
 		vindexValueMap := make(map[string]sqltypes.PlanValue)
 		first := true
 		for _, vcol := range vindex.Columns {
@@ -94,9 +239,6 @@ func buildChangedVindexesValues(update *sqlparser.Update, table *vindexes.Table,
 		if update.Limit != nil && len(update.OrderBy) == 0 {
 			return nil, "", vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: Need to provide order by clause when using limit. Invalid update on vindex: %v", vindex.Name)
 		}
-		if i == 0 {
-			return nil, "", vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: You can't update primary vindex columns. Invalid update on vindex: %v", vindex.Name)
-		}
 		if _, ok := vindex.Vindex.(vindexes.Lookup); !ok {
 			return nil, "", vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: You can only update lookup vindexes. Invalid update on vindex: %v", vindex.Name)
 		}
@@ -0,0 +1,233 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logz"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+)
+
+const (
+	pathQueryDigests = "/debug/query_digests"
+	pathTopQueries   = "/debug/top_queries"
+)
+
+var (
+	queryDigestsCapacity = flag.Int("query_digests_capacity", 0, "Maximum number of query fingerprints whose latency, rows, shard queries and errors are tracked, in /debug/query_digests and the VtgateQueryDigests metrics. The least frequent fingerprints are dropped. 0 disables the query digests.")
+	topQueriesPeers      = flag.String("top_queries_peers", "", "Comma-separated list of the host:port of the other vtgates whose query digests are added up in /debug/top_queries.")
+	topQueriesTimeout    = flag.Duration("top_queries_timeout", 5*time.Second, "Timeout to fetch the query digests of the other vtgates for /debug/top_queries.")
+
+	queryDigestsOnce sync.Once
+	queryDigests     *stats.QueryDigests
+)
+
+// initQueryDigests returns the query digests of the process, or nil if they
+// are disabled.
+func initQueryDigests() *stats.QueryDigests {
+	if *queryDigestsCapacity <= 0 {
+		return nil
+	}
+	queryDigestsOnce.Do(func() {
+		queryDigests = stats.NewQueryDigests("VtgateQueryDigests", "Vtgate statistics by query fingerprint", *queryDigestsCapacity)
+	})
+	return queryDigests
+}
+
+// recordQueryDigest adds an execution of plan to the query digests. The
+// fingerprint of the query is its normalized text.
+func (e *Executor) recordQueryDigest(plan *engine.Plan, logStats *LogStats, rowsReturned int, err error) {
+	if e.queryDigests == nil {
+		return
+	}
+	e.queryDigests.Record(
+		sqlparser.TruncateForUI(plan.Original),
+		time.Since(logStats.StartTime),
+		int64(logStats.RowsAffected),
+		int64(rowsReturned),
+		int64(logStats.ShardQueries),
+		err != nil,
+	)
+}
+
+func (e *Executor) queryDigestsSnapshot() []stats.QueryDigestStats {
+	if e.queryDigests == nil {
+		return []stats.QueryDigestStats{}
+	}
+	return e.queryDigests.Snapshot()
+}
+
+var (
+	topQueriesHeader = []byte(`<thead>
+		<tr>
+			<th>Digest</th>
+			<th>Query</th>
+			<th>Count</th>
+			<th>Errors</th>
+			<th>p50</th>
+			<th>p95</th>
+			<th>p99</th>
+			<th>Rows affected per query</th>
+			<th>Rows returned per query</th>
+			<th>Shard queries per query</th>
+		</tr>
+        </thead>
+	`)
+	topQueriesTmpl = template.Must(template.New("example").Parse(`
+		<tr class="{{.Color}}">
+			<td>{{.Digest}}</td>
+			<td>{{.Query}}</td>
+			<td>{{.Count}}</td>
+			<td>{{.Errors}}</td>
+			<td>{{.P50}}</td>
+			<td>{{.P95}}</td>
+			<td>{{.P99}}</td>
+			<td>{{.RowsAffectedPQ}}</td>
+			<td>{{.RowsReturnedPQ}}</td>
+			<td>{{.ShardQueriesPQ}}</td>
+		</tr>
+	`))
+	topQueriesErrorTmpl = template.Must(template.New("error").Parse(`
+		<tr class="error">
+			<td>{{.Peer}}</td>
+			<td colspan="9">{{.Err}}</td>
+		</tr>
+	`))
+)
+
+// topQueriesRow is used for rendering the query digests
+// using go's template.
+type topQueriesRow struct {
+	stats.QueryDigestStats
+	Color string
+}
+
+func (r *topQueriesRow) perQuery(v int64) string {
+	if r.Count == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%.6f", float64(v)/float64(r.Count))
+}
+
+// RowsAffectedPQ returns the rows affected per query as a string.
+func (r *topQueriesRow) RowsAffectedPQ() string { return r.perQuery(r.RowsAffected) }
+
+// RowsReturnedPQ returns the rows returned per query as a string.
+func (r *topQueriesRow) RowsReturnedPQ() string { return r.perQuery(r.RowsReturned) }
+
+// ShardQueriesPQ returns the shard queries per query as a string.
+func (r *topQueriesRow) ShardQueriesPQ() string { return r.perQuery(r.ShardQueries) }
+
+// handleTopQueries shows the most frequent queries of this vtgate and of
+// the vtgates listed in -top_queries_peers. The number of queries can be
+// set with the limit parameter.
+func (e *Executor) handleTopQueries(response http.ResponseWriter, request *http.Request) {
+	limit := 100
+	if v := request.FormValue("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(response, fmt.Sprintf("invalid limit: %v", v), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	var peers []string
+	if *topQueriesPeers != "" {
+		peers = strings.Split(*topQueriesPeers, ",")
+	}
+	lists, errs := fetchQueryDigests(request.Context(), peers)
+	all := stats.MergeQueryDigests(append(lists, e.queryDigestsSnapshot())...)
+	if len(all) > limit {
+		all = all[:limit]
+	}
+
+	logz.StartHTMLTable(response)
+	defer logz.EndHTMLTable(response)
+	response.Write(topQueriesHeader)
+	for i, peer := range peers {
+		if errs[i] == nil {
+			continue
+		}
+		if err := topQueriesErrorTmpl.Execute(response, struct{ Peer, Err string }{peer, errs[i].Error()}); err != nil {
+			log.Errorf("top_queries: couldn't execute template: %v", err)
+		}
+	}
+	for _, s := range all {
+		row := &topQueriesRow{QueryDigestStats: s}
+		row.Query = logz.Wrappable(row.Query)
+		switch {
+		case s.P95 < 10*time.Millisecond:
+			row.Color = "low"
+		case s.P95 < 100*time.Millisecond:
+			row.Color = "medium"
+		default:
+			row.Color = "high"
+		}
+		if err := topQueriesTmpl.Execute(response, row); err != nil {
+			log.Errorf("top_queries: couldn't execute template: %v", err)
+		}
+	}
+}
+
+// fetchQueryDigests gets the query digests of the peers in parallel.
+func fetchQueryDigests(ctx context.Context, peers []string) ([][]stats.QueryDigestStats, []error) {
+	ctx, cancel := context.WithTimeout(ctx, *topQueriesTimeout)
+	defer cancel()
+
+	lists := make([][]stats.QueryDigestStats, len(peers))
+	errs := make([]error, len(peers))
+	wg := sync.WaitGroup{}
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(i int, peer string) {
+			defer wg.Done()
+			url := peer
+			if !strings.Contains(url, "://") {
+				url = "http://" + url
+			}
+			resp, err := ctxhttp.Get(ctx, nil, url+pathQueryDigests)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				errs[i] = fmt.Errorf("%v returned %v", url+pathQueryDigests, resp.Status)
+				return
+			}
+			errs[i] = json.NewDecoder(resp.Body).Decode(&lists[i])
+		}(i, strings.TrimSpace(peer))
+	}
+	wg.Wait()
+	return lists, errs
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/stats"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestQueryDigests(t *testing.T) {
+	executor, sbc1, _, _ := createLegacyExecutorEnv()
+	executor.queryDigests = stats.NewQueryDigests("", "help", 10)
+
+	for i := 0; i < 2; i++ {
+		_, err := executorExec(executor, "select id from user where id = 1", nil)
+		require.NoError(t, err)
+	}
+	sbc1.MustFailCodes[vtrpcpb.Code_INVALID_ARGUMENT] = 1
+	_, err := executorExec(executor, "select id from user where id = 1", nil)
+	require.Error(t, err)
+	_, err = executorStream(executor, "select id from user")
+	require.NoError(t, err)
+
+	all := executor.queryDigests.Snapshot()
+	require.Len(t, all, 2)
+	assert.Equal(t, "select id from user where id = 1", all[0].Query)
+	assert.EqualValues(t, 3, all[0].Count)
+	assert.EqualValues(t, 1, all[0].Errors)
+	assert.EqualValues(t, 3, all[0].ShardQueries)
+	assert.EqualValues(t, 2, all[0].RowsReturned)
+	assert.Equal(t, "select id from user", all[1].Query)
+	assert.EqualValues(t, 1, all[1].Count)
+	assert.EqualValues(t, 8, all[1].ShardQueries)
+	assert.EqualValues(t, 8, all[1].RowsReturned)
+
+	request := httptest.NewRequest("GET", pathQueryDigests, nil)
+	response := httptest.NewRecorder()
+	executor.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+	var got []stats.QueryDigestStats
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &got))
+	assert.Equal(t, all, got)
+}
+
+func TestQueryDigestsDisabled(t *testing.T) {
+	executor, _, _, _ := createLegacyExecutorEnv()
+	_, err := executorExec(executor, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest("GET", pathQueryDigests, nil)
+	response := httptest.NewRecorder()
+	executor.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "[]", response.Body.String())
+}
+
+func TestTopQueries(t *testing.T) {
+	executor, _, _, _ := createLegacyExecutorEnv()
+	executor.queryDigests = stats.NewQueryDigests("", "help", 10)
+	_, err := executorExec(executor, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+
+	// The peer has run the same query twice.
+	peerDigests := stats.NewQueryDigests("", "help", 10)
+	local := executor.queryDigests.Snapshot()[0]
+	peerDigests.Record(local.Query, local.TotalTime, 0, 1, 1, false)
+	peerDigests.Record(local.Query, local.TotalTime, 0, 1, 1, true)
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, pathQueryDigests, r.URL.Path)
+		returnAsJSON(w, peerDigests.Snapshot())
+	}))
+	defer peer.Close()
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+
+	defer func(saved string) { *topQueriesPeers = saved }(*topQueriesPeers)
+	*topQueriesPeers = strings.TrimPrefix(peer.URL, "http://") + "," + notFound.URL
+
+	request := httptest.NewRequest("GET", pathTopQueries, nil)
+	response := httptest.NewRecorder()
+	executor.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+	body, _ := ioutil.ReadAll(response.Body)
+	assert.Contains(t, string(body), "<td>"+local.Digest+"</td>\n\t\t\t<td>select id from user where id = 1</td>\n\t\t\t<td>3</td>\n\t\t\t<td>1</td>")
+	assert.Contains(t, string(body), "<td>"+notFound.URL+"</td>\n\t\t\t<td colspan=\"9\">"+notFound.URL+"/debug/query_digests returned 404 Not Found</td>")
+
+	request = httptest.NewRequest("GET", pathTopQueries+"?limit=x", nil)
+	response = httptest.NewRecorder()
+	executor.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
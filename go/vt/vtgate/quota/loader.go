@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"flag"
+	"io/ioutil"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	configFile           = flag.String("quota_config_file", "", "JSON file with the vtgate quotas. The file is reloaded every quota_config_reload_interval.")
+	configTopoPath       = flag.String("quota_config_topo_path", "", "Path of the JSON vtgate quotas in the global topo, e.g. vtgate/quotas.json. The file is watched for changes, and can be written with 'vtctl TopoCp'.")
+	configReloadInterval = flag.Duration("quota_config_reload_interval", 30*time.Second, "How often the quota_config_file is reloaded, or how long to wait before watching the quota_config_topo_path again after an error.")
+	dryRun               = flag.Bool("quota_dry_run", false, "If set, the requests over the vtgate quotas are only counted in the QuotaRejectionsDryRun stats instead of being rejected or queued.")
+
+	configErrors = stats.NewCounter("QuotaConfigErrors", "Errors loading the vtgate quota config")
+)
+
+// Init creates the Manager configured by the flags, and keeps its config up
+// to date until ctx is done. It returns nil if no quota config is set.
+func Init(ctx context.Context, ts *topo.Server) (*Manager, error) {
+	if *configFile == "" && *configTopoPath == "" {
+		return nil, nil
+	}
+	if *configFile != "" && *configTopoPath != "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "only one of quota_config_file and quota_config_topo_path can be set")
+	}
+
+	m := NewManager(*dryRun)
+	if *configFile != "" {
+		if err := m.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+		go m.reloadFile(ctx, *configFile, *configReloadInterval)
+		return m, nil
+	}
+	if ts == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "quota_config_topo_path is set, but no topo server is available")
+	}
+	go m.watchTopo(ctx, ts, *configTopoPath, *configReloadInterval)
+	return m, nil
+}
+
+// load parses data and replaces the quotas. If the config is invalid,
+// the current quotas are kept.
+func (m *Manager) load(data []byte, source string) error {
+	config, err := ParseConfig(data)
+	if err != nil {
+		configErrors.Add(1)
+		return vterrors.Wrapf(err, "cannot load the quotas from %s", source)
+	}
+	m.SetConfig(config)
+	return nil
+}
+
+func (m *Manager) loadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		configErrors.Add(1)
+		return vterrors.Wrapf(err, "cannot read the quota config")
+	}
+	return m.load(data, path)
+}
+
+// reloadFile reloads the config file every interval until ctx is done.
+func (m *Manager) reloadFile(ctx context.Context, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.loadFile(path); err != nil {
+			log.Errorf("%v", err)
+		}
+	}
+}
+
+// watchTopo follows the config file in the global topo until ctx is done.
+// Deleting the file removes the quotas.
+func (m *Manager) watchTopo(ctx context.Context, ts *topo.Server, path string, retryDelay time.Duration) {
+	for {
+		if err := m.watchTopoOnce(ctx, ts, path); err != nil {
+			log.Errorf("%v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (m *Manager) watchTopoOnce(ctx context.Context, ts *topo.Server, path string) error {
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot watch the quota config")
+	}
+	current, changes, cancel := conn.Watch(ctx, path)
+	if err := m.processWatchData(current, path); err != nil {
+		return err
+	}
+	defer cancel()
+	for wd := range changes {
+		if err := m.processWatchData(wd, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processWatchData loads the config of wd. It returns an error if the
+// watch stopped.
+func (m *Manager) processWatchData(wd *topo.WatchData, path string) error {
+	if wd.Err != nil {
+		if topo.IsErrType(wd.Err, topo.NoNode) {
+			m.SetConfig(nil)
+		}
+		return vterrors.Wrapf(wd.Err, "cannot watch the quota config %s", path)
+	}
+	if err := m.load(wd.Contents, "topo "+path); err != nil {
+		log.Errorf("%v", err)
+	}
+	return nil
+}
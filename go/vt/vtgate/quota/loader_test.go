@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+func quotaNames(m *Manager) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var names []string
+	for _, q := range m.quotas {
+		names = append(names, q.config.Name)
+	}
+	return names
+}
+
+func waitForQuotas(t *testing.T, m *Manager, want []string) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if assert.ObjectsAreEqual(want, quotaNames(m)) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("quotas are %v, want %v", quotaNames(m), want)
+}
+
+func TestInitFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "quota")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "quotas.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"quotas": [{"name": "a"}]}`), 0644))
+
+	defer func(saved string, interval time.Duration) {
+		*configFile, *configReloadInterval = saved, interval
+	}(*configFile, *configReloadInterval)
+	*configFile = file
+	*configReloadInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m, err := Init(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, quotaNames(m))
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"quotas": [{"name": "b"}]}`), 0644))
+	waitForQuotas(t, m, []string{"b"})
+
+	// An invalid config keeps the current quotas.
+	errors := configErrors.Get()
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"quotas": [{"name": "b", "key": "shard"}]}`), 0644))
+	for configErrors.Get() == errors {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, []string{"b"}, quotaNames(m))
+
+	// An invalid config fails the startup.
+	m, err = Init(ctx, nil)
+	assert.Nil(t, m)
+	assert.Error(t, err)
+}
+
+func TestInitNotConfigured(t *testing.T) {
+	m, err := Init(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+}
+
+func TestWatchTopo(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer("cell1")
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+
+	m := NewManager(false)
+	go m.watchTopo(ctx, ts, "vtgate/quotas.json", 10*time.Millisecond)
+
+	version, err := conn.Create(ctx, "vtgate/quotas.json", []byte(`{"quotas": [{"name": "a"}]}`))
+	require.NoError(t, err)
+	waitForQuotas(t, m, []string{"a"})
+
+	version, err = conn.Update(ctx, "vtgate/quotas.json", []byte(`{"quotas": [{"name": "a"}, {"name": "b"}]}`), version)
+	require.NoError(t, err)
+	waitForQuotas(t, m, []string{"a", "b"})
+
+	require.NoError(t, conn.Delete(ctx, "vtgate/quotas.json", version))
+	waitForQuotas(t, m, nil)
+}
@@ -0,0 +1,540 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota implements the admission control of vtgate.
+//
+// A quota limits the queries per second, the concurrent queries and the
+// concurrent scatter queries of the requests which share a key: the
+// immediate caller, the effective caller, the workload or a table. Each
+// distinct value of the key gets its own limits. A request over a limit is
+// either rejected, or queued until the limit allows it or its queue timeout
+// expires. In dry run mode, the requests over a limit are only counted.
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The keys a quota can group the requests by.
+const (
+	// KeyImmediateCaller groups the requests by the user of the connection.
+	KeyImmediateCaller = "immediate_caller"
+	// KeyEffectiveCaller groups the requests by the principal of the
+	// effective caller ID.
+	KeyEffectiveCaller = "effective_caller"
+	// KeyWorkload groups the requests by workload: OLTP, OLAP or DBA.
+	KeyWorkload = "workload"
+	// KeyTable groups the requests by the tables they use. A request
+	// is counted against every table it uses.
+	KeyTable = "table"
+)
+
+// The actions taken when a request is over a limit.
+const (
+	// ActionReject fails the request right away.
+	ActionReject = "reject"
+	// ActionQueue waits until the limit allows the request, and fails it
+	// if it had to wait for longer than the queue timeout.
+	ActionQueue = "queue"
+)
+
+// The limits of a quota, as used in the stats.
+const (
+	limitQPS               = "QPS"
+	limitConcurrent        = "Concurrent"
+	limitConcurrentScatter = "ConcurrentScatter"
+)
+
+var (
+	// The Key label of the stats is only set for the quotas restricted to
+	// some values, to keep the number of stats bounded.
+	rejections       = stats.NewCountersWithMultiLabels("QuotaRejections", "Requests rejected by the vtgate quotas", []string{"Quota", "Key", "Limit"})
+	rejectionsDryRun = stats.NewCountersWithMultiLabels("QuotaRejectionsDryRun", "Requests which would have been rejected by the vtgate quotas if they were not in dry run mode", []string{"Quota", "Key", "Limit"})
+	queueTimings     = stats.NewMultiTimings("QuotaQueueTimings", "Time requests waited in the queue of the vtgate quotas", []string{"Quota", "Limit"})
+	inFlight         = stats.NewGaugesWithMultiLabels("QuotaInFlight", "Concurrent requests counted by the vtgate quotas", []string{"Quota", "Key"})
+)
+
+// Config is the quota config of vtgate.
+type Config struct {
+	Quotas []*QuotaConfig `json:"quotas,omitempty"`
+}
+
+// QuotaConfig is the config of a single quota. A zero limit is unlimited.
+type QuotaConfig struct {
+	// Name identifies the quota in the stats and errors.
+	Name string `json:"name"`
+	// Key is the key the requests are grouped by. If it is empty,
+	// all the requests share the same limits.
+	Key string `json:"key,omitempty"`
+	// Values restricts the quota to the requests whose key is one of
+	// them. If it is empty, the quota applies to all the values.
+	Values []string `json:"values,omitempty"`
+
+	QPS                  float64 `json:"qps,omitempty"`
+	MaxConcurrent        int     `json:"max_concurrent,omitempty"`
+	MaxConcurrentScatter int     `json:"max_concurrent_scatter,omitempty"`
+
+	// Action is ActionReject or ActionQueue. The default is ActionReject.
+	Action string `json:"action,omitempty"`
+	// QueueTimeoutMs bounds the time a queued request waits. If it is 0,
+	// the request waits until its own deadline.
+	QueueTimeoutMs int64 `json:"queue_timeout_ms,omitempty"`
+
+	// ErrorCode is the vtrpc code of the error returned to the rejected
+	// requests. The default is RESOURCE_EXHAUSTED.
+	ErrorCode string `json:"error_code,omitempty"`
+	// ErrorMessage replaces the default message of the error.
+	ErrorMessage string `json:"error_message,omitempty"`
+
+	// DryRun only counts the requests over the limits of this quota.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ParseConfig parses and validates a JSON quota config.
+func ParseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, vterrors.Wrap(err, "invalid quota config")
+	}
+	names := make(map[string]bool)
+	for _, qc := range config.Quotas {
+		if qc.Name == "" {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid quota config: quota without a name")
+		}
+		if names[qc.Name] {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid quota config: duplicate quota %s", qc.Name)
+		}
+		names[qc.Name] = true
+		switch qc.Key {
+		case "", KeyImmediateCaller, KeyEffectiveCaller, KeyWorkload, KeyTable:
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid quota config: quota %s has an unknown key %s", qc.Name, qc.Key)
+		}
+		switch qc.Action {
+		case "", ActionReject, ActionQueue:
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid quota config: quota %s has an unknown action %s", qc.Name, qc.Action)
+		}
+		if qc.ErrorCode != "" {
+			if _, ok := vtrpcpb.Code_value[qc.ErrorCode]; !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid quota config: quota %s has an unknown error code %s", qc.Name, qc.ErrorCode)
+			}
+		}
+		if qc.QPS < 0 || qc.MaxConcurrent < 0 || qc.MaxConcurrentScatter < 0 || qc.QueueTimeoutMs < 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid quota config: quota %s has a negative limit", qc.Name)
+		}
+	}
+	return config, nil
+}
+
+// Request describes a query for the quotas.
+type Request struct {
+	ImmediateCaller string
+	EffectiveCaller string
+	Workload        string
+	// Tables are the tables used by the query, as keyspace.table.
+	Tables []string
+	// Scatter is true if the query is sent to all the shards of a keyspace.
+	Scatter bool
+}
+
+// values returns the values of key for the request.
+func (r *Request) values(key string) []string {
+	switch key {
+	case KeyImmediateCaller:
+		return []string{r.ImmediateCaller}
+	case KeyEffectiveCaller:
+		return []string{r.EffectiveCaller}
+	case KeyWorkload:
+		return []string{r.Workload}
+	case KeyTable:
+		return r.Tables
+	}
+	return []string{""}
+}
+
+// Manager enforces the quotas. The config can be replaced at any time:
+// the requests admitted with the previous config are released against it.
+type Manager struct {
+	dryRun bool
+
+	mu     sync.Mutex
+	quotas []*quota
+}
+
+// NewManager creates a Manager without quotas. If dryRun is set, no request
+// is ever rejected or queued.
+func NewManager(dryRun bool) *Manager {
+	return &Manager{dryRun: dryRun}
+}
+
+// SetConfig replaces the quotas. A nil config removes them. The quotas
+// whose config did not change keep their state.
+func (m *Manager) SetConfig(config *Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous := make(map[string]*quota)
+	for _, q := range m.quotas {
+		previous[q.config.Name] = q
+	}
+	var quotas []*quota
+	if config != nil {
+		for _, qc := range config.Quotas {
+			if q, ok := previous[qc.Name]; ok && reflect.DeepEqual(q.config, qc) {
+				quotas = append(quotas, q)
+				continue
+			}
+			quotas = append(quotas, newQuota(qc, m.dryRun || qc.DryRun))
+		}
+	}
+	m.quotas = quotas
+}
+
+// Admit returns nil if the request is within its quotas, possibly after
+// waiting in their queues. The returned release func must then be called
+// once the request is done. Otherwise, Admit returns the error of the
+// quota which rejected the request.
+// All the quotas are checked before the request waits in any queue, and a
+// rejected request gives back what it took from the other quotas, such
+// that it does not consume their QPS.
+func (m *Manager) Admit(ctx context.Context, req *Request) (release func(), err error) {
+	m.mu.Lock()
+	quotas := m.quotas
+	m.mu.Unlock()
+
+	now := time.Now()
+	var claims []*claim
+	cancel := func(at time.Time) {
+		for _, c := range claims {
+			c.cancel(at)
+		}
+	}
+	for _, q := range quotas {
+		for _, value := range req.values(q.config.Key) {
+			if !q.applies(value) {
+				continue
+			}
+			c := q.claim(now, value, req.Scatter)
+			claims = append(claims, c)
+			if err := c.check(); err != nil {
+				cancel(now)
+				return nil, err
+			}
+		}
+	}
+	for _, c := range claims {
+		if err := c.wait(ctx); err != nil {
+			cancel(time.Now())
+			return nil, err
+		}
+	}
+	for _, c := range claims {
+		inFlight.Add(c.q.statsLabels(c.value), 1)
+	}
+	return func() {
+		for _, c := range claims {
+			inFlight.Add(c.q.statsLabels(c.value), -1)
+			c.release()
+		}
+	}, nil
+}
+
+// bucketIdleTimeout is how long a bucket must be unused before it's
+// evicted. A quota keyed by the caller or the table gets a bucket for
+// every distinct value, which would otherwise be kept forever.
+var bucketIdleTimeout = 1 * time.Minute
+
+// quota is a QuotaConfig with the state of the limits of each value.
+type quota struct {
+	config       *QuotaConfig
+	dryRun       bool
+	values       map[string]bool
+	queueTimeout time.Duration
+	idleTimeout  time.Duration
+	code         vtrpcpb.Code
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// bucket holds the limits of a quota for a single value.
+type bucket struct {
+	limiter *rate.Limiter
+	// concurrent and concurrentScatter are semaphores of the size of the
+	// concurrency limits, or nil if they are unlimited.
+	concurrent        chan struct{}
+	concurrentScatter chan struct{}
+
+	// users and lastUsed are protected by the mutex of the quota. A
+	// bucket is only evicted if it has no users.
+	users    int
+	lastUsed time.Time
+}
+
+func newQuota(qc *QuotaConfig, dryRun bool) *quota {
+	q := &quota{
+		config:       qc,
+		dryRun:       dryRun,
+		queueTimeout: time.Duration(qc.QueueTimeoutMs) * time.Millisecond,
+		idleTimeout:  bucketIdleTimeout,
+		code:         vtrpcpb.Code_RESOURCE_EXHAUSTED,
+		buckets:      make(map[string]*bucket),
+		lastSweep:    time.Now(),
+	}
+	if len(qc.Values) > 0 {
+		q.values = make(map[string]bool)
+		for _, v := range qc.Values {
+			q.values[v] = true
+		}
+	}
+	if qc.ErrorCode != "" {
+		q.code = vtrpcpb.Code(vtrpcpb.Code_value[qc.ErrorCode])
+	}
+	// A bucket can only be evicted once its QPS limiter is full again,
+	// or a new bucket would allow more queries.
+	if qc.QPS > 0 {
+		if refill := time.Duration(float64(q.burst()) / qc.QPS * float64(time.Second)); refill > q.idleTimeout {
+			q.idleTimeout = refill
+		}
+	}
+	return q
+}
+
+func (q *quota) applies(value string) bool {
+	return q.values == nil || q.values[value]
+}
+
+// statsLabels returns the labels of the stats of value. Only the values
+// of the config are used as labels, since the values of a quota which
+// applies to all the callers or tables are unbounded.
+func (q *quota) statsLabels(value string, limit ...string) []string {
+	if q.values == nil {
+		value = ""
+	}
+	return append([]string{q.config.Name, value}, limit...)
+}
+
+// burst is the burst of the QPS limiter, which allows a second worth of
+// queries, and at least one.
+func (q *quota) burst() int {
+	burst := int(q.config.QPS)
+	if burst < 1 {
+		burst = 1
+	}
+	return burst
+}
+
+// use returns the bucket of value, and counts a user of it, which must
+// be given back with unuse. The idle buckets are evicted along the way.
+func (q *quota) use(now time.Time, value string) *bucket {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if now.Sub(q.lastSweep) >= q.idleTimeout {
+		q.lastSweep = now
+		for v, b := range q.buckets {
+			if b.users == 0 && now.Sub(b.lastUsed) >= q.idleTimeout {
+				delete(q.buckets, v)
+			}
+		}
+	}
+	b, ok := q.buckets[value]
+	if !ok {
+		b = &bucket{}
+		if q.config.QPS > 0 {
+			b.limiter = rate.NewLimiter(rate.Limit(q.config.QPS), q.burst())
+		}
+		if q.config.MaxConcurrent > 0 {
+			b.concurrent = make(chan struct{}, q.config.MaxConcurrent)
+		}
+		if q.config.MaxConcurrentScatter > 0 {
+			b.concurrentScatter = make(chan struct{}, q.config.MaxConcurrentScatter)
+		}
+		q.buckets[value] = b
+	}
+	b.users++
+	b.lastUsed = now
+	return b
+}
+
+func (q *quota) unuse(b *bucket) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	b.users--
+	b.lastUsed = time.Now()
+}
+
+// claim is what a request takes from the bucket of a value.
+type claim struct {
+	q     *quota
+	value string
+	b     *bucket
+
+	// reservation is the QPS token of the request, if the quota has a QPS
+	// limit. It may be in the future, if the request is over the limit.
+	reservation *rate.Reservation
+	// held are the semaphore slots taken by the request.
+	held []chan struct{}
+	// over are the limits the request is over, and blocked the semaphores
+	// which are full, in the same order.
+	over    []string
+	blocked []chan struct{}
+}
+
+// claim takes what it can from the bucket of value without waiting.
+func (q *quota) claim(now time.Time, value string, scatter bool) *claim {
+	c := &claim{q: q, value: value, b: q.use(now, value)}
+	if c.b.limiter != nil {
+		c.reservation = c.b.limiter.ReserveN(now, 1)
+		if c.reservation.DelayFrom(now) > 0 {
+			c.over = append(c.over, limitQPS)
+		}
+	}
+	c.tryAcquire(limitConcurrent, c.b.concurrent)
+	if scatter {
+		c.tryAcquire(limitConcurrentScatter, c.b.concurrentScatter)
+	}
+	return c
+}
+
+func (c *claim) tryAcquire(limit string, sem chan struct{}) {
+	if sem == nil {
+		return
+	}
+	select {
+	case sem <- struct{}{}:
+		c.held = append(c.held, sem)
+	default:
+		c.over = append(c.over, limit)
+		c.blocked = append(c.blocked, sem)
+	}
+}
+
+// check handles the limits the request is over: in dry run mode, they are
+// counted and the request is let through. Otherwise, the request is
+// rejected, unless the action of the quota is to queue.
+func (c *claim) check() error {
+	if len(c.over) == 0 {
+		return nil
+	}
+	q := c.q
+	if q.dryRun {
+		for _, limit := range c.over {
+			rejectionsDryRun.Add(q.statsLabels(c.value, limit), 1)
+			if limit == limitQPS {
+				c.reservation.Cancel()
+				c.reservation = nil
+			}
+		}
+		c.over, c.blocked = nil, nil
+		return nil
+	}
+	if q.config.Action == ActionQueue {
+		return nil
+	}
+	rejections.Add(q.statsLabels(c.value, c.over[0]), 1)
+	return q.error(c.value, c.over[0])
+}
+
+// wait queues the request until it's within the limits it's over, or
+// rejects it if the queue timeout or its own deadline expire first.
+func (c *claim) wait(ctx context.Context) error {
+	if len(c.over) == 0 {
+		return nil
+	}
+	q := c.q
+	if q.queueTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.queueTimeout)
+		defer cancel()
+	}
+	blocked := c.blocked
+	for _, limit := range c.over {
+		start := time.Now()
+		var err error
+		if limit == limitQPS {
+			err = sleep(ctx, c.reservation.Delay())
+		} else {
+			sem := blocked[0]
+			blocked = blocked[1:]
+			select {
+			case sem <- struct{}{}:
+				c.held = append(c.held, sem)
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+		queueTimings.Record([]string{q.config.Name, limit}, start)
+		if err != nil {
+			rejections.Add(q.statsLabels(c.value, limit), 1)
+			return q.error(c.value, limit)
+		}
+	}
+	c.over, c.blocked = nil, nil
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cancel gives back what the request took, including its QPS token as
+// much as possible, since it was rejected.
+func (c *claim) cancel(at time.Time) {
+	if c.reservation != nil {
+		c.reservation.CancelAt(at)
+	}
+	c.release()
+}
+
+// release frees the semaphore slots of the request.
+func (c *claim) release() {
+	for _, sem := range c.held {
+		<-sem
+	}
+	c.held = nil
+	c.q.unuse(c.b)
+}
+
+func (q *quota) error(value, limit string) error {
+	if q.config.ErrorMessage != "" {
+		return vterrors.New(q.code, q.config.ErrorMessage)
+	}
+	what := fmt.Sprintf("%s limit", limit)
+	if q.config.Key != "" {
+		what = fmt.Sprintf("%s limit for %s %s", limit, q.config.Key, value)
+	}
+	return vterrors.Errorf(q.code, "quota %s exceeded: %s", q.config.Name, what)
+}
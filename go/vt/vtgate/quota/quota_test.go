@@ -0,0 +1,282 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func newTestManager(t *testing.T, dryRun bool, config string) *Manager {
+	t.Helper()
+	m := NewManager(dryRun)
+	require.NoError(t, m.load([]byte(config), "test"))
+	return m
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig([]byte(`{"quotas": [{"name": "batch", "key": "immediate_caller", "values": ["batch"], "qps": 10, "action": "queue", "queue_timeout_ms": 100}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, &Config{Quotas: []*QuotaConfig{{
+		Name:           "batch",
+		Key:            KeyImmediateCaller,
+		Values:         []string{"batch"},
+		QPS:            10,
+		Action:         ActionQueue,
+		QueueTimeoutMs: 100,
+	}}}, config)
+
+	for _, tcase := range []struct {
+		config, err string
+	}{{
+		config: `{"quotas": [{"qps": 1}]}`,
+		err:    "invalid quota config: quota without a name",
+	}, {
+		config: `{"quotas": [{"name": "a"}, {"name": "a"}]}`,
+		err:    "invalid quota config: duplicate quota a",
+	}, {
+		config: `{"quotas": [{"name": "a", "key": "shard"}]}`,
+		err:    "invalid quota config: quota a has an unknown key shard",
+	}, {
+		config: `{"quotas": [{"name": "a", "action": "drop"}]}`,
+		err:    "invalid quota config: quota a has an unknown action drop",
+	}, {
+		config: `{"quotas": [{"name": "a", "error_code": "NOPE"}]}`,
+		err:    "invalid quota config: quota a has an unknown error code NOPE",
+	}, {
+		config: `{"quotas": [{"name": "a", "max_concurrent": -1}]}`,
+		err:    "invalid quota config: quota a has a negative limit",
+	}} {
+		_, err := ParseConfig([]byte(tcase.config))
+		assert.EqualError(t, err, tcase.err, tcase.config)
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	m := newTestManager(t, false, `{"quotas": [{"name": "users", "key": "immediate_caller", "max_concurrent": 1}]}`)
+	ctx := context.Background()
+	alice := &Request{ImmediateCaller: "alice"}
+
+	release, err := m.Admit(ctx, alice)
+	require.NoError(t, err)
+
+	// The callers are not labels of the stats, since they are unbounded.
+	before := rejections.Counts()["users..Concurrent"]
+	_, err = m.Admit(ctx, alice)
+	assert.EqualError(t, err, "quota users exceeded: Concurrent limit for immediate_caller alice")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.Equal(t, before+1, rejections.Counts()["users..Concurrent"])
+	assert.Equal(t, int64(1), inFlight.Counts()["users."])
+
+	// Every caller has its own limit.
+	releaseBob, err := m.Admit(ctx, &Request{ImmediateCaller: "bob"})
+	require.NoError(t, err)
+	releaseBob()
+
+	release()
+	release, err = m.Admit(ctx, alice)
+	require.NoError(t, err)
+	release()
+}
+
+func TestConcurrentScatter(t *testing.T) {
+	m := newTestManager(t, false, `{"quotas": [{"name": "scatter", "max_concurrent_scatter": 1}]}`)
+	ctx := context.Background()
+
+	release, err := m.Admit(ctx, &Request{Scatter: true})
+	require.NoError(t, err)
+	defer release()
+
+	_, err = m.Admit(ctx, &Request{Scatter: true})
+	assert.EqualError(t, err, "quota scatter exceeded: ConcurrentScatter limit")
+
+	// Queries which are not scatters are not limited.
+	releaseOne, err := m.Admit(ctx, &Request{})
+	require.NoError(t, err)
+	releaseOne()
+}
+
+func TestQPS(t *testing.T) {
+	m := newTestManager(t, false, `{"quotas": [{"name": "olap", "key": "workload", "values": ["OLAP"], "qps": 1, "error_code": "UNAVAILABLE", "error_message": "too many OLAP queries"}]}`)
+	ctx := context.Background()
+	olap := &Request{Workload: "OLAP"}
+
+	release, err := m.Admit(ctx, olap)
+	require.NoError(t, err)
+	release()
+	before := rejections.Counts()["olap.OLAP.QPS"]
+	_, err = m.Admit(ctx, olap)
+	assert.EqualError(t, err, "too many OLAP queries")
+	assert.Equal(t, vtrpcpb.Code_UNAVAILABLE, vterrors.Code(err))
+	// The configured values are labels of the stats.
+	assert.Equal(t, before+1, rejections.Counts()["olap.OLAP.QPS"])
+
+	// The quota only applies to its values.
+	for i := 0; i < 3; i++ {
+		release, err := m.Admit(ctx, &Request{Workload: "OLTP"})
+		require.NoError(t, err)
+		release()
+	}
+}
+
+func TestTables(t *testing.T) {
+	m := newTestManager(t, false, `{"quotas": [{"name": "tables", "key": "table", "max_concurrent": 1}]}`)
+	ctx := context.Background()
+
+	release, err := m.Admit(ctx, &Request{Tables: []string{"ks.a", "ks.b"}})
+	require.NoError(t, err)
+
+	// The request is counted against all its tables.
+	_, err = m.Admit(ctx, &Request{Tables: []string{"ks.b"}})
+	assert.EqualError(t, err, "quota tables exceeded: Concurrent limit for table ks.b")
+	// The slot of ks.c is freed when the request is rejected on ks.a.
+	_, err = m.Admit(ctx, &Request{Tables: []string{"ks.c", "ks.a"}})
+	assert.Error(t, err)
+	releaseC, err := m.Admit(ctx, &Request{Tables: []string{"ks.c"}})
+	require.NoError(t, err)
+	releaseC()
+	release()
+}
+
+func TestRejectionKeepsTokens(t *testing.T) {
+	m := newTestManager(t, false, `{"quotas": [{"name": "oltp", "key": "workload", "values": ["OLTP"], "qps": 1}, {"name": "all", "max_concurrent": 1}]}`)
+	ctx := context.Background()
+	oltp := &Request{Workload: "OLTP"}
+
+	hold, err := m.Admit(ctx, &Request{Workload: "OLAP"})
+	require.NoError(t, err)
+	// The requests rejected by the second quota give their QPS token back
+	// to the first one.
+	for i := 0; i < 3; i++ {
+		_, err = m.Admit(ctx, oltp)
+		assert.EqualError(t, err, "quota all exceeded: Concurrent limit")
+	}
+	hold()
+	release, err := m.Admit(ctx, oltp)
+	require.NoError(t, err)
+	release()
+	_, err = m.Admit(ctx, oltp)
+	assert.EqualError(t, err, "quota oltp exceeded: QPS limit for workload OLTP")
+}
+
+func TestIdleBuckets(t *testing.T) {
+	saved := bucketIdleTimeout
+	bucketIdleTimeout = 10 * time.Millisecond
+	defer func() { bucketIdleTimeout = saved }()
+	m := newTestManager(t, false, `{"quotas": [{"name": "users", "key": "immediate_caller", "max_concurrent": 1}]}`)
+	ctx := context.Background()
+
+	release, err := m.Admit(ctx, &Request{ImmediateCaller: "busy"})
+	require.NoError(t, err)
+	for _, user := range []string{"a", "b", "c"} {
+		r, err := m.Admit(ctx, &Request{ImmediateCaller: user})
+		require.NoError(t, err)
+		r()
+	}
+	assert.Len(t, m.quotas[0].buckets, 4)
+
+	// The idle buckets are evicted, but not the ones in use.
+	time.Sleep(20 * time.Millisecond)
+	r, err := m.Admit(ctx, &Request{ImmediateCaller: "d"})
+	require.NoError(t, err)
+	r()
+	assert.Len(t, m.quotas[0].buckets, 2)
+	_, err = m.Admit(ctx, &Request{ImmediateCaller: "busy"})
+	assert.Error(t, err)
+	release()
+}
+
+func TestQueue(t *testing.T) {
+	m := newTestManager(t, false, `{"quotas": [{"name": "queue", "max_concurrent": 1, "action": "queue", "queue_timeout_ms": 50}]}`)
+	ctx := context.Background()
+
+	release, err := m.Admit(ctx, &Request{})
+	require.NoError(t, err)
+
+	// The queued request fails after the queue timeout.
+	start := time.Now()
+	_, err = m.Admit(ctx, &Request{})
+	assert.EqualError(t, err, "quota queue exceeded: Concurrent limit")
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+
+	// The queued request runs once the slot is freed.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		release()
+	}()
+	release, err = m.Admit(ctx, &Request{})
+	require.NoError(t, err)
+	release()
+	assert.NotZero(t, queueTimings.Counts()["queue.Concurrent"])
+}
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+	for _, m := range []*Manager{
+		newTestManager(t, true, `{"quotas": [{"name": "dryrun", "max_concurrent": 1, "qps": 1}]}`),
+		newTestManager(t, false, `{"quotas": [{"name": "dryrun", "max_concurrent": 1, "qps": 1, "dry_run": true}]}`),
+	} {
+		before := rejectionsDryRun.Counts()
+		var releases []func()
+		for i := 0; i < 3; i++ {
+			release, err := m.Admit(ctx, &Request{})
+			require.NoError(t, err)
+			releases = append(releases, release)
+		}
+		for _, release := range releases {
+			release()
+		}
+		after := rejectionsDryRun.Counts()
+		assert.Equal(t, before["dryrun..Concurrent"]+2, after["dryrun..Concurrent"])
+		assert.Equal(t, before["dryrun..QPS"]+2, after["dryrun..QPS"])
+	}
+}
+
+func TestSetConfigKeepsState(t *testing.T) {
+	config := `{"quotas": [{"name": "all", "max_concurrent": 1}]}`
+	m := newTestManager(t, false, config)
+	ctx := context.Background()
+	release, err := m.Admit(ctx, &Request{})
+	require.NoError(t, err)
+
+	// Reloading the same config keeps the requests in flight.
+	require.NoError(t, m.load([]byte(config), "test"))
+	_, err = m.Admit(ctx, &Request{})
+	assert.Error(t, err)
+
+	// A changed quota starts over.
+	require.NoError(t, m.load([]byte(`{"quotas": [{"name": "all", "max_concurrent": 2}]}`), "test"))
+	release2, err := m.Admit(ctx, &Request{})
+	require.NoError(t, err)
+	release2()
+	release()
+
+	// An invalid config keeps the current quotas.
+	assert.Error(t, m.load([]byte(`{"quotas": [{}]}`), "test"))
+	assert.Len(t, m.quotas, 1)
+
+	m.SetConfig(nil)
+	assert.Empty(t, m.quotas)
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/cache"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	resultCacheHits          = stats.NewCounter("ResultCacheHits", "Queries answered from the vtgate result cache")
+	resultCacheMisses        = stats.NewCounter("ResultCacheMisses", "Cacheable queries which were not found in the vtgate result cache")
+	resultCacheInvalidations = stats.NewCountersWithSingleLabel("ResultCacheInvalidations", "Invalidations of the vtgate result cache by table", "Table")
+
+	resultCacheStatsOnce sync.Once
+)
+
+var (
+	// resultCacheRetryDelay is how long the result cache waits before
+	// restarting the invalidation stream of a keyspace after it failed.
+	resultCacheRetryDelay = 5 * time.Second
+
+	// resultCacheStreamStartDelay is how long an invalidation stream must
+	// run without error before the results of its tables are cached. The
+	// stream starts at the current position and only sends events when the
+	// tables change, so it is considered running after this delay even if
+	// no event was received.
+	resultCacheStreamStartDelay = 1 * time.Second
+)
+
+// resultCacheStreamer is the part of vstreamManager used by the result
+// cache to follow the row events of the cached tables.
+type resultCacheStreamer interface {
+	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, send func(events []*binlogdatapb.VEvent) error) error
+}
+
+// resultCache keeps the results of the queries whose plan allows it.
+// The entries are invalidated by the row events of the tables they read,
+// which are streamed from the masters of their keyspace. Each table has
+// a generation which is bumped on every invalidation, and an entry is only
+// used while the generations of its tables are unchanged and its ttl did
+// not expire. Results are only stored while the invalidation stream of
+// their keyspaces is running.
+type resultCache struct {
+	ctx      context.Context
+	streamer resultCacheStreamer
+	ttl      time.Duration
+	entries  *cache.LRUCache
+
+	mu          sync.Mutex
+	generations map[string]int64
+	keyspaces   map[string]*resultCacheKeyspace
+}
+
+// resultCacheKeyspace is the invalidation stream of a keyspace.
+type resultCacheKeyspace struct {
+	tables    map[string]bool
+	streaming bool
+	cancel    context.CancelFunc
+}
+
+// cachedResult is an entry of the result cache.
+type cachedResult struct {
+	result      *sqltypes.Result
+	tables      []string
+	generations []int64
+	expires     time.Time
+	size        int
+}
+
+// Size is part of the cache.Value interface.
+func (cr *cachedResult) Size() int {
+	return cr.size
+}
+
+// newResultCache creates a result cache which holds up to size bytes of
+// results for at most ttl. The invalidation streams run until ctx is done.
+func newResultCache(ctx context.Context, streamer resultCacheStreamer, size int64, ttl time.Duration) *resultCache {
+	return &resultCache{
+		ctx:         ctx,
+		streamer:    streamer,
+		ttl:         ttl,
+		entries:     cache.NewLRUCache(size),
+		generations: make(map[string]int64),
+		keyspaces:   make(map[string]*resultCacheKeyspace),
+	}
+}
+
+// registerStats exports the size of the result cache. Only the first
+// result cache of the process is exported.
+func (rc *resultCache) registerStats() {
+	resultCacheStatsOnce.Do(func() {
+		stats.NewGaugeFunc("ResultCacheSize", "Bytes held by the vtgate result cache", rc.entries.Size)
+		stats.NewGaugeFunc("ResultCacheLength", "Entries held by the vtgate result cache", rc.entries.Length)
+		stats.NewGaugeFunc("ResultCacheCapacity", "Capacity of the vtgate result cache in bytes", rc.entries.Capacity)
+		stats.NewGaugeFunc("ResultCacheEvictions", "Entries evicted from the vtgate result cache", rc.entries.Evictions)
+	})
+}
+
+// execute returns the result of plan from the cache, or executes it and
+// caches its result. The results are not shared between callers, since
+// they may not be allowed to read the same tables.
+func (rc *resultCache) execute(prefix string, plan *engine.Plan, vcursor engine.VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	key := resultCacheKey(prefix, callerid.ImmediateCallerIDFromContext(vcursor.Context()), plan.Original, bindVars)
+	if qr, ok := rc.get(key); ok {
+		resultCacheHits.Add(1)
+		return qr, nil
+	}
+	resultCacheMisses.Add(1)
+
+	// The generations are read before the query is sent, so that a row
+	// event received while it executes prevents its result from being used.
+	generations, ok := rc.watch(plan.ResultCacheTables)
+	qr, err := plan.Instructions.Execute(vcursor, bindVars, true)
+	if err != nil || !ok {
+		return qr, err
+	}
+	rc.set(key, plan.ResultCacheTables, generations, qr)
+	return qr, nil
+}
+
+// get returns a copy of the result cached under key, if it is still valid.
+func (rc *resultCache) get(key string) (*sqltypes.Result, bool) {
+	v, ok := rc.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	cr := v.(*cachedResult)
+	if time.Now().After(cr.expires) || !rc.current(cr.tables, cr.generations) {
+		rc.entries.Delete(key)
+		return nil, false
+	}
+	return cr.result.Copy(), true
+}
+
+// set caches a copy of qr under key if the tables did not change since
+// their generations were read.
+func (rc *resultCache) set(key string, tables []string, generations []int64, qr *sqltypes.Result) {
+	if !rc.current(tables, generations) {
+		return
+	}
+	rc.entries.Set(key, &cachedResult{
+		result:      qr.Copy(),
+		tables:      tables,
+		generations: generations,
+		expires:     time.Now().Add(rc.ttl),
+		size:        len(key) + resultSize(qr),
+	})
+}
+
+// current returns true if the generations of the tables are unchanged.
+func (rc *resultCache) current(tables []string, generations []int64) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for i, table := range tables {
+		if rc.generations[table] != generations[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// watch makes sure the row events of the tables are streamed, and returns
+// their current generations. It returns false if the result of a query
+// reading the tables cannot be cached yet, because their invalidation
+// streams are not running.
+func (rc *resultCache) watch(tables []string) ([]int64, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	ok := true
+	generations := make([]int64, len(tables))
+	for i, table := range tables {
+		generations[i] = rc.generations[table]
+		keyspace, name := splitResultCacheTable(table)
+		ks := rc.keyspaces[keyspace]
+		if ks == nil {
+			ks = &resultCacheKeyspace{tables: make(map[string]bool)}
+			rc.keyspaces[keyspace] = ks
+		}
+		if !ks.tables[name] {
+			// The stream must be restarted to include the new table.
+			ks.tables[name] = true
+			rc.startStreamLocked(keyspace, ks)
+		}
+		if !ks.streaming {
+			ok = false
+		}
+	}
+	return generations, ok
+}
+
+// startStreamLocked (re)starts the invalidation stream of a keyspace.
+// rc.mu must be held.
+func (rc *resultCache) startStreamLocked(keyspace string, ks *resultCacheKeyspace) {
+	if ks.cancel != nil {
+		ks.cancel()
+	}
+	ks.streaming = false
+	rc.invalidateKeyspaceLocked(keyspace, ks)
+
+	filter := &binlogdatapb.Filter{}
+	for name := range ks.tables {
+		filter.Rules = append(filter.Rules, &binlogdatapb.Rule{Match: name})
+	}
+	sort.Slice(filter.Rules, func(i, j int) bool { return filter.Rules[i].Match < filter.Rules[j].Match })
+
+	ctx, cancel := context.WithCancel(rc.ctx)
+	ks.cancel = cancel
+	go rc.stream(ctx, keyspace, ks, filter)
+}
+
+// stream follows the row events of a keyspace until ctx is done.
+// While it is not connected, no result is cached for its tables.
+func (rc *resultCache) stream(ctx context.Context, keyspace string, ks *resultCacheKeyspace, filter *binlogdatapb.Filter) {
+	for {
+		vgtid := &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: keyspace, Gtid: "current"}},
+		}
+		attemptCtx, attemptCancel := context.WithCancel(ctx)
+		started := time.AfterFunc(resultCacheStreamStartDelay, func() {
+			rc.mu.Lock()
+			defer rc.mu.Unlock()
+			if attemptCtx.Err() == nil {
+				ks.streaming = true
+			}
+		})
+		err := rc.streamer.VStream(attemptCtx, topodatapb.TabletType_MASTER, vgtid, filter, func(events []*binlogdatapb.VEvent) error {
+			return rc.processEvents(attemptCtx, keyspace, ks, events)
+		})
+		started.Stop()
+		attemptCancel()
+
+		rc.mu.Lock()
+		if ctx.Err() != nil {
+			rc.mu.Unlock()
+			return
+		}
+		ks.streaming = false
+		rc.invalidateKeyspaceLocked(keyspace, ks)
+		rc.mu.Unlock()
+		log.Warningf("result cache: invalidation stream of keyspace %s stopped: %v, retrying in %v", keyspace, err, resultCacheRetryDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resultCacheRetryDelay):
+		}
+	}
+}
+
+// processEvents invalidates the tables changed by the events. The stream
+// is considered running once it sends events.
+func (rc *resultCache) processEvents(ctx context.Context, keyspace string, ks *resultCacheKeyspace, events []*binlogdatapb.VEvent) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	ks.streaming = true
+	for _, event := range events {
+		switch event.Type {
+		case binlogdatapb.VEventType_ROW:
+			rc.invalidateLocked(resultCacheTableName(keyspace, event.RowEvent.TableName))
+		case binlogdatapb.VEventType_DDL:
+			rc.invalidateKeyspaceLocked(keyspace, ks)
+		}
+	}
+	return nil
+}
+
+// invalidateKeyspaceLocked invalidates all the cached tables of a keyspace.
+// rc.mu must be held.
+func (rc *resultCache) invalidateKeyspaceLocked(keyspace string, ks *resultCacheKeyspace) {
+	for name := range ks.tables {
+		rc.invalidateLocked(keyspace + "." + name)
+	}
+}
+
+// invalidateLocked bumps the generation of a table. rc.mu must be held.
+func (rc *resultCache) invalidateLocked(table string) {
+	rc.generations[table]++
+	resultCacheInvalidations.Add(table, 1)
+}
+
+// resultCacheTableName returns the keyspace.table name of a row event,
+// whose table name may already be qualified by the vstream manager.
+func resultCacheTableName(keyspace, tableName string) string {
+	if strings.Contains(tableName, ".") {
+		return tableName
+	}
+	return keyspace + "." + tableName
+}
+
+func splitResultCacheTable(table string) (keyspace, name string) {
+	i := strings.Index(table, ".")
+	return table[:i], table[i+1:]
+}
+
+// skip returns true if the reads of a session must not use the cache,
+// because the session changed rows recently. The cached results which
+// were read before its write expire within ttl, even if the invalidation
+// stream is behind, so the session reads its own writes.
+func (rc *resultCache) skip(safeSession *SafeSession) bool {
+	return safeSession.InTransaction() || safeSession.WroteWithin(rc.ttl)
+}
+
+// resultCacheKey returns the cache key of a query, made of the plan prefix,
+// the normalized query, the bind variables in name order, and the user
+// name and groups of the immediate caller.
+func resultCacheKey(prefix string, caller *querypb.VTGateCallerID, query string, bindVars map[string]*querypb.BindVariable) string {
+	names := make([]string, 0, len(bindVars))
+	for name := range bindVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteString(":")
+	b.WriteString(query)
+	for _, name := range names {
+		b.WriteString("\x00")
+		b.WriteString(name)
+		b.WriteString("=")
+		b.WriteString(proto.CompactTextString(bindVars[name]))
+	}
+	b.WriteString("\x01")
+	b.WriteString(caller.GetUsername())
+	for _, group := range caller.GetGroups() {
+		b.WriteString("\x00")
+		b.WriteString(group)
+	}
+	return b.String()
+}
+
+// splitResultCacheKey returns the plan prefix, the query and the bind
+// variables of a key built by resultCacheKey.
+func splitResultCacheKey(key string) (prefix, query, bindVars string) {
+	if i := strings.LastIndex(key, "\x01"); i >= 0 {
+		key = key[:i]
+	}
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) != 2 {
+		return "", key, ""
+	}
+	prefix, query = parts[0], parts[1]
+	if i := strings.Index(query, "\x00"); i >= 0 {
+		query, bindVars = query[:i], strings.Replace(query[i:], "\x00", " ", -1)
+	}
+	return prefix, query, bindVars
+}
+
+// resultSize estimates the memory held by a result.
+func resultSize(qr *sqltypes.Result) int {
+	size := 0
+	for _, field := range qr.Fields {
+		size += 64 + len(field.Name) + len(field.Table) + len(field.Database)
+	}
+	for _, row := range qr.Rows {
+		for _, v := range row {
+			size += 24 + v.Len()
+		}
+	}
+	return size
+}
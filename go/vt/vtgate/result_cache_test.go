@@ -0,0 +1,279 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// fakeResultCacheStreamer sends the events written to its channel, and
+// fails the stream when an error is written to errs.
+type fakeResultCacheStreamer struct {
+	events chan []*binlogdatapb.VEvent
+	errs   chan error
+
+	mu      sync.Mutex
+	filters []*binlogdatapb.Filter
+}
+
+func newFakeResultCacheStreamer() *fakeResultCacheStreamer {
+	return &fakeResultCacheStreamer{
+		events: make(chan []*binlogdatapb.VEvent),
+		errs:   make(chan error),
+	}
+}
+
+func (fs *fakeResultCacheStreamer) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, send func(events []*binlogdatapb.VEvent) error) error {
+	fs.mu.Lock()
+	fs.filters = append(fs.filters, filter)
+	fs.mu.Unlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-fs.errs:
+			return err
+		case events := <-fs.events:
+			if err := send(events); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (fs *fakeResultCacheStreamer) lastFilter() *binlogdatapb.Filter {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if len(fs.filters) == 0 {
+		return nil
+	}
+	return fs.filters[len(fs.filters)-1]
+}
+
+func rowEvent(table string) []*binlogdatapb.VEvent {
+	return []*binlogdatapb.VEvent{{
+		Type:     binlogdatapb.VEventType_ROW,
+		RowEvent: &binlogdatapb.RowEvent{TableName: table},
+	}, {
+		Type: binlogdatapb.VEventType_COMMIT,
+	}}
+}
+
+func setResultCacheDelays() func() {
+	savedStart, savedRetry := resultCacheStreamStartDelay, resultCacheRetryDelay
+	resultCacheStreamStartDelay = 10 * time.Millisecond
+	resultCacheRetryDelay = 10 * time.Millisecond
+	return func() {
+		resultCacheStreamStartDelay, resultCacheRetryDelay = savedStart, savedRetry
+	}
+}
+
+// resultCacheExec executes sql in an autocommit session, outside of
+// which nothing is cached.
+func resultCacheExec(executor *Executor, sql string) (*sqltypes.Result, error) {
+	session := NewSafeSession(&vtgatepb.Session{TargetString: "@master", Autocommit: true})
+	return executor.Execute(context.Background(), "TestResultCache", session, sql, nil)
+}
+
+// waitForInvalidations waits until table was invalidated more than
+// previous times.
+func waitForInvalidations(t *testing.T, table string, previous int64) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if resultCacheInvalidations.Counts()[table] > previous {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s was never invalidated", table)
+}
+
+// waitForCache executes sql until its result is served by the cache.
+func waitForCache(t *testing.T, executor *Executor, sql string) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		hits := resultCacheHits.Get()
+		_, err := resultCacheExec(executor, sql)
+		require.NoError(t, err)
+		if resultCacheHits.Get() > hits {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s was never served by the result cache", sql)
+}
+
+func TestResultCache(t *testing.T) {
+	defer setResultCacheDelays()()
+	executor, sbc1, _, _ := createLegacyExecutorEnv()
+	fs := newFakeResultCacheStreamer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	executor.resultCache = newResultCache(ctx, fs, 1024*1024, time.Minute)
+
+	sql := "select /*vt+ RESULT_CACHE */ id from user where id = 1"
+	waitForCache(t, executor, sql)
+	assert.Equal(t, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "user"}}}, fs.lastFilter())
+
+	// A hit does not reach the tablet.
+	count := sbc1.ExecCount.Get()
+	hits := resultCacheHits.Get()
+	_, err := resultCacheExec(executor, sql)
+	require.NoError(t, err)
+	assert.Equal(t, count, sbc1.ExecCount.Get())
+	assert.Equal(t, hits+1, resultCacheHits.Get())
+
+	// Other bind variables are another entry.
+	_, err = resultCacheExec(executor, "select /*vt+ RESULT_CACHE */ id from user where id = 2")
+	require.NoError(t, err)
+	assert.Equal(t, hits+1, resultCacheHits.Get())
+
+	// A row event of the table invalidates the entry.
+	invalidations := resultCacheInvalidations.Counts()["TestExecutor.user"]
+	fs.events <- rowEvent("TestExecutor.user")
+	waitForInvalidations(t, "TestExecutor.user", invalidations)
+	count = sbc1.ExecCount.Get()
+	_, err = resultCacheExec(executor, sql)
+	require.NoError(t, err)
+	assert.Equal(t, count+1, sbc1.ExecCount.Get())
+	waitForCache(t, executor, sql)
+
+	// Queries without the directive are not cached.
+	count = sbc1.ExecCount.Get()
+	for i := 0; i < 2; i++ {
+		_, err = resultCacheExec(executor, "select id from user where id = 1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, count+2, sbc1.ExecCount.Get())
+
+	// Nothing is cached within a transaction, which is implicitly
+	// started when autocommit is off.
+	session := NewSafeSession(&vtgatepb.Session{TargetString: "@master"})
+	count = sbc1.ExecCount.Get()
+	_, err = executor.Execute(context.Background(), "TestResultCache", session, sql, nil)
+	require.NoError(t, err)
+	assert.Equal(t, count+1, sbc1.ExecCount.Get())
+
+	// Another caller does not share the entry.
+	callerCtx := callerid.NewContext(context.Background(), nil, &querypb.VTGateCallerID{Username: "other"})
+	session = NewSafeSession(&vtgatepb.Session{TargetString: "@master", Autocommit: true})
+	count = sbc1.ExecCount.Get()
+	_, err = executor.Execute(callerCtx, "TestResultCache", session, sql, nil)
+	require.NoError(t, err)
+	assert.Equal(t, count+1, sbc1.ExecCount.Get())
+
+	// A session which changed rows reads them, and not the cached result.
+	session = NewSafeSession(&vtgatepb.Session{TargetString: "@master", Autocommit: true})
+	_, err = executor.Execute(context.Background(), "TestResultCache", session, "update user set a = 2 where id = 1", nil)
+	require.NoError(t, err)
+	count = sbc1.ExecCount.Get()
+	_, err = executor.Execute(context.Background(), "TestResultCache", session, sql, nil)
+	require.NoError(t, err)
+	assert.Equal(t, count+1, sbc1.ExecCount.Get())
+
+	// So does a session which committed a transaction that changed rows.
+	session = NewSafeSession(&vtgatepb.Session{TargetString: "@master"})
+	_, err = executor.Execute(context.Background(), "TestResultCache", session, "update user set a = 2 where id = 1", nil)
+	require.NoError(t, err)
+	_, err = executor.Execute(context.Background(), "TestResultCache", session, "commit", nil)
+	require.NoError(t, err)
+	session.Autocommit = true
+	count = sbc1.ExecCount.Get()
+	_, err = executor.Execute(context.Background(), "TestResultCache", session, sql, nil)
+	require.NoError(t, err)
+	assert.Equal(t, count+1, sbc1.ExecCount.Get())
+}
+
+func TestResultCacheStreamError(t *testing.T) {
+	defer setResultCacheDelays()()
+	executor, sbc1, _, _ := createLegacyExecutorEnv()
+	fs := newFakeResultCacheStreamer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	executor.resultCache = newResultCache(ctx, fs, 1024*1024, time.Minute)
+
+	sql := "select /*vt+ RESULT_CACHE */ id from user where id = 1"
+	waitForCache(t, executor, sql)
+
+	// The entries are dropped when the stream fails, and the results are
+	// cached again once it is restarted.
+	invalidations := resultCacheInvalidations.Counts()["TestExecutor.user"]
+	fs.errs <- errors.New("stream failed")
+	waitForInvalidations(t, "TestExecutor.user", invalidations)
+	count := sbc1.ExecCount.Get()
+	_, err := resultCacheExec(executor, sql)
+	require.NoError(t, err)
+	assert.Equal(t, count+1, sbc1.ExecCount.Get())
+	waitForCache(t, executor, sql)
+}
+
+func TestResultCacheTTL(t *testing.T) {
+	rc := newResultCache(context.Background(), newFakeResultCacheStreamer(), 1024*1024, time.Millisecond)
+	qr := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	rc.set("key", []string{"ks.t"}, []int64{0}, qr)
+	got, ok := rc.get("key")
+	require.True(t, ok)
+	assert.Equal(t, qr, got)
+
+	time.Sleep(5 * time.Millisecond)
+	_, ok = rc.get("key")
+	assert.False(t, ok)
+	assert.EqualValues(t, 0, rc.entries.Length())
+}
+
+func TestResultCacheKey(t *testing.T) {
+	bv1 := map[string]*querypb.BindVariable{
+		"a": sqltypes.Int64BindVariable(1),
+		"b": sqltypes.StringBindVariable("x"),
+	}
+	bv2 := map[string]*querypb.BindVariable{
+		"b": sqltypes.StringBindVariable("x"),
+		"a": sqltypes.Int64BindVariable(1),
+	}
+	bv3 := map[string]*querypb.BindVariable{
+		"a": sqltypes.Int64BindVariable(2),
+		"b": sqltypes.StringBindVariable("x"),
+	}
+	caller1 := &querypb.VTGateCallerID{Username: "u1", Groups: []string{"g1"}}
+	caller2 := &querypb.VTGateCallerID{Username: "u1", Groups: []string{"g2"}}
+	assert.Equal(t, resultCacheKey("ks@master", caller1, "select 1", bv1), resultCacheKey("ks@master", caller1, "select 1", bv2))
+	assert.NotEqual(t, resultCacheKey("ks@master", caller1, "select 1", bv1), resultCacheKey("ks@master", caller1, "select 1", bv3))
+	assert.NotEqual(t, resultCacheKey("ks@master", caller1, "select 1", bv1), resultCacheKey("ks@replica", caller1, "select 1", bv1))
+	assert.NotEqual(t, resultCacheKey("ks@master", caller1, "select 1", bv1), resultCacheKey("ks@master", caller2, "select 1", bv1))
+	assert.NotEqual(t, resultCacheKey("ks@master", caller1, "select 1", bv1), resultCacheKey("ks@master", nil, "select 1", bv1))
+
+	// The caller is not shown.
+	prefix, query, bindVars := splitResultCacheKey(resultCacheKey("ks@master", caller1, "select 1", bv1))
+	assert.Equal(t, "ks@master", prefix)
+	assert.Equal(t, "select 1", query)
+	assert.Equal(t, ` a=type:INT64 value:"1"  b=type:VARBINARY value:"x" `, bindVars)
+}
@@ -0,0 +1,412 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// rowPolicies are the row policies of the VSchema which apply to the
+// caller of a query. The planner adds their predicates to the queries
+// which read, update or delete rows of their tables, and verifies the
+// rows written against them.
+type rowPolicies struct {
+	// tables maps the keyspace and name of a table to the policies
+	// which apply to the caller.
+	tables map[string][]*vindexes.RowPolicy
+	// key identifies the policies in the plan cache.
+	key string
+	// attributes are the values of the user attributes used by the
+	// policies. A missing attribute is NULL.
+	attributes map[string]*querypb.BindVariable
+}
+
+// newRowPolicies returns the row policies which apply to the caller, or
+// nil if there are none.
+func newRowPolicies(ctx context.Context, vschema *vindexes.VSchema) *rowPolicies {
+	tables := vschema.RowPolicyTables()
+	if len(tables) == 0 {
+		return nil
+	}
+	caller := callerid.ImmediateCallerIDFromContext(ctx)
+	var rp *rowPolicies
+	var key strings.Builder
+	for _, t := range tables {
+		for _, policy := range t.RowPolicies {
+			if !policy.AppliesTo(caller.GetUsername(), caller.GetGroups()) {
+				continue
+			}
+			if rp == nil {
+				rp = &rowPolicies{
+					tables:     make(map[string][]*vindexes.RowPolicy),
+					attributes: make(map[string]*querypb.BindVariable),
+				}
+			}
+			name := rowPolicyTableKey(t)
+			rp.tables[name] = append(rp.tables[name], policy)
+			key.WriteString(name + "." + policy.Name + ",")
+			for _, attr := range policy.Attributes {
+				bv := sqltypes.NullBindVariable
+				if v, ok := caller.GetAttributes()[attr]; ok {
+					bv = sqltypes.StringBindVariable(v)
+				}
+				rp.attributes[vindexes.UserAttributePrefix+attr] = bv
+			}
+		}
+	}
+	if rp != nil {
+		rp.key = "RowPolicies(" + strings.TrimSuffix(key.String(), ",") + ")"
+	}
+	return rp
+}
+
+func rowPolicyTableKey(t *vindexes.Table) string {
+	return t.Keyspace.Name + "." + t.Name.String()
+}
+
+// planKey returns the part of the key of the plans which identifies the
+// policies applied.
+func (rp *rowPolicies) planKey() string {
+	if rp == nil {
+		return ""
+	}
+	return rp.key
+}
+
+// setBindVars sets the bind variables of the user attributes.
+func (rp *rowPolicies) setBindVars(bindVars map[string]*querypb.BindVariable) {
+	if rp == nil {
+		return
+	}
+	for name, bv := range rp.attributes {
+		bindVars[name] = bv
+	}
+}
+
+// rewrite adds the predicates of the policies to the statement, and
+// returns the checks of the rows it writes.
+func (rp *rowPolicies) rewrite(stmt sqlparser.Statement, vcursor *vcursorImpl) ([]engine.RowPolicyCondition, error) {
+	if rp == nil {
+		return nil, nil
+	}
+	r := &rowPolicyRewriter{policies: rp, vcursor: vcursor}
+	err := sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		var err error
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			var preds []sqlparser.Expr
+			for _, expr := range node.From {
+				if preds, err = r.tableExpr(expr, preds); err != nil {
+					return false, err
+				}
+			}
+			for _, pred := range preds {
+				node.AddWhere(pred)
+			}
+		case *sqlparser.Update:
+			err = r.update(node)
+		case *sqlparser.Delete:
+			var preds []sqlparser.Expr
+			for _, expr := range node.TableExprs {
+				if preds, err = r.tableExpr(expr, preds); err != nil {
+					return false, err
+				}
+			}
+			node.Where = addWhere(node.Where, preds)
+		case *sqlparser.Insert:
+			err = r.insert(node)
+		}
+		return err == nil, err
+	}, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return r.checks, nil
+}
+
+type rowPolicyRewriter struct {
+	policies *rowPolicies
+	vcursor  *vcursorImpl
+	checks   []engine.RowPolicyCondition
+}
+
+// policiesOf returns the policies which apply to the table, or nil if it
+// is not a table with row policies.
+func (r *rowPolicyRewriter) policiesOf(name sqlparser.TableName) (*vindexes.Table, []*vindexes.RowPolicy) {
+	// Tables which can't be found are reported by the planner.
+	table, _, _, _, _, err := r.vcursor.FindTableOrVindex(name)
+	if err != nil || table == nil || len(table.RowPolicies) == 0 {
+		return nil, nil
+	}
+	return table, r.policies.tables[rowPolicyTableKey(table)]
+}
+
+// tableExpr appends to preds the predicates of the tables of expr which
+// must be added to the WHERE clause. The predicates of the tables on the
+// nullable side of an outer join are added to its ON condition.
+func (r *rowPolicyRewriter) tableExpr(expr sqlparser.TableExpr, preds []sqlparser.Expr) ([]sqlparser.Expr, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		name, ok := expr.Expr.(sqlparser.TableName)
+		if !ok {
+			// Derived tables are rewritten on their own.
+			return preds, nil
+		}
+		_, policies := r.policiesOf(name)
+		qualifier := name
+		if !expr.As.IsEmpty() {
+			qualifier = sqlparser.TableName{Name: expr.As}
+		}
+		for _, policy := range policies {
+			preds = append(preds, policy.Expr(qualifier))
+		}
+		return preds, nil
+	case *sqlparser.ParenTableExpr:
+		var err error
+		for _, e := range expr.Exprs {
+			if preds, err = r.tableExpr(e, preds); err != nil {
+				return nil, err
+			}
+		}
+		return preds, nil
+	case *sqlparser.JoinTableExpr:
+		left, err := r.tableExpr(expr.LeftExpr, nil)
+		if err != nil {
+			return nil, err
+		}
+		right, err := r.tableExpr(expr.RightExpr, nil)
+		if err != nil {
+			return nil, err
+		}
+		switch expr.Join {
+		case sqlparser.LeftJoinType, sqlparser.NaturalLeftJoinType:
+			if err := addJoinCondition(expr, right); err != nil {
+				return nil, err
+			}
+			return append(preds, left...), nil
+		case sqlparser.RightJoinType, sqlparser.NaturalRightJoinType:
+			if err := addJoinCondition(expr, left); err != nil {
+				return nil, err
+			}
+			return append(preds, right...), nil
+		}
+		return append(append(preds, left...), right...), nil
+	}
+	return preds, nil
+}
+
+func addJoinCondition(join *sqlparser.JoinTableExpr, preds []sqlparser.Expr) error {
+	if len(preds) == 0 {
+		return nil
+	}
+	if join.Join == sqlparser.NaturalLeftJoinType || join.Join == sqlparser.NaturalRightJoinType || len(join.Condition.Using) != 0 {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: row policies on the nullable side of an outer join without an ON condition")
+	}
+	for _, pred := range preds {
+		if join.Condition.On == nil {
+			join.Condition.On = pred
+			continue
+		}
+		join.Condition.On = &sqlparser.AndExpr{Left: join.Condition.On, Right: pred}
+	}
+	return nil
+}
+
+func addWhere(where *sqlparser.Where, preds []sqlparser.Expr) *sqlparser.Where {
+	for _, pred := range preds {
+		if where == nil {
+			where = sqlparser.NewWhere(sqlparser.WhereClause, pred)
+			continue
+		}
+		where.Expr = &sqlparser.AndExpr{Left: where.Expr, Right: pred}
+	}
+	return where
+}
+
+// update restricts the rows updated to the rows which match the
+// predicates, and checks the new values of the columns they use.
+func (r *rowPolicyRewriter) update(upd *sqlparser.Update) error {
+	var preds []sqlparser.Expr
+	var targets []updateTarget
+	for _, expr := range upd.TableExprs {
+		var err error
+		if preds, err = r.tableExpr(expr, preds); err != nil {
+			return err
+		}
+		targets = r.updateTargets(expr, targets)
+	}
+	for _, ue := range upd.Exprs {
+		var matches []updateTarget
+		for _, target := range targets {
+			if target.matches(ue.Name.Qualifier) {
+				matches = append(matches, target)
+			}
+		}
+		if len(matches) != 1 {
+			// The column can't be resolved to a single table: it is
+			// rejected if it may be a column of a table with policies.
+			for _, target := range targets {
+				for _, policy := range target.policies {
+					if policy.References(ue.Name.Name) {
+						return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: can't resolve the table of column %s updated in a statement on table %s which has row policies", sqlparser.String(ue.Name), target.table.Name)
+					}
+				}
+			}
+			continue
+		}
+		for _, policy := range matches[0].policies {
+			if !policy.References(ue.Name.Name) {
+				continue
+			}
+			if err := r.addCheck(matches[0].table, policy, ue.Name.Name, ue.Expr); err != nil {
+				return err
+			}
+		}
+	}
+	upd.Where = addWhere(upd.Where, preds)
+	return nil
+}
+
+// updateTarget is a table of an update statement whose columns can be set.
+type updateTarget struct {
+	name     sqlparser.TableName
+	alias    sqlparser.TableIdent
+	table    *vindexes.Table
+	policies []*vindexes.RowPolicy
+}
+
+// updateTargets appends the tables of expr, which may be joined or
+// parenthesized, to targets.
+func (r *rowPolicyRewriter) updateTargets(expr sqlparser.TableExpr, targets []updateTarget) []updateTarget {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		target := updateTarget{alias: expr.As}
+		if name, ok := expr.Expr.(sqlparser.TableName); ok {
+			target.name = name
+			target.table, target.policies = r.policiesOf(name)
+		}
+		return append(targets, target)
+	case *sqlparser.ParenTableExpr:
+		for _, e := range expr.Exprs {
+			targets = r.updateTargets(e, targets)
+		}
+	case *sqlparser.JoinTableExpr:
+		targets = r.updateTargets(expr.LeftExpr, targets)
+		targets = r.updateTargets(expr.RightExpr, targets)
+	}
+	return targets
+}
+
+// matches returns true if a column with the qualifier may belong to the
+// target. An unqualified column may belong to any target.
+func (t updateTarget) matches(qualifier sqlparser.TableName) bool {
+	switch {
+	case qualifier.IsEmpty():
+		return true
+	case !t.alias.IsEmpty():
+		return qualifier.Qualifier.IsEmpty() && qualifier.Name == t.alias
+	case qualifier.Name != t.name.Name:
+		return false
+	case qualifier.Qualifier.IsEmpty() || qualifier.Qualifier == t.name.Qualifier:
+		return true
+	}
+	return t.table != nil && qualifier.Qualifier.String() == t.table.Keyspace.Name
+}
+
+// insert checks that the rows inserted match the predicates.
+func (r *rowPolicyRewriter) insert(ins *sqlparser.Insert) error {
+	table, policies := r.policiesOf(ins.Table)
+	if len(policies) == 0 {
+		return nil
+	}
+	switch {
+	case ins.Action == sqlparser.ReplaceAct:
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: replace into table %s which has row policies", table.Name)
+	case len(ins.OnDup) != 0:
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: on duplicate key update of table %s which has row policies", table.Name)
+	case len(ins.Columns) == 0:
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: insert into table %s which has row policies without a column list", table.Name)
+	}
+	rows, ok := ins.Rows.(sqlparser.Values)
+	if !ok {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported: insert with a select into table %s which has row policies", table.Name)
+	}
+	for _, policy := range policies {
+		for _, col := range ins.Columns {
+			if !policy.References(col) {
+				continue
+			}
+			for _, row := range rows {
+				if err := r.addCheck(table, policy, col, row[ins.Columns.FindColumn(col)]); err != nil {
+					return err
+				}
+			}
+		}
+		equalities, err := policy.Equalities()
+		if err != nil {
+			return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "%v", err)
+		}
+		for _, eq := range equalities {
+			if ins.Columns.FindColumn(eq.Column) < 0 {
+				return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "row policy %s of table %s requires column %s to be set", policy.Name, table.Name, eq.Column)
+			}
+		}
+	}
+	return nil
+}
+
+// addCheck adds the check of a value written to a column used by the
+// predicate of the policy.
+func (r *rowPolicyRewriter) addCheck(table *vindexes.Table, policy *vindexes.RowPolicy, col sqlparser.ColIdent, value sqlparser.Expr) error {
+	equalities, err := policy.Equalities()
+	if err != nil {
+		return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "%v", err)
+	}
+	for _, eq := range equalities {
+		if !eq.Column.Equal(col) {
+			continue
+		}
+		v, err := sqlparser.Convert(value)
+		if err != nil {
+			return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "row policy %s of table %s can't verify the value written to column %s: %s", policy.Name, table.Name, col, sqlparser.String(value))
+		}
+		expected, err := sqlparser.Convert(eq.Value)
+		if err != nil {
+			return err
+		}
+		r.checks = append(r.checks, engine.RowPolicyCondition{
+			Table:    table.Name.String(),
+			Policy:   policy.Name,
+			Column:   col.String(),
+			Value:    v,
+			Expected: expected,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// createRowPolicyExecutorEnv returns an executor whose user table may only
+// be accessed by the tenants group for their tenant, and whose simple
+// table only shows the rows which are not deleted.
+func createRowPolicyExecutorEnv(t *testing.T) (*Executor, *sandboxconn.SandboxConn, *sandboxconn.SandboxConn) {
+	executor, sbc1, _, sbclookup := createLegacyExecutorEnv()
+	sharded := &vschemapb.Keyspace{}
+	require.NoError(t, json2.Unmarshal([]byte(executorVSchema), sharded))
+	sharded.Tables["user"].RowPolicies = []*vschemapb.RowPolicy{{
+		Name:      "tenant",
+		Predicate: "tenant_id = :__vt_user_attr_tenant",
+		Roles:     []string{"tenants"},
+	}}
+	unsharded := &vschemapb.Keyspace{}
+	require.NoError(t, json2.Unmarshal([]byte(unshardedVSchema), unsharded))
+	unsharded.Tables["simple"].RowPolicies = []*vschemapb.RowPolicy{{
+		Name:      "visible",
+		Predicate: "deleted = 0",
+	}}
+	vschema, err := vindexes.BuildVSchema(&vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"TestExecutor":  sharded,
+			KsTestUnsharded: unsharded,
+		},
+	})
+	require.NoError(t, err)
+	executor.SaveVSchema(vschema, nil)
+	return executor, sbc1, sbclookup
+}
+
+func rowPolicyExec(executor *Executor, caller *querypb.VTGateCallerID, sql string) (*sqltypes.Result, error) {
+	ctx := callerid.NewContext(context.Background(), nil, caller)
+	return executor.Execute(ctx, "TestRowPolicy", NewSafeSession(&vtgatepb.Session{TargetString: "@master", Autocommit: true}), sql, nil)
+}
+
+var (
+	rowPolicyTenant = &querypb.VTGateCallerID{
+		Username:   "alice",
+		Groups:     []string{"tenants"},
+		Attributes: map[string]string{"tenant": "1"},
+	}
+	rowPolicyAdmin = &querypb.VTGateCallerID{Username: "bob"}
+)
+
+func TestRowPolicySelect(t *testing.T) {
+	executor, sbc1, sbclookup := createRowPolicyExecutorEnv(t)
+
+	tcases := []struct {
+		caller *querypb.VTGateCallerID
+		sql    string
+		want   string
+	}{{
+		caller: rowPolicyTenant,
+		sql:    "select id from user where id = 1",
+		want:   "select id from user where id = 1 and user.tenant_id = :__vt_user_attr_tenant",
+	}, {
+		caller: rowPolicyAdmin,
+		sql:    "select id from user where id = 1",
+		want:   "select id from user where id = 1",
+	}, {
+		caller: rowPolicyTenant,
+		sql:    "select u.id from user as u where u.id = 1",
+		want:   "select u.id from user as u where u.id = 1 and u.tenant_id = :__vt_user_attr_tenant",
+	}, {
+		caller: rowPolicyTenant,
+		sql:    "select id from user where id = 1 and name in (select name from user where id = 1)",
+		want:   "select id from user where id = 1 and user.tenant_id = :__vt_user_attr_tenant and name in (select name from user where id = 1 and user.tenant_id = :__vt_user_attr_tenant)",
+	}, {
+		caller: rowPolicyTenant,
+		sql:    "select u1.id from user as u1 left join user as u2 on u1.id = u2.id where u1.id = 1",
+		want:   "select u1.id from user as u1 left join user as u2 on u1.id = u2.id and u2.tenant_id = :__vt_user_attr_tenant where u1.id = 1 and u1.tenant_id = :__vt_user_attr_tenant",
+	}}
+	for _, tcase := range tcases {
+		sbc1.Queries = nil
+		_, err := rowPolicyExec(executor, tcase.caller, tcase.sql)
+		require.NoError(t, err, tcase.sql)
+		require.NotEmpty(t, sbc1.Queries, tcase.sql)
+		last := sbc1.Queries[len(sbc1.Queries)-1]
+		assert.Equal(t, tcase.want, last.Sql, tcase.sql)
+		if tcase.caller == rowPolicyTenant {
+			assert.Equal(t, sqltypes.StringBindVariable("1"), last.BindVariables["__vt_user_attr_tenant"], tcase.sql)
+		}
+	}
+
+	// A policy without roles applies to everyone.
+	_, err := rowPolicyExec(executor, rowPolicyAdmin, "select id from simple")
+	require.NoError(t, err)
+	assert.Equal(t, "select id from simple where simple.deleted = 0", sbclookup.Queries[len(sbclookup.Queries)-1].Sql)
+
+	// A tenant without the attribute sees no rows.
+	sbc1.Queries = nil
+	_, err = rowPolicyExec(executor, &querypb.VTGateCallerID{Username: "carol", Groups: []string{"tenants"}}, "select id from user where id = 1")
+	require.NoError(t, err)
+	assert.Equal(t, sqltypes.NullBindVariable, sbc1.Queries[0].BindVariables["__vt_user_attr_tenant"])
+
+	_, err = rowPolicyExec(executor, rowPolicyTenant, "select u1.id from user as u1 left join user as u2 using (id) where u1.id = 1")
+	require.EqualError(t, err, "unsupported: row policies on the nullable side of an outer join without an ON condition")
+}
+
+func TestRowPolicyWrite(t *testing.T) {
+	executor, sbc1, _ := createRowPolicyExecutorEnv(t)
+
+	sbc1.Queries = nil
+	_, err := rowPolicyExec(executor, rowPolicyTenant, "update user set a = 2 where id = 1")
+	require.NoError(t, err)
+	assert.Equal(t, "update user set a = 2 where id = 1 and user.tenant_id = :__vt_user_attr_tenant", sbc1.Queries[len(sbc1.Queries)-1].Sql)
+
+	sbc1.Queries = nil
+	_, err = rowPolicyExec(executor, rowPolicyTenant, "delete from user where id = 1")
+	require.NoError(t, err)
+	assert.Contains(t, sbc1.Queries[0].Sql, "where id = 1 and user.tenant_id = :__vt_user_attr_tenant")
+
+	_, err = rowPolicyExec(executor, rowPolicyTenant, "update user set tenant_id = 2 where id = 1")
+	require.EqualError(t, err, "row policy tenant of table user denies writing INT64(2) to column tenant_id")
+	assert.Equal(t, vtrpcpb.Code_PERMISSION_DENIED, vterrors.Code(err))
+	_, err = rowPolicyExec(executor, rowPolicyTenant, "update user set tenant_id = 1 where id = 1")
+	require.NoError(t, err)
+
+	sbc1.Queries = nil
+	_, err = rowPolicyExec(executor, rowPolicyTenant, "insert into user(id, tenant_id, name) values (1, 1, 'myname')")
+	require.NoError(t, err)
+	require.NotEmpty(t, sbc1.Queries)
+
+	tcases := []struct {
+		sql string
+		err string
+	}{{
+		sql: "insert into user(id, tenant_id, name) values (1, 2, 'myname')",
+		err: "row policy tenant of table user denies writing INT64(2) to column tenant_id",
+	}, {
+		sql: "insert into user(id, name) values (1, 'myname')",
+		err: "row policy tenant of table user requires column tenant_id to be set",
+	}, {
+		sql: "insert into user(id, tenant_id, name) values (1, id + 1, 'myname')",
+		err: "row policy tenant of table user can't verify the value written to column tenant_id: id + 1",
+	}, {
+		sql: "insert into user values (1, 1, 'myname')",
+		err: "unsupported: insert into table user which has row policies without a column list",
+	}, {
+		sql: "replace into user(id, tenant_id, name) values (1, 1, 'myname')",
+		err: "unsupported: replace into table user which has row policies",
+	}, {
+		sql: "insert into user(id, tenant_id, name) values (1, 1, 'myname') on duplicate key update tenant_id = 2",
+		err: "unsupported: on duplicate key update of table user which has row policies",
+	}}
+	for _, tcase := range tcases {
+		_, err := rowPolicyExec(executor, rowPolicyTenant, tcase.sql)
+		assert.EqualError(t, err, tcase.err, tcase.sql)
+	}
+
+	// The policy does not apply to other users.
+	_, err = rowPolicyExec(executor, rowPolicyAdmin, "insert into user(id, tenant_id, name) values (1, 2, 'myname')")
+	require.NoError(t, err)
+}
+
+func TestRowPolicyMultiTableUpdate(t *testing.T) {
+	executor, _, _ := createRowPolicyExecutorEnv(t)
+
+	tcases := []struct {
+		sql string
+		err string
+	}{{
+		sql: "update main1 u join simple s on u.id = s.id set s.deleted = 1",
+		err: "row policy visible of table simple denies writing INT64(1) to column deleted",
+	}, {
+		sql: "update (main1 u join simple on u.id = simple.id) set simple.deleted = 1",
+		err: "row policy visible of table simple denies writing INT64(1) to column deleted",
+	}, {
+		sql: "update main1 u join simple s on u.id = s.id set deleted = 1",
+		err: "unsupported: can't resolve the table of column deleted updated in a statement on table simple which has row policies",
+	}, {
+		sql: "update main1 u join simple s on u.id = s.id set x.deleted = 1",
+		err: "unsupported: can't resolve the table of column x.deleted updated in a statement on table simple which has row policies",
+	}, {
+		sql: "update main1 u join simple s on u.id = s.id set u.deleted = 1",
+	}, {
+		sql: "update main1 u join simple s on u.id = s.id set s.deleted = 0",
+	}}
+	for _, tcase := range tcases {
+		_, err := rowPolicyExec(executor, rowPolicyAdmin, tcase.sql)
+		if tcase.err == "" {
+			assert.NoError(t, err, tcase.sql)
+			continue
+		}
+		assert.EqualError(t, err, tcase.err, tcase.sql)
+	}
+}
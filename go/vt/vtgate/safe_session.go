@@ -384,6 +384,24 @@ func (session *SafeSession) UpdateLockHeartbeat() {
 	session.LastLockHeartbeat = time.Now().Unix()
 }
 
+// RecordWrite records that the session changed rows.
+func (session *SafeSession) RecordWrite() {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.LastWrite = time.Now().Unix()
+}
+
+// WroteWithin returns true if the session changed rows less than d ago.
+func (session *SafeSession) WroteWithin(d time.Duration) bool {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.LastWrite == 0 {
+		return false
+	}
+	// LastWrite is truncated to the second.
+	return time.Now().Unix()-session.LastWrite <= int64(d.Seconds())+1
+}
+
 // TriggerLockHeartBeat returns if it time to trigger next lock heartbeat
 func (session *SafeSession) TriggerLockHeartBeat() bool {
 	session.mu.Lock()
@@ -39,6 +39,7 @@ import (
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -108,6 +109,8 @@ func NewTabletGateway(ctx context.Context, hc discovery.HealthCheck, serv srvtop
 		statusAggregators: make(map[string]*TabletStatusAggregator),
 		buffer:            buffer.New(),
 	}
+	gw.buffer.SetShardServingFunc(newShardServingFunc(serv, localCell))
+
 	// subscribe to healthcheck updates so that buffer can be notified if needed
 	// we run this in a separate goroutine so that normal processing doesn't need to block
 	hcChan := hc.Subscribe()
@@ -133,6 +136,12 @@ func NewTabletGateway(ctx context.Context, hc discovery.HealthCheck, serv srvtop
 	return gw
 }
 
+// ProcessSrvVSchema forwards a new SrvVSchema to the buffer, which ends the
+// buffering of MoveTables cutovers when the routing rules changed.
+func (gw *TabletGateway) ProcessSrvVSchema(srvVSchema *vschemapb.SrvVSchema) {
+	gw.buffer.ProcessSrvVSchema(srvVSchema)
+}
+
 // QueryServiceByAlias satisfies the Gateway interface
 func (gw *TabletGateway) QueryServiceByAlias(alias *topodatapb.TabletAlias) (queryservice.QueryService, error) {
 	return gw.hc.TabletConnection(alias)
@@ -217,6 +226,12 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 		if !bufferedOnce && !inTransaction && target.TabletType == topodatapb.TabletType_MASTER {
 			// The next call blocks if we should buffer during a failover.
 			retryDone, bufferErr := gw.buffer.WaitForFailoverEnd(ctx, target.Keyspace, target.Shard, err)
+			if buffer.IsRoutingChangedError(bufferErr) {
+				// The request must be planned again. Keep the error,
+				// such that the executor can recognize it.
+				err = vterrors.Wrapf(bufferErr, "original err: %v", err)
+				break
+			}
 			if bufferErr != nil {
 				// Buffering failed e.g. buffer is already full. Do not retry.
 				err = vterrors.Errorf(
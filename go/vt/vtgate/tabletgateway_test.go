@@ -327,3 +327,13 @@ func verifyShardErrors(t *testing.T, err error, wantErrors []string, wantCode vt
 	}
 	require.Equal(t, vterrors.Code(err), wantCode, "wanted error code: %s, got: %v", wantCode, vterrors.Code(err))
 }
+
+func TestShardServingFunc(t *testing.T) {
+	keyspace := "TestShardServingFunc"
+	createSandbox(keyspace)
+	shardServing := newShardServingFunc(newSandboxForCells([]string{"cell"}), "cell")
+
+	assert.True(t, shardServing(keyspace, "-20"))
+	assert.False(t, shardServing(keyspace, "0"), "shard 0 is not part of the MASTER partition")
+	assert.True(t, shardServing("TestShardServingFuncUnknown", "0"), "a missing SrvKeyspace must not stop buffering")
+}
@@ -95,6 +95,7 @@ type vcursorImpl struct {
 	// must be forced to rollback.
 	rollbackOnPartialExec bool
 	ignoreMaxMemoryRows   bool
+	memoryBudget          *engine.MemoryBudget
 	vschema               *vindexes.VSchema
 	vm                    VSchemaOperator
 }
@@ -171,6 +172,7 @@ func newVCursorImpl(ctx context.Context, safeSession *SafeSession, marginComment
 		vschema:        vschema,
 		vm:             vm,
 		topoServer:     ts,
+		memoryBudget:   engine.NewMemoryBudget(*maxMemoryBytes, processMemoryBudget, *memorySpillDir),
 	}, nil
 }
 
@@ -190,6 +192,11 @@ func (vc *vcursorImpl) ExceedsMaxMemoryRows(numRows int) bool {
 	return !vc.ignoreMaxMemoryRows && numRows > *maxMemoryRows
 }
 
+// MemoryBudget returns the memory budget of the query.
+func (vc *vcursorImpl) MemoryBudget() *engine.MemoryBudget {
+	return vc.memoryBudget
+}
+
 // SetIgnoreMaxMemoryRows sets the ignoreMaxMemoryRows value.
 func (vc *vcursorImpl) SetIgnoreMaxMemoryRows(ignoreMaxMemoryRows bool) {
 	vc.ignoreMaxMemoryRows = ignoreMaxMemoryRows
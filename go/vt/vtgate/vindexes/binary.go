@@ -22,14 +22,21 @@ import (
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var (
 	_ SingleColumn = (*Binary)(nil)
 	_ Reversible   = (*Binary)(nil)
+	_ TypeOrdered  = (*Binary)(nil)
 )
 
 // Binary is a vindex that converts binary bits to a keyspace id.
+// It's Unique, Reversible and Ordered for binary columns: the
+// keyspace ids are compared byte by byte, while MySQL compares the
+// values of a text column with its collation.
 type Binary struct {
 	name string
 }
@@ -89,6 +96,26 @@ func (*Binary) ReverseMap(_ VCursor, ksids [][]byte) ([]sqltypes.Value, error) {
 	return reverseIds, nil
 }
 
+// MapRange maps the ids from start to end to the KeyRange of their
+// keyspace ids. The range ends right after end, at end followed by
+// a zero byte.
+func (*Binary) MapRange(_ VCursor, start, end sqltypes.Value) (key.Destination, error) {
+	kr := &topodatapb.KeyRange{}
+	if !start.IsNull() && len(start.ToBytes()) > 0 {
+		kr.Start = start.ToBytes()
+	}
+	if !end.IsNull() {
+		kr.End = append(append([]byte(nil), end.ToBytes()...), 0)
+	}
+	return key.DestinationKeyRange{KeyRange: kr}, nil
+}
+
+// OrdersType returns true for the BINARY, VARBINARY and BLOB column
+// types, which compare their values byte by byte.
+func (*Binary) OrdersType(typ querypb.Type) bool {
+	return sqltypes.IsBinary(typ)
+}
+
 func init() {
 	Register("binary", NewBinary)
 }
@@ -25,6 +25,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var binOnlyVindex SingleColumn
@@ -92,3 +94,30 @@ func TestBinaryReverseMap(t *testing.T) {
 		t.Errorf("ReverseMap(): %v, want %s", err, wantErr)
 	}
 }
+
+func TestBinaryMapRange(t *testing.T) {
+	got, err := binOnlyVindex.(Ordered).MapRange(nil, sqltypes.NewVarBinary("\x10"), sqltypes.NewVarBinary("\x20\xff"))
+	require.NoError(t, err)
+	want := key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x10"), End: []byte("\x20\xff\x00")}}
+	assert.Equal(t, want, got)
+
+	got, err = binOnlyVindex.(Ordered).MapRange(nil, sqltypes.NULL, sqltypes.NewVarBinary("\x20"))
+	require.NoError(t, err)
+	want = key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{End: []byte("\x20\x00")}}
+	assert.Equal(t, want, got)
+
+	// The ids of the range are in its key range.
+	kr := want.KeyRange
+	for _, id := range []string{"\x00", "\x1f\xff", "\x20"} {
+		assert.True(t, key.KeyRangeContains(kr, []byte(id)), id)
+	}
+	assert.False(t, key.KeyRangeContains(kr, []byte("\x20\x00")))
+}
+
+func TestBinaryOrdersType(t *testing.T) {
+	ordered := binOnlyVindex.(TypeOrdered)
+	assert.True(t, ordered.OrdersType(sqltypes.VarBinary))
+	assert.True(t, ordered.OrdersType(sqltypes.Blob))
+	assert.False(t, ordered.OrdersType(sqltypes.VarChar))
+	assert.False(t, ordered.OrdersType(sqltypes.Null))
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	_ SingleColumn = (*ConsistentHash)(nil)
+)
+
+// consistentHashDefaultVNodes is the number of virtual nodes of the
+// ring if the vindex doesn't set one.
+const consistentHashDefaultVNodes = 64
+
+// ConsistentHash hashes any sql type with xxhash64 onto a ring of
+// virtual nodes, each of which owns an equal arc of the ring. Every
+// virtual node is assigned a key range in the vindex params, and the
+// ids of its arc are spread evenly over its share of that key range.
+// The key ranges can be of any size, so the keyspace can be split
+// unevenly by grouping virtual nodes into them.
+//
+// Some ids can also be pinned to a keyspace id in a key range that is
+// reserved for them, which the virtual nodes never use. A heavy
+// tenant can then be given its own shard by a Reshard on its keyspace
+// id, while all the other ids keep their keyspace ids. The reserved
+// key range must be set when the vindex is created: reserving it
+// later takes it from the virtual nodes, which moves their ids.
+//
+// Pinning an id changes its keyspace id, so it's meant for ids that
+// don't have rows yet. The rows of an id that is pinned later stay
+// where its hash put them, and must be migrated: either backfilled,
+// by reading them before the override is added, and deleting them
+// and inserting them again through VTGate after, or moved with a
+// MoveTables to a keyspace of their own beforehand.
+//
+// It's Unique.
+type ConsistentHash struct {
+	name string
+	// arc is the width of the arc of each virtual node minus one,
+	// which fits the full ring of a single virtual node.
+	arc    uint64
+	vnodes []consistentHashVNode
+	// overrides maps the pinned ids to their keyspace ids, which
+	// are in the reserved key range.
+	overrides map[string][]byte
+}
+
+// consistentHashVNode is the share of a key range assigned to a
+// virtual node, from start to start+span included.
+type consistentHashVNode struct {
+	start, span uint64
+}
+
+// NewConsistentHash creates a ConsistentHash vindex. The optional
+// params are:
+//
+// "vnodes", the number of virtual nodes of the ring, which defaults
+// to 64.
+//
+// "reserved", a key range like "f0-" that is kept for the pinned ids.
+//
+// "keyranges", the key range of each virtual node, as a comma
+// separated list of assignments like "0-31:-80,32-63:80-". The
+// virtual nodes of an assignment share its key range in order.
+// Every virtual node must be assigned once, and the key ranges
+// can't overlap each other or the reserved key range. All the
+// virtual nodes share the full key range by default, or the rest of
+// it if the reserved key range is at its start or end.
+//
+// "overrides", the pinned ids, as a comma separated list of
+// assignments of an id to a hex keyspace id like "42:f0". The
+// keyspace ids must be in the reserved key range.
+func NewConsistentHash(name string, m map[string]string) (Vindex, error) {
+	count := consistentHashDefaultVNodes
+	if v, ok := m["vnodes"]; ok {
+		var err error
+		count, err = strconv.Atoi(v)
+		if err != nil || count < 1 || count > math.MaxUint16+1 {
+			return nil, fmt.Errorf("consistent_hash vnodes must be a number between 1 and %d: %v", math.MaxUint16+1, v)
+		}
+	}
+	var reserved *topodatapb.KeyRange
+	spec := fmt.Sprintf("0-%d:-", count-1)
+	if v, ok := m["reserved"]; ok {
+		var err error
+		_, _, reserved, err = parseVNodeKeyRange(v)
+		if err != nil {
+			return nil, fmt.Errorf("consistent_hash reserved: %v", err)
+		}
+		switch {
+		case len(reserved.Start) == 0 && len(reserved.End) == 0:
+			return nil, fmt.Errorf("consistent_hash reserved key range can't be the full key range")
+		case len(reserved.Start) == 0:
+			spec = fmt.Sprintf("0-%d:%s-", count-1, hex.EncodeToString(reserved.End))
+		case len(reserved.End) == 0:
+			spec = fmt.Sprintf("0-%d:-%s", count-1, hex.EncodeToString(reserved.Start))
+		default:
+			// The virtual nodes can't share two key ranges by default.
+			if _, ok := m["keyranges"]; !ok {
+				return nil, fmt.Errorf("consistent_hash keyranges must be set for the reserved key range %v", v)
+			}
+		}
+	}
+	if v, ok := m["keyranges"]; ok {
+		spec = v
+	}
+	// The ring is divided in arcs of math.MaxUint64/count+1 hashes,
+	// the last one of which can be shorter.
+	vind := &ConsistentHash{
+		name:      name,
+		arc:       math.MaxUint64 / uint64(count),
+		vnodes:    make([]consistentHashVNode, count),
+		overrides: make(map[string][]byte),
+	}
+	keyRanges, err := vind.assignKeyRanges(spec)
+	if err != nil {
+		return nil, err
+	}
+	if reserved != nil {
+		for _, kr := range keyRanges {
+			if key.KeyRangesIntersect(kr, reserved) {
+				return nil, fmt.Errorf("consistent_hash key range %v of the vnodes overlaps the reserved key range %v", key.KeyRangeString(kr), key.KeyRangeString(reserved))
+			}
+		}
+	}
+	if v, ok := m["overrides"]; ok && v != "" {
+		if reserved == nil {
+			return nil, fmt.Errorf("consistent_hash overrides require a reserved key range")
+		}
+		for _, assignment := range strings.Split(v, ",") {
+			parts := strings.Split(strings.TrimSpace(assignment), ":")
+			if len(parts) != 2 || parts[0] == "" {
+				return nil, fmt.Errorf("consistent_hash override must be an id and a keyspace id: %v", assignment)
+			}
+			ksid, err := hex.DecodeString(parts[1])
+			if err != nil || len(ksid) == 0 {
+				return nil, fmt.Errorf("consistent_hash override keyspace id must be hex: %v", assignment)
+			}
+			if !key.KeyRangeContains(reserved, ksid) {
+				return nil, fmt.Errorf("consistent_hash override keyspace id is not in the reserved key range %v: %v", key.KeyRangeString(reserved), assignment)
+			}
+			if _, ok := vind.overrides[parts[0]]; ok {
+				return nil, fmt.Errorf("consistent_hash override is duplicated: %v", parts[0])
+			}
+			vind.overrides[parts[0]] = ksid
+		}
+	}
+	return vind, nil
+}
+
+// assignKeyRanges assigns the key ranges of the spec to the virtual
+// nodes, and returns them.
+func (vind *ConsistentHash) assignKeyRanges(spec string) ([]*topodatapb.KeyRange, error) {
+	assigned := make([]bool, len(vind.vnodes))
+	var keyRanges []*topodatapb.KeyRange
+	for _, assignment := range strings.Split(spec, ",") {
+		parts := strings.Split(strings.TrimSpace(assignment), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("consistent_hash keyranges must assign vnodes to a key range: %v", assignment)
+		}
+		first, last, err := parseVNodes(parts[0], len(vind.vnodes))
+		if err != nil {
+			return nil, fmt.Errorf("consistent_hash keyranges %v: %v", assignment, err)
+		}
+		start, end, kr, err := parseVNodeKeyRange(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("consistent_hash keyranges %v: %v", assignment, err)
+		}
+		keyRanges = append(keyRanges, kr)
+
+		// The key range from start to end, which is 2^64 for an
+		// open end, is split between the virtual nodes in order.
+		total := end - start - 1
+		count := uint64(last - first + 1)
+		if total < count-1 {
+			return nil, fmt.Errorf("consistent_hash keyranges %v: key range is too small for %d vnodes", assignment, count)
+		}
+		split := func(i uint64) uint64 {
+			hi, lo := bits.Mul64(i, total)
+			lo, carry := bits.Add64(lo, i, 0)
+			q, _ := bits.Div64(hi+carry, lo, count)
+			return start + q
+		}
+		for i := first; i <= last; i++ {
+			if assigned[i] {
+				return nil, fmt.Errorf("consistent_hash vnode %d is assigned twice", i)
+			}
+			assigned[i] = true
+			n := uint64(i - first)
+			vnodeEnd := start + total
+			if n+1 < count {
+				vnodeEnd = split(n+1) - 1
+			}
+			vind.vnodes[i] = consistentHashVNode{start: split(n), span: vnodeEnd - split(n)}
+		}
+	}
+	for i, ok := range assigned {
+		if !ok {
+			return nil, fmt.Errorf("consistent_hash vnode %d is not assigned a key range", i)
+		}
+	}
+	sort.Slice(keyRanges, func(i, j int) bool {
+		return bytes.Compare(keyRanges[i].Start, keyRanges[j].Start) < 0
+	})
+	for i := 1; i < len(keyRanges); i++ {
+		if key.KeyRangesIntersect(keyRanges[i-1], keyRanges[i]) {
+			return nil, fmt.Errorf("consistent_hash key ranges overlap: %v and %v", key.KeyRangeString(keyRanges[i-1]), key.KeyRangeString(keyRanges[i]))
+		}
+	}
+	return keyRanges, nil
+}
+
+// parseVNodes parses a virtual node or a range of virtual nodes.
+func parseVNodes(s string, count int) (int, int, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) > 2 {
+		return 0, 0, fmt.Errorf("invalid vnodes: %v", s)
+	}
+	first, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid vnodes: %v", s)
+	}
+	last := first
+	if len(parts) == 2 {
+		last, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid vnodes: %v", s)
+		}
+	}
+	if first < 0 || last < first || last >= count {
+		return 0, 0, fmt.Errorf("vnodes must be between 0 and %d: %v", count-1, s)
+	}
+	return first, last, nil
+}
+
+// parseVNodeKeyRange parses a key range like a shard name, and returns
+// its start and end as 8 byte keyspace ids. An open end is 0.
+func parseVNodeKeyRange(s string) (uint64, uint64, *topodatapb.KeyRange, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return 0, 0, nil, fmt.Errorf("invalid key range: %v", s)
+	}
+	kr, err := key.ParseKeyRangeParts(parts[0], parts[1])
+	if err != nil || len(kr.Start) > 8 || len(kr.End) > 8 {
+		return 0, 0, nil, fmt.Errorf("invalid key range: %v", s)
+	}
+	var start, end [8]byte
+	copy(start[:], kr.Start)
+	copy(end[:], kr.End)
+	startNum, endNum := binary.BigEndian.Uint64(start[:]), binary.BigEndian.Uint64(end[:])
+	if len(kr.End) != 0 && endNum <= startNum {
+		return 0, 0, nil, fmt.Errorf("invalid key range: %v", s)
+	}
+	return startNum, endNum, kr, nil
+}
+
+// String returns the name of the vindex.
+func (vind *ConsistentHash) String() string {
+	return vind.name
+}
+
+// Cost returns the cost of this index as 1.
+func (vind *ConsistentHash) Cost() int {
+	return 1
+}
+
+// IsUnique returns true since the Vindex is unique.
+func (vind *ConsistentHash) IsUnique() bool {
+	return true
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (vind *ConsistentHash) NeedsVCursor() bool {
+	return false
+}
+
+// Map can map ids to key.Destination objects.
+func (vind *ConsistentHash) Map(cursor VCursor, ids []sqltypes.Value) ([]key.Destination, error) {
+	out := make([]key.Destination, len(ids))
+	for i, id := range ids {
+		out[i] = key.DestinationKeyspaceID(vind.keyspaceID(id))
+	}
+	return out, nil
+}
+
+// Verify returns true if ids maps to ksids.
+func (vind *ConsistentHash) Verify(_ VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	out := make([]bool, len(ids))
+	for i, id := range ids {
+		out[i] = bytes.Equal(vind.keyspaceID(id), ksids[i])
+	}
+	return out, nil
+}
+
+// keyspaceID returns the keyspace id of a pinned id, or else the
+// keyspace id that the offset of the hash of the id in the arc of
+// its virtual node has in the key range of the virtual node.
+func (vind *ConsistentHash) keyspaceID(id sqltypes.Value) []byte {
+	if ksid, ok := vind.overrides[id.ToString()]; ok {
+		return ksid
+	}
+	hash := xxhash.Sum64(id.ToBytes())
+	index, offset := uint64(0), hash
+	if len(vind.vnodes) > 1 {
+		index, offset = hash/(vind.arc+1), hash%(vind.arc+1)
+	}
+	vnode := vind.vnodes[index]
+
+	// The offset is scaled by (span+1)/(arc+1) in 128 bits.
+	hi, lo := bits.Mul64(offset, vnode.span)
+	var carry uint64
+	lo, carry = bits.Add64(lo, offset, 0)
+	hi += carry
+	scaled := hi
+	if vind.arc != math.MaxUint64 {
+		scaled, _ = bits.Div64(hi, lo, vind.arc+1)
+	}
+
+	var keybytes [8]byte
+	binary.BigEndian.PutUint64(keybytes[:], vnode.start+scaled)
+	return keybytes[:]
+}
+
+func init() {
+	Register("consistent_hash", NewConsistentHash)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+)
+
+func createConsistentHash(t *testing.T, params map[string]string) *ConsistentHash {
+	t.Helper()
+	vindex, err := CreateVindex("consistent_hash", "ch", params)
+	require.NoError(t, err)
+	return vindex.(*ConsistentHash)
+}
+
+func consistentHashIDs(n int) []sqltypes.Value {
+	ids := make([]sqltypes.Value, n)
+	for i := range ids {
+		ids[i] = sqltypes.NewInt64(int64(i))
+	}
+	return ids
+}
+
+func TestConsistentHashInfo(t *testing.T) {
+	vindex := createConsistentHash(t, nil)
+	assert.Equal(t, 1, vindex.Cost())
+	assert.Equal(t, "ch", vindex.String())
+	assert.True(t, vindex.IsUnique())
+	assert.False(t, vindex.NeedsVCursor())
+}
+
+func TestConsistentHashParams(t *testing.T) {
+	testcases := []struct {
+		params map[string]string
+		err    string
+	}{{
+		params: map[string]string{"vnodes": "4", "keyranges": "0-2:-c0,3:c0-"},
+	}, {
+		params: map[string]string{"vnodes": "1", "reserved": "f0-", "overrides": "42:f0, 43:f001"},
+	}, {
+		params: map[string]string{"vnodes": "2", "reserved": "-10", "keyranges": "0:10-80,1:80-"},
+	}, {
+		params: map[string]string{"vnodes": "2", "reserved": "70-80", "keyranges": "0:-70,1:80-"},
+	}, {
+		params: map[string]string{"vnodes": "0"},
+		err:    "consistent_hash vnodes must be a number between 1 and 65536: 0",
+	}, {
+		params: map[string]string{"vnodes": "4", "keyranges": "0-2:-80"},
+		err:    "consistent_hash vnode 3 is not assigned a key range",
+	}, {
+		params: map[string]string{"vnodes": "4", "keyranges": "0-2:-80,2-3:80-"},
+		err:    "consistent_hash vnode 2 is assigned twice",
+	}, {
+		params: map[string]string{"vnodes": "4", "keyranges": "0-4:-"},
+		err:    "consistent_hash keyranges 0-4:-: vnodes must be between 0 and 3: 0-4",
+	}, {
+		params: map[string]string{"vnodes": "2", "keyranges": "0:-80,1:40-"},
+		err:    "consistent_hash key ranges overlap: -80 and 40-",
+	}, {
+		params: map[string]string{"vnodes": "2", "keyranges": "0:-80,1:80-zz"},
+		err:    "consistent_hash keyranges 1:80-zz: invalid key range: 80-zz",
+	}, {
+		params: map[string]string{"vnodes": "2", "keyranges": "0-1:80-8000000000000001"},
+		err:    "consistent_hash keyranges 0-1:80-8000000000000001: key range is too small for 2 vnodes",
+	}, {
+		params: map[string]string{"vnodes": "1", "reserved": "-"},
+		err:    "consistent_hash reserved key range can't be the full key range",
+	}, {
+		params: map[string]string{"vnodes": "1", "reserved": "f0"},
+		err:    "consistent_hash reserved: invalid key range: f0",
+	}, {
+		params: map[string]string{"vnodes": "1", "reserved": "70-80"},
+		err:    "consistent_hash keyranges must be set for the reserved key range 70-80",
+	}, {
+		params: map[string]string{"vnodes": "1", "reserved": "f0-", "keyranges": "0:-"},
+		err:    "consistent_hash key range - of the vnodes overlaps the reserved key range f0-",
+	}, {
+		params: map[string]string{"vnodes": "1", "keyranges": "0:-f0", "overrides": "42:f0"},
+		err:    "consistent_hash overrides require a reserved key range",
+	}, {
+		params: map[string]string{"vnodes": "1", "reserved": "f0-", "overrides": "42:e0"},
+		err:    "consistent_hash override keyspace id is not in the reserved key range f0-: 42:e0",
+	}, {
+		params: map[string]string{"vnodes": "1", "reserved": "f0-", "overrides": "42:f0,42:f1"},
+		err:    "consistent_hash override is duplicated: 42",
+	}, {
+		params: map[string]string{"vnodes": "1", "reserved": "f0-", "overrides": "42"},
+		err:    "consistent_hash override must be an id and a keyspace id: 42",
+	}}
+	for _, tcase := range testcases {
+		_, err := CreateVindex("consistent_hash", "ch", tcase.params)
+		if tcase.err == "" {
+			assert.NoError(t, err, "%v", tcase.params)
+		} else {
+			assert.EqualError(t, err, tcase.err)
+		}
+	}
+}
+
+func TestConsistentHashMap(t *testing.T) {
+	// With the full key range, the keyspace id is the hash itself.
+	ids := consistentHashIDs(100)
+	for _, params := range []map[string]string{nil, {"vnodes": "1"}} {
+		vindex := createConsistentHash(t, params)
+		got, err := vindex.Map(nil, ids)
+		require.NoError(t, err)
+		for i, id := range ids {
+			var want [8]byte
+			binary.BigEndian.PutUint64(want[:], xxhash.Sum64(id.ToBytes()))
+			assert.Equal(t, key.DestinationKeyspaceID(want[:]), got[i], "Map(%v) with %v", id, params)
+		}
+	}
+
+	// Three quarters of the virtual nodes go to -40.
+	vindex := createConsistentHash(t, map[string]string{"vnodes": "8", "keyranges": "0-5:-40,6-7:40-"})
+	got, err := vindex.Map(nil, ids)
+	require.NoError(t, err)
+	for i, id := range ids {
+		hash := xxhash.Sum64(id.ToBytes())
+		ksid := []byte(got[i].(key.DestinationKeyspaceID))
+		if hash>>61 < 6 {
+			assert.True(t, ksid[0] < 0x40, "Map(%v): %x", id, ksid)
+		} else {
+			assert.True(t, ksid[0] >= 0x40, "Map(%v): %x", id, ksid)
+		}
+	}
+}
+
+func TestConsistentHashOverrides(t *testing.T) {
+	params := map[string]string{"vnodes": "16", "reserved": "f0-"}
+	vindex := createConsistentHash(t, params)
+	params["overrides"] = "42:f0,tenant:f1"
+	pinned := createConsistentHash(t, params)
+
+	ids := append(consistentHashIDs(1000), sqltypes.NewVarChar("tenant"))
+	want, err := vindex.Map(nil, ids)
+	require.NoError(t, err)
+	got, err := pinned.Map(nil, ids)
+	require.NoError(t, err)
+
+	// Only the pinned ids move, to a key range of their own.
+	tenants, _ := key.ParseShardingSpec("f0-")
+	for i, id := range ids {
+		switch id.ToString() {
+		case "42":
+			assert.Equal(t, key.DestinationKeyspaceID([]byte("\xf0")), got[i])
+		case "tenant":
+			assert.Equal(t, key.DestinationKeyspaceID([]byte("\xf1")), got[i])
+		default:
+			assert.Equal(t, want[i], got[i], "Map(%v)", id)
+			assert.False(t, key.KeyRangeContains(tenants[0], got[i].(key.DestinationKeyspaceID)), "Map(%v)", id)
+		}
+	}
+}
+
+func TestConsistentHashVerify(t *testing.T) {
+	vindex := createConsistentHash(t, map[string]string{"vnodes": "4", "reserved": "f0-", "overrides": "2:f0"})
+	ids := []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2), sqltypes.NewInt64(3)}
+	dests, err := vindex.Map(nil, ids)
+	require.NoError(t, err)
+	ksids := [][]byte{dests[0].(key.DestinationKeyspaceID), []byte("\xf0"), dests[0].(key.DestinationKeyspaceID)}
+	got, err := vindex.Verify(nil, ids, ksids)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false}, got)
+}
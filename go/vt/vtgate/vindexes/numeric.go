@@ -20,21 +20,25 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var (
 	_ SingleColumn = (*Numeric)(nil)
 	_ Reversible   = (*Numeric)(nil)
+	_ Ordered      = (*Numeric)(nil)
 )
 
 // Numeric defines a bit-pattern mapping of a uint64 to the KeyspaceId.
-// It's Unique and Reversible.
+// It's Unique, Reversible and Ordered.
 type Numeric struct {
 	name string
 }
@@ -108,6 +112,53 @@ func (*Numeric) ReverseMap(_ VCursor, ksids [][]byte) ([]sqltypes.Value, error)
 	return reverseIds, nil
 }
 
+// MapRange maps the ids from start to end to the KeyRange of their
+// keyspace ids. A bound that is not an integer maps to all shards.
+func (*Numeric) MapRange(_ VCursor, start, end sqltypes.Value) (key.Destination, error) {
+	kr := &topodatapb.KeyRange{}
+	if !start.IsNull() {
+		num, negative, err := numericBound(start)
+		if err != nil {
+			return key.DestinationAllShards{}, nil
+		}
+		if !negative && num > 0 {
+			kr.Start = numericKeyspaceID(num)
+		}
+	}
+	if !end.IsNull() {
+		num, negative, err := numericBound(end)
+		if err != nil {
+			return key.DestinationAllShards{}, nil
+		}
+		if negative {
+			return key.DestinationNone{}, nil
+		}
+		if num < math.MaxUint64 {
+			kr.End = numericKeyspaceID(num + 1)
+		}
+	}
+	return key.DestinationKeyRange{KeyRange: kr}, nil
+}
+
+// numericBound returns the uint64 value of a range bound, or
+// whether it's negative.
+func numericBound(v sqltypes.Value) (uint64, bool, error) {
+	num, err := evalengine.ToUint64(v)
+	if err == nil {
+		return num, false, nil
+	}
+	if ival, ierr := evalengine.ToInt64(v); ierr == nil && ival < 0 {
+		return 0, true, nil
+	}
+	return 0, false, err
+}
+
+func numericKeyspaceID(num uint64) []byte {
+	var keybytes [8]byte
+	binary.BigEndian.PutUint64(keybytes[:], num)
+	return keybytes[:]
+}
+
 func init() {
 	Register("numeric", NewNumeric)
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package vindexes
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -24,6 +25,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var numeric SingleColumn
@@ -105,3 +108,35 @@ func TestNumericReverseMapBadData(t *testing.T) {
 		t.Errorf("numeric.Map: %v, want %v", err, want)
 	}
 }
+
+func TestNumericMapRange(t *testing.T) {
+	testcases := []struct {
+		start, end sqltypes.Value
+		want       key.Destination
+	}{{
+		start: sqltypes.NewInt64(1000),
+		end:   sqltypes.NewInt64(2000),
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x00\x00\x00\x00\x00\x00\x03\xe8"), End: []byte("\x00\x00\x00\x00\x00\x00\x07\xd1")}},
+	}, {
+		start: sqltypes.NewInt64(-5),
+		end:   sqltypes.NewUint64(math.MaxUint64),
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{}},
+	}, {
+		start: sqltypes.NULL,
+		end:   sqltypes.NewInt64(255),
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{End: []byte("\x00\x00\x00\x00\x00\x00\x01\x00")}},
+	}, {
+		start: sqltypes.NewInt64(0),
+		end:   sqltypes.NewInt64(-1),
+		want:  key.DestinationNone{},
+	}, {
+		start: sqltypes.NewFloat64(1.5),
+		end:   sqltypes.NULL,
+		want:  key.DestinationAllShards{},
+	}}
+	for _, tcase := range testcases {
+		got, err := numeric.(Ordered).MapRange(nil, tcase.start, tcase.end)
+		require.NoError(t, err)
+		assert.Equal(t, tcase.want, got, "MapRange(%v, %v)", tcase.start, tcase.end)
+	}
+}
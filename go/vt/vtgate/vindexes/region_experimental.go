@@ -25,10 +25,12 @@ import (
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var (
-	_ MultiColumn = (*RegionExperimental)(nil)
+	_ Prefixable = (*RegionExperimental)(nil)
 )
 
 func init() {
@@ -101,8 +103,7 @@ func (ge *RegionExperimental) Map(vcursor VCursor, rowsColValues [][]sqltypes.Va
 			destinations = append(destinations, key.DestinationNone{})
 			continue
 		}
-		r := make([]byte, 2, 2+8)
-		binary.BigEndian.PutUint16(r, uint16(rn))
+		r := ge.regionPrefix(rn)
 
 		// Compute hash.
 		hn, err := evalengine.ToUint64(row[1])
@@ -113,15 +114,65 @@ func (ge *RegionExperimental) Map(vcursor VCursor, rowsColValues [][]sqltypes.Va
 		h := vhash(hn)
 
 		// Concatenate and add to destinations.
-		if ge.regionBytes == 1 {
-			r = r[1:]
-		}
 		dest := append(r, h...)
 		destinations = append(destinations, key.DestinationKeyspaceID(dest))
 	}
 	return destinations, nil
 }
 
+// PrefixColumns satisfies Prefixable. The region, in the first
+// column, is the prefix of the keyspace ids.
+func (ge *RegionExperimental) PrefixColumns() []int {
+	return []int{0}
+}
+
+// MapPrefix satisfies Prefixable. Each region maps to the key range
+// of the keyspace ids that start with it.
+func (ge *RegionExperimental) MapPrefix(vcursor VCursor, rowsColValues [][]sqltypes.Value) ([]key.Destination, error) {
+	destinations := make([]key.Destination, 0, len(rowsColValues))
+	for _, row := range rowsColValues {
+		if len(row) != 1 {
+			destinations = append(destinations, key.DestinationNone{})
+			continue
+		}
+		rn, err := evalengine.ToUint64(row[0])
+		if err != nil {
+			destinations = append(destinations, key.DestinationNone{})
+			continue
+		}
+		destinations = append(destinations, regionKeyRange(ge.regionPrefix(rn)))
+	}
+	return destinations, nil
+}
+
+// regionKeyRange returns the key range of the keyspace ids that start
+// with the prefix of a region. It ends at the next region, and is open
+// for the last one.
+func regionKeyRange(start []byte) key.Destination {
+	end := append([]byte(nil), start...)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			break
+		}
+		if i == 0 {
+			end = nil
+		}
+	}
+	return key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: start, End: end}}
+}
+
+// regionPrefix returns the prefix of the keyspace ids of the region,
+// with room for the hash.
+func (ge *RegionExperimental) regionPrefix(rn uint64) []byte {
+	r := make([]byte, 2, 2+8)
+	binary.BigEndian.PutUint16(r, uint16(rn))
+	if ge.regionBytes == 1 {
+		r = r[1:]
+	}
+	return r
+}
+
 // Verify satisfies MultiColumn.
 func (ge *RegionExperimental) Verify(vcursor VCursor, rowsColValues [][]sqltypes.Value, ksids [][]byte) ([]bool, error) {
 	result := make([]bool, len(rowsColValues))
@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 func TestRegionExperimentalMisc(t *testing.T) {
@@ -133,3 +135,40 @@ func createRegionVindex(t *testing.T, name, from string, rb int) (Vindex, error)
 		"to":           "toc",
 	})
 }
+
+func TestRegionExperimentalMapPrefix(t *testing.T) {
+	vindex, err := createRegionVindex(t, "region_experimental", "f1,f2", 1)
+	require.NoError(t, err)
+	ge := vindex.(Prefixable)
+	assert.Equal(t, []int{0}, ge.PrefixColumns())
+	got, err := ge.MapPrefix(nil, [][]sqltypes.Value{{
+		sqltypes.NewInt64(1),
+	}, {
+		sqltypes.NewInt64(255),
+	}, {
+		// Invalid length.
+		sqltypes.NewInt64(1), sqltypes.NewInt64(1),
+	}, {
+		// Invalid region.
+		sqltypes.NewVarBinary("abcd"),
+	}})
+	require.NoError(t, err)
+	want := []key.Destination{
+		key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte{0x01}, End: []byte{0x02}}},
+		key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte{0xff}}},
+		key.DestinationNone{},
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+
+	vindex, err = createRegionVindex(t, "region_experimental", "f1,f2", 2)
+	require.NoError(t, err)
+	got, err = vindex.(Prefixable).MapPrefix(nil, [][]sqltypes.Value{{
+		sqltypes.NewInt64(0x1ff),
+	}})
+	require.NoError(t, err)
+	want = []key.Destination{
+		key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte{0x01, 0xff}, End: []byte{0x02, 0x00}}},
+	}
+	assert.Equal(t, want, got)
+}
@@ -32,7 +32,7 @@ import (
 )
 
 var (
-	_ MultiColumn = (*RegionJSON)(nil)
+	_ Prefixable = (*RegionJSON)(nil)
 )
 
 func init() {
@@ -43,9 +43,9 @@ func init() {
 type RegionMap map[string]uint64
 
 // RegionJSON is a multi-column unique vindex
-// The first column is used to lookup the prefix part of the keyspace id, the second column is hashed,
+// The first column is hashed, the second column is used to lookup the prefix part of the keyspace id,
 // and the two values are combined to produce the keyspace id.
-// RegionJson can be used for geo-partitioning because the first column can denote a region,
+// RegionJson can be used for geo-partitioning because the second column can denote a region,
 // and it will dictate the shard range for that region.
 type RegionJSON struct {
 	name        string
@@ -117,24 +117,60 @@ func (rv *RegionJSON) Map(vcursor VCursor, rowsColValues [][]sqltypes.Value) ([]
 		}
 		h := vhash(hn)
 
-		rn, ok := rv.regionMap[row[1].ToString()]
+		r, ok := rv.regionPrefix(row[1])
 		if !ok {
 			destinations = append(destinations, key.DestinationNone{})
 			continue
 		}
-		r := make([]byte, 2)
-		binary.BigEndian.PutUint16(r, uint16(rn))
 
 		// Concatenate and add to destinations.
-		if rv.regionBytes == 1 {
-			r = r[1:]
-		}
 		dest := append(r, h...)
 		destinations = append(destinations, key.DestinationKeyspaceID(dest))
 	}
 	return destinations, nil
 }
 
+// PrefixColumns satisfies Prefixable. The region of the country, in
+// the second column, is the prefix of the keyspace ids.
+func (rv *RegionJSON) PrefixColumns() []int {
+	return []int{1}
+}
+
+// MapPrefix satisfies Prefixable. Each country maps to the key range
+// of the keyspace ids that start with its region.
+func (rv *RegionJSON) MapPrefix(vcursor VCursor, rowsColValues [][]sqltypes.Value) ([]key.Destination, error) {
+	destinations := make([]key.Destination, 0, len(rowsColValues))
+	for _, row := range rowsColValues {
+		if len(row) != 1 {
+			destinations = append(destinations, key.DestinationNone{})
+			continue
+		}
+		r, ok := rv.regionPrefix(row[0])
+		if !ok {
+			destinations = append(destinations, key.DestinationNone{})
+			continue
+		}
+		destinations = append(destinations, regionKeyRange(r))
+	}
+	return destinations, nil
+}
+
+// regionPrefix returns the prefix of the keyspace ids of the region of
+// the country, with room for the hash, or false if the country has no
+// region.
+func (rv *RegionJSON) regionPrefix(country sqltypes.Value) ([]byte, bool) {
+	rn, ok := rv.regionMap[country.ToString()]
+	if !ok {
+		return nil, false
+	}
+	r := make([]byte, 2, 2+8)
+	binary.BigEndian.PutUint16(r, uint16(rn))
+	if rv.regionBytes == 1 {
+		r = r[1:]
+	}
+	return r, true
+}
+
 // Verify satisfies MultiColumn
 func (rv *RegionJSON) Verify(vcursor VCursor, rowsColValues [][]sqltypes.Value, ksids [][]byte) ([]bool, error) {
 	result := make([]bool, len(rowsColValues))
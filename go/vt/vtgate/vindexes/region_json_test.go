@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestRegionJSONMapPrefix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "region_json")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	regionMap := path.Join(dir, "region_map.json")
+	require.NoError(t, ioutil.WriteFile(regionMap, []byte(`{"US": 1, "LAST": 255}`), 0600))
+
+	vindex, err := CreateVindex("region_json", "region_json", map[string]string{
+		"region_map":   regionMap,
+		"region_bytes": "1",
+	})
+	require.NoError(t, err)
+	rv := vindex.(Prefixable)
+	assert.Equal(t, []int{1}, rv.PrefixColumns())
+
+	ksids, err := rv.Map(nil, [][]sqltypes.Value{{sqltypes.NewInt64(1), sqltypes.NewVarChar("US")}})
+	require.NoError(t, err)
+	got, err := rv.MapPrefix(nil, [][]sqltypes.Value{{
+		sqltypes.NewVarChar("US"),
+	}, {
+		sqltypes.NewVarChar("LAST"),
+	}, {
+		// Unknown country.
+		sqltypes.NewVarChar("FR"),
+	}, {
+		// Invalid length.
+		sqltypes.NewVarChar("US"), sqltypes.NewInt64(1),
+	}})
+	require.NoError(t, err)
+	want := []key.Destination{
+		key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte{0x01}, End: []byte{0x02}}},
+		key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte{0xff}}},
+		key.DestinationNone{},
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+	// The keyspace ids of the country are in its key range.
+	assert.True(t, key.KeyRangeContains(got[0].(key.DestinationKeyRange).KeyRange, ksids[0].(key.DestinationKeyspaceID)))
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// UserAttributePrefix is the prefix of the bind variables which hold the
+// attributes of the user in the predicates of the row policies.
+const UserAttributePrefix = "__vt_user_attr_"
+
+// RowPolicy restricts the rows of a table that some users can read and
+// write to the rows which match its predicate.
+type RowPolicy struct {
+	Name      string   `json:"name"`
+	Predicate string   `json:"predicate"`
+	Roles     []string `json:"roles,omitempty"`
+	// Attributes lists the user attributes used by the predicate.
+	Attributes []string `json:"attributes,omitempty"`
+
+	// columns are the columns used by the predicate.
+	columns []sqlparser.ColIdent
+	// equalities are the conditions "column = value" of the predicate,
+	// which the rows written must satisfy. equalitiesErr is set if the
+	// predicate has other conditions.
+	equalities    []RowPolicyEquality
+	equalitiesErr error
+}
+
+// RowPolicyEquality is a condition "column = value" of a row policy.
+// Value is a literal or a user attribute.
+type RowPolicyEquality struct {
+	Column sqlparser.ColIdent
+	Value  sqlparser.Expr
+}
+
+// NewRowPolicy validates the policy of the VSchema and returns its
+// parsed form.
+func NewRowPolicy(policy *vschemapb.RowPolicy) (*RowPolicy, error) {
+	if policy.Name == "" {
+		return nil, fmt.Errorf("row policy has no name")
+	}
+	expr, err := parseRowPolicyPredicate(policy.Predicate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid predicate for row policy %s: %v", policy.Name, err)
+	}
+	rp := &RowPolicy{
+		Name:      policy.Name,
+		Predicate: policy.Predicate,
+		Roles:     policy.Roles,
+	}
+	attributes := make(map[string]bool)
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Subquery:
+			return false, fmt.Errorf("subqueries are not supported")
+		case *sqlparser.ColName:
+			if !node.Qualifier.IsEmpty() {
+				return false, fmt.Errorf("column %s must not be qualified", sqlparser.String(node))
+			}
+			rp.columns = append(rp.columns, node.Name)
+		case sqlparser.Argument:
+			name := string(node[1:])
+			if !strings.HasPrefix(name, UserAttributePrefix) || len(name) == len(UserAttributePrefix) {
+				return false, fmt.Errorf("bind variable %s is not a user attribute", string(node))
+			}
+			attr := strings.TrimPrefix(name, UserAttributePrefix)
+			if !attributes[attr] {
+				attributes[attr] = true
+				rp.Attributes = append(rp.Attributes, attr)
+			}
+		case sqlparser.ListArg:
+			return false, fmt.Errorf("list bind variable %s is not supported", string(node))
+		}
+		return true, nil
+	}, expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid predicate for row policy %s: %v", policy.Name, err)
+	}
+
+	for _, cond := range sqlparser.SplitAndExpression(nil, expr) {
+		col, value, ok := rowPolicyEquality(cond)
+		if !ok {
+			rp.equalities = nil
+			rp.equalitiesErr = fmt.Errorf("row policy %s can only verify the rows written with conditions of the form column = value: %s", policy.Name, sqlparser.String(cond))
+			break
+		}
+		rp.equalities = append(rp.equalities, RowPolicyEquality{Column: col, Value: value})
+	}
+	return rp, nil
+}
+
+// AppliesTo returns true if the policy applies to the user.
+func (rp *RowPolicy) AppliesTo(username string, groups []string) bool {
+	if len(rp.Roles) == 0 {
+		return true
+	}
+	for _, role := range rp.Roles {
+		if role == username {
+			return true
+		}
+		for _, group := range groups {
+			if role == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Expr returns a new copy of the predicate, with its columns qualified by
+// the given table name or alias.
+func (rp *RowPolicy) Expr(qualifier sqlparser.TableName) sqlparser.Expr {
+	// The predicate was validated when the policy was created.
+	expr, _ := parseRowPolicyPredicate(rp.Predicate)
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if col, ok := node.(*sqlparser.ColName); ok {
+			col.Qualifier = qualifier
+		}
+		return true, nil
+	}, expr)
+	return expr
+}
+
+// References returns true if the predicate uses the column.
+func (rp *RowPolicy) References(col sqlparser.ColIdent) bool {
+	for _, c := range rp.columns {
+		if c.Equal(col) {
+			return true
+		}
+	}
+	return false
+}
+
+// Equalities returns the conditions "column = value" which the rows
+// written to the table must satisfy. It returns an error if the predicate
+// has other conditions, which can't be verified.
+func (rp *RowPolicy) Equalities() ([]RowPolicyEquality, error) {
+	return rp.equalities, rp.equalitiesErr
+}
+
+func parseRowPolicyPredicate(predicate string) (sqlparser.Expr, error) {
+	if predicate == "" {
+		return nil, fmt.Errorf("empty predicate")
+	}
+	stmt, err := sqlparser.Parse("select 1 from dual where " + predicate)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || sel.Where == nil || sel.Limit != nil || sel.OrderBy != nil || sel.GroupBy != nil || sel.Having != nil {
+		return nil, fmt.Errorf("not a boolean expression: %s", predicate)
+	}
+	return sel.Where.Expr, nil
+}
+
+func rowPolicyEquality(cond sqlparser.Expr) (sqlparser.ColIdent, sqlparser.Expr, bool) {
+	cmp, ok := cond.(*sqlparser.ComparisonExpr)
+	if !ok || cmp.Operator != sqlparser.EqualOp {
+		return sqlparser.ColIdent{}, nil, false
+	}
+	col, value := cmp.Left, cmp.Right
+	if _, ok := col.(*sqlparser.ColName); !ok {
+		col, value = value, col
+	}
+	colName, ok := col.(*sqlparser.ColName)
+	if !ok {
+		return sqlparser.ColIdent{}, nil, false
+	}
+	switch value.(type) {
+	case sqlparser.Argument, *sqlparser.Literal:
+		return colName.Name, value, true
+	}
+	return sqlparser.ColIdent{}, nil, false
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestNewRowPolicy(t *testing.T) {
+	rp, err := NewRowPolicy(&vschemapb.RowPolicy{
+		Name:      "tenant",
+		Predicate: "tenant_id = :__vt_user_attr_tenant and deleted = 0",
+		Roles:     []string{"tenants"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tenant"}, rp.Attributes)
+	assert.True(t, rp.References(sqlparser.NewColIdent("TENANT_ID")))
+	assert.False(t, rp.References(sqlparser.NewColIdent("id")))
+
+	equalities, err := rp.Equalities()
+	require.NoError(t, err)
+	require.Len(t, equalities, 2)
+	assert.Equal(t, "tenant_id", equalities[0].Column.String())
+	assert.Equal(t, ":__vt_user_attr_tenant", sqlparser.String(equalities[0].Value))
+	assert.Equal(t, "deleted", equalities[1].Column.String())
+	assert.Equal(t, "0", sqlparser.String(equalities[1].Value))
+
+	expr := rp.Expr(sqlparser.TableName{Name: sqlparser.NewTableIdent("u")})
+	assert.Equal(t, "u.tenant_id = :__vt_user_attr_tenant and u.deleted = 0", sqlparser.String(expr))
+	// Each call returns a new copy.
+	assert.Equal(t, "tenant_id = :__vt_user_attr_tenant and deleted = 0", sqlparser.String(rp.Expr(sqlparser.TableName{})))
+
+	assert.True(t, rp.AppliesTo("bob", []string{"staff", "tenants"}))
+	assert.True(t, rp.AppliesTo("tenants", nil))
+	assert.False(t, rp.AppliesTo("bob", []string{"staff"}))
+	rp.Roles = nil
+	assert.True(t, rp.AppliesTo("bob", nil))
+}
+
+func TestNewRowPolicyNotVerifiable(t *testing.T) {
+	rp, err := NewRowPolicy(&vschemapb.RowPolicy{
+		Name:      "region",
+		Predicate: "region in ('eu', 'us') and tenant_id = :__vt_user_attr_tenant",
+	})
+	require.NoError(t, err)
+	_, err = rp.Equalities()
+	require.EqualError(t, err, "row policy region can only verify the rows written with conditions of the form column = value: region in ('eu', 'us')")
+}
+
+func TestNewRowPolicyFail(t *testing.T) {
+	tcases := []struct {
+		policy *vschemapb.RowPolicy
+		err    string
+	}{{
+		policy: &vschemapb.RowPolicy{Predicate: "a = 1"},
+		err:    "row policy has no name",
+	}, {
+		policy: &vschemapb.RowPolicy{Name: "p"},
+		err:    "invalid predicate for row policy p: empty predicate",
+	}, {
+		policy: &vschemapb.RowPolicy{Name: "p", Predicate: "a = 1 order by a"},
+		err:    "invalid predicate for row policy p: not a boolean expression: a = 1 order by a",
+	}, {
+		policy: &vschemapb.RowPolicy{Name: "p", Predicate: "t.a = 1"},
+		err:    "invalid predicate for row policy p: column t.a must not be qualified",
+	}, {
+		policy: &vschemapb.RowPolicy{Name: "p", Predicate: "a in (select a from t)"},
+		err:    "invalid predicate for row policy p: subqueries are not supported",
+	}, {
+		policy: &vschemapb.RowPolicy{Name: "p", Predicate: "a = :tenant"},
+		err:    "invalid predicate for row policy p: bind variable :tenant is not a user attribute",
+	}, {
+		policy: &vschemapb.RowPolicy{Name: "p", Predicate: "a = :__vt_user_attr_"},
+		err:    "invalid predicate for row policy p: bind variable :__vt_user_attr_ is not a user attribute",
+	}, {
+		policy: &vschemapb.RowPolicy{Name: "p", Predicate: "a in ::__vt_user_attr_a"},
+		err:    "invalid predicate for row policy p: list bind variable ::__vt_user_attr_a is not supported",
+	}}
+	for _, tcase := range tcases {
+		_, err := NewRowPolicy(tcase.policy)
+		assert.EqualError(t, err, tcase.err, tcase.policy.Predicate)
+	}
+
+	_, err := NewRowPolicy(&vschemapb.RowPolicy{Name: "p", Predicate: "a = "})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "syntax error at position 30")
+}
+
+func TestBuildVSchemaRowPolicies(t *testing.T) {
+	good := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ksb": {
+				Tables: map[string]*vschemapb.Table{
+					"t2": {
+						RowPolicies: []*vschemapb.RowPolicy{{
+							Name:      "tenant",
+							Predicate: "tenant_id = :__vt_user_attr_tenant",
+						}},
+					},
+					"t3": {},
+				},
+			},
+			"ksa": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						RowPolicies: []*vschemapb.RowPolicy{{
+							Name:      "tenant",
+							Predicate: "tenant_id = :__vt_user_attr_tenant",
+						}, {
+							Name:      "visible",
+							Predicate: "hidden = 0",
+							Roles:     []string{"users"},
+						}},
+					},
+				},
+			},
+		},
+	}
+	vschema, err := BuildVSchema(&good)
+	require.NoError(t, err)
+	require.NoError(t, vschema.Keyspaces["ksa"].Error)
+	tables := vschema.RowPolicyTables()
+	require.Len(t, tables, 2)
+	assert.Equal(t, "t1", tables[0].Name.String())
+	assert.Equal(t, "t2", tables[1].Name.String())
+	require.Len(t, tables[0].RowPolicies, 2)
+	assert.Equal(t, "visible", tables[0].RowPolicies[1].Name)
+	assert.Equal(t, []string{"users"}, tables[0].RowPolicies[1].Roles)
+
+	bad := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ksa": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						RowPolicies: []*vschemapb.RowPolicy{{
+							Name:      "tenant",
+							Predicate: "tenant_id = :__vt_user_attr_tenant",
+						}, {
+							Name:      "tenant",
+							Predicate: "hidden = 0",
+						}},
+					},
+				},
+			},
+		},
+	}
+	vschema, _ = BuildVSchema(&bad)
+	assert.EqualError(t, vschema.Keyspaces["ksa"].Error, "duplicate row policy name 'tenant' for table: t1")
+
+	bad.Keyspaces["ksa"].Tables["t1"].RowPolicies[1].Predicate = "hidden = :x"
+	bad.Keyspaces["ksa"].Tables["t1"].RowPolicies[1].Name = "visible"
+	vschema, _ = BuildVSchema(&bad)
+	assert.EqualError(t, vschema.Keyspaces["ksa"].Error, "invalid predicate for row policy visible: bind variable :x is not a user attribute for table: t1")
+}
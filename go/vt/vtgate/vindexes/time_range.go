@@ -0,0 +1,279 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	_ SingleColumn = (*TimeRange)(nil)
+	_ Reversible   = (*TimeRange)(nil)
+	_ Ordered      = (*TimeRange)(nil)
+)
+
+// The granularities of the TimeRange vindex.
+const (
+	TimeRangeDay   = "day"
+	TimeRangeMonth = "month"
+)
+
+// The column types of the TimeRange vindex.
+const (
+	TimeRangeEpoch    = "epoch"
+	TimeRangeDatetime = "datetime"
+)
+
+// timeRangeLayouts are the formats of the DATE, DATETIME and
+// TIMESTAMP values the TimeRange vindex maps.
+var timeRangeLayouts = []string{
+	"2006-01-02 15:04:05.999999",
+	"2006-01-02",
+}
+
+// TimeRange maps a DATE, DATETIME or TIMESTAMP column, or an epoch
+// column in seconds, to the number of days or months since an epoch,
+// as a 4 byte big endian keyspace id. The keyspace ids preserve the
+// order of the times, so a keyspace can be sharded by time ranges:
+// with a day granularity and the default epoch, 2020-10-19 is the
+// keyspace id 0000487a, and the shard 0000487a- has the rows since
+// that day. The first two bytes stay 0000 for 65536 days or months,
+// so the shard names must keep them. Times before the epoch are not
+// mapped.
+//
+// It's Unique, Reversible and Ordered. Its reverse mapping returns
+// the start of the day or month of the keyspace id, as an epoch or a
+// DATETIME value depending on the type.
+type TimeRange struct {
+	name        string
+	typ         string
+	granularity string
+	epoch       time.Time
+}
+
+// NewTimeRange creates a TimeRange vindex. The "type" param is
+// required: "epoch" for an integral column of seconds since
+// 1970-01-01, or "datetime" for a DATE, DATETIME or TIMESTAMP
+// column. Values that don't match the type can't be mapped, since
+// MySQL doesn't compare them to the column as the vindex would.
+// The optional params are "granularity", which can be "day" (the
+// default) or "month", and "epoch", a date in the YYYY-MM-DD format
+// which defaults to 1970-01-01. The epoch of a month granularity
+// must be the first day of a month.
+func NewTimeRange(name string, m map[string]string) (Vindex, error) {
+	typ := m["type"]
+	if typ != TimeRangeEpoch && typ != TimeRangeDatetime {
+		return nil, fmt.Errorf("time_range type must be epoch or datetime: %q", typ)
+	}
+	granularity := TimeRangeDay
+	if g, ok := m["granularity"]; ok {
+		granularity = g
+	}
+	if granularity != TimeRangeDay && granularity != TimeRangeMonth {
+		return nil, fmt.Errorf("time_range granularity must be day or month: %v", granularity)
+	}
+	epoch := time.Unix(0, 0).UTC()
+	if e, ok := m["epoch"]; ok {
+		var err error
+		epoch, err = time.Parse("2006-01-02", e)
+		if err != nil {
+			return nil, fmt.Errorf("time_range epoch must be a YYYY-MM-DD date: %v", e)
+		}
+	}
+	if granularity == TimeRangeMonth && epoch.Day() != 1 {
+		return nil, fmt.Errorf("time_range epoch must be the first day of a month for a month granularity: %v", m["epoch"])
+	}
+	return &TimeRange{
+		name:        name,
+		typ:         typ,
+		granularity: granularity,
+		epoch:       epoch,
+	}, nil
+}
+
+// String returns the name of the vindex.
+func (vind *TimeRange) String() string {
+	return vind.name
+}
+
+// Cost returns the cost of this vindex as 1.
+func (*TimeRange) Cost() int {
+	return 1
+}
+
+// IsUnique returns true since the Vindex is unique.
+func (*TimeRange) IsUnique() bool {
+	return true
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (*TimeRange) NeedsVCursor() bool {
+	return false
+}
+
+// Map can map ids to key.Destination objects. It fails on ids that
+// don't match the type of the column.
+func (vind *TimeRange) Map(cursor VCursor, ids []sqltypes.Value) ([]key.Destination, error) {
+	out := make([]key.Destination, 0, len(ids))
+	for _, id := range ids {
+		if id.IsNull() {
+			out = append(out, key.DestinationNone{})
+			continue
+		}
+		bucket, err := vind.bucket(id)
+		if err != nil {
+			return nil, err
+		}
+		if bucket < 0 || bucket > math.MaxUint32 {
+			out = append(out, key.DestinationNone{})
+			continue
+		}
+		out = append(out, key.DestinationKeyspaceID(timeRangeKeyspaceID(bucket)))
+	}
+	return out, nil
+}
+
+// Verify returns true if ids maps to ksids.
+func (vind *TimeRange) Verify(_ VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	out := make([]bool, len(ids))
+	for i := range ids {
+		bucket, err := vind.bucket(ids[i])
+		if err != nil || bucket < 0 || bucket > math.MaxUint32 {
+			continue
+		}
+		out[i] = bytes.Equal(timeRangeKeyspaceID(bucket), ksids[i])
+	}
+	return out, nil
+}
+
+// ReverseMap returns the start of the day or month of each ksid, in
+// seconds since 1970-01-01 for an epoch column.
+func (vind *TimeRange) ReverseMap(_ VCursor, ksids [][]byte) ([]sqltypes.Value, error) {
+	reverseIds := make([]sqltypes.Value, len(ksids))
+	for i, keyspaceID := range ksids {
+		if len(keyspaceID) != 4 {
+			return nil, fmt.Errorf("TimeRange.ReverseMap: length of keyspaceId is not 4: %d", len(keyspaceID))
+		}
+		bucket := int(binary.BigEndian.Uint32(keyspaceID))
+		var t time.Time
+		if vind.granularity == TimeRangeMonth {
+			t = vind.epoch.AddDate(0, bucket, 0)
+		} else {
+			t = vind.epoch.AddDate(0, 0, bucket)
+		}
+		if vind.typ == TimeRangeEpoch {
+			reverseIds[i] = sqltypes.NewInt64(t.Unix())
+			continue
+		}
+		reverseIds[i] = sqltypes.MakeTrusted(sqltypes.Datetime, []byte(t.Format("2006-01-02 15:04:05")))
+	}
+	return reverseIds, nil
+}
+
+// MapRange maps the times from start to end to the KeyRange of
+// their days or months. A range with a bound that doesn't match the
+// type of the column goes to all the shards.
+func (vind *TimeRange) MapRange(_ VCursor, start, end sqltypes.Value) (key.Destination, error) {
+	kr := &topodatapb.KeyRange{}
+	if !start.IsNull() {
+		bucket, err := vind.bucket(start)
+		if err != nil {
+			return key.DestinationAllShards{}, nil
+		}
+		if bucket > math.MaxUint32 {
+			return key.DestinationNone{}, nil
+		}
+		if bucket > 0 {
+			kr.Start = timeRangeKeyspaceID(bucket)
+		}
+	}
+	if !end.IsNull() {
+		bucket, err := vind.bucket(end)
+		if err != nil {
+			return key.DestinationAllShards{}, nil
+		}
+		if bucket < 0 {
+			return key.DestinationNone{}, nil
+		}
+		if bucket < math.MaxUint32 {
+			kr.End = timeRangeKeyspaceID(bucket + 1)
+		}
+	}
+	return key.DestinationKeyRange{KeyRange: kr}, nil
+}
+
+// bucket returns the number of days or months from the epoch to the
+// time of the id, which is negative for times before the epoch.
+func (vind *TimeRange) bucket(id sqltypes.Value) (int64, error) {
+	t, err := vind.time(id)
+	if err != nil {
+		return 0, err
+	}
+	if vind.granularity == TimeRangeMonth {
+		return int64(t.Year()-vind.epoch.Year())*12 + int64(t.Month()-vind.epoch.Month()), nil
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(day.Sub(vind.epoch) / (24 * time.Hour)), nil
+}
+
+// time returns the UTC time of the id, which must be an integral
+// epoch in seconds, or a string that is one, for an epoch column, and
+// a DATE, DATETIME or TIMESTAMP value, or a string that is one, for a
+// datetime column.
+func (vind *TimeRange) time(id sqltypes.Value) (time.Time, error) {
+	s := id.ToString()
+	if vind.typ == TimeRangeEpoch {
+		if !id.IsIntegral() && !id.IsQuoted() {
+			return time.Time{}, fmt.Errorf("TimeRange: not an epoch: %v", s)
+		}
+		seconds, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("TimeRange: not an epoch: %v", s)
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	// MySQL reads a number as a YYYYMMDD date, not as an epoch.
+	if !id.IsQuoted() {
+		return time.Time{}, fmt.Errorf("TimeRange: not a date or time: %v", s)
+	}
+	for _, layout := range timeRangeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("TimeRange: not a date or time: %v", s)
+}
+
+func timeRangeKeyspaceID(bucket int64) []byte {
+	var keybytes [4]byte
+	binary.BigEndian.PutUint32(keybytes[:], uint32(bucket))
+	return keybytes[:]
+}
+
+func init() {
+	Register("time_range", NewTimeRange)
+}
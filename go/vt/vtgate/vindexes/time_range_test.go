@@ -0,0 +1,224 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func createTimeRange(t *testing.T, params map[string]string) *TimeRange {
+	t.Helper()
+	if params == nil {
+		params = map[string]string{"type": "datetime"}
+	}
+	vindex, err := CreateVindex("time_range", "time", params)
+	require.NoError(t, err)
+	return vindex.(*TimeRange)
+}
+
+func TestTimeRangeInfo(t *testing.T) {
+	timeRange := createTimeRange(t, nil)
+	assert.Equal(t, 1, timeRange.Cost())
+	assert.Equal(t, "time", timeRange.String())
+	assert.True(t, timeRange.IsUnique())
+	assert.False(t, timeRange.NeedsVCursor())
+}
+
+func TestTimeRangeParams(t *testing.T) {
+	testcases := []struct {
+		params map[string]string
+		err    string
+	}{{
+		params: map[string]string{"type": "datetime", "granularity": "month", "epoch": "2020-01-01"},
+	}, {
+		params: map[string]string{"type": "epoch"},
+	}, {
+		params: map[string]string{"granularity": "day"},
+		err:    `time_range type must be epoch or datetime: ""`,
+	}, {
+		params: map[string]string{"type": "date"},
+		err:    `time_range type must be epoch or datetime: "date"`,
+	}, {
+		params: map[string]string{"type": "datetime", "granularity": "year"},
+		err:    "time_range granularity must be day or month: year",
+	}, {
+		params: map[string]string{"type": "datetime", "epoch": "2020/01/01"},
+		err:    "time_range epoch must be a YYYY-MM-DD date: 2020/01/01",
+	}, {
+		params: map[string]string{"type": "datetime", "granularity": "month", "epoch": "2020-01-15"},
+		err:    "time_range epoch must be the first day of a month for a month granularity: 2020-01-15",
+	}}
+	for _, tcase := range testcases {
+		_, err := CreateVindex("time_range", "time", tcase.params)
+		if tcase.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tcase.err)
+		}
+	}
+}
+
+func TestTimeRangeMap(t *testing.T) {
+	timeRange := createTimeRange(t, nil)
+	got, err := timeRange.Map(nil, []sqltypes.Value{
+		sqltypes.NewVarChar("2020-10-19"),
+		sqltypes.NewVarChar("2020-10-19 23:59:59.999999"),
+		sqltypes.MakeTrusted(sqltypes.Datetime, []byte("2020-10-20 00:00:00")),
+		sqltypes.NewVarChar("1969-12-31"),
+		sqltypes.NULL,
+	})
+	require.NoError(t, err)
+	want := []key.Destination{
+		key.DestinationKeyspaceID([]byte("\x00\x00\x48\x7a")),
+		key.DestinationKeyspaceID([]byte("\x00\x00\x48\x7a")),
+		key.DestinationKeyspaceID([]byte("\x00\x00\x48\x7b")),
+		key.DestinationNone{},
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+
+	// MySQL reads a number as a YYYYMMDD date.
+	_, err = timeRange.Map(nil, []sqltypes.Value{sqltypes.NewInt64(20201019)})
+	assert.EqualError(t, err, "TimeRange: not a date or time: 20201019")
+	_, err = timeRange.Map(nil, []sqltypes.Value{sqltypes.NewVarChar("not a date")})
+	assert.EqualError(t, err, "TimeRange: not a date or time: not a date")
+
+	epoch := createTimeRange(t, map[string]string{"type": "epoch"})
+	got, err = epoch.Map(nil, []sqltypes.Value{
+		sqltypes.NewInt64(1603065600),
+		sqltypes.NewVarChar("1603151999"),
+		sqltypes.NewInt64(-1),
+	})
+	require.NoError(t, err)
+	want = []key.Destination{
+		key.DestinationKeyspaceID([]byte("\x00\x00\x48\x7a")),
+		key.DestinationKeyspaceID([]byte("\x00\x00\x48\x7a")),
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+
+	_, err = epoch.Map(nil, []sqltypes.Value{sqltypes.NewVarChar("2020-10-19")})
+	assert.EqualError(t, err, "TimeRange: not an epoch: 2020-10-19")
+	_, err = epoch.Map(nil, []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Datetime, []byte("2020-10-19 00:00:00"))})
+	assert.EqualError(t, err, "TimeRange: not an epoch: 2020-10-19 00:00:00")
+
+	monthly := createTimeRange(t, map[string]string{"type": "datetime", "granularity": "month", "epoch": "2020-01-01"})
+	got, err = monthly.Map(nil, []sqltypes.Value{
+		sqltypes.NewVarChar("2020-01-31"),
+		sqltypes.NewVarChar("2021-03-01"),
+		sqltypes.NewVarChar("2019-12-31"),
+	})
+	require.NoError(t, err)
+	want = []key.Destination{
+		key.DestinationKeyspaceID([]byte("\x00\x00\x00\x00")),
+		key.DestinationKeyspaceID([]byte("\x00\x00\x00\x0e")),
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestTimeRangeVerify(t *testing.T) {
+	timeRange := createTimeRange(t, nil)
+	got, err := timeRange.Verify(nil,
+		[]sqltypes.Value{sqltypes.NewVarChar("2020-10-19 08:00:00"), sqltypes.NewVarChar("2020-10-19"), sqltypes.NewVarChar("bad")},
+		[][]byte{[]byte("\x00\x00\x48\x7a"), []byte("\x00\x00\x48\x7b"), []byte("\x00\x00\x48\x7a")})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, got)
+}
+
+func TestTimeRangeReverseMap(t *testing.T) {
+	timeRange := createTimeRange(t, nil)
+	got, err := timeRange.ReverseMap(nil, [][]byte{[]byte("\x00\x00\x48\x7a")})
+	require.NoError(t, err)
+	assert.Equal(t, []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Datetime, []byte("2020-10-19 00:00:00"))}, got)
+
+	monthly := createTimeRange(t, map[string]string{"type": "datetime", "granularity": "month", "epoch": "2020-01-01"})
+	got, err = monthly.ReverseMap(nil, [][]byte{[]byte("\x00\x00\x00\x0e")})
+	require.NoError(t, err)
+	assert.Equal(t, []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Datetime, []byte("2021-03-01 00:00:00"))}, got)
+
+	epoch := createTimeRange(t, map[string]string{"type": "epoch"})
+	got, err = epoch.ReverseMap(nil, [][]byte{[]byte("\x00\x00\x48\x7a")})
+	require.NoError(t, err)
+	assert.Equal(t, []sqltypes.Value{sqltypes.NewInt64(1603065600)}, got)
+
+	_, err = timeRange.ReverseMap(nil, [][]byte{[]byte("\x00\x48\x7a")})
+	assert.EqualError(t, err, "TimeRange.ReverseMap: length of keyspaceId is not 4: 3")
+}
+
+func TestTimeRangeMapRange(t *testing.T) {
+	timeRange := createTimeRange(t, nil)
+	testcases := []struct {
+		start, end sqltypes.Value
+		want       key.Destination
+	}{{
+		start: sqltypes.NewVarChar("2020-10-19"),
+		end:   sqltypes.NewVarChar("2020-10-20 08:00:00"),
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x00\x00\x48\x7a"), End: []byte("\x00\x00\x48\x7c")}},
+	}, {
+		start: sqltypes.NewVarChar("2020-10-19"),
+		end:   sqltypes.NULL,
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x00\x00\x48\x7a")}},
+	}, {
+		start: sqltypes.NewVarChar("1960-01-01"),
+		end:   sqltypes.NewVarChar("1970-01-01"),
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{End: []byte("\x00\x00\x00\x01")}},
+	}, {
+		start: sqltypes.NULL,
+		end:   sqltypes.NewVarChar("1969-12-31"),
+		want:  key.DestinationNone{},
+	}}
+	for _, tcase := range testcases {
+		got, err := timeRange.MapRange(nil, tcase.start, tcase.end)
+		require.NoError(t, err)
+		assert.Equal(t, tcase.want, got, "MapRange(%v, %v)", tcase.start, tcase.end)
+	}
+
+	// The key range of a range covers the keyspace ids of its values.
+	dest, err := timeRange.MapRange(nil, sqltypes.NewVarChar("2020-10-19"), sqltypes.NewVarChar("2020-10-25"))
+	require.NoError(t, err)
+	kr := dest.(key.DestinationKeyRange).KeyRange
+	for _, day := range []string{"2020-10-19", "2020-10-22 12:00:00", "2020-10-25 23:59:59"} {
+		ksids, err := timeRange.Map(nil, []sqltypes.Value{sqltypes.NewVarChar(day)})
+		require.NoError(t, err)
+		assert.True(t, key.KeyRangeContains(kr, ksids[0].(key.DestinationKeyspaceID)), day)
+	}
+
+	// Bounds that don't match the type of the column go to all the shards.
+	dest, err = timeRange.MapRange(nil, sqltypes.NewVarChar("bad"), sqltypes.NULL)
+	require.NoError(t, err)
+	assert.Equal(t, key.DestinationAllShards{}, dest)
+	dest, err = timeRange.MapRange(nil, sqltypes.NewVarChar("2020-10-19"), sqltypes.NewInt64(20201025))
+	require.NoError(t, err)
+	assert.Equal(t, key.DestinationAllShards{}, dest)
+
+	epoch := createTimeRange(t, map[string]string{"type": "epoch"})
+	dest, err = epoch.MapRange(nil, sqltypes.NewInt64(1603065600), sqltypes.NewVarChar("1603151999"))
+	require.NoError(t, err)
+	assert.Equal(t, key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x00\x00\x48\x7a"), End: []byte("\x00\x00\x48\x7b")}}, dest)
+	dest, err = epoch.MapRange(nil, sqltypes.NewVarChar("2020-10-19"), sqltypes.NULL)
+	require.NoError(t, err)
+	assert.Equal(t, key.DestinationAllShards{}, dest)
+}
@@ -91,6 +91,46 @@ type MultiColumn interface {
 	Verify(vcursor VCursor, rowsColValues [][]sqltypes.Value, ksids [][]byte) ([]bool, error)
 }
 
+// A Prefixable vindex is a MultiColumn vindex whose keyspace
+// ids start with a prefix computed from some of its columns only.
+// The values of these columns can then be mapped to the key range
+// of the keyspace ids that start with their prefix, which lets
+// VTGate send a query that only constrains them to a subset of
+// the shards.
+type Prefixable interface {
+	MultiColumn
+	// PrefixColumns returns the indexes of the columns that
+	// determine the prefix of the keyspace ids, in the order
+	// MapPrefix expects their values.
+	PrefixColumns() []int
+	// MapPrefix maps the values of the prefix columns of each
+	// row to a KeyRange.
+	MapPrefix(vcursor VCursor, rowsColValues [][]sqltypes.Value) ([]key.Destination, error)
+}
+
+// An Ordered vindex is a SingleColumn vindex whose keyspace
+// ids preserve the order of its ids. A range of ids can then
+// be mapped to the key range of their keyspace ids, which lets
+// VTGate send a query that only constrains the column to a
+// range to a subset of the shards.
+type Ordered interface {
+	SingleColumn
+	// MapRange maps the ids from start to end, both included,
+	// to a KeyRange. A NULL start or end leaves the range
+	// unbounded on that side.
+	MapRange(vcursor VCursor, start, end sqltypes.Value) (key.Destination, error)
+}
+
+// A TypeOrdered vindex is an Ordered vindex whose keyspace ids
+// only preserve the order MySQL gives to the ids of some column
+// types. A range on a column of another type can't be mapped.
+type TypeOrdered interface {
+	Ordered
+	// OrdersType returns true if the keyspace ids preserve the
+	// order of the ids of a column of the type.
+	OrdersType(typ querypb.Type) bool
+}
+
 // A Reversible vindex is one that can perform a
 // reverse lookup from a keyspace id to an id. This
 // is optional. If present, VTGate can use it to
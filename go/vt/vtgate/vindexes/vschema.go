@@ -59,6 +59,8 @@ type VSchema struct {
 	uniqueTables   map[string]*Table
 	uniqueVindexes map[string]Vindex
 	Keyspaces      map[string]*KeyspaceSchema `json:"keyspaces"`
+	// rowPolicyTables are the tables which have row policies.
+	rowPolicyTables []*Table
 }
 
 // RoutingRule represents one routing rule.
@@ -91,6 +93,9 @@ type Table struct {
 	Columns                 []Column             `json:"columns,omitempty"`
 	Pinned                  []byte               `json:"pinned,omitempty"`
 	ColumnListAuthoritative bool                 `json:"column_list_authoritative,omitempty"`
+	PrimaryKey              []sqlparser.ColIdent `json:"primary_key,omitempty"`
+	ResultCache             bool                 `json:"result_cache,omitempty"`
+	RowPolicies             []*RowPolicy         `json:"row_policies,omitempty"`
 }
 
 // Keyspace contains the keyspcae info for each Table.
@@ -171,9 +176,27 @@ func BuildVSchema(source *vschemapb.SrvVSchema) (vschema *VSchema, err error) {
 	resolveAutoIncrement(source, vschema)
 	addDual(vschema)
 	buildRoutingRule(source, vschema)
+	buildRowPolicyTables(vschema)
 	return vschema, nil
 }
 
+func buildRowPolicyTables(vschema *VSchema) {
+	for _, ks := range vschema.Keyspaces {
+		for _, t := range ks.Tables {
+			if len(t.RowPolicies) != 0 {
+				vschema.rowPolicyTables = append(vschema.rowPolicyTables, t)
+			}
+		}
+	}
+	sort.Slice(vschema.rowPolicyTables, func(i, j int) bool {
+		ti, tj := vschema.rowPolicyTables[i], vschema.rowPolicyTables[j]
+		if ti.Keyspace.Name != tj.Keyspace.Name {
+			return ti.Keyspace.Name < tj.Keyspace.Name
+		}
+		return ti.Name.String() < tj.Name.String()
+	})
+}
+
 // BuildKeyspaceSchema builds the vschema portion for one keyspace.
 // The build ignores sequence references because those dependencies can
 // go cross-keyspace.
@@ -241,6 +264,7 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 			Name:                    sqlparser.NewTableIdent(tname),
 			Keyspace:                keyspace,
 			ColumnListAuthoritative: table.ColumnListAuthoritative,
+			ResultCache:             table.ResultCache,
 		}
 		switch table.Type {
 		case "", TypeReference:
@@ -277,6 +301,31 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 			t.Columns = append(t.Columns, Column{Name: name, Type: col.Type})
 		}
 
+		// Initialize PrimaryKey.
+		pkNames := make(map[string]bool)
+		for _, col := range table.PrimaryKey {
+			name := sqlparser.NewColIdent(col)
+			if pkNames[name.Lowered()] {
+				return fmt.Errorf("duplicate primary key column '%v' for table: %s", name, tname)
+			}
+			pkNames[name.Lowered()] = true
+			t.PrimaryKey = append(t.PrimaryKey, name)
+		}
+
+		// Initialize RowPolicies.
+		policyNames := make(map[string]bool)
+		for _, policy := range table.RowPolicies {
+			rp, err := NewRowPolicy(policy)
+			if err != nil {
+				return fmt.Errorf("%v for table: %s", err, tname)
+			}
+			if policyNames[rp.Name] {
+				return fmt.Errorf("duplicate row policy name '%v' for table: %s", rp.Name, tname)
+			}
+			policyNames[rp.Name] = true
+			t.RowPolicies = append(t.RowPolicies, rp)
+		}
+
 		// Initialize ColumnVindexes.
 		for i, ind := range table.ColumnVindexes {
 			vindexInfo, ok := ks.Vindexes[ind.Name]
@@ -442,6 +491,12 @@ func (vschema *VSchema) findQualified(name string) (*Table, error) {
 	return nil, fmt.Errorf("table %s not found", name)
 }
 
+// RowPolicyTables returns the tables which have row policies, sorted by
+// keyspace and name.
+func (vschema *VSchema) RowPolicyTables() []*Table {
+	return vschema.rowPolicyTables
+}
+
 // FindTable returns a pointer to the Table. If a keyspace is specified, only tables
 // from that keyspace are searched. If the specified keyspace is unsharded
 // and no tables matched, it's considered valid: FindTable will construct a table
@@ -396,6 +396,39 @@ func TestVSchemaColumnsFail(t *testing.T) {
 	}
 }
 
+func TestVSchemaPrimaryKey(t *testing.T) {
+	good := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"unsharded": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						PrimaryKey: []string{"c1", "C2"},
+					},
+					"t2": {
+						PrimaryKey: []string{"c1", "C1"},
+					},
+				},
+			},
+		},
+	}
+	got, _ := BuildVSchema(&good)
+	want := "duplicate primary key column 'C1' for table: t2"
+	err := got.Keyspaces["unsharded"].Error
+	if err == nil || err.Error() != want {
+		t.Errorf("BuildVSchema(dup pk col): %v, want %v", err, want)
+	}
+
+	delete(good.Keyspaces["unsharded"].Tables, "t2")
+	got, _ = BuildVSchema(&good)
+	if err := got.Keyspaces["unsharded"].Error; err != nil {
+		t.Fatal(err)
+	}
+	wantPK := []sqlparser.ColIdent{sqlparser.NewColIdent("c1"), sqlparser.NewColIdent("C2")}
+	if gotPK := got.Keyspaces["unsharded"].Tables["t1"].PrimaryKey; !reflect.DeepEqual(gotPK, wantPK) {
+		t.Errorf("BuildVSchema(pk): %v, want %v", gotPK, wantPK)
+	}
+}
+
 func TestVSchemaPinned(t *testing.T) {
 	good := vschemapb.SrvVSchema{
 		Keyspaces: map[string]*vschemapb.Keyspace{
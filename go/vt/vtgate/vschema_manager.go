@@ -128,9 +128,24 @@ func (vm *VSchemaManager) watchSrvVSchema(ctx context.Context, cell string) {
 		}
 
 		vm.e.SaveVSchema(vschema, stats)
+
+		// Tell the buffer about the new routing rules only after the
+		// executor uses them, such that the requests which were buffered
+		// during a MoveTables cutover are planned with the new rules.
+		if v != nil {
+			if listener, ok := vm.e.scatterConn.gateway.(srvVSchemaListener); ok {
+				listener.ProcessSrvVSchema(v)
+			}
+		}
 	})
 }
 
+// srvVSchemaListener is implemented by the gateways whose buffer waits
+// for the routing rules to change during a MoveTables cutover.
+type srvVSchemaListener interface {
+	ProcessSrvVSchema(srvVSchema *vschemapb.SrvVSchema)
+}
+
 // UpdateVSchema propagates the updated vschema to the topo. The entry for
 // the given keyspace is updated in the global topo, and the full SrvVSchema
 // is updated in all known cells.
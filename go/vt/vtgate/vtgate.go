@@ -31,6 +31,7 @@ import (
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/tb"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/key"
@@ -41,6 +42,8 @@ import (
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/quota"
 
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
 
@@ -60,6 +63,14 @@ var (
 	_                  = flag.Bool("disable_local_gateway", false, "deprecated: if specified, this process will not route any queries to local tablets in the local cell")
 	maxMemoryRows      = flag.Int("max_memory_rows", 300000, "Maximum number of rows that will be held in memory for intermediate results as well as the final result.")
 	warnMemoryRows     = flag.Int("warn_memory_rows", 30000, "Warning threshold for in-memory results. A row count higher than this amount will cause the VtGateWarnings.ResultsExceeded counter to be incremented.")
+	maxMemoryBytes     = flag.Int64("max_memory_bytes", 0, "Maximum number of bytes that the sorts, joins and aggregations of a query will hold in memory for intermediate results. A query that exceeds it fails with RESOURCE_EXHAUSTED, unless it can spill to -memory_spill_dir. 0 means no limit.")
+	maxProcessMemBytes = flag.Int64("max_process_memory_bytes", 0, "Maximum number of bytes that the primitives of all queries will hold in memory for intermediate results. 0 means no limit.")
+	memorySpillDir     = flag.String("memory_spill_dir", "", "If set, the sorts, joins and aggregations of queries that exceed their memory budget spill their rows to temporary files in this directory instead of failing.")
+	resultCacheSize    = flag.Int64("result_cache_size", 0, "Maximum number of bytes of query results held by the result cache. Results are only cached for the tables which enable result_cache in the VSchema, or the queries with the RESULT_CACHE comment directive. 0 disables the result cache.")
+	resultCacheTTL     = flag.Duration("result_cache_ttl", time.Minute, "Maximum time a result is kept in the result cache, in case its invalidation by row events was missed.")
+
+	enableConsolidator         = flag.Bool("enable_consolidator", false, "Share the result of a read-only query outside of a transaction with the identical queries which run at the same time.")
+	enableConsolidatorReplicas = flag.Bool("enable_consolidator_replicas", false, "Enable the consolidator only for the queries sent to non-master tablets.")
 
 	// TODO(deepthi): change these two vars to unexported and move to healthcheck.go when LegacyHealthcheck is removed
 
@@ -104,6 +115,10 @@ var (
 	errorCounts *stats.CountersWithMultiLabels
 
 	warnings *stats.CountersWithSingleLabel
+
+	// processMemoryBudget limits the bytes held in memory by the
+	// primitives of all queries.
+	processMemoryBudget = engine.NewProcessMemoryBudget()
 )
 
 // VTGate is the rpc interface to vtgate. Only one instance
@@ -171,9 +186,25 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 	srvResolver := srvtopo.NewResolver(serv, gw, cell)
 	resolver := NewResolver(srvResolver, serv, cell, sc)
 	vsm := newVStreamManager(srvResolver, serv, cell)
+	executor := NewExecutor(ctx, serv, cell, resolver, *normalizeQueries, *streamBufferSize, *queryPlanCacheSize)
+	if *resultCacheSize > 0 {
+		executor.resultCache = newResultCache(ctx, vsm, *resultCacheSize, *resultCacheTTL)
+		executor.resultCache.registerStats()
+	}
+	if *enableConsolidator || *enableConsolidatorReplicas {
+		executor.consolidator = sync2.NewConsolidator()
+		executor.consolidateOnlyReplicas = *enableConsolidatorReplicas
+	}
+	executor.queryDigests = initQueryDigests()
+	ts, _ := serv.GetTopoServer()
+	quotas, err := quota.Init(ctx, ts)
+	if err != nil {
+		log.Exitf("Unable to load the vtgate quotas: %v", err)
+	}
+	executor.quotas = quotas
 
 	rpcVTGate = &VTGate{
-		executor: NewExecutor(ctx, serv, cell, resolver, *normalizeQueries, *streamBufferSize, *queryPlanCacheSize),
+		executor: executor,
 		resolver: resolver,
 		vsm:      vsm,
 		txConn:   tc,
@@ -202,6 +233,9 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 	_ = stats.NewRates("ErrorsByDbType", stats.CounterForDimension(errorCounts, "DbType"), 15, 1*time.Minute)
 	_ = stats.NewRates("ErrorsByCode", stats.CounterForDimension(errorCounts, "Code"), 15, 1*time.Minute)
 
+	processMemoryBudget.SetLimit(*maxProcessMemBytes)
+	stats.NewGaugeFunc("VtgateInMemoryBytes", "Bytes held in memory by the primitives of all queries", processMemoryBudget.Used)
+
 	warnings = stats.NewCountersWithSingleLabel("VtGateWarnings", "Vtgate warnings", "type", "IgnoredSet", "ResultsExceeded", "WarnPayloadSizeExceeded")
 
 	servenv.OnRun(func() {
@@ -210,7 +244,7 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 		}
 	})
 	rpcVTGate.registerDebugHealthHandler()
-	err := initQueryLogger(rpcVTGate)
+	err = initQueryLogger(rpcVTGate)
 	if err != nil {
 		log.Fatalf("error initializing query logger: %v", err)
 	}
@@ -510,9 +544,25 @@ func LegacyInit(ctx context.Context, hc discovery.LegacyHealthCheck, serv srvtop
 	srvResolver := srvtopo.NewResolver(serv, gw, cell)
 	resolver := NewResolver(srvResolver, serv, cell, sc)
 	vsm := newVStreamManager(srvResolver, serv, cell)
+	executor := NewExecutor(ctx, serv, cell, resolver, *normalizeQueries, *streamBufferSize, *queryPlanCacheSize)
+	if *resultCacheSize > 0 {
+		executor.resultCache = newResultCache(ctx, vsm, *resultCacheSize, *resultCacheTTL)
+		executor.resultCache.registerStats()
+	}
+	if *enableConsolidator || *enableConsolidatorReplicas {
+		executor.consolidator = sync2.NewConsolidator()
+		executor.consolidateOnlyReplicas = *enableConsolidatorReplicas
+	}
+	executor.queryDigests = initQueryDigests()
+	ts, _ := serv.GetTopoServer()
+	quotas, err := quota.Init(ctx, ts)
+	if err != nil {
+		log.Exitf("Unable to load the vtgate quotas: %v", err)
+	}
+	executor.quotas = quotas
 
 	rpcVTGate = &VTGate{
-		executor: NewExecutor(ctx, serv, cell, resolver, *normalizeQueries, *streamBufferSize, *queryPlanCacheSize),
+		executor: executor,
 		resolver: resolver,
 		vsm:      vsm,
 		txConn:   tc,
@@ -541,6 +591,9 @@ func LegacyInit(ctx context.Context, hc discovery.LegacyHealthCheck, serv srvtop
 	_ = stats.NewRates("ErrorsByDbType", stats.CounterForDimension(errorCounts, "DbType"), 15, 1*time.Minute)
 	_ = stats.NewRates("ErrorsByCode", stats.CounterForDimension(errorCounts, "Code"), 15, 1*time.Minute)
 
+	processMemoryBudget.SetLimit(*maxProcessMemBytes)
+	stats.NewGaugeFunc("VtgateInMemoryBytes", "Bytes held in memory by the primitives of all queries", processMemoryBudget.Used)
+
 	warnings = stats.NewCountersWithSingleLabel("VtGateWarnings", "Vtgate warnings", "type", "IgnoredSet", "ResultsExceeded")
 
 	servenv.OnRun(func() {
@@ -549,7 +602,7 @@ func LegacyInit(ctx context.Context, hc discovery.LegacyHealthCheck, serv srvtop
 		}
 	})
 	rpcVTGate.registerDebugHealthHandler()
-	err := initQueryLogger(rpcVTGate)
+	err = initQueryLogger(rpcVTGate)
 	if err != nil {
 		log.Fatalf("error initializing query logger: %v", err)
 	}
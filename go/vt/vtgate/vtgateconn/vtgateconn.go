@@ -88,6 +88,11 @@ type VTGateSession struct {
 	impl    Impl
 }
 
+// SessionPb returns the underlying proto session.
+func (sn *VTGateSession) SessionPb() *vtgatepb.Session {
+	return sn.session
+}
+
 // Execute performs a VTGate Execute.
 func (sn *VTGateSession) Execute(ctx context.Context, query string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
 	session, res, err := sn.impl.Execute(ctx, sn.session, query, bindVars)
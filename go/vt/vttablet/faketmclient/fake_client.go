@@ -255,6 +255,16 @@ func (client *FakeTabletManagerClient) VReplicationWaitForPos(ctx context.Contex
 	return nil
 }
 
+// GetDistributedTransactions is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) GetDistributedTransactions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error) {
+	return &tabletmanagerdatapb.GetDistributedTransactionsResponse{}, nil
+}
+
+// ResolveDistributedTransaction is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) ResolveDistributedTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error {
+	return nil
+}
+
 //
 // Reparenting related functions
 //
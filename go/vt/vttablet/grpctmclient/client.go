@@ -614,6 +614,30 @@ func (client *Client) VReplicationWaitForPos(ctx context.Context, tablet *topoda
 	return nil
 }
 
+// GetDistributedTransactions is part of the tmclient.TabletManagerClient interface.
+func (client *Client) GetDistributedTransactions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error) {
+	cc, c, err := client.dial(tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer cc.Close()
+	return c.GetDistributedTransactions(ctx, &tabletmanagerdatapb.GetDistributedTransactionsRequest{})
+}
+
+// ResolveDistributedTransaction is part of the tmclient.TabletManagerClient interface.
+func (client *Client) ResolveDistributedTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error {
+	cc, c, err := client.dial(tablet)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+	_, err = c.ResolveDistributedTransaction(ctx, &tabletmanagerdatapb.ResolveDistributedTransactionRequest{
+		Dtid:   dtid,
+		Action: action,
+	})
+	return err
+}
+
 //
 // Reparenting related functions
 //
@@ -340,6 +340,19 @@ func (s *server) VReplicationWaitForPos(ctx context.Context, request *tabletmana
 	return &tabletmanagerdatapb.VReplicationWaitForPosResponse{}, err
 }
 
+func (s *server) GetDistributedTransactions(ctx context.Context, request *tabletmanagerdatapb.GetDistributedTransactionsRequest) (response *tabletmanagerdatapb.GetDistributedTransactionsResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "GetDistributedTransactions", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.GetDistributedTransactions(ctx)
+}
+
+func (s *server) ResolveDistributedTransaction(ctx context.Context, request *tabletmanagerdatapb.ResolveDistributedTransactionRequest) (response *tabletmanagerdatapb.ResolveDistributedTransactionResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "ResolveDistributedTransaction", request, response, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	err = s.tm.ResolveDistributedTransaction(ctx, request.Dtid, request.Action)
+	return &tabletmanagerdatapb.ResolveDistributedTransactionResponse{}, err
+}
+
 //
 // Reparenting related functions
 //
@@ -39,6 +39,7 @@ import (
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -464,7 +465,7 @@ func (tm *TabletManager) startReplication(ctx context.Context, pos mysql.Positio
 	}
 
 	// If using semi-sync, we need to enable it before connecting to master.
-	if err := tm.fixSemiSync(tabletType); err != nil {
+	if err := tm.fixSemiSync(ctx, tabletType, ti.Tablet); err != nil {
 		return err
 	}
 
@@ -523,10 +524,14 @@ func (tm *TabletManager) getLocalMetadataValues(tabletType topodatapb.TabletType
 		"Alias":         topoproto.TabletAliasString(tablet.Alias),
 		"ClusterAlias":  fmt.Sprintf("%s.%s", tablet.Keyspace, tablet.Shard),
 		"DataCenter":    tablet.Alias.Cell,
-		"PromotionRule": "must_not",
+		"PromotionRule": string(reparentutil.MustNotPromoteRule),
 	}
-	if isMasterEligible(tabletType) {
-		values["PromotionRule"] = "neutral"
+	if reparentutil.IsMasterEligible(tabletType) {
+		values["PromotionRule"] = string(reparentutil.NeutralPromoteRule)
+	}
+	if _, d, err := reparentutil.CurrentDurabilityPolicy(); err == nil {
+		tablet.Type = tabletType
+		values["PromotionRule"] = string(d.PromotionRule(tablet))
 	}
 	return values
 }
@@ -121,6 +121,10 @@ type RPCTM interface {
 	VReplicationExec(ctx context.Context, query string) (*querypb.QueryResult, error)
 	VReplicationWaitForPos(ctx context.Context, id int, pos string) error
 
+	// Distributed transaction (2PC) API
+	GetDistributedTransactions(ctx context.Context) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error)
+	ResolveDistributedTransaction(ctx context.Context, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error
+
 	// Reparenting related functions
 
 	ResetReplication(ctx context.Context) error
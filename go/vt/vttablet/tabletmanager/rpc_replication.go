@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 	"vitess.io/vitess/go/vt/vterrors"
 
 	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
@@ -36,7 +37,7 @@ import (
 )
 
 var (
-	enableSemiSync   = flag.Bool("enable_semi_sync", false, "Enable semi-sync when configuring replication, on master and replica tablets only (rdonly tablets will not ack).")
+	enableSemiSync   = flag.Bool("enable_semi_sync", false, "Enable semi-sync when configuring replication, on master and replica tablets only (rdonly tablets will not ack). It is the same as -durability_policy=semi_sync.")
 	setSuperReadOnly = flag.Bool("use_super_read_only", false, "Set super_read_only flag when performing planned failover.")
 )
 
@@ -176,7 +177,7 @@ func (tm *TabletManager) StartReplication(ctx context.Context) error {
 		}
 	}()
 
-	if err := tm.fixSemiSync(tm.Tablet().Type); err != nil {
+	if err := tm.fixSemiSync(ctx, tm.Tablet().Type, nil); err != nil {
 		return err
 	}
 	return tm.MysqlDaemon.StartReplication(tm.hookExtraEnv())
@@ -250,7 +251,7 @@ func (tm *TabletManager) InitMaster(ctx context.Context) (string, error) {
 
 	// Enforce semi-sync after changing the type to master. Otherwise, the
 	// master will hang while trying to create the database.
-	if err := tm.fixSemiSync(topodatapb.TabletType_MASTER); err != nil {
+	if err := tm.fixSemiSync(ctx, topodatapb.TabletType_MASTER, nil); err != nil {
 		return "", err
 	}
 
@@ -304,7 +305,7 @@ func (tm *TabletManager) InitReplica(ctx context.Context, parent *topodatapb.Tab
 	if tt == topodatapb.TabletType_MASTER {
 		tt = topodatapb.TabletType_REPLICA
 	}
-	if err := tm.fixSemiSync(tt); err != nil {
+	if err := tm.fixSemiSync(ctx, tt, ti.Tablet); err != nil {
 		return err
 	}
 
@@ -416,13 +417,13 @@ func (tm *TabletManager) demoteMaster(ctx context.Context, revertPartialFailure
 	}()
 
 	// If using semi-sync, we need to disable master-side.
-	if err := tm.fixSemiSync(topodatapb.TabletType_REPLICA); err != nil {
+	if err := tm.fixSemiSync(ctx, topodatapb.TabletType_REPLICA, nil); err != nil {
 		return nil, err
 	}
 	defer func() {
 		if finalErr != nil && revertPartialFailure && wasMaster {
 			// enable master-side semi-sync again
-			if err := tm.fixSemiSync(topodatapb.TabletType_MASTER); err != nil {
+			if err := tm.fixSemiSync(ctx, topodatapb.TabletType_MASTER, nil); err != nil {
 				log.Warningf("fixSemiSync(MASTER) failed during revert: %v", err)
 			}
 		}
@@ -447,7 +448,7 @@ func (tm *TabletManager) UndoDemoteMaster(ctx context.Context) error {
 	defer tm.unlock()
 
 	// If using semi-sync, we need to enable master-side.
-	if err := tm.fixSemiSync(topodatapb.TabletType_MASTER); err != nil {
+	if err := tm.fixSemiSync(ctx, topodatapb.TabletType_MASTER, nil); err != nil {
 		return err
 	}
 
@@ -556,15 +557,15 @@ func (tm *TabletManager) setMasterLocked(ctx context.Context, parentAlias *topod
 	if tabletType == topodatapb.TabletType_MASTER {
 		tabletType = topodatapb.TabletType_REPLICA
 	}
-	if err := tm.fixSemiSync(tabletType); err != nil {
-		return err
-	}
-	// Update the master address only if needed.
-	// We don't want to interrupt replication for no reason.
 	parent, err := tm.TopoServer.GetTablet(ctx, parentAlias)
 	if err != nil {
 		return err
 	}
+	if err := tm.fixSemiSync(ctx, tabletType, parent.Tablet); err != nil {
+		return err
+	}
+	// Update the master address only if needed.
+	// We don't want to interrupt replication for no reason.
 	masterHost := parent.Tablet.MysqlHostname
 	masterPort := int(parent.Tablet.MysqlPort)
 	if status.MasterHost != masterHost || status.MasterPort != masterPort {
@@ -729,7 +730,7 @@ func (tm *TabletManager) PromoteReplica(ctx context.Context) (string, error) {
 	}
 
 	// If using semi-sync, we need to enable it before going read-write.
-	if err := tm.fixSemiSync(topodatapb.TabletType_MASTER); err != nil {
+	if err := tm.fixSemiSync(ctx, topodatapb.TabletType_MASTER, nil); err != nil {
 		return "", err
 	}
 
@@ -740,34 +741,89 @@ func (tm *TabletManager) PromoteReplica(ctx context.Context) (string, error) {
 	return mysql.EncodePosition(pos), nil
 }
 
-func isMasterEligible(tabletType topodatapb.TabletType) bool {
-	switch tabletType {
-	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA:
-		return true
+// semiSyncDurability returns the durability policy of the semi-sync setup,
+// or nil if the tablet doesn't manage semi-sync. -enable_semi_sync is the
+// semi_sync policy.
+func semiSyncDurability() (reparentutil.Durabler, error) {
+	name, d, err := reparentutil.CurrentDurabilityPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if name != reparentutil.DurabilityNone {
+		return d, nil
 	}
+	if !*enableSemiSync {
+		return nil, nil
+	}
+	return reparentutil.GetDurabilityPolicy(reparentutil.DurabilitySemiSync)
+}
 
-	return false
+// replicaSemiSync returns true if the tablet acks the transactions of the
+// master as a tablet of this type. If master is nil, the master is the
+// one of the shard record. If it can't be read, the tablet keeps acking
+// or not, so that a topo failure doesn't silently disable the acks.
+func (tm *TabletManager) replicaSemiSync(ctx context.Context, d reparentutil.Durabler, tabletType topodatapb.TabletType, master *topodatapb.Tablet) bool {
+	tablet := tm.Tablet()
+	tablet.Type = tabletType
+	if master == nil {
+		var err error
+		if master, err = tm.shardMaster(ctx, tablet); err != nil {
+			_, acking := tm.MysqlDaemon.SemiSyncEnabled()
+			log.Warningf("Cannot find the master for semi-sync, keeping replica semi-sync %v: %v", acking, err)
+			return acking
+		}
+	}
+	return d.ReplicaSemiSync(master, tablet)
 }
 
-func (tm *TabletManager) fixSemiSync(tabletType topodatapb.TabletType) error {
-	if !*enableSemiSync {
+// shardMaster returns the master tablet of the shard record of the
+// tablet, or nil if it has none.
+func (tm *TabletManager) shardMaster(ctx context.Context, tablet *topodatapb.Tablet) (*topodatapb.Tablet, error) {
+	si, err := tm.TopoServer.GetShard(ctx, tablet.Keyspace, tablet.Shard)
+	if err != nil {
+		return nil, err
+	}
+	if topoproto.TabletAliasIsZero(si.MasterAlias) {
+		return nil, nil
+	}
+	ti, err := tm.TopoServer.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return nil, err
+	}
+	return ti.Tablet, nil
+}
+
+// fixSemiSync sets up semi-sync for a tablet of this type, according to
+// the durability policy. If master is nil, the master is the one of the
+// shard record.
+func (tm *TabletManager) fixSemiSync(ctx context.Context, tabletType topodatapb.TabletType, master *topodatapb.Tablet) error {
+	d, err := semiSyncDurability()
+	if err != nil {
+		return err
+	}
+	if d == nil {
 		// Semi-sync handling is not enabled.
 		return nil
 	}
 
-	// Only enable if we're eligible for becoming master (REPLICA type).
-	// Ineligible tablets (RDONLY) shouldn't ACK because we'll never promote them.
-	if !isMasterEligible(tabletType) {
-		return tm.MysqlDaemon.SetSemiSyncEnabled(false, false)
+	if tabletType == topodatapb.TabletType_MASTER {
+		tablet := tm.Tablet()
+		tablet.Type = tabletType
+		// Always enable replica-side since it doesn't hurt to keep it on for a master.
+		return tm.MysqlDaemon.SetSemiSyncEnabled(d.MasterSemiSync(tablet) > 0, true)
 	}
 
-	// Always enable replica-side since it doesn't hurt to keep it on for a master.
 	// The master-side needs to be off for a replica, or else it will get stuck.
-	return tm.MysqlDaemon.SetSemiSyncEnabled(tabletType == topodatapb.TabletType_MASTER, true)
+	// Tablets which are not acking (e.g. RDONLY) won't be promoted anyway.
+	return tm.MysqlDaemon.SetSemiSyncEnabled(false, tm.replicaSemiSync(ctx, d, tabletType, master))
 }
 
-func (tm *TabletManager) fixSemiSyncAndReplication(tabletType topodatapb.TabletType) error {
-	if !*enableSemiSync {
+func (tm *TabletManager) fixSemiSyncAndReplication(ctx context.Context, tabletType topodatapb.TabletType) error {
+	d, err := semiSyncDurability()
+	if err != nil {
+		return err
+	}
+	if d == nil {
 		// Semi-sync handling is not enabled.
 		return nil
 	}
@@ -779,7 +835,8 @@ func (tm *TabletManager) fixSemiSyncAndReplication(tabletType topodatapb.TabletT
 		return nil
 	}
 
-	if err := tm.fixSemiSync(tabletType); err != nil {
+	shouldAck := tm.replicaSemiSync(ctx, d, tabletType, nil)
+	if err := tm.MysqlDaemon.SetSemiSyncEnabled(false, shouldAck); err != nil {
 		return vterrors.Wrapf(err, "failed to fixSemiSync(%v)", tabletType)
 	}
 
@@ -796,7 +853,6 @@ func (tm *TabletManager) fixSemiSyncAndReplication(tabletType topodatapb.TabletT
 		return nil
 	}
 
-	shouldAck := isMasterEligible(tabletType)
 	acking, err := tm.MysqlDaemon.SemiSyncReplicationStatus()
 	if err != nil {
 		return vterrors.Wrap(err, "failed to get SemiSyncReplicationStatus")
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/mysqlctl/fakemysqldaemon"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestFixSemiSyncCrossCell(t *testing.T) {
+	defer flag.Set("durability_policy", reparentutil.DurabilityNone)
+	require.NoError(t, flag.Set("durability_policy", reparentutil.DurabilityCrossCell))
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1", "cell2")
+	tm := newTestTM(t, ts, 1, "ks", "0")
+	defer tm.Stop()
+	mysqld := tm.MysqlDaemon.(*fakemysqldaemon.FakeMysqlDaemon)
+
+	master := newTestTablet(t, 2, "ks", "0")
+	master.Alias.Cell = "cell2"
+	master.Type = topodatapb.TabletType_MASTER
+	require.NoError(t, ts.CreateTablet(ctx, master))
+	_, err := ts.UpdateShardFields(ctx, "ks", "0", func(si *topo.ShardInfo) error {
+		si.MasterAlias = master.Alias
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The master of the shard record is in another cell.
+	require.NoError(t, tm.fixSemiSync(ctx, topodatapb.TabletType_REPLICA, nil))
+	assert.True(t, mysqld.SemiSyncReplicaEnabled)
+
+	// The master can't be read: the replica keeps acking.
+	require.NoError(t, ts.DeleteTablet(ctx, master.Alias))
+	require.NoError(t, tm.fixSemiSync(ctx, topodatapb.TabletType_REPLICA, nil))
+	assert.True(t, mysqld.SemiSyncReplicaEnabled)
+
+	// The master given by the caller is in the same cell.
+	sameCell := newTestTablet(t, 3, "ks", "0")
+	require.NoError(t, tm.fixSemiSync(ctx, topodatapb.TabletType_REPLICA, sameCell))
+	assert.False(t, mysqld.SemiSyncReplicaEnabled)
+}
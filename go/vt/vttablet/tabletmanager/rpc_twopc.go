@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// GetDistributedTransactions returns the 2pc metadata and the prepared
+// transactions stored on this tablet.
+func (tm *TabletManager) GetDistributedTransactions(ctx context.Context) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error) {
+	distributed, prepared, failed, err := tm.QueryServiceControl.ReadTwopcInflight(ctx, tm.twopcTarget())
+	if err != nil {
+		return nil, err
+	}
+	response := &tabletmanagerdatapb.GetDistributedTransactionsResponse{}
+	for _, dtx := range distributed {
+		metadata := &querypb.TransactionMetadata{
+			Dtid:        dtx.Dtid,
+			State:       querypb.TransactionState(querypb.TransactionState_value[dtx.State]),
+			TimeCreated: dtx.Created.UnixNano(),
+		}
+		for _, participant := range dtx.Participants {
+			metadata.Participants = append(metadata.Participants, &querypb.Target{
+				Keyspace:   participant.Keyspace,
+				Shard:      participant.Shard,
+				TabletType: topodatapb.TabletType_MASTER,
+			})
+		}
+		response.Transactions = append(response.Transactions, metadata)
+	}
+	for _, ptx := range prepared {
+		response.PreparedDtids = append(response.PreparedDtids, ptx.Dtid)
+	}
+	for _, ptx := range failed {
+		response.FailedDtids = append(response.FailedDtids, ptx.Dtid)
+	}
+	return response, nil
+}
+
+// ResolveDistributedTransaction applies the requested action to the
+// 2pc transaction identified by dtid. The decision of whether the action
+// is safe is left to the caller, which has a view of all participants.
+func (tm *TabletManager) ResolveDistributedTransaction(ctx context.Context, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error {
+	if action == tabletmanagerdatapb.DistributedTransactionAction_UNKNOWN {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "no distributed transaction action for %v", dtid)
+	}
+	target := tm.twopcTarget()
+	qs := tm.QueryServiceControl.QueryService()
+	switch action {
+	case tabletmanagerdatapb.DistributedTransactionAction_CONCLUDE:
+		return qs.ConcludeTransaction(ctx, target, dtid)
+	case tabletmanagerdatapb.DistributedTransactionAction_COMMIT_PREPARED:
+		return qs.CommitPrepared(ctx, target, dtid)
+	case tabletmanagerdatapb.DistributedTransactionAction_ROLLBACK_PREPARED:
+		return qs.RollbackPrepared(ctx, target, dtid, 0)
+	case tabletmanagerdatapb.DistributedTransactionAction_SET_ROLLBACK:
+		return qs.SetRollback(ctx, target, dtid, 0)
+	}
+	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown distributed transaction action: %v", action)
+}
+
+// twopcTarget returns the target of the 2pc requests to the tablet
+// server, which is the tablet itself.
+func (tm *TabletManager) twopcTarget() *querypb.Target {
+	tablet := tm.Tablet()
+	return &querypb.Target{
+		Keyspace:   tablet.Keyspace,
+		Shard:      tablet.Shard,
+		TabletType: tablet.Type,
+	}
+}
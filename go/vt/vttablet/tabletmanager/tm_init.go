@@ -243,6 +243,9 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, healthCheckInterval ti
 		log.Warningf("deprecated demote_master_type %v must match init_tablet_type %v", demoteType, tablet.Type)
 	}
 	tm.baseTabletType = tablet.Type
+	if _, err := semiSyncDurability(); err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithTimeout(tm.BatchCtx, *initTimeout)
 	defer cancel()
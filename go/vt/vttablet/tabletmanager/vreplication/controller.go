@@ -63,6 +63,8 @@ type controller struct {
 	stopPos      string
 	tabletPicker *discovery.TabletPicker
 
+	throttlerPriority string
+
 	cancel context.CancelFunc
 	done   chan struct{}
 
@@ -106,6 +108,9 @@ func newController(ctx context.Context, params map[string]string, dbClientFactor
 		return nil, err
 	}
 	ct.stopPos = params["stop_pos"]
+	if ct.throttlerPriority, err = parseThrottlerPriority(params["throttler_priority"]); err != nil {
+		return nil, err
+	}
 
 	if ct.source.GetExternalMysql() == "" {
 		// tabletPicker
@@ -242,6 +247,7 @@ func (ct *controller) runBlp(ctx context.Context) (err error) {
 		defer vsClient.Close(ctx)
 
 		vr := newVReplicator(ct.id, &ct.source, vsClient, ct.blpStats, dbClient, ct.mysqld, ct.vre)
+		vr.throttlerPriority = ct.throttlerPriority
 		return vr.Replicate(ctx)
 	}
 	ct.blpStats.ErrorCounts.Add([]string{"Invalid Source"}, 1)
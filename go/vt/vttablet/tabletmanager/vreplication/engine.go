@@ -108,6 +108,10 @@ type Engine struct {
 
 	journaler map[string]*journalEvent
 	ec        *externalConnector
+
+	// throttler is the lag throttler of the tablet. The streams
+	// wait for it before writing. It is nil in tests.
+	throttler LagThrottler
 }
 
 type journalEvent struct {
@@ -118,7 +122,7 @@ type journalEvent struct {
 
 // NewEngine creates a new Engine.
 // A nil ts means that the Engine is disabled.
-func NewEngine(config *tabletenv.TabletConfig, ts *topo.Server, cell string, mysqld mysqlctl.MysqlDaemon) *Engine {
+func NewEngine(config *tabletenv.TabletConfig, ts *topo.Server, cell string, mysqld mysqlctl.MysqlDaemon, throttler LagThrottler) *Engine {
 	vre := &Engine{
 		controllers: make(map[int]*controller),
 		ts:          ts,
@@ -126,6 +130,7 @@ func NewEngine(config *tabletenv.TabletConfig, ts *topo.Server, cell string, mys
 		mysqld:      mysqld,
 		journaler:   make(map[string]*journalEvent),
 		ec:          newExternalConnector(config.ExternalConnections),
+		throttler:   throttler,
 	}
 	return vre
 }
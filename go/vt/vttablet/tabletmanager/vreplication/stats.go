@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/servenv"
 )
 
@@ -252,6 +253,37 @@ func (st *vrStats) register() {
 			}
 			return result
 		})
+	stats.NewGaugesFuncWithMultiLabels(
+		"VReplicationThrottledTimings",
+		"vreplication time spent waiting for the lag throttler per phase per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "counts", "phase"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for phase, t := range ct.blpStats.ThrottledTimings.Histograms() {
+					result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+fmt.Sprintf("%v", ct.id)+"."+phase] = t.Total()
+				}
+			}
+			return result
+		})
+
+	stats.NewCounterFunc(
+		"VReplicationThrottledTimingsTotal",
+		"vreplication time spent waiting for the lag throttler aggregated across all phases and streams",
+		func() int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := int64(0)
+			for _, ct := range st.controllers {
+				for _, t := range ct.blpStats.ThrottledTimings.Histograms() {
+					result += t.Total()
+				}
+			}
+			return result
+		})
+
 	stats.NewCountersFuncWithMultiLabels(
 		"VReplicationErrors",
 		"Errors during vreplication",
@@ -312,6 +344,7 @@ func (st *vrStats) status() *EngineStatus {
 			PhaseTimings:        ct.blpStats.PhaseTimings.Counts(),
 			CopyRowCount:        ct.blpStats.CopyRowCount.Get(),
 			CopyLoopCount:       ct.blpStats.CopyLoopCount.Get(),
+			ThrottledTimings:    throttledTimings(ct.blpStats),
 		}
 		i++
 	}
@@ -319,6 +352,16 @@ func (st *vrStats) status() *EngineStatus {
 	return status
 }
 
+// throttledTimings returns the time, per phase, that
+// a stream spent waiting for the lag throttler.
+func throttledTimings(blpStats *binlogplayer.Stats) map[string]int64 {
+	result := make(map[string]int64)
+	for phase, t := range blpStats.ThrottledTimings.Histograms() {
+		result[phase] = t.Total()
+	}
+	return result
+}
+
 // EngineStatus contains a renderable status of the Engine.
 type EngineStatus struct {
 	IsOpen      bool
@@ -342,6 +385,7 @@ type ControllerStatus struct {
 	PhaseTimings        map[string]int64
 	CopyRowCount        int64
 	CopyLoopCount       int64
+	ThrottledTimings    map[string]int64
 }
 
 var vreplicationTemplate = `
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+)
+
+const (
+	// throttlerAppName is the app name under which the streams
+	// check the lag throttler.
+	throttlerAppName = "vreplication"
+
+	// throttleCheckInterval controls both how frequently the throttler
+	// is checked, and how long to wait if it rejects the stream.
+	throttleCheckInterval = 250 * time.Millisecond
+)
+
+// The throttler priorities of a stream, as set in the
+// throttler_priority column of _vt.vreplication.
+const (
+	// throttlerPriorityNormal streams are throttled while the
+	// replication lag of the shard exceeds the threshold.
+	throttlerPriorityNormal = "normal"
+	// throttlerPriorityLow streams are also throttled for a while
+	// after a normal priority app got throttled.
+	throttlerPriorityLow = "low"
+	// throttlerPriorityHigh streams are never throttled.
+	throttlerPriorityHigh = "high"
+)
+
+// LagThrottler is the part of throttle.Throttler used by vreplication.
+type LagThrottler interface {
+	Check(ctx context.Context, appName string, remoteAddr string, flags *throttle.CheckFlags) *throttle.CheckResult
+}
+
+// parseThrottlerPriority validates the throttler_priority of a stream.
+// An empty value is the normal priority.
+func parseThrottlerPriority(priority string) (string, error) {
+	switch priority {
+	case "", throttlerPriorityNormal:
+		return throttlerPriorityNormal, nil
+	case throttlerPriorityLow, throttlerPriorityHigh:
+		return priority, nil
+	}
+	return "", fmt.Errorf("invalid throttler_priority: %s, must be one of %s, %s or %s", priority, throttlerPriorityNormal, throttlerPriorityLow, throttlerPriorityHigh)
+}
+
+// throttle waits until the lag throttler allows the stream to write.
+// The time spent waiting is recorded under phase, and the stream
+// message says it is throttled while it waits. It returns early if
+// the context is done, and leaves it to the caller to stop.
+func (vr *vreplicator) throttle(ctx context.Context, phase string) error {
+	if vr.vre == nil || vr.vre.throttler == nil || vr.throttlerPriority == throttlerPriorityHigh {
+		return nil
+	}
+	if time.Since(vr.lastThrottleCheck) < throttleCheckInterval {
+		return nil
+	}
+	flags := &throttle.CheckFlags{
+		LowPriority:   vr.throttlerPriority == throttlerPriorityLow,
+		OKIfNotExists: true,
+	}
+
+	var start time.Time
+	for {
+		checkResult := vr.vre.throttler.Check(ctx, throttlerAppName, "", flags)
+		if checkResult.StatusCode == http.StatusOK || (checkResult.StatusCode == http.StatusNotFound && flags.OKIfNotExists) {
+			vr.lastThrottleCheck = time.Now()
+			break
+		}
+		if start.IsZero() {
+			start = time.Now()
+			if err := vr.setMessage(fmt.Sprintf("throttled by the lag throttler: %s", checkResult.Message)); err != nil {
+				return err
+			}
+		}
+		timer := time.NewTimer(throttleCheckInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			vr.stats.ThrottledTimings.Record(phase, start)
+			return nil
+		case <-timer.C:
+		}
+	}
+	if start.IsZero() {
+		return nil
+	}
+	vr.stats.ThrottledTimings.Record(phase, start)
+	return vr.setMessage("")
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
+)
+
+// fakeThrottler returns the status codes in order, and then 200.
+type fakeThrottler struct {
+	codes []int
+	flags []*throttle.CheckFlags
+}
+
+func (ft *fakeThrottler) Check(ctx context.Context, appName string, remoteAddr string, flags *throttle.CheckFlags) *throttle.CheckResult {
+	ft.flags = append(ft.flags, flags)
+	if len(ft.codes) == 0 {
+		return throttle.NewCheckResult(http.StatusOK, 0, 1, nil)
+	}
+	code := ft.codes[0]
+	ft.codes = ft.codes[1:]
+	return throttle.NewCheckResult(code, 2, 1, base.ErrThresholdExceeded)
+}
+
+func newThrottledVReplicator(t *testing.T, ft *fakeThrottler, priority string) (*vreplicator, *binlogplayer.MockDBClient) {
+	dbClient := binlogplayer.NewMockDBClient(t)
+	vre := &Engine{throttler: ft}
+	vr := newVReplicator(1, nil, nil, binlogplayer.NewStats(), dbClient, nil, vre)
+	vr.throttlerPriority = priority
+	return vr, dbClient
+}
+
+func TestThrottle(t *testing.T) {
+	ft := &fakeThrottler{codes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests}}
+	vr, dbClient := newThrottledVReplicator(t, ft, throttlerPriorityNormal)
+
+	dbClient.ExpectRequest("update _vt.vreplication set message='throttled by the lag throttler: Threshold exceeded' where id=1", &sqltypes.Result{}, nil)
+	dbClient.ExpectRequest("update _vt.vreplication set message='' where id=1", &sqltypes.Result{}, nil)
+	start := time.Now()
+	require.NoError(t, vr.throttle(context.Background(), "copy"))
+	dbClient.Wait()
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(2*throttleCheckInterval))
+	assert.Len(t, ft.flags, 3)
+	assert.False(t, ft.flags[0].LowPriority)
+	assert.EqualValues(t, 1, vr.stats.ThrottledTimings.Counts()["copy"])
+	assert.Equal(t, []string{"throttled by the lag throttler: Threshold exceeded"}, vr.stats.MessageHistory())
+
+	// The throttler is not checked again right after it allowed the stream.
+	require.NoError(t, vr.throttle(context.Background(), "replay"))
+	assert.Len(t, ft.flags, 3)
+
+	// A missing metric does not block the stream.
+	vr.lastThrottleCheck = time.Time{}
+	ft.codes = []int{http.StatusNotFound}
+	require.NoError(t, vr.throttle(context.Background(), "replay"))
+	assert.Len(t, ft.flags, 4)
+	assert.EqualValues(t, 0, vr.stats.ThrottledTimings.Counts()["replay"])
+}
+
+func TestThrottlePriority(t *testing.T) {
+	ft := &fakeThrottler{}
+	vr, _ := newThrottledVReplicator(t, ft, throttlerPriorityLow)
+	require.NoError(t, vr.throttle(context.Background(), "copy"))
+	require.Len(t, ft.flags, 1)
+	assert.True(t, ft.flags[0].LowPriority)
+
+	ft = &fakeThrottler{codes: []int{http.StatusTooManyRequests}}
+	vr, _ = newThrottledVReplicator(t, ft, throttlerPriorityHigh)
+	require.NoError(t, vr.throttle(context.Background(), "copy"))
+	assert.Empty(t, ft.flags)
+}
+
+func TestThrottleCanceled(t *testing.T) {
+	ft := &fakeThrottler{codes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests}}
+	vr, dbClient := newThrottledVReplicator(t, ft, throttlerPriorityNormal)
+
+	dbClient.ExpectRequest("update _vt.vreplication set message='throttled by the lag throttler: Threshold exceeded' where id=1", &sqltypes.Result{}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, vr.throttle(ctx, "replay"))
+	dbClient.Wait()
+	assert.Len(t, ft.flags, 1)
+	assert.EqualValues(t, 1, vr.stats.ThrottledTimings.Counts()["replay"])
+}
+
+func TestParseThrottlerPriority(t *testing.T) {
+	for in, want := range map[string]string{
+		"":       throttlerPriorityNormal,
+		"normal": throttlerPriorityNormal,
+		"low":    throttlerPriorityLow,
+		"high":   throttlerPriorityHigh,
+	} {
+		got, err := parseThrottlerPriority(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := parseThrottlerPriority("urgent")
+	assert.EqualError(t, err, "invalid throttler_priority: urgent, must be one of normal, low or high")
+}
@@ -220,6 +220,9 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 	var updateCopyState *sqlparser.ParsedQuery
 	var bv map[string]*querypb.BindVariable
 	err = vc.vr.sourceVStreamer.VStreamRows(ctx, initialPlan.SendRule.Filter, lastpkpb, func(rows *binlogdatapb.VStreamRowsResponse) error {
+		if err := vc.vr.throttle(ctx, "copy"); err != nil {
+			return err
+		}
 		select {
 		case <-ctx.Done():
 			return io.EOF
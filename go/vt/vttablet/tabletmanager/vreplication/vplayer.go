@@ -340,6 +340,13 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 				return nil
 			}
 		}
+		// Wait for the lag throttler before applying the next
+		// transactions, unless one is still open.
+		if len(items) != 0 && !vp.vr.dbClient.InTransaction {
+			if err := vp.vr.throttle(ctx, "replay"); err != nil {
+				return err
+			}
+		}
 		for i, events := range items {
 			for j, event := range events {
 				if event.Timestamp != 0 {
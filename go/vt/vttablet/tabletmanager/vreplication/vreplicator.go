@@ -63,6 +63,12 @@ type vreplicator struct {
 	pkInfoMap map[string][]*PrimaryKeyInfo
 
 	originalFKCheckSetting int64
+
+	// throttlerPriority is the throttler_priority of the stream.
+	throttlerPriority string
+	// lastThrottleCheck is the time of the last check
+	// that allowed the stream to write.
+	lastThrottleCheck time.Time
 }
 
 // newVReplicator creates a new vreplicator. The valid fields from the source are:
@@ -276,10 +282,12 @@ func (vr *vreplicator) readSettings(ctx context.Context) (settings binlogplayer.
 }
 
 func (vr *vreplicator) setMessage(message string) error {
-	vr.stats.History.Add(&binlogplayer.StatsHistoryRecord{
-		Time:    time.Now(),
-		Message: message,
-	})
+	if message != "" {
+		vr.stats.History.Add(&binlogplayer.StatsHistoryRecord{
+			Time:    time.Now(),
+			Message: message,
+		})
+	}
 	query := fmt.Sprintf("update _vt.vreplication set message=%v where id=%v", encodeString(binlogplayer.MessageTruncate(message)), vr.id)
 	if _, err := vr.dbClient.Execute(query); err != nil {
 		return fmt.Errorf("could not set message: %v: %v", query, err)
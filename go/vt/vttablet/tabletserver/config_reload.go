@@ -0,0 +1,528 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/yaml2"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// sourceFlags is the source of the settings which are not set by the
+	// config file or the topo.
+	sourceFlags = "flags"
+
+	// maxConfigChanges is the number of config changes kept for /debug/config.
+	maxConfigChanges = 100
+)
+
+// configWatchRetryDelay is how long to wait before watching the config in
+// the topo again after an error.
+var configWatchRetryDelay = 30 * time.Second
+
+// configReloader keeps the live settings of the TabletServer in sync with
+// the tablet config. The config is made of layers: the flags, the YAML
+// config file and the YAML config in the global topo. A later layer
+// overrides the settings of the previous ones.
+type configReloader struct {
+	tsv          *TabletServer
+	reloadErrors *stats.Counter
+
+	mu       sync.Mutex
+	base     *tabletenv.TabletConfig
+	file     string
+	fileData []byte
+	topoPath string
+	topoData []byte
+
+	// effective holds the settings in use, and sources tells which layer
+	// each of them comes from.
+	effective *tabletenv.TabletConfig
+	sources   map[string]string
+	// restartRequired holds the settings which were changed, but only
+	// apply after a restart.
+	restartRequired map[string]string
+	history         []*configChange
+}
+
+// configChange is an entry of the config change history.
+type configChange struct {
+	Time            time.Time
+	Source          string
+	Changes         []configValueChange `json:",omitempty"`
+	RestartRequired []string            `json:",omitempty"`
+	Error           string              `json:",omitempty"`
+}
+
+type configValueChange struct {
+	Key, Old, New string
+}
+
+type configValue struct {
+	Value, Source string
+}
+
+func newConfigReloader(tsv *TabletServer) *configReloader {
+	return &configReloader{
+		tsv:             tsv,
+		reloadErrors:    tsv.exporter.NewCounter("TabletConfigReloadErrors", "Errors loading or applying the tablet config"),
+		base:            tsv.config.Clone(),
+		effective:       tsv.config.Clone(),
+		sources:         make(map[string]string),
+		restartRequired: make(map[string]string),
+	}
+}
+
+// InitConfigReload applies the changes of the tablet config file and the
+// tablet config in the global topo to the live settings. base is the config
+// made of the flags, which the layers are applied to. The file is reloaded
+// on SIGHUP and every reloadInterval, if it is not 0. The topo path is
+// watched for changes.
+func (tsv *TabletServer) InitConfigReload(base *tabletenv.TabletConfig, file, topoPath string, reloadInterval time.Duration) {
+	cr := tsv.configReloader
+	cr.mu.Lock()
+	cr.base = base.Clone()
+	cr.file = file
+	cr.topoPath = topoPath
+	cr.mu.Unlock()
+
+	if file != "" {
+		if err := cr.reloadFile(false); err != nil {
+			log.Errorf("%v", err)
+		}
+
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGHUP)
+		go func() {
+			for range sigChan {
+				if err := cr.reloadFile(false); err != nil {
+					log.Errorf("%v", err)
+				}
+			}
+		}()
+
+		if reloadInterval != 0 {
+			ticker := time.NewTicker(reloadInterval)
+			go func() {
+				for range ticker.C {
+					if err := cr.reloadFile(true); err != nil {
+						log.Errorf("%v", err)
+					}
+				}
+			}()
+		}
+	}
+
+	if topoPath != "" {
+		go cr.watchTopo(context.Background(), tsv.topoServer, topoPath, configWatchRetryDelay)
+	}
+}
+
+// reloadFile reads the config file and applies the config. If onlyChanged
+// is set, the config is only applied if the file changed.
+func (cr *configReloader) reloadFile(onlyChanged bool) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	source := "file " + cr.file
+	data, err := ioutil.ReadFile(cr.file)
+	if err != nil {
+		return cr.recordErrorLocked(source, vterrors.Wrapf(err, "cannot read the tablet config"))
+	}
+	if onlyChanged && bytes.Equal(data, cr.fileData) {
+		return nil
+	}
+	cr.fileData = data
+	return cr.reloadLocked(source)
+}
+
+// setTopoData applies the config of the topo layer. data is nil if the
+// topo has no config.
+func (cr *configReloader) setTopoData(data []byte) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	cr.topoData = data
+	return cr.reloadLocked("topo " + cr.topoPath)
+}
+
+// watchTopo follows the config in the global topo until ctx is done.
+func (cr *configReloader) watchTopo(ctx context.Context, ts *topo.Server, path string, retryDelay time.Duration) {
+	for {
+		if err := cr.watchTopoOnce(ctx, ts, path); err != nil {
+			log.Errorf("%v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (cr *configReloader) watchTopoOnce(ctx context.Context, ts *topo.Server, path string) error {
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot watch the tablet config")
+	}
+	current, changes, cancel := conn.Watch(ctx, path)
+	if err := cr.processWatchData(current, path); err != nil {
+		return err
+	}
+	defer cancel()
+	for wd := range changes {
+		if err := cr.processWatchData(wd, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processWatchData applies the config of wd. It returns an error if the
+// watch stopped. Deleting the config in the topo removes its layer.
+func (cr *configReloader) processWatchData(wd *topo.WatchData, path string) error {
+	if wd.Err != nil {
+		if topo.IsErrType(wd.Err, topo.NoNode) {
+			if err := cr.setTopoData(nil); err != nil {
+				log.Errorf("%v", err)
+			}
+		}
+		return vterrors.Wrapf(wd.Err, "cannot watch the tablet config %s", path)
+	}
+	if err := cr.setTopoData(wd.Contents); err != nil {
+		log.Errorf("%v", err)
+	}
+	return nil
+}
+
+// reloadLocked builds the config from its layers and applies it.
+func (cr *configReloader) reloadLocked(source string) error {
+	config, sources, err := cr.buildLocked()
+	if err == nil {
+		err = cr.applyLocked(config, sources, source)
+	}
+	if err != nil {
+		return cr.recordErrorLocked(source, err)
+	}
+	return nil
+}
+
+func (cr *configReloader) recordErrorLocked(source string, err error) error {
+	cr.reloadErrors.Add(1)
+	cr.recordLocked(&configChange{Time: time.Now(), Source: source, Error: err.Error()})
+	return vterrors.Wrapf(err, "cannot reload the tablet config from %s", source)
+}
+
+func (cr *configReloader) recordLocked(change *configChange) {
+	cr.history = append(cr.history, change)
+	if len(cr.history) > maxConfigChanges {
+		cr.history = cr.history[len(cr.history)-maxConfigChanges:]
+	}
+}
+
+// buildLocked applies the file and the topo layers to the base config. It
+// returns the config and which layer sets each setting.
+func (cr *configReloader) buildLocked() (*tabletenv.TabletConfig, map[string]string, error) {
+	config := cr.base.Clone()
+	// The connection settings can't be reloaded. Don't let the layers
+	// change the ones of the base config.
+	config.DB, config.ExternalConnections = nil, nil
+	sources := make(map[string]string)
+	layers := []struct {
+		source string
+		data   []byte
+	}{
+		{"file " + cr.file, cr.fileData},
+		{"topo " + cr.topoPath, cr.topoData},
+	}
+	for _, layer := range layers {
+		if layer.data == nil {
+			continue
+		}
+		if err := yaml2.Unmarshal(layer.data, config); err != nil {
+			return nil, nil, vterrors.Wrapf(err, "cannot parse the tablet config of %s", layer.source)
+		}
+		var values map[string]interface{}
+		if err := yaml2.Unmarshal(layer.data, &values); err != nil {
+			return nil, nil, vterrors.Wrapf(err, "cannot parse the tablet config of %s", layer.source)
+		}
+		for _, key := range flattenKeys("", values) {
+			sources[key] = layer.source
+		}
+	}
+	return config, sources, nil
+}
+
+// applyLocked validates config and applies its live settings. The other
+// settings which differ from the effective ones are reported as requiring
+// a restart. Every change is logged.
+func (cr *configReloader) applyLocked(config *tabletenv.TabletConfig, sources map[string]string, source string) error {
+	old := cr.effective
+	next := old.Clone()
+	copyLiveConfig(next, config)
+	if err := cr.tsv.applyLiveConfig(old, next); err != nil {
+		return err
+	}
+	cr.effective = next
+
+	oldValues, nextValues, wantValues := flattenConfig(old), flattenConfig(next), flattenConfig(config)
+	change := &configChange{Time: time.Now(), Source: source}
+	for _, key := range sortedKeys(nextValues) {
+		if oldValues[key] != nextValues[key] {
+			change.Changes = append(change.Changes, configValueChange{Key: key, Old: oldValues[key], New: nextValues[key]})
+			log.Infof("Tablet config change from %s: %s: %s -> %s", source, key, oldValues[key], nextValues[key])
+		}
+	}
+	restartRequired := make(map[string]string)
+	for _, key := range sortedKeys(wantValues) {
+		if wantValues[key] == nextValues[key] {
+			if src, ok := sources[key]; ok {
+				cr.sources[key] = src
+			} else {
+				delete(cr.sources, key)
+			}
+			continue
+		}
+		restartRequired[key] = wantValues[key]
+		if cr.restartRequired[key] != wantValues[key] {
+			change.RestartRequired = append(change.RestartRequired, key)
+			log.Warningf("Tablet config change from %s requires a restart: %s: %s -> %s", source, key, nextValues[key], wantValues[key])
+		}
+	}
+	cr.restartRequired = restartRequired
+	if len(change.Changes) != 0 || len(change.RestartRequired) != 0 {
+		cr.recordLocked(change)
+	}
+	return nil
+}
+
+// copyLiveConfig copies the settings which can be changed without a restart
+// from src to dst.
+func copyLiveConfig(dst, src *tabletenv.TabletConfig) {
+	for _, pools := range []struct{ dst, src *tabletenv.ConnPoolConfig }{
+		{&dst.OltpReadPool, &src.OltpReadPool},
+		{&dst.OlapReadPool, &src.OlapReadPool},
+		{&dst.TxPool, &src.TxPool},
+	} {
+		pools.dst.Size = pools.src.Size
+		pools.dst.IdleTimeoutSeconds = pools.src.IdleTimeoutSeconds
+	}
+	dst.Oltp = src.Oltp
+	dst.HotRowProtection = src.HotRowProtection
+	dst.Consolidator = src.Consolidator
+	dst.QueryCacheSize = src.QueryCacheSize
+	dst.TransactionLimitConfig = src.TransactionLimitConfig
+	dst.TxThrottlerConfig = src.TxThrottlerConfig
+}
+
+// applyLiveConfig validates config and changes the live settings which
+// differ from old. Nothing is changed if config is invalid.
+func (tsv *TabletServer) applyLiveConfig(old, config *tabletenv.TabletConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+	pools := []struct {
+		name   string
+		pools  []*connpool.Pool
+		config tabletenv.ConnPoolConfig
+		old    tabletenv.ConnPoolConfig
+	}{
+		{"oltpReadPool", []*connpool.Pool{tsv.qe.conns}, config.OltpReadPool, old.OltpReadPool},
+		{"olapReadPool", []*connpool.Pool{tsv.qe.streamConns}, config.OlapReadPool, old.OlapReadPool},
+		{"txPool", []*connpool.Pool{tsv.te.txPool.scp.conns, tsv.te.txPool.scp.foundRowsPool}, config.TxPool, old.TxPool},
+	}
+	for _, p := range pools {
+		if p.config.Size <= 0 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s.size must be > 0 (specified value: %v)", p.name, p.config.Size)
+		}
+		// The pools can't grow above the maxSize they were opened with,
+		// which defaults to their size.
+		if maxCap := p.pools[0].MaxCap(); maxCap != 0 && int64(p.config.Size) > maxCap {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s.size can't be raised above %d without a restart, set %s.maxSize to allow it (specified value: %v)", p.name, maxCap, p.name, p.config.Size)
+		}
+	}
+	switch config.Consolidator {
+	case tabletenv.Enable, tabletenv.Disable, tabletenv.NotOnMaster:
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid consolidator mode: %v", config.Consolidator)
+	}
+	switch config.HotRowProtection.Mode {
+	case tabletenv.Enable, tabletenv.Disable, tabletenv.Dryrun:
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid hot row protection mode: %v", config.HotRowProtection.Mode)
+	}
+	// The throttler config is applied first because it is the only one
+	// which can fail.
+	if config.TxThrottlerConfig != old.TxThrottlerConfig {
+		if err := tsv.txThrottler.UpdateConfiguration(config.TxThrottlerConfig); err != nil {
+			return vterrors.Wrapf(err, "invalid txThrottlerConfig")
+		}
+	}
+
+	for _, p := range pools {
+		for _, pool := range p.pools {
+			if p.config.Size != p.old.Size {
+				if err := pool.SetCapacity(p.config.Size); err != nil {
+					log.Errorf("Cannot change the size of %s: %v", p.name, err)
+				}
+			}
+			if p.config.IdleTimeoutSeconds != p.old.IdleTimeoutSeconds {
+				pool.SetIdleTimeout(p.config.IdleTimeoutSeconds.Get())
+			}
+		}
+	}
+	if config.Oltp.QueryTimeoutSeconds != old.Oltp.QueryTimeoutSeconds {
+		tsv.QueryTimeout.Set(config.Oltp.QueryTimeoutSeconds.Get())
+	}
+	if config.Oltp.TxTimeoutSeconds != old.Oltp.TxTimeoutSeconds {
+		tsv.te.txPool.SetTimeout(config.Oltp.TxTimeoutSeconds.Get())
+	}
+	if config.Oltp.MaxRows != old.Oltp.MaxRows {
+		tsv.qe.maxResultSize.Set(int64(config.Oltp.MaxRows))
+	}
+	if config.Oltp.WarnRows != old.Oltp.WarnRows {
+		tsv.qe.warnResultSize.Set(int64(config.Oltp.WarnRows))
+	}
+	if config.HotRowProtection != old.HotRowProtection {
+		tsv.qe.txSerializer.SetConfig(config.HotRowProtection)
+		tsv.enableHotRowProtection.Set(config.HotRowProtection.Mode != tabletenv.Disable)
+	}
+	if config.Consolidator != old.Consolidator {
+		tsv.qe.consolidatorMode.Set(config.Consolidator)
+	}
+	if config.QueryCacheSize != old.QueryCacheSize {
+		tsv.qe.SetQueryPlanCacheCap(config.QueryCacheSize)
+	}
+	if config.TransactionLimitConfig != old.TransactionLimitConfig || config.TxPool.Size != old.TxPool.Size {
+		tsv.te.limiter.SetConfig(config)
+	}
+	return nil
+}
+
+// flattenConfig returns the settings of config by their YAML path, e.g.
+// oltp.queryTimeoutSeconds. The connection settings are left out.
+func flattenConfig(config *tabletenv.TabletConfig) map[string]string {
+	values := make(map[string]string)
+	flattenStruct("", reflect.ValueOf(config).Elem(), values)
+	return values
+}
+
+func flattenStruct(prefix string, v reflect.Value, values map[string]string) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || name == "" {
+			continue
+		}
+		value := v.Field(i)
+		switch value.Kind() {
+		case reflect.Struct:
+			flattenStruct(prefix+name+".", value, values)
+		case reflect.Ptr, reflect.Map:
+		default:
+			values[prefix+name] = fmt.Sprint(value.Interface())
+		}
+	}
+}
+
+// flattenKeys returns the paths of the leaves of values.
+func flattenKeys(prefix string, values map[string]interface{}) []string {
+	var keys []string
+	for key, value := range values {
+		if m, ok := value.(map[string]interface{}); ok {
+			keys = append(keys, flattenKeys(prefix+key+".", m)...)
+			continue
+		}
+		keys = append(keys, prefix+key)
+	}
+	return keys
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// status returns the content of /debug/config.
+func (cr *configReloader) status() interface{} {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	values := make(map[string]configValue)
+	for key, value := range flattenConfig(cr.effective) {
+		source, ok := cr.sources[key]
+		if !ok {
+			source = sourceFlags
+		}
+		values[key] = configValue{Value: value, Source: source}
+	}
+	restartRequired := make(map[string]string, len(cr.restartRequired))
+	for key, value := range cr.restartRequired {
+		restartRequired[key] = value
+	}
+	return struct {
+		Values          map[string]configValue
+		RestartRequired map[string]string
+		History         []*configChange
+	}{values, restartRequired, append([]*configChange(nil), cr.history...)}
+}
+
+func (tsv *TabletServer) registerDebugConfigHandler() {
+	tsv.exporter.HandleFunc("/debug/config", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		b, err := json.MarshalIndent(tsv.configReloader.status(), "", " ")
+		if err != nil {
+			w.Write([]byte(err.Error()))
+			return
+		}
+		buf := bytes.NewBuffer(nil)
+		json.HTMLEscape(buf, b)
+		w.Write(buf.Bytes())
+	})
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+type testConfigStatus struct {
+	Values          map[string]configValue
+	RestartRequired map[string]string
+	History         []*configChange
+}
+
+func getConfigStatus(t *testing.T, tsv *TabletServer) *testConfigStatus {
+	t.Helper()
+	b, err := json.Marshal(tsv.configReloader.status())
+	require.NoError(t, err)
+	status := &testConfigStatus{}
+	require.NoError(t, json.Unmarshal(b, status))
+	return status
+}
+
+func waitForConfig(t *testing.T, desc string, done func() bool) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if done() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", desc)
+}
+
+func TestConfigReloadFile(t *testing.T) {
+	config := tabletenv.NewDefaultConfig()
+	db, tsv := setupTabletServerTestCustom(t, config, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	dir, err := ioutil.TempDir("", "tablet_config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`
+oltp:
+  queryTimeoutSeconds: 10
+  maxRpws: 5
+oltpReadPool:
+  size: 8
+txPool:
+  prefillParallelism: 3
+consolidator: disable
+hotRowProtection:
+  mode: enable
+`), 0644))
+
+	tsv.InitConfigReload(config, file, "", 0)
+	assert.Equal(t, 10*time.Second, tsv.QueryTimeout.Get())
+	assert.Equal(t, 5, tsv.MaxResultSize())
+	assert.Equal(t, 8, tsv.PoolSize())
+	assert.Equal(t, tabletenv.Disable, tsv.qe.consolidatorMode.Get())
+	assert.True(t, tsv.enableHotRowProtection.Get())
+
+	source := "file " + file
+	status := getConfigStatus(t, tsv)
+	assert.Equal(t, configValue{Value: "10", Source: source}, status.Values["oltp.queryTimeoutSeconds"])
+	assert.Equal(t, configValue{Value: "0", Source: sourceFlags}, status.Values["oltp.warnRows"])
+	// The prefill parallelism only applies after a restart.
+	assert.Equal(t, configValue{Value: "0", Source: sourceFlags}, status.Values["txPool.prefillParallelism"])
+	assert.Equal(t, map[string]string{"txPool.prefillParallelism": "3"}, status.RestartRequired)
+	require.Len(t, status.History, 1)
+	assert.Equal(t, source, status.History[0].Source)
+	assert.Contains(t, status.History[0].Changes, configValueChange{Key: "oltp.maxRpws", Old: "10000", New: "5"})
+	assert.Equal(t, []string{"txPool.prefillParallelism"}, status.History[0].RestartRequired)
+
+	// An invalid config is not applied.
+	require.NoError(t, ioutil.WriteFile(file, []byte(`
+oltp:
+  maxRpws: 6
+oltpReadPool:
+  size: 100
+`), 0644))
+	assert.EqualError(t, tsv.configReloader.reloadFile(false), "cannot reload the tablet config from "+source+": oltpReadPool.size can't be raised above 16 without a restart, set oltpReadPool.maxSize to allow it (specified value: 100)")
+	assert.Equal(t, 5, tsv.MaxResultSize())
+	assert.Equal(t, 8, tsv.PoolSize())
+	status = getConfigStatus(t, tsv)
+	require.Len(t, status.History, 2)
+	assert.Equal(t, "oltpReadPool.size can't be raised above 16 without a restart, set oltpReadPool.maxSize to allow it (specified value: 100)", status.History[1].Error)
+
+	// The ticker only reloads the file if it changed.
+	assert.NoError(t, tsv.configReloader.reloadFile(true))
+	assert.Len(t, getConfigStatus(t, tsv).History, 2)
+
+	// SIGHUP reloads the file. The settings which are not in the file any
+	// more go back to the values of the flags.
+	require.NoError(t, ioutil.WriteFile(file, []byte(`
+oltp:
+  maxRpws: 7
+`), 0644))
+	syscall.Kill(syscall.Getpid(), syscall.SIGHUP)
+	waitForConfig(t, "the SIGHUP reload", func() bool { return tsv.MaxResultSize() == 7 })
+	assert.Equal(t, 30*time.Second, tsv.QueryTimeout.Get())
+	assert.Equal(t, 16, tsv.PoolSize())
+	assert.Equal(t, tabletenv.Enable, tsv.qe.consolidatorMode.Get())
+	assert.False(t, tsv.enableHotRowProtection.Get())
+	assert.Empty(t, getConfigStatus(t, tsv).RestartRequired)
+}
+
+func TestConfigReloadTopo(t *testing.T) {
+	defer func(saved time.Duration) { configWatchRetryDelay = saved }(configWatchRetryDelay)
+	configWatchRetryDelay = 10 * time.Millisecond
+
+	config := tabletenv.NewDefaultConfig()
+	db, tsv := setupTabletServerTestCustom(t, config, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := tsv.topoServer.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	version, err := conn.Create(ctx, "vttablet/config.yaml", []byte("oltp:\n  warnRows: 3\n"))
+	require.NoError(t, err)
+
+	tsv.InitConfigReload(config, "", "vttablet/config.yaml", 0)
+	waitForConfig(t, "the topo config", func() bool { return tsv.WarnResultSize() == 3 })
+	assert.Equal(t, configValue{Value: "3", Source: "topo vttablet/config.yaml"}, getConfigStatus(t, tsv).Values["oltp.warnRows"])
+
+	version, err = conn.Update(ctx, "vttablet/config.yaml", []byte("oltp:\n  warnRows: 4\n"), version)
+	require.NoError(t, err)
+	waitForConfig(t, "the topo config update", func() bool { return tsv.WarnResultSize() == 4 })
+
+	// Deleting the config goes back to the flags.
+	require.NoError(t, conn.Delete(ctx, "vttablet/config.yaml", version))
+	waitForConfig(t, "the topo config deletion", func() bool { return tsv.WarnResultSize() == 0 })
+	assert.Equal(t, configValue{Value: "0", Source: sourceFlags}, getConfigStatus(t, tsv).Values["oltp.warnRows"])
+}
+
+func TestApplyLiveConfig(t *testing.T) {
+	config := tabletenv.NewDefaultConfig()
+	db, tsv := setupTabletServerTestCustom(t, config, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	next := config.Clone()
+	next.TxPool.Size = 10
+	next.OlapReadPool.IdleTimeoutSeconds = 60
+	next.Oltp.TxTimeoutSeconds = 5
+	next.QueryCacheSize = 10
+	next.HotRowProtection.MaxConcurrency = 2
+	next.EnableTransactionLimit = true
+	require.NoError(t, tsv.applyLiveConfig(config, next))
+	assert.Equal(t, 10, tsv.TxPoolSize())
+	assert.Equal(t, 60*time.Second, tsv.qe.streamConns.IdleTimeout())
+	assert.Equal(t, 5*time.Second, tsv.TxTimeout())
+	assert.Equal(t, 10, tsv.QueryPlanCacheCap())
+
+	for _, tcase := range []struct {
+		change func(*tabletenv.TabletConfig)
+		err    string
+	}{{
+		change: func(c *tabletenv.TabletConfig) { c.OltpReadPool.Size = 0 },
+		err:    "oltpReadPool.size must be > 0 (specified value: 0)",
+	}, {
+		change: func(c *tabletenv.TabletConfig) { c.Consolidator = "sometimes" },
+		err:    "invalid consolidator mode: sometimes",
+	}, {
+		change: func(c *tabletenv.TabletConfig) { c.HotRowProtection.Mode = "sometimes" },
+		err:    "invalid hot row protection mode: sometimes",
+	}, {
+		change: func(c *tabletenv.TabletConfig) { c.HotRowProtection.MaxConcurrency = 0 },
+		err:    "-hot_row_protection_concurrent_transactions must be > 0 (specified value: 0)",
+	}, {
+		change: func(c *tabletenv.TabletConfig) { c.TxThrottlerConfig = "no_such_field: 1" },
+		err:    "invalid txThrottlerConfig: line 1.0: unknown field name \"no_such_field\" in throttlerdata.Configuration",
+	}} {
+		invalid := next.Clone()
+		tcase.change(invalid)
+		assert.EqualError(t, tsv.applyLiveConfig(next, invalid), tcase.err)
+	}
+	assert.Equal(t, 10, tsv.TxPoolSize())
+}
@@ -50,6 +50,7 @@ type Pool struct {
 	mu                 sync.Mutex
 	connections        *pools.ResourcePool
 	capacity           int
+	maxCap             int
 	prefillParallelism int
 	timeout            time.Duration
 	idleTimeout        time.Duration
@@ -67,6 +68,7 @@ func NewPool(env tabletenv.Env, name string, cfg tabletenv.ConnPoolConfig) *Pool
 		env:                env,
 		name:               name,
 		capacity:           cfg.Size,
+		maxCap:             cfg.MaxSize,
 		prefillParallelism: cfg.PrefillParallelism,
 		timeout:            cfg.TimeoutSeconds.Get(),
 		idleTimeout:        idleTimeout,
@@ -109,7 +111,11 @@ func (cp *Pool) Open(appParams, dbaParams, appDebugParams dbconfigs.Connector) {
 	f := func(ctx context.Context) (pools.Resource, error) {
 		return NewDBConn(ctx, cp, appParams)
 	}
-	cp.connections = pools.NewResourcePool(f, cp.capacity, cp.capacity, cp.idleTimeout, cp.prefillParallelism, cp.getLogWaitCallback())
+	maxCap := cp.capacity
+	if cp.maxCap > maxCap {
+		maxCap = cp.maxCap
+	}
+	cp.connections = pools.NewResourcePool(f, cp.capacity, maxCap, cp.idleTimeout, cp.prefillParallelism, cp.getLogWaitCallback())
 	cp.appDebugParams = appDebugParams
 
 	cp.dbaPool.Open(dbaParams)
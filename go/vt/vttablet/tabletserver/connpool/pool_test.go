@@ -68,6 +68,21 @@ func TestConnPoolTimeout(t *testing.T) {
 	assert.EqualError(t, err, "resource pool timed out")
 }
 
+func TestConnPoolMaxSize(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	connPool := NewPool(tabletenv.NewEnv(nil, "PoolTest"), "TestPool", tabletenv.ConnPoolConfig{
+		Size:    1,
+		MaxSize: 3,
+	})
+	connPool.Open(db.ConnParams(), db.ConnParams(), db.ConnParams())
+	defer connPool.Close()
+	assert.EqualValues(t, 3, connPool.MaxCap())
+	require.NoError(t, connPool.SetCapacity(3))
+	assert.EqualValues(t, 3, connPool.Capacity())
+	assert.Error(t, connPool.SetCapacity(4))
+}
+
 func TestConnPoolMaxWaiters(t *testing.T) {
 	db := fakesqldb.New(t)
 	defer db.Close()
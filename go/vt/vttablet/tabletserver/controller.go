@@ -27,6 +27,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 
 	"time"
 
@@ -93,6 +94,9 @@ type Controller interface {
 
 	// TopoServer returns the topo server.
 	TopoServer() *topo.Server
+
+	// ReadTwopcInflight returns the 2pc transactions stored on this tablet.
+	ReadTwopcInflight(ctx context.Context, target *querypb.Target) (distributed []*tx.DistributedTx, prepared, failed []*tx.PreparedTx, err error)
 }
 
 // Ensure TabletServer satisfies Controller interface.
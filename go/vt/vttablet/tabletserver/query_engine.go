@@ -64,6 +64,38 @@ type TabletPlan struct {
 	MysqlTime  time.Duration
 	RowCount   int64
 	ErrorCount int64
+	// columnAccess caches the result of the column rules of the table
+	// ACL by the restricted columns of the callers.
+	columnAccess map[string]*columnAccess
+}
+
+// columnAccess is the result of the column rules of the table ACL for the
+// callers who have the same restricted columns: either an error, or the
+// plan of the query with the masks applied, which is nil if the query is
+// left as is.
+type columnAccess struct {
+	err   error
+	query string
+	plan  *TabletPlan
+}
+
+// maxColumnAccess bounds the number of column access results cached by
+// a plan.
+const maxColumnAccess = 100
+
+func (ep *TabletPlan) getColumnAccess(key string) *columnAccess {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	return ep.columnAccess[key]
+}
+
+func (ep *TabletPlan) setColumnAccess(key string, access *columnAccess) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	if ep.columnAccess == nil || len(ep.columnAccess) >= maxColumnAccess {
+		ep.columnAccess = make(map[string]*columnAccess)
+	}
+	ep.columnAccess[key] = access
 }
 
 // Size allows TabletPlan to be in cache.LRUCache.
@@ -150,11 +182,13 @@ type QueryEngine struct {
 
 	strictTransTables bool
 
-	consolidatorMode            string
+	consolidatorMode            sync2.AtomicString
 	enableQueryPlanFieldCaching bool
 
 	// stats
 	queryCounts, queryTimes, queryRowCounts, queryErrorCounts *stats.CountersWithMultiLabels
+	// queryDigests is nil unless the query digests are enabled.
+	queryDigests *stats.QueryDigests
 
 	// Loggers
 	accessCheckerLogger *logutil.ThrottledLogger
@@ -175,7 +209,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 
 	qe.conns = connpool.NewPool(env, "ConnPool", config.OltpReadPool)
 	qe.streamConns = connpool.NewPool(env, "StreamConnPool", config.OlapReadPool)
-	qe.consolidatorMode = config.Consolidator
+	qe.consolidatorMode.Set(config.Consolidator)
 	qe.enableQueryPlanFieldCaching = config.CacheResultFields
 	qe.consolidator = sync2.NewConsolidator()
 	qe.txSerializer = txserializer.New(env)
@@ -223,6 +257,10 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	qe.queryTimes = env.Exporter().NewCountersWithMultiLabels("QueryTimesNs", "query times in ns", []string{"Table", "Plan"})
 	qe.queryRowCounts = env.Exporter().NewCountersWithMultiLabels("QueryRowCounts", "query row counts", []string{"Table", "Plan"})
 	qe.queryErrorCounts = env.Exporter().NewCountersWithMultiLabels("QueryErrorCounts", "query error counts", []string{"Table", "Plan"})
+	if config.QueryDigestsCapacity > 0 {
+		qe.queryDigests = stats.NewQueryDigests("", "query statistics by fingerprint", config.QueryDigestsCapacity)
+		env.Exporter().Publish("QueryDigests", qe.queryDigests)
+	}
 
 	env.Exporter().HandleFunc("/debug/hotrows", qe.txSerializer.ServeHTTP)
 	env.Exporter().HandleFunc("/debug/tablet_plans", qe.handleHTTPQueryPlans)
@@ -435,6 +473,15 @@ func (qe *QueryEngine) AddStats(planName, tableName string, queryCount int64, du
 	qe.queryErrorCounts.Add(keys, errorCount)
 }
 
+// recordQueryDigest adds an execution of query to the query digests. The
+// fingerprint of the query is its text, which vtgate has normalized.
+func (qe *QueryEngine) recordQueryDigest(query string, duration time.Duration, rowsAffected, rowsReturned int64, err error) {
+	if qe.queryDigests == nil {
+		return
+	}
+	qe.queryDigests.Record(sqlparser.TruncateForUI(query), duration, rowsAffected, rowsReturned, 0, err != nil)
+}
+
 type perQueryStats struct {
 	Query      string
 	Table      string
@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/audit"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/callinfo"
 	"vitess.io/vitess/go/vt/log"
@@ -74,6 +75,7 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		duration := time.Since(start)
 		qre.tsv.stats.QueryTimings.Add(planName, duration)
 		qre.recordUserQuery("Execute", int64(duration))
+		qre.recordAudit(reply, err)
 
 		mysqlTime := qre.logStats.MysqlResponseTime
 		tableName := qre.plan.TableName().String()
@@ -84,10 +86,12 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		if reply == nil {
 			qre.tsv.qe.AddStats(planName, tableName, 1, duration, mysqlTime, 0, 1)
 			qre.plan.AddStats(1, duration, mysqlTime, 0, 1)
+			qre.tsv.qe.recordQueryDigest(qre.query, duration, 0, 0, err)
 			return
 		}
 		qre.tsv.qe.AddStats(planName, tableName, 1, duration, mysqlTime, int64(reply.RowsAffected), 0)
 		qre.plan.AddStats(1, duration, mysqlTime, int64(reply.RowsAffected), 0)
+		qre.tsv.qe.recordQueryDigest(qre.query, duration, int64(reply.RowsAffected), int64(len(reply.Rows)), nil)
 		qre.logStats.RowsAffected = int(reply.RowsAffected)
 		qre.logStats.Rows = reply.Rows
 		qre.tsv.Stats().ResultHistogram.Add(int64(len(reply.Rows)))
@@ -227,12 +231,15 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 }
 
 // Stream performs a streaming query execution.
-func (qre *QueryExecutor) Stream(callback func(*sqltypes.Result) error) error {
+func (qre *QueryExecutor) Stream(callback func(*sqltypes.Result) error) (err error) {
 	qre.logStats.PlanType = qre.plan.PlanID.String()
 
+	var rowsReturned int64
 	defer func(start time.Time) {
 		qre.tsv.stats.QueryTimings.Record(qre.plan.PlanID.String(), start)
 		qre.recordUserQuery("Stream", int64(time.Since(start)))
+		qre.tsv.qe.recordQueryDigest(qre.query, time.Since(start), 0, rowsReturned, err)
+		qre.recordAudit(nil, err)
 	}(time.Now())
 
 	if err := qre.checkPermissions(); err != nil {
@@ -261,7 +268,10 @@ func (qre *QueryExecutor) Stream(callback func(*sqltypes.Result) error) error {
 	qre.tsv.qe.streamQList.Add(qd)
 	defer qre.tsv.qe.streamQList.Remove(qd)
 
-	return qre.streamFetch(conn, qre.plan.FullQuery, qre.bindVars, callback)
+	return qre.streamFetch(conn, qre.plan.FullQuery, qre.bindVars, func(qr *sqltypes.Result) error {
+		rowsReturned += int64(len(qr.Rows))
+		return callback(qr)
+	})
 }
 
 // MessageStream streams messages from a message table.
@@ -349,9 +359,89 @@ func (qre *QueryExecutor) checkPermissions() error {
 		}
 	}
 
+	return qre.checkColumnAccess(callerID)
+}
+
+// checkColumnAccess enforces the column rules of the table ACL: the masked
+// columns selected by the query are replaced by their mask, and the query
+// fails if it uses a denied column. The queries sent by vtgate are already
+// rewritten, and are left as is. The result is cached by the plan for the
+// callers who have the same restricted columns, so that a query is only
+// checked once for them.
+func (qre *QueryExecutor) checkColumnAccess(callerID *querypb.VTGateCallerID) error {
+	if qre.tsv.qe.enableTableACLDryRun || qre.plan.PlanID == planbuilder.PlanMessageStream {
+		return nil
+	}
+	var key strings.Builder
+	for _, perm := range qre.plan.Permissions {
+		if restricted := tableacl.RestrictedColumns(perm.TableName, callerID); len(restricted) != 0 {
+			key.WriteString(perm.TableName + restricted.String() + ";")
+		}
+	}
+	if key.Len() == 0 {
+		return nil
+	}
+	access := qre.plan.getColumnAccess(key.String())
+	if access == nil {
+		var err error
+		if access, err = qre.buildColumnAccess(callerID); err != nil {
+			return err
+		}
+		qre.plan.setColumnAccess(key.String(), access)
+	}
+	if access.err != nil {
+		qre.tsv.Stats().TableaclDenied.Add([]string{qre.plan.TableName().String(), "columns", qre.plan.PlanID.String(), callerID.Username}, 1)
+		return access.err
+	}
+	if access.plan != nil {
+		qre.query, qre.plan = access.query, access.plan
+	}
 	return nil
 }
 
+// buildColumnAccess applies the column rules of the table ACL to the query.
+// It only returns an error if the query with the masks can't be planned.
+func (qre *QueryExecutor) buildColumnAccess(callerID *querypb.VTGateCallerID) (*columnAccess, error) {
+	stmt, err := sqlparser.Parse(qre.query)
+	if err != nil {
+		return nil, err
+	}
+	masked, err := tableacl.EnforceColumnRestrictions(stmt, func(table string) tableacl.ColumnRestrictions {
+		return tableacl.RestrictedColumns(table, callerID)
+	})
+	if err != nil {
+		return &columnAccess{err: err}, nil
+	}
+	if !masked {
+		return &columnAccess{}, nil
+	}
+	query := sqlparser.String(stmt)
+	var plan *TabletPlan
+	if qre.plan.PlanID == planbuilder.PlanSelectStream {
+		plan, err = qre.tsv.qe.GetStreamPlan(query, false /* isReservedConn */)
+	} else {
+		plan, err = qre.tsv.qe.GetPlan(qre.ctx, qre.logStats, query, false /* skipQueryPlanCache */, qre.logStats.ReservedID != 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &columnAccess{query: query, plan: plan}, nil
+}
+
+// recordAudit records the schema changes and the DML statements on the
+// audited tables in the audit log.
+func (qre *QueryExecutor) recordAudit(reply *sqltypes.Result, err error) {
+	var rowsAffected uint64
+	if reply != nil {
+		rowsAffected = reply.RowsAffected
+	}
+	var tables []string
+	for _, perm := range qre.plan.Permissions {
+		tables = append(tables, perm.TableName)
+	}
+	audit.Query(qre.ctx, sqlparser.Preview(qre.query), qre.tsv.sm.Target().Keyspace, qre.query, tables, rowsAffected, err)
+}
+
 func (qre *QueryExecutor) checkAccess(authorized *tableacl.ACLResult, tableName string, callerID *querypb.VTGateCallerID) error {
 	statsKey := []string{tableName, authorized.GroupName, qre.plan.PlanID.String(), callerID.Username}
 	if !authorized.IsMember(callerID) {
@@ -577,7 +667,8 @@ func (qre *QueryExecutor) qFetch(logStats *tabletenv.LogStats, parsedQuery *sqlp
 		return nil, err
 	}
 	// Check tablet type.
-	if qre.tsv.qe.consolidatorMode == tabletenv.Enable || (qre.tsv.qe.consolidatorMode == tabletenv.NotOnMaster && qre.tabletType != topodatapb.TabletType_MASTER) {
+	consolidatorMode := qre.tsv.qe.consolidatorMode.Get()
+	if consolidatorMode == tabletenv.Enable || (consolidatorMode == tabletenv.NotOnMaster && qre.tabletType != topodatapb.TabletType_MASTER) {
 		q, original := qre.tsv.qe.consolidator.Create(string(sqlWithoutComments))
 		if original {
 			defer q.Broadcast()
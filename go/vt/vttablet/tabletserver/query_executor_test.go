@@ -789,6 +789,76 @@ func TestQueryExecutorTableAclNoPermission(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorTableAclColumns(t *testing.T) {
+	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int63())
+	tableacl.Register(aclName, &simpleacl.Factory{})
+	tableacl.SetDefaultACL(aclName)
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	want := &sqltypes.Result{
+		Fields: []*querypb.Field{{Name: "pk", Type: sqltypes.Int32}, {Name: "name", Type: sqltypes.VarChar}},
+	}
+	db.AddQuery("select pk, sha2(name, 256) as name from test_table limit 10001", want)
+	db.AddQuery("select pk, sha2(name, 256) as name from test_table where 1 != 1", want)
+	db.AddQuery("select pk, name from test_table where 1 != 1", want)
+	db.AddQuery("select pk from test_table where 1 != 1", want)
+
+	callerID := &querypb.VTGateCallerID{Username: "u2"}
+	ctx := callerid.NewContext(context.Background(), nil, callerID)
+	config := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{{
+			Name:                 "group01",
+			TableNamesOrPrefixes: []string{"test_table"},
+			Readers:              []string{"u2"},
+			ColumnRules: []*tableaclpb.ColumnRule{{
+				Columns: []string{"name"},
+				Readers: []string{"superuser"},
+				Mask:    tableacl.MaskHash,
+			}, {
+				Columns: []string{"addr"},
+				Readers: []string{"superuser"},
+			}},
+		}},
+	}
+	if err := tableacl.InitFromProto(config); err != nil {
+		t.Fatalf("unable to load tableacl config, error: %v", err)
+	}
+	defer func() {
+		_ = tableacl.InitFromProto(&tableaclpb.Config{})
+	}()
+
+	tsv := newTestTabletServer(ctx, enableStrictTableACL, db)
+	defer tsv.StopService()
+
+	qre := newTestQueryExecutor(ctx, tsv, "select pk, name from test_table", 0)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, "select pk, sha2(name, 256) as name from test_table", qre.query)
+
+	// The result is cached by the plan of the query.
+	plan, err := tsv.qe.GetPlan(ctx, tabletenv.NewLogStats(ctx, "Test"), "select pk, name from test_table", false, false)
+	require.NoError(t, err)
+	require.Len(t, plan.columnAccess, 1)
+	for _, access := range plan.columnAccess {
+		assert.Equal(t, "select pk, sha2(name, 256) as name from test_table", access.query)
+		assert.True(t, access.plan == qre.plan)
+	}
+	qre = newTestQueryExecutor(ctx, tsv, "select pk, name from test_table", 0)
+	got, err = qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, "select pk, sha2(name, 256) as name from test_table", qre.query)
+
+	for i := 0; i < 2; i++ {
+		qre = newTestQueryExecutor(ctx, tsv, "select pk from test_table where addr = 1", 0)
+		_, err = qre.Execute()
+		require.EqualError(t, err, "table acl error: access denied to column addr of table test_table")
+		assert.Equal(t, vtrpcpb.Code_PERMISSION_DENIED, vterrors.Code(err))
+	}
+	assert.EqualValues(t, 2, tsv.Stats().TableaclDenied.Counts()["test_table.columns.Select.u2"])
+}
+
 func TestQueryExecutorTableAclDualTableExempt(t *testing.T) {
 	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int63())
 	tableacl.Register(aclName, &simpleacl.Factory{})
@@ -1068,6 +1138,37 @@ func TestQueryExecutorBlacklistQRRetry(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorQueryDigests(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	fields := sqltypes.MakeTestFields("a|b", "int64|varchar")
+	db.AddQuery("select * from t where 1 != 1", sqltypes.MakeTestResult(fields))
+	db.AddQuery("select * from t limit 10001", sqltypes.MakeTestResult(fields, "1|aaa"))
+	db.AddQuery("select * from t", sqltypes.MakeTestResult(fields, "1|aaa", "2|bbb"))
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableQueryDigests, db)
+	defer tsv.StopService()
+
+	target := tsv.sm.Target()
+	for i := 0; i < 2; i++ {
+		_, err := tsv.Execute(ctx, &target, "select * from t", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	err := tsv.StreamExecute(ctx, &target, "select * from t", nil, 0, nil, func(*sqltypes.Result) error { return nil })
+	require.NoError(t, err)
+	_, err = tsv.Execute(ctx, &target, "select * from t where a = 1", nil, 0, 0, nil)
+	require.Error(t, err)
+
+	all := tsv.qe.queryDigests.Snapshot()
+	require.Len(t, all, 2)
+	assert.Equal(t, "select * from t", all[0].Query)
+	assert.EqualValues(t, 3, all[0].Count)
+	assert.EqualValues(t, 0, all[0].Errors)
+	assert.EqualValues(t, 4, all[0].RowsReturned)
+	assert.Equal(t, "select * from t where a = 1", all[1].Query)
+	assert.EqualValues(t, 1, all[1].Errors)
+}
+
 type executorFlags int64
 
 const (
@@ -1077,6 +1178,7 @@ const (
 	noTwopc
 	shortTwopcAge
 	smallResultSize
+	enableQueryDigests
 )
 
 // newTestQueryExecutor uses a package level variable testTabletServer defined in tabletserver_test.go
@@ -1107,6 +1209,9 @@ func newTestTabletServer(ctx context.Context, flags executorFlags, db *fakesqldb
 	if flags&smallResultSize > 0 {
 		config.Oltp.MaxRows = 2
 	}
+	if flags&enableQueryDigests > 0 {
+		config.QueryDigestsCapacity = 10
+	}
 	tsv := NewTabletServer("TabletServerTest", config, memorytopo.NewServer(""), topodatapb.TabletAlias{})
 	dbconfigs := newDBConfigs(db)
 	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
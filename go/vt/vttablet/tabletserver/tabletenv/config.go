@@ -83,12 +83,15 @@ var (
 
 func init() {
 	flag.IntVar(&currentConfig.OltpReadPool.Size, "queryserver-config-pool-size", defaultConfig.OltpReadPool.Size, "query server read pool size, connection pool is used by regular queries (non streaming, not in a transaction)")
+	flag.IntVar(&currentConfig.OltpReadPool.MaxSize, "queryserver-config-pool-max-size", defaultConfig.OltpReadPool.MaxSize, "query server read pool max size, the size the read pool can be raised to by a config reload without a restart. Defaults to the pool size.")
 	flag.IntVar(&currentConfig.OltpReadPool.PrefillParallelism, "queryserver-config-pool-prefill-parallelism", defaultConfig.OltpReadPool.PrefillParallelism, "query server read pool prefill parallelism, a non-zero value will prefill the pool using the specified parallism.")
 	flag.IntVar(&currentConfig.OlapReadPool.Size, "queryserver-config-stream-pool-size", defaultConfig.OlapReadPool.Size, "query server stream connection pool size, stream pool is used by stream queries: queries that return results to client in a streaming fashion")
+	flag.IntVar(&currentConfig.OlapReadPool.MaxSize, "queryserver-config-stream-pool-max-size", defaultConfig.OlapReadPool.MaxSize, "query server stream pool max size, the size the stream pool can be raised to by a config reload without a restart. Defaults to the pool size.")
 	flag.IntVar(&currentConfig.OlapReadPool.PrefillParallelism, "queryserver-config-stream-pool-prefill-parallelism", defaultConfig.OlapReadPool.PrefillParallelism, "query server stream pool prefill parallelism, a non-zero value will prefill the pool using the specified parallelism")
 	flag.IntVar(&deprecatedMessagePoolSize, "queryserver-config-message-conn-pool-size", 0, "DEPRECATED")
 	flag.IntVar(&deprecatedMessagePoolPrefillParallelism, "queryserver-config-message-conn-pool-prefill-parallelism", 0, "DEPRECATED: Unused.")
 	flag.IntVar(&currentConfig.TxPool.Size, "queryserver-config-transaction-cap", defaultConfig.TxPool.Size, "query server transaction cap is the maximum number of transactions allowed to happen at any given point of a time for a single vttablet. E.g. by setting transaction cap to 100, there are at most 100 transactions will be processed by a vttablet and the 101th transaction will be blocked (and fail if it cannot get connection within specified timeout)")
+	flag.IntVar(&currentConfig.TxPool.MaxSize, "queryserver-config-transaction-max-cap", defaultConfig.TxPool.MaxSize, "query server transaction max cap, the value the transaction cap can be raised to by a config reload without a restart. Defaults to the transaction cap.")
 	flag.IntVar(&currentConfig.TxPool.PrefillParallelism, "queryserver-config-transaction-prefill-parallelism", defaultConfig.TxPool.PrefillParallelism, "query server transaction prefill parallelism, a non-zero value will prefill the pool using the specified parallism.")
 	flag.IntVar(&currentConfig.MessagePostponeParallelism, "queryserver-config-message-postpone-cap", defaultConfig.MessagePostponeParallelism, "query server message postpone cap is the maximum number of messages that can be postponed at any given time. Set this number to substantially lower than transaction cap, so that the transaction pool isn't exhausted by the message subsystem.")
 	flag.IntVar(&deprecatedFoundRowsPoolSize, "client-found-rows-pool-size", 0, "DEPRECATED: queryserver-config-transaction-cap will be used instead.")
@@ -102,6 +105,7 @@ func init() {
 
 	flag.IntVar(&currentConfig.StreamBufferSize, "queryserver-config-stream-buffer-size", defaultConfig.StreamBufferSize, "query server stream buffer size, the maximum number of bytes sent from vttablet for each stream call. It's recommended to keep this value in sync with vtgate's stream_buffer_size.")
 	flag.IntVar(&currentConfig.QueryCacheSize, "queryserver-config-query-cache-size", defaultConfig.QueryCacheSize, "query server query cache size, maximum number of queries to be cached. vttablet analyzes every incoming query and generate a query plan, these plans are being cached in a lru cache. This config controls the capacity of the lru cache.")
+	flag.IntVar(&currentConfig.QueryDigestsCapacity, "queryserver-config-query-digests-capacity", defaultConfig.QueryDigestsCapacity, "query server query digests capacity, maximum number of query fingerprints whose latency, rows and errors are tracked in /debug/query_digests and the QueryDigests metrics. The least frequent fingerprints are dropped. 0 disables the query digests.")
 	SecondsVar(&currentConfig.SchemaReloadIntervalSeconds, "queryserver-config-schema-reload-time", defaultConfig.SchemaReloadIntervalSeconds, "query server schema reload time, how often vttablet reloads schemas from underlying MySQL instance in seconds. vttablet keeps table schemas in its own memory and periodically refreshes it from MySQL. This config controls the reload time.")
 	SecondsVar(&currentConfig.Oltp.QueryTimeoutSeconds, "queryserver-config-query-timeout", defaultConfig.Oltp.QueryTimeoutSeconds, "query server query timeout (in seconds), this is the query timeout in vttablet side. If a query takes more than this timeout, it will be killed.")
 	SecondsVar(&currentConfig.OltpReadPool.TimeoutSeconds, "queryserver-config-query-pool-timeout", defaultConfig.OltpReadPool.TimeoutSeconds, "query server query pool timeout (in seconds), it is how long vttablet waits for a connection from the query pool. If set to 0 (default) then the overall query timeout is used instead.")
@@ -242,6 +246,7 @@ type TabletConfig struct {
 	PassthroughDML              bool    `json:"passthroughDML,omitempty"`
 	StreamBufferSize            int     `json:"streamBufferSize,omitempty"`
 	QueryCacheSize              int     `json:"queryCacheSize,omitempty"`
+	QueryDigestsCapacity        int     `json:"queryDigestsCapacity,omitempty"`
 	SchemaReloadIntervalSeconds Seconds `json:"schemaReloadIntervalSeconds,omitempty"`
 	WatchReplication            bool    `json:"watchReplication,omitempty"`
 	TrackSchemaVersions         bool    `json:"trackSchemaVersions,omitempty"`
@@ -259,19 +264,22 @@ type TabletConfig struct {
 	TwoPCAbandonAge         Seconds `json:"-"`
 
 	EnableTxThrottler           bool     `json:"-"`
-	TxThrottlerConfig           string   `json:"-"`
+	TxThrottlerConfig           string   `json:"txThrottlerConfig,omitempty"`
 	TxThrottlerHealthCheckCells []string `json:"-"`
 
 	EnableLagThrottler bool `json:"-"`
 
-	TransactionLimitConfig `json:"-"`
+	TransactionLimitConfig `json:"transactionLimit,omitempty"`
 
 	EnforceStrictTransTables bool `json:"-"`
 }
 
 // ConnPoolConfig contains the config for a conn pool.
+// MaxSize is the size the pool can be raised to without a restart.
+// It defaults to Size.
 type ConnPoolConfig struct {
 	Size               int     `json:"size,omitempty"`
+	MaxSize            int     `json:"maxSize,omitempty"`
 	TimeoutSeconds     Seconds `json:"timeoutSeconds,omitempty"`
 	IdleTimeoutSeconds Seconds `json:"idleTimeoutSeconds,omitempty"`
 	PrefillParallelism int     `json:"prefillParallelism,omitempty"`
@@ -319,13 +327,13 @@ type ReplicationTrackerConfig struct {
 // TransactionLimitConfig captures configuration of transaction pool slots
 // limiter configuration.
 type TransactionLimitConfig struct {
-	EnableTransactionLimit         bool
-	EnableTransactionLimitDryRun   bool
-	TransactionLimitPerUser        float64
-	TransactionLimitByUsername     bool
-	TransactionLimitByPrincipal    bool
-	TransactionLimitByComponent    bool
-	TransactionLimitBySubcomponent bool
+	EnableTransactionLimit         bool    `json:"enable,omitempty"`
+	EnableTransactionLimitDryRun   bool    `json:"dryRun,omitempty"`
+	TransactionLimitPerUser        float64 `json:"perUser,omitempty"`
+	TransactionLimitByUsername     bool    `json:"byUsername,omitempty"`
+	TransactionLimitByPrincipal    bool    `json:"byPrincipal,omitempty"`
+	TransactionLimitByComponent    bool    `json:"byComponent,omitempty"`
+	TransactionLimitBySubcomponent bool    `json:"bySubcomponent,omitempty"`
 }
 
 // NewCurrentConfig returns a copy of the current config.
@@ -75,6 +75,7 @@ oltpReadPool:
   size: 16
   timeoutSeconds: 10
 replicationTracker: {}
+transactionLimit: {}
 txPool: {}
 `
 	assert.Equal(t, wantBytes, string(gotBytes))
@@ -135,11 +136,28 @@ replicationTracker:
   mode: disable
 schemaReloadIntervalSeconds: 1800
 streamBufferSize: 32768
+transactionLimit:
+  byPrincipal: true
+  byUsername: true
+  perUser: 0.4
 txPool:
   idleTimeoutSeconds: 1800
   maxWaiters: 5000
   size: 20
   timeoutSeconds: 1
+txThrottlerConfig: |
+  target_replication_lag_sec: 2
+  max_replication_lag_sec: 10
+  initial_rate: 100
+  max_increase: 1
+  emergency_decrease: 0.5
+  min_duration_between_increases_sec: 40
+  max_duration_between_increases_sec: 62
+  min_duration_between_decreases_sec: 20
+  spread_backlog_across_sec: 20
+  age_bad_rate_after_sec: 180
+  bad_rate_increase: 0.1
+  max_rate_approach_threshold: 0.9
 `
 	assert.Equal(t, want, string(gotBytes))
 }
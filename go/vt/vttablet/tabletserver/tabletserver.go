@@ -21,12 +21,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-	"os/signal"
 	"sort"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	"golang.org/x/net/context"
@@ -62,6 +59,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txserializer"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txthrottler"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/vstreamer"
@@ -92,7 +90,7 @@ type TabletServer struct {
 	stats                  *tabletenv.Stats
 	QueryTimeout           sync2.AtomicDuration
 	TerseErrors            bool
-	enableHotRowProtection bool
+	enableHotRowProtection sync2.AtomicBool
 	topoServer             *topo.Server
 
 	// These are sub-components of TabletServer.
@@ -109,6 +107,9 @@ type TabletServer struct {
 	lagThrottler *throttle.Throttler
 	tableGC      *gc.TableGC
 
+	// configReloader applies the changes of the tablet config.
+	configReloader *configReloader
+
 	// sm manages state transitions.
 	sm                *stateManager
 	onlineDDLExecutor *onlineddl.Executor
@@ -144,7 +145,7 @@ func NewTabletServer(name string, config *tabletenv.TabletConfig, topoServer *to
 		config:                 config,
 		QueryTimeout:           sync2.NewAtomicDuration(config.Oltp.QueryTimeoutSeconds.Get()),
 		TerseErrors:            config.TerseErrors,
-		enableHotRowProtection: config.HotRowProtection.Mode != tabletenv.Disable,
+		enableHotRowProtection: sync2.NewAtomicBool(config.HotRowProtection.Mode != tabletenv.Disable),
 		topoServer:             topoServer,
 		alias:                  alias,
 	}
@@ -171,6 +172,7 @@ func NewTabletServer(name string, config *tabletenv.TabletConfig, topoServer *to
 	tsv.onlineDDLExecutor = onlineddl.NewExecutor(tsv, topoServer, tabletTypeFunc)
 	tsv.lagThrottler = throttle.NewThrottler(tsv, topoServer, tabletTypeFunc)
 	tsv.tableGC = gc.NewTableGC(tsv, topoServer, tabletTypeFunc, tsv.lagThrottler)
+	tsv.configReloader = newConfigReloader(tsv)
 
 	tsv.sm = &stateManager{
 		hs:          tsv.hs,
@@ -201,10 +203,12 @@ func NewTabletServer(name string, config *tabletenv.TabletConfig, topoServer *to
 	tsv.registerHealthzHealthHandler()
 	tsv.registerDebugHealthHandler()
 	tsv.registerQueryzHandler()
+	tsv.registerQueryDigestsHandler()
 	tsv.registerStreamQueryzHandlers()
 	tsv.registerTwopczHandler()
 	tsv.registerMigrationStatusHandler()
 	tsv.registerThrottlerHandlers()
+	tsv.registerDebugConfigHandler()
 
 	return tsv
 }
@@ -299,24 +303,9 @@ func (tsv *TabletServer) initACL(tableACLConfigFile string, enforceTableACLConfi
 
 // InitACL loads the table ACL and sets up a SIGHUP handler for reloading it.
 func (tsv *TabletServer) InitACL(tableACLConfigFile string, enforceTableACLConfig bool, reloadACLConfigFileInterval time.Duration) {
-	tsv.initACL(tableACLConfigFile, enforceTableACLConfig)
-
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGHUP)
-	go func() {
-		for range sigChan {
-			tsv.initACL(tableACLConfigFile, enforceTableACLConfig)
-		}
-	}()
-
-	if reloadACLConfigFileInterval != 0 {
-		ticker := time.NewTicker(reloadACLConfigFileInterval)
-		go func() {
-			for range ticker.C {
-				sigChan <- syscall.SIGHUP
-			}
-		}()
-	}
+	tableacl.InitAndReload(func() {
+		tsv.initACL(tableACLConfigFile, enforceTableACLConfig)
+	}, reloadACLConfigFileInterval)
 }
 
 // SetServingType changes the serving type of the tabletserver. It starts or
@@ -636,6 +625,26 @@ func (tsv *TabletServer) ReadTransaction(ctx context.Context, target *querypb.Ta
 	return metadata, err
 }
 
+// ReadTwopcInflight returns info about all in-flight 2pc transactions
+// stored on this tablet.
+func (tsv *TabletServer) ReadTwopcInflight(ctx context.Context, target *querypb.Target) (distributed []*tx.DistributedTx, prepared, failed []*tx.PreparedTx, err error) {
+	err = tsv.execRequest(
+		ctx, tsv.QueryTimeout.Get(),
+		"ReadTwopcInflight", "read_twopc_inflight", nil,
+		target, nil, true, /* allowOnShutdown */
+		func(ctx context.Context, logStats *tabletenv.LogStats) error {
+			txe := &TxExecutor{
+				ctx:      ctx,
+				logStats: logStats,
+				te:       tsv.te,
+			}
+			distributed, prepared, failed, err = txe.ReadTwopcInflight()
+			return err
+		},
+	)
+	return distributed, prepared, failed, err
+}
+
 // Execute executes the query and returns the result as response.
 func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (result *sqltypes.Result, err error) {
 	span, ctx := trace.NewSpan(ctx, "TabletServer.Execute")
@@ -760,7 +769,7 @@ func (tsv *TabletServer) ExecuteBatch(ctx context.Context, target *querypb.Targe
 		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot start a new transaction in the scope of an existing one")
 	}
 
-	if tsv.enableHotRowProtection && asTransaction {
+	if tsv.enableHotRowProtection.Get() && asTransaction {
 		// Serialize transactions which target the same hot row range.
 		// NOTE: We put this intentionally at this place *before* StartRequest()
 		// gets called below. Otherwise, the StartRequest()/EndRequest() section from
@@ -839,7 +848,7 @@ func (tsv *TabletServer) ExecuteBatch(ctx context.Context, target *querypb.Targe
 func (tsv *TabletServer) BeginExecute(ctx context.Context, target *querypb.Target, preQueries []string, sql string, bindVariables map[string]*querypb.BindVariable, reservedID int64, options *querypb.ExecuteOptions) (*sqltypes.Result, int64, *topodatapb.TabletAlias, error) {
 
 	// Disable hot row protection in case of reserve connection.
-	if tsv.enableHotRowProtection && reservedID == 0 {
+	if tsv.enableHotRowProtection.Get() && reservedID == 0 {
 		txDone, err := tsv.beginWaitForSameRangeTransactions(ctx, target, options, sql, bindVariables)
 		if err != nil {
 			return nil, 0, nil, err
@@ -1495,6 +1504,28 @@ func (tsv *TabletServer) registerQueryzHandler() {
 	})
 }
 
+func (tsv *TabletServer) registerQueryDigestsHandler() {
+	tsv.exporter.HandleFunc("/debug/query_digests", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		digests := []stats.QueryDigestStats{}
+		if tsv.qe.queryDigests != nil {
+			digests = tsv.qe.queryDigests.Snapshot()
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		b, err := json.MarshalIndent(digests, "", " ")
+		if err != nil {
+			w.Write([]byte(err.Error()))
+			return
+		}
+		buf := bytes.NewBuffer(nil)
+		json.HTMLEscape(buf, b)
+		w.Write(buf.Bytes())
+	})
+}
+
 func (tsv *TabletServer) registerStreamQueryzHandlers() {
 	tsv.exporter.HandleFunc("/streamqueryz", func(w http.ResponseWriter, r *http.Request) {
 		streamQueryzHandler(tsv.qe.streamQList, w, r)
@@ -1678,7 +1709,7 @@ func (tsv *TabletServer) SetPassthroughDMLs(val bool) {
 // SetConsolidatorMode sets the consolidator mode.
 // This function should only be used for testing.
 func (tsv *TabletServer) SetConsolidatorMode(mode string) {
-	tsv.qe.consolidatorMode = mode
+	tsv.qe.consolidatorMode.Set(mode)
 }
 
 // queryAsString returns a readable version of query+bind variables.
@@ -97,6 +97,10 @@ type TxEngine struct {
 	// reservedConnStats keeps statistics about reserved connections
 	reservedConnStats *servenv.TimingsWrapper
 
+	// limiter is the transaction limiter of txPool. Its config can be
+	// changed at runtime.
+	limiter *txlimiter.Dynamic
+
 	txPool       *TxPool
 	preparedPool *TxPreparedPool
 	twoPC        *TwoPC
@@ -110,8 +114,8 @@ func NewTxEngine(env tabletenv.Env) *TxEngine {
 		shutdownGracePeriod: config.GracePeriods.TransactionShutdownSeconds.Get(),
 		reservedConnStats:   env.Exporter().NewTimings("ReservedConnections", "Reserved connections stats", "operation"),
 	}
-	limiter := txlimiter.New(env)
-	te.txPool = NewTxPool(env, limiter)
+	te.limiter = txlimiter.NewDynamic(env)
+	te.txPool = NewTxPool(env, te.limiter)
 	te.twopcEnabled = config.TwoPCEnable
 	if te.twopcEnabled {
 		if config.TwoPCCoordinatorAddress == "" {
@@ -51,7 +51,16 @@ func New(env tabletenv.Env) TxLimiter {
 	if !config.EnableTransactionLimit && !config.EnableTransactionLimitDryRun {
 		return &TxAllowAll{}
 	}
+	rejections, rejectionsDryRun := newRejectionCounters(env)
+	return newImpl(config, rejections, rejectionsDryRun)
+}
+
+func newRejectionCounters(env tabletenv.Env) (rejections, rejectionsDryRun *stats.CountersWithSingleLabel) {
+	return env.Exporter().NewCountersWithSingleLabel("TxLimiterRejections", "rejections from TxLimiter", "user"),
+		env.Exporter().NewCountersWithSingleLabel("TxLimiterRejectionsDryRun", "rejections from TxLimiter in dry run", "user")
+}
 
+func newImpl(config *tabletenv.TabletConfig, rejections, rejectionsDryRun *stats.CountersWithSingleLabel) *Impl {
 	return &Impl{
 		maxPerUser:       int64(float64(config.TxPool.Size) * config.TransactionLimitPerUser),
 		dryRun:           config.EnableTransactionLimitDryRun,
@@ -61,11 +70,97 @@ func New(env tabletenv.Env) TxLimiter {
 		bySubcomponent:   config.TransactionLimitBySubcomponent,
 		byEffectiveUser:  config.TransactionLimitByPrincipal || config.TransactionLimitByComponent || config.TransactionLimitBySubcomponent,
 		usageMap:         make(map[string]int64),
-		rejections:       env.Exporter().NewCountersWithSingleLabel("TxLimiterRejections", "rejections from TxLimiter", "user"),
-		rejectionsDryRun: env.Exporter().NewCountersWithSingleLabel("TxLimiterRejectionsDryRun", "rejections from TxLimiter in dry run", "user"),
+		rejections:       rejections,
+		rejectionsDryRun: rejectionsDryRun,
+	}
+}
+
+// Dynamic is a TxLimiter whose config can be changed at runtime.
+// Implements TxLimiter.
+type Dynamic struct {
+	rejections, rejectionsDryRun *stats.CountersWithSingleLabel
+
+	mu      sync.Mutex
+	limiter TxLimiter
+	// slots are the transactions in flight by caller, which are counted
+	// by every new limiter.
+	slots map[string]*callerSlots
+}
+
+// callerSlots are the transactions in flight of a caller.
+type callerSlots struct {
+	immediate *querypb.VTGateCallerID
+	effective *vtrpcpb.CallerID
+	count     int64
+}
+
+// NewDynamic creates a Dynamic TxLimiter for the config of env.
+func NewDynamic(env tabletenv.Env) *Dynamic {
+	d := &Dynamic{slots: make(map[string]*callerSlots)}
+	d.rejections, d.rejectionsDryRun = newRejectionCounters(env)
+	d.SetConfig(env.Config())
+	return d
+}
+
+// SetConfig replaces the limiter with one for the transaction limit config
+// and the transaction pool size of config. The transactions in flight
+// are counted by the new limiter, and released against it.
+func (d *Dynamic) SetConfig(config *tabletenv.TabletConfig) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !config.EnableTransactionLimit && !config.EnableTransactionLimitDryRun {
+		d.limiter = &TxAllowAll{}
+		return
+	}
+	limiter := newImpl(config, d.rejections, d.rejectionsDryRun)
+	for _, slots := range d.slots {
+		limiter.usageMap[limiter.extractKey(slots.immediate, slots.effective)] += slots.count
+	}
+	d.limiter = limiter
+}
+
+// Get is part of the TxLimiter interface.
+func (d *Dynamic) Get(immediate *querypb.VTGateCallerID, effective *vtrpcpb.CallerID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.limiter.Get(immediate, effective) {
+		return false
+	}
+	key := callerKey(immediate, effective)
+	slots, ok := d.slots[key]
+	if !ok {
+		slots = &callerSlots{immediate: immediate, effective: effective}
+		d.slots[key] = slots
+	}
+	slots.count++
+	return true
+}
+
+// Release is part of the TxLimiter interface.
+func (d *Dynamic) Release(immediate *querypb.VTGateCallerID, effective *vtrpcpb.CallerID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.limiter.Release(immediate, effective)
+	key := callerKey(immediate, effective)
+	slots, ok := d.slots[key]
+	if !ok {
+		return
+	}
+	if slots.count--; slots.count == 0 {
+		delete(d.slots, key)
 	}
 }
 
+// callerKey identifies a caller by all the fields a limiter can use.
+func callerKey(immediate *querypb.VTGateCallerID, effective *vtrpcpb.CallerID) string {
+	return strings.Join([]string{
+		callerid.GetUsername(immediate),
+		callerid.GetPrincipal(effective),
+		callerid.GetComponent(effective),
+		callerid.GetSubcomponent(effective),
+	}, "\x00")
+}
+
 // TxAllowAll is a TxLimiter that allows all Get requests and does no tracking.
 // Implements Txlimiter.
 type TxAllowAll struct{}
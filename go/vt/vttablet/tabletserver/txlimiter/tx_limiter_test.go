@@ -163,3 +163,54 @@ func TestTxLimiterDryRun(t *testing.T) {
 		t.Errorf("RejectionsDryRun count for %s: got %d, want %d", key, got, want)
 	}
 }
+
+func TestTxLimiterDynamic(t *testing.T) {
+	config := tabletenv.NewDefaultConfig()
+	config.TxPool.Size = 10
+	config.TransactionLimitPerUser = 0.1
+	config.EnableTransactionLimit = false
+	config.TransactionLimitByUsername = true
+	limiter := NewDynamic(tabletenv.NewEnv(config, "TabletServerTest"))
+	im, ef := createCallers("user", "", "", "")
+	for i := 0; i < 2; i++ {
+		if got, want := limiter.Get(im, ef), true; got != want {
+			t.Errorf("Transaction number %d, Get(im, ef): got %v, want %v", i, got, want)
+		}
+	}
+
+	// The transactions started before the change count against the new
+	// limit of 2 transactions.
+	config = config.Clone()
+	config.TxPool.Size = 20
+	config.EnableTransactionLimit = true
+	limiter.SetConfig(config)
+	if got, want := limiter.Get(im, ef), false; got != want {
+		t.Errorf("Get(im, ef) after enabling the limit: got %v, want %v", got, want)
+	}
+	limiter.Release(im, ef)
+	if got, want := limiter.Get(im, ef), true; got != want {
+		t.Errorf("Get(im, ef) after releasing: got %v, want %v", got, want)
+	}
+	if got, want := limiter.Get(im, ef), false; got != want {
+		t.Errorf("Get(im, ef) after using up all allowed attempts: got %v, want %v", got, want)
+	}
+
+	// They are still counted when the limit applies to other fields.
+	config = config.Clone()
+	config.TransactionLimitByUsername = false
+	config.TransactionLimitByPrincipal = true
+	limiter.SetConfig(config)
+	if got, want := limiter.Get(im, ef), false; got != want {
+		t.Errorf("Get(im, ef) after limiting by principal: got %v, want %v", got, want)
+	}
+	limiter.Release(im, ef)
+	limiter.Release(im, ef)
+	for i := 0; i < 2; i++ {
+		if got, want := limiter.Get(im, ef), true; got != want {
+			t.Errorf("Transaction number %d after releasing, Get(im, ef): got %v, want %v", i, got, want)
+		}
+	}
+	if got, want := limiter.Get(im, ef), false; got != want {
+		t.Errorf("Get(im, ef) after using up all allowed attempts again: got %v, want %v", got, want)
+	}
+}
@@ -56,7 +56,7 @@ type TxSerializer struct {
 	env tabletenv.Env
 	*sync2.ConsolidatorCache
 
-	// Immutable fields.
+	// The settings are guarded by mu and can be changed with SetConfig.
 	dryRun                 bool
 	maxQueueSize           int
 	maxGlobalQueueSize     int
@@ -133,6 +133,19 @@ func New(env tabletenv.Env) *TxSerializer {
 
 }
 
+// SetConfig changes the hot row protection settings. The queues for the row
+// ranges which are already in flight keep their mode and concurrency, and
+// the new ones use the new settings.
+func (txs *TxSerializer) SetConfig(config tabletenv.HotRowProtectionConfig) {
+	txs.mu.Lock()
+	defer txs.mu.Unlock()
+
+	txs.dryRun = config.Mode == tabletenv.Dryrun
+	txs.maxQueueSize = config.MaxQueueSize
+	txs.maxGlobalQueueSize = config.MaxGlobalQueueSize
+	txs.concurrentTransactions = config.MaxConcurrency
+}
+
 // DoneFunc is returned by Wait() and must be called by the caller.
 type DoneFunc func()
 
@@ -165,13 +178,13 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 	q, ok := txs.queues[key]
 	if !ok {
 		// First transaction in the queue i.e. we don't wait and return immediately.
-		txs.queues[key] = newQueueForFirstTransaction(txs.concurrentTransactions)
+		txs.queues[key] = newQueueForFirstTransaction(txs.concurrentTransactions, txs.dryRun)
 		txs.globalSize++
 		return false, nil
 	}
 
 	if txs.globalSize >= txs.maxGlobalQueueSize {
-		if txs.dryRun {
+		if q.dryRun {
 			txs.globalQueueExceededDryRun.Add(1)
 			txs.logGlobalQueueExceededDryRun.Warningf("Would have rejected BeginExecute RPC because there are too many queued transactions (%d >= %d)", txs.globalSize, txs.maxGlobalQueueSize)
 		} else {
@@ -182,7 +195,7 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 	}
 
 	if q.size >= txs.maxQueueSize {
-		if txs.dryRun {
+		if q.dryRun {
 			txs.queueExceededDryRun.Add(table, 1)
 			txs.logQueueExceededDryRun.Warningf("Would have rejected BeginExecute RPC because there are too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, txs.maxQueueSize, key)
 		} else {
@@ -197,7 +210,7 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 		// first time.
 
 		// As an optimization, we deferred the creation of the channel until now.
-		q.availableSlots = make(chan struct{}, txs.concurrentTransactions)
+		q.availableSlots = make(chan struct{}, q.concurrentTransactions)
 		q.availableSlots <- struct{}{}
 
 		// Include first transaction in the count at /debug/hotrows. (It was not
@@ -214,7 +227,7 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 	// Publish the number of waits at /debug/hotrows.
 	txs.Record(key)
 
-	if txs.dryRun {
+	if q.dryRun {
 		txs.waitsDryRun.Add(table, 1)
 		txs.logWaitsDryRun.Warningf("Would have queued BeginExecute RPC for row (range): '%v' because another transaction to the same range is already in progress.", key)
 		return false, nil
@@ -260,7 +273,7 @@ func (txs *TxSerializer) unlockLocked(key string, returnSlot bool) {
 		delete(txs.queues, key)
 
 		if q.max > 1 {
-			if txs.dryRun {
+			if q.dryRun {
 				txs.logDryRun.Infof("%v simultaneous transactions (%v in total) for the same row range (%v) would have been queued.", q.max, q.count, key)
 			} else {
 				txs.log.Infof("%v simultaneous transactions (%v in total) for the same row range (%v) were queued.", q.max, q.count, key)
@@ -277,7 +290,7 @@ func (txs *TxSerializer) unlockLocked(key string, returnSlot bool) {
 	// Give up slot by removing ourselves from the channel.
 	// Wakes up the next queued transaction.
 
-	if txs.dryRun {
+	if q.dryRun {
 		// Dry-run did not acquire a slot in the first place.
 		return
 	}
@@ -362,12 +375,19 @@ type queue struct {
 	// NOTE: As an optimization, we defer the creation of the channel until
 	// a second transaction for the same hot row is running.
 	availableSlots chan struct{}
+
+	// concurrentTransactions and dryRun are the settings of the TxSerializer
+	// when the queue was created. They don't change while the queue is in use.
+	concurrentTransactions int
+	dryRun                 bool
 }
 
-func newQueueForFirstTransaction(concurrentTransactions int) *queue {
+func newQueueForFirstTransaction(concurrentTransactions int, dryRun bool) *queue {
 	return &queue{
-		size:  1,
-		count: 1,
-		max:   1,
+		size:                   1,
+		count:                  1,
+		max:                    1,
+		concurrentTransactions: concurrentTransactions,
+		dryRun:                 dryRun,
 	}
 }
@@ -510,6 +510,54 @@ func TestTxSerializerGlobalQueueOverflow(t *testing.T) {
 	done2()
 }
 
+func TestTxSerializerSetConfig(t *testing.T) {
+	config := tabletenv.NewDefaultConfig()
+	config.HotRowProtection.MaxQueueSize = 1
+	config.HotRowProtection.MaxGlobalQueueSize = 10
+	config.HotRowProtection.MaxConcurrency = 1
+	txs := New(tabletenv.NewEnv(config, "TxSerializerTest"))
+
+	done1, _, err := txs.Wait(context.Background(), "t1 where1", "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := txs.Wait(context.Background(), "t1 where1", "t1"); err == nil {
+		t.Fatal("transaction over the queue size must be rejected")
+	}
+
+	txs.SetConfig(tabletenv.HotRowProtectionConfig{
+		Mode:               tabletenv.Enable,
+		MaxQueueSize:       2,
+		MaxGlobalQueueSize: 10,
+		MaxConcurrency:     2,
+	})
+
+	// The new queue size applies to the row range in flight, but its
+	// concurrency does not change.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, waited, err := txs.Wait(ctx, "t1 where1", "t1")
+	if !waited || err != context.DeadlineExceeded {
+		t.Errorf("transaction must wait for the slot of the row range in flight: waited = %v, err = %v", waited, err)
+	}
+
+	// A new row range uses the new concurrency.
+	done2, _, err := txs.Wait(context.Background(), "t1 where2", "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done3, waited, err := txs.Wait(context.Background(), "t1 where2", "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if waited {
+		t.Error("second transaction must not wait with a concurrency of 2")
+	}
+	done1()
+	done2()
+	done3()
+}
+
 func TestTxSerializerPending(t *testing.T) {
 	config := tabletenv.NewDefaultConfig()
 	config.HotRowProtection.MaxQueueSize = 1
@@ -63,10 +63,14 @@ import (
 // allowed to execute it concurrently.
 type TxThrottler struct {
 	// config stores the transaction throttler's configuration.
-	// It is populated in NewTxThrottler and is not modified
-	// since.
+	// It is populated in NewTxThrottler. Only its throttlerConfig
+	// is changed since, by UpdateConfiguration.
 	config *txThrottlerConfig
 
+	// mu guards state and config.throttlerConfig against the concurrent
+	// calls to UpdateConfiguration.
+	mu sync.Mutex
+
 	// state holds an open transaction throttler state. It is nil
 	// if the TransactionThrottler is closed.
 	state *txThrottlerState
@@ -222,6 +226,8 @@ func (t *TxThrottler) Open() error {
 	if !t.config.enabled {
 		return nil
 	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	if t.state != nil {
 		return nil
 	}
@@ -238,6 +244,8 @@ func (t *TxThrottler) Close() {
 	if !t.config.enabled {
 		return
 	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	if t.state == nil {
 		return
 	}
@@ -246,6 +254,34 @@ func (t *TxThrottler) Close() {
 	log.Info("TxThrottler: closed")
 }
 
+// UpdateConfiguration replaces the configuration of the throttler with
+// configText, a text formatted throttlerdata.Configuration protocol buffer
+// message. If the throttler is open, the new configuration applies right
+// away. The configuration of a disabled throttler is only verified.
+func (t *TxThrottler) UpdateConfiguration(configText string) error {
+	var throttlerConfig throttlerdatapb.Configuration
+	if err := proto.UnmarshalText(configText, &throttlerConfig); err != nil {
+		return err
+	}
+	if err := (throttler.MaxReplicationLagModuleConfig{Configuration: throttlerConfig}).Verify(); err != nil {
+		return err
+	}
+	if !t.config.enabled {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state != nil {
+		if err := t.state.throttler.UpdateConfiguration(&throttlerConfig, true /* copyZeroValues */); err != nil {
+			return err
+		}
+	}
+	t.config.throttlerConfig = &throttlerConfig
+	log.Infof("TxThrottler: updated config: %v", configText)
+	return nil
+}
+
 // Throttle should be called before a new transaction is started.
 // It returns true if the transaction should not proceed (the caller
 // should back off). Throttle requires that Open() was previously called
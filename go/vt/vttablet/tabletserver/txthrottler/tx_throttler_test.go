@@ -107,11 +107,13 @@ func TestEnabledThrottler(t *testing.T) {
 	call2 := mockThrottler.EXPECT().RecordReplicationLag(gomock.Any(), tabletStats)
 	call3 := mockThrottler.EXPECT().Throttle(0)
 	call3.Return(1 * time.Second)
-	call4 := mockThrottler.EXPECT().Close()
+	call4 := mockThrottler.EXPECT().UpdateConfiguration(gomock.Any(), true /* copyZeroValues */)
+	call5 := mockThrottler.EXPECT().Close()
 	call1.After(call0)
 	call2.After(call1)
 	call3.After(call2)
 	call4.After(call3)
+	call5.After(call4)
 
 	config := tabletenv.NewDefaultConfig()
 	config.EnableTxThrottler = true
@@ -143,5 +145,24 @@ func TestEnabledThrottler(t *testing.T) {
 	if result := throttler.Throttle(); result != true {
 		t.Errorf("want: true, got: %v", result)
 	}
+	// The new configuration is passed to the open throttler.
+	if err := throttler.UpdateConfiguration(config.TxThrottlerConfig); err != nil {
+		t.Errorf("want: nil, got: %v", err)
+	}
 	throttler.Close()
 }
+
+func TestUpdateConfiguration(t *testing.T) {
+	config := tabletenv.NewDefaultConfig()
+	config.EnableTxThrottler = false
+	throttler := NewTxThrottler(config, nil)
+	if err := throttler.UpdateConfiguration(config.TxThrottlerConfig); err != nil {
+		t.Errorf("want: nil, got: %v", err)
+	}
+	if err := throttler.UpdateConfiguration("max_replication_lag_sec: 1 target_replication_lag_sec: 2"); err == nil {
+		t.Error("want: error for an invalid configuration, got: nil")
+	}
+	if err := throttler.UpdateConfiguration("no_such_field: 1"); err == nil {
+		t.Error("want: error for a configuration which cannot be parsed, got: nil")
+	}
+}
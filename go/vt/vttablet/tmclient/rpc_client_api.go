@@ -163,6 +163,17 @@ type TabletManagerClient interface {
 	VReplicationExec(ctx context.Context, tablet *topodatapb.Tablet, query string) (*querypb.QueryResult, error)
 	VReplicationWaitForPos(ctx context.Context, tablet *topodatapb.Tablet, id int, pos string) error
 
+	//
+	// Distributed transaction (2PC) related functions
+	//
+
+	// GetDistributedTransactions returns the 2pc metadata and the
+	// prepared transactions stored on the tablet.
+	GetDistributedTransactions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error)
+
+	// ResolveDistributedTransaction applies an action to a dtid on the tablet.
+	ResolveDistributedTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error
+
 	//
 	// Reparenting related functions
 	//
@@ -1012,6 +1012,66 @@ func tmRPCTestVReplicationWaitForPosPanic(ctx context.Context, t *testing.T, cli
 	expectHandleRPCPanic(t, "VReplicationWaitForPos", true /*verbose*/, err)
 }
 
+//
+// Distributed transaction (2PC) related functions
+//
+
+var testGetDistributedTransactionsReply = &tabletmanagerdatapb.GetDistributedTransactionsResponse{
+	Transactions: []*querypb.TransactionMetadata{{
+		Dtid:        "ks:-80:1234",
+		State:       querypb.TransactionState_COMMIT,
+		TimeCreated: 1,
+		Participants: []*querypb.Target{{
+			Keyspace:   "ks",
+			Shard:      "80-",
+			TabletType: topodatapb.TabletType_MASTER,
+		}},
+	}},
+	PreparedDtids: []string{"ks:80-:5678"},
+	FailedDtids:   []string{"ks:80-:9012"},
+}
+
+func (fra *fakeRPCTM) GetDistributedTransactions(ctx context.Context) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error) {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	return testGetDistributedTransactionsReply, nil
+}
+
+func tmRPCTestGetDistributedTransactions(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	result, err := client.GetDistributedTransactions(ctx, tablet)
+	compareError(t, "GetDistributedTransactions", err, result, testGetDistributedTransactionsReply)
+}
+
+func tmRPCTestGetDistributedTransactionsPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.GetDistributedTransactions(ctx, tablet)
+	expectHandleRPCPanic(t, "GetDistributedTransactions", false /*verbose*/, err)
+}
+
+var (
+	testResolveDistributedTransactionDtid   = "ks:-80:1234"
+	testResolveDistributedTransactionAction = tabletmanagerdatapb.DistributedTransactionAction_COMMIT_PREPARED
+)
+
+func (fra *fakeRPCTM) ResolveDistributedTransaction(ctx context.Context, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	compare(fra.t, "ResolveDistributedTransaction dtid", dtid, testResolveDistributedTransactionDtid)
+	compare(fra.t, "ResolveDistributedTransaction action", action, testResolveDistributedTransactionAction)
+	return nil
+}
+
+func tmRPCTestResolveDistributedTransaction(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	err := client.ResolveDistributedTransaction(ctx, tablet, testResolveDistributedTransactionDtid, testResolveDistributedTransactionAction)
+	compareError(t, "ResolveDistributedTransaction", err, true, true)
+}
+
+func tmRPCTestResolveDistributedTransactionPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	err := client.ResolveDistributedTransaction(ctx, tablet, testResolveDistributedTransactionDtid, testResolveDistributedTransactionAction)
+	expectHandleRPCPanic(t, "ResolveDistributedTransaction", true /*verbose*/, err)
+}
+
 //
 // Reparenting related functions
 //
@@ -1463,6 +1523,10 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestVReplicationExec(ctx, t, client, tablet)
 	tmRPCTestVReplicationWaitForPos(ctx, t, client, tablet)
 
+	// Distributed transaction methods
+	tmRPCTestGetDistributedTransactions(ctx, t, client, tablet)
+	tmRPCTestResolveDistributedTransaction(ctx, t, client, tablet)
+
 	// Reparenting related functions
 	tmRPCTestResetReplication(ctx, t, client, tablet)
 	tmRPCTestInitMaster(ctx, t, client, tablet)
@@ -1524,6 +1588,10 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestVReplicationExecPanic(ctx, t, client, tablet)
 	tmRPCTestVReplicationWaitForPosPanic(ctx, t, client, tablet)
 
+	// Distributed transaction methods
+	tmRPCTestGetDistributedTransactionsPanic(ctx, t, client, tablet)
+	tmRPCTestResolveDistributedTransactionPanic(ctx, t, client, tablet)
+
 	// Reparenting related functions
 	tmRPCTestResetReplicationPanic(ctx, t, client, tablet)
 	tmRPCTestInitMasterPanic(ctx, t, client, tablet)
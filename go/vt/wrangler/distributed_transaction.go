@@ -0,0 +1,275 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/dtids"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The states a distributed transaction can be in on a participant.
+const (
+	// ParticipantPrepared means the participant has prepared the
+	// transaction and is waiting for a decision.
+	ParticipantPrepared = "PREPARED"
+	// ParticipantFailed means the participant could not redo the
+	// prepared transaction after a restart.
+	ParticipantFailed = "FAILED"
+	// ParticipantResolved means the participant has no record of the
+	// transaction: it was either committed, rolled back or never prepared.
+	ParticipantResolved = "RESOLVED"
+)
+
+// The actions accepted by ResolveDistributedTransaction.
+const (
+	ResolveConclude = "conclude"
+	ResolveCommit   = "commit"
+	ResolveRollback = "rollback"
+)
+
+// DistributedTransaction is a 2pc transaction as recorded by its metadata
+// manager, along with its state on every participant.
+type DistributedTransaction struct {
+	Dtid         string
+	State        string
+	Created      time.Time
+	Participants []*TransactionParticipant
+}
+
+// TransactionParticipant is the state of a distributed transaction on
+// one participant shard.
+type TransactionParticipant struct {
+	Keyspace string
+	Shard    string
+	State    string
+}
+
+// distributedTransactionReader reads the 2pc state of shard masters,
+// fetching each shard at most once.
+type distributedTransactionReader struct {
+	wr     *Wrangler
+	shards map[string]*tabletmanagerdatapb.GetDistributedTransactionsResponse
+}
+
+func (wr *Wrangler) newDistributedTransactionReader() *distributedTransactionReader {
+	return &distributedTransactionReader{
+		wr:     wr,
+		shards: make(map[string]*tabletmanagerdatapb.GetDistributedTransactionsResponse),
+	}
+}
+
+func (dr *distributedTransactionReader) read(ctx context.Context, keyspace, shard string) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error) {
+	key := topoproto.KeyspaceShardString(keyspace, shard)
+	if resp, ok := dr.shards[key]; ok {
+		return resp, nil
+	}
+	master, err := dr.wr.shardMaster(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := dr.wr.tmc.GetDistributedTransactions(ctx, master.Tablet)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "GetDistributedTransactions(%v) failed", topoproto.TabletAliasString(master.Alias))
+	}
+	dr.shards[key] = resp
+	return resp, nil
+}
+
+// describe builds the DistributedTransaction for the metadata, reading
+// the state of the dtid from each participant.
+func (dr *distributedTransactionReader) describe(ctx context.Context, metadata *querypb.TransactionMetadata) (*DistributedTransaction, error) {
+	dt := &DistributedTransaction{
+		Dtid:    metadata.Dtid,
+		State:   metadata.State.String(),
+		Created: time.Unix(0, metadata.TimeCreated),
+	}
+	for _, target := range metadata.Participants {
+		resp, err := dr.read(ctx, target.Keyspace, target.Shard)
+		if err != nil {
+			return nil, err
+		}
+		participant := &TransactionParticipant{
+			Keyspace: target.Keyspace,
+			Shard:    target.Shard,
+			State:    ParticipantResolved,
+		}
+		switch {
+		case containsString(resp.PreparedDtids, metadata.Dtid):
+			participant.State = ParticipantPrepared
+		case containsString(resp.FailedDtids, metadata.Dtid):
+			participant.State = ParticipantFailed
+		}
+		dt.Participants = append(dt.Participants, participant)
+	}
+	return dt, nil
+}
+
+// ListDistributedTransactions returns the unresolved distributed transactions
+// whose metadata manager is a shard of the keyspace.
+func (wr *Wrangler) ListDistributedTransactions(ctx context.Context, keyspace string) ([]*DistributedTransaction, error) {
+	shards, err := wr.ts.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	dr := wr.newDistributedTransactionReader()
+	var result []*DistributedTransaction
+	for _, shard := range shards {
+		resp, err := dr.read(ctx, keyspace, shard)
+		if err != nil {
+			return nil, err
+		}
+		for _, metadata := range resp.Transactions {
+			dt, err := dr.describe(ctx, metadata)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, dt)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Dtid < result[j].Dtid
+	})
+	return result, nil
+}
+
+// GetDistributedTransaction returns the distributed transaction identified
+// by dtid, as recorded by its metadata manager.
+func (wr *Wrangler) GetDistributedTransaction(ctx context.Context, dtid string) (*DistributedTransaction, error) {
+	return wr.newDistributedTransactionReader().get(ctx, dtid)
+}
+
+func (dr *distributedTransactionReader) get(ctx context.Context, dtid string) (*DistributedTransaction, error) {
+	mmShard, err := dtids.ShardSession(dtid)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := dr.read(ctx, mmShard.Target.Keyspace, mmShard.Target.Shard)
+	if err != nil {
+		return nil, err
+	}
+	for _, metadata := range resp.Transactions {
+		if metadata.Dtid == dtid {
+			return dr.describe(ctx, metadata)
+		}
+	}
+	return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "dtid %s not found on its metadata manager %s", dtid, topoproto.KeyspaceShardString(mmShard.Target.Keyspace, mmShard.Target.Shard))
+}
+
+// ResolveDistributedTransaction forces the resolution of a distributed
+// transaction. The action is only applied if the state of every participant
+// agrees with it. Conclude deletes the metadata once no participant holds the
+// dtid anymore. Commit requires the commit decision to have been recorded,
+// and rollback refuses to proceed if it was. Both apply the decision to the
+// prepared participants and then conclude the transaction.
+func (wr *Wrangler) ResolveDistributedTransaction(ctx context.Context, dtid, action string) error {
+	dr := wr.newDistributedTransactionReader()
+	dt, err := dr.get(ctx, dtid)
+	if err != nil {
+		return err
+	}
+	mmShard, err := dtids.ShardSession(dtid)
+	if err != nil {
+		return err
+	}
+
+	var participantAction tabletmanagerdatapb.DistributedTransactionAction
+	switch action {
+	case ResolveConclude:
+		for _, participant := range dt.Participants {
+			if participant.State != ParticipantResolved {
+				return fmt.Errorf("cannot conclude %s: participant %s is %s", dtid, topoproto.KeyspaceShardString(participant.Keyspace, participant.Shard), participant.State)
+			}
+		}
+		return wr.resolveOnShard(ctx, mmShard.Target.Keyspace, mmShard.Target.Shard, dtid, tabletmanagerdatapb.DistributedTransactionAction_CONCLUDE)
+	case ResolveCommit:
+		if dt.State != querypb.TransactionState_COMMIT.String() {
+			return fmt.Errorf("cannot commit %s: the commit decision was not recorded, state is %s", dtid, dt.State)
+		}
+		for _, participant := range dt.Participants {
+			if participant.State == ParticipantFailed {
+				return fmt.Errorf("cannot commit %s: participant %s is %s", dtid, topoproto.KeyspaceShardString(participant.Keyspace, participant.Shard), participant.State)
+			}
+		}
+		participantAction = tabletmanagerdatapb.DistributedTransactionAction_COMMIT_PREPARED
+	case ResolveRollback:
+		switch dt.State {
+		case querypb.TransactionState_COMMIT.String():
+			return fmt.Errorf("cannot rollback %s: the commit decision was already recorded", dtid)
+		case querypb.TransactionState_PREPARE.String():
+			if err := wr.resolveOnShard(ctx, mmShard.Target.Keyspace, mmShard.Target.Shard, dtid, tabletmanagerdatapb.DistributedTransactionAction_SET_ROLLBACK); err != nil {
+				return err
+			}
+		}
+		participantAction = tabletmanagerdatapb.DistributedTransactionAction_ROLLBACK_PREPARED
+	default:
+		return fmt.Errorf("unknown action %v, must be one of %s, %s or %s", action, ResolveConclude, ResolveCommit, ResolveRollback)
+	}
+
+	for _, participant := range dt.Participants {
+		if participant.State == ParticipantResolved {
+			continue
+		}
+		wr.Logger().Infof("Applying %v to %s on %s", participantAction, dtid, topoproto.KeyspaceShardString(participant.Keyspace, participant.Shard))
+		if err := wr.resolveOnShard(ctx, participant.Keyspace, participant.Shard, dtid, participantAction); err != nil {
+			return err
+		}
+	}
+	return wr.resolveOnShard(ctx, mmShard.Target.Keyspace, mmShard.Target.Shard, dtid, tabletmanagerdatapb.DistributedTransactionAction_CONCLUDE)
+}
+
+func (wr *Wrangler) resolveOnShard(ctx context.Context, keyspace, shard, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error {
+	master, err := wr.shardMaster(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	if err := wr.tmc.ResolveDistributedTransaction(ctx, master.Tablet, dtid, action); err != nil {
+		return vterrors.Wrapf(err, "%v of %s on %v failed", action, dtid, topoproto.TabletAliasString(master.Alias))
+	}
+	return nil
+}
+
+func (wr *Wrangler) shardMaster(ctx context.Context, keyspace, shard string) (*topo.TabletInfo, error) {
+	si, err := wr.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	if !si.HasMaster() {
+		return nil, fmt.Errorf("shard %s has no master", topoproto.KeyspaceShardString(keyspace, shard))
+	}
+	return wr.ts.GetTablet(ctx, si.MasterAlias)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
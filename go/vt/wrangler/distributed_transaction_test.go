@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+type testDTTMClient struct {
+	tmclient.TabletManagerClient
+	responses map[string]*tabletmanagerdatapb.GetDistributedTransactionsResponse
+	actions   []string
+}
+
+func (tmc *testDTTMClient) GetDistributedTransactions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.GetDistributedTransactionsResponse, error) {
+	return tmc.responses[tablet.Shard], nil
+}
+
+func (tmc *testDTTMClient) ResolveDistributedTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string, action tabletmanagerdatapb.DistributedTransactionAction) error {
+	tmc.actions = append(tmc.actions, fmt.Sprintf("%s:%v:%s", tablet.Shard, action, dtid))
+	return nil
+}
+
+func newTestDTWrangler(t *testing.T, tmc *testDTTMClient) *Wrangler {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell")
+	wr := New(logutil.NewConsoleLogger(), ts, tmc)
+	for i, shard := range []string{"-80", "80-"} {
+		tablet := &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "cell", Uid: uint32(100 + i)},
+			Keyspace: "ks",
+			Shard:    shard,
+			Type:     topodatapb.TabletType_MASTER,
+		}
+		require.NoError(t, wr.InitTablet(ctx, tablet, false /* allowMasterOverride */, true /* createShardAndKeyspace */, false /* allowUpdate */))
+		_, err := ts.UpdateShardFields(ctx, "ks", shard, func(si *topo.ShardInfo) error {
+			si.MasterAlias = tablet.Alias
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	return wr
+}
+
+func dtMetadata(dtid string, state querypb.TransactionState) *querypb.TransactionMetadata {
+	return &querypb.TransactionMetadata{
+		Dtid:  dtid,
+		State: state,
+		Participants: []*querypb.Target{{
+			Keyspace:   "ks",
+			Shard:      "80-",
+			TabletType: topodatapb.TabletType_MASTER,
+		}},
+	}
+}
+
+func TestListDistributedTransactions(t *testing.T) {
+	tmc := &testDTTMClient{
+		responses: map[string]*tabletmanagerdatapb.GetDistributedTransactionsResponse{
+			"-80": {
+				Transactions: []*querypb.TransactionMetadata{
+					dtMetadata("ks:-80:2", querypb.TransactionState_COMMIT),
+					dtMetadata("ks:-80:1", querypb.TransactionState_PREPARE),
+				},
+			},
+			"80-": {
+				PreparedDtids: []string{"ks:-80:1"},
+			},
+		},
+	}
+	wr := newTestDTWrangler(t, tmc)
+
+	got, err := wr.ListDistributedTransactions(context.Background(), "ks")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "ks:-80:1", got[0].Dtid)
+	assert.Equal(t, "PREPARE", got[0].State)
+	assert.Equal(t, []*TransactionParticipant{{Keyspace: "ks", Shard: "80-", State: ParticipantPrepared}}, got[0].Participants)
+	assert.Equal(t, "ks:-80:2", got[1].Dtid)
+	assert.Equal(t, []*TransactionParticipant{{Keyspace: "ks", Shard: "80-", State: ParticipantResolved}}, got[1].Participants)
+
+	_, err = wr.GetDistributedTransaction(context.Background(), "ks:-80:3")
+	assert.EqualError(t, err, "dtid ks:-80:3 not found on its metadata manager ks/-80")
+}
+
+func TestResolveDistributedTransaction(t *testing.T) {
+	testcases := []struct {
+		name     string
+		state    querypb.TransactionState
+		prepared []string
+		failed   []string
+		action   string
+		actions  []string
+		err      string
+	}{{
+		name:    "conclude resolved",
+		state:   querypb.TransactionState_COMMIT,
+		action:  ResolveConclude,
+		actions: []string{"-80:CONCLUDE:ks:-80:1"},
+	}, {
+		name:     "conclude prepared",
+		state:    querypb.TransactionState_COMMIT,
+		prepared: []string{"ks:-80:1"},
+		action:   ResolveConclude,
+		err:      "cannot conclude ks:-80:1: participant ks/80- is PREPARED",
+	}, {
+		name:     "commit",
+		state:    querypb.TransactionState_COMMIT,
+		prepared: []string{"ks:-80:1"},
+		action:   ResolveCommit,
+		actions:  []string{"80-:COMMIT_PREPARED:ks:-80:1", "-80:CONCLUDE:ks:-80:1"},
+	}, {
+		name:     "commit undecided",
+		state:    querypb.TransactionState_PREPARE,
+		prepared: []string{"ks:-80:1"},
+		action:   ResolveCommit,
+		err:      "cannot commit ks:-80:1: the commit decision was not recorded, state is PREPARE",
+	}, {
+		name:   "commit failed",
+		state:  querypb.TransactionState_COMMIT,
+		failed: []string{"ks:-80:1"},
+		action: ResolveCommit,
+		err:    "cannot commit ks:-80:1: participant ks/80- is FAILED",
+	}, {
+		name:     "rollback undecided",
+		state:    querypb.TransactionState_PREPARE,
+		prepared: []string{"ks:-80:1"},
+		action:   ResolveRollback,
+		actions:  []string{"-80:SET_ROLLBACK:ks:-80:1", "80-:ROLLBACK_PREPARED:ks:-80:1", "-80:CONCLUDE:ks:-80:1"},
+	}, {
+		name:    "rollback failed",
+		state:   querypb.TransactionState_ROLLBACK,
+		failed:  []string{"ks:-80:1"},
+		action:  ResolveRollback,
+		actions: []string{"80-:ROLLBACK_PREPARED:ks:-80:1", "-80:CONCLUDE:ks:-80:1"},
+	}, {
+		name:     "rollback committed",
+		state:    querypb.TransactionState_COMMIT,
+		prepared: []string{"ks:-80:1"},
+		action:   ResolveRollback,
+		err:      "cannot rollback ks:-80:1: the commit decision was already recorded",
+	}, {
+		name:   "unknown action",
+		state:  querypb.TransactionState_COMMIT,
+		action: "discard",
+		err:    "unknown action discard, must be one of conclude, commit or rollback",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmc := &testDTTMClient{
+				responses: map[string]*tabletmanagerdatapb.GetDistributedTransactionsResponse{
+					"-80": {
+						Transactions: []*querypb.TransactionMetadata{dtMetadata("ks:-80:1", tc.state)},
+					},
+					"80-": {
+						PreparedDtids: tc.prepared,
+						FailedDtids:   tc.failed,
+					},
+				},
+			}
+			wr := newTestDTWrangler(t, tmc)
+
+			err := wr.ResolveDistributedTransaction(context.Background(), "ks:-80:1", tc.action)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Empty(t, tmc.actions)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.actions, tmc.actions)
+		})
+	}
+}
@@ -23,6 +23,10 @@ This file handles the reparenting operations.
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,12 +36,17 @@ import (
 	"vitess.io/vitess/go/event"
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/vt/audit"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/callinfo"
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/topotools/events"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 	"vitess.io/vitess/go/vt/vterrors"
 
 	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
@@ -49,9 +58,77 @@ const (
 	initShardMasterOperation            = "InitShardMaster"
 	plannedReparentShardOperation       = "PlannedReparentShard"
 	emergencyReparentShardOperation     = "EmergencyReparentShard"
-	tabletExternallyReparentedOperation = "TabletExternallyReparented" //nolint
+	tabletExternallyReparentedOperation = "TabletExternallyReparented"
 )
 
+// recordReparent records a reparent of the shard, or a failed attempt, in
+// the audit log and in the reparent history of the shard. A reparent which
+// had nothing to do isn't added to the history.
+func (wr *Wrangler) recordReparent(ctx context.Context, operation, keyspace, shard, reason string, start time.Time, ev *events.Reparent, err error) {
+	aev := &audit.Event{
+		Class:     audit.ClassReparent,
+		Operation: operation,
+		Keyspace:  keyspace,
+		Shard:     shard,
+	}
+	if ev.OldMaster.Alias != nil {
+		aev.OldMaster = topoproto.TabletAliasString(ev.OldMaster.Alias)
+	}
+	if ev.NewMaster.Alias != nil {
+		aev.NewMaster = topoproto.TabletAliasString(ev.NewMaster.Alias)
+	}
+	if err != nil {
+		aev.Error = err.Error()
+	}
+	audit.Record(ctx, aev)
+
+	if err == nil && ev.NewMaster.Alias == nil {
+		return
+	}
+	record := &topodatapb.ReparentRecord{
+		Operation:         operation,
+		Time:              logutil.TimeToProto(start),
+		DurationNs:        int64(time.Since(start)),
+		OldMaster:         ev.OldMaster.Alias,
+		NewMaster:         ev.NewMaster.Alias,
+		OldMasterPosition: ev.OldMasterPosition,
+		NewMasterPosition: ev.NewMasterPosition,
+		Reason:            reason,
+		Caller:            reparentCaller(ctx),
+	}
+	if record.OldMaster == nil && ev.ShardInfo.Shard != nil && !topoproto.TabletAliasEqual(ev.ShardInfo.MasterAlias, record.NewMaster) {
+		record.OldMaster = ev.ShardInfo.MasterAlias
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	// The reparent may have used up ctx.
+	historyCtx, cancel := context.WithTimeout(context.Background(), *topo.RemoteOperationTimeout)
+	defer cancel()
+	if err := wr.ts.AddReparentRecord(historyCtx, keyspace, shard, record); err != nil {
+		wr.logger.Warningf("cannot add the reparent to the history of %v/%v: %v", keyspace, shard, err)
+	}
+}
+
+// reparentCaller returns who asked for a reparent, as precisely as ctx
+// tells, or the process which runs it.
+func reparentCaller(ctx context.Context) string {
+	if ef := callerid.EffectiveCallerIDFromContext(ctx); ef != nil && ef.Principal != "" {
+		return ef.Principal
+	}
+	if im := callerid.ImmediateCallerIDFromContext(ctx); im != nil && im.Username != "" {
+		return im.Username
+	}
+	if identity := callinfo.TLSIdentity(ctx); identity != "" {
+		return identity
+	}
+	if ci, ok := callinfo.FromContext(ctx); ok {
+		return ci.Text()
+	}
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%v@%v", filepath.Base(os.Args[0]), hostname)
+}
+
 // ShardReplicationStatuses returns the ReplicationStatus for each tablet in a shard.
 func (wr *Wrangler) ShardReplicationStatuses(ctx context.Context, keyspace, shard string) ([]*topo.TabletInfo, []*replicationdatapb.Status, error) {
 	tabletMap, err := wr.ts.GetTabletMapForShard(ctx, keyspace, shard)
@@ -147,6 +224,7 @@ func (wr *Wrangler) InitShardMaster(ctx context.Context, keyspace, shard string,
 
 	// Create reusable Reparent event with available info
 	ev := &events.Reparent{}
+	start := time.Now()
 
 	// do the work
 	err = wr.initShardMasterLocked(ctx, ev, keyspace, shard, masterElectTabletAlias, force, waitReplicasTimeout)
@@ -155,6 +233,11 @@ func (wr *Wrangler) InitShardMaster(ctx context.Context, keyspace, shard string,
 	} else {
 		event.DispatchUpdate(ev, "finished InitShardMaster")
 	}
+	reason := fmt.Sprintf("initialize %v as the master", topoproto.TabletAliasString(masterElectTabletAlias))
+	if force {
+		reason += ", forced"
+	}
+	wr.recordReparent(ctx, initShardMasterOperation, keyspace, shard, reason, start, ev, err)
 	return err
 }
 
@@ -247,6 +330,7 @@ func (wr *Wrangler) initShardMasterLocked(ctx context.Context, ev *events.Repare
 	if err != nil {
 		return err
 	}
+	ev.NewMasterPosition = rp
 
 	// Check we stil have the topology lock.
 	if err := topo.CheckShardLocked(ctx, keyspace, shard); err != nil {
@@ -352,6 +436,7 @@ func (wr *Wrangler) PlannedReparentShard(ctx context.Context, keyspace, shard st
 
 	// Create reusable Reparent event with available info
 	ev := &events.Reparent{}
+	start := time.Now()
 
 	// Attempt to set avoidMasterAlias if not provided by parameters
 	if masterElectTabletAlias == nil && avoidMasterAlias == nil {
@@ -369,51 +454,100 @@ func (wr *Wrangler) PlannedReparentShard(ctx context.Context, keyspace, shard st
 	} else {
 		event.DispatchUpdate(ev, "finished PlannedReparentShard")
 	}
+	reason := fmt.Sprintf("reparent away from %v", topoproto.TabletAliasString(avoidMasterAlias))
+	if masterElectTabletAlias != nil {
+		reason = fmt.Sprintf("reparent to %v", topoproto.TabletAliasString(masterElectTabletAlias))
+	}
+	wr.recordReparent(ctx, plannedReparentShardOperation, keyspace, shard, reason, start, ev, err)
 	return err
 }
 
-func (wr *Wrangler) plannedReparentShardLocked(ctx context.Context, ev *events.Reparent, keyspace, shard string, masterElectTabletAlias, avoidMasterTabletAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration) error {
+// PlannedReparentShardDryRun returns the steps PlannedReparentShard would
+// take, without taking any of them, nor locking the shard.
+func (wr *Wrangler) PlannedReparentShardDryRun(ctx context.Context, keyspace, shard string, masterElectTabletAlias, avoidMasterAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration) ([]string, error) {
 	shardInfo, err := wr.ts.GetShard(ctx, keyspace, shard)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if masterElectTabletAlias == nil && avoidMasterAlias == nil {
+		avoidMasterAlias = shardInfo.MasterAlias
 	}
-	ev.ShardInfo = *shardInfo
-
-	event.DispatchUpdate(ev, "reading tablet map")
 	tabletMap, err := wr.ts.GetTabletMapForShard(ctx, keyspace, shard)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Check invariants we're going to depend on.
-	if topoproto.TabletAliasEqual(masterElectTabletAlias, avoidMasterTabletAlias) {
-		return fmt.Errorf("master-elect tablet %v is the same as the tablet to avoid", topoproto.TabletAliasString(masterElectTabletAlias))
+	masterElectTabletInfo, err := wr.plannedReparentCandidate(ctx, nil, shardInfo, tabletMap, masterElectTabletAlias, avoidMasterAlias, waitReplicasTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if masterElectTabletInfo == nil {
+		return []string{fmt.Sprintf("current master %v is not %v, nothing to do", topoproto.TabletAliasString(shardInfo.MasterAlias), topoproto.TabletAliasString(avoidMasterAlias))}, nil
 	}
+	masterElectTabletAliasStr := masterElectTabletInfo.AliasString()
+
+	var plan []string
 	if masterElectTabletAlias == nil {
-		if !topoproto.TabletAliasEqual(avoidMasterTabletAlias, shardInfo.MasterAlias) {
-			event.DispatchUpdate(ev, "current master is different than -avoid_master, nothing to do")
-			return nil
-		}
-		event.DispatchUpdate(ev, "searching for master candidate")
-		masterElectTabletAlias, err = wr.chooseNewMaster(ctx, shardInfo, tabletMap, avoidMasterTabletAlias, waitReplicasTimeout)
+		plan = append(plan, fmt.Sprintf("choose %v as the new master", masterElectTabletAliasStr))
+	}
+	currentMaster := wr.findCurrentMaster(tabletMap)
+	switch {
+	case currentMaster == nil:
+		plan = append(plan,
+			fmt.Sprintf("no clear current master: demote all %v tablets, and check no tablet is ahead of %v", len(tabletMap), masterElectTabletAliasStr),
+			fmt.Sprintf("promote %v to master", masterElectTabletAliasStr))
+	case topoproto.TabletAliasEqual(currentMaster.Alias, masterElectTabletInfo.Alias):
+		plan = append(plan, fmt.Sprintf("%v is already the master: make sure it is read-write", masterElectTabletAliasStr))
+	default:
+		snapshotPos, err := wr.tmc.MasterPosition(ctx, currentMaster.Tablet)
 		if err != nil {
-			return err
+			return nil, vterrors.Wrapf(err, "can't get replication position on current master %v; current master must be healthy to perform planned reparent", currentMaster.AliasString())
 		}
-		if masterElectTabletAlias == nil {
-			return fmt.Errorf("cannot find a tablet to reparent to")
+		plan = append(plan,
+			fmt.Sprintf("wait up to %v for %v to catch up with current master %v at position %v", waitReplicasTimeout, masterElectTabletAliasStr, currentMaster.AliasString(), snapshotPos),
+			fmt.Sprintf("demote current master %v", currentMaster.AliasString()),
+			fmt.Sprintf("wait up to %v for %v to catch up with the demoted master, or undo the demotion", waitReplicasTimeout, masterElectTabletAliasStr),
+			fmt.Sprintf("promote %v to master", masterElectTabletAliasStr))
+	}
+	return append(plan, reparentReplicasPlan(tabletMap, masterElectTabletAliasStr, nil)...), nil
+}
+
+// reparentReplicasPlan returns the last steps of a reparent, which are the
+// same for all reparents.
+func reparentReplicasPlan(tabletMap map[string]*topo.TabletInfo, newMasterTabletAliasStr string, ignoredTablets sets.String) []string {
+	var replicas []string
+	for alias := range tabletMap {
+		if alias != newMasterTabletAliasStr && !ignoredTablets.Has(alias) {
+			replicas = append(replicas, alias)
 		}
-		wr.logger.Infof("elected new master candidate %v", topoproto.TabletAliasString(masterElectTabletAlias))
-		event.DispatchUpdate(ev, "elected new master candidate")
 	}
-	masterElectTabletAliasStr := topoproto.TabletAliasString(masterElectTabletAlias)
-	masterElectTabletInfo, ok := tabletMap[masterElectTabletAliasStr]
-	if !ok {
-		return fmt.Errorf("master-elect tablet %v is not in the shard", masterElectTabletAliasStr)
+	sort.Strings(replicas)
+	plan := []string{fmt.Sprintf("populate the reparent journal on %v", newMasterTabletAliasStr)}
+	if len(replicas) > 0 {
+		plan = append(plan, fmt.Sprintf("point %v at %v", strings.Join(replicas, ", "), newMasterTabletAliasStr))
 	}
-	ev.NewMaster = *masterElectTabletInfo.Tablet
-	if topoproto.TabletAliasIsZero(shardInfo.MasterAlias) {
-		return fmt.Errorf("the shard has no master, use EmergencyReparentShard")
+	return plan
+}
+
+func (wr *Wrangler) plannedReparentShardLocked(ctx context.Context, ev *events.Reparent, keyspace, shard string, masterElectTabletAlias, avoidMasterTabletAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration) error {
+	shardInfo, err := wr.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
 	}
+	ev.ShardInfo = *shardInfo
+
+	event.DispatchUpdate(ev, "reading tablet map")
+	tabletMap, err := wr.ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+
+	masterElectTabletInfo, err := wr.plannedReparentCandidate(ctx, ev, shardInfo, tabletMap, masterElectTabletAlias, avoidMasterTabletAlias, waitReplicasTimeout)
+	if err != nil || masterElectTabletInfo == nil {
+		return err
+	}
+	masterElectTabletAlias = masterElectTabletInfo.Alias
+	masterElectTabletAliasStr := masterElectTabletInfo.AliasString()
 
 	// Find the current master (if any) based on the tablet states. We no longer
 	// trust the shard record for this, because it is updated asynchronously.
@@ -603,6 +737,7 @@ func (wr *Wrangler) plannedReparentShardLocked(ctx context.Context, ev *events.R
 		if err != nil {
 			return fmt.Errorf("old master tablet %v DemoteMaster failed: %v", topoproto.TabletAliasString(shardInfo.MasterAlias), err)
 		}
+		ev.OldMasterPosition = masterStatus.Position
 
 		waitCtx, waitCancel := context.WithTimeout(ctx, waitReplicasTimeout)
 		defer waitCancel()
@@ -640,6 +775,7 @@ func (wr *Wrangler) plannedReparentShardLocked(ctx context.Context, ev *events.R
 		}
 		reparentJournalPos = rp
 	}
+	ev.NewMasterPosition = reparentJournalPos
 
 	// Check we still have the topology lock.
 	if err := topo.CheckShardLocked(ctx, keyspace, shard); err != nil {
@@ -711,6 +847,55 @@ func (wr *Wrangler) plannedReparentShardLocked(ctx context.Context, ev *events.R
 	return nil
 }
 
+// plannedReparentCandidate checks the invariants a planned reparent depends
+// on, and returns the master-elect, which is chosen if it isn't provided. It
+// returns nil if the current master is already not the tablet to avoid. ev
+// is nil in a dry run.
+func (wr *Wrangler) plannedReparentCandidate(ctx context.Context, ev *events.Reparent, shardInfo *topo.ShardInfo, tabletMap map[string]*topo.TabletInfo, masterElectTabletAlias, avoidMasterTabletAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration) (*topo.TabletInfo, error) {
+	dispatchUpdate := func(update string) {
+		if ev != nil {
+			event.DispatchUpdate(ev, update)
+		}
+	}
+
+	// Check invariants we're going to depend on.
+	if topoproto.TabletAliasEqual(masterElectTabletAlias, avoidMasterTabletAlias) {
+		return nil, fmt.Errorf("master-elect tablet %v is the same as the tablet to avoid", topoproto.TabletAliasString(masterElectTabletAlias))
+	}
+	if masterElectTabletAlias == nil {
+		if !topoproto.TabletAliasEqual(avoidMasterTabletAlias, shardInfo.MasterAlias) {
+			dispatchUpdate("current master is different than -avoid_master, nothing to do")
+			return nil, nil
+		}
+		dispatchUpdate("searching for master candidate")
+		var err error
+		masterElectTabletAlias, err = wr.chooseNewMaster(ctx, shardInfo, tabletMap, avoidMasterTabletAlias, waitReplicasTimeout)
+		if err != nil {
+			return nil, err
+		}
+		if masterElectTabletAlias == nil {
+			return nil, fmt.Errorf("cannot find a tablet to reparent to")
+		}
+		wr.logger.Infof("elected new master candidate %v", topoproto.TabletAliasString(masterElectTabletAlias))
+		dispatchUpdate("elected new master candidate")
+	}
+	masterElectTabletAliasStr := topoproto.TabletAliasString(masterElectTabletAlias)
+	masterElectTabletInfo, ok := tabletMap[masterElectTabletAliasStr]
+	if !ok {
+		return nil, fmt.Errorf("master-elect tablet %v is not in the shard", masterElectTabletAliasStr)
+	}
+	if ev != nil {
+		ev.NewMaster = *masterElectTabletInfo.Tablet
+	}
+	if topoproto.TabletAliasIsZero(shardInfo.MasterAlias) {
+		return nil, fmt.Errorf("the shard has no master, use EmergencyReparentShard")
+	}
+	if err := checkPromotionRule(masterElectTabletInfo.Tablet); err != nil {
+		return nil, err
+	}
+	return masterElectTabletInfo, nil
+}
+
 // findCurrentMaster returns the current master of a shard, if any.
 //
 // The tabletMap must be a complete map (not a partial result) for the shard.
@@ -765,9 +950,11 @@ type maxReplPosSearch struct {
 	wrangler            *Wrangler
 	ctx                 context.Context
 	waitReplicasTimeout time.Duration
+	durability          reparentutil.Durabler
 	waitGroup           sync.WaitGroup
 	maxPosLock          sync.Mutex
 	maxPos              mysql.Position
+	maxPosRule          reparentutil.CandidatePromotionRule
 	maxPosTablet        *topodatapb.Tablet
 }
 
@@ -789,9 +976,12 @@ func (maxPosSearch *maxReplPosSearch) processTablet(tablet *topodatapb.Tablet) {
 		return
 	}
 
+	rule := maxPosSearch.durability.PromotionRule(tablet)
 	maxPosSearch.maxPosLock.Lock()
-	if maxPosSearch.maxPosTablet == nil || !maxPosSearch.maxPos.AtLeast(replPos) {
+	if maxPosSearch.maxPosTablet == nil || rule.BetterThan(maxPosSearch.maxPosRule) ||
+		(rule == maxPosSearch.maxPosRule && !maxPosSearch.maxPos.AtLeast(replPos)) {
 		maxPosSearch.maxPos = replPos
+		maxPosSearch.maxPosRule = rule
 		maxPosSearch.maxPosTablet = tablet
 	}
 	maxPosSearch.maxPosLock.Unlock()
@@ -799,7 +989,9 @@ func (maxPosSearch *maxReplPosSearch) processTablet(tablet *topodatapb.Tablet) {
 
 // chooseNewMaster finds a tablet that is going to become master after reparent. The criteria
 // for the new master-elect are (preferably) to be in the same cell as the current master, and
-// to be different from avoidMasterTabletAlias. The tablet with the largest replication
+// to be different from avoidMasterTabletAlias. The tablets with the best promotion rule of the
+// durability policy are preferred, and the ones the policy doesn't allow are never chosen. Among
+// them, the tablet with the largest replication
 // position is chosen to minimize the time of catching up with the master. Note that the search
 // for largest replication position will race with transactions being executed on the master at
 // the same time, so when all tablets are roughly at the same position then the choice of the
@@ -814,6 +1006,10 @@ func (wr *Wrangler) chooseNewMaster(
 	if avoidMasterTabletAlias == nil {
 		return nil, fmt.Errorf("tablet to avoid for reparent is not provided, cannot choose new master")
 	}
+	_, durability, err := reparentutil.CurrentDurabilityPolicy()
+	if err != nil {
+		return nil, err
+	}
 	var masterCell string
 	if shardInfo.MasterAlias != nil {
 		masterCell = shardInfo.MasterAlias.Cell
@@ -823,13 +1019,15 @@ func (wr *Wrangler) chooseNewMaster(
 		wrangler:            wr,
 		ctx:                 ctx,
 		waitReplicasTimeout: waitReplicasTimeout,
+		durability:          durability,
 		waitGroup:           sync.WaitGroup{},
 		maxPosLock:          sync.Mutex{},
 	}
 	for _, tabletInfo := range tabletMap {
 		if (masterCell != "" && tabletInfo.Alias.Cell != masterCell) ||
 			topoproto.TabletAliasEqual(tabletInfo.Alias, avoidMasterTabletAlias) ||
-			tabletInfo.Tablet.Type != topodatapb.TabletType_REPLICA {
+			tabletInfo.Tablet.Type != topodatapb.TabletType_REPLICA ||
+			durability.PromotionRule(tabletInfo.Tablet) == reparentutil.MustNotPromoteRule {
 			continue
 		}
 		maxPosSearch.waitGroup.Add(1)
@@ -859,6 +1057,7 @@ func (wr *Wrangler) EmergencyReparentShard(ctx context.Context, keyspace, shard
 
 	// Create reusable Reparent event with available info
 	ev := &events.Reparent{}
+	start := time.Now()
 
 	// do the work
 	err = wr.emergencyReparentShardLocked(ctx, ev, keyspace, shard, masterElectTabletAlias, waitReplicasTimeout, ignoredTablets)
@@ -867,9 +1066,104 @@ func (wr *Wrangler) EmergencyReparentShard(ctx context.Context, keyspace, shard
 	} else {
 		event.DispatchUpdate(ev, "finished EmergencyReparentShard")
 	}
+	reason := "emergency reparent to the most advanced tablet"
+	if masterElectTabletAlias != nil {
+		reason = fmt.Sprintf("emergency reparent to %v", topoproto.TabletAliasString(masterElectTabletAlias))
+	}
+	if ignoredTablets.Len() > 0 {
+		reason += fmt.Sprintf(", ignoring %v", strings.Join(ignoredTablets.List(), ", "))
+	}
+	wr.recordReparent(ctx, emergencyReparentShardOperation, keyspace, shard, reason, start, ev, err)
 	return err
 }
 
+// EmergencyReparentShardDryRun returns the steps EmergencyReparentShard
+// would take, without taking any of them, nor locking the shard. The
+// replication of the tablets isn't stopped, so the positions the choice of
+// the new master is based on may still change.
+func (wr *Wrangler) EmergencyReparentShardDryRun(ctx context.Context, keyspace, shard string, masterElectTabletAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration, ignoredTablets sets.String) ([]string, error) {
+	tabletMap, err := wr.ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to get tablet map for shard %v in keyspace %v: %v", shard, keyspace, err)
+	}
+	statusMap, masterStatusMap, err := wr.buildStatusMaps(ctx, tabletMap, waitReplicasTimeout, ignoredTablets)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to build status maps: %v", err)
+	}
+	validCandidates, err := wr.findValidReparentCandidates(statusMap, masterStatusMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(validCandidates) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no valid candidates for emergency reparent")
+	}
+	newMasterTabletAliasStr, handoffTabletAliasStr, err := emergencyReparentCandidates(tabletMap, validCandidates, masterElectTabletAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	var stopped, demoted, candidates []string
+	for alias := range statusMap {
+		stopped = append(stopped, alias)
+	}
+	for alias := range masterStatusMap {
+		demoted = append(demoted, alias)
+	}
+	for alias := range validCandidates {
+		candidates = append(candidates, alias)
+	}
+	sort.Strings(stopped)
+	sort.Strings(demoted)
+	sort.Strings(candidates)
+
+	var plan []string
+	if len(stopped) > 0 {
+		plan = append(plan, fmt.Sprintf("stop replication on %v", strings.Join(stopped, ", ")))
+	}
+	if len(demoted) > 0 {
+		plan = append(plan, fmt.Sprintf("demote %v, which is a master", strings.Join(demoted, ", ")))
+	}
+	plan = append(plan,
+		fmt.Sprintf("wait up to %v for the valid candidates %v to apply their relay logs", waitReplicasTimeout, strings.Join(candidates, ", ")),
+		fmt.Sprintf("promote %v to master", newMasterTabletAliasStr))
+	plan = append(plan, reparentReplicasPlan(tabletMap, newMasterTabletAliasStr, ignoredTablets)...)
+	if handoffTabletAliasStr != "" {
+		plan = append(plan, fmt.Sprintf("hand off the mastership to %v, which the durability policy prefers, with a planned reparent", handoffTabletAliasStr))
+	}
+	return plan, nil
+}
+
+// emergencyReparentCandidates returns the candidate an emergency reparent
+// promotes, and the one it hands off the mastership to, if any. The
+// master-elect, if provided, must be one of the most advanced valid
+// candidates.
+func emergencyReparentCandidates(tabletMap map[string]*topo.TabletInfo, validCandidates map[string]mysql.Position, masterElectTabletAlias *topodatapb.TabletAlias) (string, string, error) {
+	_, durability, err := reparentutil.CurrentDurabilityPolicy()
+	if err != nil {
+		return "", "", err
+	}
+	newMasterTabletAliasStr, winningPosition, handoffTabletAliasStr, err := chooseEmergencyCandidates(durability, tabletMap, validCandidates)
+	if err != nil {
+		return "", "", err
+	}
+	if masterElectTabletAlias == nil {
+		return newMasterTabletAliasStr, handoffTabletAliasStr, nil
+	}
+
+	newMasterTabletAliasStr = topoproto.TabletAliasString(masterElectTabletAlias)
+	masterPos, ok := validCandidates[newMasterTabletAliasStr]
+	if !ok {
+		return "", "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "master elect %v has errant GTIDs", newMasterTabletAliasStr)
+	}
+	if !masterPos.AtLeast(winningPosition) {
+		return "", "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "master elect: %v at position %v, is not fully caught up. Winning position: %v", newMasterTabletAliasStr, masterPos, winningPosition)
+	}
+	if err := checkPromotionRule(tabletMap[newMasterTabletAliasStr].Tablet); err != nil {
+		return "", "", err
+	}
+	return newMasterTabletAliasStr, "", nil
+}
+
 func (wr *Wrangler) emergencyReparentShardLocked(ctx context.Context, ev *events.Reparent, keyspace, shard string, masterElectTabletAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration, ignoredTablets sets.String) error {
 	shardInfo, err := wr.ts.GetShard(ctx, keyspace, shard)
 	if err != nil {
@@ -887,6 +1181,12 @@ func (wr *Wrangler) emergencyReparentShardLocked(ctx context.Context, ev *events
 	if err != nil {
 		return vterrors.Wrapf(err, "failed to stop replication and build status maps: %v", err)
 	}
+	if oldMaster, ok := tabletMap[topoproto.TabletAliasString(shardInfo.MasterAlias)]; ok {
+		ev.OldMaster = *oldMaster.Tablet
+		if masterStatus, ok := masterStatusMap[oldMaster.AliasString()]; ok {
+			ev.OldMasterPosition = masterStatus.Position
+		}
+	}
 
 	// Check we still have the topology lock.
 	if err := topo.CheckShardLocked(ctx, keyspace, shard); err != nil {
@@ -928,29 +1228,9 @@ func (wr *Wrangler) emergencyReparentShardLocked(ctx context.Context, ev *events
 		return vterrors.Wrapf(rec.Error(), "could not apply all relay logs within the provided wait_replicas_timeout: %v", rec.Error())
 	}
 
-	var winningPosition mysql.Position
-	var newMasterTabletAliasStr string
-	for alias, position := range validCandidates {
-		if winningPosition.IsZero() {
-			winningPosition = position
-			newMasterTabletAliasStr = alias
-			continue
-		}
-		if position.AtLeast(winningPosition) {
-			winningPosition = position
-			newMasterTabletAliasStr = alias
-		}
-	}
-
-	if masterElectTabletAlias != nil {
-		newMasterTabletAliasStr = topoproto.TabletAliasString(masterElectTabletAlias)
-		masterPos, ok := validCandidates[newMasterTabletAliasStr]
-		if !ok {
-			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "master elect %v has errant GTIDs", newMasterTabletAliasStr)
-		}
-		if !masterPos.AtLeast(winningPosition) {
-			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "master elect: %v at position %v, is not fully caught up. Winning position: %v", newMasterTabletAliasStr, masterPos, winningPosition)
-		}
+	newMasterTabletAliasStr, handoffTabletAliasStr, err := emergencyReparentCandidates(tabletMap, validCandidates, masterElectTabletAlias)
+	if err != nil {
+		return err
 	}
 
 	// Check we still have the topology lock.
@@ -960,11 +1240,13 @@ func (wr *Wrangler) emergencyReparentShardLocked(ctx context.Context, ev *events
 
 	// Promote the masterElect
 	wr.logger.Infof("promote tablet %v to master", newMasterTabletAliasStr)
+	ev.NewMaster = *tabletMap[newMasterTabletAliasStr].Tablet
 	event.DispatchUpdate(ev, "promoting replica")
 	rp, err := wr.tmc.PromoteReplica(ctx, tabletMap[newMasterTabletAliasStr].Tablet)
 	if err != nil {
 		return vterrors.Wrapf(err, "master-elect tablet %v failed to be upgraded to master: %v", newMasterTabletAliasStr, err)
 	}
+	ev.NewMasterPosition = rp
 
 	// Check we still have the topology lock.
 	if err := topo.CheckShardLocked(ctx, keyspace, shard); err != nil {
@@ -1019,9 +1301,84 @@ func (wr *Wrangler) emergencyReparentShardLocked(ctx context.Context, ev *events
 		return vterrors.Wrapf(masterErr, "failed to PopulateReparentJournal on master: %v", masterErr)
 	}
 
+	if handoffTabletAliasStr == "" {
+		return nil
+	}
+
+	// The durability policy prefers a candidate which was behind the new
+	// master. Now that it replicates from the new master, hand off the
+	// mastership to it with a planned reparent.
+	wr.logger.Infof("handing off mastership from %v to %v, which the durability policy prefers", newMasterTabletAliasStr, handoffTabletAliasStr)
+	event.DispatchUpdate(ev, "handing off to the preferred candidate")
+	handoffEv := &events.Reparent{}
+	if err := wr.plannedReparentShardLocked(ctx, handoffEv, keyspace, shard, tabletMap[handoffTabletAliasStr].Alias, nil, waitReplicasTimeout); err != nil {
+		return vterrors.Wrapf(err, "tablet %v was promoted, but the hand off to %v failed: %v", newMasterTabletAliasStr, handoffTabletAliasStr, err)
+	}
+	ev.NewMaster = handoffEv.NewMaster
+	ev.NewMasterPosition = handoffEv.NewMasterPosition
 	return nil
 }
 
+// checkPromotionRule returns an error if the durability policy doesn't
+// allow the promotion of a tablet.
+func checkPromotionRule(tablet *topodatapb.Tablet) error {
+	_, durability, err := reparentutil.CurrentDurabilityPolicy()
+	if err != nil {
+		return err
+	}
+	if durability.PromotionRule(tablet) == reparentutil.MustNotPromoteRule {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the durability policy doesn't allow the promotion of tablet %v", topoproto.TabletAliasString(tablet.Alias))
+	}
+	return nil
+}
+
+// chooseEmergencyCandidates chooses the valid candidate which is promoted by
+// an emergency reparent. It is the most advanced one, so that the other
+// tablets can catch up with it, and the one with the best promotion rule if
+// several are. The winning position is returned with it. If the durability
+// policy prefers another candidate which is behind, or doesn't allow the
+// promotion of the most advanced ones, the preferred candidate is returned as
+// the one the mastership is handed off to. Otherwise, the returned handoff
+// candidate is empty.
+func chooseEmergencyCandidates(durability reparentutil.Durabler, tabletMap map[string]*topo.TabletInfo, validCandidates map[string]mysql.Position) (newMaster string, winningPosition mysql.Position, handoff string, err error) {
+	aliases := make([]string, 0, len(validCandidates))
+	for alias, position := range validCandidates {
+		aliases = append(aliases, alias)
+		if winningPosition.IsZero() || position.AtLeast(winningPosition) {
+			winningPosition = position
+		}
+	}
+	sort.Strings(aliases)
+
+	// better returns true if the candidate is better than the chosen one,
+	// by promotion rule, and then by position.
+	better := func(alias string, rule reparentutil.CandidatePromotionRule, chosen string, chosenRule reparentutil.CandidatePromotionRule) bool {
+		if chosen == "" || rule.BetterThan(chosenRule) {
+			return true
+		}
+		return rule == chosenRule && !validCandidates[chosen].AtLeast(validCandidates[alias])
+	}
+
+	var newMasterRule, preferredRule reparentutil.CandidatePromotionRule
+	var preferred string
+	for _, alias := range aliases {
+		rule := durability.PromotionRule(tabletMap[alias].Tablet)
+		if validCandidates[alias].AtLeast(winningPosition) && better(alias, rule, newMaster, newMasterRule) {
+			newMaster, newMasterRule = alias, rule
+		}
+		if rule != reparentutil.MustNotPromoteRule && better(alias, rule, preferred, preferredRule) {
+			preferred, preferredRule = alias, rule
+		}
+	}
+	if preferred == "" {
+		return "", mysql.Position{}, "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the durability policy doesn't allow the promotion of any valid candidate for emergency reparent")
+	}
+	if preferred != newMaster && (newMasterRule == reparentutil.MustNotPromoteRule || preferredRule.BetterThan(newMasterRule)) {
+		handoff = preferred
+	}
+	return newMaster, winningPosition, handoff, nil
+}
+
 // waitOnNMinusOneTablets will wait until N-1 tablets have responded via a supplied error channel. In that case that N-1 tablets have responded,
 // the supplied cancel function will be called, and we will wait until N tablets return their errors, and then return an AllErrorRecorder to the caller.
 func waitOnNMinusOneTablets(ctxCancel context.CancelFunc, tabletCount int, errorChannel chan error, acceptableErrCnt int) *concurrency.AllErrorRecorder {
@@ -1173,6 +1530,57 @@ func (wr *Wrangler) stopReplicationAndBuildStatusMaps(ctx context.Context, ev *e
 	return statusMap, masterStatusMap, nil
 }
 
+// buildStatusMaps is the read-only version of
+// stopReplicationAndBuildStatusMaps, for a dry run: it gets the replication
+// status of the replicas, and the master status of the masters, without
+// stopping or demoting any of them.
+func (wr *Wrangler) buildStatusMaps(ctx context.Context, tabletMap map[string]*topo.TabletInfo, waitReplicasTimeout time.Duration, ignoredTablets sets.String) (map[string]*replicationdatapb.StopReplicationStatus, map[string]*replicationdatapb.MasterStatus, error) {
+	statusMap := make(map[string]*replicationdatapb.StopReplicationStatus)
+	masterStatusMap := make(map[string]*replicationdatapb.MasterStatus)
+	mu := sync.Mutex{}
+
+	groupCtx, groupCancel := context.WithTimeout(ctx, waitReplicasTimeout)
+	defer groupCancel()
+	wg := sync.WaitGroup{}
+	rec := concurrency.AllErrorRecorder{}
+	for alias, tabletInfo := range tabletMap {
+		if ignoredTablets.Has(alias) {
+			continue
+		}
+		wg.Add(1)
+		go func(alias string, tabletInfo *topo.TabletInfo) {
+			defer wg.Done()
+			if tabletInfo.Type == topodatapb.TabletType_MASTER {
+				masterStatus, err := wr.tmc.MasterStatus(groupCtx, tabletInfo.Tablet)
+				if err != nil {
+					rec.RecordError(vterrors.Wrapf(err, "error when getting master status for alias %v: %v", alias, err))
+					return
+				}
+				mu.Lock()
+				masterStatusMap[alias] = masterStatus
+				mu.Unlock()
+				return
+			}
+			status, err := wr.tmc.ReplicationStatus(groupCtx, tabletInfo.Tablet)
+			if err != nil {
+				rec.RecordError(vterrors.Wrapf(err, "error when getting replication status for alias %v: %v", alias, err))
+				return
+			}
+			mu.Lock()
+			statusMap[alias] = &replicationdatapb.StopReplicationStatus{Before: status, After: status}
+			mu.Unlock()
+		}(alias, tabletInfo)
+	}
+	wg.Wait()
+
+	// Like an emergency reparent, tolerate one unreachable tablet, which
+	// is usually the failed master.
+	if len(rec.Errors) > 1 {
+		return nil, nil, vterrors.Wrapf(rec.Error(), "encountered more than one error when trying to get positions: %v", rec.Error())
+	}
+	return statusMap, masterStatusMap, nil
+}
+
 // WaitForRelayLogsToApply will block execution waiting for the given tablets relay logs to apply, unless the supplied
 // context is cancelled, or waitReplicasTimeout is exceeded.
 func (wr *Wrangler) WaitForRelayLogsToApply(ctx context.Context, tabletInfo *topo.TabletInfo, status *replicationdatapb.StopReplicationStatus) error {
@@ -1224,11 +1632,15 @@ func (wr *Wrangler) TabletExternallyReparented(ctx context.Context, newMasterAli
 		}()
 		event.DispatchUpdate(ev, "starting external reparent")
 
+		start := time.Now()
+		reason := fmt.Sprintf("external reparent to %v", topoproto.TabletAliasString(newMasterAlias))
 		if err := wr.tmc.ChangeType(ctx, tablet, topodatapb.TabletType_MASTER); err != nil {
 			log.Warningf("Error calling ChangeType on new master %v: %v", topoproto.TabletAliasString(newMasterAlias), err)
+			wr.recordReparent(ctx, tabletExternallyReparentedOperation, tablet.Keyspace, tablet.Shard, reason, start, ev, err)
 			return err
 		}
 		event.DispatchUpdate(ev, "finished")
+		wr.recordReparent(ctx, tabletExternallyReparentedOperation, tablet.Keyspace, tablet.Shard, reason, start, ev, nil)
 	}
 	return nil
 }
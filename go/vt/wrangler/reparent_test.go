@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestChooseEmergencyCandidates(t *testing.T) {
+	durability, err := reparentutil.GetDurabilityPolicy(reparentutil.DurabilitySemiSync)
+	require.NoError(t, err)
+
+	position := func(gtids string) mysql.Position {
+		pos, err := mysql.ParsePosition(mysql.Mysql56FlavorID, "3e11fa47-71ca-11e1-9e33-c80aa9429562:"+gtids)
+		require.NoError(t, err)
+		return pos
+	}
+	tabletInfo := func(uid uint32, tabletType topodatapb.TabletType, rule reparentutil.CandidatePromotionRule) *topo.TabletInfo {
+		tablet := &topodatapb.Tablet{
+			Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: uid},
+			Type:  tabletType,
+		}
+		if rule != "" {
+			tablet.Tags = map[string]string{reparentutil.PromotionRuleTag: string(rule)}
+		}
+		return &topo.TabletInfo{Tablet: tablet}
+	}
+
+	testcases := []struct {
+		name            string
+		tabletMap       map[string]*topo.TabletInfo
+		validCandidates map[string]mysql.Position
+		newMaster       string
+		handoff         string
+		err             string
+	}{{
+		name: "most advanced",
+		tabletMap: map[string]*topo.TabletInfo{
+			"zone1-0000000101": tabletInfo(101, topodatapb.TabletType_REPLICA, ""),
+			"zone1-0000000102": tabletInfo(102, topodatapb.TabletType_REPLICA, ""),
+		},
+		validCandidates: map[string]mysql.Position{
+			"zone1-0000000101": position("1-10"),
+			"zone1-0000000102": position("1-9"),
+		},
+		newMaster: "zone1-0000000101",
+	}, {
+		name: "preferred among the most advanced",
+		tabletMap: map[string]*topo.TabletInfo{
+			"zone1-0000000101": tabletInfo(101, topodatapb.TabletType_REPLICA, ""),
+			"zone1-0000000102": tabletInfo(102, topodatapb.TabletType_REPLICA, reparentutil.PreferPromoteRule),
+		},
+		validCandidates: map[string]mysql.Position{
+			"zone1-0000000101": position("1-10"),
+			"zone1-0000000102": position("1-10"),
+		},
+		newMaster: "zone1-0000000102",
+	}, {
+		name: "hand off to the preferred candidate",
+		tabletMap: map[string]*topo.TabletInfo{
+			"zone1-0000000101": tabletInfo(101, topodatapb.TabletType_REPLICA, ""),
+			"zone1-0000000102": tabletInfo(102, topodatapb.TabletType_REPLICA, reparentutil.PreferPromoteRule),
+			"zone1-0000000103": tabletInfo(103, topodatapb.TabletType_REPLICA, reparentutil.PreferPromoteRule),
+		},
+		validCandidates: map[string]mysql.Position{
+			"zone1-0000000101": position("1-10"),
+			"zone1-0000000102": position("1-8"),
+			"zone1-0000000103": position("1-9"),
+		},
+		newMaster: "zone1-0000000101",
+		handoff:   "zone1-0000000103",
+	}, {
+		name: "most advanced cannot be promoted",
+		tabletMap: map[string]*topo.TabletInfo{
+			"zone1-0000000101": tabletInfo(101, topodatapb.TabletType_RDONLY, ""),
+			"zone1-0000000102": tabletInfo(102, topodatapb.TabletType_REPLICA, ""),
+		},
+		validCandidates: map[string]mysql.Position{
+			"zone1-0000000101": position("1-10"),
+			"zone1-0000000102": position("1-9"),
+		},
+		newMaster: "zone1-0000000101",
+		handoff:   "zone1-0000000102",
+	}, {
+		name: "no candidate can be promoted",
+		tabletMap: map[string]*topo.TabletInfo{
+			"zone1-0000000101": tabletInfo(101, topodatapb.TabletType_RDONLY, ""),
+			"zone1-0000000102": tabletInfo(102, topodatapb.TabletType_REPLICA, reparentutil.MustNotPromoteRule),
+		},
+		validCandidates: map[string]mysql.Position{
+			"zone1-0000000101": position("1-10"),
+			"zone1-0000000102": position("1-10"),
+		},
+		err: "the durability policy doesn't allow the promotion of any valid candidate for emergency reparent",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			newMaster, winningPosition, handoff, err := chooseEmergencyCandidates(durability, tc.tabletMap, tc.validCandidates)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.newMaster, newMaster)
+			assert.True(t, winningPosition.Equal(tc.validCandidates[tc.newMaster]))
+			assert.Equal(t, tc.handoff, handoff)
+		})
+	}
+}
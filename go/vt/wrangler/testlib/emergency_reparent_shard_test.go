@@ -178,6 +178,69 @@ func TestEmergencyReparentShard(t *testing.T) {
 
 	assert.False(t, newMaster.FakeMysqlDaemon.ReadOnly, "newMaster.FakeMysqlDaemon.ReadOnly set")
 	checkSemiSyncEnabled(t, true, true, newMaster)
+
+	// the reparent is in the history of the shard
+	history, err := ts.GetReparentHistory(context.Background(), newMaster.Tablet.Keyspace, newMaster.Tablet.Shard)
+	require.NoError(t, err)
+	require.Len(t, history.Records, 1)
+	record := history.Records[0]
+	assert.Equal(t, "EmergencyReparentShard", record.Operation)
+	assert.True(t, topoproto.TabletAliasEqual(oldMaster.Tablet.Alias, record.OldMaster), "old master is %v", record.OldMaster)
+	assert.True(t, topoproto.TabletAliasEqual(newMaster.Tablet.Alias, record.NewMaster), "new master is %v", record.NewMaster)
+	assert.Equal(t, "MariaDB/2-123-456", record.NewMasterPosition)
+	assert.Equal(t, "emergency reparent to cell1-0000000001", record.Reason)
+	assert.Empty(t, record.Error)
+}
+
+func TestEmergencyReparentShardDryRun(t *testing.T) {
+	delay := discovery.GetTabletPickerRetryDelay()
+	defer func() {
+		discovery.SetTabletPickerRetryDelay(delay)
+	}()
+	discovery.SetTabletPickerRetryDelay(5 * time.Millisecond)
+
+	ts := memorytopo.NewServer("cell1", "cell2")
+	wr := wrangler.New(logutil.NewConsoleLogger(), ts, tmclient.NewTabletManagerClient())
+	vp := NewVtctlPipe(t, ts)
+	defer vp.Close()
+
+	// Create a dead master, and two replicas which don't expect any query.
+	oldMaster := NewFakeTablet(t, wr, "cell1", 0, topodatapb.TabletType_MASTER, nil)
+	newMaster := NewFakeTablet(t, wr, "cell1", 1, topodatapb.TabletType_REPLICA, nil)
+	goodReplica := NewFakeTablet(t, wr, "cell2", 2, topodatapb.TabletType_REPLICA, nil)
+
+	for i, replica := range []*FakeTablet{newMaster, goodReplica} {
+		replica.FakeMysqlDaemon.ReadOnly = true
+		replica.FakeMysqlDaemon.Replicating = true
+		replica.FakeMysqlDaemon.CurrentMasterPosition = mysql.Position{
+			GTIDSet: mysql.MariadbGTIDSet{
+				2: mysql.MariadbGTID{
+					Domain:   2,
+					Server:   123,
+					Sequence: uint64(456 - i),
+				},
+			},
+		}
+		replica.StartActionLoop(t, wr)
+		defer replica.StopActionLoop(t)
+	}
+
+	out, err := vp.RunAndOutput([]string{"EmergencyReparentShard", "-dry_run", "-wait_replicas_timeout", "2s", "-keyspace_shard", newMaster.Tablet.Keyspace + "/" + newMaster.Tablet.Shard})
+	require.NoError(t, err)
+	assert.Equal(t, `1. stop replication on cell1-0000000001, cell2-0000000002
+2. wait up to 2s for the valid candidates cell1-0000000001, cell2-0000000002 to apply their relay logs
+3. promote cell1-0000000001 to master
+4. populate the reparent journal on cell1-0000000001
+5. point cell1-0000000000, cell2-0000000002 at cell1-0000000001
+`, out)
+
+	// nothing changed
+	require.NoError(t, newMaster.FakeMysqlDaemon.CheckSuperQueryList())
+	require.NoError(t, goodReplica.FakeMysqlDaemon.CheckSuperQueryList())
+	assert.True(t, newMaster.FakeMysqlDaemon.Replicating, "newMaster.FakeMysqlDaemon.Replicating not set")
+	history, err := ts.GetReparentHistory(context.Background(), oldMaster.Tablet.Keyspace, oldMaster.Tablet.Shard)
+	require.NoError(t, err)
+	assert.Empty(t, history.Records)
 }
 
 // TestEmergencyReparentShardMasterElectNotBest tries to emergency reparent
@@ -145,6 +145,70 @@ func TestPlannedReparentShardNoMasterProvided(t *testing.T) {
 	assert.True(t, goodReplica1.FakeMysqlDaemon.Replicating, "goodReplica1.FakeMysqlDaemon.Replicating not set")
 	checkSemiSyncEnabled(t, true, true, newMaster)
 	checkSemiSyncEnabled(t, false, true, goodReplica1, oldMaster)
+
+	// the reparent is in the history of the shard
+	history, err := ts.GetReparentHistory(context.Background(), newMaster.Tablet.Keyspace, newMaster.Tablet.Shard)
+	require.NoError(t, err)
+	require.Len(t, history.Records, 1)
+	record := history.Records[0]
+	assert.Equal(t, "PlannedReparentShard", record.Operation)
+	assert.True(t, topoproto.TabletAliasEqual(oldMaster.Tablet.Alias, record.OldMaster), "old master is %v", record.OldMaster)
+	assert.True(t, topoproto.TabletAliasEqual(newMaster.Tablet.Alias, record.NewMaster), "new master is %v", record.NewMaster)
+	assert.Equal(t, "MariaDB/7-123-990", record.OldMasterPosition)
+	assert.Equal(t, "MariaDB/7-456-991", record.NewMasterPosition)
+	assert.Equal(t, "reparent away from cell1-0000000000", record.Reason)
+	assert.Empty(t, record.Error)
+}
+
+func TestPlannedReparentShardDryRun(t *testing.T) {
+	delay := discovery.GetTabletPickerRetryDelay()
+	defer func() {
+		discovery.SetTabletPickerRetryDelay(delay)
+	}()
+	discovery.SetTabletPickerRetryDelay(5 * time.Millisecond)
+
+	ts := memorytopo.NewServer("cell1", "cell2")
+	wr := wrangler.New(logutil.NewConsoleLogger(), ts, tmclient.NewTabletManagerClient())
+	vp := NewVtctlPipe(t, ts)
+	defer vp.Close()
+
+	// Create a master and a replica, which don't expect any query.
+	oldMaster := NewFakeTablet(t, wr, "cell1", 0, topodatapb.TabletType_MASTER, nil)
+	newMaster := NewFakeTablet(t, wr, "cell1", 1, topodatapb.TabletType_REPLICA, nil)
+	oldMaster.FakeMysqlDaemon.CurrentMasterPosition = mysql.Position{
+		GTIDSet: mysql.MariadbGTIDSet{
+			7: mysql.MariadbGTID{
+				Domain:   7,
+				Server:   123,
+				Sequence: 990,
+			},
+		},
+	}
+	oldMaster.StartActionLoop(t, wr)
+	defer oldMaster.StopActionLoop(t)
+	newMaster.FakeMysqlDaemon.Replicating = true
+	newMaster.StartActionLoop(t, wr)
+	defer newMaster.StopActionLoop(t)
+
+	out, err := vp.RunAndOutput([]string{"PlannedReparentShard", "-dry_run", "-wait_replicas_timeout", "10s", "-keyspace_shard", newMaster.Tablet.Keyspace + "/" + newMaster.Tablet.Shard, "-new_master", topoproto.TabletAliasString(newMaster.Tablet.Alias)})
+	require.NoError(t, err)
+	assert.Equal(t, `1. wait up to 10s for cell1-0000000001 to catch up with current master cell1-0000000000 at position MariaDB/7-123-990
+2. demote current master cell1-0000000000
+3. wait up to 10s for cell1-0000000001 to catch up with the demoted master, or undo the demotion
+4. promote cell1-0000000001 to master
+5. populate the reparent journal on cell1-0000000001
+6. point cell1-0000000000 at cell1-0000000001
+`, out)
+
+	// nothing changed
+	require.NoError(t, oldMaster.FakeMysqlDaemon.CheckSuperQueryList())
+	require.NoError(t, newMaster.FakeMysqlDaemon.CheckSuperQueryList())
+	si, err := ts.GetShard(context.Background(), newMaster.Tablet.Keyspace, newMaster.Tablet.Shard)
+	require.NoError(t, err)
+	assert.True(t, topoproto.TabletAliasEqual(oldMaster.Tablet.Alias, si.MasterAlias), "master is %v", si.MasterAlias)
+	history, err := ts.GetReparentHistory(context.Background(), newMaster.Tablet.Keyspace, newMaster.Tablet.Shard)
+	require.NoError(t, err)
+	assert.Empty(t, history.Records)
 }
 
 func TestPlannedReparentShardNoError(t *testing.T) {